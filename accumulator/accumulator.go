@@ -0,0 +1,336 @@
+// Package accumulator implements RSA accumulators (Benaloh and de Mare,
+// "One-Way Accumulators"; Li, Li and Xue, "Universal Accumulators with
+// Efficient Nonmembership Proofs"), an alternative to the pairing-based
+// membership package with constant-size commitments and witnesses, no
+// trusted SRS of powers, and no bound on the number of members.
+//
+// Elements are mapped to primes with HashToPrime, and a set with primes x_i is
+// accumulated as A = g^(prod_i x_i) mod N for an RSA modulus N of unknown
+// factorization. The membership witness of x is w = g^(prod_{x_i != x} x_i),
+// with w^x = A; that of several elements is the accumulator of the others.
+// The non-membership witness of a prime y is (a, B = g^b) with
+// a prod_i x_i + b y = 1, so that A^a B^y = g.
+//
+// Whoever knows the factorization of N can forge witnesses. Setup discards it,
+// so production moduli require a ceremony, or a modulus of unknown
+// factorization such as RSA-2048. Class groups, which avoid the trusted
+// setup altogether, aren't implemented.
+package accumulator
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrMember is returned for non-membership witnesses of members.
+	ErrMember = errors.New("element is a member")
+	// ErrNotMember is returned for membership witnesses and deletions of
+	// elements that aren't members.
+	ErrNotMember = errors.New("element is not a member")
+)
+
+var bigOne = big.NewInt(1)
+
+// Params are the public parameters of accumulators: an RSA modulus N and a
+// quadratic residue G generating the accumulated values.
+type Params struct {
+	N, G *big.Int
+}
+
+// Setup returns Params with a modulus of the specified number of bits, drawing
+// its primes and the generator from r. The primes are discarded before Setup
+// returns, but r MUST be a source of cryptographic randomness as its output
+// reveals them.
+func Setup(bits int, r io.Reader) (*Params, error) {
+	if bits < 64 {
+		return nil, fmt.Errorf("modulus of %d bits; want at least 64", bits)
+	}
+	for {
+		p, err := rand.Prime(r, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(r, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).Mul(p, q)
+		if p.Cmp(q) == 0 || n.BitLen() != bits {
+			continue
+		}
+		x, err := rand.Int(r, n)
+		if err != nil {
+			return nil, err
+		}
+		if new(big.Int).GCD(nil, nil, x, n).Cmp(bigOne) != 0 {
+			continue
+		}
+		return &Params{N: n, G: new(big.Int).Exp(x, big.NewInt(2), n)}, nil
+	}
+}
+
+// HashToPrime maps the element to a 256-bit prime, by hashing it with a
+// counter until the result, with its top and bottom bits set, is prime.
+func HashToPrime(element []byte) *big.Int {
+	var ctr [8]byte
+	for i := uint64(0); ; i++ {
+		h := sha256.New()
+		h.Write([]byte("zkp.xyz/accumulator"))
+		binary.BigEndian.PutUint64(ctr[:], i)
+		h.Write(ctr[:])
+		h.Write(element)
+		d := h.Sum(nil)
+		d[0] |= 0x80
+		d[len(d)-1] |= 1
+		x := new(big.Int).SetBytes(d)
+		if x.ProbablyPrime(20) {
+			return x
+		}
+	}
+}
+
+// product returns the product of the primes of the elements.
+func product(elements [][]byte) *big.Int {
+	p := big.NewInt(1)
+	for _, e := range elements {
+		p.Mul(p, HashToPrime(e))
+	}
+	return p
+}
+
+// exp returns x^e mod N for any sign of e.
+func (p *Params) exp(x, e *big.Int) (*big.Int, error) {
+	if e.Sign() >= 0 {
+		return new(big.Int).Exp(x, e, p.N), nil
+	}
+	inv := new(big.Int).ModInverse(x, p.N)
+	if inv == nil {
+		return nil, errors.New("not invertible modulo N")
+	}
+	return new(big.Int).Exp(inv, new(big.Int).Neg(e), p.N), nil
+}
+
+// An Accumulator is a set of elements, accumulated into its Value.
+type Accumulator struct {
+	params *Params
+	value  *big.Int
+	// members holds the primes of the elements, by element.
+	members map[string]*big.Int
+}
+
+// New returns an empty Accumulator, of Value G.
+func New(params *Params) *Accumulator {
+	return &Accumulator{params: params, value: new(big.Int).Set(params.G), members: make(map[string]*big.Int)}
+}
+
+// Value returns the accumulated value, which can be shared publicly.
+func (a *Accumulator) Value() *big.Int {
+	return new(big.Int).Set(a.value)
+}
+
+// Len returns the number of members.
+func (a *Accumulator) Len() int {
+	return len(a.members)
+}
+
+// Contains reports whether the element is a member.
+func (a *Accumulator) Contains(element []byte) bool {
+	_, ok := a.members[string(element)]
+	return ok
+}
+
+// An Update records a batch of additions or deletions, with the resulting
+// Value, from which holders of witnesses can update them without access to
+// the Accumulator; see Params.UpdateWitness.
+type Update struct {
+	Added, Deleted [][]byte
+	Value          *big.Int
+}
+
+// Add adds the elements, ignoring members and duplicates, and returns the
+// Update.
+func (a *Accumulator) Add(elements ...[]byte) *Update {
+	u := new(Update)
+	for _, e := range elements {
+		if a.Contains(e) {
+			continue
+		}
+		a.members[string(e)] = HashToPrime(e)
+		u.Added = append(u.Added, append([]byte{}, e...))
+	}
+	a.value.Exp(a.value, product(u.Added), a.params.N)
+	u.Value = a.Value()
+	return u
+}
+
+// Delete deletes the elements, which must be distinct members, and returns
+// the Update. Without the factorization of N, the Value is recomputed from the
+// remaining members.
+func (a *Accumulator) Delete(elements ...[]byte) (*Update, error) {
+	seen := make(map[string]bool)
+	for _, e := range elements {
+		if !a.Contains(e) || seen[string(e)] {
+			return nil, fmt.Errorf("deleting %x: %w", e, ErrNotMember)
+		}
+		seen[string(e)] = true
+	}
+	u := new(Update)
+	for _, e := range elements {
+		delete(a.members, string(e))
+		u.Deleted = append(u.Deleted, append([]byte{}, e...))
+	}
+	a.value = a.exclude(nil)
+	u.Value = a.Value()
+	return u, nil
+}
+
+// exclude returns G raised to the product of the primes of all members except
+// those of the excluded elements.
+func (a *Accumulator) exclude(excluded map[string]bool) *big.Int {
+	e := big.NewInt(1)
+	for m, x := range a.members {
+		if !excluded[m] {
+			e.Mul(e, x)
+		}
+	}
+	return new(big.Int).Exp(a.params.G, e, a.params.N)
+}
+
+// MembershipWitness returns the witness that all elements are members, i.e.
+// the Value of the Accumulator without them.
+func (a *Accumulator) MembershipWitness(elements ...[]byte) (*big.Int, error) {
+	excluded := make(map[string]bool)
+	for _, e := range elements {
+		if !a.Contains(e) {
+			return nil, fmt.Errorf("witness of %x: %w", e, ErrNotMember)
+		}
+		excluded[string(e)] = true
+	}
+	return a.exclude(excluded), nil
+}
+
+// MembershipWitnesses returns the witnesses of all members, by element, with
+// the RootFactor algorithm of Sander, Ta-Shma and Yung in O(n log n)
+// exponentiations rather than the O(n^2) of separate MembershipWitness calls.
+func (a *Accumulator) MembershipWitnesses() map[string]*big.Int {
+	elements := make([]string, 0, len(a.members))
+	for m := range a.members {
+		elements = append(elements, m)
+	}
+	ws := make(map[string]*big.Int, len(elements))
+	a.rootFactor(a.params.G, elements, ws)
+	return ws
+}
+
+// rootFactor sets the witnesses of the elements for g, the accumulator of all
+// other members.
+func (a *Accumulator) rootFactor(g *big.Int, elements []string, ws map[string]*big.Int) {
+	switch len(elements) {
+	case 0:
+		return
+	case 1:
+		ws[elements[0]] = g
+		return
+	}
+	left, right := elements[:len(elements)/2], elements[len(elements)/2:]
+	prod := func(es []string) *big.Int {
+		p := big.NewInt(1)
+		for _, e := range es {
+			p.Mul(p, a.members[e])
+		}
+		return p
+	}
+	a.rootFactor(new(big.Int).Exp(g, prod(right), a.params.N), left, ws)
+	a.rootFactor(new(big.Int).Exp(g, prod(left), a.params.N), right, ws)
+}
+
+// VerifyMembership reports whether the witness shows that all elements are
+// members of the accumulated value, i.e. whether w^(prod x_i) = value.
+func (p *Params) VerifyMembership(value, w *big.Int, elements ...[]byte) bool {
+	if w == nil || w.Sign() <= 0 || w.Cmp(p.N) >= 0 {
+		return false
+	}
+	return new(big.Int).Exp(w, product(elements), p.N).Cmp(value) == 0
+}
+
+// A NonMembershipWitness shows that an element isn't a member, with
+// value^A B^y = G for the prime y of the element.
+type NonMembershipWitness struct {
+	A, B *big.Int
+}
+
+// NonMembershipWitness returns the witness that the element isn't a member,
+// or ErrMember.
+func (a *Accumulator) NonMembershipWitness(element []byte) (*NonMembershipWitness, error) {
+	if a.Contains(element) {
+		return nil, ErrMember
+	}
+	u := big.NewInt(1)
+	for _, x := range a.members {
+		u.Mul(u, x)
+	}
+	y := HashToPrime(element)
+	// a u + b y = 1, with 0 <= a < y.
+	ca, cb := new(big.Int), new(big.Int)
+	if new(big.Int).GCD(ca, cb, u, y).Cmp(bigOne) != 0 {
+		// Distinct primes of the same size are coprime.
+		return nil, ErrMember
+	}
+	// With ca = q y + k, k u + (cb + q u) y = 1.
+	q, k := new(big.Int).DivMod(ca, y, new(big.Int))
+	cb.Add(cb, q.Mul(q, u))
+	b, err := a.params.exp(a.params.G, cb)
+	if err != nil {
+		return nil, err
+	}
+	return &NonMembershipWitness{A: k, B: b}, nil
+}
+
+// VerifyNonMembership reports whether the witness shows that the element
+// isn't a member of the accumulated value.
+func (p *Params) VerifyNonMembership(value *big.Int, element []byte, w *NonMembershipWitness) bool {
+	if w == nil || w.A == nil || w.B == nil || w.A.Sign() < 0 || w.B.Sign() <= 0 || w.B.Cmp(p.N) >= 0 {
+		return false
+	}
+	y := HashToPrime(element)
+	if w.A.Cmp(y) >= 0 {
+		return false
+	}
+	lhs := new(big.Int).Exp(value, w.A, p.N)
+	lhs.Mul(lhs, new(big.Int).Exp(w.B, y, p.N))
+	return lhs.Mod(lhs, p.N).Cmp(p.G) == 0
+}
+
+// UpdateWitness returns the membership witness of the elements after the
+// Update, for their witness w before it. Additions raise w to the product of
+// the added primes; deletions of primes with product d and the new value A'
+// use a x + b d = 1 for the product x of the primes of the elements, with
+// the new witness w^b A'^a. An error wrapping ErrNotMember is returned if any
+// of the elements was deleted.
+func (p *Params) UpdateWitness(w *big.Int, u *Update, elements ...[]byte) (*big.Int, error) {
+	w = new(big.Int).Exp(w, product(u.Added), p.N)
+	if len(u.Deleted) == 0 {
+		return w, nil
+	}
+	x, d := product(elements), product(u.Deleted)
+	a, b := new(big.Int), new(big.Int)
+	if new(big.Int).GCD(a, b, x, d).Cmp(bigOne) != 0 {
+		return nil, fmt.Errorf("updating witness: %w", ErrNotMember)
+	}
+	wb, err := p.exp(w, b)
+	if err != nil {
+		return nil, err
+	}
+	va, err := p.exp(u.Value, a)
+	if err != nil {
+		return nil, err
+	}
+	wb.Mul(wb, va)
+	return wb.Mod(wb, p.N), nil
+}
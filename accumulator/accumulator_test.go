@@ -0,0 +1,176 @@
+package accumulator
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+var params = func() *Params {
+	p, err := Setup(1024, rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}()
+
+func elements(names ...string) [][]byte {
+	es := make([][]byte, len(names))
+	for i, n := range names {
+		es[i] = []byte(n)
+	}
+	return es
+}
+
+func TestMembership(t *testing.T) {
+	acc := New(params)
+	acc.Add(elements("a", "b", "c", "d", "a")...)
+	if got, want := acc.Len(), 4; got != want {
+		t.Errorf("Len() = %d; want %d", got, want)
+	}
+	value := acc.Value()
+
+	tests := []struct {
+		name     string
+		elements [][]byte
+	}{
+		{"single", elements("a")},
+		{"batch", elements("b", "d")},
+		{"all", elements("a", "b", "c", "d")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := acc.MembershipWitness(tt.elements...)
+			if err != nil {
+				t.Fatalf("MembershipWitness() error %v", err)
+			}
+			if !params.VerifyMembership(value, w, tt.elements...) {
+				t.Error("VerifyMembership() = false; want true")
+			}
+			if params.VerifyMembership(value, w, append(tt.elements, []byte("e"))...) {
+				t.Error("VerifyMembership() with a non-member = true; want false")
+			}
+		})
+	}
+
+	ws := acc.MembershipWitnesses()
+	for _, e := range elements("a", "b", "c", "d") {
+		if !params.VerifyMembership(value, ws[string(e)], e) {
+			t.Errorf("VerifyMembership(%s, MembershipWitnesses()) = false; want true", e)
+		}
+	}
+	if params.VerifyMembership(value, ws["a"], []byte("b")) {
+		t.Error("VerifyMembership() with the witness of another member = true; want false")
+	}
+	if _, err := acc.MembershipWitness([]byte("e")); !errors.Is(err, ErrNotMember) {
+		t.Errorf("MembershipWitness(non-member) error %v; want %v", err, ErrNotMember)
+	}
+	if ws := New(params).MembershipWitnesses(); len(ws) != 0 {
+		t.Errorf("MembershipWitnesses() of the empty accumulator = %v; want none", ws)
+	}
+}
+
+func TestNonMembership(t *testing.T) {
+	acc := New(params)
+	acc.Add(elements("a", "b", "c")...)
+	value := acc.Value()
+
+	w, err := acc.NonMembershipWitness([]byte("x"))
+	if err != nil {
+		t.Fatalf("NonMembershipWitness() error %v", err)
+	}
+	if !params.VerifyNonMembership(value, []byte("x"), w) {
+		t.Error("VerifyNonMembership() = false; want true")
+	}
+	if params.VerifyNonMembership(value, []byte("y"), w) {
+		t.Error("VerifyNonMembership() of another element = true; want false")
+	}
+	acc.Add([]byte("x"))
+	if params.VerifyNonMembership(acc.Value(), []byte("x"), w) {
+		t.Error("VerifyNonMembership() after Add() = true; want false")
+	}
+	if _, err := acc.NonMembershipWitness([]byte("a")); !errors.Is(err, ErrMember) {
+		t.Errorf("NonMembershipWitness(member) error %v; want %v", err, ErrMember)
+	}
+
+	empty := New(params)
+	w, err = empty.NonMembershipWitness([]byte("a"))
+	if err != nil {
+		t.Fatalf("NonMembershipWitness() error %v", err)
+	}
+	if !params.VerifyNonMembership(empty.Value(), []byte("a"), w) {
+		t.Error("VerifyNonMembership() of the empty accumulator = false; want true")
+	}
+}
+
+func TestUpdates(t *testing.T) {
+	acc := New(params)
+	acc.Add(elements("a", "b", "c")...)
+	w, err := acc.MembershipWitness([]byte("a"))
+	if err != nil {
+		t.Fatalf("MembershipWitness() error %v", err)
+	}
+	batch, err := acc.MembershipWitness(elements("a", "b")...)
+	if err != nil {
+		t.Fatalf("MembershipWitness() error %v", err)
+	}
+
+	updates := []*Update{acc.Add(elements("d", "e")...)}
+	u, err := acc.Delete(elements("c", "e")...)
+	if err != nil {
+		t.Fatalf("Delete() error %v", err)
+	}
+	updates = append(updates, u, acc.Add([]byte("f")))
+	for _, u := range updates {
+		if w, err = params.UpdateWitness(w, u, []byte("a")); err != nil {
+			t.Fatalf("UpdateWitness() error %v", err)
+		}
+		if batch, err = params.UpdateWitness(batch, u, elements("a", "b")...); err != nil {
+			t.Fatalf("UpdateWitness() error %v", err)
+		}
+	}
+	value := acc.Value()
+	if !params.VerifyMembership(value, w, []byte("a")) {
+		t.Error("VerifyMembership(UpdateWitness()) = false; want true")
+	}
+	if !params.VerifyMembership(value, batch, elements("a", "b")...) {
+		t.Error("VerifyMembership(UpdateWitness()) of a batch = false; want true")
+	}
+
+	// Deletion recomputes the value of the remaining members.
+	fresh := New(params)
+	fresh.Add(elements("f", "d", "b", "a")...)
+	if fresh.Value().Cmp(value) != 0 {
+		t.Error("Value() after Delete() differs from that of the remaining members")
+	}
+
+	u, err = acc.Delete([]byte("a"))
+	if err != nil {
+		t.Fatalf("Delete() error %v", err)
+	}
+	if _, err := params.UpdateWitness(w, u, []byte("a")); !errors.Is(err, ErrNotMember) {
+		t.Errorf("UpdateWitness() of a deleted element error %v; want %v", err, ErrNotMember)
+	}
+	if _, err := acc.Delete([]byte("a")); !errors.Is(err, ErrNotMember) {
+		t.Errorf("Delete(non-member) error %v; want %v", err, ErrNotMember)
+	}
+	if _, err := acc.Delete(elements("b", "b")...); !errors.Is(err, ErrNotMember) {
+		t.Errorf("Delete(duplicates) error %v; want %v", err, ErrNotMember)
+	}
+	if !acc.Contains([]byte("b")) {
+		t.Error("failed Delete() removed members")
+	}
+}
+
+func TestHashToPrime(t *testing.T) {
+	x, y := HashToPrime([]byte("a")), HashToPrime([]byte("b"))
+	if x.BitLen() != 256 || !x.ProbablyPrime(20) {
+		t.Errorf("HashToPrime() = %v; want 256-bit prime", x)
+	}
+	if x.Cmp(y) == 0 || x.Cmp(HashToPrime([]byte("a"))) != 0 {
+		t.Error("HashToPrime() not injective or not deterministic")
+	}
+	if _, err := Setup(32, rand.Reader); err == nil {
+		t.Error("Setup(32) error nil; want error")
+	}
+}
@@ -0,0 +1,207 @@
+// Package bilinear implements pairing-based accumulators (Nguyen, "Accumulators
+// from Bilinear Pairings and Applications"), committing to a set of members z_i
+// directly as the value [prod_i (s + z_i)]_1 of a KZG SRS.
+//
+// The membership package commits to the same polynomial with roots at -z_i,
+// and proves membership with KZG openings on G2. Here the witness of z is the
+// accumulator of the other members, w = [prod_{z_i != z} (s + z_i)]_1, with
+//
+//	e(w, [s + z]_2) = e(value, [1]_2).
+//
+// Holders of witnesses update them with a single scalar multiplication per
+// added or deleted member, given the Update published by the manager, instead
+// of requesting a new opening.
+package bilinear
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+var (
+	// ErrMember is returned when adding members.
+	ErrMember = errors.New("element is a member")
+	// ErrNotMember is returned for witnesses and deletions of elements that
+	// aren't members.
+	ErrNotMember = errors.New("element is not a member")
+)
+
+// canonical returns z reduced into kzg.Field.
+func canonical(z *big.Int) *big.Int {
+	return new(big.Int).Mod(z, kzg.Field.Order())
+}
+
+// An Accumulator is a set of field elements, accumulated into its Value.
+type Accumulator struct {
+	srs *kzg.SRS
+	// members holds the canonical members, by their decimal representation.
+	members map[string]*big.Int
+	// poly is prod_i (X + z_i).
+	poly  *polynomial.Polynomial
+	value *bn256.G1
+}
+
+// New returns an Accumulator of the members, which are reduced into kzg.Field,
+// ignoring duplicates. The SRS bounds the number of members by its maximum
+// degree.
+func New(srs *kzg.SRS, members []*big.Int) (*Accumulator, error) {
+	a := &Accumulator{srs: srs, members: make(map[string]*big.Int), poly: polynomial.OnePolynomial}
+	for _, z := range members {
+		z = canonical(z)
+		if _, ok := a.members[z.String()]; ok {
+			continue
+		}
+		a.members[z.String()] = z
+		a.poly = a.poly.Mul(linear(z), kzg.Field)
+	}
+	value, err := a.srs.Commit(a.poly)
+	if err != nil {
+		return nil, fmt.Errorf("accumulating %d members: %v", len(a.members), err)
+	}
+	a.value = value
+	return a, nil
+}
+
+// linear returns the polynomial X + z.
+func linear(z *big.Int) *polynomial.Polynomial {
+	return polynomial.NewPolynomial([]*big.Int{z, big.NewInt(1)})
+}
+
+// Value returns the accumulated value, which can be shared publicly.
+func (a *Accumulator) Value() *bn256.G1 {
+	return new(bn256.G1).Set(a.value)
+}
+
+// Len returns the number of members.
+func (a *Accumulator) Len() int {
+	return len(a.members)
+}
+
+// Contains reports whether z is a member.
+func (a *Accumulator) Contains(z *big.Int) bool {
+	_, ok := a.members[canonical(z).String()]
+	return ok
+}
+
+// An Update records the addition or deletion of a single member, with the
+// Values before and after, from which holders of witnesses can update them
+// without access to the Accumulator; see UpdateWitness. Exactly one of Added
+// and Deleted is set.
+type Update struct {
+	Added, Deleted  *big.Int
+	Previous, Value *bn256.G1
+}
+
+// Add adds z to the Accumulator and returns the Update. It returns an error
+// wrapping ErrMember if z is already a member, or one if the SRS is too small,
+// in which case the Accumulator is unchanged.
+func (a *Accumulator) Add(z *big.Int) (*Update, error) {
+	z = canonical(z)
+	if a.Contains(z) {
+		return nil, fmt.Errorf("adding %v: %w", z, ErrMember)
+	}
+	p := a.poly.Mul(linear(z), kzg.Field)
+	value, err := a.srs.Commit(p)
+	if err != nil {
+		return nil, fmt.Errorf("accumulating %d members: %v", len(a.members)+1, err)
+	}
+	u := &Update{Added: z, Previous: a.value, Value: value}
+	a.members[z.String()] = z
+	a.poly, a.value = p, value
+	return u, nil
+}
+
+// Delete deletes z from the Accumulator and returns the Update, or an error
+// wrapping ErrNotMember. The new Value is the witness of z.
+func (a *Accumulator) Delete(z *big.Int) (*Update, error) {
+	z = canonical(z)
+	if !a.Contains(z) {
+		return nil, fmt.Errorf("deleting %v: %w", z, ErrNotMember)
+	}
+	p, value, err := a.without(z)
+	if err != nil {
+		return nil, err
+	}
+	u := &Update{Deleted: z, Previous: a.value, Value: value}
+	delete(a.members, z.String())
+	a.poly, a.value = p, value
+	return u, nil
+}
+
+// without returns the polynomial of all members but z, and its commitment.
+func (a *Accumulator) without(z *big.Int) (*polynomial.Polynomial, *bn256.G1, error) {
+	q, r := a.poly.Div(linear(z), kzg.Field)
+	if !r.Eq(polynomial.ZeroPolynomial) {
+		// Unreachable as -z is a root for all members.
+		return nil, nil, fmt.Errorf("division rest not zero: %v", r)
+	}
+	c, err := a.srs.Commit(q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return q, c, nil
+}
+
+// Witness returns the witness of z, i.e. the Value of the Accumulator without
+// it, or an error wrapping ErrNotMember.
+func (a *Accumulator) Witness(z *big.Int) (*bn256.G1, error) {
+	z = canonical(z)
+	if !a.Contains(z) {
+		return nil, fmt.Errorf("witness of %v: %w", z, ErrNotMember)
+	}
+	_, w, err := a.without(z)
+	return w, err
+}
+
+// Verify reports whether the witness w shows that z is a member of the
+// accumulated value, by checking
+//
+//	e(w, [s]_2 + z [1]_2) == e(value, [1]_2).
+//
+// Verification requires the VerifierKey of the SRS of the Accumulator.
+func Verify(vk *kzg.VerifierKey, value *bn256.G1, z *big.Int, w *bn256.G1) bool {
+	if value == nil || w == nil {
+		return false
+	}
+	sz := new(bn256.G2).Add(vk.S2, new(bn256.G2).ScalarBaseMult(canonical(z)))
+	return bn256.PairingCheck(
+		[]*bn256.G1{w, new(bn256.G1).Neg(value)},
+		[]*bn256.G2{sz, new(bn256.G2).ScalarBaseMult(big.NewInt(1))},
+	)
+}
+
+// UpdateWitness returns the witness of z after the Update, for its witness w
+// before it, with a single scalar multiplication. For an addition of z',
+//
+//	w' = Previous + (z' - z) w,
+//
+// and for a deletion of z' != z,
+//
+//	w' = (w - Value) / (z' - z).
+//
+// An error wrapping ErrNotMember is returned if z itself was deleted.
+func UpdateWitness(z *big.Int, w *bn256.G1, u *Update) (*bn256.G1, error) {
+	z = canonical(z)
+	switch {
+	case u.Added != nil && u.Previous != nil:
+		d := kzg.Field.Sub(canonical(u.Added), z)
+		return new(bn256.G1).Add(u.Previous, new(bn256.G1).ScalarMult(w, d)), nil
+	case u.Deleted != nil && u.Value != nil:
+		d := kzg.Field.Sub(canonical(u.Deleted), z)
+		if d.Sign() == 0 {
+			return nil, fmt.Errorf("updating witness of %v: %w", z, ErrNotMember)
+		}
+		inv, err := kzg.Field.Div(big.NewInt(1), d)
+		if err != nil {
+			return nil, err
+		}
+		diff := new(bn256.G1).Add(w, new(bn256.G1).Neg(u.Value))
+		return diff.ScalarMult(diff, inv), nil
+	}
+	return nil, errors.New("update without addition or deletion")
+}
@@ -0,0 +1,247 @@
+package bilinear
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/membership"
+)
+
+func bigInts(xs ...int64) []*big.Int {
+	var bs []*big.Int
+	for _, x := range xs {
+		bs = append(bs, big.NewInt(x))
+	}
+	return bs
+}
+
+var srs = kzg.NewSRS(big.NewInt(1337), 8)
+
+func newAccumulator(t testing.TB, members []*big.Int) *Accumulator {
+	t.Helper()
+	a, err := New(srs, members)
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	return a
+}
+
+func TestWitness(t *testing.T) {
+	vk := srs.VerifierKey()
+	members := bigInts(1, 2, 42, -7, 100000)
+	a := newAccumulator(t, append(members, big.NewInt(2)))
+	if got, want := a.Len(), len(members); got != want {
+		t.Errorf("Len() = %d; want %d", got, want)
+	}
+
+	for _, z := range members {
+		w, err := a.Witness(z)
+		if err != nil {
+			t.Fatalf("Witness(%v): %v", z, err)
+		}
+		if !Verify(vk, a.Value(), z, w) {
+			t.Errorf("Verify(%v, Witness(%v)) = false; want true", z, z)
+		}
+		other := new(big.Int).Add(z, big.NewInt(1))
+		if Verify(vk, a.Value(), other, w) {
+			t.Errorf("Verify(%v, Witness(%v)) = true; want false", other, z)
+		}
+	}
+
+	// Members are reduced into the field.
+	reduced := new(big.Int).Add(kzg.Field.Order(), big.NewInt(42))
+	if !a.Contains(reduced) {
+		t.Errorf("Contains(%v) = false; want true", reduced)
+	}
+	if _, err := a.Witness(big.NewInt(3)); !errors.Is(err, ErrNotMember) {
+		t.Errorf("Witness(3) error %v; want %v", err, ErrNotMember)
+	}
+	if _, err := New(srs, bigInts(1, 2, 3, 4, 5, 6, 7, 8, 9)); err == nil {
+		t.Error("New() with more members than the SRS degree; want error")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	vk := srs.VerifierKey()
+	a := newAccumulator(t, bigInts(1, 2, 3))
+	witnesses := make(map[int64]*bn256.G1)
+	for _, z := range []int64{1, 2, 3} {
+		w, err := a.Witness(big.NewInt(z))
+		if err != nil {
+			t.Fatalf("Witness(%d): %v", z, err)
+		}
+		witnesses[z] = w
+	}
+
+	tests := []struct {
+		name string
+		add  bool
+		z    int64
+	}{
+		{"add", true, 4},
+		{"delete", false, 2},
+		{"add negative", true, -5},
+		{"delete added", false, 4},
+		{"delete other", false, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				u   *Update
+				err error
+			)
+			if tt.add {
+				u, err = a.Add(big.NewInt(tt.z))
+			} else {
+				u, err = a.Delete(big.NewInt(tt.z))
+			}
+			if err != nil {
+				t.Fatalf("update of %d: %v", tt.z, err)
+			}
+			if u.Value.String() != a.Value().String() {
+				t.Errorf("Update.Value = %v; want %v", u.Value, a.Value())
+			}
+
+			if tt.add {
+				w, err := a.Witness(big.NewInt(tt.z))
+				if err != nil {
+					t.Fatalf("Witness(%d): %v", tt.z, err)
+				}
+				witnesses[tt.z] = w
+			} else {
+				if _, err := UpdateWitness(big.NewInt(tt.z), witnesses[tt.z], u); !errors.Is(err, ErrNotMember) {
+					t.Errorf("UpdateWitness() of the deleted member error %v; want %v", err, ErrNotMember)
+				}
+				delete(witnesses, tt.z)
+			}
+			for z, w := range witnesses {
+				if z == tt.z {
+					continue
+				}
+				got, err := UpdateWitness(big.NewInt(z), w, u)
+				if err != nil {
+					t.Fatalf("UpdateWitness(%d): %v", z, err)
+				}
+				if !Verify(vk, a.Value(), big.NewInt(z), got) {
+					t.Errorf("Verify(%d, UpdateWitness()) = false; want true", z)
+				}
+				witnesses[z] = got
+			}
+		})
+	}
+
+	if _, err := a.Add(big.NewInt(3)); !errors.Is(err, ErrMember) {
+		t.Errorf("Add() of a member error %v; want %v", err, ErrMember)
+	}
+	if _, err := a.Delete(big.NewInt(2)); !errors.Is(err, ErrNotMember) {
+		t.Errorf("Delete() of a non-member error %v; want %v", err, ErrNotMember)
+	}
+}
+
+const benchmarkMembers = 64
+
+// benchmarkSets returns an Accumulator and a committed membership.Set of the
+// same members, and the VerifierKey of their SRS.
+func benchmarkSets(b *testing.B) (*Accumulator, *membership.Set, *kzg.VerifierKey) {
+	b.Helper()
+	srs := kzg.NewSRS(big.NewInt(1337), benchmarkMembers+1)
+	members := make([]*big.Int, benchmarkMembers)
+	for i := range members {
+		members[i] = big.NewInt(int64(i + 1))
+	}
+	a, err := New(srs, members)
+	if err != nil {
+		b.Fatalf("New(): %v", err)
+	}
+	s := membership.NewSet(members)
+	if _, err := s.Commit(srs); err != nil {
+		b.Fatalf("Commit(): %v", err)
+	}
+	return a, s, srs.VerifierKey()
+}
+
+// BenchmarkWitness compares computing the witness of a member with proving
+// membership in a committed membership.Set, both an O(n) commitment.
+func BenchmarkWitness(b *testing.B) {
+	a, s, _ := benchmarkSets(b)
+	z := big.NewInt(benchmarkMembers / 2)
+
+	b.Run("bilinear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := a.Witness(z); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("membership", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.ProveMember(z); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkUpdateWitness compares updating a witness after the addition of a
+// member, in O(1), with proving membership anew in the updated
+// membership.Set.
+func BenchmarkUpdateWitness(b *testing.B) {
+	a, s, _ := benchmarkSets(b)
+	z, added := big.NewInt(1), big.NewInt(benchmarkMembers+1)
+	w, err := a.Witness(z)
+	if err != nil {
+		b.Fatal(err)
+	}
+	u, err := a.Add(added)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := s.Add(added); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("bilinear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := UpdateWitness(z, w, u); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("membership", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := s.ProveMember(z); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkVerify compares verification, with a G1 witness against a G2
+// quotient.
+func BenchmarkVerify(b *testing.B) {
+	a, s, vk := benchmarkSets(b)
+	for _, z := range bigInts(1, benchmarkMembers) {
+		w, err := a.Witness(z)
+		if err != nil {
+			b.Fatal(err)
+		}
+		proof, err := s.ProveMember(z)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("bilinear/%v", z), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Verify(vk, a.Value(), z, w)
+			}
+		})
+		b.Run(fmt.Sprintf("membership/%v", z), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				membership.VerifyMember(vk, s.Commitment(), z, proof)
+			}
+		})
+	}
+}
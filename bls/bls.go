@@ -0,0 +1,262 @@
+// Package bls implements BLS signatures over a curve.Suite, with signature
+// aggregation and proofs of possession. See Boneh et al., "Short Signatures
+// from the Weil Pairing", and the IETF draft "BLS Signatures",
+// draft-irtf-cfrg-bls-signature-05.
+//
+// Signatures are in G1 and public keys in G2, the "minimal-signature-size"
+// variant of the draft, as only G1 supports hashing to the curve on all
+// backends. Aggregation is secure against rogue-key attacks only if every
+// public key comes with a verified proof of possession of its private key, as
+// in the draft's proof-of-possession scheme.
+package bls
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+)
+
+var (
+	// BN256 is the Scheme over bn256.
+	BN256 = New(curve.BN256)
+	// BLS12381 is the Scheme over BLS12-381, whose tags match the
+	// proof-of-possession ciphersuite BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_
+	// of the draft.
+	BLS12381 = New(curve.BLS12381)
+
+	// ErrNoSignatures is returned when aggregating no signatures or keys.
+	ErrNoSignatures = errors.New("nothing to aggregate")
+)
+
+// A Scheme signs and verifies messages over a Suite.
+type Scheme struct {
+	s      curve.Suite
+	f      *galois.Field
+	sigDST []byte
+	popDST []byte
+}
+
+// hashID returns the identifier of the hash to G1 of the Suite, as used in the
+// ciphersuite IDs of the draft.
+func hashID(s curve.Suite) string {
+	switch s {
+	case curve.BN256:
+		return "BN254G1_XMD:SHA-256_TAI_"
+	case curve.BLS12381:
+		return "BLS12381G1_XMD:SHA-256_SSWU_RO_"
+	}
+	return s.Name() + "G1_"
+}
+
+// New returns the Scheme over the Suite.
+func New(s curve.Suite) *Scheme {
+	f := s.ScalarField()
+	id := hashID(s)
+	return &Scheme{
+		s:      s,
+		f:      f,
+		sigDST: []byte("BLS_SIG_" + id + "POP_"),
+		popDST: []byte("BLS_POP_" + id + "POP_"),
+	}
+}
+
+// Suite returns the curve of the Scheme.
+func (s *Scheme) Suite() curve.Suite {
+	return s.s
+}
+
+// A PrivateKey is a non-zero scalar X.
+type PrivateKey struct {
+	X *big.Int
+}
+
+// A PublicKey is X times the generator of G2 for the PrivateKey X.
+type PublicKey struct {
+	P curve.Point
+}
+
+// A Signature is X times the hash of the message to G1 for the PrivateKey X,
+// or the sum of such Signatures.
+type Signature struct {
+	P curve.Point
+}
+
+// keyGenSalt is the initial salt of KeyGen.
+const keyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+
+// KeyGen derives a PrivateKey from the secret input keying material, of at
+// least 32 bytes, and the optional key information, as section 2.3 of the
+// draft.
+func (s *Scheme) KeyGen(ikm, info []byte) (*PrivateKey, error) {
+	if len(ikm) < 32 {
+		return nil, fmt.Errorf("%d bytes of keying material < 32", len(ikm))
+	}
+	l := (3*s.f.Order().BitLen() + 15) / 16
+	salt := []byte(keyGenSalt)
+	for {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		secret := append(append([]byte(nil), ikm...), 0)
+		okm := make([]byte, l)
+		kdf := hkdf.New(sha256.New, secret, salt, append(append([]byte(nil), info...), byte(l>>8), byte(l)))
+		if _, err := io.ReadFull(kdf, okm); err != nil {
+			return nil, err
+		}
+		if x := s.f.Mod(new(big.Int).SetBytes(okm)); x.Sign() != 0 {
+			return &PrivateKey{X: x}, nil
+		}
+	}
+}
+
+// GenerateKey returns a PrivateKey derived from 32 bytes of the reader.
+func (s *Scheme) GenerateKey(r io.Reader) (*PrivateKey, error) {
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(r, ikm); err != nil {
+		return nil, err
+	}
+	return s.KeyGen(ikm, nil)
+}
+
+// PublicKey returns the PublicKey of sk.
+func (s *Scheme) PublicKey(sk *PrivateKey) *PublicKey {
+	return &PublicKey{P: s.s.G2().New().ScalarBaseMult(sk.X)}
+}
+
+func (s *Scheme) sign(sk *PrivateKey, dst, msg []byte) *Signature {
+	h := s.s.HashToG1(dst, msg)
+	return &Signature{P: s.s.G1().New().ScalarMult(h, sk.X)}
+}
+
+// verify reports whether e(sig, g2) = e(H(msg), pk) with the hash to G1 under
+// the tag, for a valid pk.
+func (s *Scheme) verify(pk *PublicKey, dst, msg []byte, sig *Signature) bool {
+	if !s.validKey(pk) {
+		return false
+	}
+	h := s.s.HashToG1(dst, msg)
+	return s.s.PairingCheck(
+		[]curve.Point{sig.P, s.s.G1().New().Neg(h)},
+		[]curve.Point{s.s.G2().Generator(), pk.P},
+	)
+}
+
+// validKey reports whether pk is not the identity, as KeyValidate of the draft;
+// Points are always in the prime-order subgroup.
+func (s *Scheme) validKey(pk *PublicKey) bool {
+	return !pk.P.Equal(s.s.G2().New())
+}
+
+// Sign returns the Signature of the message under sk.
+func (s *Scheme) Sign(sk *PrivateKey, msg []byte) *Signature {
+	return s.sign(sk, s.sigDST, msg)
+}
+
+// Verify reports whether sig is a valid Signature of the message under pk.
+func (s *Scheme) Verify(pk *PublicKey, msg []byte, sig *Signature) bool {
+	return s.verify(pk, s.sigDST, msg, sig)
+}
+
+// ProvePossession returns a proof of possession of sk: a Signature of the
+// encoded PublicKey under a tag distinct from that of Sign.
+func (s *Scheme) ProvePossession(sk *PrivateKey) *Signature {
+	return s.sign(sk, s.popDST, s.PublicKey(sk).P.Marshal())
+}
+
+// VerifyPossession reports whether the proof shows possession of the
+// PrivateKey of pk.
+func (s *Scheme) VerifyPossession(pk *PublicKey, proof *Signature) bool {
+	return s.verify(pk, s.popDST, pk.P.Marshal(), proof)
+}
+
+// Aggregate returns the sum of the Signatures, or ErrNoSignatures if there
+// are none.
+func (s *Scheme) Aggregate(sigs ...*Signature) (*Signature, error) {
+	if len(sigs) == 0 {
+		return nil, ErrNoSignatures
+	}
+	sum := s.s.G1().New()
+	for _, sig := range sigs {
+		sum.Add(sum, sig.P)
+	}
+	return &Signature{P: sum}, nil
+}
+
+// AggregatePublicKeys returns the sum of the PublicKeys, or ErrNoSignatures
+// if there are none. It is only secure for keys with verified proofs of
+// possession.
+func (s *Scheme) AggregatePublicKeys(pks ...*PublicKey) (*PublicKey, error) {
+	if len(pks) == 0 {
+		return nil, ErrNoSignatures
+	}
+	sum := s.s.G2().New()
+	for _, pk := range pks {
+		sum.Add(sum, pk.P)
+	}
+	return &PublicKey{P: sum}, nil
+}
+
+// FastAggregateVerify reports whether sig is the aggregate of Signatures of
+// the same message under all of the PublicKeys, each of which MUST have a
+// verified proof of possession.
+func (s *Scheme) FastAggregateVerify(pks []*PublicKey, msg []byte, sig *Signature) bool {
+	for _, pk := range pks {
+		if !s.validKey(pk) {
+			return false
+		}
+	}
+	pk, err := s.AggregatePublicKeys(pks...)
+	if err != nil {
+		return false
+	}
+	return s.Verify(pk, msg, sig)
+}
+
+// AggregateVerify reports whether sig is the aggregate of Signatures of
+// msgs[i] under pks[i], for all i, with a single product of pairings. The
+// messages need not be distinct if every PublicKey has a verified proof of
+// possession.
+func (s *Scheme) AggregateVerify(pks []*PublicKey, msgs [][]byte, sig *Signature) bool {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return false
+	}
+	g1s := []curve.Point{sig.P}
+	g2s := []curve.Point{s.s.G2().Generator()}
+	for i, pk := range pks {
+		if !s.validKey(pk) {
+			return false
+		}
+		h := s.s.HashToG1(s.sigDST, msgs[i])
+		g1s = append(g1s, s.s.G1().New().Neg(h))
+		g2s = append(g2s, pk.P)
+	}
+	return s.s.PairingCheck(g1s, g2s)
+}
+
+// UnmarshalPublicKey returns the PublicKey encoded by Marshal of its Point,
+// rejecting the identity.
+func (s *Scheme) UnmarshalPublicKey(b []byte) (*PublicKey, error) {
+	p := s.s.G2().New()
+	if err := p.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf("public key: %v", err)
+	}
+	pk := &PublicKey{P: p}
+	if !s.validKey(pk) {
+		return nil, errors.New("public key is the identity")
+	}
+	return pk, nil
+}
+
+// UnmarshalSignature returns the Signature encoded by Marshal of its Point.
+func (s *Scheme) UnmarshalSignature(b []byte) (*Signature, error) {
+	p := s.s.G1().New()
+	if err := p.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf("signature: %v", err)
+	}
+	return &Signature{P: p}, nil
+}
@@ -0,0 +1,183 @@
+package bls
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+var schemes = []*Scheme{BN256, BLS12381}
+
+func generateKeys(t *testing.T, s *Scheme, n int) ([]*PrivateKey, []*PublicKey) {
+	t.Helper()
+	sks, pks := make([]*PrivateKey, n), make([]*PublicKey, n)
+	for i := range sks {
+		sk, err := s.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey(): %v", err)
+		}
+		sks[i], pks[i] = sk, s.PublicKey(sk)
+	}
+	return sks, pks
+}
+
+func TestKeyGen(t *testing.T) {
+	// EIP-2333 test case 0, whose master key is KeyGen(seed, "").
+	seed, _ := hex.DecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+	want, _ := new(big.Int).SetString("6083874454709270928345386274498605044986640685124978867557563392430687146096", 10)
+	sk, err := BLS12381.KeyGen(seed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk.X.Cmp(want) != 0 {
+		t.Errorf("KeyGen(<EIP-2333 seed>) = %v; want %v", sk.X, want)
+	}
+
+	for _, s := range schemes {
+		if _, err := s.KeyGen(make([]byte, 31), nil); err == nil {
+			t.Errorf("%s: KeyGen(<31 bytes>): got nil error", s.Suite().Name())
+		}
+		a, err := s.KeyGen(seed, []byte("a"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.X.Cmp(sk.X) == 0 {
+			t.Errorf("%s: KeyGen() independent of the key information", s.Suite().Name())
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	for _, s := range schemes {
+		name := s.Suite().Name()
+		sks, pks := generateKeys(t, s, 2)
+		msg := []byte("message")
+		sig := s.Sign(sks[0], msg)
+		if !s.Verify(pks[0], msg, sig) {
+			t.Errorf("%s: Verify(Sign()) = false", name)
+		}
+		if s.Verify(pks[0], []byte("other"), sig) {
+			t.Errorf("%s: Verify(Sign()) of other message = true", name)
+		}
+		if s.Verify(pks[1], msg, sig) {
+			t.Errorf("%s: Verify(Sign()) under other key = true", name)
+		}
+		if s.Verify(&PublicKey{P: s.Suite().G2().New()}, msg, &Signature{P: s.Suite().G1().New()}) {
+			t.Errorf("%s: Verify() under identity key = true", name)
+		}
+
+		pk, err := s.UnmarshalPublicKey(pks[0].P.Marshal())
+		if err != nil {
+			t.Fatalf("%s: UnmarshalPublicKey(): %v", name, err)
+		}
+		got, err := s.UnmarshalSignature(sig.P.Marshal())
+		if err != nil {
+			t.Fatalf("%s: UnmarshalSignature(): %v", name, err)
+		}
+		if !s.Verify(pk, msg, got) {
+			t.Errorf("%s: Verify() after unmarshalling = false", name)
+		}
+		if _, err := s.UnmarshalPublicKey(s.Suite().G2().New().Marshal()); err == nil {
+			t.Errorf("%s: UnmarshalPublicKey(<identity>): got nil error", name)
+		}
+		if _, err := s.UnmarshalSignature(pks[0].P.Marshal()); err == nil {
+			t.Errorf("%s: UnmarshalSignature(<G2 point>): got nil error", name)
+		}
+	}
+}
+
+func TestPossession(t *testing.T) {
+	for _, s := range schemes {
+		name := s.Suite().Name()
+		sks, pks := generateKeys(t, s, 2)
+		proof := s.ProvePossession(sks[0])
+		if !s.VerifyPossession(pks[0], proof) {
+			t.Errorf("%s: VerifyPossession(ProvePossession()) = false", name)
+		}
+		if s.VerifyPossession(pks[1], proof) {
+			t.Errorf("%s: VerifyPossession(ProvePossession()) for other key = true", name)
+		}
+		// Proofs of possession are not signatures of the encoded key.
+		if s.Verify(pks[0], pks[0].P.Marshal(), proof) {
+			t.Errorf("%s: Verify(ProvePossession()) = true", name)
+		}
+		if s.VerifyPossession(pks[0], s.Sign(sks[0], pks[0].P.Marshal())) {
+			t.Errorf("%s: VerifyPossession(Sign(<public key>)) = true", name)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	for _, s := range schemes {
+		name := s.Suite().Name()
+		sks, pks := generateKeys(t, s, 4)
+
+		msg := []byte("message")
+		var sigs []*Signature
+		for _, sk := range sks {
+			sigs = append(sigs, s.Sign(sk, msg))
+		}
+		agg, err := s.Aggregate(sigs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.FastAggregateVerify(pks, msg, agg) {
+			t.Errorf("%s: FastAggregateVerify() = false", name)
+		}
+		if s.FastAggregateVerify(pks[1:], msg, agg) {
+			t.Errorf("%s: FastAggregateVerify() with missing key = true", name)
+		}
+		if s.FastAggregateVerify(nil, msg, agg) {
+			t.Errorf("%s: FastAggregateVerify(<no keys>) = true", name)
+		}
+
+		msgs := make([][]byte, len(sks))
+		sigs = sigs[:0]
+		for i, sk := range sks {
+			msgs[i] = []byte(fmt.Sprintf("message %d", i))
+			sigs = append(sigs, s.Sign(sk, msgs[i]))
+		}
+		agg, err = s.Aggregate(sigs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s.AggregateVerify(pks, msgs, agg) {
+			t.Errorf("%s: AggregateVerify() = false", name)
+		}
+		msgs[0], msgs[1] = msgs[1], msgs[0]
+		if s.AggregateVerify(pks, msgs, agg) {
+			t.Errorf("%s: AggregateVerify() with swapped messages = true", name)
+		}
+		if s.AggregateVerify(pks, msgs[1:], agg) {
+			t.Errorf("%s: AggregateVerify() with missing message = true", name)
+		}
+
+		if _, err := s.Aggregate(); err != ErrNoSignatures {
+			t.Errorf("%s: Aggregate(): got err %v; want %v", name, err, ErrNoSignatures)
+		}
+		if _, err := s.AggregatePublicKeys(); err != ErrNoSignatures {
+			t.Errorf("%s: AggregatePublicKeys(): got err %v; want %v", name, err, ErrNoSignatures)
+		}
+	}
+}
+
+func TestRogueKey(t *testing.T) {
+	// A rogue key pk' = x*g2 - pk lets its owner forge an aggregate signature
+	// under {pk, pk'} alone, but not a proof of possession.
+	s := BN256
+	_, pks := generateKeys(t, s, 1)
+	x := big.NewInt(42)
+	rogue := &PublicKey{P: s.Suite().G2().New().ScalarBaseMult(x)}
+	rogue.P.Add(rogue.P, s.Suite().G2().New().Neg(pks[0].P))
+
+	msg := []byte("message")
+	forged := &Signature{P: s.Suite().G1().New().ScalarMult(s.Suite().HashToG1(s.sigDST, msg), x)}
+	if !s.FastAggregateVerify([]*PublicKey{pks[0], rogue}, msg, forged) {
+		t.Fatalf("FastAggregateVerify(<rogue key>) = false")
+	}
+	if s.VerifyPossession(rogue, s.ProvePossession(&PrivateKey{X: x})) {
+		t.Errorf("VerifyPossession(<rogue key>) = true")
+	}
+}
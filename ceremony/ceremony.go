@@ -0,0 +1,129 @@
+// Package ceremony implements multi-party "powers of tau" ceremonies (Bowe,
+// Gabizon and Miers, "Scalable Multi-party Computation for zk-SNARK
+// Parameters") producing a kzg.SRS whose secret nobody knows as long as one
+// participant discards theirs.
+//
+// Starting from the SRS of secret 1, each participant multiplies in a secret
+// x of their own, turning the powers [s^i] into [(s x)^i], and proves the
+// update with same-ratio pairing checks: that the new SRS is well-formed, that
+// its [s x]_1 is x times the previous [s]_1 for the committed [x]_2, and that
+// they know x, by x times a point hashed from the previous SRS. Coordinators
+// verify each update before accepting it, and anybody can verify the full
+// Transcript.
+package ceremony
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// dst is the domain separation tag of the points from which proofs of
+// knowledge are computed.
+const dst = "zkp.xyz/ceremony/pok"
+
+var (
+	bigOne = big.NewInt(1)
+	g2     = new(bn256.G2).ScalarBaseMult(bigOne)
+)
+
+// Start returns the SRS of secret 1 for polynomials of degree up to
+// maxDegree, with which ceremonies start.
+func Start(maxDegree int) *kzg.SRS {
+	return kzg.NewSRS(bigOne, maxDegree)
+}
+
+// An UpdateProof attests that an SRS was derived from the previous one by
+// multiplying its secret with the x of X2 = [x]_2, known to the participant
+// as shown by PoK = x R for the point R hashed from the previous SRS.
+type UpdateProof struct {
+	X2  *bn256.G2
+	PoK *bn256.G1
+}
+
+// pokBase returns the point R of the previous SRS, hashed from its binary
+// encoding so that proofs can't be replayed for other SRSs.
+func pokBase(prev *kzg.SRS) (*bn256.G1, error) {
+	buf, err := prev.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(buf)
+	r := new(bn256.G1)
+	if _, err := r.Unmarshal(curve.BN256.HashToG1([]byte(dst), h[:]).Marshal()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Contribute returns the SRS with the secret of prev multiplied by a secret x
+// drawn from r, and the UpdateProof. The secret is discarded before
+// Contribute returns, but r MUST be a source of cryptographic randomness.
+func Contribute(prev *kzg.SRS, r io.Reader) (*kzg.SRS, *UpdateProof, error) {
+	if prev.IsHiding() {
+		return nil, nil, errors.New("hiding SRSs aren't supported")
+	}
+	if len(prev.G1) != len(prev.G2) {
+		return nil, nil, fmt.Errorf("SRS with %d powers in G1 and %d in G2; want equal", len(prev.G1), len(prev.G2))
+	}
+	var x *big.Int
+	for x == nil || x.Sign() == 0 {
+		var err error
+		if x, err = kzg.Field.Random(r); err != nil {
+			return nil, nil, err
+		}
+	}
+	base, err := pokBase(prev)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next := &kzg.SRS{G1: make([]*bn256.G1, len(prev.G1)), G2: make([]*bn256.G2, len(prev.G2))}
+	for i, xi := range polynomial.ComputePowers(x, len(prev.G1), kzg.Field) {
+		next.G1[i] = new(bn256.G1).ScalarMult(prev.G1[i], xi)
+		next.G2[i] = new(bn256.G2).ScalarMult(prev.G2[i], xi)
+	}
+	proof := &UpdateProof{
+		X2:  new(bn256.G2).ScalarBaseMult(x),
+		PoK: new(bn256.G1).ScalarMult(base, x),
+	}
+	return next, proof, nil
+}
+
+// VerifyUpdate returns an error unless the proof shows that next was derived
+// from prev by Contribute, i.e. unless next is well-formed as checked by
+// kzg.SRS.Verify, has the size of prev, and
+//
+//	e(next.G1[1], [1]_2) == e(prev.G1[1], X2) and e(PoK, [1]_2) == e(R, X2).
+func VerifyUpdate(prev, next *kzg.SRS, proof *UpdateProof) error {
+	if proof == nil || proof.X2 == nil || proof.PoK == nil {
+		return errors.New("incomplete update proof")
+	}
+	if len(next.G1) != len(prev.G1) || len(next.G2) != len(prev.G2) {
+		return fmt.Errorf("SRS of max degree %d updated to %d", prev.MaxDegree(), next.MaxDegree())
+	}
+	if next.IsHiding() {
+		return errors.New("hiding SRSs aren't supported")
+	}
+	if err := next.Verify(); err != nil {
+		return err
+	}
+	if !kzg.SameRatio(next.G1[1], prev.G1[1], proof.X2, g2) {
+		return errors.New("[s]_1 not updated by the x of the proof")
+	}
+	base, err := pokBase(prev)
+	if err != nil {
+		return err
+	}
+	if !kzg.SameRatio(proof.PoK, base, proof.X2, g2) {
+		return errors.New("invalid proof of knowledge of x")
+	}
+	return nil
+}
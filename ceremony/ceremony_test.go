@@ -0,0 +1,92 @@
+package ceremony
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+func contribute(t *testing.T, prev *kzg.SRS) (*kzg.SRS, *UpdateProof) {
+	t.Helper()
+	next, proof, err := Contribute(prev, rand.Reader)
+	if err != nil {
+		t.Fatalf("Contribute() error %v", err)
+	}
+	return next, proof
+}
+
+func TestContribute(t *testing.T) {
+	// A contribution to the SRS of secret s gives that of s x.
+	prev := kzg.NewSRS(big.NewInt(1337), 4)
+	next, proof := contribute(t, prev)
+	if err := VerifyUpdate(prev, next, proof); err != nil {
+		t.Fatalf("VerifyUpdate() error %v", err)
+	}
+
+	p := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1, 0, 3})
+	c, err := kzg.Commit(next, p)
+	if err != nil {
+		t.Fatalf("Commit() error %v", err)
+	}
+	opening, err := kzg.Open(next, p, big.NewInt(7))
+	if err != nil {
+		t.Fatalf("Open() error %v", err)
+	}
+	if !kzg.Verify(next.VerifierKey(), c, opening) {
+		t.Error("Verify() with the updated SRS = false; want true")
+	}
+	if kzg.Verify(prev.VerifierKey(), c, opening) {
+		t.Error("Verify() with the previous SRS = true; want false")
+	}
+}
+
+func TestVerifyUpdate(t *testing.T) {
+	prev := Start(3)
+	next, proof := contribute(t, prev)
+	other, otherProof := contribute(t, next)
+
+	tamperedPower := &kzg.SRS{G1: append([]*bn256.G1{}, next.G1...), G2: next.G2}
+	tamperedPower.G1[2] = new(bn256.G1).Add(next.G1[2], next.G1[0])
+	identity := &kzg.SRS{G1: append([]*bn256.G1{}, next.G1...), G2: next.G2}
+	identity.G1[3] = new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	shifted := &kzg.SRS{G1: next.G1[1:], G2: next.G2[1:]}
+	truncated := &kzg.SRS{G1: next.G1[:3], G2: next.G2[:3]}
+
+	tests := []struct {
+		name       string
+		prev, next *kzg.SRS
+		proof      *UpdateProof
+		wantErr    bool
+	}{
+		{"valid", prev, next, proof, false},
+		{"chained", next, other, otherProof, false},
+		{"skipped contribution", prev, other, otherProof, true},
+		{"replayed proof", next, other, proof, true},
+		{"no update", prev, prev, &UpdateProof{X2: prev.G2[0], PoK: proof.PoK}, true},
+		{"borrowed x", next, other, &UpdateProof{X2: otherProof.X2, PoK: proof.PoK}, true},
+		{"tampered power", prev, tamperedPower, proof, true},
+		{"identity power", prev, identity, proof, true},
+		{"not starting at generators", prev, shifted, proof, true},
+		{"truncated", prev, truncated, proof, true},
+		{"missing proof", prev, next, nil, true},
+		{"incomplete proof", prev, next, &UpdateProof{X2: proof.X2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyUpdate(tt.prev, tt.next, tt.proof)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("VerifyUpdate() error %v; want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContributeHiding(t *testing.T) {
+	if _, _, err := Contribute(kzg.NewHidingSRS(big.NewInt(1337), big.NewInt(42), 2), rand.Reader); err == nil {
+		t.Error("Contribute() to a hiding SRS error nil; want error")
+	}
+}
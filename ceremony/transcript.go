@@ -0,0 +1,71 @@
+package ceremony
+
+import (
+	"fmt"
+
+	"zkp.xyz/membership/kzg"
+)
+
+// A Contribution is the SRS after an update, with its UpdateProof.
+type Contribution struct {
+	SRS   *kzg.SRS
+	Proof *UpdateProof
+}
+
+// A Transcript records the Contributions to a ceremony, starting from the SRS
+// returned by Start.
+type Transcript struct {
+	MaxDegree     int
+	Contributions []Contribution
+}
+
+// NewTranscript returns the Transcript of a ceremony for polynomials of
+// degree up to maxDegree, without Contributions.
+func NewTranscript(maxDegree int) (*Transcript, error) {
+	if maxDegree < 1 {
+		return nil, fmt.Errorf("max degree %d; want at least 1", maxDegree)
+	}
+	return &Transcript{MaxDegree: maxDegree}, nil
+}
+
+// SRS returns the SRS of the last Contribution, or that of Start if there are
+// none, to which the next participant contributes.
+func (t *Transcript) SRS() *kzg.SRS {
+	if len(t.Contributions) == 0 {
+		return Start(t.MaxDegree)
+	}
+	return t.Contributions[len(t.Contributions)-1].SRS
+}
+
+// Append verifies the update of SRS() to next with VerifyUpdate and records
+// it. The Transcript is unchanged on error.
+func (t *Transcript) Append(next *kzg.SRS, proof *UpdateProof) error {
+	if err := VerifyUpdate(t.SRS(), next, proof); err != nil {
+		return fmt.Errorf("contribution %d: %v", len(t.Contributions), err)
+	}
+	t.Contributions = append(t.Contributions, Contribution{SRS: next, Proof: proof})
+	return nil
+}
+
+// Verify verifies the chain of all Contributions from Start, returning the
+// final SRS. A Transcript without Contributions is rejected, as its secret is
+// known.
+func (t *Transcript) Verify() (*kzg.SRS, error) {
+	if len(t.Contributions) == 0 {
+		return nil, fmt.Errorf("transcript without contributions")
+	}
+	if t.MaxDegree < 1 {
+		return nil, fmt.Errorf("max degree %d; want at least 1", t.MaxDegree)
+	}
+	prev := Start(t.MaxDegree)
+	for i, c := range t.Contributions {
+		if c.SRS == nil {
+			return nil, fmt.Errorf("contribution %d without SRS", i)
+		}
+		if err := VerifyUpdate(prev, c.SRS, c.Proof); err != nil {
+			return nil, fmt.Errorf("contribution %d: %v", i, err)
+		}
+		prev = c.SRS
+	}
+	return prev, nil
+}
@@ -0,0 +1,46 @@
+package ceremony
+
+import "testing"
+
+func TestTranscript(t *testing.T) {
+	tr, err := NewTranscript(4)
+	if err != nil {
+		t.Fatalf("NewTranscript() error %v", err)
+	}
+	if _, err := tr.Verify(); err == nil {
+		t.Error("Verify() without contributions error nil; want error")
+	}
+
+	for i := 0; i < 3; i++ {
+		next, proof := contribute(t, tr.SRS())
+		if err := tr.Append(next, proof); err != nil {
+			t.Fatalf("Append(contribution %d) error %v", i, err)
+		}
+	}
+	final, err := tr.Verify()
+	if err != nil {
+		t.Fatalf("Verify() error %v", err)
+	}
+	if final != tr.SRS() {
+		t.Error("Verify() != SRS(); want the last contribution")
+	}
+
+	// A contribution to an earlier SRS is rejected.
+	stale, proof := contribute(t, tr.Contributions[0].SRS)
+	if err := tr.Append(stale, proof); err == nil {
+		t.Error("Append() of a stale contribution error nil; want error")
+	}
+	if got := len(tr.Contributions); got != 3 {
+		t.Errorf("len(Contributions) = %d after rejected Append(); want 3", got)
+	}
+
+	// Reordering breaks the chain.
+	tr.Contributions[1], tr.Contributions[2] = tr.Contributions[2], tr.Contributions[1]
+	if _, err := tr.Verify(); err == nil {
+		t.Error("Verify() of reordered contributions error nil; want error")
+	}
+
+	if _, err := NewTranscript(0); err == nil {
+		t.Error("NewTranscript(0) error nil; want error")
+	}
+}
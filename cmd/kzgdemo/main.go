@@ -0,0 +1,75 @@
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+func check(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func main() {
+	// The trusted setup computes the hidden powers [s^i] of a random secret s on both curves, after which s is
+	// discarded.
+	srs, err := kzg.Setup(100, rand.Reader)
+	check(err)
+	vk := srs.VerifierKey()
+
+	// These are the set members that we want to hide.
+	// We'd like to generate a proof to verify that a given (public) number is part of this set.
+	zs := []*big.Int{big.NewInt(1), big.NewInt(2)}
+
+	// generate poly containing zs as roots, i.e. p(v) = (v - z1)(v - z2)...
+	p := polynomial.FromRoots(zs, kzg.Field)
+
+	// evaluate p(s) on G1 - This is our commitment to the polynomial that we can share publicly
+	c, err := kzg.Commit(srs, p)
+	check(err)
+
+	// We would now like to prove that we have complete knowledge of the polynomial and that
+	// its evaluation at z is p(z) = y
+	z := big.NewInt(5)
+
+	// Uncomment the following line to simulation what it looks like if we don't know the polynomial
+	// p = p.Add(polynomial.OnePolynomial, kzg.Field)
+
+	// To do this we use Kate proofs (see also https://dankradfeist.de/ethereum/2020/06/16/kate-polynomial-commitments.html)
+	// and construct the polynomial q(v) = (p(v) - p(z)) / (v - z), which we can always compute without rest because
+	// the numerator and divisor both have a root at z. The proof contains y = p(z) and q(s) evaluated on G2, which
+	// proves that we have full knowledge of the polynomial since every coefficient needs to be multiplied with its
+	// corresponding power of s on G2.
+	proof, err := kzg.Open(srs, p, z)
+	check(err)
+
+	// This pairing check proves that we have full knowledge of the polynomial and that we correctly
+	// evaluated p(z) = y.
+	// The verifier only needs to get z, y, [q(s)]_2 from the prover. All other info is publicly available.
+	// In the end we are verifying [s-z]_1 x [q(s)]_2 - [p(s) - y]_1 x [1]_2 = 0
+	fmt.Println(kzg.Verify(vk, c, proof))
+
+	// The values sent to the verifier can be encoded for the bn256 precompiles
+	fmt.Printf("%x\n", proof.MarshalEVM())
+
+	// This can for example be used to verify set membership in a smart contract by encoding all members as roots
+	// of a polynomial (as done above). We would verify that p(z) = y was correctly computed using the above machinery
+	// and check that y == 0 meaning that z was indeed a root and therefore a member of the set.
+
+	{
+		// We can also perform pairing checks by moving the hidden quantities from one curve to the other,
+		// i.e. exploiting the commutative property of bilinear pairings.
+		// In belows example we are proving the equivalent equation [q(s)]_1 x [s-z]_2 - [p(s) - y]_1 x [1]_2 = 0
+		// where the terms in the first multiplication have been swapped.
+		qs1, _, y, err := srs.QuotientCommitmentsBothCurves(p, z)
+		check(err)
+		fmt.Println(vk.VerifyWithG1Quotient(c.G1(), z, y, qs1))
+	}
+}
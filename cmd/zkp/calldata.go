@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+	"zkp.xyz/membership/kzg"
+)
+
+// Signatures of the functions of contracts generated by kzg/solgen.
+var signatures = map[string]string{
+	"verify":           "verify(uint256[2],uint256,uint256,uint256[4])",
+	"verifyEncoded":    "verifyEncoded(uint256[2],bytes)",
+	"verifyMembership": "verifyMembership(uint256[2],uint256,uint256[4])",
+}
+
+// selector returns the ABI function selector of the signature.
+func selector(signature string) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(signature))
+	return h.Sum(nil)[:4]
+}
+
+// word returns x as a 32-byte ABI word.
+func word(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, 32))
+}
+
+// calldata returns the ABI-encoded call of the function of a kzg/solgen
+// contract verifying the proof against the commitment. The commitment is
+// encoded as its affine coordinates (x, y) and the quotient as in
+// kzg.Proof.MarshalEVM, with the imaginary part c1 of each coordinate first as
+// required by the ecPairing precompile. All arguments are static except the
+// proof of verifyEncoded, which follows its offset and length.
+func calldata(function string, c *kzg.Commitment, proof *kzg.Proof) ([]byte, error) {
+	signature, ok := signatures[function]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q; want verify, verifyEncoded or verifyMembership", function)
+	}
+	evm := proof.MarshalEVM()
+	buf := append(selector(signature), c.G1().Marshal()...)
+	switch function {
+	case "verify":
+		buf = append(buf, evm...)
+	case "verifyEncoded":
+		// The offset of the bytes from the start of the arguments, after the
+		// two words of the commitment and the offset itself.
+		buf = append(buf, word(big.NewInt(3*32))...)
+		buf = append(buf, word(big.NewInt(int64(len(evm))))...)
+		// kzg.EVMProofSize is a multiple of 32, so no padding is required.
+		buf = append(buf, evm...)
+	case "verifyMembership":
+		if proof.Y.Sign() != 0 {
+			return nil, fmt.Errorf("membership proof with y = %v; want 0", proof.Y)
+		}
+		buf = append(buf, evm[:32]...)
+		buf = append(buf, evm[64:]...)
+	}
+	return buf, nil
+}
+
+// calldataJSON is the JSON output of encode-calldata.
+type calldataJSON struct {
+	Signature string `json:"signature"`
+	Selector  string `json:"selector"`
+	Calldata  string `json:"calldata"`
+}
+
+// encodeCalldata writes the calldata verifying a proof file against a
+// commitment file on-chain.
+func encodeCalldata(args []string, stdout io.Writer) error {
+	fs := newFlagSet("encode-calldata")
+	var (
+		commitment = fs.String("commitment", "", "commitment `file`, as written by zkp commit")
+		proofPath  = fs.String("proof", "", "proof `file`, as written by zkp prove")
+		function   = fs.String("function", "verify", "function of the kzg/solgen contract: verify, verifyEncoded or verifyMembership")
+		out        = fs.String("out", "", "write the calldata to `file` instead of stdout")
+		format     = fs.String("format", formatHex, "format of the calldata: binary, hex or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "commitment", "proof"); err != nil {
+		return err
+	}
+	c, err := readFile(*commitment, decodeCommitment)
+	if err != nil {
+		return err
+	}
+	proof, err := readFile(*proofPath, decodeProof)
+	if err != nil {
+		return err
+	}
+	data, err := calldata(*function, c, proof)
+	if err != nil {
+		return err
+	}
+	buf, err := encode(*format, data, calldataJSON{
+		Signature: signatures[*function],
+		Selector:  fmt.Sprintf("0x%x", data[:4]),
+		Calldata:  fmt.Sprintf("0x%x", data),
+	})
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, stdout, buf)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// solgenABI is the ABI of the functions of kzg/solgen contracts.
+const solgenABI = `[
+	{"type": "function", "name": "verify", "inputs": [
+		{"name": "commitment", "type": "uint256[2]"}, {"name": "z", "type": "uint256"},
+		{"name": "y", "type": "uint256"}, {"name": "quotient", "type": "uint256[4]"}]},
+	{"type": "function", "name": "verifyEncoded", "inputs": [
+		{"name": "commitment", "type": "uint256[2]"}, {"name": "proof", "type": "bytes"}]},
+	{"type": "function", "name": "verifyMembership", "inputs": [
+		{"name": "commitment", "type": "uint256[2]"}, {"name": "member", "type": "uint256"},
+		{"name": "quotient", "type": "uint256[4]"}]}
+]`
+
+func TestCalldata(t *testing.T) {
+	contract, err := abi.JSON(strings.NewReader(solgenABI))
+	if err != nil {
+		t.Fatalf("abi.JSON() error %v", err)
+	}
+	srs := kzg.NewSRS(big.NewInt(1337), 2)
+	p := polynomial.FromRoots([]*big.Int{big.NewInt(3), big.NewInt(5)}, kzg.Field)
+	c, err := kzg.Commit(srs, p)
+	if err != nil {
+		t.Fatalf("Commit() error %v", err)
+	}
+	member, err := kzg.Open(srs, p, big.NewInt(5))
+	if err != nil {
+		t.Fatalf("Open() error %v", err)
+	}
+	other, err := kzg.Open(srs, p, big.NewInt(7))
+	if err != nil {
+		t.Fatalf("Open() error %v", err)
+	}
+
+	// The quotient, with c1 first, as consumed by the contract.
+	quotient := func(proof *kzg.Proof) [4]*big.Int {
+		var q [4]*big.Int
+		buf := proof.Quotient.Marshal()
+		for i := range q {
+			q[i] = new(big.Int).SetBytes(buf[32*i : 32*(i+1)])
+		}
+		return q
+	}
+	cxy := c.G1().Marshal()
+	commitment := [2]*big.Int{new(big.Int).SetBytes(cxy[:32]), new(big.Int).SetBytes(cxy[32:])}
+
+	tests := []struct {
+		function string
+		proof    *kzg.Proof
+		args     []interface{}
+	}{
+		{"verify", other, []interface{}{commitment, other.Z, other.Y, quotient(other)}},
+		{"verifyEncoded", other, []interface{}{commitment, other.MarshalEVM()}},
+		{"verifyMembership", member, []interface{}{commitment, member.Z, quotient(member)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			got, err := calldata(tt.function, c, tt.proof)
+			if err != nil {
+				t.Fatalf("calldata() error %v", err)
+			}
+			want, err := contract.Pack(tt.function, tt.args...)
+			if err != nil {
+				t.Fatalf("abi.Pack() error %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("calldata(%s) = %x; want %x", tt.function, got, want)
+			}
+		})
+	}
+
+	if _, err := calldata("verifyMembership", c, other); err == nil {
+		t.Error("calldata(verifyMembership) with y != 0; want error")
+	}
+	if _, err := calldata("prove", c, member); err == nil {
+		t.Error("calldata() of unknown function; want error")
+	}
+}
+
+func TestEncodeCalldata(t *testing.T) {
+	dir := t.TempDir()
+	path := func(name string) string { return filepath.Join(dir, name) }
+	if err := run([]string{"setup", "-degree", "4", "-out", path("srs")}, new(bytes.Buffer)); err != nil {
+		t.Fatalf("run(setup) error %v", err)
+	}
+	if err := os.WriteFile(path("members"), []byte("3\n5\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	set := []string{"-srs", path("srs"), "-members", path("members")}
+	if err := run(append([]string{"commit", "-out", path("commitment")}, set...), new(bytes.Buffer)); err != nil {
+		t.Fatalf("run(commit) error %v", err)
+	}
+	if err := run(append([]string{"prove", "-z", "5", "-format", "json", "-out", path("proof")}, set...), new(bytes.Buffer)); err != nil {
+		t.Fatalf("run(prove) error %v", err)
+	}
+
+	c, err := readFile(path("commitment"), decodeCommitment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := readFile(path("proof"), decodeProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := calldata("verifyMembership", c, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := run([]string{"encode-calldata", "-commitment", path("commitment"), "-proof", path("proof"), "-function", "verifyMembership", "-format", "binary"}, &out); err != nil {
+		t.Fatalf("run(encode-calldata) error %v", err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("run(encode-calldata) = %x; want %x", out.Bytes(), want)
+	}
+	if err := run([]string{"encode-calldata", "-commitment", path("commitment"), "-proof", path("proof"), "-function", "open"}, new(bytes.Buffer)); err == nil {
+		t.Error("run(encode-calldata -function open); want error")
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+)
+
+// Output formats, selected with the -format flag of all commands. Binary and
+// hex are the binary encodings documented with each value, the latter
+// 0x-prefixed; JSON follows the schemas of the *JSON types below, in which
+// all field values are 0x-prefixed big-endian hex of 32 bytes.
+const (
+	formatBinary = "binary"
+	formatHex    = "hex"
+	formatJSON   = "json"
+)
+
+// fieldHex encodes a field value as in JSON.
+func fieldHex(x *big.Int) string {
+	return fmt.Sprintf("0x%064x", x)
+}
+
+// parseFieldHex decodes a field value of JSON, which must be smaller than
+// the modulus.
+func parseFieldHex(s string, modulus *big.Int) (*big.Int, error) {
+	x, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok || !strings.HasPrefix(s, "0x") || x.Cmp(modulus) >= 0 {
+		return nil, fmt.Errorf("invalid field value %q", s)
+	}
+	return x, nil
+}
+
+// g1JSON is the affine encoding of a G1 point.
+type g1JSON struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+// fp2JSON is an element c1*i + c0 of the quadratic extension field.
+type fp2JSON struct {
+	C0 string `json:"c0"`
+	C1 string `json:"c1"`
+}
+
+// g2JSON is the affine encoding of a G2 point.
+type g2JSON struct {
+	X fp2JSON `json:"x"`
+	Y fp2JSON `json:"y"`
+}
+
+// words returns the 32-byte words of buf as field values.
+func words(buf []byte) []string {
+	var ws []string
+	for i := 0; i < len(buf); i += 32 {
+		ws = append(ws, fieldHex(new(big.Int).SetBytes(buf[i:i+32])))
+	}
+	return ws
+}
+
+// unwords is the inverse of words, for coordinates of points.
+func unwords(ws ...string) ([]byte, error) {
+	buf := make([]byte, 32*len(ws))
+	for i, w := range ws {
+		x, err := parseFieldHex(w, bn256.P)
+		if err != nil {
+			return nil, err
+		}
+		x.FillBytes(buf[32*i : 32*(i+1)])
+	}
+	return buf, nil
+}
+
+func newG1JSON(p *bn256.G1) g1JSON {
+	ws := words(p.Marshal())
+	return g1JSON{X: ws[0], Y: ws[1]}
+}
+
+func (j g1JSON) point() (*bn256.G1, error) {
+	buf, err := unwords(j.X, j.Y)
+	if err != nil {
+		return nil, err
+	}
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(buf); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func newG2JSON(p *bn256.G2) g2JSON {
+	// bn256.G2.Marshal orders the imaginary part c1 first.
+	ws := words(p.Marshal())
+	return g2JSON{X: fp2JSON{C0: ws[1], C1: ws[0]}, Y: fp2JSON{C0: ws[3], C1: ws[2]}}
+}
+
+func (j g2JSON) point() (*bn256.G2, error) {
+	buf, err := unwords(j.X.C1, j.X.C0, j.Y.C1, j.Y.C0)
+	if err != nil {
+		return nil, err
+	}
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(buf); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// encode returns the binary encoding in the format, or the JSON value v.
+func encode(format string, binary []byte, v interface{}) ([]byte, error) {
+	switch format {
+	case formatBinary:
+		return binary, nil
+	case formatHex:
+		return []byte("0x" + hex.EncodeToString(binary) + "\n"), nil
+	case formatJSON:
+		buf, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, '\n'), nil
+	}
+	return nil, fmt.Errorf("unknown format %q; want binary, hex or json", format)
+}
+
+// decode decodes buf in any of the formats of encode, telling them apart by
+// the leading brace of JSON and 0x prefix of hex. It returns the binary
+// encoding, or nil if buf was decoded into the JSON value v.
+func decode(buf []byte, v interface{}) ([]byte, error) {
+	trimmed := bytes.TrimSpace(buf)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		if err := json.Unmarshal(trimmed, v); err != nil {
+			return nil, fmt.Errorf("decoding JSON: %v", err)
+		}
+		return nil, nil
+	case bytes.HasPrefix(trimmed, []byte("0x")):
+		b, err := hex.DecodeString(string(trimmed[2:]))
+		if err != nil {
+			return nil, fmt.Errorf("decoding hex: %v", err)
+		}
+		return b, nil
+	}
+	return buf, nil
+}
+
+// checkCurve returns an error unless the curve of a JSON value is bn256.
+func checkCurve(curve string) error {
+	if curve != curveName {
+		return fmt.Errorf("curve %q; want %q", curve, curveName)
+	}
+	return nil
+}
+
+// commitmentJSON is the JSON encoding of a commitment, whose binary encoding
+// is that of kzg.Commitment.MarshalBinary.
+type commitmentJSON struct {
+	Curve      string `json:"curve"`
+	Commitment g1JSON `json:"commitment"`
+}
+
+func encodeCommitment(c *kzg.Commitment, format string) ([]byte, error) {
+	buf, err := c.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return encode(format, buf, commitmentJSON{Curve: curveName, Commitment: newG1JSON(c.G1())})
+}
+
+func decodeCommitment(buf []byte) (*kzg.Commitment, error) {
+	var j commitmentJSON
+	b, err := decode(buf, &j)
+	if err != nil {
+		return nil, err
+	}
+	c := new(kzg.Commitment)
+	if b != nil {
+		return c, c.UnmarshalBinary(b)
+	}
+	if err := checkCurve(j.Curve); err != nil {
+		return nil, err
+	}
+	p, err := j.Commitment.point()
+	if err != nil {
+		return nil, fmt.Errorf("commitment: %v", err)
+	}
+	*c = kzg.Commitment(*p)
+	return c, nil
+}
+
+// proofJSON is the JSON encoding of an opening proof, i.e. a kzg.Proof, whose
+// binary encoding is that of kzg.Proof.MarshalEVM. Membership proofs have
+// y = 0 and the member as z.
+type proofJSON struct {
+	Curve    string `json:"curve"`
+	Z        string `json:"z"`
+	Y        string `json:"y"`
+	Quotient g2JSON `json:"quotient"`
+}
+
+func encodeProof(p *kzg.Proof, format string) ([]byte, error) {
+	return encode(format, p.MarshalEVM(), proofJSON{
+		Curve:    curveName,
+		Z:        fieldHex(new(big.Int).Mod(p.Z, bn256.Order)),
+		Y:        fieldHex(new(big.Int).Mod(p.Y, bn256.Order)),
+		Quotient: newG2JSON(p.Quotient),
+	})
+}
+
+func decodeProof(buf []byte) (*kzg.Proof, error) {
+	var j proofJSON
+	b, err := decode(buf, &j)
+	if err != nil {
+		return nil, err
+	}
+	p := new(kzg.Proof)
+	if b != nil {
+		return p, p.UnmarshalEVM(b)
+	}
+	if err := checkCurve(j.Curve); err != nil {
+		return nil, err
+	}
+	if p.Z, err = parseFieldHex(j.Z, bn256.Order); err != nil {
+		return nil, fmt.Errorf("z: %v", err)
+	}
+	if p.Y, err = parseFieldHex(j.Y, bn256.Order); err != nil {
+		return nil, fmt.Errorf("y: %v", err)
+	}
+	if p.Quotient, err = j.Quotient.point(); err != nil {
+		return nil, fmt.Errorf("quotient: %v", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 2)
+	p := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1})
+	c, err := kzg.Commit(srs, p)
+	if err != nil {
+		t.Fatalf("Commit() error %v", err)
+	}
+	proof, err := kzg.Open(srs, p, big.NewInt(7))
+	if err != nil {
+		t.Fatalf("Open() error %v", err)
+	}
+
+	for _, format := range []string{formatBinary, formatHex, formatJSON} {
+		t.Run(format, func(t *testing.T) {
+			buf, err := encodeCommitment(c, format)
+			if err != nil {
+				t.Fatalf("encodeCommitment() error %v", err)
+			}
+			gotC, err := decodeCommitment(buf)
+			if err != nil {
+				t.Fatalf("decodeCommitment(encodeCommitment()) error %v", err)
+			}
+			if !bytes.Equal(gotC.G1().Marshal(), c.G1().Marshal()) {
+				t.Errorf("decodeCommitment(encodeCommitment()) = %v; want %v", gotC.G1(), c.G1())
+			}
+
+			if buf, err = encodeProof(proof, format); err != nil {
+				t.Fatalf("encodeProof() error %v", err)
+			}
+			gotProof, err := decodeProof(buf)
+			if err != nil {
+				t.Fatalf("decodeProof(encodeProof()) error %v", err)
+			}
+			if !bytes.Equal(gotProof.MarshalEVM(), proof.MarshalEVM()) {
+				t.Errorf("decodeProof(encodeProof()) = %x; want %x", gotProof.MarshalEVM(), proof.MarshalEVM())
+			}
+		})
+	}
+
+	if _, err := encodeProof(proof, "xml"); err == nil {
+		t.Error("encodeProof() in unknown format; want error")
+	}
+}
+
+func TestProofJSONSchema(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 2)
+	proof, err := kzg.Open(srs, polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1}), big.NewInt(7))
+	if err != nil {
+		t.Fatalf("Open() error %v", err)
+	}
+	buf, err := encodeProof(proof, formatJSON)
+	if err != nil {
+		t.Fatalf("encodeProof() error %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("json.Unmarshal(encodeProof()) error %v", err)
+	}
+
+	// The coordinates of the quotient are those of MarshalEVM, c1 first.
+	ws := words(proof.MarshalEVM())
+	want := map[string]interface{}{
+		"curve": "bn256",
+		"z":     ws[0],
+		"y":     ws[1],
+		"quotient": map[string]interface{}{
+			"x": map[string]interface{}{"c1": ws[2], "c0": ws[3]},
+			"y": map[string]interface{}{"c1": ws[4], "c0": ws[5]},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("encodeProof(json) diff (-want +got):\n%s", diff)
+	}
+	if got, want := ws[1], "0x"+string(bytes.Repeat([]byte("0"), 62))+"14"; got != want {
+		t.Errorf("y = %s; want %s", got, want)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"wrong curve", `{"curve": "bls12-381"}`},
+		{"invalid JSON", `{"curve": `},
+		{"invalid hex", "0xzz"},
+		{"short binary", "\x01\x02"},
+		{"unprefixed field value", `{"curve": "bn256", "z": "12", "y": "0x0"}`},
+		{"field value too large", `{"curve": "bn256", "z": "0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001", "y": "0x0"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeProof([]byte(tt.in)); err == nil {
+				t.Errorf("decodeProof(%q) error nil; want error", tt.in)
+			}
+		})
+	}
+}
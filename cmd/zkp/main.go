@@ -0,0 +1,82 @@
+// Command zkp drives the kzg and membership packages from the command line.
+//
+// Usage:
+//
+//	zkp <command> [flags]
+//
+// The commands are:
+//
+//	setup   generate, import or inspect an SRS
+//	commit  commit to a member list
+//	prove   prove that a value is in a member list
+//	verify  verify a membership proof against a commitment
+//	encode-calldata
+//	        ABI-encode a call verifying a proof with a kzg/solgen contract
+//
+// Run zkp <command> -h for the flags of a command.
+//
+// The -format flag of each command selects the encoding of its output: binary,
+// 0x-prefixed hex of the binary encoding, or JSON with a "curve" field, points
+// as objects of affine coordinates, and field values as 0x-prefixed 32-byte
+// hex. G2 coordinates c1*i + c0 are objects of c0 and c1. Inputs are accepted
+// in any format.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// A command runs a subcommand with its arguments, writing its output to
+// stdout.
+type command func(args []string, stdout io.Writer) error
+
+var commands = map[string]command{
+	"setup":           setup,
+	"commit":          commit,
+	"prove":           prove,
+	"verify":          verify,
+	"encode-calldata": encodeCalldata,
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "zkp: %v\n", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run runs the subcommand named by args[0].
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command; want one of %v", commandNames())
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q; want one of %v", args[0], commandNames())
+	}
+	return cmd(args[1:], stdout)
+}
+
+func commandNames() []string {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newFlagSet returns a FlagSet for the subcommand that reports errors instead
+// of exiting.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("zkp "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
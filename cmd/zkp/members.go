@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// parseValue parses a field element in decimal, or in hex with a 0x prefix.
+func parseValue(s string) (*big.Int, error) {
+	x, ok := new(big.Int).SetString(strings.TrimSpace(s), 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid value %q; want decimal or 0x-prefixed hex", s)
+	}
+	return x, nil
+}
+
+// parseMembers parses a member list, either a JSON array of numbers or
+// strings, or one value per line, skipping empty lines and those starting
+// with #.
+func parseMembers(buf []byte) ([]*big.Int, error) {
+	var values []string
+	if trimmed := bytes.TrimLeft(buf, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		var raw []json.RawMessage
+		if err := json.Unmarshal(buf, &raw); err != nil {
+			return nil, fmt.Errorf("decoding JSON members: %v", err)
+		}
+		for _, r := range raw {
+			var s string
+			if err := json.Unmarshal(r, &s); err != nil {
+				s = string(r)
+			}
+			values = append(values, s)
+		}
+	} else {
+		sc := bufio.NewScanner(bytes.NewReader(buf))
+		for sc.Scan() {
+			if line := strings.TrimSpace(sc.Text()); line != "" && !strings.HasPrefix(line, "#") {
+				values = append(values, line)
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	members := make([]*big.Int, len(values))
+	for i, v := range values {
+		x, err := parseValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("member %d: %v", i, err)
+		}
+		members[i] = x
+	}
+	return members, nil
+}
+
+// readMembers reads a member list file in either format of parseMembers.
+func readMembers(path string) ([]*big.Int, error) {
+	return readFile(path, parseMembers)
+}
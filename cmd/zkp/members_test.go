@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []int64
+		wantErr bool
+	}{
+		{"lines", "1\n\n# comment\n  42 \n0x10\n-7\n", []int64{1, 42, 16, -7}, false},
+		{"json numbers", "[1, 42, 16]", []int64{1, 42, 16}, false},
+		{"json strings", ` ["1", "0x2a"]`, []int64{1, 42}, false},
+		{"empty", "", nil, false},
+		{"invalid line", "1\nfoo\n", nil, true},
+		{"invalid json", "[1, ", nil, true},
+		{"fractional json", "[1.5]", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMembers([]byte(tt.in))
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("parseMembers(%q) error %v; want error %t", tt.in, err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseMembers(%q) = %v; want %v", tt.in, got, tt.want)
+			}
+			for i, w := range tt.want {
+				if got[i].Cmp(big.NewInt(w)) != 0 {
+					t.Errorf("parseMembers(%q)[%d] = %v; want %d", tt.in, i, got[i], w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/membership"
+)
+
+// errInvalidProof is returned by verify for proofs that don't verify, so that
+// scripts can rely on the exit status.
+var errInvalidProof = errors.New("invalid proof")
+
+// requireFlags returns an error if any of the named flags wasn't set.
+func requireFlags(fs *flag.FlagSet, names ...string) error {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, name := range names {
+		if !set[name] {
+			return fmt.Errorf("missing required flag -%s", name)
+		}
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	return nil
+}
+
+// writeOutput writes buf to the file at path, or to stdout if path is empty.
+func writeOutput(path string, stdout io.Writer, buf []byte) error {
+	if path == "" {
+		_, err := stdout.Write(buf)
+		return err
+	}
+	return os.WriteFile(path, buf, 0o644)
+}
+
+// committedSet reads the SRS and members and commits to them.
+func committedSet(srsPath, membersPath string) (*membership.Set, error) {
+	srs, err := readSRS(srsPath)
+	if err != nil {
+		return nil, err
+	}
+	members, err := readMembers(membersPath)
+	if err != nil {
+		return nil, err
+	}
+	s := membership.NewSet(members)
+	if _, err := s.Commit(srs); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// commit writes the commitment to a member list.
+func commit(args []string, stdout io.Writer) error {
+	fs := newFlagSet("commit")
+	var (
+		srsPath = fs.String("srs", "", "SRS `file`, as written by zkp setup")
+		members = fs.String("members", "", "member list `file`: one value per line, or a JSON array")
+		out     = fs.String("out", "", "write the commitment to `file` instead of stdout")
+		format  = fs.String("format", formatHex, "format of the commitment: binary (kzg.Commitment.MarshalBinary), hex or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "srs", "members"); err != nil {
+		return err
+	}
+	s, err := committedSet(*srsPath, *members)
+	if err != nil {
+		return err
+	}
+	buf, err := encodeCommitment(s.Commitment(), *format)
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, stdout, buf)
+}
+
+// prove writes the proof that a value is in a member list.
+func prove(args []string, stdout io.Writer) error {
+	fs := newFlagSet("prove")
+	var (
+		srsPath = fs.String("srs", "", "SRS `file`, as written by zkp setup")
+		members = fs.String("members", "", "member list `file`: one value per line, or a JSON array")
+		z       = fs.String("z", "", "the member to prove, in decimal or 0x-prefixed hex")
+		out     = fs.String("out", "", "write the proof to `file` instead of stdout")
+		format  = fs.String("format", formatHex, "format of the proof: binary (kzg.Proof.MarshalEVM), hex or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "srs", "members", "z"); err != nil {
+		return err
+	}
+	member, err := parseValue(*z)
+	if err != nil {
+		return err
+	}
+	s, err := committedSet(*srsPath, *members)
+	if err != nil {
+		return err
+	}
+	proof, err := s.ProveMember(member)
+	if err != nil {
+		return err
+	}
+	buf, err := encodeProof(&kzg.Proof{Z: member, Y: big.NewInt(0), Quotient: proof.Quotient}, *format)
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, stdout, buf)
+}
+
+// verifyJSON is the JSON output of verify.
+type verifyJSON struct {
+	Valid bool   `json:"valid"`
+	Z     string `json:"z"`
+}
+
+// verify verifies a proof file against a commitment file, printing whether
+// it is valid.
+func verify(args []string, stdout io.Writer) error {
+	fs := newFlagSet("verify")
+	var (
+		srsPath    = fs.String("srs", "", "SRS `file`, as written by zkp setup")
+		commitment = fs.String("commitment", "", "commitment `file`, as written by zkp commit")
+		z          = fs.String("z", "", "the claimed member, in decimal or 0x-prefixed hex; defaults to that of the proof")
+		proofPath  = fs.String("proof", "", "proof `file`, as written by zkp prove")
+		format     = fs.String("format", "text", "format of the result: text or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "srs", "commitment", "proof"); err != nil {
+		return err
+	}
+	if *format != "text" && *format != formatJSON {
+		return fmt.Errorf("unknown format %q; want text or json", *format)
+	}
+	srs, err := readSRS(*srsPath)
+	if err != nil {
+		return err
+	}
+	c, err := readFile(*commitment, decodeCommitment)
+	if err != nil {
+		return err
+	}
+	proof, err := readFile(*proofPath, decodeProof)
+	if err != nil {
+		return err
+	}
+	member := proof.Z
+	if *z != "" {
+		if member, err = parseValue(*z); err != nil {
+			return err
+		}
+	}
+
+	// The proof holds its member, which must match the claimed one.
+	ok := proof.Y.Sign() == 0 && canonical(member).Cmp(proof.Z) == 0 &&
+		membership.VerifyMember(srs.VerifierKey(), c, member, &membership.Proof{Quotient: proof.Quotient})
+	if *format == formatJSON {
+		buf, err := encode(formatJSON, nil, verifyJSON{Valid: ok, Z: fieldHex(canonical(member))})
+		if err != nil {
+			return err
+		}
+		if _, err := stdout.Write(buf); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(stdout, ok)
+	}
+	if !ok {
+		return errInvalidProof
+	}
+	return nil
+}
+
+// canonical returns x reduced into kzg.Field.
+func canonical(x *big.Int) *big.Int {
+	return new(big.Int).Mod(x, kzg.Field.Order())
+}
+
+// readFile reads the file at path and decodes its contents.
+func readFile[T any](path string, decode func([]byte) (T, error)) (T, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := decode(buf)
+	if err != nil {
+		return v, fmt.Errorf("%s: %v", path, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommitProveVerify(t *testing.T) {
+	dir := t.TempDir()
+	path := func(name string) string { return filepath.Join(dir, name) }
+	if err := run([]string{"setup", "-degree", "8", "-out", path("srs")}, new(bytes.Buffer)); err != nil {
+		t.Fatalf("run(setup) error %v", err)
+	}
+	if err := os.WriteFile(path("members"), []byte("1\n2\n42\n0x100\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path("members.json"), []byte(`[1, 2, "42", "0x100"]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var fromLines, fromJSON bytes.Buffer
+	if err := run([]string{"commit", "-srs", path("srs"), "-members", path("members")}, &fromLines); err != nil {
+		t.Fatalf("run(commit) error %v", err)
+	}
+	if err := run([]string{"commit", "-srs", path("srs"), "-members", path("members.json"), "-out", path("commitment")}, &fromJSON); err != nil {
+		t.Fatalf("run(commit -out) error %v", err)
+	}
+	written, err := os.ReadFile(path("commitment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(fromLines.Bytes(), written) || fromJSON.Len() != 0 {
+		t.Errorf("run(commit) = %q and written %q; want equal commitments to equal members", fromLines.String(), written)
+	}
+
+	if err := run([]string{"prove", "-srs", path("srs"), "-members", path("members"), "-z", "256", "-out", path("proof")}, new(bytes.Buffer)); err != nil {
+		t.Fatalf("run(prove) error %v", err)
+	}
+	if err := run([]string{"prove", "-srs", path("srs"), "-members", path("members"), "-z", "3"}, new(bytes.Buffer)); err == nil {
+		t.Error("run(prove -z 3) of a non-member; want error")
+	}
+
+	tests := []struct {
+		name string
+		z    string
+		want error
+	}{
+		{"member", "256", nil},
+		{"hex member", "0x100", nil},
+		{"other member", "42", errInvalidProof},
+		{"non-member", "3", errInvalidProof},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := run([]string{"verify", "-srs", path("srs"), "-commitment", path("commitment"), "-z", tt.z, "-proof", path("proof")}, &out)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("run(verify -z %s) error %v; want %v", tt.z, err, tt.want)
+			}
+			if got, want := strings.TrimSpace(out.String()), map[bool]string{true: "true", false: "false"}[tt.want == nil]; got != want {
+				t.Errorf("run(verify -z %s) printed %q; want %q", tt.z, got, want)
+			}
+		})
+	}
+
+	for _, format := range []string{formatBinary, formatHex, formatJSON} {
+		t.Run(format, func(t *testing.T) {
+			c, proof := path("commitment."+format), path("proof."+format)
+			if err := run([]string{"commit", "-srs", path("srs"), "-members", path("members"), "-format", format, "-out", c}, new(bytes.Buffer)); err != nil {
+				t.Fatalf("run(commit -format %s) error %v", format, err)
+			}
+			if err := run([]string{"prove", "-srs", path("srs"), "-members", path("members"), "-z", "42", "-format", format, "-out", proof}, new(bytes.Buffer)); err != nil {
+				t.Fatalf("run(prove -format %s) error %v", format, err)
+			}
+			// The member defaults to that of the proof.
+			var out bytes.Buffer
+			if err := run([]string{"verify", "-srs", path("srs"), "-commitment", c, "-proof", proof, "-format", "json"}, &out); err != nil {
+				t.Fatalf("run(verify) of the %s proof error %v", format, err)
+			}
+			var got verifyJSON
+			if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal(run(verify -format json)) error %v", err)
+			}
+			if want := (verifyJSON{Valid: true, Z: fieldHex(big.NewInt(42))}); got != want {
+				t.Errorf("run(verify -format json) = %+v; want %+v", got, want)
+			}
+		})
+	}
+
+	for _, args := range [][]string{
+		{"commit", "-srs", path("srs")},
+		{"prove", "-srs", path("srs"), "-members", path("members"), "-z", "foo"},
+		{"verify", "-srs", path("srs"), "-commitment", path("proof"), "-z", "1", "-proof", path("proof")},
+		{"verify", "-srs", path("srs"), "-commitment", path("commitment"), "-proof", path("proof"), "-format", "hex"},
+		{"commit", "-srs", path("srs"), "-members", path("members"), "-format", "xml"},
+	} {
+		if err := run(args, new(bytes.Buffer)); err == nil {
+			t.Errorf("run(%q) error nil; want error", args)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"zkp.xyz/membership/kzg"
+)
+
+// setup generates an SRS from OS randomness, imports one from a .ptau
+// ceremony file, or reads an existing one, optionally writes it, and prints
+// its metadata.
+func setup(args []string, stdout io.Writer) error {
+	fs := newFlagSet("setup")
+	var (
+		degree = fs.Int("degree", 0, "maximum degree of the generated SRS, or to trim an imported one to")
+		ptau   = fs.String("ptau", "", "import the SRS from a snarkjs .ptau ceremony `file`")
+		in     = fs.String("in", "", "inspect an existing SRS `file`, in either format")
+		out    = fs.String("out", "", "write the SRS to `file`")
+		format = fs.String("format", "binary", "format of the written SRS: binary (kzg.SRS.MarshalBinary) or json")
+	)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", fs.Args())
+	}
+	if *ptau != "" && *in != "" {
+		return errors.New("-ptau and -in are mutually exclusive")
+	}
+
+	var (
+		srs *kzg.SRS
+		err error
+	)
+	switch {
+	case *ptau != "":
+		srs, err = importPtau(*ptau)
+	case *in != "":
+		srs, err = readSRS(*in)
+	case *degree < 1:
+		return fmt.Errorf("-degree %d; want at least 1 to generate an SRS", *degree)
+	default:
+		srs, err = kzg.Setup(*degree, rand.Reader)
+	}
+	if err != nil {
+		return err
+	}
+	if *degree > 0 && *degree < srs.MaxDegree() {
+		srs = trimSRS(srs, *degree)
+	} else if *degree > srs.MaxDegree() {
+		return fmt.Errorf("-degree %d exceeds the max degree %d of the SRS", *degree, srs.MaxDegree())
+	}
+
+	buf, err := encodeSRS(srs, *format)
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		if err := os.WriteFile(*out, buf, 0o644); err != nil {
+			return err
+		}
+	}
+	return printSRS(stdout, srs, *format)
+}
+
+func importPtau(path string) (*kzg.SRS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	srs, err := kzg.SRSFromPowersOfTau(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if err := srs.Verify(); err != nil {
+		return nil, fmt.Errorf("%s: invalid SRS: %v", path, err)
+	}
+	return srs, nil
+}
+
+// trimSRS returns the SRS restricted to the powers up to degree, which remains
+// valid as all powers are of the same secret. G2 keeps at most degree+1 powers,
+// fewer if the SRS has fewer, as imported from the Ethereum KZG ceremony.
+func trimSRS(srs *kzg.SRS, degree int) *kzg.SRS {
+	m := len(srs.G2)
+	if m > degree+1 {
+		m = degree + 1
+	}
+	t := &kzg.SRS{G1: srs.G1[:degree+1], G2: srs.G2[:m]}
+	if srs.IsHiding() {
+		t.H1, t.H2 = srs.H1[:degree+1], srs.H2[:degree+1]
+	}
+	return t
+}
+
+// srsInfoJSON is the JSON encoding of the metadata of an SRS.
+type srsInfoJSON struct {
+	Curve     string `json:"curve"`
+	MaxDegree int    `json:"max_degree"`
+	Hiding    bool   `json:"hiding"`
+	SHA256    string `json:"sha256"`
+}
+
+// printSRS prints the metadata of the SRS, as JSON for formatJSON and as text
+// otherwise.
+func printSRS(w io.Writer, srs *kzg.SRS, format string) error {
+	h, err := srsHash(srs)
+	if err != nil {
+		return err
+	}
+	if format == formatJSON {
+		buf, err := encode(formatJSON, nil, srsInfoJSON{
+			Curve:     curveName,
+			MaxDegree: srs.MaxDegree(),
+			Hiding:    srs.IsHiding(),
+			SHA256:    fmt.Sprintf("0x%x", h),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buf)
+		return err
+	}
+	_, err = fmt.Fprintf(w, "curve: %s\nmax degree: %d\nhiding: %t\nsha256: %x\n", curveName, srs.MaxDegree(), srs.IsHiding(), h)
+	return err
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/google/go-cmp/cmp"
+	"zkp.xyz/membership/kzg"
+)
+
+func TestSetup(t *testing.T) {
+	dir := t.TempDir()
+	for _, format := range []string{formatBinary, formatHex, formatJSON} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(dir, "srs."+format)
+			var generated bytes.Buffer
+			if err := run([]string{"setup", "-degree", "4", "-format", format, "-out", path}, &generated); err != nil {
+				t.Fatalf("run(setup -out) error %v", err)
+			}
+			want := "max degree: 4\n"
+			if format == formatJSON {
+				want = `"max_degree": 4,`
+			}
+			if !strings.Contains(generated.String(), want) {
+				t.Errorf("run(setup -degree 4) printed %q; want %q", generated.String(), want)
+			}
+
+			var inspected bytes.Buffer
+			if err := run([]string{"setup", "-in", path, "-format", format}, &inspected); err != nil {
+				t.Fatalf("run(setup -in) error %v", err)
+			}
+			if diff := cmp.Diff(generated.String(), inspected.String()); diff != "" {
+				t.Errorf("run(setup -in) diff (-generated +inspected):\n%s", diff)
+			}
+
+			var trimmed bytes.Buffer
+			if err := run([]string{"setup", "-in", path, "-degree", "2"}, &trimmed); err != nil {
+				t.Fatalf("run(setup -in -degree 2) error %v", err)
+			}
+			if !strings.Contains(trimmed.String(), "max degree: 2\n") {
+				t.Errorf("run(setup -in -degree 2) printed %q; want max degree 2", trimmed.String())
+			}
+		})
+	}
+}
+
+// writePtau writes a .ptau file of 1<<power powers of s in tauG1 and the first
+// g2Powers in tauG2, in the format read by kzg.SRSFromPowersOfTau.
+func writePtau(t *testing.T, path string, s *big.Int, power, g2Powers int) {
+	t.Helper()
+	u32 := func(x int) []byte {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(x))
+		return b
+	}
+	le := func(x *big.Int) []byte {
+		b := x.FillBytes(make([]byte, 32))
+		for j := 0; j < 16; j++ {
+			b[j], b[31-j] = b[31-j], b[j]
+		}
+		return b
+	}
+	// lem returns the little-endian Montgomery encodings of the big-endian
+	// coordinates in be.
+	lem := func(be []byte) []byte {
+		r := new(big.Int).Lsh(big.NewInt(1), 256)
+		var out []byte
+		for i := 0; i < len(be); i += 32 {
+			x := new(big.Int).SetBytes(be[i : i+32])
+			out = append(out, le(x.Mul(x, r).Mod(x, bn256.P))...)
+		}
+		return out
+	}
+	section := func(typ int, data []byte) []byte {
+		b := make([]byte, 12, 12+len(data))
+		binary.LittleEndian.PutUint32(b, uint32(typ))
+		binary.LittleEndian.PutUint64(b[4:], uint64(len(data)))
+		return append(b, data...)
+	}
+
+	header := append(u32(32), le(bn256.P)...)
+	header = append(append(header, u32(power)...), u32(power)...)
+	var tauG1, tauG2 []byte
+	x := big.NewInt(1)
+	for i := 0; i < 2<<power-1; i++ {
+		tauG1 = append(tauG1, lem(new(bn256.G1).ScalarBaseMult(x).Marshal())...)
+		if i < g2Powers {
+			// bn256 encodes the imaginary part first.
+			be := new(bn256.G2).ScalarBaseMult(x).Marshal()
+			tauG2 = append(tauG2, lem(append(append(append(append([]byte{}, be[32:64]...), be[:32]...), be[96:]...), be[64:96]...))...)
+		}
+		x = new(big.Int).Mod(x.Mul(x, s), bn256.Order)
+	}
+
+	buf := append(append([]byte("ptau"), u32(1)...), u32(3)...)
+	buf = append(buf, section(1, header)...)
+	buf = append(buf, section(2, tauG1)...)
+	buf = append(buf, section(3, tauG2)...)
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSetupPtauFewerG2 imports a .ptau file with fewer powers in G2 than in
+// G1, as from the Ethereum KZG ceremony, with and without trimming.
+func TestSetupPtauFewerG2(t *testing.T) {
+	dir := t.TempDir()
+	ptau := filepath.Join(dir, "ceremony.ptau")
+	writePtau(t, ptau, big.NewInt(1337), 3, 3)
+
+	tests := []struct {
+		degree         int
+		wantG1, wantG2 int
+	}{
+		{degree: 0, wantG1: 8, wantG2: 3},
+		{degree: 5, wantG1: 6, wantG2: 3},
+		{degree: 1, wantG1: 2, wantG2: 2},
+	}
+	for _, tt := range tests {
+		for _, format := range []string{formatBinary, formatJSON} {
+			path := filepath.Join(dir, "srs."+format)
+			args := []string{"setup", "-ptau", ptau, "-degree", strconv.Itoa(tt.degree), "-format", format, "-out", path}
+			var imported bytes.Buffer
+			if err := run(args, &imported); err != nil {
+				t.Fatalf("run(%q) error %v", args, err)
+			}
+
+			srs, err := readSRS(path)
+			if err != nil {
+				t.Fatalf("readSRS(<setup -degree %d -format %s>) error %v", tt.degree, format, err)
+			}
+			if len(srs.G1) != tt.wantG1 || len(srs.G2) != tt.wantG2 {
+				t.Errorf("setup -degree %d -format %s wrote %d and %d powers in G1 and G2; want %d and %d", tt.degree, format, len(srs.G1), len(srs.G2), tt.wantG1, tt.wantG2)
+			}
+
+			var inspected bytes.Buffer
+			if err := run([]string{"setup", "-in", path, "-format", format}, &inspected); err != nil {
+				t.Fatalf("run(setup -in) error %v", err)
+			}
+			if diff := cmp.Diff(imported.String(), inspected.String()); diff != "" {
+				t.Errorf("run(setup -in) diff (-imported +inspected):\n%s", diff)
+			}
+		}
+	}
+}
+
+func TestSRSFormats(t *testing.T) {
+	plain := kzg.NewSRS(big.NewInt(1337), 3)
+	hiding := kzg.NewHidingSRS(big.NewInt(1337), big.NewInt(42), 3)
+	for _, srs := range []*kzg.SRS{plain, hiding} {
+		for _, format := range []string{formatBinary, formatHex, formatJSON} {
+			buf, err := encodeSRS(srs, format)
+			if err != nil {
+				t.Fatalf("encodeSRS(%s) error %v", format, err)
+			}
+			got, err := decodeSRS(buf)
+			if err != nil {
+				t.Fatalf("decodeSRS(encodeSRS(%s)) error %v", format, err)
+			}
+			want, _ := srs.MarshalBinary()
+			if gotBuf, _ := got.MarshalBinary(); !bytes.Equal(gotBuf, want) {
+				t.Errorf("decodeSRS(encodeSRS(%s, hiding %t)) differs", format, srs.IsHiding())
+			}
+		}
+	}
+}
+
+func TestSetupErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "srs")
+	if err := run([]string{"setup", "-degree", "2", "-out", path}, new(bytes.Buffer)); err != nil {
+		t.Fatalf("run(setup) error %v", err)
+	}
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"curve": "bls12-381", "g1": [], "g2": []}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mixed := &kzg.SRS{G1: kzg.NewSRS(big.NewInt(1337), 2).G1, G2: kzg.NewSRS(big.NewInt(42), 2).G2}
+	buf, err := encodeSRS(mixed, formatBinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := filepath.Join(dir, "tampered")
+	if err := os.WriteFile(tampered, buf, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no command", nil},
+		{"tampered SRS", []string{"setup", "-in", tampered}},
+		{"unknown command", []string{"frobnicate"}},
+		{"no degree", []string{"setup"}},
+		{"degree too large", []string{"setup", "-in", path, "-degree", "3"}},
+		{"both sources", []string{"setup", "-in", path, "-ptau", path}},
+		{"unknown format", []string{"setup", "-degree", "2", "-format", "xml", "-out", filepath.Join(dir, "out")}},
+		{"missing file", []string{"setup", "-in", filepath.Join(dir, "missing")}},
+		{"wrong curve", []string{"setup", "-in", bad}},
+		{"not ptau", []string{"setup", "-ptau", path}},
+		{"extra argument", []string{"setup", "-degree", "2", "extra"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(tt.args, new(bytes.Buffer)); err == nil {
+				t.Errorf("run(%q) error nil; want error", tt.args)
+			}
+		})
+	}
+}
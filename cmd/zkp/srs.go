@@ -0,0 +1,131 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+)
+
+// curveName is the name of the curve of all points, as used in files.
+const curveName = "bn256"
+
+// srsJSON is the JSON encoding of an SRS, with G2 possibly holding fewer
+// points than G1, and H1 and H2 only present for hiding SRSs. Its binary
+// encoding is that of kzg.SRS.MarshalBinary.
+type srsJSON struct {
+	Curve string   `json:"curve"`
+	G1    []g1JSON `json:"g1"`
+	G2    []g2JSON `json:"g2"`
+	H1    []g1JSON `json:"h1,omitempty"`
+	H2    []g2JSON `json:"h2,omitempty"`
+}
+
+func g1sJSON(ps []*bn256.G1) []g1JSON {
+	var js []g1JSON
+	for _, p := range ps {
+		js = append(js, newG1JSON(p))
+	}
+	return js
+}
+
+func g2sJSON(ps []*bn256.G2) []g2JSON {
+	var js []g2JSON
+	for _, p := range ps {
+		js = append(js, newG2JSON(p))
+	}
+	return js
+}
+
+// points decodes the JSON points.
+func points[J interface{ point() (P, error) }, P any](name string, js []J) ([]P, error) {
+	var ps []P
+	for i, j := range js {
+		p, err := j.point()
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %v", name, i, err)
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// encodeSRS encodes the SRS in the format.
+func encodeSRS(srs *kzg.SRS, format string) ([]byte, error) {
+	buf, err := srs.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return encode(format, buf, srsJSON{
+		Curve: curveName,
+		G1:    g1sJSON(srs.G1),
+		G2:    g2sJSON(srs.G2),
+		H1:    g1sJSON(srs.H1),
+		H2:    g2sJSON(srs.H2),
+	})
+}
+
+// decodeSRS decodes an SRS in any format of encodeSRS.
+func decodeSRS(buf []byte) (*kzg.SRS, error) {
+	var j srsJSON
+	b, err := decode(buf, &j)
+	if err != nil {
+		return nil, err
+	}
+	if b != nil {
+		srs := new(kzg.SRS)
+		if err := srs.UnmarshalBinary(b); err != nil {
+			return nil, err
+		}
+		return srs, nil
+	}
+
+	if err := checkCurve(j.Curve); err != nil {
+		return nil, err
+	}
+	hiding := len(j.H1) > 0 || len(j.H2) > 0
+	if len(j.G2) == 0 || len(j.G2) > len(j.G1) || hiding && (len(j.G2) != len(j.G1) || len(j.H1) != len(j.G1) || len(j.H2) != len(j.G1)) {
+		return nil, fmt.Errorf("SRS with %d, %d, %d and %d points in g1, g2, h1 and h2; want non-zero counts, at most as many in g2 as in g1, and equal counts or no h1 and h2", len(j.G1), len(j.G2), len(j.H1), len(j.H2))
+	}
+	var srs kzg.SRS
+	if srs.G1, err = points[g1JSON, *bn256.G1]("g1", j.G1); err != nil {
+		return nil, err
+	}
+	if srs.G2, err = points[g2JSON, *bn256.G2]("g2", j.G2); err != nil {
+		return nil, err
+	}
+	if srs.H1, err = points[g1JSON, *bn256.G1]("h1", j.H1); err != nil {
+		return nil, err
+	}
+	if srs.H2, err = points[g2JSON, *bn256.G2]("h2", j.H2); err != nil {
+		return nil, err
+	}
+	return &srs, nil
+}
+
+// readSRS reads an SRS file in any format of encodeSRS, rejecting it unless
+// it passes kzg.SRS.Verify.
+func readSRS(path string) (*kzg.SRS, error) {
+	return readFile(path, func(buf []byte) (*kzg.SRS, error) {
+		srs, err := decodeSRS(buf)
+		if err != nil {
+			return nil, err
+		}
+		if err := srs.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid SRS: %v", err)
+		}
+		return srs, nil
+	})
+}
+
+// srsHash returns the SHA-256 hash of the binary encoding of the SRS, which
+// identifies it independently of the file format.
+func srsHash(srs *kzg.SRS) ([]byte, error) {
+	buf, err := srs.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(buf)
+	return h[:], nil
+}
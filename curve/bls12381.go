@@ -0,0 +1,200 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+	"zkp.xyz/membership/galois"
+)
+
+// BLS12381 is the BLS12-381 curve of go-ethereum's crypto/bls12381 package.
+// Points are marshalled as the uncompressed concatenation of their affine
+// coordinates, with the point at infinity encoded as zeros. As marshalling and
+// pairing normalise coordinates in place, Points are not safe for concurrent
+// use even if only read.
+var BLS12381 Suite = bls12381Suite{}
+
+// bls12381Order is the order of the BLS12-381 groups.
+var bls12381Order = bls12381.NewG1().Q()
+
+// bls12381Field is the scalar field of BLS12-381.
+var bls12381Field = newScalarField(bls12381Order)
+
+type bls12381Suite struct{}
+
+func (bls12381Suite) Name() string {
+	return "BLS12-381"
+}
+
+func (bls12381Suite) Order() *big.Int {
+	return new(big.Int).Set(bls12381Order)
+}
+
+func (bls12381Suite) ScalarField() *galois.Field {
+	return bls12381Field
+}
+
+func (bls12381Suite) G1() Group {
+	return blsGroup[bls12381.PointG1, *bls12381.G1]{bls12381.NewG1}
+}
+
+func (bls12381Suite) G2() Group {
+	return blsGroup[bls12381.PointG2, *bls12381.G2]{bls12381.NewG2}
+}
+
+func (bls12381Suite) Pair(a, b Point) GT {
+	e := bls12381.NewPairingEngine()
+	e.AddPair(blsG1(a), blsG2(b))
+	return &blsGT{e.Result()}
+}
+
+func (bls12381Suite) PairingCheck(a, b []Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	e := bls12381.NewPairingEngine()
+	for i := range a {
+		e.AddPair(blsG1(a[i]), blsG2(b[i]))
+	}
+	return e.Check()
+}
+
+func blsG1(a Point) *bls12381.PointG1 {
+	return a.(*blsPoint[bls12381.PointG1, *bls12381.G1]).p
+}
+
+func blsG2(a Point) *bls12381.PointG2 {
+	return a.(*blsPoint[bls12381.PointG2, *bls12381.G2]).p
+}
+
+// blsArithmetic is the common interface of *bls12381.G1 and *bls12381.G2,
+// which perform arithmetic on points P.
+type blsArithmetic[P any] interface {
+	New() *P
+	Zero() *P
+	One() *P
+	Add(r, a, b *P) *P
+	Neg(r, a *P) *P
+	MulScalar(r, a *P, e *big.Int) *P
+	MultiExp(r *P, points []*P, scalars []*big.Int) (*P, error)
+	Equal(a, b *P) bool
+	ToBytes(a *P) []byte
+	FromBytes(b []byte) (*P, error)
+	InCorrectSubgroup(a *P) bool
+}
+
+// A blsGroup creates Points, each with its own arithmetic as the latter holds
+// scratch space and isn't safe for concurrent use.
+type blsGroup[P any, A blsArithmetic[P]] struct {
+	newArithmetic func() A
+}
+
+func (g blsGroup[P, A]) point(p func(A) *P) *blsPoint[P, A] {
+	a := g.newArithmetic()
+	return &blsPoint[P, A]{a, p(a)}
+}
+
+func (g blsGroup[P, A]) New() Point {
+	return g.point(A.Zero)
+}
+
+func (g blsGroup[P, A]) Generator() Point {
+	return g.point(A.One)
+}
+
+func (g blsGroup[P, A]) MultiExp(points []Point, scalars []*big.Int) (Point, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("len(points) != len(scalars): %d != %d", len(points), len(scalars))
+	}
+	ps, ss := make([]*P, len(points)), make([]*big.Int, len(scalars))
+	for i := range points {
+		ps[i] = points[i].(*blsPoint[P, A]).p
+		// MultiExp replaces the scalars in place.
+		ss[i] = new(big.Int).Mod(scalars[i], bls12381Order)
+	}
+	r := g.point(A.Zero)
+	if _, err := r.a.MultiExp(r.p, ps, ss); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+type blsPoint[P any, A blsArithmetic[P]] struct {
+	a A
+	p *P
+}
+
+func (e *blsPoint[P, A]) of(a Point) *P {
+	return a.(*blsPoint[P, A]).p
+}
+
+func (e *blsPoint[P, A]) Set(a Point) Point {
+	p := e.a.New()
+	*p = *e.of(a)
+	e.p = p
+	return e
+}
+
+func (e *blsPoint[P, A]) Add(a, b Point) Point {
+	e.p = e.a.Add(e.a.New(), e.of(a), e.of(b))
+	return e
+}
+
+func (e *blsPoint[P, A]) Neg(a Point) Point {
+	e.p = e.a.Neg(e.a.New(), e.of(a))
+	return e
+}
+
+func (e *blsPoint[P, A]) ScalarMult(a Point, scalar *big.Int) Point {
+	e.p = e.a.MulScalar(e.a.New(), e.of(a), new(big.Int).Mod(scalar, bls12381Order))
+	return e
+}
+
+func (e *blsPoint[P, A]) ScalarBaseMult(scalar *big.Int) Point {
+	e.p = e.a.MulScalar(e.a.New(), e.a.One(), new(big.Int).Mod(scalar, bls12381Order))
+	return e
+}
+
+func (e *blsPoint[P, A]) Equal(b Point) bool {
+	return e.a.Equal(e.p, e.of(b))
+}
+
+func (e *blsPoint[P, A]) Marshal() []byte {
+	return e.a.ToBytes(e.p)
+}
+
+func (e *blsPoint[P, A]) Unmarshal(b []byte) error {
+	p, err := e.a.FromBytes(b)
+	if err != nil {
+		return err
+	}
+	if !e.a.InCorrectSubgroup(p) {
+		return fmt.Errorf("point not in prime-order subgroup")
+	}
+	e.p = p
+	return nil
+}
+
+func (e *blsPoint[P, A]) String() string {
+	return fmt.Sprintf("%x", e.Marshal())
+}
+
+type blsGT struct {
+	e *bls12381.E
+}
+
+func (e *blsGT) Add(a, b GT) GT {
+	r := bls12381.NewGT().New()
+	bls12381.NewGT().Mul(r, a.(*blsGT).e, b.(*blsGT).e)
+	e.e = r
+	return e
+}
+
+func (e *blsGT) Equal(b GT) bool {
+	return e.e.Equal(b.(*blsGT).e)
+}
+
+func (e *blsGT) Marshal() []byte {
+	return bls12381.NewGT().ToBytes(e.e)
+}
@@ -0,0 +1,169 @@
+package curve
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+)
+
+// BN256 is the bn256 curve of go-ethereum's crypto/bn256/cloudflare package.
+var BN256 Suite = bn256Suite{}
+
+// bn256Field is the scalar field of bn256.
+var bn256Field = newScalarField(bn256.Order)
+
+type bn256Suite struct{}
+
+func (bn256Suite) Name() string {
+	return "bn256"
+}
+
+func (bn256Suite) Order() *big.Int {
+	return new(big.Int).Set(bn256.Order)
+}
+
+func (bn256Suite) ScalarField() *galois.Field {
+	return bn256Field
+}
+
+func (bn256Suite) G1() Group {
+	return bn256Group[bn256.G1, *bn256.G1]{}
+}
+
+func (bn256Suite) G2() Group {
+	return bn256Group[bn256.G2, *bn256.G2]{}
+}
+
+func (bn256Suite) Pair(a, b Point) GT {
+	return &bn256GT{bn256.Pair(
+		a.(*bn256Point[bn256.G1, *bn256.G1]).p,
+		b.(*bn256Point[bn256.G2, *bn256.G2]).p,
+	)}
+}
+
+func (bn256Suite) PairingCheck(a, b []Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	g1s, g2s := make([]*bn256.G1, len(a)), make([]*bn256.G2, len(b))
+	for i := range a {
+		g1s[i] = a[i].(*bn256Point[bn256.G1, *bn256.G1]).p
+		g2s[i] = b[i].(*bn256Point[bn256.G2, *bn256.G2]).p
+	}
+	return bn256.PairingCheck(g1s, g2s)
+}
+
+// bn256Element is the common interface of *bn256.G1 and *bn256.G2.
+type bn256Element[E, P any] interface {
+	polynomial.PointerGroupElement[E, P]
+	Neg(a P) P
+	Marshal() []byte
+	Unmarshal([]byte) ([]byte, error)
+}
+
+type bn256Group[E any, P bn256Element[E, P]] struct{}
+
+func (bn256Group[E, P]) New() Point {
+	return &bn256Point[E, P]{P(new(E)).ScalarBaseMult(new(big.Int))}
+}
+
+func (bn256Group[E, P]) Generator() Point {
+	return &bn256Point[E, P]{P(new(E)).ScalarBaseMult(big.NewInt(1))}
+}
+
+func (bn256Group[E, P]) MultiExp(points []Point, scalars []*big.Int) (Point, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("len(points) != len(scalars): %d != %d", len(points), len(scalars))
+	}
+	ps, ss := make([]P, len(points)), make([]*big.Int, len(scalars))
+	for i := range points {
+		ps[i] = points[i].(*bn256Point[E, P]).p
+		ss[i] = new(big.Int).Mod(scalars[i], bn256.Order)
+	}
+	p, err := msm.MultiExp(ps, ss)
+	if err != nil {
+		return nil, err
+	}
+	return &bn256Point[E, P]{p}, nil
+}
+
+type bn256Point[E any, P bn256Element[E, P]] struct {
+	p P
+}
+
+func (e *bn256Point[E, P]) of(a Point) P {
+	return a.(*bn256Point[E, P]).p
+}
+
+func (e *bn256Point[E, P]) Set(a Point) Point {
+	e.p = P(new(E)).Set(e.of(a))
+	return e
+}
+
+func (e *bn256Point[E, P]) Add(a, b Point) Point {
+	e.p = P(new(E)).Add(e.of(a), e.of(b))
+	return e
+}
+
+func (e *bn256Point[E, P]) Neg(a Point) Point {
+	e.p = P(new(E)).Neg(e.of(a))
+	return e
+}
+
+// ScalarMult reduces the scalar as bn256.G2 doesn't support negative scalars.
+func (e *bn256Point[E, P]) ScalarMult(a Point, scalar *big.Int) Point {
+	e.p = P(new(E)).ScalarMult(e.of(a), new(big.Int).Mod(scalar, bn256.Order))
+	return e
+}
+
+func (e *bn256Point[E, P]) ScalarBaseMult(scalar *big.Int) Point {
+	e.p = P(new(E)).ScalarBaseMult(new(big.Int).Mod(scalar, bn256.Order))
+	return e
+}
+
+func (e *bn256Point[E, P]) Equal(b Point) bool {
+	return bytes.Equal(e.Marshal(), b.Marshal())
+}
+
+func (e *bn256Point[E, P]) Marshal() []byte {
+	return e.p.Marshal()
+}
+
+func (e *bn256Point[E, P]) Unmarshal(b []byte) error {
+	p := P(new(E))
+	rest, err := p.Unmarshal(b)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("%d trailing bytes", len(rest))
+	}
+	e.p = p
+	return nil
+}
+
+func (e *bn256Point[E, P]) String() string {
+	return fmt.Sprint(e.p)
+}
+
+type bn256GT struct {
+	gt *bn256.GT
+}
+
+func (e *bn256GT) Add(a, b GT) GT {
+	e.gt = new(bn256.GT).Add(a.(*bn256GT).gt, b.(*bn256GT).gt)
+	return e
+}
+
+func (e *bn256GT) Equal(b GT) bool {
+	return bytes.Equal(e.Marshal(), b.Marshal())
+}
+
+func (e *bn256GT) Marshal() []byte {
+	return e.gt.Marshal()
+}
@@ -0,0 +1,88 @@
+// Package curve abstracts the pairing-friendly elliptic curves over which
+// commitments can be computed, with backends for bn256 (alt_bn128, as
+// supported by Ethereum precompiles) and BLS12-381 (as used by EIP-4844).
+//
+// Points of different Groups and Suites MUST NOT be mixed; doing so panics.
+package curve
+
+import (
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// A Suite is a pairing-friendly curve, consisting of two groups G1 and G2 of
+// prime order, and a bilinear pairing e: G1 x G2 -> GT.
+type Suite interface {
+	// Name returns a human-readable name of the curve.
+	Name() string
+	// Order returns the order of G1, G2 and GT, i.e. the order of the scalar
+	// field.
+	Order() *big.Int
+	// ScalarField returns the scalar field of order Order(), which is shared
+	// between callers.
+	ScalarField() *galois.Field
+	G1() Group
+	G2() Group
+	// HashToG1 returns the hash of the message to G1 with the domain
+	// separation tag, of at most 255 bytes, as a Point with unknown discrete
+	// logarithm.
+	HashToG1(dst, msg []byte) Point
+	// Pair returns e(a, b) for a in G1 and b in G2.
+	Pair(a, b Point) GT
+	// PairingCheck reports whether the product of e(a[i], b[i]) is the
+	// identity of GT. It returns false if the lengths differ.
+	PairingCheck(a, b []Point) bool
+}
+
+// A Group is G1 or G2 of a Suite.
+type Group interface {
+	// New returns the identity of the Group.
+	New() Point
+	// Generator returns the generator of the Group.
+	Generator() Point
+	// MultiExp returns sum_i scalars[i]*points[i].
+	MultiExp(points []Point, scalars []*big.Int) (Point, error)
+}
+
+// A Point is an element of a Group. As with the bn256 group elements, methods
+// set the receiver to the result and return it. Scalars may be negative or
+// exceed the order. The zero value is not usable; use Group.New.
+//
+// Point implements polynomial.GroupElement[Point], so polynomials can be
+// evaluated on hidden powers with polynomial.EvaluateOnPowersFunc and
+// Group.New.
+type Point interface {
+	Set(a Point) Point
+	Add(a, b Point) Point
+	Neg(a Point) Point
+	ScalarMult(a Point, scalar *big.Int) Point
+	// ScalarBaseMult sets the receiver to scalar times the Group's generator.
+	ScalarBaseMult(scalar *big.Int) Point
+	Equal(b Point) bool
+	// Marshal returns the uncompressed encoding of the Point; the encoding
+	// is specific to the backend.
+	Marshal() []byte
+	// Unmarshal sets the receiver to the Point encoded by Marshal, returning
+	// an error if the encoding is invalid or the Point is not in the Group.
+	Unmarshal(b []byte) error
+}
+
+// A GT is an element of the target group of a pairing. The group operation is
+// written additively, as for bn256.GT.
+type GT interface {
+	Add(a, b GT) GT
+	Equal(b GT) bool
+	Marshal() []byte
+}
+
+// newScalarField returns the field of the specified order with the
+// galois.Montgomery256 strategy.
+func newScalarField(order *big.Int) *galois.Field {
+	f, err := galois.NewFieldWithStrategy(order, galois.Montgomery256)
+	if err != nil {
+		// The orders of supported Suites are odd primes of at most 256 bits.
+		panic(err)
+	}
+	return f
+}
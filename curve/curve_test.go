@@ -0,0 +1,209 @@
+package curve
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+var suites = []Suite{BN256, BLS12381}
+
+var _ polynomial.GroupElement[Point] = Point(nil)
+
+func randomScalar(t *testing.T, s Suite) *big.Int {
+	t.Helper()
+	k, err := rand.Int(rand.Reader, s.Order())
+	if err != nil {
+		t.Fatalf("rand.Int(): %v", err)
+	}
+	return k
+}
+
+func groups(s Suite) map[string]Group {
+	return map[string]Group{"G1": s.G1(), "G2": s.G2()}
+}
+
+func TestGroupArithmetic(t *testing.T) {
+	for _, s := range suites {
+		for name, g := range groups(s) {
+			t.Run(fmt.Sprintf("%s/%s", s.Name(), name), func(t *testing.T) {
+				a, b := randomScalar(t, s), randomScalar(t, s)
+				aG := g.New().ScalarBaseMult(a)
+				bG := g.New().ScalarBaseMult(b)
+				sum := new(big.Int).Add(a, b)
+
+				if got, want := g.New().Add(aG, bG), g.New().ScalarBaseMult(sum); !got.Equal(want) {
+					t.Errorf("[a]+[b] != [a+b]")
+				}
+				if got, want := g.New().ScalarMult(aG, b), g.New().ScalarMult(bG, a); !got.Equal(want) {
+					t.Errorf("b*[a] != a*[b]")
+				}
+				if got := g.New().Add(aG, g.New().Neg(aG)); !got.Equal(g.New()) {
+					t.Errorf("[a] + -[a] != identity")
+				}
+				if got, want := g.New().ScalarBaseMult(big.NewInt(-1)), g.New().Neg(g.Generator()); !got.Equal(want) {
+					t.Errorf("ScalarBaseMult(-1) != Neg(Generator())")
+				}
+				if got, want := g.New().ScalarBaseMult(new(big.Int).Add(a, s.Order())), aG; !got.Equal(want) {
+					t.Errorf("ScalarBaseMult(a + Order()) != ScalarBaseMult(a)")
+				}
+				if cp := g.New().Set(aG); !cp.Equal(aG) || cp.Equal(bG) {
+					t.Errorf("Set() copy mismatch")
+				}
+				if g.Generator().Equal(g.New()) {
+					t.Errorf("Generator() == identity")
+				}
+			})
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	for _, s := range suites {
+		for name, g := range groups(s) {
+			t.Run(fmt.Sprintf("%s/%s", s.Name(), name), func(t *testing.T) {
+				for _, p := range []Point{g.New(), g.Generator(), g.New().ScalarBaseMult(randomScalar(t, s))} {
+					got := g.New()
+					if err := got.Unmarshal(p.Marshal()); err != nil {
+						t.Fatalf("Unmarshal(Marshal()): %v", err)
+					}
+					if !got.Equal(p) {
+						t.Errorf("Unmarshal(Marshal(%v)) = %v", p, got)
+					}
+				}
+
+				buf := g.Generator().Marshal()
+				for _, b := range [][]byte{buf[1:], append(buf, 0)} {
+					if err := g.New().Unmarshal(b); err == nil {
+						t.Errorf("Unmarshal(<%d bytes>): got nil error", len(b))
+					}
+				}
+				buf[len(buf)-1] ^= 1
+				if err := g.New().Unmarshal(buf); err == nil {
+					t.Errorf("Unmarshal(<point not on curve>): got nil error")
+				}
+			})
+		}
+	}
+}
+
+func TestPairing(t *testing.T) {
+	for _, s := range suites {
+		t.Run(s.Name(), func(t *testing.T) {
+			a, b := randomScalar(t, s), randomScalar(t, s)
+			ab := new(big.Int).Mul(a, b)
+			g1, g2 := s.G1(), s.G2()
+
+			lhs := s.Pair(g1.New().ScalarBaseMult(a), g2.New().ScalarBaseMult(b))
+			if rhs := s.Pair(g1.New().ScalarBaseMult(ab), g2.Generator()); !lhs.Equal(rhs) {
+				t.Errorf("e([a]_1, [b]_2) != e([ab]_1, [1]_2)")
+			}
+			if rhs := s.Pair(g1.Generator(), g2.Generator()); lhs.Equal(rhs) {
+				t.Errorf("e([a]_1, [b]_2) == e([1]_1, [1]_2)")
+			}
+			sum := s.Pair(g1.Generator(), g2.Generator())
+			sum.Add(sum, sum)
+			if want := s.Pair(g1.New().ScalarBaseMult(big.NewInt(2)), g2.Generator()); !sum.Equal(want) {
+				t.Errorf("e(1, 1) + e(1, 1) != e(2, 1)")
+			}
+
+			tests := []struct {
+				name string
+				a, b []Point
+				want bool
+			}{
+				{
+					name: "e([a]_1, [b]_2) * e(-[ab]_1, [1]_2)",
+					a:    []Point{g1.New().ScalarBaseMult(a), g1.New().Neg(g1.New().ScalarBaseMult(ab))},
+					b:    []Point{g2.New().ScalarBaseMult(b), g2.Generator()},
+					want: true,
+				},
+				{
+					name: "e([a]_1, [b]_2) * e(-[a]_1, [1]_2)",
+					a:    []Point{g1.New().ScalarBaseMult(a), g1.New().Neg(g1.New().ScalarBaseMult(a))},
+					b:    []Point{g2.New().ScalarBaseMult(b), g2.Generator()},
+					want: false,
+				},
+				{
+					name: "length mismatch",
+					a:    []Point{g1.New()},
+					b:    []Point{},
+					want: false,
+				},
+			}
+			for _, tt := range tests {
+				if got := s.PairingCheck(tt.a, tt.b); got != tt.want {
+					t.Errorf("PairingCheck(%s) = %t; want %t", tt.name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMultiExp(t *testing.T) {
+	for _, s := range suites {
+		f := s.ScalarField()
+		for name, g := range groups(s) {
+			t.Run(fmt.Sprintf("%s/%s", s.Name(), name), func(t *testing.T) {
+				for _, n := range []int{0, 1, 20} {
+					x := randomScalar(t, s)
+					var powers []Point
+					for _, y := range polynomial.ComputePowers(x, n, f) {
+						powers = append(powers, g.New().ScalarBaseMult(y))
+					}
+					p, err := polynomial.Random(n, f, rand.Reader)
+					if err != nil {
+						t.Fatalf("Random(): %v", err)
+					}
+					coeffs := (*p)[:n]
+					if n > 0 {
+						coeffs[0] = big.NewInt(-1)
+					}
+
+					got, err := g.MultiExp(powers, coeffs)
+					if err != nil {
+						t.Fatalf("MultiExp(): %v", err)
+					}
+					want := g.New()
+					if n > 0 {
+						want.ScalarBaseMult(polynomial.NewPolynomial(coeffs).Evaluate(x, f))
+					}
+					if !got.Equal(want) {
+						t.Errorf("MultiExp(<%d powers>) != [p(x)]", n)
+					}
+					if n == 0 {
+						continue
+					}
+					eval, err := polynomial.EvaluateOnPowersFunc(polynomial.NewPolynomial(coeffs), powers, g.New)
+					if err != nil {
+						t.Fatalf("EvaluateOnPowersFunc(): %v", err)
+					}
+					if !eval.Equal(want) {
+						t.Errorf("EvaluateOnPowersFunc(<%d powers>) != [p(x)]", n)
+					}
+				}
+
+				if _, err := g.MultiExp([]Point{g.New()}, nil); err == nil {
+					t.Errorf("MultiExp(<1 point>, <0 scalars>): got nil error")
+				}
+			})
+		}
+	}
+}
+
+func TestScalarField(t *testing.T) {
+	for _, s := range suites {
+		t.Run(s.Name(), func(t *testing.T) {
+			f := s.ScalarField()
+			if f.Order().Cmp(s.Order()) != 0 {
+				t.Errorf("ScalarField().Order() = %v; want %v", f.Order(), s.Order())
+			}
+			if f != s.ScalarField() {
+				t.Error("ScalarField() returned distinct Fields; want shared Field")
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package curve
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// expandMessageXMD is expand_message_xmd of RFC 9380, section 5.3.1, with
+// SHA-256, returning n <= 255*32 pseudorandom bytes from the message and the
+// domain separation tag of at most 255 bytes.
+func expandMessageXMD(msg, dst []byte, n int) []byte {
+	const b = sha256.Size
+	ell := (n + b - 1) / b
+	dstPrime := append(append([]byte(nil), dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, sha256.BlockSize))
+	h.Write(msg)
+	h.Write([]byte{byte(n >> 8), byte(n), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+	out := append(make([]byte, 0, ell*b), bi...)
+	for i := 2; i <= ell; i++ {
+		x := make([]byte, b)
+		for j := range x {
+			x[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(x)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:n]
+}
+
+// hashToField is hash_to_field of RFC 9380, section 5.2, returning count
+// elements of the prime field of order p, each from L bytes of
+// expand_message_xmd.
+func hashToField(msg, dst []byte, p *big.Int, count, L int) []*big.Int {
+	b := expandMessageXMD(msg, dst, count*L)
+	us := make([]*big.Int, count)
+	for i := range us {
+		us[i] = new(big.Int).SetBytes(b[i*L : (i+1)*L])
+		us[i].Mod(us[i], p)
+	}
+	return us
+}
+
+// HashToG1 returns the hash of the message to G1 of BLS12-381, the suite
+// BLS12381G1_XMD:SHA-256_SSWU_RO_ of RFC 9380 with the domain separation tag.
+func (bls12381Suite) HashToG1(dst, msg []byte) Point {
+	g := bls12381.NewG1()
+	sum := g.Zero()
+	for _, u := range hashToField(msg, dst, blsP, 2, 64) {
+		q, err := g.MapToCurve(u.FillBytes(make([]byte, 48)))
+		if err != nil {
+			// Unreachable as u is reduced.
+			panic(err)
+		}
+		g.Add(sum, sum, q)
+	}
+	return &blsPoint[bls12381.PointG1, *bls12381.G1]{g, g.Affine(sum)}
+}
+
+// blsP is the order of the base field of BLS12-381.
+var blsP, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+
+// bn256SqrtExp is (P+1)/4; as P = 3 mod 4, x^bn256SqrtExp is a square root of
+// every quadratic residue x.
+var bn256SqrtExp = new(big.Int).Rsh(new(big.Int).Add(bn256.P, big.NewInt(1)), 2)
+
+// HashToG1 returns the hash of the message to G1 of bn256 by try-and-increment,
+// hashing the message with a counter to candidate x coordinates with
+// hash_to_field of RFC 9380 until x^3 + 3 is a square, and taking its
+// principal square root. As bn256 has cofactor 1, every point on the curve is
+// in G1. Unlike the maps of RFC 9380, the time taken depends on the message.
+func (bn256Suite) HashToG1(dst, msg []byte) Point {
+	three := big.NewInt(3)
+	m := append(append([]byte(nil), msg...), 0)
+	// Each candidate succeeds with probability about 1/2, so a counter byte
+	// fails with probability 2^-256.
+	for ctr := 0; ctr < 256; ctr++ {
+		m[len(m)-1] = byte(ctr)
+		x := hashToField(m, dst, bn256.P, 1, 48)[0]
+		y2 := new(big.Int).Exp(x, three, bn256.P)
+		y2.Add(y2, three).Mod(y2, bn256.P)
+		y := new(big.Int).Exp(y2, bn256SqrtExp, bn256.P)
+		if new(big.Int).Exp(y, big.NewInt(2), bn256.P).Cmp(y2) != 0 {
+			continue
+		}
+
+		enc := make([]byte, 64)
+		x.FillBytes(enc[:32])
+		y.FillBytes(enc[32:])
+		p := new(bn256.G1)
+		if _, err := p.Unmarshal(enc); err != nil {
+			// Unreachable as (x, y) is on the curve.
+			panic(err)
+		}
+		return &bn256Point[bn256.G1, *bn256.G1]{p}
+	}
+	panic("no square in 256 candidates")
+}
@@ -0,0 +1,70 @@
+package curve
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestExpandMessageXMD(t *testing.T) {
+	// RFC 9380, appendix K.1.
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	tests := []struct {
+		msg  string
+		n    int
+		want string
+	}{
+		{msg: "", n: 0x20, want: "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{msg: "abc", n: 0x20, want: "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+	}
+
+	for _, tt := range tests {
+		if got := hex.EncodeToString(expandMessageXMD([]byte(tt.msg), dst, tt.n)); got != tt.want {
+			t.Errorf("expandMessageXMD(%q, %d) = %s; want %s", tt.msg, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestHashToG1(t *testing.T) {
+	// RFC 9380, appendix J.9.1.
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{
+			msg:  "",
+			want: "052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1" + "08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265",
+		},
+		{
+			msg:  "abc",
+			want: "03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903" + "0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d",
+		},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(BLS12381.HashToG1(dst, []byte(tt.msg)).Marshal()); got != tt.want {
+			t.Errorf("BLS12381.HashToG1(%q) = %s; want %s", tt.msg, got, tt.want)
+		}
+	}
+
+	for _, s := range suites {
+		seen := make(map[string]bool)
+		for i := 0; i < 16; i++ {
+			p := s.HashToG1([]byte("test"), []byte(fmt.Sprint(i)))
+			q := s.G1().New()
+			if err := q.Unmarshal(p.Marshal()); err != nil {
+				t.Errorf("%s: Unmarshal(HashToG1(%d)): %v", s.Name(), i, err)
+			}
+			if p.Equal(s.G1().New()) || seen[string(p.Marshal())] {
+				t.Errorf("%s: HashToG1(%d) = %v, the identity or a repeat", s.Name(), i, p)
+			}
+			seen[string(p.Marshal())] = true
+			if !p.Equal(s.HashToG1([]byte("test"), []byte(fmt.Sprint(i)))) {
+				t.Errorf("%s: HashToG1(%d) not deterministic", s.Name(), i)
+			}
+		}
+		if s.HashToG1([]byte("a"), nil).Equal(s.HashToG1([]byte("b"), nil)) {
+			t.Errorf("%s: HashToG1() independent of the tag", s.Name())
+		}
+	}
+}
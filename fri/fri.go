@@ -0,0 +1,244 @@
+// Package fri implements the Fast Reed-Solomon Interactive Oracle Proof of
+// Proximity (FRI) of Ben-Sasson et al., https://eccc.weizmann.ac.il/report/2017/134,
+// made non-interactive with Fiat-Shamir.
+//
+// The prover commits to the evaluations of a polynomial p of degree less than
+// k over a Domain of size n = blowup*k with a Merkle tree. Every folding round
+// splits p(v) = p_e(v^2) + v*p_o(v^2) and commits to p_e + beta*p_o over the
+// Domain of squares, which is half the size for half the degree. After log2(k)
+// rounds the polynomial is a constant. Queries at random positions check that
+// consecutive layers are consistent, so a codeword far from any polynomial of
+// degree less than k is rejected with high probability. Only hashing is
+// required, with no trusted setup or pairings.
+package fri
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/merkle"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// ErrRejected is returned by Verify for proofs that are inconsistent.
+var ErrRejected = errors.New("FRI proof rejected")
+
+// A Scheme holds the parameters shared by prover and verifier.
+type Scheme struct {
+	f       *galois.Field
+	d       *polynomial.Domain
+	k       int
+	queries int
+	inv2    *big.Int
+}
+
+// New returns a Scheme for polynomials with fewer than k coefficients,
+// evaluated over the Domain, which MUST be over f. Both k and the blowup
+// d.Size()/k MUST be powers of two of at least 2. Every query reduces the
+// soundness error by a factor of roughly 2/blowup.
+func New(f *galois.Field, d *polynomial.Domain, k, queries int) (*Scheme, error) {
+	n := int(d.Size())
+	if k < 2 || k&(k-1) != 0 {
+		return nil, fmt.Errorf("degree bound %d is not a power of two >= 2", k)
+	}
+	if n < 2*k {
+		return nil, fmt.Errorf("domain size %d < 2 * degree bound %d", n, k)
+	}
+	if queries < 1 {
+		return nil, fmt.Errorf("number of queries %d < 1", queries)
+	}
+	inv2, err := f.MultInverse(big.NewInt(2))
+	if err != nil {
+		return nil, err
+	}
+	return &Scheme{f: f, d: d, k: k, queries: queries, inv2: inv2}, nil
+}
+
+// rounds returns the number of folding rounds, log2(k).
+func (s *Scheme) rounds() int {
+	return bits.TrailingZeros(uint(s.k))
+}
+
+// A LayerOpening holds the values at positions j and j + n_i/2 of a layer of
+// size n_i, with their Merkle proofs.
+type LayerOpening struct {
+	Values [2]*big.Int
+	Proofs [2]*merkle.Proof
+}
+
+// leaves returns the 32-byte big-endian encodings of the values of a layer.
+func leaves(layer []*big.Int) [][]byte {
+	ls := make([][]byte, len(layer))
+	for i, v := range layer {
+		ls[i] = v.FillBytes(make([]byte, 32))
+	}
+	return ls
+}
+
+// verifyValue reports whether the proof shows that v is at position i of the
+// layer of size n committed to by root.
+func verifyValue(root []byte, n, i int, v *big.Int, proof *merkle.Proof) bool {
+	if proof == nil || proof.Index != i || v.Sign() < 0 || v.BitLen() > 256 {
+		return false
+	}
+	return merkle.Verify(merkle.SHA256, root, n, v.FillBytes(make([]byte, 32)), proof)
+}
+
+// A Proof attests that Roots[0] commits to the evaluations of a polynomial of
+// degree less than k. Roots holds the commitment to every layer before
+// folding, Final the constant left after the last round, and Queries the
+// openings of every layer for each query.
+type Proof struct {
+	Roots   [][]byte
+	Final   *big.Int
+	Queries [][]LayerOpening
+}
+
+// Commitment returns the Merkle root of the evaluations over the Domain.
+func (proof *Proof) Commitment() []byte {
+	return proof.Roots[0]
+}
+
+// fold returns p_e(x^2) + beta*p_o(x^2) from a = p(x) and b = p(-x), with
+// xInv = 1/x.
+func (s *Scheme) fold(a, b, beta, xInv *big.Int) *big.Int {
+	even := s.f.Mul(s.f.Add(a, b), s.inv2)
+	odd := s.f.Mul(s.f.Mul(s.f.Sub(a, b), s.inv2), xInv)
+	return s.f.Add(even, s.f.Mul(beta, odd))
+}
+
+// xInv returns the inverse of element j of layer i, i.e. w^(-j*2^i).
+func (s *Scheme) xInv(i, j int) *big.Int {
+	n := s.d.Size()
+	return s.d.Element(n - (uint64(j)<<i)%n)
+}
+
+// queryPositions derives the query positions within the first half of the
+// Domain.
+func (s *Scheme) queryPositions(t *transcript.Transcript) []int {
+	half := new(big.Int).SetUint64(s.d.Size() / 2)
+	pos := make([]int, s.queries)
+	for q := range pos {
+		pos[q] = int(new(big.Int).Mod(t.Challenge(s.f), half).Int64())
+	}
+	return pos
+}
+
+// Prove returns a Proof that p has degree less than k, or an error if it
+// doesn't. The transcript continues with the messages of the proof.
+func (s *Scheme) Prove(p *polynomial.Polynomial, t *transcript.Transcript) (*Proof, error) {
+	p = p.Normalize(s.f)
+	if d := p.Degree(); d >= s.k {
+		return nil, fmt.Errorf("polynomial degree %d >= degree bound %d", d, s.k)
+	}
+
+	return s.proveCodeword(s.d.FFT(*p), t)
+}
+
+// proveCodeword returns a Proof for the evaluations of a polynomial over the
+// Domain, without checking its degree.
+func (s *Scheme) proveCodeword(layer []*big.Int, t *transcript.Transcript) (*Proof, error) {
+	var layers [][]*big.Int
+	var trees []*merkle.Tree
+	proof := &Proof{}
+	for i := 0; i < s.rounds(); i++ {
+		tree, err := merkle.New(merkle.SHA256, leaves(layer))
+		if err != nil {
+			return nil, err
+		}
+		layers, trees = append(layers, layer), append(trees, tree)
+		proof.Roots = append(proof.Roots, tree.Root())
+		t.AppendBytes("root", tree.Root())
+		beta := t.Challenge(s.f)
+
+		half := len(layer) / 2
+		next := make([]*big.Int, half)
+		for j := range next {
+			next[j] = s.fold(layer[j], layer[j+half], beta, s.xInv(i, j))
+		}
+		layer = next
+	}
+	proof.Final = layer[0]
+	t.AppendScalar("final", proof.Final)
+
+	for _, pos := range s.queryPositions(t) {
+		var openings []LayerOpening
+		for i, l := range layers {
+			half := len(l) / 2
+			j := pos % half
+			lo, err := trees[i].Prove(j)
+			if err != nil {
+				return nil, err
+			}
+			hi, err := trees[i].Prove(j + half)
+			if err != nil {
+				return nil, err
+			}
+			openings = append(openings, LayerOpening{
+				Values: [2]*big.Int{l[j], l[j+half]},
+				Proofs: [2]*merkle.Proof{lo, hi},
+			})
+		}
+		proof.Queries = append(proof.Queries, openings)
+	}
+	return proof, nil
+}
+
+// Verify checks the proof, with a transcript in the state passed to Prove, and
+// returns an error wrapping ErrRejected if it is invalid.
+func (s *Scheme) Verify(proof *Proof, t *transcript.Transcript) error {
+	rounds := s.rounds()
+	if len(proof.Roots) != rounds || len(proof.Queries) != s.queries || proof.Final == nil {
+		return fmt.Errorf("%w: malformed proof", ErrRejected)
+	}
+	if proof.Final.Sign() < 0 || proof.Final.Cmp(s.f.Order()) >= 0 {
+		return fmt.Errorf("%w: final value not in [0, %v)", ErrRejected, s.f.Order())
+	}
+
+	betas := make([]*big.Int, rounds)
+	for i, root := range proof.Roots {
+		t.AppendBytes("root", root)
+		betas[i] = t.Challenge(s.f)
+	}
+	final := proof.Final
+	t.AppendScalar("final", final)
+
+	for q, pos := range s.queryPositions(t) {
+		openings := proof.Queries[q]
+		if len(openings) != rounds {
+			return fmt.Errorf("%w: query %d opens %d layers; want %d", ErrRejected, q, len(openings), rounds)
+		}
+
+		// The value at position pos mod n_i of layer i, as folded from layer
+		// i-1, or nil for the first layer.
+		var want *big.Int
+		n := int(s.d.Size())
+		for i, o := range openings {
+			half := n / 2
+			j := pos % half
+			if o.Values[0] == nil || o.Values[1] == nil {
+				return fmt.Errorf("%w: query %d layer %d: missing value", ErrRejected, q, i)
+			}
+			for side, idx := range []int{j, j + half} {
+				if !verifyValue(proof.Roots[i], n, idx, o.Values[side], o.Proofs[side]) {
+					return fmt.Errorf("%w: query %d layer %d: invalid Merkle path for position %d", ErrRejected, q, i, idx)
+				}
+			}
+			if want != nil {
+				if got := o.Values[(pos%n)/half]; got.Cmp(want) != 0 {
+					return fmt.Errorf("%w: query %d layer %d: value %v; folded %v", ErrRejected, q, i, got, want)
+				}
+			}
+			want = s.fold(o.Values[0], o.Values[1], betas[i], s.xInv(i, j))
+			n = half
+		}
+		if want.Cmp(final) != 0 {
+			return fmt.Errorf("%w: query %d: folded %v; final %v", ErrRejected, q, want, final)
+		}
+	}
+	return nil
+}
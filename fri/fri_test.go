@@ -0,0 +1,143 @@
+package fri
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+var f = galois.NewField(bn256.Order)
+
+func newScheme(t *testing.T, k, n, queries int) *Scheme {
+	t.Helper()
+	d, err := polynomial.NewDomain(f, uint64(n), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(f, d, k, queries)
+	if err != nil {
+		t.Fatalf("New(%d, %d, %d): %v", k, n, queries, err)
+	}
+	return s
+}
+
+func TestNewErrors(t *testing.T) {
+	d, err := polynomial.NewDomain(f, 16, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		k, queries int
+	}{
+		{k: 1, queries: 1},
+		{k: 3, queries: 1},
+		{k: 16, queries: 1},
+		{k: 4, queries: 0},
+	}
+
+	for _, tt := range tests {
+		if _, err := New(f, d, tt.k, tt.queries); err == nil {
+			t.Errorf("New(<domain size 16>, %d, %d): got nil error", tt.k, tt.queries)
+		}
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	tests := []struct {
+		k, n, degree int
+	}{
+		{k: 2, n: 4, degree: 0},
+		{k: 8, n: 32, degree: 7},
+		{k: 8, n: 32, degree: 3},
+		{k: 16, n: 64, degree: 15},
+	}
+
+	for _, tt := range tests {
+		s := newScheme(t, tt.k, tt.n, 8)
+		p, err := polynomial.Random(tt.degree, f, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := s.Prove(p, transcript.New("test"))
+		if err != nil {
+			t.Fatalf("Prove(<degree %d>): %v", tt.degree, err)
+		}
+		if err := s.Verify(proof, transcript.New("test")); err != nil {
+			t.Errorf("Verify(Prove(<degree %d>)) with k = %d, n = %d: %v", tt.degree, tt.k, tt.n, err)
+		}
+	}
+}
+
+func TestProveDegreeTooLarge(t *testing.T) {
+	s := newScheme(t, 8, 32, 8)
+	p, err := polynomial.Random(8, f, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Prove(p, transcript.New("test")); err == nil {
+		t.Errorf("Prove(<degree 8>) with k = 8: got nil error")
+	}
+}
+
+func TestVerifyRejectsHighDegree(t *testing.T) {
+	s := newScheme(t, 8, 64, 16)
+	p, err := polynomial.Random(63, f, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := s.proveCodeword(s.d.FFT(*p), transcript.New("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(proof, transcript.New("test")); !errors.Is(err, ErrRejected) {
+		t.Errorf("Verify(<codeword of degree 63>) with k = 8: got err %v; want %v", err, ErrRejected)
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	s := newScheme(t, 8, 32, 8)
+	p, err := polynomial.Random(7, f, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(*Proof)
+	}{
+		{name: "root", tamper: func(p *Proof) { p.Roots[1] = p.Roots[0] }},
+		{name: "final", tamper: func(p *Proof) { p.Final = f.Add(p.Final, big.NewInt(1)) }},
+		{name: "final + r", tamper: func(p *Proof) { p.Final = new(big.Int).Add(p.Final, f.Order()) }},
+		{name: "value", tamper: func(p *Proof) {
+			p.Queries[0][1].Values[0] = f.Add(p.Queries[0][1].Values[0], big.NewInt(1))
+		}},
+		{name: "Merkle proof", tamper: func(p *Proof) { p.Queries[2][0].Proofs[1] = p.Queries[2][0].Proofs[0] }},
+		{name: "missing query", tamper: func(p *Proof) { p.Queries = p.Queries[1:] }},
+		{name: "missing layer", tamper: func(p *Proof) { p.Queries[3] = p.Queries[3][1:] }},
+	}
+
+	for _, tt := range tests {
+		proof, err := s.Prove(p, transcript.New("test"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tt.tamper(proof)
+		if err := s.Verify(proof, transcript.New("test")); !errors.Is(err, ErrRejected) {
+			t.Errorf("Verify() with tampered %s: got err %v; want %v", tt.name, err, ErrRejected)
+		}
+	}
+
+	proof, err := s.Prove(p, transcript.New("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(proof, transcript.New("other")); err == nil {
+		t.Errorf("Verify() with other transcript: got nil error")
+	}
+}
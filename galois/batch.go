@@ -0,0 +1,58 @@
+package galois
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BatchInverse returns the multiplicative inverses of all xs with a single
+// inversion and 3(n-1) multiplications (Montgomery's trick): with prefix
+// products a_i = x_0 * ... * x_i, the inverse of a_(n-1) yields all inverses by
+// x_i^-1 = a_(i-1) * a_i^-1 and a_(i-1)^-1 = a_i^-1 * x_i. It returns an error
+// wrapping ErrNotInvertible, naming the offending index, if any x is zero in f.
+func (f *Field) BatchInverse(xs []*big.Int) ([]*big.Int, error) {
+	if len(xs) == 0 {
+		return nil, nil
+	}
+
+	prefix := make([]*big.Int, len(xs))
+	acc := big.NewInt(1)
+	for i, x := range xs {
+		if new(big.Int).Mod(x, f.order).Sign() == 0 {
+			return nil, fmt.Errorf("%w: element %d is zero", ErrNotInvertible, i)
+		}
+		acc = f.Mul(acc, x)
+		prefix[i] = acc
+	}
+
+	accInv, err := f.MultInverse(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	invs := make([]*big.Int, len(xs))
+	for i := len(xs) - 1; i > 0; i-- {
+		invs[i] = f.Mul(accInv, prefix[i-1])
+		accInv = f.Mul(accInv, xs[i])
+	}
+	invs[0] = accInv
+	return invs, nil
+}
+
+// DivSlice returns nums[i]/dens[i] for all i, inverting all denominators with
+// a single field inversion. It returns an error if the slices differ in length
+// or if any denominator is not invertible.
+func (f *Field) DivSlice(nums, dens []*big.Int) ([]*big.Int, error) {
+	if len(nums) != len(dens) {
+		return nil, fmt.Errorf("len(nums) != len(dens): %d != %d", len(nums), len(dens))
+	}
+
+	invs, err := f.BatchInverse(dens)
+	if err != nil {
+		return nil, err
+	}
+	for i, inv := range invs {
+		invs[i] = f.Mul(nums[i], inv)
+	}
+	return invs, nil
+}
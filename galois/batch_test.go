@@ -0,0 +1,144 @@
+package galois
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func randomElements(tb testing.TB, f *Field, n int) []*big.Int {
+	tb.Helper()
+	xs := make([]*big.Int, n)
+	for i := range xs {
+		for xs[i] == nil || xs[i].Sign() == 0 {
+			x, err := f.Random(rand.Reader)
+			if err != nil {
+				tb.Fatalf("Random(): %v", err)
+			}
+			xs[i] = x
+		}
+	}
+	return xs
+}
+
+func TestBatchInverse(t *testing.T) {
+	for _, order := range []*big.Int{big.NewInt(13), bn256.Order} {
+		f := NewField(order)
+
+		for _, n := range []int{0, 1, 2, 17} {
+			xs := randomElements(t, f, n)
+			if n > 1 {
+				xs[0] = new(big.Int).Sub(xs[0], order)
+			}
+
+			got, err := f.BatchInverse(xs)
+			if err != nil {
+				t.Fatalf("BatchInverse(<%d elements>) mod %v: %v", n, order, err)
+			}
+			if len(got) != n {
+				t.Fatalf("len(BatchInverse(<%d elements>)) = %d", n, len(got))
+			}
+			for i := range got {
+				if p := f.Mul(got[i], xs[i]); p.Cmp(bigOne) != 0 {
+					t.Errorf("BatchInverse()[%d] * %v mod %v = %v; want 1", i, xs[i], order, p)
+				}
+			}
+		}
+	}
+
+	f := NewField(big.NewInt(13))
+	xs := randomElements(t, f, 3)
+	xs[2] = big.NewInt(0)
+	if _, err := f.BatchInverse(xs); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("BatchInverse(<zero element>): got err %v; want %v", err, ErrNotInvertible)
+	}
+}
+
+func TestDivSlice(t *testing.T) {
+	for _, order := range []*big.Int{big.NewInt(13), bn256.Order} {
+		f := NewField(order)
+
+		for _, n := range []int{0, 1, 2, 17} {
+			nums, dens := randomElements(t, f, n), randomElements(t, f, n)
+			if n > 1 {
+				// Values outside of [0, q) and duplicates.
+				nums[0] = new(big.Int).Neg(nums[0])
+				dens[1] = new(big.Int).Add(dens[0], order)
+			}
+
+			got, err := f.DivSlice(nums, dens)
+			if err != nil {
+				t.Fatalf("DivSlice(<%d elements>) mod %v: %v", n, order, err)
+			}
+			if len(got) != n {
+				t.Fatalf("len(DivSlice(<%d elements>)) = %d", n, len(got))
+			}
+			for i := range got {
+				want, err := f.Div(nums[i], dens[i])
+				if err != nil {
+					t.Fatalf("Div(): %v", err)
+				}
+				if got[i].Cmp(want) != 0 {
+					t.Errorf("DivSlice()[%d] mod %v = %v; want %v", i, order, got[i], want)
+				}
+			}
+		}
+	}
+}
+
+func TestDivSliceErrors(t *testing.T) {
+	f := NewField(big.NewInt(13))
+
+	if _, err := f.DivSlice(randomElements(t, f, 2), randomElements(t, f, 3)); err == nil {
+		t.Errorf("DivSlice(<2 elements>, <3 elements>): got nil error")
+	}
+
+	dens := randomElements(t, f, 3)
+	dens[1] = big.NewInt(26)
+	if _, err := f.DivSlice(randomElements(t, f, 3), dens); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("DivSlice(<zero denominator>): got err %v; want %v", err, ErrNotInvertible)
+	}
+}
+
+const benchmarkBatchSize = 1024
+
+func BenchmarkBatchInverse(b *testing.B) {
+	f := NewField(bn256.Order)
+	xs := randomElements(b, f, benchmarkBatchSize)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := f.BatchInverse(xs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDivSlice(b *testing.B) {
+	f := NewField(bn256.Order)
+	nums, dens := randomElements(b, f, benchmarkBatchSize), randomElements(b, f, benchmarkBatchSize)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := f.DivSlice(nums, dens); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDivElementwise(b *testing.B) {
+	f := NewField(bn256.Order)
+	nums, dens := randomElements(b, f, benchmarkBatchSize), randomElements(b, f, benchmarkBatchSize)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j := range nums {
+			if _, err := f.Div(nums[j], dens[j]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package galois
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Binary fields GF(2^k) are polynomials over GF(2) modulo an irreducible
+// polynomial of degree k, with coefficients packed into the bits of uint64s:
+// addition is XOR and multiplication the carry-less product, computed with a
+// 4-bit window, followed by a reduction exploiting the sparse moduli
+//
+//	GF(2^64):  x^64 + x^4 + x^3 + x + 1
+//	GF(2^128): x^128 + x^7 + x^2 + x + 1
+//
+// The zero value of GF64 and GF128 is the field's zero, and arithmetic is on
+// values, without allocation. Both are Elements, so polynomials over them are
+// polynomial.Generic[GF64] and polynomial.Generic[GF128].
+
+// A GF64 is an element of GF(2^64), with bit i the coefficient of x^i.
+type GF64 uint64
+
+// A GF128 is an element of GF(2^128), with bit i of Lo the coefficient of x^i
+// and bit i of Hi that of x^(64+i).
+type GF128 struct {
+	Lo, Hi uint64
+}
+
+// clmul64 returns the carry-less product of a and b as its high and low words.
+func clmul64(a, b uint64) (hi, lo uint64) {
+	// window[i] is the product of a and the 4-bit polynomial i.
+	var window [16][2]uint64
+	window[1] = [2]uint64{0, a}
+	for i := 2; i < 16; i += 2 {
+		h, l := window[i/2][0], window[i/2][1]
+		window[i] = [2]uint64{h<<1 | l>>63, l << 1}
+		window[i+1] = [2]uint64{window[i][0], window[i][1] ^ a}
+	}
+	for i := 60; i >= 0; i -= 4 {
+		hi, lo = hi<<4|lo>>60, lo<<4
+		w := window[(b>>uint(i))&15]
+		hi, lo = hi^w[0], lo^w[1]
+	}
+	return hi, lo
+}
+
+// Add returns a+b, which is also a-b.
+func (a GF64) Add(b GF64) GF64 {
+	return a ^ b
+}
+
+// Mul returns a*b.
+func (a GF64) Mul(b GF64) GF64 {
+	hi, lo := clmul64(uint64(a), uint64(b))
+	// x^64 = x^4 + x^3 + x + 1; the bits shifted out of hi are folded in once
+	// more, without overflowing again.
+	over := hi>>60 ^ hi>>61 ^ hi>>63
+	lo ^= hi ^ hi<<1 ^ hi<<3 ^ hi<<4
+	lo ^= over ^ over<<1 ^ over<<3 ^ over<<4
+	return GF64(lo)
+}
+
+// Sub returns a-b, which is a+b.
+func (a GF64) Sub(b GF64) GF64 {
+	return a.Add(b)
+}
+
+// Neg returns -a, which is a.
+func (a GF64) Neg() GF64 {
+	return a
+}
+
+// Square returns a*a.
+func (a GF64) Square() GF64 {
+	return a.Mul(a)
+}
+
+// Inverse returns the multiplicative inverse a^(2^64 - 2) of a, or
+// ErrNotInvertible for zero.
+func (a GF64) Inverse() (GF64, error) {
+	if a == 0 {
+		return 0, fmt.Errorf("%w: zero in GF(2^64)", ErrNotInvertible)
+	}
+	// 2^64 - 2 = 2 + 4 + ... + 2^63.
+	inv, sq := GF64(1), a
+	for i := 1; i < 64; i++ {
+		sq = sq.Square()
+		inv = inv.Mul(sq)
+	}
+	return inv, nil
+}
+
+// One returns the multiplicative identity, independent of a.
+func (GF64) One() GF64 {
+	return 1
+}
+
+// IsZero reports whether a is zero.
+func (a GF64) IsZero() bool {
+	return a == 0
+}
+
+// Bytes returns the big-endian encoding of a.
+func (a GF64) Bytes() []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(a))
+	return buf
+}
+
+// Random returns RandomGF64(r), independent of the receiver.
+func (GF64) Random(r io.Reader) (GF64, error) {
+	return RandomGF64(r)
+}
+
+// RandomGF64 returns a uniformly random element of GF(2^64) read from r.
+func RandomGF64(r io.Reader) (GF64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	return GF64(binary.BigEndian.Uint64(buf[:])), nil
+}
+
+// Add returns a+b, which is also a-b.
+func (a GF128) Add(b GF128) GF128 {
+	return GF128{Lo: a.Lo ^ b.Lo, Hi: a.Hi ^ b.Hi}
+}
+
+// Mul returns a*b.
+func (a GF128) Mul(b GF128) GF128 {
+	// Karatsuba: three 64-bit carry-less products.
+	h1, h0 := clmul64(a.Hi, b.Hi)
+	l1, l0 := clmul64(a.Lo, b.Lo)
+	m1, m0 := clmul64(a.Hi^a.Lo, b.Hi^b.Lo)
+	m1, m0 = m1^h1^l1, m0^h0^l0
+	w3, w2, w1, w0 := h1, h0^m1, l1^m0, l0
+
+	// x^128 = x^7 + x^2 + x + 1.
+	r1, r0 := w1^w3, w0^w2
+	for _, n := range []uint{1, 2, 7} {
+		r1 ^= w3<<n | w2>>(64-n)
+		r0 ^= w2 << n
+	}
+	over := w3>>63 ^ w3>>62 ^ w3>>57
+	r0 ^= over ^ over<<1 ^ over<<2 ^ over<<7
+	return GF128{Lo: r0, Hi: r1}
+}
+
+// Sub returns a-b, which is a+b.
+func (a GF128) Sub(b GF128) GF128 {
+	return a.Add(b)
+}
+
+// Neg returns -a, which is a.
+func (a GF128) Neg() GF128 {
+	return a
+}
+
+// Square returns a*a.
+func (a GF128) Square() GF128 {
+	return a.Mul(a)
+}
+
+// Inverse returns the multiplicative inverse a^(2^128 - 2) of a, or
+// ErrNotInvertible for zero.
+func (a GF128) Inverse() (GF128, error) {
+	if a.IsZero() {
+		return GF128{}, fmt.Errorf("%w: zero in GF(2^128)", ErrNotInvertible)
+	}
+	inv, sq := GF128{Lo: 1}, a
+	for i := 1; i < 128; i++ {
+		sq = sq.Square()
+		inv = inv.Mul(sq)
+	}
+	return inv, nil
+}
+
+// One returns the multiplicative identity, independent of a.
+func (GF128) One() GF128 {
+	return GF128{Lo: 1}
+}
+
+// IsZero reports whether a is zero.
+func (a GF128) IsZero() bool {
+	return a == (GF128{})
+}
+
+// Bytes returns the big-endian encoding of a, Hi first.
+func (a GF128) Bytes() []byte {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[:8], a.Hi)
+	binary.BigEndian.PutUint64(buf[8:], a.Lo)
+	return buf
+}
+
+// Random returns RandomGF128(r), independent of the receiver.
+func (GF128) Random(r io.Reader) (GF128, error) {
+	return RandomGF128(r)
+}
+
+// RandomGF128 returns a uniformly random element of GF(2^128) read from r.
+func RandomGF128(r io.Reader) (GF128, error) {
+	var buf [16]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return GF128{}, err
+	}
+	return GF128{Hi: binary.BigEndian.Uint64(buf[:8]), Lo: binary.BigEndian.Uint64(buf[8:])}, nil
+}
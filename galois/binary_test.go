@@ -0,0 +1,135 @@
+package galois
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/bits"
+	"testing"
+)
+
+// mulGF2 returns the product of a and b, bit-packed polynomials over GF(2) of
+// n words, modulo x^(64n) + low, one bit at a time.
+func mulGF2(a, b []uint64, low uint64) []uint64 {
+	n := len(a)
+	out := make([]uint64, n)
+	x := append([]uint64(nil), a...)
+	for i := 0; i < 64*n; i++ {
+		if b[i/64]>>(i%64)&1 == 1 {
+			for j := range out {
+				out[j] ^= x[j]
+			}
+		}
+		// x *= X, reducing the carried-out bit.
+		carry := x[n-1] >> 63
+		for j := n - 1; j > 0; j-- {
+			x[j] = x[j]<<1 | x[j-1]>>63
+		}
+		x[0] <<= 1
+		if carry == 1 {
+			x[0] ^= low
+		}
+	}
+	return out
+}
+
+func TestGF64(t *testing.T) {
+	inputs := []GF64{0, 1, 2, 1 << 63, ^GF64(0), 0x1b}
+	for i := 0; i < 20; i++ {
+		x, err := RandomGF64(rand.Reader)
+		if err != nil {
+			t.Fatalf("RandomGF64(): %v", err)
+		}
+		inputs = append(inputs, x)
+	}
+
+	for _, a := range inputs {
+		for _, b := range inputs {
+			want := GF64(mulGF2([]uint64{uint64(a)}, []uint64{uint64(b)}, 0x1b)[0])
+			if got := a.Mul(b); got != want {
+				t.Errorf("%#x.Mul(%#x) = %#x; want %#x", a, b, got, want)
+			}
+		}
+		if a.IsZero() {
+			if _, err := a.Inverse(); !errors.Is(err, ErrNotInvertible) {
+				t.Errorf("GF64(0).Inverse(): got err %v; want %v", err, ErrNotInvertible)
+			}
+			continue
+		}
+		inv, err := a.Inverse()
+		if err != nil {
+			t.Fatalf("%#x.Inverse(): %v", a, err)
+		}
+		if got := a.Mul(inv); got != a.One() {
+			t.Errorf("%#x * Inverse() = %#x; want 1", a, got)
+		}
+	}
+}
+
+func TestGF128(t *testing.T) {
+	inputs := []GF128{{}, {Lo: 1}, {Lo: 2}, {Hi: 1 << 63}, {Lo: ^uint64(0), Hi: ^uint64(0)}, {Lo: 0x87}}
+	for i := 0; i < 20; i++ {
+		x, err := RandomGF128(rand.Reader)
+		if err != nil {
+			t.Fatalf("RandomGF128(): %v", err)
+		}
+		inputs = append(inputs, x)
+	}
+
+	for _, a := range inputs {
+		for _, b := range inputs {
+			w := mulGF2([]uint64{a.Lo, a.Hi}, []uint64{b.Lo, b.Hi}, 0x87)
+			if got, want := a.Mul(b), (GF128{Lo: w[0], Hi: w[1]}); got != want {
+				t.Errorf("%v.Mul(%v) = %v; want %v", a, b, got, want)
+			}
+		}
+		if a.IsZero() {
+			if _, err := a.Inverse(); !errors.Is(err, ErrNotInvertible) {
+				t.Errorf("GF128{}.Inverse(): got err %v; want %v", err, ErrNotInvertible)
+			}
+			continue
+		}
+		inv, err := a.Inverse()
+		if err != nil {
+			t.Fatalf("%v.Inverse(): %v", a, err)
+		}
+		if got := a.Mul(inv); got != a.One() {
+			t.Errorf("%v * Inverse() = %v; want 1", a, got)
+		}
+	}
+}
+
+func TestClmul64(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		a, err := RandomGF64(rand.Reader)
+		if err != nil {
+			t.Fatalf("RandomGF64(): %v", err)
+		}
+		b, err := RandomGF64(rand.Reader)
+		if err != nil {
+			t.Fatalf("RandomGF64(): %v", err)
+		}
+		var wantHi, wantLo uint64
+		for j := 0; j < 64; j++ {
+			if b>>j&1 == 1 {
+				wantLo ^= uint64(a) << j
+				if j > 0 {
+					wantHi ^= uint64(a) >> (64 - j)
+				}
+			}
+		}
+		if hi, lo := clmul64(uint64(a), uint64(b)); hi != wantHi || lo != wantLo {
+			t.Errorf("clmul64(%#x, %#x) = %#x, %#x; want %#x, %#x", a, b, hi, lo, wantHi, wantLo)
+		}
+		// The product of polynomials of degree 63 has degree 126.
+		if hi, _ := clmul64(uint64(a), uint64(b)); bits.Len64(hi) > 63 {
+			t.Errorf("clmul64(%#x, %#x) of degree %d; want at most 126", a, b, 63+bits.Len64(hi))
+		}
+	}
+}
+
+func BenchmarkGF128Mul(b *testing.B) {
+	x, y := GF128{Lo: 0x0123456789abcdef, Hi: 0xfedcba9876543210}, GF128{Lo: 0x87, Hi: 1 << 63}
+	for i := 0; i < b.N; i++ {
+		x = x.Mul(y)
+	}
+}
@@ -0,0 +1,199 @@
+package galois
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+)
+
+// BN256Order is the order of the scalar field of bn256 (alt_bn128), i.e. of
+// its groups.
+var BN256Order, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+var (
+	bn256FrReducer = mustMontgomery256(BN256Order)
+	bn256FrField   = NewField(BN256Order)
+	// bn256FrOne is R mod BN256Order, the Montgomery form of one.
+	bn256FrOne = bn256FrReducer.montMul(&limbs{1}, &bn256FrReducer.r2)
+)
+
+func mustMontgomery256(n *big.Int) *montgomery256Reducer {
+	r, err := newMontgomery256Reducer(n)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// A BN256Fr is an element of the scalar field of bn256, of order BN256Order,
+// held in Montgomery form x*2^256 mod BN256Order on four 64-bit limbs. Unlike
+// *big.Int values of a Field with the Montgomery256 strategy, which are
+// converted to and from that form on every multiplication, elements stay in
+// it, so arithmetic neither converts nor allocates. The zero value is zero.
+type BN256Fr struct {
+	l limbs
+}
+
+// NewBN256Fr returns x reduced modulo BN256Order.
+func NewBN256Fr(x *big.Int) BN256Fr {
+	r := bn256FrReducer
+	l := r.toLimbs(canonical(x, r.n))
+	return BN256Fr{r.montMul(&l, &r.r2)}
+}
+
+// BigInt returns a as an integer in [0, BN256Order).
+func (a BN256Fr) BigInt() *big.Int {
+	return bn256FrReducer.fromLimbs(bn256FrReducer.montMul(&a.l, &limbs{1}))
+}
+
+// Add returns a+b.
+func (a BN256Fr) Add(b BN256Fr) BN256Fr {
+	var s limbs
+	var carry uint64
+	for i := range s {
+		s[i], carry = bits.Add64(a.l[i], b.l[i], carry)
+	}
+	if carry != 0 || !less(&s, &bn256FrModulus) {
+		s = sub(&s, &bn256FrModulus)
+	}
+	return BN256Fr{s}
+}
+
+// Sub returns a-b.
+func (a BN256Fr) Sub(b BN256Fr) BN256Fr {
+	d := sub(&a.l, &b.l)
+	if less(&a.l, &b.l) {
+		var carry uint64
+		for i := range d {
+			d[i], carry = bits.Add64(d[i], bn256FrModulus[i], carry)
+		}
+	}
+	return BN256Fr{d}
+}
+
+// sub returns a-b mod 2^256.
+func sub(a, b *limbs) limbs {
+	var d limbs
+	var borrow uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	return d
+}
+
+// Mul returns a*b.
+func (a BN256Fr) Mul(b BN256Fr) BN256Fr {
+	return BN256Fr{bn256FrMul(&a.l, &b.l)}
+}
+
+// The limbs of BN256Order, and -BN256Order^-1 mod 2^64.
+const (
+	bn256Fr0   = 0x43e1f593f0000001
+	bn256Fr1   = 0x2833e84879b97091
+	bn256Fr2   = 0xb85045b68181585d
+	bn256Fr3   = 0x30644e72e131a029
+	bn256FrInv = 0xc2e1f593efffffff
+)
+
+var bn256FrModulus = limbs{bn256Fr0, bn256Fr1, bn256Fr2, bn256Fr3}
+
+// bn256FrMul returns x*y*R^-1 mod BN256Order, as montgomery256Reducer.montMul
+// but with the modulus inlined and without the carry words that the CIOS
+// method needs for moduli whose top limb exceeds 2^62.
+func bn256FrMul(x, y *limbs) limbs {
+	var t0, t1, t2, t3 uint64
+	for i := 0; i < 4; i++ {
+		yi := y[i]
+		a, t0l := madd(x[0], yi, t0, 0)
+		m := t0l * bn256FrInv
+		c, _ := madd(m, bn256Fr0, t0l, 0)
+
+		a, t1 = madd(x[1], yi, t1, a)
+		c, t0 = madd(m, bn256Fr1, t1, c)
+		a, t2 = madd(x[2], yi, t2, a)
+		c, t1 = madd(m, bn256Fr2, t2, c)
+		a, t3 = madd(x[3], yi, t3, a)
+		c, t2 = madd(m, bn256Fr3, t3, c)
+		t3 = a + c
+	}
+
+	z := limbs{t0, t1, t2, t3}
+	if !less(&z, &bn256FrModulus) {
+		z = sub(&z, &bn256FrModulus)
+	}
+	return z
+}
+
+// Neg returns -a.
+func (a BN256Fr) Neg() BN256Fr {
+	return BN256Fr{}.Sub(a)
+}
+
+// Exp returns a^n for non-negative n.
+func (a BN256Fr) Exp(n *big.Int) BN256Fr {
+	z := a.One()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		z = z.Mul(z)
+		if n.Bit(i) == 1 {
+			z = z.Mul(a)
+		}
+	}
+	return z
+}
+
+// Inverse returns the multiplicative inverse of a, or ErrNotInvertible for
+// zero.
+func (a BN256Fr) Inverse() (BN256Fr, error) {
+	if a.IsZero() {
+		return a, fmt.Errorf("%w: zero in BN256Fr field", ErrNotInvertible)
+	}
+	return a.Exp(new(big.Int).Sub(BN256Order, big.NewInt(2))), nil
+}
+
+// IsZero reports whether a is zero.
+func (a BN256Fr) IsZero() bool {
+	return a.l == limbs{}
+}
+
+// One returns the multiplicative identity, independent of the receiver.
+func (BN256Fr) One() BN256Fr {
+	return BN256Fr{bn256FrOne}
+}
+
+// RootOfUnity returns the primitive nth root of unity of
+// Field.PrimitiveRootOfUnity for a power of two n dividing BN256Order-1,
+// independent of the receiver.
+func (BN256Fr) RootOfUnity(n uint64) (BN256Fr, error) {
+	w, err := bn256FrField.PrimitiveRootOfUnity(n)
+	if err != nil {
+		return BN256Fr{}, err
+	}
+	return NewBN256Fr(w), nil
+}
+
+// Bytes returns the 32-byte big-endian encoding of a.
+func (a BN256Fr) Bytes() []byte {
+	return a.BigInt().FillBytes(make([]byte, 32))
+}
+
+// Random returns RandomBN256Fr(r), independent of the receiver.
+func (BN256Fr) Random(r io.Reader) (BN256Fr, error) {
+	return RandomBN256Fr(r)
+}
+
+// RandomBN256Fr returns a uniformly random BN256Fr, rejection-sampled from
+// 32-byte values read from r and truncated to the bit length of BN256Order.
+func RandomBN256Fr(r io.Reader) (BN256Fr, error) {
+	var buf [32]byte
+	mask := byte(0xff) >> uint(8*len(buf)-BN256Order.BitLen())
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return BN256Fr{}, err
+		}
+		buf[0] &= mask
+		if x := new(big.Int).SetBytes(buf[:]); x.Cmp(BN256Order) < 0 {
+			return NewBN256Fr(x), nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package galois
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func TestBN256Fr(t *testing.T) {
+	if BN256Order.Cmp(bn256.Order) != 0 {
+		t.Fatalf("BN256Order = %v; want %v", BN256Order, bn256.Order)
+	}
+	f := NewField(BN256Order)
+
+	var zero BN256Fr
+	inputs := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(-1),
+		new(big.Int).Sub(BN256Order, big.NewInt(2)),
+		new(big.Int).Lsh(big.NewInt(1), 253),
+		new(big.Int).Add(BN256Order, big.NewInt(42)),
+	}
+	for i := 0; i < 20; i++ {
+		x, err := RandomBN256Fr(rand.Reader)
+		if err != nil {
+			t.Fatalf("RandomBN256Fr(): %v", err)
+		}
+		inputs = append(inputs, x.BigInt())
+	}
+
+	for _, x := range inputs {
+		a := NewBN256Fr(x)
+		if got, want := a.BigInt(), f.Mod(new(big.Int).Set(x)); got.Cmp(want) != 0 {
+			t.Errorf("NewBN256Fr(%v).BigInt() = %v; want %v", x, got, want)
+		}
+		if got, want := a.Bytes(), f.Mod(new(big.Int).Set(x)).FillBytes(make([]byte, 32)); !bytes.Equal(got, want) {
+			t.Errorf("NewBN256Fr(%v).Bytes() = %x; want %x", x, got, want)
+		}
+		if got, want := a.Neg().BigInt(), f.Sub(big.NewInt(0), x); got.Cmp(want) != 0 {
+			t.Errorf("NewBN256Fr(%v).Neg() = %v; want %v", x, got, want)
+		}
+
+		for _, y := range inputs {
+			b := NewBN256Fr(y)
+			for _, op := range []struct {
+				name string
+				got  BN256Fr
+				want *big.Int
+			}{
+				{"Add", a.Add(b), f.Add(x, y)},
+				{"Sub", a.Sub(b), f.Sub(x, y)},
+				{"Mul", a.Mul(b), f.Mul(x, y)},
+			} {
+				if got := op.got.BigInt(); got.Cmp(op.want) != 0 {
+					t.Errorf("NewBN256Fr(%v).%s(%v) = %v; want %v", x, op.name, y, got, op.want)
+				}
+			}
+		}
+
+		inv, err := a.Inverse()
+		if a.IsZero() {
+			if !errors.Is(err, ErrNotInvertible) {
+				t.Errorf("BN256Fr(0).Inverse() error %v; want %v", err, ErrNotInvertible)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("NewBN256Fr(%v).Inverse(): %v", x, err)
+		}
+		if got := a.Mul(inv); got != zero.One() {
+			t.Errorf("NewBN256Fr(%v) * Inverse() = %v; want 1", x, got.BigInt())
+		}
+	}
+
+	if !zero.IsZero() || zero.One().IsZero() || zero.One().BigInt().Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("BN256Fr{} and One() not zero and one")
+	}
+}
+
+func TestBN256FrRootOfUnity(t *testing.T) {
+	f := NewField(BN256Order)
+	for _, n := range []uint64{1, 2, 1 << 10, 1 << 28} {
+		w, err := BN256Fr{}.RootOfUnity(n)
+		if err != nil {
+			t.Fatalf("RootOfUnity(%d): %v", n, err)
+		}
+		want, err := f.PrimitiveRootOfUnity(n)
+		if err != nil {
+			t.Fatalf("PrimitiveRootOfUnity(%d): %v", n, err)
+		}
+		if w.BigInt().Cmp(want) != 0 {
+			t.Errorf("RootOfUnity(%d) = %v; want %v", n, w.BigInt(), want)
+		}
+		if got := w.Exp(new(big.Int).SetUint64(n)); got != w.One() {
+			t.Errorf("RootOfUnity(%d)^%d = %v; want 1", n, n, got.BigInt())
+		}
+	}
+	if _, err := (BN256Fr{}).RootOfUnity(1 << 29); err == nil {
+		t.Errorf("RootOfUnity(2^29): got nil error")
+	}
+}
+
+func BenchmarkBN256FrMul(b *testing.B) {
+	x, err := RandomBN256Fr(rand.Reader)
+	if err != nil {
+		b.Fatalf("RandomBN256Fr(): %v", err)
+	}
+	y, err := RandomBN256Fr(rand.Reader)
+	if err != nil {
+		b.Fatalf("RandomBN256Fr(): %v", err)
+	}
+	b.Run("BN256Fr", func(b *testing.B) {
+		z := x
+		for i := 0; i < b.N; i++ {
+			z = z.Mul(y)
+		}
+	})
+	for _, s := range []ReductionStrategy{GenericMod, Montgomery256} {
+		b.Run(s.String(), func(b *testing.B) {
+			f, err := NewFieldWithStrategy(BN256Order, s)
+			if err != nil {
+				b.Fatalf("NewFieldWithStrategy(BN256Order, %v): %v", s, err)
+			}
+			z, w := x.BigInt(), y.BigInt()
+			for i := 0; i < b.N; i++ {
+				z = f.Mul(z, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package galois
+
+import (
+	"crypto/subtle"
+	"math/big"
+)
+
+// ctWindow is the window size, in bits, of ctExp.
+const ctWindow = 4
+
+// byteLen returns the number of bytes required to represent any element.
+func (f *Field) byteLen() int {
+	return (f.order.BitLen() + 7) / 8
+}
+
+// Equal reports whether x and y represent the same field element, i.e.
+// whether x = y mod f.Order(). The comparison of the reduced values is
+// constant-time.
+func (f *Field) Equal(x, y *big.Int) bool {
+	n := f.byteLen()
+	a := new(big.Int).Mod(x, f.order).FillBytes(make([]byte, n))
+	b := new(big.Int).Mod(y, f.order).FillBytes(make([]byte, n))
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// MultInverseCT is equivalent to MultInverse but computes x^(q-2) by Fermat's
+// little theorem with ctExp, so the sequence of operations is independent of
+// x. The order of the Field MUST be prime.
+func (f *Field) MultInverseCT(x *big.Int) (*big.Int, error) {
+	x = new(big.Int).Mod(x, f.order)
+	inv := f.ctExp(x, new(big.Int).Sub(f.order, big.NewInt(2)))
+	if x.Sign() == 0 {
+		return nil, ErrNotInvertible
+	}
+	return inv, nil
+}
+
+// ctExp returns x^e for 0 <= x < f.Order() using fixed-window exponentiation.
+// Irrespective of the bits of e, a multiplication is performed for every
+// window and table entries are selected by scanning the whole table.
+func (f *Field) ctExp(x, e *big.Int) *big.Int {
+	n := f.byteLen()
+
+	var table [1 << ctWindow][]byte
+	entry := big.NewInt(1)
+	for i := range table {
+		table[i] = entry.FillBytes(make([]byte, n))
+		entry = f.Mul(entry, x)
+	}
+
+	windows := (f.order.BitLen() + ctWindow - 1) / ctWindow
+	selected := make([]byte, n)
+	result := big.NewInt(1)
+	tmp := new(big.Int)
+
+	for w := windows - 1; w >= 0; w-- {
+		for i := 0; i < ctWindow; i++ {
+			result = f.Mul(result, result)
+		}
+
+		var idx int
+		for i := 0; i < ctWindow; i++ {
+			idx |= int(e.Bit(w*ctWindow+i)) << i
+		}
+		for i := range table {
+			subtle.ConstantTimeCopy(subtle.ConstantTimeEq(int32(i), int32(idx)), selected, table[i])
+		}
+		result = f.Mul(result, tmp.SetBytes(selected))
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package galois
+
+import "io"
+
+// An Element is an element of a field with a fixed-width implementation, such
+// as Goldilocks, BabyBear, BN256Fr, GF64 and GF128, over which generic code,
+// e.g. polynomial.Generic, is written without depending on math/big. Elements
+// are values whose zero value is the field's zero, and arithmetic returns new
+// values rather than modifying the receiver.
+//
+// Methods that don't depend on the element, such as One and Random, ignore
+// their receiver, so they can be called on the zero value.
+type Element[E any] interface {
+	comparable
+	Add(E) E
+	Sub(E) E
+	Mul(E) E
+	Neg() E
+	// Inverse returns the multiplicative inverse, or ErrNotInvertible for
+	// zero.
+	Inverse() (E, error)
+	IsZero() bool
+	One() E
+	// Bytes returns the fixed-length, big-endian encoding of the element.
+	Bytes() []byte
+	// Random returns a uniformly random element read from r.
+	Random(r io.Reader) (E, error)
+}
@@ -0,0 +1,405 @@
+package galois
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// An ExtElement is an element c_0 + c_1 X + ... + c_{k-1} X^(k-1) of an
+// ExtField of degree k, as its coefficients in the base Field.
+type ExtElement []*big.Int
+
+// An ExtField is the extension GF(p^k) = GF(p)[X] / m(X) of a prime Field by a
+// monic irreducible polynomial m of degree k >= 2, e.g. X^2 + 1 for the
+// quadratic extension of bn256's base field. Towers of extensions, such as
+// bn256's GF(p^12), are represented by a single extension of the full degree.
+//
+// Methods of an ExtField accept elements with at most k coefficients, in any
+// representation of the base Field, and return new elements with exactly k
+// canonical coefficients.
+type ExtField struct {
+	base *Field
+	// modulus holds the coefficients m_0, ..., m_{k-1} of m, without the
+	// leading 1.
+	modulus []*big.Int
+	// frobenius holds (X^j)^p for j in [0, k); as the Frobenius is linear
+	// over the base Field, it maps x to sum_j x_j frobenius[j].
+	frobenius []ExtElement
+}
+
+// NewExtField returns the extension of the base Field by the polynomial with
+// the specified coefficients, in increasing order of degree. The polynomial
+// MUST be monic, i.e. have a leading coefficient of 1, and of degree at least
+// 2. An error is returned if it isn't irreducible over the base Field, which
+// MUST be of prime order.
+func NewExtField(base *Field, modulus []*big.Int) (*ExtField, error) {
+	m := polyTrim(base, modulus)
+	k := len(m) - 1
+	if k < 2 {
+		return nil, fmt.Errorf("modulus of degree %d; want at least 2", k)
+	}
+	if m[k].Cmp(bigOne) != 0 {
+		return nil, fmt.Errorf("modulus with leading coefficient %v; want monic", m[k])
+	}
+	e := &ExtField{base: base, modulus: m[:k]}
+	xp := e.Exp(e.Element(big.NewInt(0), big.NewInt(1)), base.order)
+	e.frobenius = []ExtElement{e.One()}
+	for j := 1; j < k; j++ {
+		e.frobenius = append(e.frobenius, e.Mul(e.frobenius[j-1], xp))
+	}
+	if !e.irreducible() {
+		return nil, fmt.Errorf("modulus %v is reducible over GF(%v)", modulus, base.order)
+	}
+	return e, nil
+}
+
+// Base returns the base Field.
+func (e *ExtField) Base() *Field {
+	return e.base
+}
+
+// Degree returns the degree k of the extension.
+func (e *ExtField) Degree() int {
+	return len(e.modulus)
+}
+
+// Modulus returns the coefficients of the irreducible polynomial, including
+// the leading 1.
+func (e *ExtField) Modulus() []*big.Int {
+	m := make([]*big.Int, 0, len(e.modulus)+1)
+	for _, c := range e.modulus {
+		m = append(m, new(big.Int).Set(c))
+	}
+	return append(m, big.NewInt(1))
+}
+
+// Order returns the number of elements p^k.
+func (e *ExtField) Order() *big.Int {
+	return new(big.Int).Exp(e.base.order, big.NewInt(int64(e.Degree())), nil)
+}
+
+// Element returns the element with the specified coefficients, at most
+// e.Degree() of them, reduced into the base Field. It panics if there are more
+// coefficients.
+func (e *ExtField) Element(coeffs ...*big.Int) ExtElement {
+	k := e.Degree()
+	if len(coeffs) > k {
+		panic(fmt.Sprintf("%d coefficients exceed extension degree %d", len(coeffs), k))
+	}
+	x := make(ExtElement, k)
+	for i := range x {
+		x[i] = new(big.Int)
+		if i < len(coeffs) {
+			x[i].Mod(coeffs[i], e.base.order)
+		}
+	}
+	return x
+}
+
+// Zero returns the additive identity.
+func (e *ExtField) Zero() ExtElement {
+	return e.Element()
+}
+
+// One returns the multiplicative identity.
+func (e *ExtField) One() ExtElement {
+	return e.Element(bigOne)
+}
+
+// Random returns a uniformly random element, with coefficients drawn from r.
+func (e *ExtField) Random(r io.Reader) (ExtElement, error) {
+	x := make(ExtElement, e.Degree())
+	for i := range x {
+		c, err := e.base.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		x[i] = c
+	}
+	return x, nil
+}
+
+// Equal reports whether x and y are the same element.
+func (e *ExtField) Equal(x, y ExtElement) bool {
+	x, y = e.Element(x...), e.Element(y...)
+	for i := range x {
+		if x[i].Cmp(y[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero reports whether x is the additive identity.
+func (e *ExtField) IsZero(x ExtElement) bool {
+	return e.Equal(x, nil)
+}
+
+// Add returns x+y.
+func (e *ExtField) Add(x, y ExtElement) ExtElement {
+	z := e.Element(x...)
+	for i, c := range y {
+		z[i] = e.base.Add(z[i], e.base.Mod(new(big.Int).Set(c)))
+	}
+	return z
+}
+
+// Sub returns x-y.
+func (e *ExtField) Sub(x, y ExtElement) ExtElement {
+	z := e.Element(x...)
+	for i, c := range y {
+		z[i] = e.base.Sub(z[i], e.base.Mod(new(big.Int).Set(c)))
+	}
+	return z
+}
+
+// Neg returns -x.
+func (e *ExtField) Neg(x ExtElement) ExtElement {
+	return e.Sub(nil, x)
+}
+
+// ScalarMul returns c*x for c in the base Field.
+func (e *ExtField) ScalarMul(x ExtElement, c *big.Int) ExtElement {
+	z := e.Element(x...)
+	c = new(big.Int).Mod(c, e.base.order)
+	for i := range z {
+		z[i] = e.base.Mul(z[i], c)
+	}
+	return z
+}
+
+// Mul returns x*y, the product of the polynomials reduced modulo m.
+func (e *ExtField) Mul(x, y ExtElement) ExtElement {
+	return e.reduce(polyMul(e.base, x, y))
+}
+
+// Square returns x*x.
+func (e *ExtField) Square(x ExtElement) ExtElement {
+	return e.Mul(x, x)
+}
+
+// Exp returns x^n for n >= 0.
+func (e *ExtField) Exp(x ExtElement, n *big.Int) ExtElement {
+	z := e.One()
+	x = e.Element(x...)
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		z = e.Square(z)
+		if n.Bit(i) == 1 {
+			z = e.Mul(z, x)
+		}
+	}
+	return z
+}
+
+// Inverse returns the multiplicative inverse of x, computed with the extended
+// Euclidean algorithm, or ErrNotInvertible for zero.
+func (e *ExtField) Inverse(x ExtElement) (ExtElement, error) {
+	a := polyTrim(e.base, x)
+	if len(a) == 0 {
+		return nil, fmt.Errorf("%w: zero in GF(%v^%d)", ErrNotInvertible, e.base.order, e.Degree())
+	}
+	// Invariant: s*x = r mod m for (r, s) in {(r0, s0), (r1, s1)}.
+	r0, r1 := e.Modulus(), a
+	s0, s1 := []*big.Int(nil), []*big.Int{big.NewInt(1)}
+	for len(r1) > 1 {
+		q, r, err := polyDivMod(e.base, r0, r1)
+		if err != nil {
+			return nil, err
+		}
+		r0, r1 = r1, r
+		s0, s1 = s1, polySub(e.base, s0, polyMul(e.base, q, s1))
+	}
+	if len(r1) == 0 {
+		// Unreachable as m is irreducible and x non-zero.
+		return nil, errors.New("x shares a factor with the modulus")
+	}
+	inv, err := e.base.MultInverse(r1[0])
+	if err != nil {
+		return nil, err
+	}
+	return e.ScalarMul(e.reduce(s1), inv), nil
+}
+
+// Div returns x/y, or ErrNotInvertible if y is zero.
+func (e *ExtField) Div(x, y ExtElement) (ExtElement, error) {
+	inv, err := e.Inverse(y)
+	if err != nil {
+		return nil, err
+	}
+	return e.Mul(x, inv), nil
+}
+
+// Frobenius returns x^(p^i), the i-th power of the Frobenius automorphism of
+// the extension over its base Field.
+func (e *ExtField) Frobenius(x ExtElement, i int) ExtElement {
+	x = e.Element(x...)
+	for i %= e.Degree(); i > 0; i-- {
+		x = e.frobenius1(x)
+	}
+	return x
+}
+
+// frobenius1 returns x^p for canonical x with k coefficients.
+func (e *ExtField) frobenius1(x ExtElement) ExtElement {
+	y := e.Zero()
+	for j, xj := range x {
+		if xj.Sign() != 0 {
+			y = e.Add(y, e.ScalarMul(e.frobenius[j], xj))
+		}
+	}
+	return y
+}
+
+// conjugates returns the Galois conjugates x^(p^i) of x for i in [0, k).
+func (e *ExtField) conjugates(x ExtElement) []ExtElement {
+	cs := []ExtElement{e.Element(x...)}
+	for i := 1; i < e.Degree(); i++ {
+		cs = append(cs, e.frobenius1(cs[i-1]))
+	}
+	return cs
+}
+
+// Norm returns the norm of x over the base Field, the product of its Galois
+// conjugates.
+func (e *ExtField) Norm(x ExtElement) *big.Int {
+	n := e.One()
+	for _, c := range e.conjugates(x) {
+		n = e.Mul(n, c)
+	}
+	return n[0]
+}
+
+// Trace returns the trace of x over the base Field, the sum of its Galois
+// conjugates.
+func (e *ExtField) Trace(x ExtElement) *big.Int {
+	t := e.Zero()
+	for _, c := range e.conjugates(x) {
+		t = e.Add(t, c)
+	}
+	return t[0]
+}
+
+// reduce returns the polynomial p of any degree reduced modulo m.
+func (e *ExtField) reduce(p []*big.Int) ExtElement {
+	k := e.Degree()
+	p = polyTrim(e.base, p)
+	for i := len(p) - 1; i >= k; i-- {
+		c := p[i]
+		if c.Sign() == 0 {
+			continue
+		}
+		// X^k = -m_0 - ... - m_{k-1} X^(k-1).
+		for j, mj := range e.modulus {
+			p[i-k+j] = e.base.Sub(p[i-k+j], e.base.Mul(c, mj))
+		}
+	}
+	if len(p) > k {
+		p = p[:k]
+	}
+	return e.Element(p...)
+}
+
+// irreducible reports whether m is irreducible with Rabin's test: a monic m of
+// degree k is irreducible iff X^(p^k) = X mod m and gcd(X^(p^(k/q)) - X, m) = 1
+// for every prime factor q of k.
+func (e *ExtField) irreducible() bool {
+	k := e.Degree()
+	x := e.Element(big.NewInt(0), big.NewInt(1))
+	for _, q := range primeFactors(uint64(k)) {
+		d := polyTrim(e.base, e.Sub(e.Frobenius(x, k/int(q)), x))
+		g, err := polyGCD(e.base, e.Modulus(), d)
+		if err != nil || len(g) != 1 {
+			return false
+		}
+	}
+	// Frobenius reduces i modulo k, so X^(p^k) is computed explicitly.
+	return e.Equal(e.frobenius1(e.Frobenius(x, k-1)), x)
+}
+
+// polyTrim returns copies of the coefficients reduced into f, without
+// trailing zeros.
+func polyTrim(f *Field, p []*big.Int) []*big.Int {
+	out := make([]*big.Int, len(p))
+	for i, c := range p {
+		out[i] = new(big.Int).Mod(c, f.order)
+	}
+	for len(out) > 0 && out[len(out)-1].Sign() == 0 {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+func polyMul(f *Field, a, b []*big.Int) []*big.Int {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	out := make([]*big.Int, len(a)+len(b)-1)
+	for i := range out {
+		out[i] = new(big.Int)
+	}
+	for i, x := range a {
+		for j, y := range b {
+			out[i+j] = f.Add(out[i+j], f.Mul(x, y))
+		}
+	}
+	return polyTrim(f, out)
+}
+
+func polySub(f *Field, a, b []*big.Int) []*big.Int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := make([]*big.Int, n)
+	for i := range out {
+		x, y := new(big.Int), new(big.Int)
+		if i < len(a) {
+			x.Mod(a[i], f.order)
+		}
+		if i < len(b) {
+			y.Mod(b[i], f.order)
+		}
+		out[i] = f.Sub(x, y)
+	}
+	return polyTrim(f, out)
+}
+
+// polyDivMod returns the quotient and remainder of a / b for non-zero b.
+func polyDivMod(f *Field, a, b []*big.Int) ([]*big.Int, []*big.Int, error) {
+	r, b := polyTrim(f, a), polyTrim(f, b)
+	if len(b) == 0 {
+		return nil, nil, errors.New("division by the zero polynomial")
+	}
+	lead, err := f.MultInverse(b[len(b)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(r) < len(b) {
+		return nil, r, nil
+	}
+	q := make([]*big.Int, len(r)-len(b)+1)
+	for i := len(q) - 1; i >= 0; i-- {
+		c := f.Mul(r[i+len(b)-1], lead)
+		q[i] = c
+		for j, bj := range b {
+			r[i+j] = f.Sub(r[i+j], f.Mul(c, bj))
+		}
+	}
+	return polyTrim(f, q), polyTrim(f, r), nil
+}
+
+// polyGCD returns a greatest common divisor of a and b, not necessarily
+// monic.
+func polyGCD(f *Field, a, b []*big.Int) ([]*big.Int, error) {
+	a, b = polyTrim(f, a), polyTrim(f, b)
+	for len(b) > 0 {
+		_, r, err := polyDivMod(f, a, b)
+		if err != nil {
+			return nil, err
+		}
+		a, b = b, r
+	}
+	return a, nil
+}
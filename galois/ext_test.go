@@ -0,0 +1,162 @@
+package galois
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func coeffs(xs ...int64) []*big.Int {
+	out := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		out[i] = big.NewInt(x)
+	}
+	return out
+}
+
+func TestNewExtField(t *testing.T) {
+	tests := []struct {
+		p       int64
+		modulus []int64
+		ok      bool
+	}{
+		{p: 7, modulus: []int64{1, 0, 1}, ok: true},        // X^2 + 1, -1 a non-square mod 7
+		{p: 5, modulus: []int64{1, 0, 1}, ok: false},       // 2^2 = -1 mod 5
+		{p: 7, modulus: []int64{-2, 0, 0, 1}, ok: true},    // 2 isn't a cube mod 7
+		{p: 7, modulus: []int64{-1, 0, 0, 1}, ok: false},   // X^3 - 1 has the root 1
+		{p: 3, modulus: []int64{1, 0, 1, 0, 1}, ok: false}, // (X^2 + X + 2)(X^2 + 2X + 2), without roots
+		{p: 3, modulus: []int64{2, 1, 0, 0, 1}, ok: true},  // X^4 + X + 2
+		{p: 7, modulus: []int64{1, 1}, ok: false},          // degree 1
+		{p: 7, modulus: []int64{1, 0, 2}, ok: false},       // not monic
+	}
+	for _, tt := range tests {
+		_, err := NewExtField(NewField(big.NewInt(tt.p)), coeffs(tt.modulus...))
+		if got := err == nil; got != tt.ok {
+			t.Errorf("NewExtField(GF(%d), %v): got err %v; want ok = %t", tt.p, tt.modulus, err, tt.ok)
+		}
+	}
+}
+
+// extFields returns extensions of small and bn256-sized fields.
+func extFields(t *testing.T) []*ExtField {
+	t.Helper()
+	p := bn256.P
+	// bn256's GF(p^12) = GF(p)[w] / (w^12 - 18 w^6 + 82).
+	fp12 := coeffs(82, 0, 0, 0, 0, 0, -18, 0, 0, 0, 0, 0, 1)
+	configs := []struct {
+		p       *big.Int
+		modulus []*big.Int
+	}{
+		{big.NewInt(7), coeffs(1, 0, 1)},
+		{big.NewInt(7), coeffs(-2, 0, 0, 1)},
+		{big.NewInt(3), coeffs(2, 1, 0, 0, 1)},
+		{p, coeffs(1, 0, 1)},
+		{p, fp12},
+	}
+	var fs []*ExtField
+	for _, c := range configs {
+		e, err := NewExtField(NewField(c.p), c.modulus)
+		if err != nil {
+			t.Fatalf("NewExtField(GF(%v), %v): %v", c.p, c.modulus, err)
+		}
+		fs = append(fs, e)
+	}
+	return fs
+}
+
+func TestExtFieldArithmetic(t *testing.T) {
+	for _, e := range extFields(t) {
+		k := e.Degree()
+		for i := 0; i < 5; i++ {
+			x, err := e.Random(rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			y, err := e.Random(rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			if e.IsZero(x) || e.IsZero(y) {
+				continue
+			}
+
+			inv, err := e.Inverse(x)
+			if err != nil {
+				t.Fatalf("GF(%v^%d): Inverse(%v): %v", e.Base().Order(), k, x, err)
+			}
+			if got := e.Mul(x, inv); !e.Equal(got, e.One()) {
+				t.Errorf("GF(%v^%d): x * Inverse(x) = %v; want 1", e.Base().Order(), k, got)
+			}
+			q, err := e.Div(e.Mul(x, y), y)
+			if err != nil {
+				t.Fatalf("Div(): %v", err)
+			}
+			if !e.Equal(q, x) {
+				t.Errorf("GF(%v^%d): Div(x*y, y) = %v; want %v", e.Base().Order(), k, q, x)
+			}
+			if got := e.Sub(e.Add(x, y), y); !e.Equal(got, x) {
+				t.Errorf("GF(%v^%d): x + y - y = %v; want %v", e.Base().Order(), k, got, x)
+			}
+			if got := e.Add(x, e.Neg(x)); !e.IsZero(got) {
+				t.Errorf("GF(%v^%d): x + Neg(x) = %v; want 0", e.Base().Order(), k, got)
+			}
+
+			// The Frobenius is a field automorphism of order k.
+			if got := e.Frobenius(e.Mul(x, y), 1); !e.Equal(got, e.Mul(e.Frobenius(x, 1), e.Frobenius(y, 1))) {
+				t.Errorf("GF(%v^%d): Frobenius(x*y) != Frobenius(x) * Frobenius(y)", e.Base().Order(), k)
+			}
+			if got := e.Frobenius(x, k); !e.Equal(got, x) {
+				t.Errorf("GF(%v^%d): Frobenius(x, %d) = %v; want %v", e.Base().Order(), k, k, got, x)
+			}
+
+			// The norm is multiplicative and the trace additive.
+			f := e.Base()
+			if got, want := e.Norm(e.Mul(x, y)), f.Mul(e.Norm(x), e.Norm(y)); got.Cmp(want) != 0 {
+				t.Errorf("GF(%v^%d): Norm(x*y) = %v; want Norm(x) * Norm(y) = %v", f.Order(), k, got, want)
+			}
+			if got, want := e.Trace(e.Add(x, y)), f.Add(e.Trace(x), e.Trace(y)); got.Cmp(want) != 0 {
+				t.Errorf("GF(%v^%d): Trace(x+y) = %v; want Trace(x) + Trace(y) = %v", f.Order(), k, got, want)
+			}
+		}
+	}
+}
+
+func TestExtFieldSmall(t *testing.T) {
+	e, err := NewExtField(NewField(big.NewInt(7)), coeffs(1, 0, 1))
+	if err != nil {
+		t.Fatalf("NewExtField(): %v", err)
+	}
+	if got, want := e.Order(), big.NewInt(49); got.Cmp(want) != 0 {
+		t.Errorf("Order() = %v; want %v", got, want)
+	}
+
+	// i^2 = -1, (1 + i)(1 - i) = 2 and (3 + 2i)^(p^2 - 1) = 1.
+	i := e.Element(big.NewInt(0), big.NewInt(1))
+	if got, want := e.Square(i), e.Element(big.NewInt(-1)); !e.Equal(got, want) {
+		t.Errorf("i^2 = %v; want %v", got, want)
+	}
+	if got, want := e.Mul(coeffs(1, 1), coeffs(1, -1)), e.Element(big.NewInt(2)); !e.Equal(got, want) {
+		t.Errorf("(1 + i)(1 - i) = %v; want %v", got, want)
+	}
+	if got := e.Exp(coeffs(3, 2), big.NewInt(48)); !e.Equal(got, e.One()) {
+		t.Errorf("(3 + 2i)^48 = %v; want 1", got)
+	}
+	// The conjugate of a + bi is a - bi, so the norm is a^2 + b^2 and the
+	// trace 2a.
+	if got, want := e.Frobenius(coeffs(3, 2), 1), e.Element(coeffs(3, -2)...); !e.Equal(got, want) {
+		t.Errorf("Frobenius(3 + 2i) = %v; want %v", got, want)
+	}
+	if got, want := e.Norm(coeffs(3, 2)), big.NewInt(13%7); got.Cmp(want) != 0 {
+		t.Errorf("Norm(3 + 2i) = %v; want %v", got, want)
+	}
+	if got, want := e.Trace(coeffs(3, 2)), big.NewInt(6); got.Cmp(want) != 0 {
+		t.Errorf("Trace(3 + 2i) = %v; want %v", got, want)
+	}
+
+	if _, err := e.Inverse(e.Zero()); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("Inverse(0): got err %v; want %v", err, ErrNotInvertible)
+	}
+}
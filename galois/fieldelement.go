@@ -1,28 +1,159 @@
 package galois
 
-import "math/big"
+import (
+	"fmt"
+	"math/big"
+)
 
+// A FieldElement is an element of a Field, viewed as an element of its additive
+// group with generator 1. Its methods mirror those of the bn256 group elements,
+// setting the receiver to the result and returning it, so that it satisfies
+// polynomial.GroupElement and polynomials can be evaluated on powers in the
+// plain field, e.g. for testing without curve operations.
+//
+// The zero value represents zero in an unspecified Field, adopting the Field of
+// the first operand it is combined with. Until then, ScalarBaseMult doesn't
+// reduce its result, and Bytes and SetBytes are unavailable.
 type FieldElement struct {
 	x     *big.Int
 	Field *Field
 }
 
+// NewFieldElement returns x as an element of f. The value is copied and reduced
+// modulo f.Order().
 func NewFieldElement(x *big.Int, f *Field) *FieldElement {
-	return &FieldElement{x, f}
+	return &FieldElement{new(big.Int).Mod(x, f.order), f}
 }
 
-func (e *FieldElement) Set(a *FieldElement) {
-	e.x.Set(a.x)
+// Int returns the value of e in [0, e.Field.Order()).
+func (e *FieldElement) Int() *big.Int {
+	if e.x == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(e.x)
 }
 
-func (e *FieldElement) Add(a, b *FieldElement) {
-	e.x = e.Field.Add(a.x, b.x)
+// adopt sets e.Field to the first non-nil Field of e and the operands.
+func (e *FieldElement) adopt(operands ...*FieldElement) {
+	for _, o := range operands {
+		if e.Field != nil {
+			return
+		}
+		e.Field = o.Field
+	}
 }
 
-func (e *FieldElement) Mul(a *FieldElement, scalar *big.Int) {
-	e.x = e.Field.Mul(a.x, scalar)
+// reduce sets e to x mod e.Field.Order(), or x itself if e has no Field.
+func (e *FieldElement) reduce(x *big.Int) *FieldElement {
+	if e.Field == nil {
+		e.x = x
+		return e
+	}
+	e.x = x.Mod(x, e.Field.order)
+	return e
 }
 
-func (e *FieldElement) SetInfinity() {
-	e.x.Set(bigZero)
+// Set sets e to a and returns e.
+func (e *FieldElement) Set(a *FieldElement) *FieldElement {
+	e.Field = a.Field
+	e.x = a.Int()
+	return e
+}
+
+// Add sets e to a+b and returns e.
+func (e *FieldElement) Add(a, b *FieldElement) *FieldElement {
+	e.adopt(a, b)
+	return e.reduce(new(big.Int).Add(a.Int(), b.Int()))
+}
+
+// Sub sets e to a-b and returns e.
+func (e *FieldElement) Sub(a, b *FieldElement) *FieldElement {
+	e.adopt(a, b)
+	return e.reduce(new(big.Int).Sub(a.Int(), b.Int()))
+}
+
+// Neg sets e to -a and returns e.
+func (e *FieldElement) Neg(a *FieldElement) *FieldElement {
+	e.adopt(a)
+	return e.reduce(new(big.Int).Neg(a.Int()))
+}
+
+// ScalarMult sets e to scalar*a and returns e.
+func (e *FieldElement) ScalarMult(a *FieldElement, scalar *big.Int) *FieldElement {
+	e.adopt(a)
+	return e.reduce(new(big.Int).Mul(a.Int(), scalar))
+}
+
+// ScalarBaseMult sets e to scalar*1 and returns e.
+func (e *FieldElement) ScalarBaseMult(scalar *big.Int) *FieldElement {
+	return e.reduce(new(big.Int).Set(scalar))
+}
+
+// Mul is equivalent to ScalarMult.
+func (e *FieldElement) Mul(a *FieldElement, scalar *big.Int) *FieldElement {
+	return e.ScalarMult(a, scalar)
+}
+
+// SetInfinity sets e to zero, the identity of the additive group, and returns
+// e.
+func (e *FieldElement) SetInfinity() *FieldElement {
+	e.x = new(big.Int)
+	return e
+}
+
+// Inverse sets e to the multiplicative inverse of a and returns e, or returns
+// an error wrapping ErrNotInvertible if it doesn't exist, in which case e is
+// unchanged.
+func (e *FieldElement) Inverse(a *FieldElement) (*FieldElement, error) {
+	if a.Field == nil {
+		return nil, fmt.Errorf("Inverse() of %v without a Field", a.Int())
+	}
+	inv, err := a.Field.MultInverse(a.Int())
+	if err != nil {
+		return nil, err
+	}
+	e.Field = a.Field
+	e.x = inv
+	return e, nil
+}
+
+// Equal reports whether e and b are equal elements of Fields of equal order. A
+// zero value without a Field is only equal to zero.
+func (e *FieldElement) Equal(b *FieldElement) bool {
+	f := e.Field
+	if f == nil {
+		f = b.Field
+	}
+	if f == nil {
+		return e.Int().Cmp(b.Int()) == 0
+	}
+	if e.Field != nil && b.Field != nil && e.Field.order.Cmp(b.Field.order) != 0 {
+		return false
+	}
+	return f.Equal(e.Int(), b.Int())
+}
+
+// Bytes returns the big-endian encoding of e, padded to the length of the
+// Field's order. It panics if e has no Field.
+func (e *FieldElement) Bytes() []byte {
+	return e.Int().FillBytes(make([]byte, e.Field.byteLen()))
+}
+
+// SetBytes sets e to the big-endian encoding b, as returned by Bytes, and
+// returns e. It returns an error if e has no Field, if b isn't of the encoded
+// length, or if the encoded value isn't less than the order, in which case e
+// is unchanged.
+func (e *FieldElement) SetBytes(b []byte) (*FieldElement, error) {
+	if e.Field == nil {
+		return nil, fmt.Errorf("SetBytes() without a Field")
+	}
+	if n := e.Field.byteLen(); len(b) != n {
+		return nil, fmt.Errorf("invalid encoding length %d; want %d", len(b), n)
+	}
+	x := new(big.Int).SetBytes(b)
+	if !e.Field.isCanonical(x) {
+		return nil, fmt.Errorf("encoded value %v not less than order %v", x, e.Field.order)
+	}
+	e.x = x
+	return e, nil
 }
@@ -0,0 +1,144 @@
+package galois_test
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+)
+
+var _ polynomial.GroupElement[*galois.FieldElement] = (*galois.FieldElement)(nil)
+
+func TestFieldElementArithmetic(t *testing.T) {
+	f := galois.NewField(big.NewInt(13))
+	el := func(x int64) *galois.FieldElement {
+		return galois.NewFieldElement(big.NewInt(x), f)
+	}
+	var zero galois.FieldElement
+
+	inv, err := new(galois.FieldElement).Inverse(el(5))
+	if err != nil {
+		t.Fatalf("Inverse(5): %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  *galois.FieldElement
+		want int64
+	}{
+		{name: "NewFieldElement(-1)", got: el(-1), want: 12},
+		{name: "Add(7, 9)", got: new(galois.FieldElement).Add(el(7), el(9)), want: 3},
+		{name: "Sub(2, 9)", got: new(galois.FieldElement).Sub(el(2), el(9)), want: 6},
+		{name: "Neg(4)", got: new(galois.FieldElement).Neg(el(4)), want: 9},
+		{name: "Neg(0)", got: new(galois.FieldElement).Neg(el(0)), want: 0},
+		{name: "ScalarMult(4, 5)", got: new(galois.FieldElement).ScalarMult(el(4), big.NewInt(5)), want: 7},
+		{name: "ScalarMult(4, -1)", got: new(galois.FieldElement).ScalarMult(el(4), big.NewInt(-1)), want: 9},
+		{name: "ScalarBaseMult(27)", got: el(0).ScalarBaseMult(big.NewInt(27)), want: 1},
+		{name: "Set(11)", got: new(galois.FieldElement).Set(el(11)), want: 11},
+		{name: "SetInfinity()", got: el(5).SetInfinity(), want: 0},
+		{name: "Inverse(5)", got: inv, want: 8},
+		{name: "Add(<zero value>, 5)", got: new(galois.FieldElement).Add(&zero, el(5)), want: 5},
+	}
+
+	for _, tt := range tests {
+		if got := tt.got.Int(); got.Int64() != tt.want {
+			t.Errorf("%s = %v; want %d", tt.name, got, tt.want)
+		}
+		if got := tt.got.Field; got != f {
+			t.Errorf("%s.Field = %v; want %v", tt.name, got, f)
+		}
+	}
+
+	if _, err := new(galois.FieldElement).Inverse(el(0)); !errors.Is(err, galois.ErrNotInvertible) {
+		t.Errorf("Inverse(0): got err %v; want %v", err, galois.ErrNotInvertible)
+	}
+}
+
+func TestFieldElementEqual(t *testing.T) {
+	f13 := galois.NewField(big.NewInt(13))
+	f7 := galois.NewField(big.NewInt(7))
+
+	tests := []struct {
+		a, b *galois.FieldElement
+		want bool
+	}{
+		{a: galois.NewFieldElement(big.NewInt(3), f13), b: galois.NewFieldElement(big.NewInt(16), f13), want: true},
+		{a: galois.NewFieldElement(big.NewInt(3), f13), b: galois.NewFieldElement(big.NewInt(4), f13), want: false},
+		{a: galois.NewFieldElement(big.NewInt(3), f13), b: galois.NewFieldElement(big.NewInt(3), f7), want: false},
+		{a: galois.NewFieldElement(big.NewInt(3), f13), b: galois.NewFieldElement(big.NewInt(3), galois.NewField(big.NewInt(13))), want: true},
+		{a: new(galois.FieldElement), b: galois.NewFieldElement(big.NewInt(13), f13), want: true},
+		{a: new(galois.FieldElement), b: galois.NewFieldElement(big.NewInt(1), f13), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("%v.Equal(%v) = %t; want %t", tt.a.Int(), tt.b.Int(), got, tt.want)
+		}
+		if got := tt.b.Equal(tt.a); got != tt.want {
+			t.Errorf("%v.Equal(%v) = %t; want %t", tt.b.Int(), tt.a.Int(), got, tt.want)
+		}
+	}
+}
+
+func TestFieldElementBytes(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	e := galois.NewFieldElement(big.NewInt(258), f)
+	b := e.Bytes()
+	if len(b) != 32 {
+		t.Fatalf("len(Bytes()) = %d; want 32", len(b))
+	}
+	if !bytes.Equal(b[30:], []byte{1, 2}) {
+		t.Errorf("Bytes() = %x; want big-endian 258", b)
+	}
+
+	got, err := (&galois.FieldElement{Field: f}).SetBytes(b)
+	if err != nil {
+		t.Fatalf("SetBytes(Bytes()): %v", err)
+	}
+	if !got.Equal(e) {
+		t.Errorf("SetBytes(Bytes()) = %v; want %v", got.Int(), e.Int())
+	}
+
+	for _, b := range [][]byte{
+		b[1:],
+		append(b, 0),
+		bn256.Order.FillBytes(make([]byte, 32)),
+	} {
+		if _, err := (&galois.FieldElement{Field: f}).SetBytes(b); err == nil {
+			t.Errorf("SetBytes(%x): got nil error", b)
+		}
+	}
+	if _, err := new(galois.FieldElement).SetBytes(b); err == nil {
+		t.Errorf("SetBytes() without Field: got nil error")
+	}
+}
+
+func TestFieldElementEvaluateOnPowers(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	x := big.NewInt(1337)
+
+	for _, n := range []int{1, 5, polynomial.ParallelEvaluateThreshold + 1} {
+		p := polynomial.NewZeroPolynomial(n - 1)
+		for i := range *p {
+			(*p)[i] = big.NewInt(int64(i*i - 7))
+		}
+
+		var powers []*galois.FieldElement
+		for _, y := range polynomial.ComputePowers(x, n, f) {
+			powers = append(powers, galois.NewFieldElement(y, f))
+		}
+
+		got, err := polynomial.EvaluateOnPowers(p, powers)
+		if err != nil {
+			t.Fatalf("EvaluateOnPowers(<degree %d>): %v", n-1, err)
+		}
+		if want := galois.NewFieldElement(p.Evaluate(x, f), f); !got.Equal(want) {
+			t.Errorf("EvaluateOnPowers(<degree %d>) = %v; want %v", n-1, got.Int(), want.Int())
+		}
+	}
+}
@@ -1,11 +1,17 @@
 // Package galois provides functionality over Galois finite fields, implemented
-// over the integers modulo n.
+// over the integers modulo n. Prime Fields are extended to GF(p^k) by an
+// ExtField.
 //
-// Operations do NOT run in cryptographic constant time.
+// Operations do NOT run in cryptographic constant time, with the exception of
+// Field.MultInverseCT and Field.Equal, whose control flow and memory access
+// patterns are independent of their inputs. As they are implemented with
+// math/big, which makes no timing guarantees of its own, they are only a best
+// effort and SHOULD NOT be relied upon where timing side channels are critical.
 package galois
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -16,67 +22,176 @@ var (
 	bigOne  = big.NewInt(1)
 )
 
+// ErrNotInvertible is returned when attempting to invert an element that has
+// no multiplicative inverse, i.e. one that isn't coprime with the order. In a
+// prime field this is only the case for zero.
+var ErrNotInvertible = errors.New("element not invertible")
+
+// primalityRounds is the number of Miller-Rabin rounds performed by
+// NewPrimeField, in addition to the Baillie-PSW test of big.Int.ProbablyPrime.
+const primalityRounds = 20
+
 // A Field represents a finite field of specific order.
-type Field big.Int
+type Field struct {
+	order    *big.Int
+	strategy ReductionStrategy
+	reducer  reducer
+	ring     bool
+}
 
-// NewField returns a new Field of the specified order.
+// NewField returns a new Field of the specified order, using the GenericMod
+// reduction strategy. The order is not validated; see NewPrimeField and
+// NewRing for prime and composite orders respectively.
 func NewField(order *big.Int) *Field {
-	return (*Field)(order)
+	f, err := NewFieldWithStrategy(order, GenericMod)
+	if err != nil {
+		// GenericMod is valid for all moduli.
+		panic(err)
+	}
+	return f
+}
+
+// NewFieldWithStrategy returns a new Field of the specified order, performing
+// modular reduction of products with the specified strategy. Montgomery
+// reduction requires an odd order.
+func NewFieldWithStrategy(order *big.Int, s ReductionStrategy) (*Field, error) {
+	n := new(big.Int).Set(order)
+	r, err := newReducer(n, s)
+	if err != nil {
+		return nil, err
+	}
+	return &Field{order: n, strategy: s, reducer: r}, nil
+}
+
+// NewPrimeField is equivalent to NewField but returns an error if the order is
+// not prime, as determined by a probabilistic primality test. This guarantees
+// that all non-zero elements are invertible.
+func NewPrimeField(order *big.Int) (*Field, error) {
+	if !order.ProbablyPrime(primalityRounds) {
+		return nil, fmt.Errorf("field order %v is not prime", order)
+	}
+	return NewField(order), nil
+}
+
+// NewRing returns the ring of integers modulo order, which may be composite but
+// MUST be greater than 1. Arithmetic is identical to that of a Field, except
+// that elements sharing a factor with the order have no multiplicative inverse:
+// MultInverse, Div, BatchInverse and DivSlice return errors wrapping
+// ErrNotInvertible for them. Sqrt, which requires a prime order, always returns
+// an error.
+func NewRing(order *big.Int) (*Field, error) {
+	if order.Cmp(bigOne) <= 0 {
+		return nil, fmt.Errorf("ring order %v must be greater than 1", order)
+	}
+	f := NewField(order)
+	f.ring = true
+	return f, nil
+}
+
+// IsRing reports whether f was returned by NewRing.
+func (f *Field) IsRing() bool {
+	return f.ring
 }
 
 // Order returns the order of the Field.
 func (f *Field) Order() *big.Int {
-	return new(big.Int).Set((*big.Int)(f))
+	return new(big.Int).Set(f.order)
+}
+
+// Strategy returns the ReductionStrategy with which the Field was constructed.
+func (f *Field) Strategy() ReductionStrategy {
+	return f.strategy
 }
 
 // Add returns x+y mod f.Order().
 func (f *Field) Add(x, y *big.Int) *big.Int {
 	p := new(big.Int).Add(x, y)
-	return p.Mod(p, f.Order())
+	if f.isCanonical(x) && f.isCanonical(y) {
+		// A single subtraction suffices and avoids the division of Mod.
+		if p.Cmp(f.order) >= 0 {
+			p.Sub(p, f.order)
+		}
+		return p
+	}
+	return p.Mod(p, f.order)
 }
 
 // Add returns x-y mod f.Order().
 func (f *Field) Sub(x, y *big.Int) *big.Int {
 	p := new(big.Int).Sub(x, y)
-	return p.Mod(p, f.Order())
+	if f.isCanonical(x) && f.isCanonical(y) {
+		if p.Sign() < 0 {
+			p.Add(p, f.order)
+		}
+		return p
+	}
+	return p.Mod(p, f.order)
+}
+
+// isCanonical reports whether 0 <= x < f.Order().
+func (f *Field) isCanonical(x *big.Int) bool {
+	return x.Sign() >= 0 && x.Cmp(f.order) < 0
 }
 
 func (f *Field) Mod(x *big.Int) *big.Int {
-	return x.Mod(x, f.Order())
+	return x.Mod(x, f.order)
 }
 
 // Exp returns x**y mod f.Order().
 func (f *Field) Exp(x, y *big.Int) *big.Int {
-	return new(big.Int).Exp(x, y, f.Order())
+	return new(big.Int).Exp(x, y, f.order)
 }
 
 // Mul returns x*y mod f.Order().
 func (f *Field) Mul(x, y *big.Int) *big.Int {
-	p := new(big.Int).Mul(x, y)
-	return p.Mod(p, f.Order())
+	return f.reducer.mul(x, y)
 }
 
-// MultInverse returns the multiplicative inverse of x.
-func (f *Field) MultInverse(x *big.Int) *big.Int {
-	return new(big.Int).ModInverse(x, f.Order())
+// Square returns x*x mod f.Order().
+func (f *Field) Square(x *big.Int) *big.Int {
+	return f.reducer.mul(x, x)
 }
 
-// Mul returns x*(1/y) mod f.Order().
-func (f *Field) Div(x, y *big.Int) *big.Int {
-	p := new(big.Int).Mul(x, f.MultInverse(y))
-	return p.Mod(p, f.Order())
+// MultInverse returns the multiplicative inverse of x, or ErrNotInvertible if
+// it doesn't exist.
+func (f *Field) MultInverse(x *big.Int) (*big.Int, error) {
+	inv := new(big.Int).ModInverse(x, f.order)
+	if inv == nil {
+		return nil, fmt.Errorf("%w: %v mod %v", ErrNotInvertible, x, f.order)
+	}
+	return inv, nil
+}
+
+// Div returns x*(1/y) mod f.Order(), or ErrNotInvertible if y has no inverse.
+func (f *Field) Div(x, y *big.Int) (*big.Int, error) {
+	inv, err := f.MultInverse(y)
+	if err != nil {
+		return nil, err
+	}
+	return f.Mul(x, inv), nil
 }
 
 // Random returns a random field element from [0,q). The Reader is propagated to
 // rand.Int().
 func (f *Field) Random(r io.Reader) (*big.Int, error) {
-	x, err := rand.Int(r, f.Order())
+	x, err := rand.Int(r, f.order)
 	if err != nil {
 		return nil, fmt.Errorf("rand.Int(): %v", err)
 	}
 	return x, nil
 }
 
+// HasSubgroupOfOrder reports whether the multiplicative group of the field has
+// a subgroup of order n, i.e. whether n divides q-1. This is a precondition for
+// the existence of primitive nth roots of unity.
+func (f *Field) HasSubgroupOfOrder(n uint64) bool {
+	if n == 0 {
+		return false
+	}
+	qSub1 := new(big.Int).Sub(f.order, bigOne)
+	return new(big.Int).Mod(qSub1, new(big.Int).SetUint64(n)).Sign() == 0
+}
+
 // RootOfUnity returns a random nth root of unity; n must be even. A primitive
 // root is one that can be used to generate all corresponding roots by raising
 // it to each of [0,n). If n does not divide (q-1), the only root is 1 itself,
@@ -84,35 +199,111 @@ func (f *Field) Random(r io.Reader) (*big.Int, error) {
 //
 // The io.Reader is used to choose a random field element from which a root is
 // determined via crypto/rand.Int(). If primitive==false this will only be
-// called once, but primitive roots are determined probabilistically with 50%
-// success rate on each attempt.
+// called until a non-zero element is found, but primitive roots are determined
+// probabilistically with phi(n)/n success rate on each attempt (50% if n is a
+// power of two).
 //
 // The implementation is based on https://crypto.stackexchange.com/a/63616. As
 // we'rein a cyclic group, for any non-zero x: x^q = x, so x^(q-1) = 1. Since
 // x^(y/n)^n = x^y, x^((q-1)/n) is an nth root of 1 mod q.
 func (f *Field) RootOfUnity(r io.Reader, n uint64, primitive bool) (*big.Int, error) {
-	if n%2 == 1 {
-		return nil, fmt.Errorf("can only calculate even roots of unity; n = %d", n)
+	if n == 0 || n%2 == 1 {
+		return nil, fmt.Errorf("can only calculate non-zero, even roots of unity; n = %d", n)
 	}
 
-	bigN := new(big.Int).SetUint64(n)
-
-	qSub1 := new(big.Int).Sub(f.Order(), bigOne)
-	qSub1OverN, rem := new(big.Int).DivMod(qSub1, bigN, new(big.Int))
-	if rem.Cmp(bigZero) == 0 {
+	if !f.HasSubgroupOfOrder(n) {
 		return big.NewInt(1), nil
 	}
+	qSub1 := new(big.Int).Sub(f.order, bigOne)
+	qSub1OverN := qSub1.Div(qSub1, new(big.Int).SetUint64(n))
+
+	// A root of order n is primitive iff raising it to n/p for any prime
+	// factor p of n doesn't yield 1.
+	var cofactors []*big.Int
+	for _, p := range primeFactors(n) {
+		cofactors = append(cofactors, new(big.Int).SetUint64(n/p))
+	}
 
-	halfN := new(big.Int).Rsh(bigN, 1)
-	var root *big.Int
 	for {
 		x, err := f.Random(r)
 		if err != nil {
 			return nil, err
 		}
-		root = f.Exp(x, qSub1OverN)
-		if !primitive || f.Exp(root, halfN).Cmp(bigOne) != 0 {
+		if x.Sign() == 0 {
+			continue
+		}
+		root := f.Exp(x, qSub1OverN)
+		if !primitive || isPrimitive(f, root, cofactors) {
+			return root, nil
+		}
+	}
+}
+
+// TwoAdicity returns the largest s such that 2^s divides q-1, i.e. the
+// largest power of two for which primitive roots of unity exist.
+func (f *Field) TwoAdicity() int {
+	qSub1 := new(big.Int).Sub(f.order, bigOne)
+	if qSub1.Sign() == 0 {
+		return 0
+	}
+	return int(qSub1.TrailingZeroBits())
+}
+
+// PrimitiveRootOfUnity returns the canonical primitive nth root of unity
+// x^((q-1)/n) for the smallest x > 1 for which it is primitive, or an error if
+// n doesn't divide q-1. Unlike RootOfUnity it is deterministic, so roots are
+// reproducible across machines.
+//
+// For powers of two n, x is the smallest quadratic non-residue, independent of
+// n, so the root of order n is the square of that of order 2n. In the BN254
+// scalar field this is x = 5, matching the root of order 2^28 of gnark-crypto,
+// but not EIP-4844, which derives its roots from 7.
+func (f *Field) PrimitiveRootOfUnity(n uint64) (*big.Int, error) {
+	if !f.HasSubgroupOfOrder(n) {
+		return nil, fmt.Errorf("no primitive %dth root of unity as n ∤ (q-1) = %v", n, new(big.Int).Sub(f.order, bigOne))
+	}
+	if n == 1 {
+		return big.NewInt(1), nil
+	}
+	qSub1OverN := new(big.Int).Sub(f.order, bigOne)
+	qSub1OverN.Div(qSub1OverN, new(big.Int).SetUint64(n))
+
+	var cofactors []*big.Int
+	for _, p := range primeFactors(n) {
+		cofactors = append(cofactors, new(big.Int).SetUint64(n/p))
+	}
+	for x := big.NewInt(2); x.Cmp(f.order) < 0; x.Add(x, bigOne) {
+		if root := f.Exp(x, qSub1OverN); isPrimitive(f, root, cofactors) {
 			return root, nil
 		}
 	}
+	// Unreachable for prime q, whose multiplicative group is cyclic.
+	return nil, fmt.Errorf("no primitive %dth root of unity in ring of order %v", n, f.order)
+}
+
+func isPrimitive(f *Field, root *big.Int, cofactors []*big.Int) bool {
+	for _, c := range cofactors {
+		if f.Exp(root, c).Cmp(bigOne) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// primeFactors returns the distinct prime factors of n, in increasing order.
+func primeFactors(n uint64) []uint64 {
+	var ps []uint64
+	for p := uint64(2); p*p <= n; p++ {
+		if n%p != 0 {
+			continue
+		}
+		ps = append(ps, p)
+		for n%p == 0 {
+			n /= p
+		}
+	}
+	if n > 1 {
+		ps = append(ps, n)
+	}
+	return ps
 }
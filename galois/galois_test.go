@@ -0,0 +1,419 @@
+package galois
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	mrand "math/rand"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+var strategies = []ReductionStrategy{GenericMod, Barrett, Montgomery, Montgomery256}
+
+func TestReductionStrategies(t *testing.T) {
+	orders := []*big.Int{
+		big.NewInt(3),
+		big.NewInt(7),
+		big.NewInt(65537),
+		bn256.Order,
+		bn256.P,
+		// The largest prime below 2^256, exercising carries of Montgomery256.
+		new(big.Int).Sub(new(big.Int).Lsh(bigOne, 256), big.NewInt(189)),
+	}
+
+	for _, order := range orders {
+		generic := NewField(order)
+
+		inputs := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1),
+			big.NewInt(-1),
+			new(big.Int).Sub(order, bigOne),
+			order,
+			new(big.Int).Mul(order, big.NewInt(3)),
+		}
+		for i := 0; i < 20; i++ {
+			x, err := generic.Random(rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			inputs = append(inputs, x)
+		}
+
+		for _, s := range strategies {
+			f, err := NewFieldWithStrategy(order, s)
+			if err != nil {
+				t.Fatalf("NewFieldWithStrategy(%v, %v): %v", order, s, err)
+			}
+
+			for _, x := range inputs {
+				for _, y := range inputs {
+					if got, want := f.Mul(x, y), generic.Mul(x, y); got.Cmp(want) != 0 {
+						t.Errorf("%v: Mul(%v, %v) mod %v: want %v, got %v", s, x, y, order, want, got)
+					}
+				}
+				if got, want := f.Square(x), generic.Mul(x, x); got.Cmp(want) != 0 {
+					t.Errorf("%v: Square(%v) mod %v: want %v, got %v", s, x, order, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestMontgomeryRequiresOddOrder(t *testing.T) {
+	for _, s := range []ReductionStrategy{Montgomery, Montgomery256} {
+		if _, err := NewFieldWithStrategy(big.NewInt(100), s); err == nil {
+			t.Errorf("NewFieldWithStrategy(100, %v): got nil error", s)
+		}
+	}
+}
+
+func TestMontgomery256RequiresSmallOrder(t *testing.T) {
+	order := new(big.Int).Add(new(big.Int).Lsh(bigOne, 256), bigOne)
+	if _, err := NewFieldWithStrategy(order, Montgomery256); err == nil {
+		t.Errorf("NewFieldWithStrategy(2^256+1, Montgomery256): got nil error")
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	for _, s := range strategies {
+		b.Run(s.String(), func(b *testing.B) {
+			f, err := NewFieldWithStrategy(bn256.Order, s)
+			if err != nil {
+				b.Fatalf("NewFieldWithStrategy(bn256.Order, %v): %v", s, err)
+			}
+			x, err := f.Random(rand.Reader)
+			if err != nil {
+				b.Fatalf("Random(): %v", err)
+			}
+			y, err := f.Random(rand.Reader)
+			if err != nil {
+				b.Fatalf("Random(): %v", err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				f.Mul(x, y)
+			}
+		})
+	}
+}
+
+func TestNewPrimeField(t *testing.T) {
+	tests := []struct {
+		order     *big.Int
+		wantPrime bool
+	}{
+		{order: big.NewInt(2), wantPrime: true},
+		{order: big.NewInt(7), wantPrime: true},
+		{order: big.NewInt(10), wantPrime: false},
+		{order: big.NewInt(100), wantPrime: false},
+		{order: big.NewInt(561), wantPrime: false}, // Carmichael number
+		{order: bn256.Order, wantPrime: true},
+		{order: new(big.Int).Mul(bn256.Order, bn256.P), wantPrime: false},
+	}
+
+	for _, tt := range tests {
+		_, err := NewPrimeField(tt.order)
+		if got := err == nil; got != tt.wantPrime {
+			t.Errorf("NewPrimeField(%v) got err %v; want prime = %t", tt.order, err, tt.wantPrime)
+		}
+	}
+}
+
+func TestNewRing(t *testing.T) {
+	for _, order := range []*big.Int{big.NewInt(-7), big.NewInt(0), big.NewInt(1)} {
+		if _, err := NewRing(order); err == nil {
+			t.Errorf("NewRing(%v): got nil error", order)
+		}
+	}
+
+	r, err := NewRing(big.NewInt(100))
+	if err != nil {
+		t.Fatalf("NewRing(100): %v", err)
+	}
+	if !r.IsRing() {
+		t.Errorf("NewRing(100).IsRing() = false; want true")
+	}
+	if NewField(big.NewInt(100)).IsRing() {
+		t.Errorf("NewField(100).IsRing() = true; want false")
+	}
+
+	if got, want := r.Mul(big.NewInt(12), big.NewInt(25)), big.NewInt(0); got.Cmp(want) != 0 {
+		t.Errorf("Mul(12, 25) mod 100 = %v; want %v", got, want)
+	}
+	if _, err := r.BatchInverse(bigInts(3, 7, 10)); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("BatchInverse(3, 7, 10) mod 100: got err %v; want %v", err, ErrNotInvertible)
+	}
+	if _, err := r.Sqrt(big.NewInt(4)); err == nil {
+		t.Errorf("Sqrt(4) mod 100 in ring: got nil error")
+	}
+}
+
+func bigInts(xs ...int64) []*big.Int {
+	bs := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		bs[i] = big.NewInt(x)
+	}
+	return bs
+}
+
+func TestNotInvertible(t *testing.T) {
+	f := NewField(big.NewInt(100))
+
+	if _, err := f.MultInverse(big.NewInt(10)); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("MultInverse(10) mod 100: got err %v; want %v", err, ErrNotInvertible)
+	}
+	if _, err := f.Div(big.NewInt(1), big.NewInt(2)); !errors.Is(err, ErrNotInvertible) {
+		t.Errorf("Div(1, 2) mod 100: got err %v; want %v", err, ErrNotInvertible)
+	}
+
+	got, err := f.Div(big.NewInt(1), big.NewInt(3))
+	if err != nil {
+		t.Fatalf("Div(1, 3) mod 100: %v", err)
+	}
+	if want := big.NewInt(67); got.Cmp(want) != 0 {
+		t.Errorf("Div(1, 3) mod 100: got %v; want %v", got, want)
+	}
+}
+
+func FuzzRootOfUnity(f *testing.F) {
+	seeds := []struct {
+		q uint32
+		n uint16
+	}{
+		// n | (q-1)
+		{q: 3, n: 2},
+		{q: 7, n: 6},
+		{q: 13, n: 4},
+		{q: 17, n: 16},
+		{q: 65537, n: 256},
+		// n ∤ (q-1)
+		{q: 7, n: 4},
+		{q: 13, n: 8},
+		{q: 65521, n: 32},
+	}
+	for _, s := range seeds {
+		for _, primitive := range []bool{false, true} {
+			f.Add(s.q, s.n, primitive, int64(s.q)*int64(s.n))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, q uint32, n uint16, primitive bool, seed int64) {
+		order := big.NewInt(int64(q))
+		if n == 0 || n%2 == 1 || !order.ProbablyPrime(primalityRounds) {
+			t.Skip()
+		}
+		field := NewField(order)
+
+		root, err := field.RootOfUnity(mrand.New(mrand.NewSource(seed)), uint64(n), primitive)
+		if err != nil {
+			t.Fatalf("RootOfUnity(%d): %v", n, err)
+		}
+
+		bigN := big.NewInt(int64(n))
+		if got := field.Exp(root, bigN); got.Cmp(bigOne) != 0 {
+			t.Errorf("RootOfUnity(%d) mod %d = %v; %v^%d = %v, want 1", n, q, root, root, n, got)
+		}
+
+		if (q-1)%uint32(n) != 0 {
+			if root.Cmp(bigOne) != 0 {
+				t.Errorf("RootOfUnity(%d) mod %d = %v; want 1 as n ∤ (q-1)", n, q, root)
+			}
+			return
+		}
+		if !primitive {
+			return
+		}
+
+		if got := field.Exp(root, big.NewInt(int64(n/2))); got.Cmp(bigOne) == 0 {
+			t.Errorf("RootOfUnity(%d, primitive) mod %d = %v; %v^%d = 1", n, q, root, root, n/2)
+		}
+		// All n powers of a primitive root are distinct.
+		seen := make(map[int64]bool)
+		x := big.NewInt(1)
+		for i := uint16(0); i < n; i++ {
+			seen[x.Int64()] = true
+			x = field.Mul(x, root)
+		}
+		if len(seen) != int(n) {
+			t.Errorf("RootOfUnity(%d, primitive) mod %d = %v generates %d distinct powers; want %d", n, q, root, len(seen), n)
+		}
+	})
+}
+
+func TestHasSubgroupOfOrder(t *testing.T) {
+	f := NewField(big.NewInt(13))
+
+	for n := uint64(0); n <= 24; n++ {
+		want := n != 0 && 12%n == 0
+		if got := f.HasSubgroupOfOrder(n); got != want {
+			t.Errorf("HasSubgroupOfOrder(%d) mod 13 = %t; want %t", n, got, want)
+		}
+	}
+
+	if !NewField(bn256.Order).HasSubgroupOfOrder(1 << 28) {
+		t.Errorf("HasSubgroupOfOrder(2^28) mod bn256.Order = false; want true")
+	}
+}
+
+func TestPrimitiveRootOfUnity(t *testing.T) {
+	bn254Root, _ := new(big.Int).SetString("19103219067921713944291392827692070036145651957329286315305642004821462161904", 10)
+	tests := []struct {
+		q       *big.Int
+		n       uint64
+		want    *big.Int
+		adicity int
+	}{
+		{q: big.NewInt(17), n: 1, want: big.NewInt(1), adicity: 4},
+		{q: big.NewInt(17), n: 4, want: big.NewInt(13), adicity: 4},
+		{q: big.NewInt(17), n: 16, want: big.NewInt(3), adicity: 4},
+		{q: big.NewInt(13), n: 3, want: big.NewInt(3), adicity: 2},
+		{q: big.NewInt(13), n: 12, want: big.NewInt(2), adicity: 2},
+		{q: big.NewInt(7), n: 6, want: big.NewInt(3), adicity: 1},
+		{q: new(big.Int).SetUint64(GoldilocksModulus), n: 1 << 32, want: new(big.Int).SetUint64(1753635133440165772), adicity: 32},
+		{q: bn256.Order, n: 1 << 28, want: bn254Root, adicity: 28},
+	}
+
+	for _, tt := range tests {
+		f := NewField(tt.q)
+		got, err := f.PrimitiveRootOfUnity(tt.n)
+		if err != nil {
+			t.Errorf("PrimitiveRootOfUnity(%d) mod %v: %v", tt.n, tt.q, err)
+			continue
+		}
+		if got.Cmp(tt.want) != 0 {
+			t.Errorf("PrimitiveRootOfUnity(%d) mod %v = %v; want %v", tt.n, tt.q, got, tt.want)
+		}
+		if got := f.TwoAdicity(); got != tt.adicity {
+			t.Errorf("TwoAdicity() mod %v = %d; want %d", tt.q, got, tt.adicity)
+		}
+	}
+
+	for _, n := range []uint64{0, 5, 32} {
+		if _, err := NewField(big.NewInt(17)).PrimitiveRootOfUnity(n); err == nil {
+			t.Errorf("PrimitiveRootOfUnity(%d) mod 17: got nil error", n)
+		}
+	}
+}
+
+func TestMultInverseCT(t *testing.T) {
+	for _, order := range []*big.Int{big.NewInt(2), big.NewInt(13), big.NewInt(65537), bn256.Order} {
+		f := NewField(order)
+
+		inputs := []*big.Int{big.NewInt(1), new(big.Int).Sub(order, bigOne), order, new(big.Int).Add(order, bigOne)}
+		for i := 0; i < 20; i++ {
+			x, err := f.Random(rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			if x.Sign() != 0 {
+				inputs = append(inputs, x)
+			}
+		}
+
+		for _, x := range inputs {
+			want, wantErr := f.MultInverse(x)
+			got, err := f.MultInverseCT(x)
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("MultInverseCT(%v) mod %v: got err %v; want %v", x, order, err, wantErr)
+			}
+			if err == nil && got.Cmp(want) != 0 {
+				t.Errorf("MultInverseCT(%v) mod %v = %v; want %v", x, order, got, want)
+			}
+		}
+
+		if _, err := f.MultInverseCT(big.NewInt(0)); !errors.Is(err, ErrNotInvertible) {
+			t.Errorf("MultInverseCT(0) mod %v: got err %v; want %v", order, err, ErrNotInvertible)
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	f := NewField(big.NewInt(13))
+
+	tests := []struct {
+		x, y int64
+		want bool
+	}{
+		{x: 0, y: 0, want: true},
+		{x: 1, y: 14, want: true},
+		{x: -1, y: 12, want: true},
+		{x: 1, y: 2, want: false},
+		{x: 0, y: 13, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := f.Equal(big.NewInt(tt.x), big.NewInt(tt.y)); got != tt.want {
+			t.Errorf("Equal(%d, %d) mod 13 = %t; want %t", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestMontgomeryBatch(t *testing.T) {
+	f, err := NewFieldWithStrategy(bn256.Order, Montgomery)
+	if err != nil {
+		t.Fatalf("NewFieldWithStrategy(): %v", err)
+	}
+
+	var xs, orig []*big.Int
+	for i := 0; i < 20; i++ {
+		x, err := f.Random(rand.Reader)
+		if err != nil {
+			t.Fatalf("Random(): %v", err)
+		}
+		xs = append(xs, x)
+		orig = append(orig, new(big.Int).Set(x))
+	}
+	xs = append(xs, big.NewInt(0), big.NewInt(1))
+	orig = append(orig, big.NewInt(0), big.NewInt(1))
+
+	if err := f.ToMontgomeryBatch(xs); err != nil {
+		t.Fatalf("ToMontgomeryBatch(): %v", err)
+	}
+
+	// Arithmetic in Montgomery form, converted back, matches standard form.
+	var got, want []*big.Int
+	for i := 1; i < len(xs); i++ {
+		prod, err := f.MulMontgomery(xs[i-1], xs[i])
+		if err != nil {
+			t.Fatalf("MulMontgomery(): %v", err)
+		}
+		got = append(got, prod, f.Add(xs[i-1], xs[i]), f.Sub(xs[i-1], xs[i]))
+		want = append(want, f.Mul(orig[i-1], orig[i]), f.Add(orig[i-1], orig[i]), f.Sub(orig[i-1], orig[i]))
+	}
+	if err := f.FromMontgomeryBatch(got); err != nil {
+		t.Fatalf("FromMontgomeryBatch(): %v", err)
+	}
+	for i := range got {
+		if got[i].Cmp(want[i]) != 0 {
+			t.Errorf("Montgomery-form arithmetic [%d]: got %v; want %v", i, got[i], want[i])
+		}
+	}
+
+	if err := f.FromMontgomeryBatch(xs); err != nil {
+		t.Fatalf("FromMontgomeryBatch(): %v", err)
+	}
+	for i := range xs {
+		if xs[i].Cmp(orig[i]) != 0 {
+			t.Errorf("FromMontgomeryBatch(ToMontgomeryBatch(%v)) = %v", orig[i], xs[i])
+		}
+	}
+}
+
+func TestMontgomeryBatchRequiresStrategy(t *testing.T) {
+	f := NewField(bn256.Order)
+	xs := []*big.Int{big.NewInt(1)}
+
+	if err := f.ToMontgomeryBatch(xs); !errors.Is(err, ErrNotMontgomery) {
+		t.Errorf("ToMontgomeryBatch() on GenericMod field: got err %v; want %v", err, ErrNotMontgomery)
+	}
+	if err := f.FromMontgomeryBatch(xs); !errors.Is(err, ErrNotMontgomery) {
+		t.Errorf("FromMontgomeryBatch() on GenericMod field: got err %v; want %v", err, ErrNotMontgomery)
+	}
+	if _, err := f.MulMontgomery(xs[0], xs[0]); !errors.Is(err, ErrNotMontgomery) {
+		t.Errorf("MulMontgomery() on GenericMod field: got err %v; want %v", err, ErrNotMontgomery)
+	}
+}
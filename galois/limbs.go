@@ -0,0 +1,123 @@
+package galois
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// limbs is an integer in [0, 2^256) as little-endian 64-bit words.
+type limbs [4]uint64
+
+// montgomery256Reducer implements Montgomery multiplication with R = 2^256 on
+// fixed-size limbs, for odd moduli n < 2^256. Unlike montgomeryReducer, no
+// intermediate big.Int values are allocated.
+type montgomery256Reducer struct {
+	n     *big.Int
+	nl    limbs
+	nInv  uint64 // -n^-1 mod 2^64
+	r2    limbs  // R^2 mod n
+	words int    // big.Words per limb
+}
+
+func newMontgomery256Reducer(n *big.Int) (*montgomery256Reducer, error) {
+	if n.BitLen() > 256 {
+		return nil, fmt.Errorf("Montgomery256 reduction requires an order < 2^256; got %d bits", n.BitLen())
+	}
+
+	// Newton iteration for n^-1 mod 2^64; each step doubles the correct bits,
+	// starting from 3 correct bits as n*n = 1 mod 8 for odd n.
+	n0 := n.Uint64()
+	inv := n0
+	for i := 0; i < 5; i++ {
+		inv *= 2 - n0*inv
+	}
+
+	r2 := new(big.Int).Lsh(bigOne, 512)
+	r2.Mod(r2, n)
+
+	r := &montgomery256Reducer{n: n, nInv: -inv, words: 64 / bits.UintSize}
+	r.nl = r.toLimbs(n)
+	r.r2 = r.toLimbs(r2)
+	return r, nil
+}
+
+// toLimbs returns canonical x, which MUST be in [0, 2^256).
+func (r *montgomery256Reducer) toLimbs(x *big.Int) limbs {
+	var l limbs
+	for i, w := range x.Bits() {
+		l[i/r.words] |= uint64(w) << (uint(i%r.words) * bits.UintSize)
+	}
+	return l
+}
+
+func (r *montgomery256Reducer) fromLimbs(l limbs) *big.Int {
+	ws := make([]big.Word, 4*r.words)
+	for i := range ws {
+		ws[i] = big.Word(l[i/r.words] >> (uint(i%r.words) * bits.UintSize))
+	}
+	return new(big.Int).SetBits(ws)
+}
+
+// montMul returns a*b*R^-1 mod n for a, b < n using the coarsely integrated
+// operand scanning (CIOS) method.
+func (r *montgomery256Reducer) montMul(a, b *limbs) limbs {
+	var t [6]uint64
+	n := &r.nl
+
+	for i := 0; i < 4; i++ {
+		var c uint64
+		for j := 0; j < 4; j++ {
+			c, t[j] = madd(a[j], b[i], t[j], c)
+		}
+		var carry uint64
+		t[4], carry = bits.Add64(t[4], c, 0)
+		t[5] = carry
+
+		m := t[0] * r.nInv
+		c, _ = madd(m, n[0], t[0], 0)
+		for j := 1; j < 4; j++ {
+			c, t[j-1] = madd(m, n[j], t[j], c)
+		}
+		t[3], carry = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + carry
+	}
+
+	res := limbs{t[0], t[1], t[2], t[3]}
+	if t[4] != 0 || !less(&res, n) {
+		var b uint64
+		for j := 0; j < 4; j++ {
+			res[j], b = bits.Sub64(res[j], n[j], b)
+		}
+	}
+	return res
+}
+
+// madd returns the high and low words of a*b + c + d.
+func madd(a, b, c, d uint64) (hi, lo uint64) {
+	hi, lo = bits.Mul64(a, b)
+	var carry uint64
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, d, 0)
+	hi += carry
+	return hi, lo
+}
+
+// less reports whether a < b.
+func less(a, b *limbs) bool {
+	for i := 3; i >= 0; i-- {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func (r *montgomery256Reducer) mul(x, y *big.Int) *big.Int {
+	a, b := r.toLimbs(canonical(x, r.n)), r.toLimbs(canonical(y, r.n))
+	// montMul(a, b) = a*b*R^-1, so a second multiplication by R^2 cancels the
+	// factor.
+	t := r.montMul(&a, &b)
+	return r.fromLimbs(r.montMul(&t, &r.r2))
+}
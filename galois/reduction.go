@@ -0,0 +1,222 @@
+package galois
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// A ReductionStrategy determines how a Field reduces products modulo its order.
+// All strategies produce identical results; they differ only in setup cost and
+// per-operation speed.
+type ReductionStrategy int
+
+const (
+	// GenericMod reduces with big.Int.Mod and has no setup cost.
+	GenericMod ReductionStrategy = iota
+	// Barrett reduction replaces the division in big.Int.Mod with two
+	// multiplications by a precomputed constant.
+	Barrett
+	// Montgomery reduction replaces the division with multiplications and
+	// shifts, but requires an odd order. As Field operations accept and return
+	// values in standard form, every product is converted out of Montgomery
+	// form, which requires a second reduction.
+	Montgomery
+	// Montgomery256 is equivalent to Montgomery, but operates on fixed 4x64-bit
+	// limbs instead of big.Int intermediates. It requires an odd order below
+	// 2^256, such as bn256.Order, and is the fastest strategy for them.
+	Montgomery256
+)
+
+// String returns the name of the strategy.
+func (s ReductionStrategy) String() string {
+	switch s {
+	case GenericMod:
+		return "GenericMod"
+	case Barrett:
+		return "Barrett"
+	case Montgomery:
+		return "Montgomery"
+	case Montgomery256:
+		return "Montgomery256"
+	default:
+		return fmt.Sprintf("ReductionStrategy(%d)", int(s))
+	}
+}
+
+// A reducer computes x*y mod n for its modulus n.
+type reducer interface {
+	mul(x, y *big.Int) *big.Int
+}
+
+func newReducer(n *big.Int, s ReductionStrategy) (reducer, error) {
+	if s == GenericMod {
+		return genericReducer{n}, nil
+	}
+	if n.Cmp(bigOne) <= 0 {
+		return nil, fmt.Errorf("%v reduction requires an order > 1; got %v", s, n)
+	}
+
+	switch s {
+	case Barrett:
+		return newBarrettReducer(n), nil
+	case Montgomery:
+		if n.Bit(0) == 0 {
+			return nil, fmt.Errorf("Montgomery reduction requires an odd order; got %v", n)
+		}
+		return newMontgomeryReducer(n), nil
+	case Montgomery256:
+		if n.Bit(0) == 0 {
+			return nil, fmt.Errorf("Montgomery256 reduction requires an odd order; got %v", n)
+		}
+		return newMontgomery256Reducer(n)
+	default:
+		return nil, fmt.Errorf("unsupported reduction strategy %v", s)
+	}
+}
+
+// canonical returns x if 0 <= x < n, otherwise a new big.Int holding x mod n.
+func canonical(x, n *big.Int) *big.Int {
+	if x.Sign() >= 0 && x.Cmp(n) < 0 {
+		return x
+	}
+	return new(big.Int).Mod(x, n)
+}
+
+type genericReducer struct {
+	n *big.Int
+}
+
+func (r genericReducer) mul(x, y *big.Int) *big.Int {
+	p := new(big.Int).Mul(x, y)
+	return p.Mod(p, r.n)
+}
+
+// barrettReducer implements Barrett reduction for products of canonical
+// values, i.e. for 0 <= a < n^2.
+type barrettReducer struct {
+	n  *big.Int
+	k  uint
+	mu *big.Int // floor(4^k / n)
+}
+
+func newBarrettReducer(n *big.Int) *barrettReducer {
+	k := uint(n.BitLen())
+	mu := new(big.Int).Lsh(bigOne, 2*k)
+	return &barrettReducer{n: n, k: k, mu: mu.Div(mu, n)}
+}
+
+func (r *barrettReducer) mul(x, y *big.Int) *big.Int {
+	a := new(big.Int).Mul(canonical(x, r.n), canonical(y, r.n))
+
+	q := new(big.Int).Rsh(a, r.k-1)
+	q.Mul(q, r.mu)
+	q.Rsh(q, r.k+1)
+
+	a.Sub(a, q.Mul(q, r.n))
+	// The estimate of the quotient is off by at most 2.
+	for a.Cmp(r.n) >= 0 {
+		a.Sub(a, r.n)
+	}
+	return a
+}
+
+// montgomeryReducer implements Montgomery reduction with R = 2^k > n.
+type montgomeryReducer struct {
+	n      *big.Int
+	k      uint
+	mask   *big.Int // R-1
+	nPrime *big.Int // -n^-1 mod R
+	r2     *big.Int // R^2 mod n
+}
+
+func newMontgomeryReducer(n *big.Int) *montgomeryReducer {
+	k := uint(n.BitLen())
+	r := new(big.Int).Lsh(bigOne, k)
+
+	nPrime := new(big.Int).ModInverse(n, r)
+	nPrime.Sub(r, nPrime)
+
+	r2 := new(big.Int).Lsh(bigOne, 2*k)
+	r2.Mod(r2, n)
+
+	return &montgomeryReducer{
+		n:      n,
+		k:      k,
+		mask:   r.Sub(r, bigOne),
+		nPrime: nPrime,
+		r2:     r2,
+	}
+}
+
+// redc returns t*R^-1 mod n for 0 <= t < n*R, modifying t in place.
+func (r *montgomeryReducer) redc(t *big.Int) *big.Int {
+	m := new(big.Int).And(t, r.mask)
+	m.Mul(m, r.nPrime)
+	m.And(m, r.mask)
+
+	t.Add(t, m.Mul(m, r.n))
+	t.Rsh(t, r.k)
+	if t.Cmp(r.n) >= 0 {
+		t.Sub(t, r.n)
+	}
+	return t
+}
+
+func (r *montgomeryReducer) mul(x, y *big.Int) *big.Int {
+	t := new(big.Int).Mul(canonical(x, r.n), canonical(y, r.n))
+	// REDC(x*y) = x*y*R^-1, so a second reduction with R^2 cancels the factor.
+	t = r.redc(t)
+	return r.redc(t.Mul(t, r.r2))
+}
+
+// ErrNotMontgomery is returned by Montgomery-form operations on a Field that
+// wasn't constructed with the Montgomery strategy.
+var ErrNotMontgomery = errors.New("field does not use Montgomery reduction")
+
+func (f *Field) montgomery() (*montgomeryReducer, error) {
+	r, ok := f.reducer.(*montgomeryReducer)
+	if !ok {
+		return nil, ErrNotMontgomery
+	}
+	return r, nil
+}
+
+// ToMontgomeryBatch converts each of xs in place into Montgomery form x*R mod
+// f.Order(), with a single reduction per element. Values in Montgomery form are
+// added and subtracted as usual, but MUST be multiplied with MulMontgomery.
+func (f *Field) ToMontgomeryBatch(xs []*big.Int) error {
+	r, err := f.montgomery()
+	if err != nil {
+		return err
+	}
+	for _, x := range xs {
+		// REDC(x * R^2) = x*R
+		x.Set(r.redc(x.Mul(canonical(x, r.n), r.r2)))
+	}
+	return nil
+}
+
+// FromMontgomeryBatch is the inverse of ToMontgomeryBatch.
+func (f *Field) FromMontgomeryBatch(xs []*big.Int) error {
+	r, err := f.montgomery()
+	if err != nil {
+		return err
+	}
+	for _, x := range xs {
+		// REDC(x*R) = x
+		x.Set(r.redc(x.Set(canonical(x, r.n))))
+	}
+	return nil
+}
+
+// MulMontgomery returns the product of x and y, both in Montgomery form, in
+// Montgomery form. It requires a single reduction, as opposed to the two of
+// Mul.
+func (f *Field) MulMontgomery(x, y *big.Int) (*big.Int, error) {
+	r, err := f.montgomery()
+	if err != nil {
+		return nil, err
+	}
+	return r.redc(new(big.Int).Mul(canonical(x, r.n), canonical(y, r.n))), nil
+}
@@ -0,0 +1,288 @@
+package galois
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// A SmallPrimeElement is an Element of a prime field of at most 64 bits, such
+// as Goldilocks and BabyBear, as used by STARKs. Elements are reduced values
+// and arithmetic doesn't allocate.
+//
+// Methods that don't depend on the element, such as Modulus, ignore their
+// receiver.
+type SmallPrimeElement[E any] interface {
+	Element[E]
+	// Exp returns the receiver raised to the power n.
+	Exp(n uint64) E
+	// Uint64 returns the canonical representative in [0, Modulus()).
+	Uint64() uint64
+	Modulus() uint64
+	// RootOfUnity returns a primitive nth root of unity for a power of two n
+	// dividing Modulus()-1.
+	RootOfUnity(n uint64) (E, error)
+}
+
+// GoldilocksModulus is the Goldilocks prime 2^64 - 2^32 + 1.
+const GoldilocksModulus = 1<<64 - 1<<32 + 1
+
+// A Goldilocks is an element of the field of order GoldilocksModulus, whose
+// multiplicative group has a subgroup of order 2^32 for FFTs.
+type Goldilocks uint64
+
+// goldilocksGenerator generates the multiplicative group of the Goldilocks
+// field.
+const goldilocksGenerator Goldilocks = 7
+
+// NewGoldilocks returns x reduced modulo GoldilocksModulus.
+func NewGoldilocks(x uint64) Goldilocks {
+	if x >= GoldilocksModulus {
+		x -= GoldilocksModulus
+	}
+	return Goldilocks(x)
+}
+
+// goldilocksReduce returns hi*2^64 + lo modulo GoldilocksModulus, exploiting
+// 2^64 = 2^32 - 1 and 2^96 = -1.
+func goldilocksReduce(hi, lo uint64) Goldilocks {
+	const epsilon = 1<<32 - 1 // 2^64 mod p
+	t, borrow := bits.Sub64(lo, hi>>32, 0)
+	if borrow != 0 {
+		t -= epsilon
+	}
+	r, carry := bits.Add64(t, (hi&epsilon)*epsilon, 0)
+	if carry != 0 {
+		r += epsilon
+	}
+	return NewGoldilocks(r)
+}
+
+// Add returns a+b.
+func (a Goldilocks) Add(b Goldilocks) Goldilocks {
+	s, carry := bits.Add64(uint64(a), uint64(b), 0)
+	if carry != 0 || s >= GoldilocksModulus {
+		s -= GoldilocksModulus
+	}
+	return Goldilocks(s)
+}
+
+// Sub returns a-b.
+func (a Goldilocks) Sub(b Goldilocks) Goldilocks {
+	d, borrow := bits.Sub64(uint64(a), uint64(b), 0)
+	if borrow != 0 {
+		d += GoldilocksModulus
+	}
+	return Goldilocks(d)
+}
+
+// Mul returns a*b.
+func (a Goldilocks) Mul(b Goldilocks) Goldilocks {
+	return goldilocksReduce(bits.Mul64(uint64(a), uint64(b)))
+}
+
+// Neg returns -a.
+func (a Goldilocks) Neg() Goldilocks {
+	return Goldilocks(0).Sub(a)
+}
+
+// Exp returns a^n.
+func (a Goldilocks) Exp(n uint64) Goldilocks {
+	return exp(a, n)
+}
+
+// Inverse returns the multiplicative inverse of a, or ErrNotInvertible for
+// zero.
+func (a Goldilocks) Inverse() (Goldilocks, error) {
+	return inverse(a, "Goldilocks")
+}
+
+// IsZero reports whether a is zero.
+func (a Goldilocks) IsZero() bool {
+	return a == 0
+}
+
+// One returns the multiplicative identity, independent of the receiver.
+func (Goldilocks) One() Goldilocks {
+	return 1
+}
+
+// Uint64 returns a as an integer in [0, Modulus()).
+func (a Goldilocks) Uint64() uint64 {
+	return uint64(a)
+}
+
+// Modulus returns the order of the field.
+func (Goldilocks) Modulus() uint64 {
+	return GoldilocksModulus
+}
+
+// RootOfUnity returns a primitive nth root of unity for a power of two n
+// dividing Modulus()-1, independent of the receiver.
+func (Goldilocks) RootOfUnity(n uint64) (Goldilocks, error) {
+	return rootOfUnity(goldilocksGenerator, n)
+}
+
+// Bytes returns the 8-byte big-endian encoding of a.
+func (a Goldilocks) Bytes() []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(a))
+	return buf
+}
+
+// Random returns RandomGoldilocks(r), independent of the receiver.
+func (Goldilocks) Random(r io.Reader) (Goldilocks, error) {
+	return RandomGoldilocks(r)
+}
+
+// RandomGoldilocks returns a uniformly random Goldilocks, rejection-sampled
+// from 8-byte values read from r.
+func RandomGoldilocks(r io.Reader) (Goldilocks, error) {
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, err
+		}
+		if x := binary.BigEndian.Uint64(buf[:]); x < GoldilocksModulus {
+			return Goldilocks(x), nil
+		}
+	}
+}
+
+// BabyBearModulus is the BabyBear prime 15 * 2^27 + 1.
+const BabyBearModulus = 15<<27 + 1
+
+// A BabyBear is an element of the field of order BabyBearModulus, whose
+// elements fit into 31 bits and whose multiplicative group has a subgroup of
+// order 2^27 for FFTs.
+type BabyBear uint32
+
+// babyBearGenerator generates the multiplicative group of the BabyBear field.
+const babyBearGenerator BabyBear = 31
+
+// NewBabyBear returns x reduced modulo BabyBearModulus.
+func NewBabyBear(x uint64) BabyBear {
+	return BabyBear(x % BabyBearModulus)
+}
+
+// Add returns a+b.
+func (a BabyBear) Add(b BabyBear) BabyBear {
+	// The sum of reduced elements fits into 32 bits.
+	s := uint32(a) + uint32(b)
+	if s >= BabyBearModulus {
+		s -= BabyBearModulus
+	}
+	return BabyBear(s)
+}
+
+// Sub returns a-b.
+func (a BabyBear) Sub(b BabyBear) BabyBear {
+	if a >= b {
+		return a - b
+	}
+	return BabyBear(uint32(a) + BabyBearModulus - uint32(b))
+}
+
+// Mul returns a*b.
+func (a BabyBear) Mul(b BabyBear) BabyBear {
+	return BabyBear(uint64(a) * uint64(b) % BabyBearModulus)
+}
+
+// Neg returns -a.
+func (a BabyBear) Neg() BabyBear {
+	return BabyBear(0).Sub(a)
+}
+
+// Exp returns a^n.
+func (a BabyBear) Exp(n uint64) BabyBear {
+	return exp(a, n)
+}
+
+// Inverse returns the multiplicative inverse of a, or ErrNotInvertible for
+// zero.
+func (a BabyBear) Inverse() (BabyBear, error) {
+	return inverse(a, "BabyBear")
+}
+
+// IsZero reports whether a is zero.
+func (a BabyBear) IsZero() bool {
+	return a == 0
+}
+
+// One returns the multiplicative identity, independent of the receiver.
+func (BabyBear) One() BabyBear {
+	return 1
+}
+
+// Uint64 returns a as an integer in [0, Modulus()).
+func (a BabyBear) Uint64() uint64 {
+	return uint64(a)
+}
+
+// Modulus returns the order of the field.
+func (BabyBear) Modulus() uint64 {
+	return BabyBearModulus
+}
+
+// RootOfUnity returns a primitive nth root of unity for a power of two n
+// dividing Modulus()-1, independent of the receiver.
+func (BabyBear) RootOfUnity(n uint64) (BabyBear, error) {
+	return rootOfUnity(babyBearGenerator, n)
+}
+
+// Bytes returns the 4-byte big-endian encoding of a.
+func (a BabyBear) Bytes() []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(a))
+	return buf
+}
+
+// Random returns RandomBabyBear(r), independent of the receiver.
+func (BabyBear) Random(r io.Reader) (BabyBear, error) {
+	return RandomBabyBear(r)
+}
+
+// RandomBabyBear returns a uniformly random BabyBear, rejection-sampled from
+// 31-bit values read from r.
+func RandomBabyBear(r io.Reader) (BabyBear, error) {
+	var buf [4]byte
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, err
+		}
+		if x := binary.BigEndian.Uint32(buf[:]) >> 1; x < BabyBearModulus {
+			return BabyBear(x), nil
+		}
+	}
+}
+
+// exp returns a^n by square-and-multiply.
+func exp[E SmallPrimeElement[E]](a E, n uint64) E {
+	z := a.One()
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		z = z.Mul(z)
+		if n>>uint(i)&1 == 1 {
+			z = z.Mul(a)
+		}
+	}
+	return z
+}
+
+// inverse returns a^(p-2), the inverse of non-zero a by Fermat's little
+// theorem.
+func inverse[E SmallPrimeElement[E]](a E, name string) (E, error) {
+	if a.IsZero() {
+		return a, fmt.Errorf("%w: zero in %s field", ErrNotInvertible, name)
+	}
+	return a.Exp(a.Modulus() - 2), nil
+}
+
+// rootOfUnity returns g^((p-1)/n) for the generator g of the multiplicative
+// group, a primitive nth root of unity.
+func rootOfUnity[E SmallPrimeElement[E]](g E, n uint64) (E, error) {
+	if n == 0 || n&(n-1) != 0 || (g.Modulus()-1)%n != 0 {
+		return g, fmt.Errorf("no subgroup of order %d in the field of order %d", n, g.Modulus())
+	}
+	return g.Exp((g.Modulus() - 1) / n), nil
+}
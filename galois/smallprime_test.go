@@ -0,0 +1,138 @@
+package galois
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"testing"
+)
+
+// testSmallPrime checks the arithmetic of E against a Field of the same order,
+// the multiplicative generator g against the prime factors of the order minus
+// one, and the roots of unity up to the 2-adicity.
+func testSmallPrime[E SmallPrimeElement[E]](t *testing.T, random func(io.Reader) (E, error), fromUint64 func(uint64) E, g E, factors []uint64, adicity int) {
+	t.Helper()
+	var zero E
+	p := zero.Modulus()
+	f := NewField(new(big.Int).SetUint64(p))
+
+	inputs := []E{zero, zero.One(), fromUint64(2), fromUint64(p - 1), fromUint64(p - 2), fromUint64(1 << 32), fromUint64(1<<63 + 12345)}
+	for i := 0; i < 20; i++ {
+		x, err := random(rand.Reader)
+		if err != nil {
+			t.Fatalf("random(): %v", err)
+		}
+		inputs = append(inputs, x)
+	}
+	toBig := func(x E) *big.Int {
+		return new(big.Int).SetUint64(x.Uint64())
+	}
+
+	for _, a := range inputs {
+		if a.Uint64() >= p {
+			t.Fatalf("%d not reduced modulo %d", a.Uint64(), p)
+		}
+		for _, b := range inputs {
+			for _, op := range []struct {
+				name string
+				got  E
+				want *big.Int
+			}{
+				{"Add", a.Add(b), f.Add(toBig(a), toBig(b))},
+				{"Sub", a.Sub(b), f.Sub(toBig(a), toBig(b))},
+				{"Mul", a.Mul(b), f.Mul(toBig(a), toBig(b))},
+			} {
+				if toBig(op.got).Cmp(op.want) != 0 {
+					t.Errorf("%d.%s(%d) = %d; want %v", a.Uint64(), op.name, b.Uint64(), op.got.Uint64(), op.want)
+				}
+			}
+		}
+		if got := a.Add(a.Neg()); !got.IsZero() {
+			t.Errorf("%d + Neg() = %d; want 0", a.Uint64(), got.Uint64())
+		}
+		if got, want := a.Exp(1234567), f.Exp(toBig(a), big.NewInt(1234567)); toBig(got).Cmp(want) != 0 {
+			t.Errorf("%d.Exp(1234567) = %d; want %v", a.Uint64(), got.Uint64(), want)
+		}
+
+		inv, err := a.Inverse()
+		if a.IsZero() {
+			if !errors.Is(err, ErrNotInvertible) {
+				t.Errorf("Inverse(0): got err %v; want %v", err, ErrNotInvertible)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d.Inverse(): %v", a.Uint64(), err)
+		}
+		if got := a.Mul(inv); got != a.One() {
+			t.Errorf("%d * Inverse() = %d; want 1", a.Uint64(), got.Uint64())
+		}
+	}
+
+	for _, q := range factors {
+		if g.Exp((p-1)/q) == g.One() {
+			t.Errorf("generator %d of order dividing (p-1)/%d", g.Uint64(), q)
+		}
+	}
+	for k := 0; k <= adicity; k++ {
+		n := uint64(1) << k
+		w, err := g.RootOfUnity(n)
+		if err != nil {
+			t.Fatalf("RootOfUnity(%d): %v", n, err)
+		}
+		if w.Exp(n) != w.One() || (n > 1 && w.Exp(n/2) == w.One()) {
+			t.Errorf("RootOfUnity(%d) = %d not a primitive root", n, w.Uint64())
+		}
+	}
+	for _, n := range []uint64{0, 3, 1 << (adicity + 1)} {
+		if _, err := g.RootOfUnity(n); err == nil {
+			t.Errorf("RootOfUnity(%d): got nil error", n)
+		}
+	}
+}
+
+func TestGoldilocks(t *testing.T) {
+	testSmallPrime(t, RandomGoldilocks, NewGoldilocks, goldilocksGenerator, []uint64{2, 3, 5, 17, 257, 65537}, 32)
+
+	// Reduction of products hi*2^64 + lo with all combinations of extreme
+	// words.
+	ws := []uint64{0, 1, 1<<32 - 1, 1 << 32, 1<<63 - 1, 1 << 63, ^uint64(0)}
+	f := NewField(new(big.Int).SetUint64(GoldilocksModulus))
+	for _, hi := range ws {
+		for _, lo := range ws {
+			x := new(big.Int).Lsh(new(big.Int).SetUint64(hi), 64)
+			want := f.Mod(x.Add(x, new(big.Int).SetUint64(lo)))
+			if got := goldilocksReduce(hi, lo); got.Uint64() != want.Uint64() {
+				t.Errorf("goldilocksReduce(%#x, %#x) = %d; want %v", hi, lo, got.Uint64(), want)
+			}
+		}
+	}
+}
+
+func TestBabyBear(t *testing.T) {
+	testSmallPrime(t, RandomBabyBear, NewBabyBear, babyBearGenerator, []uint64{2, 3, 5}, 27)
+}
+
+func BenchmarkSmallPrimeMul(b *testing.B) {
+	b.Run("Goldilocks", func(b *testing.B) {
+		x, y := Goldilocks(0x0123456789abcdef), Goldilocks(0xfedcba9876543210%GoldilocksModulus)
+		for i := 0; i < b.N; i++ {
+			x = x.Mul(y)
+		}
+	})
+	b.Run("BabyBear", func(b *testing.B) {
+		x, y := BabyBear(0x01234567), BabyBear(0x7654321)
+		for i := 0; i < b.N; i++ {
+			x = x.Mul(y)
+		}
+	})
+	b.Run(fmt.Sprintf("Field(%d)", uint64(GoldilocksModulus)), func(b *testing.B) {
+		f := NewField(new(big.Int).SetUint64(GoldilocksModulus))
+		x, y := big.NewInt(0x0123456789abcdef), big.NewInt(0x7edcba9876543210)
+		for i := 0; i < b.N; i++ {
+			x = f.Mul(x, y)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package galois
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrNoSquareRoot is returned by Sqrt for quadratic non-residues.
+var ErrNoSquareRoot = errors.New("element has no square root")
+
+var bigTwo = big.NewInt(2)
+
+// Legendre returns the Legendre symbol of x with respect to the order, which
+// MUST be prime: 0 if x is zero in f, 1 if x is a non-zero square, and -1
+// otherwise.
+func (f *Field) Legendre(x *big.Int) int {
+	x = new(big.Int).Mod(x, f.order)
+	if x.Sign() == 0 {
+		return 0
+	}
+	if f.order.Cmp(bigTwo) == 0 {
+		return 1
+	}
+
+	// Euler's criterion: x^((q-1)/2) is 1 for squares and -1 otherwise.
+	e := new(big.Int).Rsh(f.order, 1)
+	if f.Exp(x, e).Cmp(bigOne) == 0 {
+		return 1
+	}
+	return -1
+}
+
+// Sqrt returns a square root r of x, i.e. r*r = x mod f.Order(), or
+// ErrNoSquareRoot if x is a quadratic non-residue. The order MUST be prime, and
+// an error is returned for rings from NewRing. The other root, if distinct, is
+// -r. Orders q = 3 mod 4 take a single exponentiation; all others use the
+// Tonelli-Shanks algorithm.
+func (f *Field) Sqrt(x *big.Int) (*big.Int, error) {
+	if f.ring {
+		return nil, fmt.Errorf("Sqrt() requires a prime order; got ring modulo %v", f.order)
+	}
+	x = new(big.Int).Mod(x, f.order)
+	if x.Sign() == 0 || f.order.Cmp(bigTwo) == 0 {
+		return x, nil
+	}
+	if f.Legendre(x) != 1 {
+		return nil, fmt.Errorf("%w: %v mod %v", ErrNoSquareRoot, x, f.order)
+	}
+
+	if f.order.Bit(1) == 1 {
+		// x^((q+1)/4)^2 = x^((q+1)/2) = x * x^((q-1)/2) = x.
+		e := new(big.Int).Add(f.order, bigOne)
+		return f.Exp(x, e.Rsh(e, 2)), nil
+	}
+	return f.tonelliShanks(x)
+}
+
+// tonelliShanks returns a square root of the quadratic residue x.
+func (f *Field) tonelliShanks(x *big.Int) (*big.Int, error) {
+	// q-1 = odd * 2^s
+	qSub1 := new(big.Int).Sub(f.order, bigOne)
+	s := qSub1.TrailingZeroBits()
+	odd := new(big.Int).Rsh(qSub1, s)
+
+	z := big.NewInt(2)
+	for f.Legendre(z) != -1 {
+		z.Add(z, bigOne)
+		if z.Cmp(f.order) >= 0 {
+			return nil, fmt.Errorf("no quadratic non-residue mod %v; order not prime", f.order)
+		}
+	}
+
+	// Invariants: r^2 = x*t, t^(2^(m-1)) = 1 and c^(2^(m-1)) = -1.
+	m := s
+	c := f.Exp(z, odd)
+	t := f.Exp(x, odd)
+	e := new(big.Int).Add(odd, bigOne)
+	r := f.Exp(x, e.Rsh(e, 1))
+
+	for t.Cmp(bigOne) != 0 {
+		// Least i with t^(2^i) = 1.
+		i := uint(0)
+		for t2 := t; t2.Cmp(bigOne) != 0; t2 = f.Square(t2) {
+			i++
+			if i == m {
+				return nil, fmt.Errorf("Tonelli-Shanks did not converge mod %v; order not prime", f.order)
+			}
+		}
+
+		b := c
+		for j := uint(0); j < m-i-1; j++ {
+			b = f.Square(b)
+		}
+		m = i
+		c = f.Square(b)
+		t = f.Mul(t, c)
+		r = f.Mul(r, b)
+	}
+	return r, nil
+}
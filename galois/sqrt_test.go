@@ -0,0 +1,102 @@
+package galois
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func TestSqrtSmallFields(t *testing.T) {
+	// 7 and 11 take the q = 3 mod 4 path, 13 and 17 (with 2^4 | q-1) use
+	// Tonelli-Shanks.
+	for _, q := range []int64{2, 3, 7, 11, 13, 17, 97} {
+		f := NewField(big.NewInt(q))
+
+		squares := make(map[int64]bool)
+		for r := int64(0); r < q; r++ {
+			squares[r*r%q] = true
+		}
+
+		for x := int64(-q); x < 2*q; x++ {
+			X := big.NewInt(x)
+			xMod := (x%q + q) % q
+
+			wantLegendre := -1
+			switch {
+			case xMod == 0:
+				wantLegendre = 0
+			case squares[xMod]:
+				wantLegendre = 1
+			}
+			if got := f.Legendre(X); got != wantLegendre {
+				t.Errorf("Legendre(%d) mod %d = %d; want %d", x, q, got, wantLegendre)
+			}
+
+			r, err := f.Sqrt(X)
+			if !squares[xMod] {
+				if !errors.Is(err, ErrNoSquareRoot) {
+					t.Errorf("Sqrt(%d) mod %d: got err %v; want %v", x, q, err, ErrNoSquareRoot)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("Sqrt(%d) mod %d: %v", x, q, err)
+				continue
+			}
+			if got := f.Square(r); got.Int64() != xMod {
+				t.Errorf("Sqrt(%d)^2 mod %d = %v; want %d", x, q, got, xMod)
+			}
+		}
+	}
+}
+
+func TestSqrtLargeFields(t *testing.T) {
+	p25519 := new(big.Int).Lsh(bigOne, 255)
+	p25519.Sub(p25519, big.NewInt(19))
+	// bn256's base field, with q = 3 mod 4.
+	bn256P, _ := new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+
+	for _, order := range []*big.Int{bn256.Order, bn256P, p25519} {
+		f := NewField(order)
+
+		for i, x := range randomElements(t, f, 20) {
+			sq := f.Square(x)
+			r, err := f.Sqrt(sq)
+			if err != nil {
+				t.Fatalf("Sqrt(<square %d>) mod %v: %v", i, order, err)
+			}
+			if got := f.Square(r); got.Cmp(sq) != 0 {
+				t.Errorf("Sqrt(%v)^2 mod %v = %v; want %v", sq, order, got, sq)
+			}
+			if f.Legendre(sq) != 1 {
+				t.Errorf("Legendre(%v) mod %v = %d; want 1", sq, order, f.Legendre(sq))
+			}
+
+			want := new(big.Int).ModSqrt(x, order)
+			got, err := f.Sqrt(x)
+			if (want == nil) != errors.Is(err, ErrNoSquareRoot) {
+				t.Errorf("Sqrt(%v) mod %v: got err %v; want square root %v", x, order, err, want)
+			}
+			if want != nil && err == nil && f.Square(got).Cmp(x) != 0 {
+				t.Errorf("Sqrt(%v)^2 mod %v = %v; want %v", x, order, f.Square(got), x)
+			}
+		}
+	}
+}
+
+func BenchmarkSqrt(b *testing.B) {
+	f := NewField(bn256.Order)
+	xs := randomElements(b, f, 64)
+	for i, x := range xs {
+		xs[i] = f.Square(x)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := f.Sqrt(xs[i%len(xs)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
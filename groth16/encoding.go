@@ -0,0 +1,484 @@
+package groth16
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+)
+
+// Keys and Proofs are encoded in two formats:
+//
+//   - Binary, as gnark's WriteRawTo of its groth16.VerifyingKey and
+//     groth16.Proof, without commitments: uncompressed affine points, with
+//     G2 coordinates c1 before c0, and slices prefixed with their uint32
+//     length. gnark's ReadFrom accepts them; keys and proofs written by gnark
+//     must be written with WriteRawTo, as compressed points are not supported.
+//   - JSON, as snarkjs's verification_key.json and proof.json, and
+//     MarshalPublicInputs as its public.json. The verification key omits
+//     vk_alphabeta_12, which snarkjs doesn't use to verify; BetaG1 and DeltaG1
+//     are absent from the format, so keys read from it can't be encoded in
+//     binary.
+
+// A format holds the encoding parameters of a Suite.
+type format struct {
+	// name is the curve name of snarkjs.
+	name string
+	// size is the length of a coordinate in bytes.
+	size int
+	// mask holds the bits of the first byte that gnark uses for flags, and
+	// rawInfinity those with which it encodes the uncompressed point at
+	// infinity.
+	mask, rawInfinity byte
+}
+
+var formats = map[curve.Suite]*format{
+	curve.BN256:    {name: "bn128", size: 32, mask: 0xc0, rawInfinity: 0},
+	curve.BLS12381: {name: "bls12381", size: 48, mask: 0xe0, rawInfinity: 0x40},
+}
+
+func formatOf(s curve.Suite) (*format, error) {
+	if s == nil {
+		return nil, errors.New("no curve")
+	}
+	f, ok := formats[s]
+	if !ok {
+		return nil, fmt.Errorf("no encoding for curve %s", s.Name())
+	}
+	return f, nil
+}
+
+// suiteOf returns the Suite of the snarkjs curve name.
+func suiteOf(name string) (curve.Suite, *format, error) {
+	for s, f := range formats {
+		if f.name == name {
+			return s, f, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("unsupported curve %q", name)
+}
+
+func isZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// appendPoint appends the gnark raw encoding of p.
+func (f *format) appendPoint(b []byte, p curve.Point) []byte {
+	raw := p.Marshal()
+	if isZero(raw) {
+		raw[0] = f.rawInfinity
+	}
+	return append(b, raw...)
+}
+
+// readPoint reads a point of the Group in gnark's raw encoding from the
+// front of b, of n coordinates.
+func (f *format) readPoint(g curve.Group, b []byte, n int) (curve.Point, []byte, error) {
+	size := n * f.size
+	if len(b) < size {
+		return nil, nil, errors.New("truncated point")
+	}
+	raw := append([]byte(nil), b[:size]...)
+	switch flags := raw[0] & f.mask; {
+	case flags == f.rawInfinity && isZero(raw[1:]):
+		raw[0] = 0
+	case flags != 0:
+		return nil, nil, fmt.Errorf("unsupported point flags %#x", flags)
+	}
+	p := g.New()
+	if err := p.Unmarshal(raw); err != nil {
+		return nil, nil, fmt.Errorf("invalid point: %v", err)
+	}
+	return p, b[size:], nil
+}
+
+func appendUint32(b []byte, n int) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(n))
+	return append(b, buf[:]...)
+}
+
+func readUint32(b []byte) (int, []byte, error) {
+	if len(b) < 4 {
+		return 0, nil, errors.New("truncated length")
+	}
+	return int(binary.BigEndian.Uint32(b)), b[4:], nil
+}
+
+// MarshalBinary encodes the Proof as gnark's Proof.WriteRawTo.
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	f, err := formatOf(p.suite)
+	if err != nil {
+		return nil, err
+	}
+	b := f.appendPoint(nil, p.A)
+	b = f.appendPoint(b, p.B)
+	b = f.appendPoint(b, p.C)
+	// No commitments, and the proof of knowledge of commitments at infinity.
+	b = appendUint32(b, 0)
+	return f.appendPoint(b, p.suite.G1().New()), nil
+}
+
+// UnmarshalProof returns the Proof over the Suite encoded by MarshalBinary.
+func UnmarshalProof(s curve.Suite, b []byte) (*Proof, error) {
+	f, err := formatOf(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &Proof{suite: s}
+	if p.A, b, err = f.readPoint(s.G1(), b, 2); err != nil {
+		return nil, fmt.Errorf("A: %v", err)
+	}
+	if p.B, b, err = f.readPoint(s.G2(), b, 4); err != nil {
+		return nil, fmt.Errorf("B: %v", err)
+	}
+	if p.C, b, err = f.readPoint(s.G1(), b, 2); err != nil {
+		return nil, fmt.Errorf("C: %v", err)
+	}
+	n, b, err := readUint32(b)
+	if err != nil {
+		return nil, err
+	}
+	if n != 0 {
+		return nil, fmt.Errorf("%d commitments unsupported", n)
+	}
+	if _, b, err = f.readPoint(s.G1(), b, 2); err != nil {
+		return nil, fmt.Errorf("commitment proof: %v", err)
+	}
+	if len(b) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", len(b))
+	}
+	return p, nil
+}
+
+// MarshalBinary encodes the VerifyingKey as gnark's VerifyingKey.WriteRawTo.
+func (vk *VerifyingKey) MarshalBinary() ([]byte, error) {
+	f, err := formatOf(vk.suite)
+	if err != nil {
+		return nil, err
+	}
+	points := []curve.Point{vk.AlphaG1, vk.BetaG1, vk.BetaG2, vk.GammaG2, vk.DeltaG1, vk.DeltaG2}
+	var b []byte
+	for _, p := range points {
+		if p == nil {
+			return nil, errors.New("incomplete verifying key")
+		}
+		b = f.appendPoint(b, p)
+	}
+	b = appendUint32(b, len(vk.IC))
+	for _, p := range vk.IC {
+		b = f.appendPoint(b, p)
+	}
+	// No public inputs committed to, and no commitment keys.
+	b = appendUint32(b, 0)
+	return appendUint32(b, 0), nil
+}
+
+// UnmarshalVerifyingKey returns the VerifyingKey over the Suite encoded by
+// MarshalBinary.
+func UnmarshalVerifyingKey(s curve.Suite, b []byte) (*VerifyingKey, error) {
+	f, err := formatOf(s)
+	if err != nil {
+		return nil, err
+	}
+	vk := &VerifyingKey{suite: s}
+	g1, g2 := s.G1(), s.G2()
+	for _, p := range []struct {
+		name string
+		dst  *curve.Point
+		g    curve.Group
+		n    int
+	}{
+		{"alpha", &vk.AlphaG1, g1, 2},
+		{"beta", &vk.BetaG1, g1, 2},
+		{"beta", &vk.BetaG2, g2, 4},
+		{"gamma", &vk.GammaG2, g2, 4},
+		{"delta", &vk.DeltaG1, g1, 2},
+		{"delta", &vk.DeltaG2, g2, 4},
+	} {
+		if *p.dst, b, err = f.readPoint(p.g, b, p.n); err != nil {
+			return nil, fmt.Errorf("%s: %v", p.name, err)
+		}
+	}
+	n, b, err := readUint32(b)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 || n > len(b)/(2*f.size) {
+		return nil, fmt.Errorf("invalid number of IC points %d", n)
+	}
+	vk.IC = make([]curve.Point, n)
+	for i := range vk.IC {
+		if vk.IC[i], b, err = f.readPoint(g1, b, 2); err != nil {
+			return nil, fmt.Errorf("IC[%d]: %v", i, err)
+		}
+	}
+	for _, what := range []string{"committed public inputs", "commitment keys"} {
+		if n, b, err = readUint32(b); err != nil {
+			return nil, err
+		}
+		if n != 0 {
+			return nil, fmt.Errorf("%d %s unsupported", n, what)
+		}
+	}
+	if len(b) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes", len(b))
+	}
+	return vk, nil
+}
+
+// snarkjsG1 returns the projective coordinates of p as decimal strings, with
+// z = 1 except for the point at infinity (0, 1, 0).
+func (f *format) snarkjsG1(p curve.Point) []string {
+	raw := p.Marshal()
+	if isZero(raw) {
+		return []string{"0", "1", "0"}
+	}
+	return []string{decimal(raw[:f.size]), decimal(raw[f.size:]), "1"}
+}
+
+// snarkjsG2 is snarkjsG1 for G2, with each coordinate as [c0, c1].
+func (f *format) snarkjsG2(p curve.Point) [][]string {
+	raw := p.Marshal()
+	if isZero(raw) {
+		return [][]string{{"0", "0"}, {"1", "0"}, {"0", "0"}}
+	}
+	s := f.size
+	return [][]string{
+		{decimal(raw[s : 2*s]), decimal(raw[:s])},
+		{decimal(raw[3*s:]), decimal(raw[2*s : 3*s])},
+		{"1", "0"},
+	}
+}
+
+func decimal(b []byte) string {
+	return new(big.Int).SetBytes(b).String()
+}
+
+// parseCoordinates returns the concatenated big-endian encoding of the
+// decimal coordinates.
+func (f *format) parseCoordinates(xs ...string) ([]byte, error) {
+	var b []byte
+	for _, x := range xs {
+		v, ok := new(big.Int).SetString(x, 10)
+		if !ok || v.Sign() < 0 || v.BitLen() > 8*f.size {
+			return nil, fmt.Errorf("invalid coordinate %q", x)
+		}
+		b = append(b, v.FillBytes(make([]byte, f.size))...)
+	}
+	return b, nil
+}
+
+func (f *format) parseG1(g curve.Group, xs []string) (curve.Point, error) {
+	if len(xs) != 3 {
+		return nil, fmt.Errorf("G1 point of %d coordinates", len(xs))
+	}
+	p := g.New()
+	switch xs[2] {
+	case "0":
+		return p, nil
+	case "1":
+	default:
+		return nil, fmt.Errorf("G1 point with z = %q; want affine", xs[2])
+	}
+	raw, err := f.parseCoordinates(xs[0], xs[1])
+	if err != nil {
+		return nil, err
+	}
+	if isZero(raw) {
+		return nil, errors.New("invalid G1 point (0, 0)")
+	}
+	if err := p.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("invalid G1 point: %v", err)
+	}
+	return p, nil
+}
+
+func (f *format) parseG2(g curve.Group, xs [][]string) (curve.Point, error) {
+	if len(xs) != 3 || len(xs[0]) != 2 || len(xs[1]) != 2 || len(xs[2]) != 2 {
+		return nil, errors.New("G2 point not of 3 pairs of coordinates")
+	}
+	p := g.New()
+	switch z := xs[2]; {
+	case z[0] == "0" && z[1] == "0":
+		return p, nil
+	case z[0] == "1" && z[1] == "0":
+	default:
+		return nil, fmt.Errorf("G2 point with z = %q; want affine", z)
+	}
+	raw, err := f.parseCoordinates(xs[0][1], xs[0][0], xs[1][1], xs[1][0])
+	if err != nil {
+		return nil, err
+	}
+	if isZero(raw) {
+		return nil, errors.New("invalid G2 point (0, 0)")
+	}
+	if err := p.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("invalid G2 point: %v", err)
+	}
+	return p, nil
+}
+
+// snarkjsProof is the proof.json of snarkjs.
+type snarkjsProof struct {
+	A        []string   `json:"pi_a"`
+	B        [][]string `json:"pi_b"`
+	C        []string   `json:"pi_c"`
+	Protocol string     `json:"protocol"`
+	Curve    string     `json:"curve"`
+}
+
+// MarshalJSON encodes the Proof as snarkjs's proof.json.
+func (p *Proof) MarshalJSON() ([]byte, error) {
+	f, err := formatOf(p.suite)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&snarkjsProof{
+		A:        f.snarkjsG1(p.A),
+		B:        f.snarkjsG2(p.B),
+		C:        f.snarkjsG1(p.C),
+		Protocol: "groth16",
+		Curve:    f.name,
+	})
+}
+
+// UnmarshalJSON decodes a proof.json of snarkjs, over the curve it names.
+func (p *Proof) UnmarshalJSON(b []byte) error {
+	var raw snarkjsProof
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw.Protocol != "groth16" {
+		return fmt.Errorf("protocol %q; want groth16", raw.Protocol)
+	}
+	s, f, err := suiteOf(raw.Curve)
+	if err != nil {
+		return err
+	}
+	var q Proof
+	q.suite = s
+	if q.A, err = f.parseG1(s.G1(), raw.A); err != nil {
+		return fmt.Errorf("pi_a: %v", err)
+	}
+	if q.B, err = f.parseG2(s.G2(), raw.B); err != nil {
+		return fmt.Errorf("pi_b: %v", err)
+	}
+	if q.C, err = f.parseG1(s.G1(), raw.C); err != nil {
+		return fmt.Errorf("pi_c: %v", err)
+	}
+	*p = q
+	return nil
+}
+
+// snarkjsVerifyingKey is the verification_key.json of snarkjs.
+type snarkjsVerifyingKey struct {
+	Protocol string     `json:"protocol"`
+	Curve    string     `json:"curve"`
+	NPublic  int        `json:"nPublic"`
+	Alpha    []string   `json:"vk_alpha_1"`
+	Beta     [][]string `json:"vk_beta_2"`
+	Gamma    [][]string `json:"vk_gamma_2"`
+	Delta    [][]string `json:"vk_delta_2"`
+	IC       [][]string `json:"IC"`
+}
+
+// MarshalJSON encodes the VerifyingKey as snarkjs's verification_key.json.
+func (vk *VerifyingKey) MarshalJSON() ([]byte, error) {
+	f, err := formatOf(vk.suite)
+	if err != nil {
+		return nil, err
+	}
+	raw := &snarkjsVerifyingKey{
+		Protocol: "groth16",
+		Curve:    f.name,
+		NPublic:  len(vk.IC) - 1,
+		Alpha:    f.snarkjsG1(vk.AlphaG1),
+		Beta:     f.snarkjsG2(vk.BetaG2),
+		Gamma:    f.snarkjsG2(vk.GammaG2),
+		Delta:    f.snarkjsG2(vk.DeltaG2),
+	}
+	for _, p := range vk.IC {
+		raw.IC = append(raw.IC, f.snarkjsG1(p))
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON decodes a verification_key.json of snarkjs, over the curve it
+// names. BetaG1 and DeltaG1 are left nil.
+func (vk *VerifyingKey) UnmarshalJSON(b []byte) error {
+	var raw snarkjsVerifyingKey
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw.Protocol != "groth16" {
+		return fmt.Errorf("protocol %q; want groth16", raw.Protocol)
+	}
+	s, f, err := suiteOf(raw.Curve)
+	if err != nil {
+		return err
+	}
+	if raw.NPublic+1 != len(raw.IC) {
+		return fmt.Errorf("%d IC points for %d public inputs", len(raw.IC), raw.NPublic)
+	}
+	k := VerifyingKey{suite: s}
+	if k.AlphaG1, err = f.parseG1(s.G1(), raw.Alpha); err != nil {
+		return fmt.Errorf("vk_alpha_1: %v", err)
+	}
+	for _, p := range []struct {
+		name string
+		dst  *curve.Point
+		xs   [][]string
+	}{
+		{"vk_beta_2", &k.BetaG2, raw.Beta},
+		{"vk_gamma_2", &k.GammaG2, raw.Gamma},
+		{"vk_delta_2", &k.DeltaG2, raw.Delta},
+	} {
+		if *p.dst, err = f.parseG2(s.G2(), p.xs); err != nil {
+			return fmt.Errorf("%s: %v", p.name, err)
+		}
+	}
+	k.IC = make([]curve.Point, len(raw.IC))
+	for i, xs := range raw.IC {
+		if k.IC[i], err = f.parseG1(s.G1(), xs); err != nil {
+			return fmt.Errorf("IC[%d]: %v", i, err)
+		}
+	}
+	*vk = k
+	return nil
+}
+
+// MarshalPublicInputs encodes the public inputs as snarkjs's public.json, an
+// array of decimal strings.
+func MarshalPublicInputs(public []*big.Int) ([]byte, error) {
+	xs := make([]string, len(public))
+	for i, x := range public {
+		xs[i] = x.String()
+	}
+	return json.Marshal(xs)
+}
+
+// UnmarshalPublicInputs is the inverse of MarshalPublicInputs.
+func UnmarshalPublicInputs(b []byte) ([]*big.Int, error) {
+	var xs []string
+	if err := json.Unmarshal(b, &xs); err != nil {
+		return nil, err
+	}
+	public := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		v, ok := new(big.Int).SetString(x, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid public input %q", x)
+		}
+		public[i] = v
+	}
+	return public, nil
+}
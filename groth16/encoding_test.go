@@ -0,0 +1,423 @@
+package groth16
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"zkp.xyz/membership/curve"
+)
+
+// The verifying key and a proof for x = 3 of the circuit out = x^3 + x + 5
+// with the public input out = 35, set up and proven by gnark v0.11.0 over
+// BN254 and written with WriteRawTo.
+const (
+	gnarkVerifyingKey = "1494526890135eff2e969851df78aa847e634cfdd57682e9bb745739e6a838c4" +
+		"0699299a68182acd97ab48881948729d07aa076386b105be6a90e593ff3c1bf3" +
+		"0eef7f2b92d4bf70719dadce1aa9373f00c18b7c7ab190b1af116b441b441487" +
+		"13a56749b46dc3d46ce9bc51242bb4fa3e9cf2104122580a41a53255df71f749" +
+		"03aeeb5b981ef07da0f309b60ff3683e59b703542076c60b68eeec465e8b9a1c" +
+		"13d5e6aed21abec48ac9aae8012d5b2c3c07fde70c71692c8dc73b1fb3076f6b" +
+		"1865feeee94f3c4e53740a9b3199d00026552ccba73a10ea40d99ca60ac79905" +
+		"228222149997f92d2f91c5dd52e41451ef18ac14c44cd0899a61592222d8c33c" +
+		"20446d7497b415af363e989bc081fe2d08633b9a0757dce669266c5e5cb998ae" +
+		"00603f3921103506d43d7be420a10d913dfa644c34c8b0d063bd8145819001dc" +
+		"237c8967cae575409a914a46faa1c6fa06b116faaba3dc9a18c901329bcd4b79" +
+		"1bf57388d9fbe69d7c81db2dd7906a533f81c580a268b3900d312d1be9d96a4d" +
+		"0bfdd60f34ac089453ed5194ec01035e9bb972651602ed8c198ddd58b54567d0" +
+		"0e53bebda91667b6f41b1dd3799fada339381e8d9c05dda28675f4351893cb15" +
+		"0851c8335861a623f6c0714bfae6c6ff33a225830a7d0ce3a94becbbb0faa0a7" +
+		"1f90b0e68e4255c643771c1d12f79b467cfafe59be96795ae22a1c86b8457252" +
+		"073aaf58a78505a0341241b9becf95be6ecfff3a5782d2184a25d5c9975ed615" +
+		"29beb80572e7f11b98d8dae7513a39bc2c5ae1bf98f0bb9257277e1b0ff68de3" +
+		"00000002174063731a2519b2e1bcd46f8dccbeda4490e9689c7b852382bee554" +
+		"33a895cf06902017c88a8081b85193f78945818903daabcbfdb24832bee90ff3" +
+		"0eb52d6813c7919b7626acf08aeaacafb1d0d450ba047a3359c131a06f08013a" +
+		"7d1a8ca02c9898f9378bfc0a8af309516e98cd23d4e9f285ece95f3bb3dfcd07" +
+		"4ceca1350000000000000000"
+	gnarkProof = "1c68e88e447f3dc3ac677216e40ff7aa90873f135cddb8a897a222baf7cb2f8d" +
+		"22d384393f8074ec92f9064654d466c0c69303a9b51eef56a68b38baf039131b" +
+		"0933e813b5e9ac02408dda5c625ebe82f22c8ceed66a0835d8bf71797e64d321" +
+		"20a757e13eeb4698145914a645c575f07cfb7ade40349a7d7bbde3b3abf31f30" +
+		"237e2e687742cc55468898888859bef871ee9887c0815fa903838ffd6e4b6c52" +
+		"29e540c0aa9b5535ba66cf87f0b62fa388fdc6ebd1143bd739333c260ae6d3ae" +
+		"14bcff40eaa2ab6b25943b55fd012dc4c1dd10675b27eecb682fdd410a506ba1" +
+		"22ae8d10b70caba08d6ce32325cd483d5d8ced99a5f9a5c6ba8c11118081dd9d" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"00000000"
+)
+
+// The verification_key.json, proof.json and public.json of the same circuit
+// and witness as the gnark vector, as snarkjs writes them with
+// JSON.stringify(_, null, 1): projective coordinates, and vk_alphabeta_12,
+// e(alpha, beta) with Fp12 coefficients nested as [[[c0, c1], ...]], which
+// UnmarshalJSON ignores. The points are of a deterministic Setup and Prove
+// rather than of snarkjs, so this only checks the layout; see
+// TestSnarkjsFiles for files written by snarkjs itself.
+const (
+	snarkjsVerificationKey = `{
+ "protocol": "groth16",
+ "curve": "bn128",
+ "nPublic": 1,
+ "vk_alpha_1": [
+  "6161628204344246819061775253384023531513976681502828663736990281234542183328",
+  "21831828351230939023395736853264017528973584175568131493438124381710561086790",
+  "1"
+ ],
+ "vk_beta_2": [
+  [
+   "18962400387931362739376191111270972925249856281416752660545279418022932348258",
+   "20794951229508204793518941058562609217256655108163817601482528808059384936271"
+  ],
+  [
+   "21531367775263053482060619797222078044760114247722162927426240705659834419771",
+   "15456692086616742867724013086457363387279254532164991686376960760742265970189"
+  ],
+  [
+   "1",
+   "0"
+  ]
+ ],
+ "vk_gamma_2": [
+  [
+   "18848192471983009406590023821817356581569871423248839903301917613949349574789",
+   "1625943492542434660247065241488651961228638545325661881114684437283314089843"
+  ],
+  [
+   "10412070062376857315876739183528674520247252035394528168076374189145260027335",
+   "19287678873813484194790895508260764815327908198015656630044649806986214886513"
+  ],
+  [
+   "1",
+   "0"
+  ]
+ ],
+ "vk_delta_2": [
+  [
+   "5027208731603039770056586941713291291943454112501256867345807374333272376689",
+   "21246078946889276343670632753933691515711382573578190852374255230707702642758"
+  ],
+  [
+   "8179997600396958169989765157026546645865685608140527927970565572796830457375",
+   "12384141859216986270825828839673319737747200107722474271818518274951090463732"
+  ],
+  [
+   "1",
+   "0"
+  ]
+ ],
+ "vk_alphabeta_12": [
+  [
+   [
+    "237278683261442897518067165965093907170550694555393985587635808079922905669",
+    "10881970624992380669955508403975505623931898750330770224987185474427209469215"
+   ],
+   [
+    "4997711386461587619795745556967106885010492666201643112360761161491375305725",
+    "6098813844896224818111438039558510546079984575796116627325669919826471470743"
+   ],
+   [
+    "21288268807938525222774783194232199528115643761202197167463192146449600705607",
+    "7675865955912902839889205023478237955686715178351956341401811370344964480458"
+   ]
+  ],
+  [
+   [
+    "19039408918191753406277573706407940938803482616146047360582513707628386449036",
+    "8636288791595285071010040143876512998234475453937890291572143077581965790782"
+   ],
+   [
+    "1220771119656108633671725252523986390944405073200229508968181479862921516224",
+    "9787354472861987785082978778780958389885389419000626099450590390048197829392"
+   ],
+   [
+    "4062525727704472886672631029496296304956678885014329155539274368684815662018",
+    "15370791984552919551428324921043477260133481473129087094038384328400275155545"
+   ]
+  ]
+ ],
+ "IC": [
+  [
+   "17665542596610404855996153704721397287108021266495661246473647894412344527271",
+   "16418178337449957452825234183389669769545871066281032467320534881889339682907",
+   "1"
+  ],
+  [
+   "5957085918908232663049009579084906928869871798664380242213369110424691741683",
+   "19457763266313524233138694218727473444832843026162422714179202341723274228529",
+   "1"
+  ]
+ ]
+}`
+	snarkjsProofJSON = `{
+ "pi_a": [
+  "5032836094469915240263042336798065626340148346797049116730085758839303457451",
+  "17124324719706781218292367349512656901011731275224743707857577886961262488916",
+  "1"
+ ],
+ "pi_b": [
+  [
+   "9878230322711129337229683811538703884926924958267103275674121013905569902005",
+   "11973012163784898061170814561394252725923044842756279037304282307773904194092"
+  ],
+  [
+   "21375314336137122600271800684382043559348867375671745281034670826795852208306",
+   "4077190663442092507729876692980386022024145390164059369660931535703212525261"
+  ],
+  [
+   "1",
+   "0"
+  ]
+ ],
+ "pi_c": [
+  "2311353440580852818588585889042069196637214431883286162690451127742139872372",
+  "16353091434237899874075805331001436499133598832878199762495036869526599915953",
+  "1"
+ ],
+ "protocol": "groth16",
+ "curve": "bn128"
+}`
+	snarkjsPublic = `[
+ "35"
+]`
+)
+
+func TestGnarkVector(t *testing.T) {
+	vkb, err := hex.DecodeString(gnarkVerifyingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := hex.DecodeString(gnarkProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vk, err := UnmarshalVerifyingKey(curve.BN256, vkb)
+	if err != nil {
+		t.Fatalf("UnmarshalVerifyingKey() error %v", err)
+	}
+	proof, err := UnmarshalProof(curve.BN256, pb)
+	if err != nil {
+		t.Fatalf("UnmarshalProof() error %v", err)
+	}
+	if !Verify(vk, proof, []*big.Int{big.NewInt(35)}) {
+		t.Error("Verify(gnark proof) = false; want true")
+	}
+	if Verify(vk, proof, []*big.Int{big.NewInt(36)}) {
+		t.Error("Verify(gnark proof, wrong public input) = true; want false")
+	}
+
+	for _, tt := range []struct {
+		name string
+		got  func() ([]byte, error)
+		want string
+	}{
+		{"VerifyingKey", vk.MarshalBinary, gnarkVerifyingKey},
+		{"Proof", proof.MarshalBinary, gnarkProof},
+	} {
+		got, err := tt.got()
+		if err != nil {
+			t.Fatalf("%s.MarshalBinary() error %v", tt.name, err)
+		}
+		if hex.EncodeToString(got) != tt.want {
+			t.Errorf("%s.MarshalBinary() = %x; want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSnarkjsVector(t *testing.T) {
+	var vk VerifyingKey
+	if err := json.Unmarshal([]byte(snarkjsVerificationKey), &vk); err != nil {
+		t.Fatalf("json.Unmarshal(verification_key.json) error %v", err)
+	}
+	var proof Proof
+	if err := json.Unmarshal([]byte(snarkjsProofJSON), &proof); err != nil {
+		t.Fatalf("json.Unmarshal(proof.json) error %v", err)
+	}
+	public, err := UnmarshalPublicInputs([]byte(snarkjsPublic))
+	if err != nil {
+		t.Fatalf("UnmarshalPublicInputs(public.json) error %v", err)
+	}
+	if vk.Suite() != curve.BN256 {
+		t.Errorf("json.Unmarshal(verification_key.json).Suite() = %v; want %v", vk.Suite().Name(), curve.BN256.Name())
+	}
+	if !Verify(&vk, &proof, public) {
+		t.Error("Verify(snarkjs proof) = false; want true")
+	}
+	if Verify(&vk, &proof, []*big.Int{big.NewInt(36)}) {
+		t.Error("Verify(snarkjs proof, wrong public input) = true; want false")
+	}
+
+	got, err := json.Marshal(&proof)
+	if err != nil {
+		t.Fatalf("json.Marshal(Proof) error %v", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(snarkjsProofJSON)); err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != compact.String() {
+		t.Errorf("json.Marshal(Proof) = %s; want %s", got, compact.String())
+	}
+}
+
+// TestSnarkjsFiles verifies the verification_key.json, proof.json and
+// public.json written by snarkjs for the circuit and witness of the gnark
+// vector, if checked in to testdata/snarkjs. They are produced with
+//
+//	circom cubic.circom --r1cs --wasm
+//	snarkjs groth16 setup cubic.r1cs pot12_final.ptau cubic.zkey
+//	snarkjs zkey export verificationkey cubic.zkey verification_key.json
+//	snarkjs groth16 fullprove input.json cubic_js/cubic.wasm cubic.zkey proof.json public.json
+//
+// for input.json {"x": "3"}.
+func TestSnarkjsFiles(t *testing.T) {
+	files := make(map[string][]byte)
+	for _, name := range []string{"verification_key.json", "proof.json", "public.json"} {
+		buf, err := os.ReadFile(filepath.Join("testdata", "snarkjs", name))
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Skipf("testdata/snarkjs/%s not checked in", name)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = buf
+	}
+
+	var vk VerifyingKey
+	if err := json.Unmarshal(files["verification_key.json"], &vk); err != nil {
+		t.Fatalf("json.Unmarshal(verification_key.json) error %v", err)
+	}
+	var proof Proof
+	if err := json.Unmarshal(files["proof.json"], &proof); err != nil {
+		t.Fatalf("json.Unmarshal(proof.json) error %v", err)
+	}
+	public, err := UnmarshalPublicInputs(files["public.json"])
+	if err != nil {
+		t.Fatalf("UnmarshalPublicInputs(public.json) error %v", err)
+	}
+	if !Verify(&vk, &proof, public) {
+		t.Error("Verify(snarkjs files) = false; want true")
+	}
+	if Verify(&vk, &proof, []*big.Int{big.NewInt(36)}) {
+		t.Error("Verify(snarkjs files, wrong public input) = true; want false")
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	for name, s := range suites {
+		t.Run(name, func(t *testing.T) {
+			sys, w := cubic(t, s)
+			pk, vk, err := Setup(s, sys, rand.Reader)
+			if err != nil {
+				t.Fatalf("Setup() error %v", err)
+			}
+			proof, err := Prove(pk, w, rand.Reader)
+			if err != nil {
+				t.Fatalf("Prove() error %v", err)
+			}
+			public := sys.PublicInputs(w)
+
+			t.Run("binary", func(t *testing.T) {
+				vkb, err := vk.MarshalBinary()
+				if err != nil {
+					t.Fatalf("VerifyingKey.MarshalBinary() error %v", err)
+				}
+				pb, err := proof.MarshalBinary()
+				if err != nil {
+					t.Fatalf("Proof.MarshalBinary() error %v", err)
+				}
+				gotVK, err := UnmarshalVerifyingKey(s, vkb)
+				if err != nil {
+					t.Fatalf("UnmarshalVerifyingKey() error %v", err)
+				}
+				gotProof, err := UnmarshalProof(s, pb)
+				if err != nil {
+					t.Fatalf("UnmarshalProof() error %v", err)
+				}
+				if !Verify(gotVK, gotProof, public) {
+					t.Error("Verify() after round trip = false; want true")
+				}
+
+				if _, err := UnmarshalProof(s, pb[:len(pb)-1]); err == nil {
+					t.Error("UnmarshalProof(truncated) error nil; want error")
+				}
+				if _, err := UnmarshalProof(s, append(pb, 0)); err == nil {
+					t.Error("UnmarshalProof(trailing byte) error nil; want error")
+				}
+				if _, err := UnmarshalVerifyingKey(s, vkb[:len(vkb)-1]); err == nil {
+					t.Error("UnmarshalVerifyingKey(truncated) error nil; want error")
+				}
+				compressed := append([]byte(nil), pb...)
+				compressed[0] |= 0x80
+				if _, err := UnmarshalProof(s, compressed); err == nil {
+					t.Error("UnmarshalProof(compressed flag) error nil; want error")
+				}
+			})
+
+			t.Run("snarkjs", func(t *testing.T) {
+				vkj, err := json.Marshal(vk)
+				if err != nil {
+					t.Fatalf("json.Marshal(VerifyingKey) error %v", err)
+				}
+				pj, err := json.Marshal(proof)
+				if err != nil {
+					t.Fatalf("json.Marshal(Proof) error %v", err)
+				}
+				inj, err := MarshalPublicInputs(public)
+				if err != nil {
+					t.Fatalf("MarshalPublicInputs() error %v", err)
+				}
+				if got, want := string(inj), `["35"]`; got != want {
+					t.Errorf("MarshalPublicInputs() = %s; want %s", got, want)
+				}
+				if !strings.Contains(string(pj), `"protocol":"groth16"`) {
+					t.Errorf("json.Marshal(Proof) = %s; want protocol groth16", pj)
+				}
+
+				var gotVK VerifyingKey
+				if err := json.Unmarshal(vkj, &gotVK); err != nil {
+					t.Fatalf("json.Unmarshal(VerifyingKey) error %v", err)
+				}
+				var gotProof Proof
+				if err := json.Unmarshal(pj, &gotProof); err != nil {
+					t.Fatalf("json.Unmarshal(Proof) error %v", err)
+				}
+				gotPublic, err := UnmarshalPublicInputs(inj)
+				if err != nil {
+					t.Fatalf("UnmarshalPublicInputs() error %v", err)
+				}
+				if gotVK.Suite() != s {
+					t.Errorf("json.Unmarshal(VerifyingKey).Suite() = %v; want %v", gotVK.Suite().Name(), s.Name())
+				}
+				if !Verify(&gotVK, &gotProof, gotPublic) {
+					t.Error("Verify() after round trip = false; want true")
+				}
+				if _, err := gotVK.MarshalBinary(); err == nil {
+					t.Error("MarshalBinary() of snarkjs VerifyingKey error nil; want error")
+				}
+				if _, err := gotProof.MarshalBinary(); err != nil {
+					t.Errorf("MarshalBinary() of snarkjs Proof error %v", err)
+				}
+
+				for _, bad := range []string{
+					strings.Replace(string(pj), `"groth16"`, `"plonk"`, 1),
+					strings.Replace(string(pj), `"curve":"`, `"curve":"x`, 1),
+					strings.Replace(string(pj), `"1"]`, `"2"]`, 1),
+				} {
+					if err := json.Unmarshal([]byte(bad), new(Proof)); err == nil {
+						t.Errorf("json.Unmarshal(%s) error nil; want error", bad)
+					}
+				}
+			})
+		})
+	}
+}
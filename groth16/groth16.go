@@ -0,0 +1,235 @@
+// Package groth16 implements the zk-SNARK of Groth, "On the Size of
+// Pairing-based Non-interactive Arguments", for r1cs.Systems over a
+// curve.Suite: proofs of three group elements, verified with a single
+// pairing check.
+//
+// Keys and proofs are encoded as by gnark and snarkjs, so that proofs can be
+// verified by either; see encoding.go. The public inputs are those of the
+// r1cs.System, in order, without the constant One.
+package groth16
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/qap"
+	"zkp.xyz/membership/r1cs"
+)
+
+// A VerifyingKey verifies Proofs of an r1cs.System. IC holds the points of One
+// and each public input. BetaG1 and DeltaG1 aren't needed for verification,
+// but are part of gnark's encoding.
+type VerifyingKey struct {
+	suite                    curve.Suite
+	AlphaG1, BetaG1, DeltaG1 curve.Point
+	BetaG2, GammaG2, DeltaG2 curve.Point
+	IC                       []curve.Point
+}
+
+// Suite returns the curve of the VerifyingKey.
+func (vk *VerifyingKey) Suite() curve.Suite {
+	return vk.suite
+}
+
+// A ProvingKey proves witnesses of an r1cs.System. For the toxic waste tau,
+// alpha, beta, gamma and delta of the setup, with A_j, B_j and C_j the QAP
+// polynomials of variable j:
+//
+//	A[j] = A_j(tau) G1, B1[j] = B_j(tau) G1, B2[j] = B_j(tau) G2
+//	K[j] = (beta A_j(tau) + alpha B_j(tau) + C_j(tau)) / delta G1 for each secret variable j
+//	H[i] = tau^i Z(tau) / delta G1 for i < n-1
+type ProvingKey struct {
+	suite                    curve.Suite
+	qap                      *qap.QAP
+	AlphaG1, BetaG1, DeltaG1 curve.Point
+	BetaG2, DeltaG2          curve.Point
+	A, B1, K, H              []curve.Point
+	B2                       []curve.Point
+}
+
+// A Proof is a Groth16 proof, with A and C in G1 and B in G2. Proofs are
+// returned by Prove and decoded over a Suite, which is required to encode
+// them.
+type Proof struct {
+	suite   curve.Suite
+	A, B, C curve.Point
+}
+
+// Setup returns the keys of the System, whose field must be the scalar field
+// of the Suite, drawing the toxic waste from r. Whoever knows it can forge
+// Proofs; production keys require a multi-party ceremony instead.
+func Setup(s curve.Suite, sys *r1cs.System, r io.Reader) (*ProvingKey, *VerifyingKey, error) {
+	f := sys.Field()
+	if f.Order().Cmp(s.Order()) != 0 {
+		return nil, nil, fmt.Errorf("system over field of order %v; want %v", f.Order(), s.Order())
+	}
+	q, err := qap.FromR1CS(sys.WithInputConstraints())
+	if err != nil {
+		return nil, nil, err
+	}
+	n := q.Domain().Size()
+
+	var toxic [5]*big.Int
+	for i := range toxic {
+		if toxic[i], err = randomNonZero(f, r); err != nil {
+			return nil, nil, err
+		}
+	}
+	tau, alpha, beta, gamma, delta := toxic[0], toxic[1], toxic[2], toxic[3], toxic[4]
+	// tau must not be in the Domain, i.e. Z(tau) != 0.
+	zTau := f.Sub(f.Exp(tau, new(big.Int).SetUint64(n)), big.NewInt(1))
+	if zTau.Sign() == 0 {
+		return nil, nil, errors.New("tau in the domain")
+	}
+	as, bs, cs, err := q.EvaluateAt(tau)
+	if err != nil {
+		return nil, nil, err
+	}
+	gammaInv, err := f.MultInverse(gamma)
+	if err != nil {
+		return nil, nil, err
+	}
+	deltaInv, err := f.MultInverse(delta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g1, g2 := s.G1(), s.G2()
+	mul1 := func(x *big.Int) curve.Point { return g1.New().ScalarBaseMult(x) }
+	mul2 := func(x *big.Int) curve.Point { return g2.New().ScalarBaseMult(x) }
+
+	m, public := sys.NumVariables(), sys.NumPublic()
+	pk := &ProvingKey{
+		suite: s, qap: q,
+		AlphaG1: mul1(alpha), BetaG1: mul1(beta), DeltaG1: mul1(delta),
+		BetaG2: mul2(beta), DeltaG2: mul2(delta),
+		A: make([]curve.Point, m), B1: make([]curve.Point, m), B2: make([]curve.Point, m),
+	}
+	vk := &VerifyingKey{
+		suite:   s,
+		AlphaG1: pk.AlphaG1, BetaG1: pk.BetaG1, DeltaG1: pk.DeltaG1,
+		BetaG2: pk.BetaG2, GammaG2: mul2(gamma), DeltaG2: pk.DeltaG2,
+	}
+	for j := 0; j < m; j++ {
+		pk.A[j], pk.B1[j], pk.B2[j] = mul1(as[j]), mul1(bs[j]), mul2(bs[j])
+		k := f.Add(f.Add(f.Mul(beta, as[j]), f.Mul(alpha, bs[j])), cs[j])
+		if j <= public {
+			vk.IC = append(vk.IC, mul1(f.Mul(k, gammaInv)))
+		} else {
+			pk.K = append(pk.K, mul1(f.Mul(k, deltaInv)))
+		}
+	}
+	h := f.Mul(zTau, deltaInv)
+	for i := uint64(0); i+1 < n; i++ {
+		pk.H = append(pk.H, mul1(h))
+		h = f.Mul(h, tau)
+	}
+	return pk, vk, nil
+}
+
+// randomNonZero returns a random non-zero element of the field.
+func randomNonZero(f *galois.Field, r io.Reader) (*big.Int, error) {
+	for {
+		x, err := f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		if x.Sign() != 0 {
+			return x, nil
+		}
+	}
+}
+
+// Suite returns the curve of the ProvingKey.
+func (pk *ProvingKey) Suite() curve.Suite {
+	return pk.suite
+}
+
+// System returns the r1cs.System of the ProvingKey, including the constraints
+// of r1cs.System.WithInputConstraints.
+func (pk *ProvingKey) System() *r1cs.System {
+	return pk.qap.System()
+}
+
+// Prove returns a Proof for the witness of the System, as returned by
+// r1cs.System.Solve, drawing the blinding factors from r.
+func Prove(pk *ProvingKey, w []*big.Int, r io.Reader) (*Proof, error) {
+	h, err := pk.qap.Quotient(w)
+	if err != nil {
+		return nil, err
+	}
+	hs := (*h)[:h.Degree()+1]
+	if h.Degree() == 0 && hs[0].Sign() == 0 {
+		hs = nil
+	}
+	if len(hs) > len(pk.H) {
+		return nil, fmt.Errorf("quotient of degree %d; want less than %d", h.Degree(), len(pk.H))
+	}
+	f := pk.qap.Field()
+	rr, err := f.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := f.Random(r)
+	if err != nil {
+		return nil, err
+	}
+
+	g1, g2 := pk.suite.G1(), pk.suite.G2()
+	one := big.NewInt(1)
+	scalars := append(append([]*big.Int(nil), w...), one, rr)
+	a, err := g1.MultiExp(append(append([]curve.Point(nil), pk.A...), pk.AlphaG1, pk.DeltaG1), scalars)
+	if err != nil {
+		return nil, err
+	}
+	scalars[len(scalars)-1] = ss
+	b1, err := g1.MultiExp(append(append([]curve.Point(nil), pk.B1...), pk.BetaG1, pk.DeltaG1), scalars)
+	if err != nil {
+		return nil, err
+	}
+	b2, err := g2.MultiExp(append(append([]curve.Point(nil), pk.B2...), pk.BetaG2, pk.DeltaG2), scalars)
+	if err != nil {
+		return nil, err
+	}
+
+	// C = sum_j w_j K[j] + sum_i h_i H[i] + s A + r B1 - r s delta.
+	secret := w[len(w)-len(pk.K):]
+	points := append(append(append([]curve.Point(nil), pk.K...), pk.H[:len(hs)]...), a, b1, pk.DeltaG1)
+	scalars = append(append(append([]*big.Int(nil), secret...), hs...), ss, rr, f.Sub(big.NewInt(0), f.Mul(rr, ss)))
+	c, err := g1.MultiExp(points, scalars)
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{suite: pk.suite, A: a, B: b2, C: c}, nil
+}
+
+// Verify reports whether the proof is valid for the public inputs, each of
+// which must be reduced into the scalar field, by checking
+//
+//	e(A, B) = e(alpha, beta) e(sum_i x_i IC[i], gamma) e(C, delta)
+//
+// with x_0 = 1.
+func Verify(vk *VerifyingKey, proof *Proof, public []*big.Int) bool {
+	if len(public)+1 != len(vk.IC) || proof.A == nil || proof.B == nil || proof.C == nil {
+		return false
+	}
+	for _, x := range public {
+		if x == nil || x.Sign() < 0 || x.Cmp(vk.suite.Order()) >= 0 {
+			return false
+		}
+	}
+	g1 := vk.suite.G1()
+	sum, err := g1.MultiExp(vk.IC, append([]*big.Int{big.NewInt(1)}, public...))
+	if err != nil {
+		return false
+	}
+	negA := g1.New().Neg(proof.A)
+	return vk.suite.PairingCheck(
+		[]curve.Point{negA, vk.AlphaG1, sum, proof.C},
+		[]curve.Point{proof.B, vk.BetaG2, vk.GammaG2, vk.DeltaG2},
+	)
+}
@@ -0,0 +1,112 @@
+package groth16
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/r1cs"
+)
+
+var suites = map[string]curve.Suite{
+	"bn256":     curve.BN256,
+	"BLS12-381": curve.BLS12381,
+}
+
+// cubic returns the System of out = x^3 + x + 5 over the scalar field of the
+// Suite, and the witness for x = 3.
+func cubic(t *testing.T, s curve.Suite) (*r1cs.System, []*big.Int) {
+	t.Helper()
+	b := r1cs.NewBuilder(galois.NewField(s.Order()))
+	out := b.Public("out")
+	x := b.Secret("x")
+	x2 := b.Mul(x.LC(), x.LC())
+	x3 := b.Mul(x2.LC(), x.LC())
+	b.AssertEqual(x3.LC().Add(x.LC()).Add(r1cs.Constant(big.NewInt(5))), out.LC())
+	sys, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error %v", err)
+	}
+	w, err := sys.Solve(map[string]*big.Int{"out": big.NewInt(35), "x": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Solve() error %v", err)
+	}
+	return sys, w
+}
+
+func TestGroth16(t *testing.T) {
+	for name, s := range suites {
+		t.Run(name, func(t *testing.T) {
+			sys, w := cubic(t, s)
+			pk, vk, err := Setup(s, sys, rand.Reader)
+			if err != nil {
+				t.Fatalf("Setup() error %v", err)
+			}
+			proof, err := Prove(pk, w, rand.Reader)
+			if err != nil {
+				t.Fatalf("Prove() error %v", err)
+			}
+			public := sys.PublicInputs(w)
+			if !Verify(vk, proof, public) {
+				t.Fatal("Verify() = false; want true")
+			}
+
+			g1, g2 := s.G1(), s.G2()
+			tests := []struct {
+				name   string
+				proof  *Proof
+				public []*big.Int
+			}{
+				{"wrong public input", proof, []*big.Int{big.NewInt(36)}},
+				{"unreduced public input", proof, []*big.Int{new(big.Int).Add(public[0], s.Order())}},
+				{"no public input", proof, nil},
+				{"extra public input", proof, append(public, big.NewInt(0))},
+				{"wrong A", &Proof{A: g1.New().Add(proof.A, g1.Generator()), B: proof.B, C: proof.C}, public},
+				{"wrong B", &Proof{A: proof.A, B: g2.New().Add(proof.B, g2.Generator()), C: proof.C}, public},
+				{"wrong C", &Proof{A: proof.A, B: proof.B, C: g1.New().Add(proof.C, g1.Generator())}, public},
+				{"incomplete", &Proof{A: proof.A, B: proof.B}, public},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					if Verify(vk, tt.proof, tt.public) {
+						t.Error("Verify() = true; want false")
+					}
+				})
+			}
+
+			// Proofs are randomised, so that they don't reveal the witness.
+			other, err := Prove(pk, w, rand.Reader)
+			if err != nil {
+				t.Fatalf("Prove() error %v", err)
+			}
+			if other.A.Equal(proof.A) || !Verify(vk, other, public) {
+				t.Error("Prove() twice returned equal or invalid proofs")
+			}
+		})
+	}
+}
+
+func TestProveErrors(t *testing.T) {
+	s := curve.BN256
+	sys, w := cubic(t, s)
+	pk, _, err := Setup(s, sys, rand.Reader)
+	if err != nil {
+		t.Fatalf("Setup() error %v", err)
+	}
+
+	bad := append([]*big.Int(nil), w...)
+	bad[3] = big.NewInt(10)
+	if _, err := Prove(pk, bad, rand.Reader); err == nil {
+		t.Error("Prove(unsatisfying witness) error nil; want error")
+	}
+	if _, err := Prove(pk, w[1:], rand.Reader); err == nil {
+		t.Error("Prove(short witness) error nil; want error")
+	}
+
+	other, _ := cubic(t, curve.BLS12381)
+	if _, _, err := Setup(s, other, rand.Reader); err == nil {
+		t.Error("Setup() with system over another field; error nil; want error")
+	}
+}
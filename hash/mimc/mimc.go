@@ -0,0 +1,173 @@
+// Package mimc implements the MiMC-Feistel permutation and the MiMC sponge
+// over a galois.Field, an algebraic hash that is cheap to evaluate in
+// arithmetic circuits. See Albrecht et al., "MiMC: Efficient Encryption and
+// Cryptographic Hashing with Minimal Multiplicative Complexity",
+// https://eprint.iacr.org/2016/492.
+//
+// The permutation and sponge are those of circomlib's MiMCSponge template,
+// with the S-box x^5, 220 rounds and round constants derived from the seed
+// "mimcsponge" by iterated Keccak-256. Over bn256, Hash therefore matches the
+// Merkle trees of Tornado Cash and other circuits built on circomlib.
+package mimc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+)
+
+const (
+	// Seed is the seed of circomlib's round constants.
+	Seed = "mimcsponge"
+	// Rounds is the number of rounds of circomlib.
+	Rounds = 220
+	// alpha is the exponent of the S-box.
+	alpha = 5
+)
+
+var (
+	// BN256 is MiMC over the scalar field of bn256.
+	BN256 = mustNew(curve.BN256)
+	// BLS12381 is MiMC over the scalar field of BLS12-381, with the constants
+	// of circomlib reduced into the field.
+	BLS12381 = mustNew(curve.BLS12381)
+
+	bigOne = big.NewInt(1)
+)
+
+func mustNew(s curve.Suite) *Hasher {
+	h, err := New(s.ScalarField(), Seed, Rounds)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// A Hasher computes the MiMC-Feistel permutation and sponge over a field.
+type Hasher struct {
+	f *galois.Field
+	// constants[i] is the constant of round i, zero for the first and last.
+	constants []*big.Int
+}
+
+// New returns a Hasher over f with the number of rounds and round constants
+// derived from the seed as by circomlib: c_0 = 0, c_i = Keccak-256^(i+1)(seed)
+// modulo the order, where the hash is iterated over the 32-byte digests, and
+// c_{rounds-1} = 0. The order must be a prime for which x^5 is a permutation,
+// i.e. one that is not 1 modulo 5.
+func New(f *galois.Field, seed string, rounds int) (*Hasher, error) {
+	if f.IsRing() {
+		return nil, errors.New("MiMC over a ring")
+	}
+	if new(big.Int).Mod(f.Order(), big.NewInt(alpha)).Cmp(bigOne) == 0 {
+		return nil, fmt.Errorf("x^%d is not a permutation of the field", alpha)
+	}
+	if rounds < 2 {
+		return nil, fmt.Errorf("%d rounds < 2", rounds)
+	}
+
+	h := &Hasher{f: f, constants: make([]*big.Int, rounds)}
+	d := keccak256([]byte(seed))
+	for i := 1; i < rounds-1; i++ {
+		d = keccak256(d)
+		h.constants[i] = f.Mod(new(big.Int).SetBytes(d))
+	}
+	h.constants[0] = new(big.Int)
+	h.constants[rounds-1] = new(big.Int)
+	return h, nil
+}
+
+func keccak256(b []byte) []byte {
+	d := sha3.NewLegacyKeccak256()
+	d.Write(b)
+	return d.Sum(nil)
+}
+
+// Field returns the field of the Hasher.
+func (h *Hasher) Field() *galois.Field {
+	return h.f
+}
+
+// Rounds returns the number of rounds of the permutation.
+func (h *Hasher) Rounds() int {
+	return len(h.constants)
+}
+
+// Permute returns the MiMC-Feistel permutation of (xL, xR) with the key k,
+// all reduced into the field. Each round maps (xL, xR) to
+// (xR + (xL + k + c_i)^5, xL), except for the last, which leaves xL in place.
+func (h *Hasher) Permute(xL, xR, k *big.Int) (*big.Int, *big.Int) {
+	f := h.f
+	xL, xR, k = f.Mod(xL), f.Mod(xR), f.Mod(k)
+	last := len(h.constants) - 1
+	for i, c := range h.constants {
+		t := f.Add(f.Add(xL, k), c)
+		t2 := f.Square(t)
+		t = f.Mul(f.Square(t2), t)
+		if i < last {
+			xL, xR = f.Add(xR, t), xL
+		} else {
+			xR = f.Add(xR, t)
+		}
+	}
+	return xL, xR
+}
+
+// Hash returns the first output of a Sponge with key zero after absorbing the
+// inputs, reduced into the field. This is the hash of circomlib's
+// MiMCSponge(len(inputs), 220, 1) template with k = 0.
+func (h *Hasher) Hash(inputs ...*big.Int) *big.Int {
+	s := h.NewSponge(new(big.Int))
+	s.Absorb(inputs...)
+	return s.Squeeze()
+}
+
+// Compress returns the Hash of a and b, so that the Hasher can be used as a
+// merkle.Compressor.
+func (h *Hasher) Compress(a, b *big.Int) *big.Int {
+	return h.Hash(a, b)
+}
+
+// A Sponge absorbs and squeezes any number of field elements with the
+// MiMC-Feistel permutation under a fixed key, with a rate and capacity of one
+// element each, as circomlib's MiMCSponge template.
+//
+// Absorbing an element adds it to the rate part of the state and permutes.
+// Squeezing returns the rate part, permuting first unless it is fresh from
+// absorbing. Unlike the sponges of the poseidon package, absorbed elements are
+// not padded, so inputs that differ only in trailing zeros collide; callers
+// hashing variable-length inputs must encode their length.
+type Sponge struct {
+	h    *Hasher
+	k    *big.Int
+	r, c *big.Int
+	// fresh reports whether r has not been squeezed since the last
+	// permutation, or since the start.
+	fresh bool
+}
+
+// NewSponge returns a Sponge with the key k.
+func (h *Hasher) NewSponge(k *big.Int) *Sponge {
+	return &Sponge{h: h, k: h.f.Mod(k), r: new(big.Int), c: new(big.Int), fresh: true}
+}
+
+// Absorb absorbs the elements, reduced into the field.
+func (s *Sponge) Absorb(xs ...*big.Int) {
+	for _, x := range xs {
+		s.r, s.c = s.h.Permute(s.h.f.Add(s.r, s.h.f.Mod(x)), s.c, s.k)
+		s.fresh = true
+	}
+}
+
+// Squeeze returns the next element of output.
+func (s *Sponge) Squeeze() *big.Int {
+	if !s.fresh {
+		s.r, s.c = s.h.Permute(s.r, s.c, s.k)
+	}
+	s.fresh = false
+	return new(big.Int).Set(s.r)
+}
@@ -0,0 +1,144 @@
+package mimc
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/merkle"
+)
+
+var _ merkle.Compressor = BN256
+
+func hex(s string) *big.Int {
+	x, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic(s)
+	}
+	return x
+}
+
+func TestCompress(t *testing.T) {
+	// The empty subtrees of Tornado Cash's Merkle trees, whose leaves are
+	// Keccak-256("tornado") modulo the order.
+	zeros := []*big.Int{
+		hex("2fe54c60d3acabf3343a35b6eba15db4821b340f76e741e2249685ed4899af6c"),
+		hex("256a6135777eee2fd26f54b8b7037a25439d5235caee224154186d2b8a52e31d"),
+		hex("1151949895e82ab19924de92c40a3d6f7bcb60d92b00504b8199613683f0c200"),
+	}
+	for i := 1; i < len(zeros); i++ {
+		if got := BN256.Compress(zeros[i-1], zeros[i-1]); got.Cmp(zeros[i]) != 0 {
+			t.Errorf("Compress(zeros[%d], zeros[%d]) = %x; want %x", i-1, i-1, got, zeros[i])
+		}
+	}
+
+	h := merkle.NewFieldHasher(BN256.Field(), BN256)
+	leaves := make([][]byte, 4)
+	for i := range leaves {
+		leaves[i] = zeros[0].Bytes()
+	}
+	tree, err := merkle.New(h, leaves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Element(tree.Root()); got.Cmp(zeros[2]) != 0 {
+		t.Errorf("merkle.New(<4 zero leaves>).Root() = %x; want %x", got, zeros[2])
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		order  int64
+		rounds int
+	}{
+		{order: 11, rounds: Rounds},
+		{order: 13, rounds: 1},
+	}
+	for _, tt := range tests {
+		if _, err := New(galois.NewField(big.NewInt(tt.order)), Seed, tt.rounds); err == nil {
+			t.Errorf("New(<field of order %d>, %q, %d): got nil error", tt.order, Seed, tt.rounds)
+		}
+	}
+
+	if BN256.Rounds() != Rounds {
+		t.Errorf("BN256.Rounds() = %d; want %d", BN256.Rounds(), Rounds)
+	}
+	other, err := New(BN256.Field(), "other", Rounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b := BN256.Hash(big.NewInt(1)), other.Hash(big.NewInt(1)); a.Cmp(b) == 0 {
+		t.Errorf("Hash(1) with seeds %q and %q = %v", Seed, "other", a)
+	}
+}
+
+// inverse undoes Permute by running the rounds backwards.
+func inverse(h *Hasher, xL, xR, k *big.Int) (*big.Int, *big.Int) {
+	f := h.f
+	for i := len(h.constants) - 1; i >= 0; i-- {
+		if i < len(h.constants)-1 {
+			xL, xR = xR, xL
+		}
+		t := f.Exp(f.Add(f.Add(xL, k), h.constants[i]), big.NewInt(alpha))
+		xR = f.Sub(xR, t)
+	}
+	return xL, xR
+}
+
+func TestPermute(t *testing.T) {
+	for _, h := range []*Hasher{BN256, BLS12381} {
+		xL, xR, k := big.NewInt(1), big.NewInt(2), big.NewInt(3)
+		yL, yR := h.Permute(xL, xR, k)
+		if yL.Cmp(xL) == 0 || yR.Cmp(xR) == 0 {
+			t.Errorf("Permute(1, 2, 3) = %v, %v", yL, yR)
+		}
+		if gotL, gotR := inverse(h, yL, yR, k); gotL.Cmp(xL) != 0 || gotR.Cmp(xR) != 0 {
+			t.Errorf("inverse(Permute(1, 2, 3)) = %v, %v; want 1, 2", gotL, gotR)
+		}
+
+		order := h.Field().Order()
+		if zL, zR := h.Permute(new(big.Int).Add(xL, order), xR, k); zL.Cmp(yL) != 0 || zR.Cmp(yR) != 0 {
+			t.Errorf("Permute(1 + p, 2, 3) = %v, %v; want %v, %v", zL, zR, yL, yR)
+		}
+	}
+}
+
+func TestSponge(t *testing.T) {
+	for _, h := range []*Hasher{BN256, BLS12381} {
+		if got := h.Hash(); got.Sign() != 0 {
+			t.Errorf("Hash() = %v; want 0", got)
+		}
+
+		// Hash(x) is the left half of Permute(x, 0, 0).
+		x := big.NewInt(42)
+		want, _ := h.Permute(x, new(big.Int), new(big.Int))
+		if got := h.Hash(x); got.Cmp(want) != 0 {
+			t.Errorf("Hash(42) = %v; want %v", got, want)
+		}
+
+		k := big.NewInt(7)
+		whole := h.NewSponge(k)
+		whole.Absorb(big.NewInt(1), big.NewInt(2), big.NewInt(3))
+		split := h.NewSponge(k)
+		split.Absorb(big.NewInt(1))
+		split.Absorb()
+		split.Absorb(big.NewInt(2), big.NewInt(3))
+		seen := make(map[string]bool)
+		for i := 0; i < 5; i++ {
+			a, b := whole.Squeeze(), split.Squeeze()
+			if a.Cmp(b) != 0 {
+				t.Errorf("Squeeze() #%d = %v for split input; want %v", i, b, a)
+			}
+			if seen[a.String()] {
+				t.Errorf("Squeeze() repeats %v", a)
+			}
+			seen[a.String()] = true
+		}
+
+		other := h.NewSponge(big.NewInt(8))
+		other.Absorb(big.NewInt(1), big.NewInt(2), big.NewInt(3))
+		if seen[other.Squeeze().String()] {
+			t.Errorf("Squeeze() with key 8 equals an output with key 7")
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package poseidon
+
+import (
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// grain is the Grain LFSR with which the reference implementation of Poseidon
+// derives round constants and MDS matrices, so that they are reproducible and
+// nothing is hidden in them.
+type grain struct {
+	bits [80]uint8
+	pos  int
+}
+
+// newGrain returns the LFSR seeded with the parameters of a permutation with
+// the S-box x^alpha over a prime field of n bits, of width t and with rf full
+// and rp partial rounds.
+func newGrain(n, t, rf, rp int) *grain {
+	g := new(grain)
+	i := 0
+	put := func(v, width int) {
+		for b := width - 1; b >= 0; b-- {
+			g.bits[i] = uint8(v>>b) & 1
+			i++
+		}
+	}
+	put(1, 2) // Prime field.
+	put(0, 4) // S-box x^alpha.
+	put(n, 12)
+	put(t, 12)
+	put(rf, 10)
+	put(rp, 10)
+	put(1<<30-1, 30)
+	for i := 0; i < 160; i++ {
+		g.step()
+	}
+	return g
+}
+
+// step advances the LFSR and returns the new bit.
+func (g *grain) step() uint8 {
+	at := func(i int) uint8 { return g.bits[(g.pos+i)%80] }
+	b := at(62) ^ at(51) ^ at(38) ^ at(23) ^ at(13) ^ at(0)
+	g.bits[g.pos] = b
+	g.pos = (g.pos + 1) % 80
+	return b
+}
+
+// bit returns the next output bit, shrinking the LFSR output by keeping the
+// second of each pair of bits only if the first is set.
+func (g *grain) bit() uint {
+	for g.step() == 0 {
+		g.step()
+	}
+	return uint(g.step())
+}
+
+// int returns the next n output bits as a big-endian integer.
+func (g *grain) int(n int) *big.Int {
+	x := new(big.Int)
+	for i := 0; i < n; i++ {
+		x.Lsh(x, 1)
+		x.SetBit(x, 0, g.bit())
+	}
+	return x
+}
+
+// element returns a uniformly random element of f by rejection sampling.
+func (g *grain) element(f *galois.Field) *big.Int {
+	n := f.Order().BitLen()
+	for {
+		if x := g.int(n); x.Cmp(f.Order()) < 0 {
+			return x
+		}
+	}
+}
+
+// cauchy returns the t×t Cauchy matrix 1/(x_i + y_j) for 2t distinct
+// elements x_0, ..., x_{t-1}, y_0, ..., y_{t-1} of f, sampled modulo the
+// order, retrying if any of them repeat or a denominator is zero.
+func (g *grain) cauchy(f *galois.Field, t int) [][]*big.Int {
+	n := f.Order().BitLen()
+	for {
+		xs := make([]*big.Int, 2*t)
+		for {
+			seen := make(map[string]bool)
+			for i := range xs {
+				xs[i] = f.Mod(g.int(n))
+				seen[xs[i].String()] = true
+			}
+			if len(seen) == len(xs) {
+				break
+			}
+		}
+
+		m := make([][]*big.Int, t)
+		ok := true
+		for i := range m {
+			m[i] = make([]*big.Int, t)
+			for j := range m[i] {
+				inv, err := f.MultInverse(f.Add(xs[i], xs[t+j]))
+				if err != nil {
+					ok = false
+					break
+				}
+				m[i][j] = inv
+			}
+		}
+		if ok {
+			return m
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Package poseidon implements the Poseidon permutation and hash over the
+// scalar fields of bn256 and BLS12-381, an algebraic hash that is cheap to
+// evaluate in arithmetic circuits. See Grassi et al., "Poseidon: A New Hash
+// Function for Zero-Knowledge Proof Systems", https://eprint.iacr.org/2019/458.
+//
+// The permutations use the S-box x^5, 8 full rounds and the number of partial
+// rounds of circomlib, with round constants and MDS matrices derived with the
+// Grain LFSR of the reference implementation. Over bn256, Hash therefore
+// matches circomlib's Poseidon templates and compatible implementations such
+// as go-iden3-crypto.
+package poseidon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+)
+
+const (
+	// alpha is the exponent of the S-box.
+	alpha = 5
+	// fullRounds is the number of full rounds, half of them before and half
+	// after the partial rounds.
+	fullRounds = 8
+	// MaxWidth is the largest supported width of a Permutation.
+	MaxWidth = 17
+)
+
+// partialRounds[t-2] is the number of partial rounds of circomlib for width
+// t, for 128 bits of security rounded up to a multiple of t.
+var partialRounds = [MaxWidth - 1]int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}
+
+var (
+	// BN256 is Poseidon over the scalar field of bn256.
+	BN256 = mustNew(curve.BN256)
+	// BLS12381 is Poseidon over the scalar field of BLS12-381.
+	BLS12381 = mustNew(curve.BLS12381)
+
+	bigOne = big.NewInt(1)
+)
+
+func mustNew(s curve.Suite) *Hasher {
+	h, err := New(s.ScalarField())
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// A Hasher computes Poseidon over a prime field. Its Permutations are derived
+// on first use and cached. A Hasher is safe for concurrent use.
+type Hasher struct {
+	f     *galois.Field
+	mu    sync.Mutex
+	perms [MaxWidth + 1]*Permutation
+}
+
+// New returns a Hasher over f, whose order must be a prime for which x^5 is a
+// permutation, i.e. one that is not 1 modulo 5.
+func New(f *galois.Field) (*Hasher, error) {
+	if f.IsRing() {
+		return nil, errors.New("poseidon over a ring")
+	}
+	if new(big.Int).Mod(f.Order(), big.NewInt(alpha)).Cmp(bigOne) == 0 {
+		return nil, fmt.Errorf("x^%d is not a permutation of the field", alpha)
+	}
+	return &Hasher{f: f}, nil
+}
+
+// Field returns the field of the Hasher.
+func (h *Hasher) Field() *galois.Field {
+	return h.f
+}
+
+// Permutation returns the Permutation of width t, in [2, MaxWidth].
+func (h *Hasher) Permutation(t int) (*Permutation, error) {
+	if t < 2 || t > MaxWidth {
+		return nil, fmt.Errorf("width %d out of range [2, %d]", t, MaxWidth)
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.perms[t] == nil {
+		h.perms[t] = newPermutation(h.f, t, fullRounds, partialRounds[t-2])
+	}
+	return h.perms[t], nil
+}
+
+// Hash returns the Poseidon hash of 1 to MaxWidth-1 inputs, which must be
+// elements of the field: the first element of the state after permuting the
+// inputs, preceded by a zero, with the Permutation of width len(inputs)+1.
+// This is the hash of circomlib's Poseidon(len(inputs)) template.
+func (h *Hasher) Hash(inputs ...*big.Int) (*big.Int, error) {
+	if len(inputs) == 0 || len(inputs) >= MaxWidth {
+		return nil, fmt.Errorf("%d inputs out of range [1, %d]", len(inputs), MaxWidth-1)
+	}
+	state := make([]*big.Int, len(inputs)+1)
+	state[0] = new(big.Int)
+	for i, x := range inputs {
+		if x.Sign() < 0 || x.Cmp(h.f.Order()) >= 0 {
+			return nil, fmt.Errorf("input %d not in the field", i)
+		}
+		state[i+1] = new(big.Int).Set(x)
+	}
+	p, err := h.Permutation(len(state))
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Permute(state); err != nil {
+		return nil, err
+	}
+	return state[0], nil
+}
+
+// Compress returns the Hash of a and b, reduced into the field, so that the
+// Hasher can be used as a merkle.Compressor.
+func (h *Hasher) Compress(a, b *big.Int) *big.Int {
+	out, err := h.Hash(h.f.Mod(a), h.f.Mod(b))
+	if err != nil {
+		// Two reduced inputs are always valid.
+		panic(err)
+	}
+	return out
+}
+
+// A Permutation is the Poseidon permutation of a fixed width over a field.
+type Permutation struct {
+	f      *galois.Field
+	t      int
+	rf, rp int
+	// constants holds the t round constants of each round in turn.
+	constants []*big.Int
+	mds       [][]*big.Int
+}
+
+func newPermutation(f *galois.Field, t, rf, rp int) *Permutation {
+	g := newGrain(f.Order().BitLen(), t, rf, rp)
+	p := &Permutation{f: f, t: t, rf: rf, rp: rp}
+	p.constants = make([]*big.Int, (rf+rp)*t)
+	for i := range p.constants {
+		p.constants[i] = g.element(f)
+	}
+	p.mds = g.cauchy(f, t)
+	return p
+}
+
+// Width returns the number of field elements permuted.
+func (p *Permutation) Width() int {
+	return p.t
+}
+
+// sbox returns x^alpha.
+func (p *Permutation) sbox(x *big.Int) *big.Int {
+	x2 := p.f.Square(x)
+	return p.f.Mul(p.f.Square(x2), x)
+}
+
+// Permute applies the permutation to the state, in place. The state must hold
+// Width elements of the field.
+func (p *Permutation) Permute(state []*big.Int) error {
+	if len(state) != p.t {
+		return fmt.Errorf("state of %d elements; want %d", len(state), p.t)
+	}
+	for i, x := range state {
+		if x == nil || x.Sign() < 0 || x.Cmp(p.f.Order()) >= 0 {
+			return fmt.Errorf("state element %d not in the field", i)
+		}
+	}
+
+	s := make([]*big.Int, p.t)
+	copy(s, state)
+	next := make([]*big.Int, p.t)
+	for r := 0; r < p.rf+p.rp; r++ {
+		for i := range s {
+			s[i] = p.f.Add(s[i], p.constants[r*p.t+i])
+		}
+		if r < p.rf/2 || r >= p.rf/2+p.rp {
+			for i := range s {
+				s[i] = p.sbox(s[i])
+			}
+		} else {
+			s[0] = p.sbox(s[0])
+		}
+		for i, row := range p.mds {
+			acc := new(big.Int)
+			for j, m := range row {
+				acc = p.f.Add(acc, p.f.Mul(m, s[j]))
+			}
+			next[i] = acc
+		}
+		s, next = next, s
+	}
+	for i := range state {
+		state[i] = s[i]
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/merkle"
+)
+
+var _ merkle.Compressor = BN256
+
+func ints(n int) []*big.Int {
+	xs := make([]*big.Int, n)
+	for i := range xs {
+		xs[i] = big.NewInt(int64(i + 1))
+	}
+	return xs
+}
+
+func TestHash(t *testing.T) {
+	// Poseidon of 1, ..., n by circomlib and go-iden3-crypto.
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 1, want: "29176100eaa962bdc1fe6c654d6a3c130e96a4d1168b33848b897dc502820133"},
+		{n: 2, want: "115cc0f5e7d690413df64c6b9662e9cf2a3617f2743245519e19607a4417189a"},
+		{n: 4, want: "299c867db6c1fdd79dcefa40e4510b9837e60ebb1ce0663dbaa525df65250465"},
+		{n: 16, want: "16159a551cbb66108281a48099fff949ae08afd7f1f2ec06de2ffb96b919b765"},
+	}
+
+	for _, tt := range tests {
+		got, err := BN256.Hash(ints(tt.n)...)
+		if err != nil {
+			t.Fatalf("Hash(1, ..., %d): %v", tt.n, err)
+		}
+		if want, _ := new(big.Int).SetString(tt.want, 16); got.Cmp(want) != 0 {
+			t.Errorf("Hash(1, ..., %d) = %x; want %s", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestHashErrors(t *testing.T) {
+	for _, h := range []*Hasher{BN256, BLS12381} {
+		order := h.Field().Order()
+		for _, inputs := range [][]*big.Int{
+			nil,
+			ints(MaxWidth),
+			{big.NewInt(-1)},
+			{big.NewInt(1), order},
+		} {
+			if _, err := h.Hash(inputs...); err == nil {
+				t.Errorf("Hash(%v): got nil error", inputs)
+			}
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(galois.NewField(big.NewInt(11))); err == nil {
+		t.Errorf("New(<field of order 11>): got nil error")
+	}
+	h, err := New(galois.NewField(big.NewInt(13)))
+	if err != nil {
+		t.Fatalf("New(<field of order 13>): %v", err)
+	}
+	got, err := h.Hash(big.NewInt(1), big.NewInt(2))
+	if err != nil || got.Cmp(big.NewInt(13)) >= 0 {
+		t.Errorf("Hash(1, 2) over order 13 = %v, %v; want an element", got, err)
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	for _, h := range []*Hasher{BN256, BLS12381} {
+		for _, w := range []int{1, MaxWidth + 1} {
+			if _, err := h.Permutation(w); err == nil {
+				t.Errorf("Permutation(%d): got nil error", w)
+			}
+		}
+
+		p, err := h.Permutation(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Width() != 3 {
+			t.Errorf("Permutation(3).Width() = %d; want 3", p.Width())
+		}
+		if q, _ := h.Permutation(3); q != p {
+			t.Errorf("Permutation(3) not cached")
+		}
+		if err := p.Permute(ints(2)); err == nil {
+			t.Errorf("Permute(<2 elements>): got nil error")
+		}
+		if err := p.Permute([]*big.Int{big.NewInt(0), h.Field().Order(), big.NewInt(0)}); err == nil {
+			t.Errorf("Permute(<unreduced element>): got nil error")
+		}
+
+		state := ints(3)
+		if err := p.Permute(state); err != nil {
+			t.Fatal(err)
+		}
+		again := ints(3)
+		if err := p.Permute(again); err != nil {
+			t.Fatal(err)
+		}
+		for i := range state {
+			if state[i].Cmp(again[i]) != 0 {
+				t.Errorf("Permute(1, 2, 3)[%d] = %v, then %v", i, state[i], again[i])
+			}
+			if state[i].Cmp(big.NewInt(int64(i+1))) == 0 {
+				t.Errorf("Permute(1, 2, 3)[%d] = %d", i, i+1)
+			}
+		}
+	}
+
+	// The fields differ in size, and so do the parameters.
+	a, _ := BN256.Hash(ints(2)...)
+	b, _ := BLS12381.Hash(ints(2)...)
+	if a.Cmp(b) == 0 {
+		t.Errorf("BN256.Hash(1, 2) = BLS12381.Hash(1, 2) = %v", a)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	a, b := big.NewInt(1), big.NewInt(2)
+	want, err := BN256.Hash(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := BN256.Field().Order()
+	if got := BN256.Compress(new(big.Int).Add(a, order), b); got.Cmp(want) != 0 {
+		t.Errorf("Compress(1 + p, 2) = %v; want %v", got, want)
+	}
+}
+
+func squeeze(t *testing.T, h *Hasher, rate int, absorb [][]*big.Int, n int) []*big.Int {
+	t.Helper()
+	s, err := h.NewSponge(rate)
+	if err != nil {
+		t.Fatalf("NewSponge(%d): %v", rate, err)
+	}
+	for _, xs := range absorb {
+		s.Absorb(xs...)
+	}
+	out := make([]*big.Int, n)
+	for i := range out {
+		out[i] = s.Squeeze()
+	}
+	return out
+}
+
+func TestSponge(t *testing.T) {
+	for _, rate := range []int{0, MaxWidth} {
+		if _, err := BN256.NewSponge(rate); err == nil {
+			t.Errorf("NewSponge(%d): got nil error", rate)
+		}
+	}
+
+	for _, h := range []*Hasher{BN256, BLS12381} {
+		for _, rate := range []int{1, 2, 4} {
+			// Absorbing in one or several calls is the same.
+			whole := squeeze(t, h, rate, [][]*big.Int{ints(7)}, 10)
+			xs := ints(7)
+			split := squeeze(t, h, rate, [][]*big.Int{xs[:3], nil, xs[3:]}, 10)
+			for i := range whole {
+				if whole[i].Cmp(split[i]) != 0 {
+					t.Errorf("rate %d: Squeeze() #%d differs for split input", rate, i)
+				}
+			}
+
+			// Padding distinguishes trailing zeros.
+			padded := squeeze(t, h, rate, [][]*big.Int{append(ints(7), big.NewInt(0))}, 1)
+			if padded[0].Cmp(whole[0]) == 0 {
+				t.Errorf("rate %d: Squeeze() with trailing zero = %v, as without", rate, padded[0])
+			}
+
+			seen := make(map[string]bool)
+			for _, x := range whole {
+				if seen[x.String()] {
+					t.Errorf("rate %d: Squeeze() repeats %v", rate, x)
+				}
+				seen[x.String()] = true
+			}
+		}
+
+		// Absorbing after squeezing changes the output.
+		s, err := h.NewSponge(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Absorb(ints(2)...)
+		first := s.Squeeze()
+		s.Absorb(big.NewInt(3))
+		if got := s.Squeeze(); got.Cmp(first) == 0 {
+			t.Errorf("Squeeze() after Absorb(3) = %v, as before", got)
+		}
+	}
+}
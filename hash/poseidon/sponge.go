@@ -0,0 +1,84 @@
+package poseidon
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// A Sponge absorbs and squeezes any number of field elements with a
+// Permutation, with a capacity of one element and a rate of the remaining
+// Width-1. The capacity element starts out as zero.
+//
+// Absorbed elements are added to the rate part of the state, permuting
+// whenever it is full. Squeezing first pads the absorbed elements with a
+// single one, so that sequences of different lengths are distinguished, and
+// permutes. Elements absorbed after squeezing start a new phase in the same
+// way, so the output depends on all elements absorbed before it.
+type Sponge struct {
+	p     *Permutation
+	state []*big.Int
+	// pos is the position in the rate part of the next element to absorb or
+	// squeeze, in [0, rate].
+	pos       int
+	squeezing bool
+}
+
+// NewSponge returns a Sponge with the Permutation of width rate+1.
+func (h *Hasher) NewSponge(rate int) (*Sponge, error) {
+	p, err := h.Permutation(rate + 1)
+	if err != nil {
+		return nil, fmt.Errorf("rate %d: %v", rate, err)
+	}
+	s := &Sponge{p: p, state: make([]*big.Int, p.t)}
+	for i := range s.state {
+		s.state[i] = new(big.Int)
+	}
+	return s, nil
+}
+
+// Rate returns the number of elements absorbed or squeezed per permutation.
+func (s *Sponge) Rate() int {
+	return s.p.t - 1
+}
+
+// permute applies the Permutation to the state and resets the position.
+func (s *Sponge) permute() {
+	if err := s.p.Permute(s.state); err != nil {
+		// The state always holds Width reduced elements.
+		panic(err)
+	}
+	s.pos = 0
+}
+
+// Absorb absorbs the elements, reduced into the field.
+func (s *Sponge) Absorb(xs ...*big.Int) {
+	f := s.p.f
+	if s.squeezing {
+		s.squeezing = false
+		s.pos = 0
+	}
+	for _, x := range xs {
+		if s.pos == s.Rate() {
+			s.permute()
+		}
+		s.state[1+s.pos] = f.Add(s.state[1+s.pos], f.Mod(x))
+		s.pos++
+	}
+}
+
+// Squeeze returns the next element of output.
+func (s *Sponge) Squeeze() *big.Int {
+	if !s.squeezing {
+		if s.pos == s.Rate() {
+			s.permute()
+		}
+		s.state[1+s.pos] = s.p.f.Add(s.state[1+s.pos], bigOne)
+		s.permute()
+		s.squeezing = true
+	} else if s.pos == s.Rate() {
+		s.permute()
+	}
+	out := new(big.Int).Set(s.state[1+s.pos])
+	s.pos++
+	return out
+}
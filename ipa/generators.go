@@ -0,0 +1,22 @@
+package ipa
+
+import (
+	"encoding/binary"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/curve"
+)
+
+// hashToG1 returns the generator of the specified index, a point on G1 with
+// unknown discrete logarithm hashed with curve.BN256.HashToG1 from the
+// big-endian index under dst.
+func hashToG1(index uint64) *bn256.G1 {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], index)
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(curve.BN256.HashToG1([]byte(dst), msg[:]).Marshal()); err != nil {
+		// Unreachable as the hash is on the curve.
+		panic(err)
+	}
+	return p
+}
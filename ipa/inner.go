@@ -0,0 +1,139 @@
+package ipa
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/transcript"
+)
+
+// An InnerProductProof shows knowledge of vectors a and b with
+// P = <a, G> + <b, H> + <a, b> U for generators G, H and U, not revealing a
+// and b. This is the general argument of Bulletproofs (Protocol 2), of which
+// Open is the special case of a public b. L and R hold the cross terms of
+// each round, and A and B the single elements left after the last.
+type InnerProductProof struct {
+	L, R []*bn256.G1
+	A, B *big.Int
+}
+
+// ProveInnerProduct returns an InnerProductProof for a and b, with the
+// generators G, H and U, whose lengths must be equal and a power of two. The
+// caller MUST append P, or the messages it is derived from, to the transcript.
+func ProveInnerProduct(g, h []*bn256.G1, u *bn256.G1, a, b []*big.Int, t *transcript.Transcript) (*InnerProductProof, error) {
+	n := len(g)
+	if n == 0 || n&(n-1) != 0 || len(h) != n || len(a) != n || len(b) != n {
+		return nil, fmt.Errorf("vectors of lengths %d, %d, %d, %d; want equal powers of two", len(g), len(h), len(a), len(b))
+	}
+	a, b = reduce(a), reduce(b)
+
+	proof := new(InnerProductProof)
+	for len(a) > 1 {
+		k := len(a) / 2
+		aLo, aHi, bLo, bHi := a[:k], a[k:], b[:k], b[k:]
+		gLo, gHi, hLo, hHi := g[:k], g[k:], h[:k], h[k:]
+
+		l, err := msm.MultiExp(append(append(append([]*bn256.G1(nil), gHi...), hLo...), u),
+			append(append(append([]*big.Int(nil), aLo...), bHi...), innerProduct(aLo, bHi)))
+		if err != nil {
+			return nil, err
+		}
+		r, err := msm.MultiExp(append(append(append([]*bn256.G1(nil), gLo...), hHi...), u),
+			append(append(append([]*big.Int(nil), aHi...), bLo...), innerProduct(aHi, bLo)))
+		if err != nil {
+			return nil, err
+		}
+		proof.L, proof.R = append(proof.L, l), append(proof.R, r)
+
+		x, xInv, err := roundChallenge(t, l, r)
+		if err != nil {
+			return nil, err
+		}
+		a = fold(aLo, aHi, x, xInv)
+		b = fold(bLo, bHi, xInv, x)
+		g = foldPoints(gLo, gHi, xInv, x)
+		h = foldPoints(hLo, hHi, x, xInv)
+	}
+	proof.A, proof.B = a[0], b[0]
+	return proof, nil
+}
+
+// VerifyInnerProduct reports whether the proof is valid for P with the
+// generators G, H and U, with the same transcript as the prover. Rather than
+// folding the generators, it checks
+//
+//	P + sum_j (x_j^2 L_j + x_j^-2 R_j) == sum_i (A s_i G_i + B s_i^-1 H_i) + A*B*U
+//
+// with a single multi-exponentiation, where s_i is the product of x_j or
+// x_j^-1 for the round challenges x_j, depending on whether G_i was in the
+// upper or lower half in round j. Proofs with A or B outside of
+// [0, Field.Order()) are rejected.
+func VerifyInnerProduct(g, h []*bn256.G1, u, p *bn256.G1, proof *InnerProductProof, t *transcript.Transcript) bool {
+	n := len(g)
+	if n == 0 || n&(n-1) != 0 || len(h) != n {
+		return false
+	}
+	rounds := bits.Len(uint(n)) - 1
+	if len(proof.L) != rounds || len(proof.R) != rounds || !canonical(proof.A, proof.B) {
+		return false
+	}
+
+	xs, xInvs := make([]*big.Int, rounds), make([]*big.Int, rounds)
+	for j := range xs {
+		x, xInv, err := roundChallenge(t, proof.L[j], proof.R[j])
+		if err != nil {
+			return false
+		}
+		xs[j], xInvs[j] = x, xInv
+	}
+
+	// s[i] = prod_j x_j^(+-1), where bit rounds-1-j of i selects the half of
+	// round j.
+	s := make([]*big.Int, n)
+	s[0] = big.NewInt(1)
+	for j := range xInvs {
+		s[0] = Field.Mul(s[0], xInvs[j])
+	}
+	x2s := make([]*big.Int, rounds)
+	for j := range xs {
+		x2s[j] = Field.Mul(xs[j], xs[j])
+	}
+	for i := 1; i < n; i++ {
+		// Flip the lowest set bit: from s[i - 2^k], replace x_j^-1 by x_j.
+		k := bits.TrailingZeros(uint(i))
+		s[i] = Field.Mul(s[i-(1<<k)], x2s[rounds-1-k])
+	}
+
+	a, b := proof.A, proof.B
+	points := make([]*bn256.G1, 0, 2*n+2*rounds+1)
+	scalars := make([]*big.Int, 0, cap(points))
+	for i := 0; i < n; i++ {
+		points = append(points, g[i], h[i])
+		// s_i^-1 = s_{n-1-i}, as the complement swaps x_j and x_j^-1.
+		scalars = append(scalars, Field.Mul(a, s[i]), Field.Mul(b, s[n-1-i]))
+	}
+	points = append(points, u)
+	scalars = append(scalars, Field.Mul(a, b))
+	for j := 0; j < rounds; j++ {
+		points = append(points, proof.L[j], proof.R[j])
+		scalars = append(scalars, Field.Sub(bigZero, x2s[j]), Field.Sub(bigZero, Field.Mul(xInvs[j], xInvs[j])))
+	}
+	want, err := msm.MultiExp(points, scalars)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(p.Marshal(), want.Marshal())
+}
+
+// reduce returns all xs reduced into the Field.
+func reduce(xs []*big.Int) []*big.Int {
+	ys := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		ys[i] = new(big.Int).Mod(x, Field.Order())
+	}
+	return ys
+}
@@ -0,0 +1,88 @@
+package ipa
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/transcript"
+)
+
+func randomVector(t *testing.T, n int) []*big.Int {
+	t.Helper()
+	v := make([]*big.Int, n)
+	for i := range v {
+		x, err := Field.Random(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v[i] = x
+	}
+	return v
+}
+
+// innerProductCommitment returns <a, G> + <b, H> + <a, b> U.
+func innerProductCommitment(t *testing.T, g, h []*bn256.G1, u *bn256.G1, a, b []*big.Int) *bn256.G1 {
+	t.Helper()
+	points := append(append(append([]*bn256.G1(nil), g...), h...), u)
+	scalars := append(append(append([]*big.Int(nil), a...), b...), innerProduct(a, b))
+	p, err := msm.MultiExp(points, scalars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestInnerProduct(t *testing.T) {
+	params, err := Setup(31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []int{1, 2, 8, 16} {
+		g, h := params.G[:n], params.G[n:2*n]
+		a, b := randomVector(t, n), randomVector(t, n)
+		p := innerProductCommitment(t, g, h, params.U, a, b)
+
+		proof, err := ProveInnerProduct(g, h, params.U, a, b, transcript.New("test"))
+		if err != nil {
+			t.Fatalf("ProveInnerProduct(<n = %d>): %v", n, err)
+		}
+		if !VerifyInnerProduct(g, h, params.U, p, proof, transcript.New("test")) {
+			t.Errorf("VerifyInnerProduct(ProveInnerProduct(<n = %d>)) = false", n)
+		}
+		if n > 1 && VerifyInnerProduct(g, h, params.U, p, proof, transcript.New("other")) {
+			t.Errorf("VerifyInnerProduct(ProveInnerProduct(<n = %d>)) with other transcript = true", n)
+		}
+
+		b[0] = Field.Add(b[0], big.NewInt(1))
+		wrong := innerProductCommitment(t, g, h, params.U, a, b)
+		if VerifyInnerProduct(g, h, params.U, wrong, proof, transcript.New("test")) {
+			t.Errorf("VerifyInnerProduct(<n = %d>) for other b = true", n)
+		}
+		if n > 1 {
+			if VerifyInnerProduct(h, g, params.U, p, proof, transcript.New("test")) {
+				t.Errorf("VerifyInnerProduct(<n = %d>) with swapped generators = true", n)
+			}
+			if VerifyInnerProduct(g, h, params.U, p, &InnerProductProof{L: proof.L[1:], R: proof.R[1:], A: proof.A, B: proof.B}, transcript.New("test")) {
+				t.Errorf("VerifyInnerProduct(<n = %d>) with missing round = true", n)
+			}
+		}
+		for _, nc := range []*InnerProductProof{
+			{L: proof.L, R: proof.R, A: new(big.Int).Add(proof.A, Field.Order()), B: proof.B},
+			{L: proof.L, R: proof.R, A: proof.A, B: new(big.Int).Add(proof.B, Field.Order())},
+		} {
+			if VerifyInnerProduct(g, h, params.U, p, nc, transcript.New("test")) {
+				t.Errorf("VerifyInnerProduct(<n = %d>) with non-canonical scalar = true", n)
+			}
+		}
+	}
+
+	if _, err := ProveInnerProduct(params.G[:3], params.G[3:6], params.U, randomVector(t, 3), randomVector(t, 3), transcript.New("test")); err == nil {
+		t.Errorf("ProveInnerProduct(<n = 3>): got nil error")
+	}
+	if _, err := ProveInnerProduct(params.G[:4], params.G[4:8], params.U, randomVector(t, 4), randomVector(t, 2), transcript.New("test")); err == nil {
+		t.Errorf("ProveInnerProduct(<len(b) = 2>): got nil error")
+	}
+}
@@ -0,0 +1,286 @@
+// Package ipa implements polynomial commitments from Pedersen vector
+// commitments and the recursive inner-product argument (IPA) of Bulletproofs,
+// over bn256 G1.
+//
+// Unlike kzg, the Params require no trusted setup: the generators are derived
+// by hashing, so nobody knows their discrete logarithms. The price is a proof
+// of 2*log2(n) points and verification in O(n) group operations for
+// polynomials with n coefficients. See Bünz et al., "Bulletproofs: Short
+// Proofs for Confidential Transactions and More",
+// https://eprint.iacr.org/2017/1066, and Bowe et al., "Recursive Proof
+// Composition without a Trusted Setup", https://eprint.iacr.org/2019/1021.
+package ipa
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+var (
+	// Field is the scalar field of bn256, over which all committed polynomials
+	// are defined.
+	Field = kzg.Field
+
+	bigZero = big.NewInt(0)
+)
+
+// dst is the domain separation tag with which the generators of all Params
+// are hashed.
+const dst = "zkp.xyz/ipa/generators"
+
+// Params are the public parameters for committing to polynomials of degree
+// less than len(G), which is a power of two. A commitment to p is the Pedersen
+// vector commitment sum_i p_i G[i], and U binds evaluations in proofs.
+type Params struct {
+	G []*bn256.G1
+	U *bn256.G1
+}
+
+// Setup returns the Params supporting polynomials of degree up to maxDegree.
+// They are deterministic, so Params for the same maxDegree are identical and
+// those for smaller degrees are prefixes of those for larger ones.
+func Setup(maxDegree int) (*Params, error) {
+	if maxDegree < 1 {
+		return nil, fmt.Errorf("max degree %d < 1", maxDegree)
+	}
+	n := 1 << bits.Len(uint(maxDegree))
+	params := &Params{G: make([]*bn256.G1, n), U: hashToG1(0)}
+	for i := range params.G {
+		params.G[i] = hashToG1(uint64(i + 1))
+	}
+	return params, nil
+}
+
+// MaxDegree returns the maximum degree of polynomials supported by the Params.
+func (params *Params) MaxDegree() int {
+	return len(params.G) - 1
+}
+
+// coefficients returns the coefficients of p reduced into Field and padded to
+// len(G), or an error if its degree is too large.
+func (params *Params) coefficients(p *polynomial.Polynomial) ([]*big.Int, error) {
+	p = p.Normalize(Field)
+	if d := p.Degree(); d > params.MaxDegree() {
+		return nil, fmt.Errorf("polynomial degree %d > max degree %d", d, params.MaxDegree())
+	}
+	cs := make([]*big.Int, len(params.G))
+	for i := range cs {
+		cs[i] = bigZero
+		if i < len(*p) {
+			cs[i] = (*p)[i]
+		}
+	}
+	return cs, nil
+}
+
+// Commit returns the commitment sum_i p_i G[i] to p.
+func (params *Params) Commit(p *polynomial.Polynomial) (*bn256.G1, error) {
+	cs, err := params.coefficients(p)
+	if err != nil {
+		return nil, err
+	}
+	return msm.MultiExp(params.G, cs)
+}
+
+// A Proof attests that a committed polynomial p evaluates to p(Z) = Y. In
+// every round of the argument, the vectors of coefficients a, powers of Z b,
+// and generators are halved; L and R hold the cross terms of each round and A
+// is the single coefficient left after the last.
+type Proof struct {
+	Z, Y *big.Int
+	L, R []*bn256.G1
+	A    *big.Int
+}
+
+// newTranscript returns the Transcript of an opening of c at z to y.
+func newTranscript(c *bn256.G1, z, y *big.Int) *transcript.Transcript {
+	t := transcript.New("zkp.xyz/ipa/open")
+	t.AppendG1("commitment", c)
+	t.AppendScalar("z", z)
+	t.AppendScalar("y", y)
+	return t
+}
+
+// Open returns a Proof of the evaluation of p at z.
+func (params *Params) Open(p *polynomial.Polynomial, z *big.Int) (*Proof, error) {
+	a, err := params.coefficients(p)
+	if err != nil {
+		return nil, err
+	}
+	c, err := msm.MultiExp(params.G, a)
+	if err != nil {
+		return nil, err
+	}
+	z = new(big.Int).Mod(z, Field.Order())
+	b := polynomial.ComputePowers(z, len(a), Field)
+	y := innerProduct(a, b)
+
+	t := newTranscript(c, z, y)
+	u := new(bn256.G1).ScalarMult(params.U, t.Challenge(Field))
+
+	proof := &Proof{Z: z, Y: y}
+	g := params.G
+	for len(a) > 1 {
+		h := len(a) / 2
+		aLo, aHi, bLo, bHi, gLo, gHi := a[:h], a[h:], b[:h], b[h:], g[:h], g[h:]
+
+		l, err := crossTerm(aLo, gHi, innerProduct(aLo, bHi), u)
+		if err != nil {
+			return nil, err
+		}
+		r, err := crossTerm(aHi, gLo, innerProduct(aHi, bLo), u)
+		if err != nil {
+			return nil, err
+		}
+		proof.L, proof.R = append(proof.L, l), append(proof.R, r)
+
+		x, xInv, err := roundChallenge(t, l, r)
+		if err != nil {
+			return nil, err
+		}
+		a = fold(aLo, aHi, x, xInv)
+		b = fold(bLo, bHi, xInv, x)
+		g = foldPoints(gLo, gHi, xInv, x)
+	}
+	proof.A = a[0]
+	return proof, nil
+}
+
+// crossTerm returns <a, g> + ab*u.
+func crossTerm(a []*big.Int, g []*bn256.G1, ab *big.Int, u *bn256.G1) (*bn256.G1, error) {
+	p, err := msm.MultiExp(g, a)
+	if err != nil {
+		return nil, err
+	}
+	return p.Add(p, new(bn256.G1).ScalarMult(u, ab)), nil
+}
+
+// roundChallenge appends the cross terms of a round and returns its challenge
+// x and x^-1.
+func roundChallenge(t *transcript.Transcript, l, r *bn256.G1) (*big.Int, *big.Int, error) {
+	t.AppendG1("L", l)
+	t.AppendG1("R", r)
+	x := t.Challenge(Field)
+	xInv, err := Field.MultInverse(x)
+	if err != nil {
+		return nil, nil, fmt.Errorf("round challenge: %v", err)
+	}
+	return x, xInv, nil
+}
+
+func innerProduct(a, b []*big.Int) *big.Int {
+	sum := big.NewInt(0)
+	for i := range a {
+		sum = Field.Add(sum, Field.Mul(a[i], b[i]))
+	}
+	return sum
+}
+
+// fold returns lo*x + hi*y element-wise.
+func fold(lo, hi []*big.Int, x, y *big.Int) []*big.Int {
+	out := make([]*big.Int, len(lo))
+	for i := range out {
+		out[i] = Field.Add(Field.Mul(lo[i], x), Field.Mul(hi[i], y))
+	}
+	return out
+}
+
+// foldPoints returns lo*x + hi*y element-wise.
+func foldPoints(lo, hi []*bn256.G1, x, y *big.Int) []*bn256.G1 {
+	out := make([]*bn256.G1, len(lo))
+	for i := range out {
+		out[i] = new(bn256.G1).ScalarMult(lo[i], x)
+		out[i].Add(out[i], new(bn256.G1).ScalarMult(hi[i], y))
+	}
+	return out
+}
+
+// Verify reports whether the proof is valid for the commitment c, i.e. whether
+// the committed polynomial p satisfies p(proof.Z) = proof.Y. Starting from
+// P = c + y*u, every round replaces P by x^2 L + P + x^-2 R, which holds the
+// folded vectors if P held the original ones, and finally checks
+//
+//	P == A*G' + A*b'*u
+//
+// for the folded generator G' and power b'. Proofs with scalars outside of
+// [0, Field.Order()) are rejected, so that every proof has a single encoding.
+func (params *Params) Verify(c *bn256.G1, proof *Proof) bool {
+	rounds := bits.Len(uint(len(params.G))) - 1
+	if len(proof.L) != rounds || len(proof.R) != rounds || !canonical(proof.Z, proof.Y, proof.A) {
+		return false
+	}
+	z, y := proof.Z, proof.Y
+
+	t := newTranscript(c, z, y)
+	u := new(bn256.G1).ScalarMult(params.U, t.Challenge(Field))
+
+	p := new(bn256.G1).Add(c, new(bn256.G1).ScalarMult(u, y))
+	g := params.G
+	b := polynomial.ComputePowers(z, len(g), Field)
+	for j := 0; j < rounds; j++ {
+		x, xInv, err := roundChallenge(t, proof.L[j], proof.R[j])
+		if err != nil {
+			return false
+		}
+		x2, xInv2 := Field.Mul(x, x), Field.Mul(xInv, xInv)
+		p.Add(p, new(bn256.G1).ScalarMult(proof.L[j], x2))
+		p.Add(p, new(bn256.G1).ScalarMult(proof.R[j], xInv2))
+
+		h := len(g) / 2
+		g = foldPoints(g[:h], g[h:], xInv, x)
+		b = fold(b[:h], b[h:], xInv, x)
+	}
+
+	a := proof.A
+	want := new(bn256.G1).ScalarMult(g[0], a)
+	want.Add(want, new(bn256.G1).ScalarMult(u, Field.Mul(a, b[0])))
+	return bytes.Equal(p.Marshal(), want.Marshal())
+}
+
+// canonical reports whether all xs are non-nil and in [0, Field.Order()).
+func canonical(xs ...*big.Int) bool {
+	for _, x := range xs {
+		if x == nil || x.Sign() < 0 || x.Cmp(Field.Order()) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// A Commitment is a commitment to a polynomial under some Params. It can be
+// converted to and from a *bn256.G1 at no cost.
+type Commitment bn256.G1
+
+// G1 returns the commitment as a point on G1, which MUST NOT be modified.
+func (c *Commitment) G1() *bn256.G1 {
+	return (*bn256.G1)(c)
+}
+
+// Commit returns the Commitment to p under the Params.
+func Commit(params *Params, p *polynomial.Polynomial) (*Commitment, error) {
+	c, err := params.Commit(p)
+	if err != nil {
+		return nil, err
+	}
+	return (*Commitment)(c), nil
+}
+
+// Open returns a Proof of the evaluation of p at z, with the Params under
+// which p was committed to.
+func Open(params *Params, p *polynomial.Polynomial, z *big.Int) (*Proof, error) {
+	return params.Open(p, z)
+}
+
+// Verify reports whether the proof is valid for the Commitment, with the
+// Params under which the Commitment was created.
+func Verify(params *Params, c *Commitment, proof *Proof) bool {
+	return params.Verify(c.G1(), proof)
+}
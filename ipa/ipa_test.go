@@ -0,0 +1,161 @@
+package ipa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		maxDegree, want int
+	}{
+		{maxDegree: 1, want: 1},
+		{maxDegree: 3, want: 3},
+		{maxDegree: 4, want: 7},
+		{maxDegree: 16, want: 31},
+	}
+
+	for _, tt := range tests {
+		params, err := Setup(tt.maxDegree)
+		if err != nil {
+			t.Fatalf("Setup(%d): %v", tt.maxDegree, err)
+		}
+		if got := params.MaxDegree(); got != tt.want {
+			t.Errorf("Setup(%d).MaxDegree() = %d; want %d", tt.maxDegree, got, tt.want)
+		}
+	}
+
+	if _, err := Setup(0); err == nil {
+		t.Errorf("Setup(0): got nil error")
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	small, err := Setup(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	large, err := Setup(15)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[string]bool{string(large.U.Marshal()): true}
+	for i, g := range large.G {
+		enc := g.Marshal()
+		if seen[string(enc)] {
+			t.Errorf("generator %d repeated", i)
+		}
+		seen[string(enc)] = true
+		if i < len(small.G) && !bytes.Equal(enc, small.G[i].Marshal()) {
+			t.Errorf("Setup(3).G[%d] != Setup(15).G[%d]", i, i)
+		}
+		if _, err := new(bn256.G1).Unmarshal(enc); err != nil {
+			t.Errorf("generator %d not on curve: %v", i, err)
+		}
+	}
+}
+
+func TestOpenVerify(t *testing.T) {
+	params, err := Setup(15)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []int{0, 1, 7, 15} {
+		p, err := polynomial.Random(d, Field, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := Commit(params, p)
+		if err != nil {
+			t.Fatalf("Commit(<degree %d>): %v", d, err)
+		}
+		z, err := Field.Random(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := Open(params, p, z)
+		if err != nil {
+			t.Fatalf("Open(<degree %d>): %v", d, err)
+		}
+		if want := p.Evaluate(z, Field); proof.Y.Cmp(want) != 0 {
+			t.Errorf("Open(<degree %d>, z).Y = %v; want p(z) = %v", d, proof.Y, want)
+		}
+		if got := len(proof.L); got != 4 {
+			t.Errorf("len(Open(<degree %d>).L) = %d; want 4", d, got)
+		}
+		if !Verify(params, c, proof) {
+			t.Errorf("Verify(Commit(<degree %d>), Open()) = false", d)
+		}
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	params, err := Setup(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := polynomial.NewPolynomialFromCoefficients([]int64{3, 1, 4, 1, 5, 9, 2, 6})
+	c, err := params.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := params.Commit(polynomial.NewPolynomialFromCoefficients([]int64{3, 1, 4, 1, 5, 9, 2, 7}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		c      *bn256.G1
+		tamper func(*Proof)
+	}{
+		{name: "other commitment", c: other, tamper: func(*Proof) {}},
+		{name: "Y", c: c, tamper: func(p *Proof) { p.Y = Field.Add(p.Y, big.NewInt(1)) }},
+		{name: "Z", c: c, tamper: func(p *Proof) { p.Z = Field.Add(p.Z, big.NewInt(1)) }},
+		{name: "A", c: c, tamper: func(p *Proof) { p.A = Field.Add(p.A, big.NewInt(1)) }},
+		{name: "L", c: c, tamper: func(p *Proof) { p.L[1] = new(bn256.G1).Add(p.L[1], params.G[0]) }},
+		{name: "swapped L and R", c: c, tamper: func(p *Proof) { p.L, p.R = p.R, p.L }},
+		{name: "missing round", c: c, tamper: func(p *Proof) { p.L, p.R = p.L[1:], p.R[1:] }},
+		// Non-canonical scalars, equal to the honest ones modulo the Field.
+		{name: "Y + r", c: c, tamper: func(p *Proof) { p.Y = new(big.Int).Add(p.Y, Field.Order()) }},
+		{name: "Z + r", c: c, tamper: func(p *Proof) { p.Z = new(big.Int).Add(p.Z, Field.Order()) }},
+		{name: "A + r", c: c, tamper: func(p *Proof) { p.A = new(big.Int).Add(p.A, Field.Order()) }},
+		{name: "A - r", c: c, tamper: func(p *Proof) { p.A = new(big.Int).Sub(p.A, Field.Order()) }},
+		{name: "nil A", c: c, tamper: func(p *Proof) { p.A = nil }},
+	}
+
+	for _, tt := range tests {
+		proof, err := params.Open(p, big.NewInt(42))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !params.Verify(c, proof) {
+			t.Fatalf("Verify(Open()) = false")
+		}
+		tt.tamper(proof)
+		if params.Verify(tt.c, proof) {
+			t.Errorf("Verify() with tampered %s = true", tt.name)
+		}
+	}
+}
+
+func TestOpenDegreeTooLarge(t *testing.T) {
+	params, err := Setup(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 4, 5})
+	if _, err := params.Commit(p); err == nil {
+		t.Errorf("Commit(<degree 4>) with max degree 3: got nil error")
+	}
+	if _, err := params.Open(p, big.NewInt(1)); err == nil {
+		t.Errorf("Open(<degree 4>) with max degree 3: got nil error")
+	}
+}
@@ -0,0 +1,131 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// An AggregateProof attests that each of a set of committed polynomials p_i
+// evaluates to p_i(Z) = Ys[i], with a single quotient. The polynomials are
+// combined as sum_i gamma^i p_i for a Fiat-Shamir challenge gamma.
+type AggregateProof struct {
+	Z        *big.Int
+	Ys       []*big.Int
+	Quotient *bn256.G2
+}
+
+// OpensAt reports whether the proof is non-nil and opens n evaluations, none of
+// them nil, at z. Verifiers check this before using the evaluations.
+func (p *AggregateProof) OpensAt(z *big.Int, n int) bool {
+	if p == nil || p.Z == nil || p.Z.Cmp(z) != 0 || len(p.Ys) != n {
+		return false
+	}
+	for _, y := range p.Ys {
+		if y == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// aggregateChallenge returns the challenge gamma for combining the commitments.
+func aggregateChallenge(cs []*bn256.G1, z *big.Int, ys []*big.Int) *big.Int {
+	t := transcript.New("zkp.xyz/kzg/aggregate")
+	for _, c := range cs {
+		t.AppendG1("commitment", c)
+	}
+	t.AppendScalar("z", z)
+	for _, y := range ys {
+		t.AppendScalar("y", y)
+	}
+	return t.Challenge(Field)
+}
+
+// OpenAggregate opens all polynomials, committed to as cs, at z.
+func (pr *Prover) OpenAggregate(ps []*polynomial.Polynomial, cs []*bn256.G1, z *big.Int) (*AggregateProof, error) {
+	if len(ps) != len(cs) || len(ps) == 0 {
+		return nil, fmt.Errorf("len(polynomials) != len(commitments) or empty: %d != %d", len(ps), len(cs))
+	}
+
+	z = new(big.Int).Mod(z, Field.Order())
+	ys := make([]*big.Int, len(ps))
+	for i, p := range ps {
+		ys[i] = p.Evaluate(z, Field)
+	}
+
+	gammas := pr.gammaPowers(aggregateChallenge(cs, z, ys), len(ps))
+	combined := polynomial.NewZeroPolynomial(0)
+	for i, p := range ps {
+		combined = combined.Add(p.Scale(gammas[i], Field), Field)
+	}
+
+	proof, err := pr.srs.Open(combined, z)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregateProof{Z: z, Ys: ys, Quotient: proof.Quotient}, nil
+}
+
+// VerifyAggregate reports whether the proof is valid for the commitments.
+func (vk *VerifierKey) VerifyAggregate(cs []*bn256.G1, proof *AggregateProof) bool {
+	if len(cs) != len(proof.Ys) || len(cs) == 0 {
+		return false
+	}
+
+	gammas := polynomial.ComputePowers(aggregateChallenge(cs, proof.Z, proof.Ys), len(cs), Field)
+	c := new(bn256.G1).ScalarBaseMult(bigZero)
+	y := big.NewInt(0)
+	for i, g := range gammas {
+		c.Add(c, new(bn256.G1).ScalarMult(cs[i], g))
+		y = Field.Add(y, Field.Mul(proof.Ys[i], g))
+	}
+
+	return vk.Verify(c, &Proof{Z: proof.Z, Y: y, Quotient: proof.Quotient})
+}
+
+// maxCachedChallenges bounds the number of challenges for which the powers are
+// cached by a Prover.
+const maxCachedChallenges = 16
+
+// A powersCache caches the powers of recent challenges, as aggregating the
+// same set of polynomials repeatedly results in the same challenges.
+type powersCache struct {
+	mu     sync.Mutex
+	powers map[string][]*big.Int
+	order  []string // insertion order for eviction
+	muls   int      // field multiplications performed, for testing
+}
+
+// gammaPowers returns [gamma^0, ..., gamma^(n-1)], reusing and extending
+// previously computed powers of gamma. The returned slice MUST NOT be modified.
+func (pr *Prover) gammaPowers(gamma *big.Int, n int) []*big.Int {
+	c := &pr.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := gamma.String()
+	powers, ok := c.powers[key]
+	if !ok {
+		if c.powers == nil {
+			c.powers = make(map[string][]*big.Int)
+		}
+		if len(c.order) == maxCachedChallenges {
+			delete(c.powers, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, key)
+		powers = []*big.Int{big.NewInt(1)}
+	}
+
+	for len(powers) < n {
+		powers = append(powers, Field.Mul(powers[len(powers)-1], gamma))
+		c.muls++
+	}
+	c.powers[key] = powers
+	return powers[:n]
+}
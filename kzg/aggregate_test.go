@@ -0,0 +1,129 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/google/go-cmp/cmp"
+	"zkp.xyz/membership/polynomial"
+)
+
+func aggregateFixture(t *testing.T, srs *SRS, n int) ([]*polynomial.Polynomial, []*bn256.G1) {
+	t.Helper()
+	var (
+		ps []*polynomial.Polynomial
+		cs []*bn256.G1
+	)
+	for i := 0; i < n; i++ {
+		p := polynomial.NewPolynomialFromCoefficients([]int64{int64(i), 2, int64(-i), 1})
+		c, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(): %v", err)
+		}
+		ps, cs = append(ps, p), append(cs, c)
+	}
+	return ps, cs
+}
+
+func TestOpenAggregate(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 5)
+	vk := srs.VerifierKey()
+	ps, cs := aggregateFixture(t, srs, 4)
+
+	proof, err := NewProver(srs).OpenAggregate(ps, cs, big.NewInt(7))
+	if err != nil {
+		t.Fatalf("OpenAggregate(): %v", err)
+	}
+	for i, p := range ps {
+		if want := p.Evaluate(big.NewInt(7), Field); proof.Ys[i].Cmp(want) != 0 {
+			t.Errorf("OpenAggregate().Ys[%d] = %v; want %v", i, proof.Ys[i], want)
+		}
+	}
+	if !vk.VerifyAggregate(cs, proof) {
+		t.Errorf("VerifyAggregate(OpenAggregate()) = false; want true")
+	}
+
+	wrong := *proof
+	wrong.Ys = append([]*big.Int{}, proof.Ys...)
+	wrong.Ys[2] = Field.Add(wrong.Ys[2], big.NewInt(1))
+	if vk.VerifyAggregate(cs, &wrong) {
+		t.Errorf("VerifyAggregate(<wrong value>) = true; want false")
+	}
+	if vk.VerifyAggregate([]*bn256.G1{cs[1], cs[0], cs[2], cs[3]}, proof) {
+		t.Errorf("VerifyAggregate(<swapped commitments>) = true; want false")
+	}
+}
+
+func TestOpensAt(t *testing.T) {
+	z := big.NewInt(7)
+	ys := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	tests := []struct {
+		name  string
+		proof *AggregateProof
+		want  bool
+	}{
+		{name: "valid", proof: &AggregateProof{Z: z, Ys: ys}, want: true},
+		{name: "nil", proof: nil, want: false},
+		{name: "nil Z", proof: &AggregateProof{Ys: ys}, want: false},
+		{name: "other Z", proof: &AggregateProof{Z: big.NewInt(8), Ys: ys}, want: false},
+		{name: "missing evaluation", proof: &AggregateProof{Z: z, Ys: ys[:1]}, want: false},
+		{name: "nil evaluation", proof: &AggregateProof{Z: z, Ys: []*big.Int{ys[0], nil}}, want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.proof.OpensAt(z, 2); got != tt.want {
+			t.Errorf("OpensAt(<%s>) = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOpenAggregateCache(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 5)
+	ps, cs := aggregateFixture(t, srs, 8)
+	z := big.NewInt(42)
+
+	cached := NewProver(srs)
+	const rounds = 5
+	var proofs []*AggregateProof
+	for i := 0; i < rounds; i++ {
+		proof, err := cached.OpenAggregate(ps, cs, z)
+		if err != nil {
+			t.Fatalf("OpenAggregate(): %v", err)
+		}
+		proofs = append(proofs, proof)
+	}
+
+	// A fresh Prover per round doesn't benefit from the cache.
+	want, err := NewProver(srs).OpenAggregate(ps, cs, z)
+	if err != nil {
+		t.Fatalf("OpenAggregate(): %v", err)
+	}
+	for i, got := range proofs {
+		if diff := cmp.Diff(want.Quotient.String(), got.Quotient.String()); diff != "" {
+			t.Errorf("round %d: cached OpenAggregate() differs, diff %v", i, diff)
+		}
+	}
+
+	uncachedMuls := rounds * (len(ps) - 1)
+	if got, want := cached.cache.muls, len(ps)-1; got != want {
+		t.Errorf("%d field multiplications for challenge powers over %d rounds; want %d (%d without cache)", got, rounds, want, uncachedMuls)
+	}
+}
+
+func TestGammaPowersEviction(t *testing.T) {
+	pr := NewProver(NewSRS(big.NewInt(1), 1))
+	for i := 0; i < 2*maxCachedChallenges; i++ {
+		pr.gammaPowers(big.NewInt(int64(i)), 3)
+	}
+	if got := len(pr.cache.powers); got != maxCachedChallenges {
+		t.Errorf("%d cached challenges; want %d", got, maxCachedChallenges)
+	}
+
+	got := pr.gammaPowers(big.NewInt(3), 4)
+	want := []string{"1", "3", "9", "27"}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Errorf("gammaPowers(3)[%d] = %v; want %s", i, got[i], want[i])
+		}
+	}
+}
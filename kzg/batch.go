@@ -0,0 +1,146 @@
+package kzg
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A BatchProof attests that a committed polynomial p evaluates to p(Zs[i]) =
+// Ys[i] for all i. The Quotient is [q(s)]_2 for q(v) = (p(v) - I(v)) / Z(v),
+// where I interpolates all (Zs[i], Ys[i]) and Z(v) = prod_i (v - Zs[i]).
+type BatchProof struct {
+	Zs, Ys   []*big.Int
+	Quotient *bn256.G2
+}
+
+// OpenBatch returns a BatchProof of the evaluations of p at all of the
+// distinct points zs, of which there can be at most srs.MaxDegree().
+func (srs *SRS) OpenBatch(p *polynomial.Polynomial, zs []*big.Int) (*BatchProof, error) {
+	p, err := srs.trim(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(zs) == 0 || len(zs) > srs.MaxDegree() {
+		return nil, fmt.Errorf("opening at %d points; want between 1 and SRS max degree %d", len(zs), srs.MaxDegree())
+	}
+
+	zs = reduceAll(zs)
+	ys := make([]*big.Int, len(zs))
+	for i, z := range zs {
+		ys[i] = p.Evaluate(z, Field)
+	}
+	interp, err := polynomial.Interpolate(zs, ys, Field)
+	if err != nil {
+		return nil, err
+	}
+
+	q, r := p.Sub(interp, Field).Div(polynomial.FromRoots(zs, Field), Field)
+	if !r.Eq(polynomial.ZeroPolynomial) {
+		// Unreachable as p - I vanishes on all zs.
+		return nil, fmt.Errorf("division rest not zero: %v", r)
+	}
+	qs2, err := srs.CommitG2(q)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchProof{Zs: zs, Ys: ys, Quotient: qs2}, nil
+}
+
+// VerifyOpenBatch reports whether the proof is valid for the commitment c, by
+// checking
+//
+//	e([Z(s)]_1, [q(s)]_2) == e([p(s) - I(s)]_1, [1]_2).
+//
+// Unlike VerifierKey.Verify, this requires the powers of the SRS up to the
+// number of points, to commit to Z and I.
+func (srs *SRS) VerifyOpenBatch(c *bn256.G1, proof *BatchProof) bool {
+	if len(proof.Zs) != len(proof.Ys) || len(proof.Zs) == 0 || len(proof.Zs) > srs.MaxDegree() {
+		return false
+	}
+	interp, err := polynomial.Interpolate(proof.Zs, proof.Ys, Field)
+	if err != nil {
+		return false
+	}
+	is1, err := srs.Commit(interp)
+	if err != nil {
+		return false
+	}
+	zs1, err := srs.Commit(polynomial.FromRoots(proof.Zs, Field))
+	if err != nil {
+		return false
+	}
+
+	return bn256.PairingCheck(
+		[]*bn256.G1{
+			zs1,
+			// (-1) * [p(s) - I(s)]_1
+			new(bn256.G1).Neg(new(bn256.G1).Add(c, new(bn256.G1).Neg(is1))),
+		},
+		[]*bn256.G2{
+			proof.Quotient,
+			new(bn256.G2).ScalarBaseMult(big.NewInt(1)),
+		},
+	)
+}
+
+// VerifyBatch reports whether all proofs are valid for their respective
+// commitments, with a single pairing check of three pairings instead of two
+// per proof. The individual checks
+//
+//	e([s - z_i]_1, [q_i(s)]_2) == e([p_i(s) - y_i]_1, [1]_2)
+//
+// are combined with random weights r_i, drawn from r, into
+//
+//	e([s]_1, sum_i r_i [q_i(s)]_2) ==
+//	    e([1]_1, sum_i r_i z_i [q_i(s)]_2) * e(sum_i r_i [p_i(s) - y_i]_1, [1]_2).
+//
+// If any proof is invalid, the combined check passes with probability at
+// most 1/|Field|. An error is only returned if r fails.
+func (vk *VerifierKey) VerifyBatch(cs []*bn256.G1, proofs []*Proof, r io.Reader) (bool, error) {
+	if len(cs) != len(proofs) {
+		return false, nil
+	}
+
+	sumQ := new(bn256.G2).ScalarBaseMult(bigZero)
+	sumZQ := new(bn256.G2).ScalarBaseMult(bigZero)
+	sumC := new(bn256.G1).ScalarBaseMult(bigZero)
+	for i, proof := range proofs {
+		w, err := Field.Random(r)
+		if err != nil {
+			return false, err
+		}
+		z := new(big.Int).Mod(proof.Z, Field.Order())
+		ny1 := new(bn256.G1).Neg(new(bn256.G1).ScalarBaseMult(proof.Y))
+
+		sumQ.Add(sumQ, new(bn256.G2).ScalarMult(proof.Quotient, w))
+		sumZQ.Add(sumZQ, new(bn256.G2).ScalarMult(proof.Quotient, Field.Mul(w, z)))
+		sumC.Add(sumC, new(bn256.G1).ScalarMult(new(bn256.G1).Add(cs[i], ny1), w))
+	}
+
+	g1 := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+	return bn256.PairingCheck(
+		[]*bn256.G1{
+			vk.S1,
+			new(bn256.G1).Neg(g1),
+			new(bn256.G1).Neg(sumC),
+		},
+		[]*bn256.G2{
+			sumQ,
+			sumZQ,
+			new(bn256.G2).ScalarBaseMult(big.NewInt(1)),
+		},
+	), nil
+}
+
+// reduceAll returns all xs reduced into the Field.
+func reduceAll(xs []*big.Int) []*big.Int {
+	ys := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		ys[i] = new(big.Int).Mod(x, Field.Order())
+	}
+	return ys
+}
@@ -0,0 +1,185 @@
+package kzg
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func bigInts(xs ...int64) []*big.Int {
+	var bs []*big.Int
+	for _, x := range xs {
+		bs = append(bs, big.NewInt(x))
+	}
+	return bs
+}
+
+func TestOpenBatch(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 8)
+	p := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1, 0, 3, 0, 7})
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	for _, zs := range [][]*big.Int{
+		bigInts(5),
+		bigInts(0, 1),
+		bigInts(2, 3, -7, 42),
+		// More points than the degree of p.
+		bigInts(1, 2, 3, 4, 5, 6, 7, 8),
+	} {
+		proof, err := srs.OpenBatch(p, zs)
+		if err != nil {
+			t.Fatalf("OpenBatch(%v): %v", zs, err)
+		}
+		for i, z := range zs {
+			if want := p.Evaluate(z, Field); proof.Ys[i].Cmp(want) != 0 {
+				t.Errorf("OpenBatch(%v).Ys[%d] = %v; want %v", zs, i, proof.Ys[i], want)
+			}
+		}
+		if !srs.VerifyOpenBatch(c, proof) {
+			t.Errorf("VerifyOpenBatch(OpenBatch(%v)) = false; want true", zs)
+		}
+
+		for i := range zs {
+			wrong := *proof
+			wrong.Ys = append([]*big.Int{}, proof.Ys...)
+			wrong.Ys[i] = Field.Add(wrong.Ys[i], big.NewInt(1))
+			if srs.VerifyOpenBatch(c, &wrong) {
+				t.Errorf("VerifyOpenBatch(OpenBatch(%v)) with wrong Ys[%d] = true; want false", zs, i)
+			}
+		}
+
+		other, err := srs.Commit(p.Add(polynomial.OnePolynomial, Field))
+		if err != nil {
+			t.Fatalf("Commit(): %v", err)
+		}
+		if srs.VerifyOpenBatch(other, proof) {
+			t.Errorf("VerifyOpenBatch(<other commitment>, OpenBatch(%v)) = true; want false", zs)
+		}
+	}
+}
+
+func TestOpenBatchErrors(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 3)
+	p := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1})
+
+	for _, zs := range [][]*big.Int{nil, bigInts(1, 2, 3, 4), bigInts(1, 2, 1)} {
+		if _, err := srs.OpenBatch(p, zs); err == nil {
+			t.Errorf("OpenBatch(%v): got nil error", zs)
+		}
+	}
+
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	proof := &BatchProof{Zs: bigInts(1, 1), Ys: bigInts(2, 2), Quotient: new(bn256.G2).ScalarBaseMult(big.NewInt(1))}
+	if srs.VerifyOpenBatch(c, proof) {
+		t.Errorf("VerifyOpenBatch(<duplicate points>) = true; want false")
+	}
+}
+
+func TestVerifyBatch(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 6)
+	vk := srs.VerifierKey()
+
+	var (
+		cs     []*bn256.G1
+		proofs []*Proof
+	)
+	for i := int64(0); i < 5; i++ {
+		p := polynomial.NewPolynomialFromCoefficients([]int64{i, -5, 1, i * i, 3})
+		c, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(): %v", err)
+		}
+		proof, err := srs.Open(p, big.NewInt(7*i-3))
+		if err != nil {
+			t.Fatalf("Open(): %v", err)
+		}
+		cs, proofs = append(cs, c), append(proofs, proof)
+	}
+
+	verify := func(cs []*bn256.G1, proofs []*Proof) bool {
+		t.Helper()
+		ok, err := vk.VerifyBatch(cs, proofs, rand.Reader)
+		if err != nil {
+			t.Fatalf("VerifyBatch(): %v", err)
+		}
+		return ok
+	}
+
+	if !verify(cs, proofs) {
+		t.Errorf("VerifyBatch(<valid proofs>) = false; want true")
+	}
+	if !verify(nil, nil) {
+		t.Errorf("VerifyBatch(<no proofs>) = false; want true")
+	}
+	if verify(cs[1:], proofs) {
+		t.Errorf("VerifyBatch(<length mismatch>) = true; want false")
+	}
+
+	for i := range proofs {
+		wrong := append([]*Proof{}, proofs...)
+		p := *proofs[i]
+		p.Y = Field.Add(p.Y, big.NewInt(1))
+		wrong[i] = &p
+		if verify(cs, wrong) {
+			t.Errorf("VerifyBatch() with wrong Y of proof %d = true; want false", i)
+		}
+	}
+
+	swapped := append([]*bn256.G1{cs[1], cs[0]}, cs[2:]...)
+	if verify(swapped, proofs) {
+		t.Errorf("VerifyBatch(<swapped commitments>) = true; want false")
+	}
+
+	if _, err := vk.VerifyBatch(cs, proofs, errReader{}); err == nil {
+		t.Errorf("VerifyBatch(<failing reader>): got nil error")
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	srs := NewSRS(big.NewInt(1337), 4)
+	vk := srs.VerifierKey()
+	p := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1, 0, 3})
+	c, err := srs.Commit(p)
+	if err != nil {
+		b.Fatalf("Commit(): %v", err)
+	}
+
+	const n = 32
+	var (
+		cs     []*bn256.G1
+		proofs []*Proof
+	)
+	for i := 0; i < n; i++ {
+		proof, err := srs.Open(p, big.NewInt(int64(i)))
+		if err != nil {
+			b.Fatalf("Open(): %v", err)
+		}
+		cs, proofs = append(cs, c), append(proofs, proof)
+	}
+
+	b.Run("individual", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j, proof := range proofs {
+				if !vk.Verify(cs[j], proof) {
+					b.Fatal("Verify() = false")
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if ok, err := vk.VerifyBatch(cs, proofs, rand.Reader); !ok || err != nil {
+				b.Fatalf("VerifyBatch() = %t, %v", ok, err)
+			}
+		}
+	})
+}
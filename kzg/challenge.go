@@ -0,0 +1,57 @@
+package kzg
+
+import (
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// A Config configures the derivation of Fiat-Shamir opening points, shared by
+// a Prover and Verifier.
+type Config struct {
+	// Nonce is supplied by the verifier and mixed into the transcript along
+	// with the commitment. As the prover doesn't control it, they can't grind
+	// the committed polynomial for a favourable opening point. A nil Nonce
+	// derives the point from the commitment alone.
+	Nonce []byte
+}
+
+func (cfg Config) clone() Config {
+	if cfg.Nonce != nil {
+		cfg.Nonce = append([]byte{}, cfg.Nonce...)
+	}
+	return cfg
+}
+
+// OpeningPoint returns the Fiat-Shamir opening point for the commitment c.
+func (cfg Config) OpeningPoint(c *bn256.G1) *big.Int {
+	t := transcript.New("zkp.xyz/kzg/open")
+	t.AppendG1("commitment", c)
+	t.AppendBytes("nonce", cfg.Nonce)
+	return t.Challenge(Field)
+}
+
+// OpenAtChallenge returns a Proof of the evaluation of p, committed to as c, at
+// the opening point derived from c by the Prover's Config.
+func (pr *Prover) OpenAtChallenge(p *polynomial.Polynomial, c *bn256.G1) (*Proof, error) {
+	return pr.srs.Open(p, pr.config.OpeningPoint(c))
+}
+
+// A Verifier verifies proofs opened at challenges derived by its Config.
+type Verifier struct {
+	vk     *VerifierKey
+	config Config
+}
+
+// NewVerifier returns a Verifier using the VerifierKey and Config.
+func NewVerifier(vk *VerifierKey, cfg Config) *Verifier {
+	return &Verifier{vk: vk, config: cfg.clone()}
+}
+
+// VerifyAtChallenge reports whether the proof is valid for the commitment c and
+// was opened at the point derived from c by the Verifier's Config.
+func (v *Verifier) VerifyAtChallenge(c *bn256.G1, proof *Proof) bool {
+	return proof.Z.Cmp(v.config.OpeningPoint(c)) == 0 && v.vk.Verify(c, proof)
+}
@@ -0,0 +1,75 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestOpeningPointNonce(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 5)
+	c, err := srs.Commit(polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1}))
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	zs := make(map[string]string)
+	for _, nonce := range []string{"", "a", "b", "ab"} {
+		z := Config{Nonce: []byte(nonce)}.OpeningPoint(c)
+		if other, ok := zs[z.String()]; ok {
+			t.Errorf("OpeningPoint() with nonces %q and %q are equal; want distinct", nonce, other)
+		}
+		zs[z.String()] = nonce
+
+		if again := (Config{Nonce: []byte(nonce)}).OpeningPoint(c); again.Cmp(z) != 0 {
+			t.Errorf("OpeningPoint() with nonce %q not deterministic: %v != %v", nonce, z, again)
+		}
+	}
+}
+
+func TestVerifyAtChallenge(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 5)
+	vk := srs.VerifierKey()
+	p := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1, 3})
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	nonce := []byte("verifier nonce")
+	proof, err := NewProverWithConfig(srs, Config{Nonce: nonce}).OpenAtChallenge(p, c)
+	if err != nil {
+		t.Fatalf("OpenAtChallenge(): %v", err)
+	}
+	if !vk.Verify(c, proof) {
+		t.Fatalf("Verify(OpenAtChallenge()) = false; want true")
+	}
+
+	tests := []struct {
+		name  string
+		nonce []byte
+		want  bool
+	}{
+		{name: "same nonce", nonce: nonce, want: true},
+		{name: "different nonce", nonce: []byte("other nonce"), want: false},
+		{name: "no nonce", nonce: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewVerifier(vk, Config{Nonce: tt.nonce}).VerifyAtChallenge(c, proof); got != tt.want {
+				t.Errorf("VerifyAtChallenge() = %t; want %t", got, tt.want)
+			}
+		})
+	}
+
+	// A valid proof at a point of the prover's choosing is rejected.
+	chosen, err := srs.Open(p, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	if NewVerifier(vk, Config{Nonce: nonce}).VerifyAtChallenge(c, chosen) {
+		t.Errorf("VerifyAtChallenge(<proof at chosen point>) = true; want false")
+	}
+}
@@ -0,0 +1,51 @@
+package kzg
+
+import (
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A DegreeProof accompanies a commitment [p(s)]_1 submitted by an untrusted
+// party, showing that it was computed from the SRS for some polynomial p with
+// degree at most SRS.MaxDegree().
+//
+// The proof is the same evaluation on G2, [p(s)]_2. Any party able to compute
+// both without knowing s must have done so as a linear combination of the
+// respective powers in the SRS (knowledge-of-exponent assumption), thus knowing
+// the coefficients of p, and the degree of p is bounded by the number of
+// available powers. An arbitrary G1 point, for which the discrete log isn't
+// known, can't be lifted onto G2.
+type DegreeProof struct {
+	Commitment2 *bn256.G2
+}
+
+// CommitWithDegreeProof returns the commitment to p along with a DegreeProof.
+func (srs *SRS) CommitWithDegreeProof(p *polynomial.Polynomial) (*bn256.G1, *DegreeProof, error) {
+	c1, err := srs.Commit(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	c2, err := srs.CommitG2(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c1, &DegreeProof{Commitment2: c2}, nil
+}
+
+// VerifyDegreeProof reports whether the proof is valid for the commitment,
+// i.e. whether e(c, [1]_2) == e([1]_1, proof.Commitment2).
+func VerifyDegreeProof(c *bn256.G1, proof *DegreeProof) bool {
+	one := big.NewInt(1)
+	return bn256.PairingCheck(
+		[]*bn256.G1{
+			c,
+			new(bn256.G1).Neg(new(bn256.G1).ScalarBaseMult(one)),
+		},
+		[]*bn256.G2{
+			new(bn256.G2).ScalarBaseMult(one),
+			proof.Commitment2,
+		},
+	)
+}
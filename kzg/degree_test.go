@@ -0,0 +1,71 @@
+package kzg
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestDegreeProof(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 10)
+
+	tests := [][]int64{
+		{42},
+		{1, 2, 3},
+		{-1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 5},
+	}
+
+	for _, cs := range tests {
+		p := polynomial.NewPolynomialFromCoefficients(cs)
+		c, proof, err := srs.CommitWithDegreeProof(p)
+		if err != nil {
+			t.Fatalf("CommitWithDegreeProof(%v): %v", cs, err)
+		}
+		if !VerifyDegreeProof(c, proof) {
+			t.Errorf("VerifyDegreeProof(CommitWithDegreeProof(%v)) = false; want true", cs)
+		}
+	}
+}
+
+func TestDegreeProofRejectsArbitraryPoint(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 10)
+
+	_, fake, err := bn256.RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatalf("bn256.RandomG1(): %v", err)
+	}
+
+	// Without the discrete log of the fake commitment, the best a forger can do
+	// is submitting G2 points derived from public values.
+	_, randG2, err := bn256.RandomG2(rand.Reader)
+	if err != nil {
+		t.Fatalf("bn256.RandomG2(): %v", err)
+	}
+	_, honest, err := srs.CommitWithDegreeProof(polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3}))
+	if err != nil {
+		t.Fatalf("CommitWithDegreeProof(): %v", err)
+	}
+
+	forgeries := []*bn256.G2{
+		new(bn256.G2).ScalarBaseMult(big.NewInt(1)),
+		srs.G2[1],
+		randG2,
+		honest.Commitment2,
+	}
+	for i, g2 := range forgeries {
+		if VerifyDegreeProof(fake, &DegreeProof{Commitment2: g2}) {
+			t.Errorf("VerifyDegreeProof(<random point>, <forgery %d>) = true; want false", i)
+		}
+	}
+}
+
+func TestCommitWithDegreeProofExceedsSRS(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 2)
+	p := polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 4})
+	if _, _, err := srs.CommitWithDegreeProof(p); err == nil {
+		t.Errorf("CommitWithDegreeProof(<degree 3>) with SRS of max degree 2: got nil error")
+	}
+}
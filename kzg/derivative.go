@@ -0,0 +1,99 @@
+package kzg
+
+import (
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A DerivativeProof attests that the derivative p' of a committed polynomial p
+// evaluates to p'(Opening.Z) = Opening.Y.
+type DerivativeProof struct {
+	// Commitment is the commitment [p'(s)]_1 to the derivative, against which
+	// the Opening is verified.
+	Commitment *bn256.G1
+	Opening    *Proof
+	// Value is p(z), and Tangent is [h(s)]_2 for
+	//
+	//	h(v) = (p(v) - p(z) - p'(z)(v - z)) / (v - z)^2,
+	//
+	// which only exists if p'(z) is indeed the slope of p at z. This binds the
+	// opened value to the commitment of p itself.
+	Value   *big.Int
+	Tangent *bn256.G2
+}
+
+// OpenDerivative commits to the derivative of p and opens it at z.
+func (pr *Prover) OpenDerivative(p *polynomial.Polynomial, z *big.Int) (*DerivativeProof, error) {
+	p, err := pr.srs.trim(p)
+	if err != nil {
+		return nil, err
+	}
+	deriv := p.Derivative(Field)
+
+	c, err := pr.srs.Commit(deriv)
+	if err != nil {
+		return nil, err
+	}
+	opening, err := pr.srs.Open(deriv, z)
+	if err != nil {
+		return nil, err
+	}
+
+	// (p(v) - p(z)) / (v - z) evaluates to p'(z) at z, so dividing off that
+	// value once more yields h.
+	y := p.Evaluate(opening.Z, Field)
+	q, err := quotient(p, opening.Z, y)
+	if err != nil {
+		return nil, err
+	}
+	h, err := quotient(q, opening.Z, opening.Y)
+	if err != nil {
+		return nil, err
+	}
+	tangent, err := pr.srs.CommitG2(h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DerivativeProof{
+		Commitment: c,
+		Opening:    opening,
+		Value:      y,
+		Tangent:    tangent,
+	}, nil
+}
+
+// VerifyDerivative reports whether the proof is valid for the commitment c to
+// p. In addition to verifying the Opening against the derivative's Commitment,
+// it checks consistency with c:
+//
+//	e([p(s) - p(z) - p'(z)(s - z)]_1, [1]_2) == e([(s - z)^2]_1, [h(s)]_2).
+//
+// It returns false if vk.SS1 is nil.
+func (vk *VerifierKey) VerifyDerivative(c *bn256.G1, proof *DerivativeProof) bool {
+	if vk.SS1 == nil || !vk.Verify(proof.Commitment, proof.Opening) {
+		return false
+	}
+
+	z, slope := proof.Opening.Z, proof.Opening.Y
+	one := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+
+	// [(s - z)^2]_1 = [s^2]_1 - 2z[s]_1 + z^2[1]_1
+	sz2 := new(bn256.G1).Set(vk.SS1)
+	sz2.Add(sz2, new(bn256.G1).ScalarMult(vk.S1, Field.Sub(bigZero, Field.Mul(big.NewInt(2), z))))
+	sz2.Add(sz2, new(bn256.G1).ScalarMult(one, Field.Mul(z, z)))
+
+	// [s - z]_1
+	sz := new(bn256.G1).Add(vk.S1, new(bn256.G1).ScalarMult(one, Field.Sub(bigZero, z)))
+
+	// [p(s) - p(z) - p'(z)(s - z)]_1
+	lhs := new(bn256.G1).Add(c, new(bn256.G1).ScalarMult(one, Field.Sub(bigZero, proof.Value)))
+	lhs.Add(lhs, new(bn256.G1).Neg(new(bn256.G1).ScalarMult(sz, slope)))
+
+	return bn256.PairingCheck(
+		[]*bn256.G1{sz2, new(bn256.G1).Neg(lhs)},
+		[]*bn256.G2{proof.Tangent, new(bn256.G2).ScalarBaseMult(big.NewInt(1))},
+	)
+}
@@ -0,0 +1,88 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestOpenDerivative(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 10)
+	prover := NewProver(srs)
+	vk := srs.VerifierKey()
+
+	tests := []struct {
+		c []int64
+		z int64
+	}{
+		{c: []int64{42}, z: 3},
+		{c: []int64{1, 2}, z: 3},
+		{c: []int64{2, -3, 1}, z: 0},
+		{c: []int64{6, -5, 1, 0, 0, 7}, z: -1337},
+	}
+
+	for _, tt := range tests {
+		p := polynomial.NewPolynomialFromCoefficients(tt.c)
+		z := big.NewInt(tt.z)
+
+		c, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(%v): %v", tt.c, err)
+		}
+		proof, err := prover.OpenDerivative(p, z)
+		if err != nil {
+			t.Fatalf("OpenDerivative(%v, %d): %v", tt.c, tt.z, err)
+		}
+
+		if want := p.Derivative(Field).Evaluate(z, Field); proof.Opening.Y.Cmp(want) != 0 {
+			t.Errorf("OpenDerivative(%v, %d).Opening.Y = %v; want %v", tt.c, tt.z, proof.Opening.Y, want)
+		}
+		if !vk.VerifyDerivative(c, proof) {
+			t.Errorf("VerifyDerivative(Commit(%v), OpenDerivative(%v, %d)) = false; want true", tt.c, tt.c, tt.z)
+		}
+
+		// A different polynomial with the same derivative has another commitment.
+		shifted, err := srs.Commit(p.Add(polynomial.OnePolynomial, Field))
+		if err != nil {
+			t.Fatalf("Commit(): %v", err)
+		}
+		if vk.VerifyDerivative(shifted, proof) {
+			t.Errorf("VerifyDerivative(<commitment to p+1>, OpenDerivative(p)) = true; want false")
+		}
+	}
+}
+
+func TestVerifyDerivativeRejectsForgedSlope(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	p := polynomial.NewPolynomialFromCoefficients([]int64{2, -3, 1})
+	z := big.NewInt(5)
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	// Commit to and open a polynomial other than p' honestly, combined with
+	// the tangent proof for p.
+	q := polynomial.NewPolynomialFromCoefficients([]int64{1, 1})
+	honest, err := NewProver(srs).OpenDerivative(p, z)
+	if err != nil {
+		t.Fatalf("OpenDerivative(): %v", err)
+	}
+	forged := *honest
+	if forged.Commitment, err = srs.Commit(q); err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	if forged.Opening, err = srs.Open(q, z); err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+
+	if vk.VerifyDerivative(c, &forged) {
+		t.Errorf("VerifyDerivative(<forged slope>) = true; want false")
+	}
+	if (&VerifierKey{S1: vk.S1, S2: vk.S2}).VerifyDerivative(c, honest) {
+		t.Errorf("VerifyDerivative() without [s^2]_1 = true; want false")
+	}
+}
@@ -0,0 +1,88 @@
+package kzg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// CommitmentSize is the length of the encoding returned by
+// Commitment.MarshalBinary.
+const CommitmentSize = 2 * evmWordSize
+
+// MarshalBinary encodes the Commitment as the uncompressed, big-endian affine
+// coordinates x | y, as consumed by the bn256 precompiles. There is no
+// standardised compressed encoding for bn256 on Ethereum.
+func (c *Commitment) MarshalBinary() ([]byte, error) {
+	return c.G1().Marshal(), nil
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary. It returns an error if buf
+// doesn't encode a point on the curve.
+func (c *Commitment) UnmarshalBinary(buf []byte) error {
+	if len(buf) != CommitmentSize {
+		return fmt.Errorf("invalid commitment length %d; want %d", len(buf), CommitmentSize)
+	}
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(buf); err != nil {
+		return fmt.Errorf("bn256.G1.Unmarshal(): %v", err)
+	}
+	*c = Commitment(*p)
+	return nil
+}
+
+// MarshalText encodes the binary encoding of the Commitment as 0x-prefixed hex.
+func (c *Commitment) MarshalText() ([]byte, error) {
+	buf, err := c.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return marshalHex(buf), nil
+}
+
+// UnmarshalText is the inverse of MarshalText. The 0x prefix is optional.
+func (c *Commitment) UnmarshalText(text []byte) error {
+	buf, err := unmarshalHex(text)
+	if err != nil {
+		return err
+	}
+	return c.UnmarshalBinary(buf)
+}
+
+// MarshalBinary is equivalent to MarshalEVM.
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	return p.MarshalEVM(), nil
+}
+
+// UnmarshalBinary is equivalent to UnmarshalEVM.
+func (p *Proof) UnmarshalBinary(buf []byte) error {
+	return p.UnmarshalEVM(buf)
+}
+
+// MarshalText encodes the binary encoding of the Proof as 0x-prefixed hex.
+func (p *Proof) MarshalText() ([]byte, error) {
+	return marshalHex(p.MarshalEVM()), nil
+}
+
+// UnmarshalText is the inverse of MarshalText. The 0x prefix is optional.
+func (p *Proof) UnmarshalText(text []byte) error {
+	buf, err := unmarshalHex(text)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalEVM(buf)
+}
+
+func marshalHex(buf []byte) []byte {
+	return []byte("0x" + hex.EncodeToString(buf))
+}
+
+func unmarshalHex(text []byte) ([]byte, error) {
+	buf, err := hex.DecodeString(strings.TrimPrefix(string(text), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("hex.DecodeString(): %v", err)
+	}
+	return buf, nil
+}
@@ -0,0 +1,96 @@
+package kzg
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestCommitmentEncoding(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 4)
+	c, err := Commit(srs, polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1}))
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	buf, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if !bytes.Equal(buf, c.G1().Marshal()) {
+		t.Errorf("MarshalBinary() = %x; want bn256.G1.Marshal() = %x", buf, c.G1().Marshal())
+	}
+	got := new(Commitment)
+	if err := got.UnmarshalBinary(buf); err != nil {
+		t.Fatalf("UnmarshalBinary(MarshalBinary()): %v", err)
+	}
+	if !bytes.Equal(got.G1().Marshal(), buf) {
+		t.Errorf("UnmarshalBinary(MarshalBinary()) differs from original")
+	}
+
+	// Round trip through JSON, which uses the text encoding.
+	j, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+	if !strings.HasPrefix(string(j), `"0x`) {
+		t.Errorf("json.Marshal(Commitment) = %s; want 0x-prefixed hex string", j)
+	}
+	got = new(Commitment)
+	if err := json.Unmarshal(j, got); err != nil {
+		t.Fatalf("json.Unmarshal(json.Marshal()): %v", err)
+	}
+	if !bytes.Equal(got.G1().Marshal(), buf) {
+		t.Errorf("json.Unmarshal(json.Marshal()) differs from original")
+	}
+
+	offCurve := append([]byte{}, buf...)
+	offCurve[CommitmentSize-1] ^= 1
+	for _, b := range [][]byte{nil, buf[1:], offCurve} {
+		if err := new(Commitment).UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%x): got nil error", b)
+		}
+	}
+	if err := new(Commitment).UnmarshalText([]byte("0xzz")); err == nil {
+		t.Errorf("UnmarshalText(<invalid hex>): got nil error")
+	}
+}
+
+func TestProofEncoding(t *testing.T) {
+	proof := &Proof{
+		Z:        big.NewInt(5),
+		Y:        big.NewInt(12),
+		Quotient: new(bn256.G2).ScalarBaseMult(big.NewInt(42)),
+	}
+
+	buf, err := proof.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if !bytes.Equal(buf, proof.MarshalEVM()) {
+		t.Errorf("MarshalBinary() != MarshalEVM()")
+	}
+
+	text, err := proof.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText(): %v", err)
+	}
+	for _, in := range [][]byte{text, bytes.TrimPrefix(text, []byte("0x"))} {
+		got := new(Proof)
+		if err := got.UnmarshalText(in); err != nil {
+			t.Fatalf("UnmarshalText(%s): %v", in, err)
+		}
+		if got.Z.Cmp(proof.Z) != 0 || got.Y.Cmp(proof.Y) != 0 || !bytes.Equal(got.Quotient.Marshal(), proof.Quotient.Marshal()) {
+			t.Errorf("UnmarshalText(%s) = %+v; want %+v", in, got, proof)
+		}
+	}
+
+	if err := new(Proof).UnmarshalBinary(buf[1:]); err == nil {
+		t.Errorf("UnmarshalBinary(<truncated>): got nil error")
+	}
+}
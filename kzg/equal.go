@@ -0,0 +1,58 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// An EqualityProof attests that two commitments on bn256, possibly under
+// different SRSs, are to the same polynomial. Both are opened at a Fiat-Shamir challenge
+// z derived from the commitments and must evaluate to the same value. Distinct
+// polynomials of degree at most d agree on at most d points, so a cheating
+// prover succeeds with probability at most d/|Field|.
+type EqualityProof struct {
+	Proof1, Proof2 *Proof
+}
+
+func equalityChallenge(c1, c2 *bn256.G1) *big.Int {
+	t := transcript.New("zkp.xyz/kzg/equal")
+	t.AppendG1("c1", c1)
+	t.AppendG1("c2", c2)
+	return t.Challenge(Field)
+}
+
+// ProveEqual proves that c1, the commitment to p1 under srs1, and c2, the
+// commitment to p2 under srs2, commit to the same polynomial. It returns an
+// error if p1 and p2 evaluate differently at the challenge.
+func ProveEqual(srs1 *SRS, c1 *bn256.G1, p1 *polynomial.Polynomial, srs2 *SRS, c2 *bn256.G1, p2 *polynomial.Polynomial) (*EqualityProof, error) {
+	z := equalityChallenge(c1, c2)
+
+	proof1, err := srs1.Open(p1, z)
+	if err != nil {
+		return nil, err
+	}
+	proof2, err := srs2.Open(p2, z)
+	if err != nil {
+		return nil, err
+	}
+	if proof1.Y.Cmp(proof2.Y) != 0 {
+		return nil, fmt.Errorf("polynomials differ at challenge %v: %v != %v", z, proof1.Y, proof2.Y)
+	}
+
+	return &EqualityProof{Proof1: proof1, Proof2: proof2}, nil
+}
+
+// VerifyEqual reports whether the proof shows that c1 and c2, verified with vk1
+// and vk2 respectively, commit to the same polynomial.
+func VerifyEqual(vk1 *VerifierKey, c1 *bn256.G1, vk2 *VerifierKey, c2 *bn256.G1, proof *EqualityProof) bool {
+	z := equalityChallenge(c1, c2)
+	p1, p2 := proof.Proof1, proof.Proof2
+
+	return p1.Z.Cmp(z) == 0 && p2.Z.Cmp(z) == 0 &&
+		p1.Y.Cmp(p2.Y) == 0 &&
+		vk1.Verify(c1, p1) && vk2.Verify(c2, p2)
+}
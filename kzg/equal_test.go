@@ -0,0 +1,80 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestProveEqual(t *testing.T) {
+	srs1 := NewSRS(big.NewInt(1337), 5)
+	srs2 := NewSRS(big.NewInt(4242), 8)
+
+	p := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1, 0, 3})
+	c1, err := srs1.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	c2, err := srs2.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	proof, err := ProveEqual(srs1, c1, p, srs2, c2, p)
+	if err != nil {
+		t.Fatalf("ProveEqual(p, p): %v", err)
+	}
+	if !VerifyEqual(srs1.VerifierKey(), c1, srs2.VerifierKey(), c2, proof) {
+		t.Errorf("VerifyEqual(ProveEqual(p, p)) = false; want true")
+	}
+	if VerifyEqual(srs2.VerifierKey(), c1, srs1.VerifierKey(), c2, proof) {
+		t.Errorf("VerifyEqual() with swapped VerifierKeys = true; want false")
+	}
+}
+
+func TestProveEqualRejectsDistinct(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 5)
+	vk := srs.VerifierKey()
+
+	p1 := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 1})
+	p2 := polynomial.NewPolynomialFromCoefficients([]int64{6, -5, 2})
+	c1, err := srs.Commit(p1)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	c2, err := srs.Commit(p2)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	if _, err := ProveEqual(srs, c1, p1, srs, c2, p2); err == nil {
+		t.Errorf("ProveEqual(p1, p2) for distinct polynomials: got nil error")
+	}
+
+	// p1 and p2 agree at 0, but the verifier rejects openings at any point
+	// other than the challenge.
+	o1, err := srs.Open(p1, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	o2, err := srs.Open(p2, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	if VerifyEqual(vk, c1, vk, c2, &EqualityProof{Proof1: o1, Proof2: o2}) {
+		t.Errorf("VerifyEqual(<openings at chosen point>) = true; want false")
+	}
+
+	// Honest openings at the challenge evaluate differently.
+	z := equalityChallenge(c1, c2)
+	if o1, err = srs.Open(p1, z); err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	if o2, err = srs.Open(p2, z); err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	if VerifyEqual(vk, c1, vk, c2, &EqualityProof{Proof1: o1, Proof2: o2}) {
+		t.Errorf("VerifyEqual(<openings of distinct polynomials>) = true; want false")
+	}
+}
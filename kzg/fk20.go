@@ -0,0 +1,166 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+)
+
+// Openings holds the precomputation of Feist and Khovratovich (FK20) for
+// opening a polynomial p of degree d at many points; see
+// https://eprint.iacr.org/2023/033.
+//
+// The quotient commitment for any z is
+//
+//	[q(s)]_2 = [(p(s) - p(z)) / (s - z)]_2 = sum_{m < d} z^m h_m
+//
+// with h_m = sum_{t < d-m} p_{t+m+1} [s^t]_2, i.e. the evaluation at z of a
+// polynomial with coefficients h on G2. The coefficients are a Toeplitz
+// matrix-vector product, computed with O(d log d) group operations instead
+// of O(d^2).
+type Openings struct {
+	p *polynomial.Polynomial
+	h []*bn256.G2
+}
+
+// PrecomputeOpenings returns the Openings of p.
+func (srs *SRS) PrecomputeOpenings(p *polynomial.Polynomial) (*Openings, error) {
+	p, err := srs.trim(p)
+	if err != nil {
+		return nil, err
+	}
+	d := p.Degree()
+	if d < 1 {
+		return &Openings{p: p}, nil
+	}
+	if d > len(srs.G2) {
+		return nil, fmt.Errorf("quotients of degree %d exceed SRS max G2 degree %d", d-1, len(srs.G2)-1)
+	}
+
+	// h_{d-1-m} is coefficient m of the convolution of (p_d, ..., p_1) with
+	// ([s^0]_2, ..., [s^{d-1}]_2), computed cyclically over a domain large
+	// enough to avoid wrapping.
+	size := uint64(1) << bits.Len(uint(2*d-2))
+	dom, err := polynomial.CanonicalDomain(Field, size)
+	if err != nil {
+		return nil, fmt.Errorf("CanonicalDomain(%d): %v", size, err)
+	}
+
+	rev := make([]*big.Int, d)
+	for i := range rev {
+		rev[i] = (*p)[d-i]
+	}
+	revHat := dom.FFT(rev)
+	sHat := fftPoints(padG2(srs.G2[:d], int(size)), dom, false)
+
+	sizeInv, err := Field.MultInverse(new(big.Int).SetUint64(size))
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range sHat {
+		s.ScalarMult(s, Field.Mul(revHat[i], sizeInv))
+	}
+	conv := fftPoints(sHat, dom, true)
+
+	h := make([]*bn256.G2, d)
+	for m := range h {
+		h[m] = conv[d-1-m]
+	}
+	return &Openings{p: p, h: h}, nil
+}
+
+// Open returns a Proof of the evaluation of p at z, with a single MSM.
+func (o *Openings) Open(z *big.Int) (*Proof, error) {
+	z = new(big.Int).Mod(z, Field.Order())
+	q, err := msm.MultiExp(o.h, polynomial.ComputePowers(z, len(o.h), Field))
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{Z: z, Y: o.p.Evaluate(z, Field), Quotient: q}, nil
+}
+
+// OpenDomain returns Proofs of the evaluations of p at every element of the
+// Domain, in order, with two FFTs of size d.Size(). The Domain MUST be over
+// Field.
+func (o *Openings) OpenDomain(d *polynomial.Domain) []*Proof {
+	n := int(d.Size())
+
+	// As w^n = 1 for all elements w, coefficients of index i apply to i mod n.
+	h := padG2(nil, n)
+	for m, p := range o.h {
+		h[m%n].Add(h[m%n], p)
+	}
+	qs := fftPoints(h, d, false)
+
+	cs := make([]*big.Int, n)
+	for i := range cs {
+		cs[i] = big.NewInt(0)
+	}
+	for i, c := range *o.p {
+		cs[i%n] = Field.Add(cs[i%n], c)
+	}
+	ys := d.FFT(cs)
+
+	proofs := make([]*Proof, n)
+	for i := range proofs {
+		proofs[i] = &Proof{Z: d.Element(uint64(i)), Y: ys[i], Quotient: qs[i]}
+	}
+	return proofs
+}
+
+// padG2 returns copies of the points padded with the identity to length n.
+func padG2(points []*bn256.G2, n int) []*bn256.G2 {
+	out := make([]*bn256.G2, n)
+	for i := range out {
+		out[i] = new(bn256.G2).ScalarBaseMult(bigZero)
+		if i < len(points) {
+			out[i].Set(points[i])
+		}
+	}
+	return out
+}
+
+// A negatableGroupElement is a PointerGroupElement with negation, such as
+// *bn256.G1 and *bn256.G2.
+type negatableGroupElement[E any, P any] interface {
+	polynomial.PointerGroupElement[E, P]
+	Neg(P) P
+}
+
+// fftPoints returns the FFT over the Domain of the points, considered as the
+// coefficients of a polynomial on G1 or G2, modifying them in place. The
+// inverse transform isn't scaled by 1/d.Size().
+func fftPoints[E any, P negatableGroupElement[E, P]](points []P, d *polynomial.Domain, inverse bool) []P {
+	n := len(points)
+	shift := 64 - bits.Len64(uint64(n-1))
+	for i := range points {
+		if j := int(bits.Reverse64(uint64(i)) >> shift); i < j {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+
+	var t P = new(E)
+	for size := 2; size <= n; size *= 2 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				a, b := points[start+j], points[start+j+half]
+				t.Set(b)
+				if j > 0 {
+					e := j * step
+					if inverse {
+						e = n - e
+					}
+					t.ScalarMult(t, d.Element(uint64(e)))
+				}
+				b.Neg(t).Add(b, a)
+				a.Add(a, t)
+			}
+		}
+	}
+	return points
+}
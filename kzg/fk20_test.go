@@ -0,0 +1,137 @@
+package kzg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestOpenings(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 20)
+	vk := srs.VerifierKey()
+
+	for _, c := range [][]int64{
+		{42},
+		{2, -3},
+		{2, -3, 1},
+		{6, -5, 1, 0, 0, 7},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
+	} {
+		p := polynomial.NewPolynomialFromCoefficients(c)
+		commitment, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(%v): %v", c, err)
+		}
+		o, err := srs.PrecomputeOpenings(p)
+		if err != nil {
+			t.Fatalf("PrecomputeOpenings(%v): %v", c, err)
+		}
+
+		for _, z := range []int64{0, 1, 5, -1337} {
+			got, err := o.Open(big.NewInt(z))
+			if err != nil {
+				t.Fatalf("PrecomputeOpenings(%v).Open(%d): %v", c, z, err)
+			}
+			want, err := srs.Open(p, big.NewInt(z))
+			if err != nil {
+				t.Fatalf("Open(%v, %d): %v", c, z, err)
+			}
+			if got.Y.Cmp(want.Y) != 0 || !bytes.Equal(got.Quotient.Marshal(), want.Quotient.Marshal()) {
+				t.Errorf("PrecomputeOpenings(%v).Open(%d) != Open()", c, z)
+			}
+			if !vk.Verify(commitment, got) {
+				t.Errorf("Verify(Commit(%v), PrecomputeOpenings().Open(%d)) = false; want true", c, z)
+			}
+		}
+
+		// Domains both larger and smaller than the degree.
+		for _, size := range []uint64{1, 2, 4, 16, 32} {
+			d, err := polynomial.NewDomain(Field, size, rand.Reader)
+			if err != nil {
+				t.Fatalf("NewDomain(%d): %v", size, err)
+			}
+			proofs := o.OpenDomain(d)
+			if len(proofs) != int(size) {
+				t.Fatalf("PrecomputeOpenings(%v).OpenDomain(<size %d>) returned %d proofs", c, size, len(proofs))
+			}
+			for i, proof := range proofs {
+				if proof.Z.Cmp(d.Element(uint64(i))) != 0 {
+					t.Errorf("OpenDomain(<size %d>)[%d].Z = %v; want %v", size, i, proof.Z, d.Element(uint64(i)))
+				}
+				if !vk.Verify(commitment, proof) {
+					t.Errorf("Verify(Commit(%v), OpenDomain(<size %d>)[%d]) = false; want true", c, size, i)
+				}
+			}
+		}
+	}
+}
+
+func TestOpeningsErrors(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 2)
+	if _, err := srs.PrecomputeOpenings(polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 4})); err == nil {
+		t.Errorf("PrecomputeOpenings(<degree 3>) with SRS of max degree 2: got nil error")
+	}
+}
+
+func TestFFTPoints(t *testing.T) {
+	d, err := polynomial.NewDomain(Field, 8, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	cs := []*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(4), big.NewInt(1), big.NewInt(5), big.NewInt(9), big.NewInt(2), big.NewInt(6)}
+	points := make([]*bn256.G2, len(cs))
+	for i, c := range cs {
+		points[i] = new(bn256.G2).ScalarBaseMult(c)
+	}
+
+	got := fftPoints(points, d, false)
+	for i, y := range d.FFT(cs) {
+		if want := new(bn256.G2).ScalarBaseMult(y); !bytes.Equal(got[i].Marshal(), want.Marshal()) {
+			t.Errorf("fftPoints()[%d] != [FFT()[%d]]_2", i, i)
+		}
+	}
+
+	back := fftPoints(got, d, true)
+	for i, c := range cs {
+		if want := new(bn256.G2).ScalarBaseMult(Field.Mul(c, big.NewInt(8))); !bytes.Equal(back[i].Marshal(), want.Marshal()) {
+			t.Errorf("fftPoints(fftPoints(), inverse)[%d] != [8 * %v]_2", i, c)
+		}
+	}
+}
+
+func BenchmarkOpenAll(b *testing.B) {
+	const n = 64
+	srs := NewSRS(big.NewInt(1337), n)
+	p, err := polynomial.Random(n-1, Field, rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	d, err := polynomial.NewDomain(Field, n, rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	b.Run("Open", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := uint64(0); j < n; j++ {
+				if _, err := srs.Open(p, d.Element(j)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("OpenDomain", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			o, err := srs.PrecomputeOpenings(p)
+			if err != nil {
+				b.Fatal(err)
+			}
+			o.OpenDomain(d)
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package kzg
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// Openings at a hidden point reveal neither z nor y = p(z), only the Pedersen
+// commitments Z = z*g + zb*h and Y = y*g + yb*h, for the generator g = [1]_1
+// and a second generator h hashed to G1. The quotient q(v) = (p(v) - y) /
+// (v - z) is blinded by a random t as Q = [q(s)]_1 + t*g, whence
+//
+//	c - A = s*Q for A = y*g - z*[q(s)]_1 - t*[s]_1,
+//
+// checked with a pairing. A sigma protocol binds A to Z and Y, proving
+// knowledge of (z, zb, y, yb, t, u = t*zb) with
+//
+//	Z = z*g + zb*h
+//	Y = y*g + yb*h
+//	A = y*g - z*Q + t*(Z - [s]_1) - u*h,
+//
+// the last equation being equivalent to the definition of A, without a
+// product of witnesses.
+
+// hiddenDST is the domain separation tag of h and of the transcripts of
+// HiddenProofs.
+const hiddenDST = "zkp.xyz/kzg/hidden"
+
+// hiddenH is the second generator h of Pedersen commitments to hidden values.
+var hiddenH = func() *bn256.G1 {
+	h := new(bn256.G1)
+	if _, err := h.Unmarshal(curve.BN256.HashToG1([]byte(hiddenDST), []byte("h")).Marshal()); err != nil {
+		panic(err)
+	}
+	return h
+}()
+
+// CommitScalar returns the Pedersen commitment x*[1]_1 + blinder*h to x,
+// as the Z and Y of a HiddenProof. The blinder MUST be uniformly random, and
+// kept secret, for the commitment to hide x.
+func CommitScalar(x, blinder *big.Int) *bn256.G1 {
+	c, err := msm.MultiExp([]*bn256.G1{new(bn256.G1).ScalarBaseMult(bigOne), hiddenH}, reduceAll([]*big.Int{x, blinder}))
+	if err != nil {
+		// Unreachable as the lengths match.
+		panic(err)
+	}
+	return c
+}
+
+// A HiddenProof attests that a committed polynomial p evaluates to p(z) = y
+// for the z and y committed to by Z and Y with CommitScalar; see OpenHidden.
+type HiddenProof struct {
+	Z, Y *bn256.G1
+	// Quotient is the blinded [q(s)]_1, and A the correction such that
+	// c - A = s*Quotient.
+	Quotient, A *bn256.G1
+	// Commitments and Responses are the sigma protocol binding A to Z and Y.
+	Commitments [3]*bn256.G1
+	Responses   [6]*big.Int
+}
+
+// hiddenWitnesses is the number of scalars proven known by a HiddenProof.
+const hiddenWitnesses = 6
+
+// hiddenRelations returns the left-hand sides of the three equations proven
+// by a HiddenProof for the witnesses (z, zb, y, yb, t, u).
+func hiddenRelations(s1 *bn256.G1, proof *HiddenProof, ws []*big.Int) ([3]*bn256.G1, error) {
+	g := new(bn256.G1).ScalarBaseMult(bigOne)
+	zs := new(bn256.G1).Add(proof.Z, new(bn256.G1).Neg(s1))
+	bases := [3][]*bn256.G1{
+		{g, hiddenH},
+		{g, hiddenH},
+		{g, proof.Quotient, zs, hiddenH},
+	}
+	scalars := [3][]*big.Int{
+		{ws[0], ws[1]},
+		{ws[2], ws[3]},
+		{ws[2], Field.Sub(bigZero, ws[0]), ws[4], Field.Sub(bigZero, ws[5])},
+	}
+	var out [3]*bn256.G1
+	for j := range out {
+		p, err := msm.MultiExp(bases[j], reduceAll(scalars[j]))
+		if err != nil {
+			return out, err
+		}
+		out[j] = p
+	}
+	return out, nil
+}
+
+// hiddenChallenge returns the Fiat-Shamir challenge of the sigma protocol.
+func hiddenChallenge(c, s1 *bn256.G1, proof *HiddenProof) *big.Int {
+	t := transcript.New(hiddenDST)
+	t.AppendG1("s1", s1)
+	t.AppendG1("c", c)
+	t.AppendG1("z", proof.Z)
+	t.AppendG1("y", proof.Y)
+	t.AppendG1("quotient", proof.Quotient)
+	t.AppendG1("a", proof.A)
+	for _, r := range proof.Commitments {
+		t.AppendG1("commitment", r)
+	}
+	return t.Challenge(Field)
+}
+
+// OpenHidden returns a HiddenProof of the evaluation of p at z, with Z and Y
+// the commitments to z and p(z) with the respective blinders. Randomness for
+// the quotient and the sigma protocol is drawn from r.
+//
+// A zero yBlinder makes Y = p(z)*[1]_1, e.g. the identity for a root z, which
+// verifiers can check to learn that p(z) = 0 but nothing about z.
+func (srs *SRS) OpenHidden(p *polynomial.Polynomial, z, zBlinder, yBlinder *big.Int, r io.Reader) (*HiddenProof, error) {
+	q1, _, y, err := srs.QuotientCommitmentsBothCurves(p, z)
+	if err != nil {
+		return nil, err
+	}
+	if len(srs.G1) < 2 {
+		return nil, errors.New("SRS of max degree 0")
+	}
+	t, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	z = new(big.Int).Mod(z, Field.Order())
+	zb, yb := new(big.Int).Mod(zBlinder, Field.Order()), new(big.Int).Mod(yBlinder, Field.Order())
+
+	// A = y*g - z*[q(s)]_1 - t*[s]_1, before blinding the quotient.
+	a, err := msm.MultiExp(
+		[]*bn256.G1{new(bn256.G1).ScalarBaseMult(bigOne), q1, srs.G1[1]},
+		[]*big.Int{y, Field.Sub(bigZero, z), Field.Sub(bigZero, t)},
+	)
+	if err != nil {
+		return nil, err
+	}
+	proof := &HiddenProof{
+		Z:        CommitScalar(z, zb),
+		Y:        CommitScalar(y, yb),
+		Quotient: q1.Add(q1, new(bn256.G1).ScalarBaseMult(t)),
+		A:        a,
+	}
+
+	ws := []*big.Int{z, zb, y, yb, t, Field.Mul(t, zb)}
+	ks := make([]*big.Int, hiddenWitnesses)
+	for i := range ks {
+		if ks[i], err = Field.Random(r); err != nil {
+			return nil, err
+		}
+	}
+	if proof.Commitments, err = hiddenRelations(srs.G1[1], proof, ks); err != nil {
+		return nil, err
+	}
+	cp, err := srs.Commit(p)
+	if err != nil {
+		return nil, err
+	}
+	c := hiddenChallenge(cp, srs.G1[1], proof)
+	for i := range proof.Responses {
+		proof.Responses[i] = Field.Add(ks[i], Field.Mul(c, ws[i]))
+	}
+	return proof, nil
+}
+
+// VerifyHidden reports whether the proof is valid for the commitment c, i.e.
+// whether the committed polynomial evaluates at the z committed to by proof.Z
+// to the y committed to by proof.Y, by checking
+//
+//	e(c - A, [1]_2) == e(Quotient, [s]_2)
+//
+// and the sigma protocol.
+func (vk *VerifierKey) VerifyHidden(c *bn256.G1, proof *HiddenProof) bool {
+	if proof == nil || proof.Z == nil || proof.Y == nil || proof.Quotient == nil || proof.A == nil {
+		return false
+	}
+	for _, r := range proof.Commitments {
+		if r == nil {
+			return false
+		}
+	}
+	for _, s := range proof.Responses {
+		if s == nil || s.Sign() < 0 || s.Cmp(Field.Order()) >= 0 {
+			return false
+		}
+	}
+
+	ch := hiddenChallenge(c, vk.S1, proof)
+	lhs, err := hiddenRelations(vk.S1, proof, proof.Responses[:])
+	if err != nil {
+		return false
+	}
+	for j, y := range []*bn256.G1{proof.Z, proof.Y, proof.A} {
+		rhs := new(bn256.G1).ScalarMult(y, ch)
+		rhs.Add(rhs, proof.Commitments[j])
+		if !bytes.Equal(lhs[j].Marshal(), rhs.Marshal()) {
+			return false
+		}
+	}
+
+	return bn256.PairingCheck(
+		[]*bn256.G1{new(bn256.G1).Add(c, new(bn256.G1).Neg(proof.A)), new(bn256.G1).Neg(proof.Quotient)},
+		[]*bn256.G2{new(bn256.G2).ScalarBaseMult(bigOne), vk.S2},
+	)
+}
+
+// OpenHidden returns a HiddenProof of the evaluation of p at z, with the SRS
+// under which p was committed to; see SRS.OpenHidden.
+func OpenHidden(srs *SRS, p *polynomial.Polynomial, z, zBlinder, yBlinder *big.Int, r io.Reader) (*HiddenProof, error) {
+	return srs.OpenHidden(p, z, zBlinder, yBlinder, r)
+}
+
+// VerifyHidden reports whether the proof is valid for the Commitment, with the
+// VerifierKey of the SRS under which the Commitment was created.
+func VerifyHidden(vk *VerifierKey, c *Commitment, proof *HiddenProof) bool {
+	return vk.VerifyHidden(c.G1(), proof)
+}
@@ -0,0 +1,108 @@
+package kzg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestOpenHidden(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 4)
+	vk := srs.VerifierKey()
+	p := polynomial.FromRoots([]*big.Int{big.NewInt(2), big.NewInt(3), big.NewInt(5)}, Field)
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		z        int64
+		yBlinder int64
+	}{
+		{"root", 3, 0},
+		{"non-root", 7, 42},
+		{"blinded root", 5, 42},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zb := big.NewInt(1234)
+			proof, err := srs.OpenHidden(p, big.NewInt(tt.z), zb, big.NewInt(tt.yBlinder), rand.Reader)
+			if err != nil {
+				t.Fatalf("OpenHidden(): %v", err)
+			}
+			if !vk.VerifyHidden(c, proof) {
+				t.Errorf("VerifyHidden() = false; want true")
+			}
+			if want := CommitScalar(big.NewInt(tt.z), zb); !bytes.Equal(proof.Z.Marshal(), want.Marshal()) {
+				t.Errorf("OpenHidden().Z != CommitScalar(z, zBlinder)")
+			}
+			y := p.Evaluate(big.NewInt(tt.z), Field)
+			if want := CommitScalar(y, big.NewInt(tt.yBlinder)); !bytes.Equal(proof.Y.Marshal(), want.Marshal()) {
+				t.Errorf("OpenHidden().Y != CommitScalar(p(z), yBlinder)")
+			}
+		})
+	}
+}
+
+func TestVerifyHiddenRejects(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 4)
+	vk := srs.VerifierKey()
+	p := polynomial.FromRoots([]*big.Int{big.NewInt(2), big.NewInt(3)}, Field)
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	other, err := srs.Commit(p.Add(polynomial.OnePolynomial, Field))
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	open := func() *HiddenProof {
+		proof, err := srs.OpenHidden(p, big.NewInt(3), big.NewInt(1), big.NewInt(0), rand.Reader)
+		if err != nil {
+			t.Fatalf("OpenHidden(): %v", err)
+		}
+		return proof
+	}
+	g := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+
+	tests := []struct {
+		name   string
+		c      *bn256.G1
+		tamper func(*HiddenProof)
+	}{
+		{"other commitment", other, func(*HiddenProof) {}},
+		{"other z", c, func(p *HiddenProof) { p.Z = CommitScalar(big.NewInt(2), big.NewInt(1)) }},
+		// The identity Y claims p(z) = 0 for the root 3; claim 1 instead.
+		{"other y", c, func(p *HiddenProof) { p.Y = g }},
+		{"quotient", c, func(p *HiddenProof) { p.Quotient = new(bn256.G1).Add(p.Quotient, g) }},
+		{"a", c, func(p *HiddenProof) { p.A = new(bn256.G1).Add(p.A, g) }},
+		{"commitment", c, func(p *HiddenProof) { p.Commitments[2] = new(bn256.G1).Add(p.Commitments[2], g) }},
+		{"response", c, func(p *HiddenProof) { p.Responses[0] = Field.Add(p.Responses[0], big.NewInt(1)) }},
+		{"non-canonical response", c, func(p *HiddenProof) { p.Responses[1] = new(big.Int).Add(p.Responses[1], Field.Order()) }},
+		{"nil response", c, func(p *HiddenProof) { p.Responses[5] = nil }},
+		{"nil commitment", c, func(p *HiddenProof) { p.Commitments[0] = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof := open()
+			tt.tamper(proof)
+			if vk.VerifyHidden(tt.c, proof) {
+				t.Errorf("VerifyHidden() = true; want false")
+			}
+		})
+	}
+}
+
+func TestOpenHiddenExceedsSRS(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 2)
+	p := polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 4})
+	if _, err := srs.OpenHidden(p, big.NewInt(1), big.NewInt(1), big.NewInt(1), rand.Reader); err == nil {
+		t.Errorf("OpenHidden(<degree 3>) with SRS of max degree 2: got nil error")
+	}
+}
@@ -0,0 +1,165 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+)
+
+// Hiding commitments follow PolyCommit_Ped of Kate et al.: a commitment to p
+// is blinded by a random polynomial r as [p(s)]_1 + r(s)*h, for the second
+// generator h of a hiding SRS. Openings reveal p(z) and r(z) but, as long as r
+// has a degree of at least the number of openings, nothing else about p.
+
+// ErrNotHiding is returned when hiding commitments are requested from an SRS
+// without a second generator.
+var ErrNotHiding = errors.New("SRS doesn't support hiding commitments")
+
+// NewHidingSRS returns a hiding SRS for the secret s and the discrete logarithm
+// a of its second generator h = [a]_1, supporting polynomials of degree up to
+// maxDegree. Knowledge of either s or a allows forging of proofs or breaking
+// of the hiding property, so both MUST be discarded after setup.
+func NewHidingSRS(s, a *big.Int, maxDegree int) *SRS {
+	srs := NewSRS(s, maxDegree)
+	ss := polynomial.ComputePowers(s, maxDegree+1, Field)
+	srs.H1 = make([]*bn256.G1, len(ss))
+	srs.H2 = make([]*bn256.G2, len(ss))
+	for i, v := range ss {
+		v = Field.Mul(v, a)
+		srs.H1[i] = new(bn256.G1).ScalarBaseMult(v)
+		srs.H2[i] = new(bn256.G2).ScalarBaseMult(v)
+	}
+	return srs
+}
+
+// SetupHiding is equivalent to Setup, but returns a hiding SRS with both
+// secrets drawn from r.
+func SetupHiding(maxDegree int, r io.Reader) (*SRS, error) {
+	if maxDegree < 1 {
+		return nil, fmt.Errorf("SRS max degree %d < 1", maxDegree)
+	}
+	s, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	a, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	srs := NewHidingSRS(s, a, maxDegree)
+	s.SetInt64(0)
+	a.SetInt64(0)
+	return srs, nil
+}
+
+// IsHiding reports whether the SRS supports hiding commitments.
+func (srs *SRS) IsHiding() bool {
+	return srs.H1 != nil || srs.H2 != nil
+}
+
+// CommitHiding returns the hiding commitment [p(s)]_1 + r(s)*h to p, blinded
+// by r. The blinding polynomial r MUST be drawn uniformly at random, e.g. with
+// polynomial.Random, and kept secret.
+func (srs *SRS) CommitHiding(p, r *polynomial.Polynomial) (*bn256.G1, error) {
+	if !srs.IsHiding() {
+		return nil, ErrNotHiding
+	}
+	c, err := srs.Commit(p)
+	if err != nil {
+		return nil, err
+	}
+	r, err = srs.trim(r)
+	if err != nil {
+		return nil, fmt.Errorf("blinding: %v", err)
+	}
+	b, err := msm.MultiExp(srs.H1[:len(*r)], *r)
+	if err != nil {
+		return nil, err
+	}
+	return c.Add(c, b), nil
+}
+
+// A HidingProof attests that a polynomial p, committed to with CommitHiding and
+// blinding r, evaluates to p(Z) = Y. The Quotient is [q(s)]_2 + qr(s)*[a]_2
+// for q(v) = (p(v) - Y) / (v - Z) and qr(v) = (r(v) - BlindingY) / (v - Z),
+// with BlindingY = r(Z).
+type HidingProof struct {
+	Z, Y, BlindingY *big.Int
+	Quotient        *bn256.G2
+}
+
+// OpenHiding returns a HidingProof of the evaluation of p at z, with the
+// blinding r passed to CommitHiding.
+func (srs *SRS) OpenHiding(p, r *polynomial.Polynomial, z *big.Int) (*HidingProof, error) {
+	if !srs.IsHiding() {
+		return nil, ErrNotHiding
+	}
+	proof, err := srs.Open(p, z)
+	if err != nil {
+		return nil, err
+	}
+	r, err = srs.trim(r)
+	if err != nil {
+		return nil, fmt.Errorf("blinding: %v", err)
+	}
+
+	yr := r.Evaluate(proof.Z, Field)
+	qr, err := quotient(r, proof.Z, yr)
+	if err != nil {
+		return nil, err
+	}
+	qrs2, err := msm.MultiExp(srs.H2[:len(*qr)], *qr)
+	if err != nil {
+		return nil, err
+	}
+	return &HidingProof{
+		Z:         proof.Z,
+		Y:         proof.Y,
+		BlindingY: yr,
+		Quotient:  qrs2.Add(qrs2, proof.Quotient),
+	}, nil
+}
+
+// VerifyHiding reports whether the proof is valid for the hiding commitment c,
+// by checking
+//
+//	e([s - z]_1, Quotient) == e(c - [y]_1 - r(z)*h, [1]_2).
+//
+// It returns false if the VerifierKey was derived from an SRS that isn't
+// hiding.
+func (vk *VerifierKey) VerifyHiding(c *bn256.G1, proof *HidingProof) bool {
+	if vk.H == nil {
+		return false
+	}
+	blinded := new(bn256.G1).ScalarMult(vk.H, new(big.Int).Mod(proof.BlindingY, bn256.Order))
+	return vk.Verify(
+		new(bn256.G1).Add(c, new(bn256.G1).Neg(blinded)),
+		&Proof{Z: proof.Z, Y: proof.Y, Quotient: proof.Quotient},
+	)
+}
+
+// CommitHiding returns the hiding Commitment to p under the SRS, blinded by r.
+func CommitHiding(srs *SRS, p, r *polynomial.Polynomial) (*Commitment, error) {
+	c, err := srs.CommitHiding(p, r)
+	if err != nil {
+		return nil, err
+	}
+	return (*Commitment)(c), nil
+}
+
+// OpenHiding returns a HidingProof of the evaluation of p at z, with the SRS and
+// blinding under which p was committed to.
+func OpenHiding(srs *SRS, p, r *polynomial.Polynomial, z *big.Int) (*HidingProof, error) {
+	return srs.OpenHiding(p, r, z)
+}
+
+// VerifyHiding reports whether the proof is valid for the hiding Commitment,
+// with the VerifierKey of the SRS under which the Commitment was created.
+func VerifyHiding(vk *VerifierKey, c *Commitment, proof *HidingProof) bool {
+	return vk.VerifyHiding(c.G1(), proof)
+}
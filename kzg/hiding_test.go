@@ -0,0 +1,161 @@
+package kzg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestOpenVerifyHiding(t *testing.T) {
+	srs := NewHidingSRS(big.NewInt(1337), big.NewInt(42), 10)
+	vk := srs.VerifierKey()
+
+	tests := []struct {
+		c, r []int64
+		z    int64
+	}{
+		{c: []int64{42}, r: []int64{7}, z: 0},
+		{c: []int64{2, -3, 1}, r: []int64{5, 1, 9}, z: 1},
+		{c: []int64{2, -3, 1}, r: []int64{0}, z: 5},
+		{c: []int64{6, -5, 1, 0, 0, 7}, r: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, z: -1337},
+	}
+
+	for _, tt := range tests {
+		p, r := polynomial.NewPolynomialFromCoefficients(tt.c), polynomial.NewPolynomialFromCoefficients(tt.r)
+		c, err := CommitHiding(srs, p, r)
+		if err != nil {
+			t.Fatalf("CommitHiding(%v, %v): %v", tt.c, tt.r, err)
+		}
+
+		proof, err := OpenHiding(srs, p, r, big.NewInt(tt.z))
+		if err != nil {
+			t.Fatalf("OpenHiding(%v, %v, %d): %v", tt.c, tt.r, tt.z, err)
+		}
+		if want := p.Evaluate(big.NewInt(tt.z), Field); proof.Y.Cmp(want) != 0 {
+			t.Errorf("OpenHiding(%v, %v, %d).Y = %v; want %v", tt.c, tt.r, tt.z, proof.Y, want)
+		}
+		if !VerifyHiding(vk, c, proof) {
+			t.Errorf("VerifyHiding(CommitHiding(%v, %v), OpenHiding()) = false; want true", tt.c, tt.r)
+		}
+
+		wrongY := *proof
+		wrongY.Y = new(big.Int).Add(proof.Y, big.NewInt(1))
+		if VerifyHiding(vk, c, &wrongY) {
+			t.Errorf("VerifyHiding(CommitHiding(%v, %v), <wrong y>) = true; want false", tt.c, tt.r)
+		}
+		wrongBlinding := *proof
+		wrongBlinding.BlindingY = new(big.Int).Add(proof.BlindingY, big.NewInt(1))
+		if VerifyHiding(vk, c, &wrongBlinding) {
+			t.Errorf("VerifyHiding(CommitHiding(%v, %v), <wrong blinding>) = true; want false", tt.c, tt.r)
+		}
+	}
+}
+
+func TestCommitHidingBlinds(t *testing.T) {
+	srs := NewHidingSRS(big.NewInt(1337), big.NewInt(42), 4)
+	p := polynomial.NewPolynomialFromCoefficients([]int64{2, -3, 1})
+
+	plain, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	zero, err := srs.CommitHiding(p, polynomial.ZeroPolynomial)
+	if err != nil {
+		t.Fatalf("CommitHiding(p, 0): %v", err)
+	}
+	if !bytes.Equal(zero.Marshal(), plain.Marshal()) {
+		t.Errorf("CommitHiding(p, 0) != Commit(p)")
+	}
+
+	seen := map[string]bool{string(plain.Marshal()): true}
+	for i := 0; i < 3; i++ {
+		r, err := polynomial.Random(srs.MaxDegree(), Field, rand.Reader)
+		if err != nil {
+			t.Fatalf("polynomial.Random(): %v", err)
+		}
+		c, err := srs.CommitHiding(p, r)
+		if err != nil {
+			t.Fatalf("CommitHiding(): %v", err)
+		}
+		if seen[string(c.Marshal())] {
+			t.Errorf("CommitHiding(p, <random r>) repeated a commitment to p")
+		}
+		seen[string(c.Marshal())] = true
+	}
+}
+
+func TestHidingErrors(t *testing.T) {
+	p := polynomial.NewPolynomialFromCoefficients([]int64{1, 2})
+	r := polynomial.NewPolynomialFromCoefficients([]int64{3, 4})
+
+	srs := NewSRS(big.NewInt(1337), 4)
+	if _, err := srs.CommitHiding(p, r); !errors.Is(err, ErrNotHiding) {
+		t.Errorf("CommitHiding() with non-hiding SRS: got err %v; want %v", err, ErrNotHiding)
+	}
+	if _, err := srs.OpenHiding(p, r, big.NewInt(1)); !errors.Is(err, ErrNotHiding) {
+		t.Errorf("OpenHiding() with non-hiding SRS: got err %v; want %v", err, ErrNotHiding)
+	}
+
+	hiding := NewHidingSRS(big.NewInt(1337), big.NewInt(42), 4)
+	c, err := hiding.CommitHiding(p, r)
+	if err != nil {
+		t.Fatalf("CommitHiding(): %v", err)
+	}
+	proof, err := hiding.OpenHiding(p, r, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("OpenHiding(): %v", err)
+	}
+	if srs.VerifierKey().VerifyHiding(c, proof) {
+		t.Errorf("VerifyHiding() with VerifierKey of non-hiding SRS = true; want false")
+	}
+
+	tooLong := polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 4, 5, 6})
+	if _, err := hiding.CommitHiding(p, tooLong); err == nil {
+		t.Errorf("CommitHiding(p, <degree 5>) with SRS of max degree 4: got nil error")
+	}
+}
+
+func TestSetupHiding(t *testing.T) {
+	srs, err := SetupHiding(3, rand.Reader)
+	if err != nil {
+		t.Fatalf("SetupHiding(): %v", err)
+	}
+	if !srs.IsHiding() || len(srs.H1) != 4 || len(srs.H2) != 4 {
+		t.Fatalf("SetupHiding(3) = %d H1 and %d H2 powers; want 4 each", len(srs.H1), len(srs.H2))
+	}
+	if _, err := SetupHiding(0, rand.Reader); err == nil {
+		t.Errorf("SetupHiding(0): got nil error")
+	}
+}
+
+func TestHidingSRSMarshalBinary(t *testing.T) {
+	srs := NewHidingSRS(big.NewInt(1337), big.NewInt(42), 3)
+	buf, err := srs.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if got, want := len(buf), 4+2*4*(64+128); got != want {
+		t.Errorf("len(MarshalBinary()) = %d; want %d", got, want)
+	}
+
+	got := new(SRS)
+	if err := got.UnmarshalBinary(buf); err != nil {
+		t.Fatalf("UnmarshalBinary(MarshalBinary()): %v", err)
+	}
+	if !got.IsHiding() {
+		t.Fatalf("UnmarshalBinary(MarshalBinary()).IsHiding() = false; want true")
+	}
+	for i := range srs.H1 {
+		if !bytes.Equal(got.H1[i].Marshal(), srs.H1[i].Marshal()) || !bytes.Equal(got.H2[i].Marshal(), srs.H2[i].Marshal()) {
+			t.Errorf("UnmarshalBinary(MarshalBinary()) hiding power %d differs", i)
+		}
+	}
+
+	if err := got.UnmarshalBinary(buf[:len(buf)-1]); err == nil {
+		t.Errorf("UnmarshalBinary(<truncated>): got nil error")
+	}
+}
@@ -0,0 +1,124 @@
+package kzg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// Verify returns an error unless the SRS holds consecutive powers of a single
+// secret s on both curves, starting at the generators, without the identity
+// among them, and at least as many in G1 as in G2: a tampered or truncated SRS, e.g. as decoded by UnmarshalBinary
+// or SRSFromPowersOfTau, SHOULD be rejected before use. For a hiding SRS, the
+// powers of h must be of the same s, and the discrete logarithms of H1[0] and
+// H2[0] equal.
+//
+// All consecutive pairs are checked at once, combined with powers of a
+// weight w derived from the SRS by Fiat-Shamir, with two pairings per curve:
+//
+//	e(sum_i w^i G1[i+1], [1]_2) == e(sum_i w^i G1[i], [s]_2)
+//	e([1]_1, sum_i w^i G2[i+1]) == e([s]_1, sum_i w^i G2[i])
+//
+// Verify doesn't establish that s is unknown, for which see the ceremony
+// package.
+func (srs *SRS) Verify() error {
+	n, m := len(srs.G1), len(srs.G2)
+	if m < 2 || n < m {
+		return fmt.Errorf("SRS with %d powers in G1 and %d in G2; want at least 2 in G2 and as many in G1", n, m)
+	}
+	if srs.IsHiding() && (m != n || len(srs.H1) != n || len(srs.H2) != n) {
+		return fmt.Errorf("hiding SRS with %d, %d, %d and %d powers in G1, G2, H1 and H2; want equal counts", n, m, len(srs.H1), len(srs.H2))
+	}
+	g1, g2 := new(bn256.G1).ScalarBaseMult(bigOne), new(bn256.G2).ScalarBaseMult(bigOne)
+	if srs.G1[0] == nil || srs.G2[0] == nil || srs.G1[0].String() != g1.String() || srs.G2[0].String() != g2.String() {
+		return errors.New("SRS doesn't start at the generators")
+	}
+	for i := 0; i < n; i++ {
+		if srs.G1[i] == nil || isIdentity(srs.G1[i]) || i < m && (srs.G2[i] == nil || isIdentity(srs.G2[i])) {
+			return fmt.Errorf("power %d is the identity", i)
+		}
+		if srs.IsHiding() && (srs.H1[i] == nil || srs.H2[i] == nil || isIdentity(srs.H1[i]) || isIdentity(srs.H2[i])) {
+			return fmt.Errorf("hiding power %d is the identity", i)
+		}
+	}
+
+	t := transcript.New("zkp.xyz/kzg/srs")
+	t.AppendBytes("g1", appendPoints(nil, srs.G1))
+	t.AppendBytes("g2", appendPoints(nil, srs.G2))
+	t.AppendBytes("h1", appendPoints(nil, srs.H1))
+	t.AppendBytes("h2", appendPoints(nil, srs.H2))
+	ws := polynomial.ComputePowers(t.Challenge(Field), n-1, Field)
+
+	hi1, lo1, err := combined(srs.G1, ws)
+	if err != nil {
+		return err
+	}
+	if !SameRatio(hi1, lo1, srs.G2[1], g2) {
+		return errors.New("G1 powers not of the secret of G2[1]")
+	}
+	hi2, lo2, err := combined(srs.G2, ws[:m-1])
+	if err != nil {
+		return err
+	}
+	if !SameRatio(srs.G1[1], g1, hi2, lo2) {
+		return errors.New("G2 powers not of the secret of G1[1]")
+	}
+	if !srs.IsHiding() {
+		return nil
+	}
+
+	if !SameRatio(srs.H1[0], g1, srs.H2[0], g2) {
+		return errors.New("H1[0] and H2[0] of different discrete logarithms")
+	}
+	hi1, lo1, err = combined(srs.H1, ws)
+	if err != nil {
+		return err
+	}
+	if !SameRatio(hi1, lo1, srs.G2[1], g2) {
+		return errors.New("H1 powers not of the secret of G2[1]")
+	}
+	hi2, lo2, err = combined(srs.H2, ws)
+	if err != nil {
+		return err
+	}
+	if !SameRatio(srs.G1[1], g1, hi2, lo2) {
+		return errors.New("H2 powers not of the secret of G1[1]")
+	}
+	return nil
+}
+
+// combined returns sum_i ws[i] ps[i+1] and sum_i ws[i] ps[i], whose ratio is
+// that of consecutive powers if all of them are powers of the same secret.
+func combined[E any, P polynomial.PointerGroupElement[E, P]](ps []P, ws []*big.Int) (P, P, error) {
+	hi, err := msm.MultiExp(ps[1:], ws)
+	if err != nil {
+		return nil, nil, err
+	}
+	lo, err := msm.MultiExp(ps[:len(ps)-1], ws)
+	if err != nil {
+		return nil, nil, err
+	}
+	return hi, lo, nil
+}
+
+// SameRatio reports whether a1 / b1 = a2 / b2 for a1, b1 in G1 and a2, b2 in
+// G2, i.e. whether e(a1, b2) == e(b1, a2).
+func SameRatio(a1, b1 *bn256.G1, a2, b2 *bn256.G2) bool {
+	return bn256.PairingCheck(
+		[]*bn256.G1{a1, new(bn256.G1).Neg(b1)},
+		[]*bn256.G2{b2, a2},
+	)
+}
+
+// isIdentity reports whether the point is the identity, whose encoding is all
+// zeros.
+func isIdentity(p interface{ Marshal() []byte }) bool {
+	buf := p.Marshal()
+	return bytes.Equal(buf, make([]byte, len(buf)))
+}
@@ -0,0 +1,91 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+func TestSRSVerify(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 4)
+	hiding := NewHidingSRS(big.NewInt(1337), big.NewInt(42), 4)
+	other := NewSRS(big.NewInt(42), 4)
+	otherHiding := NewHidingSRS(big.NewInt(42), big.NewInt(42), 4)
+
+	// with returns a copy of srs with the G1 and G2 powers replaced.
+	with := func(srs *SRS, g1 []*bn256.G1, g2 []*bn256.G2) *SRS {
+		return &SRS{G1: g1, G2: g2, H1: srs.H1, H2: srs.H2}
+	}
+	replaced := func(ps []*bn256.G1, i int, p *bn256.G1) []*bn256.G1 {
+		ps = append([]*bn256.G1{}, ps...)
+		ps[i] = p
+		return ps
+	}
+	replaced2 := func(ps []*bn256.G2, i int, p *bn256.G2) []*bn256.G2 {
+		ps = append([]*bn256.G2{}, ps...)
+		ps[i] = p
+		return ps
+	}
+	identity := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+
+	tests := []struct {
+		name    string
+		srs     *SRS
+		wantErr bool
+	}{
+		{"valid", srs, false},
+		{"valid hiding", hiding, false},
+		{"fewer powers in G2", with(srs, srs.G1, srs.G2[:2]), false},
+		{"more powers in G2", with(srs, srs.G1[:4], srs.G2), true},
+		{"single power in G2", with(srs, srs.G1, srs.G2[:1]), true},
+		{"tampered G2 of fewer powers", with(srs, srs.G1, replaced2(srs.G2[:3], 2, other.G2[2])), true},
+		{"hiding with fewer powers in G2", with(hiding, hiding.G1, hiding.G2[:4]), true},
+		{"single power", with(srs, srs.G1[:1], srs.G2[:1]), true},
+		{"shifted", with(srs, srs.G1[1:], srs.G2[1:]), true},
+		{"tampered G1", with(srs, replaced(srs.G1, 3, other.G1[3]), srs.G2), true},
+		{"tampered last G1", with(srs, replaced(srs.G1, 4, other.G1[4]), srs.G2), true},
+		{"tampered G2", with(srs, srs.G1, replaced2(srs.G2, 2, other.G2[2])), true},
+		{"mixed secrets", with(srs, srs.G1, other.G2), true},
+		{"identity", with(srs, replaced(srs.G1, 2, identity), srs.G2), true},
+		{"nil point", with(srs, replaced(srs.G1, 2, nil), srs.G2), true},
+		{"tampered H1", &SRS{G1: hiding.G1, G2: hiding.G2, H1: replaced(hiding.H1, 1, otherHiding.H1[1]), H2: hiding.H2}, true},
+		{"H1 of other secret", &SRS{G1: hiding.G1, G2: hiding.G2, H1: otherHiding.H1, H2: hiding.H2}, true},
+		{"H of other logarithm", &SRS{G1: hiding.G1, G2: hiding.G2, H1: hiding.G1, H2: hiding.H2}, true},
+		{"truncated H2", &SRS{G1: hiding.G1, G2: hiding.G2, H1: hiding.H1, H2: hiding.H2[:2]}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.srs.Verify()
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Errorf("Verify() error %v; want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSameRatio(t *testing.T) {
+	g1 := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+	g2 := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	x1 := new(bn256.G1).ScalarBaseMult(big.NewInt(42))
+	x2 := new(bn256.G2).ScalarBaseMult(big.NewInt(42))
+	y2 := new(bn256.G2).ScalarBaseMult(big.NewInt(43))
+
+	tests := []struct {
+		name   string
+		a1, b1 *bn256.G1
+		a2, b2 *bn256.G2
+		want   bool
+	}{
+		{name: "equal ratios", a1: x1, b1: g1, a2: x2, b2: g2, want: true},
+		{name: "swapped ratio", a1: g1, b1: x1, a2: x2, b2: g2, want: false},
+		{name: "other ratio", a1: x1, b1: g1, a2: y2, b2: g2, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameRatio(tt.a1, tt.b1, tt.a2, tt.b2); got != tt.want {
+				t.Errorf("SameRatio() = %t; want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package kzg implements Kate-Zaverucha-Goldberg (KZG) polynomial commitments
+// over the bn256 curve.
+//
+// A trusted Setup yields an SRS, with which a prover can Commit to a
+// polynomial p and later Open the commitment at any point z, proving that
+// p(z) = y without revealing p. Anyone holding the SRS's VerifierKey can then
+// Verify the proof against the commitment.
+//
+// See https://dankradfeist.de/ethereum/2020/06/16/kate-polynomial-commitments.html
+// for an introduction to the scheme.
+package kzg
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A Commitment is a commitment [p(s)]_1 to a polynomial p. It can be converted
+// to and from a *bn256.G1 at no cost.
+type Commitment bn256.G1
+
+// G1 returns the commitment as a point on G1, which MUST NOT be modified.
+func (c *Commitment) G1() *bn256.G1 {
+	return (*bn256.G1)(c)
+}
+
+// Setup returns an SRS supporting polynomials of degree up to maxDegree, for a
+// secret drawn from r. The secret is discarded before Setup returns, but r
+// MUST be a source of cryptographic randomness as its output is sufficient to
+// forge proofs.
+func Setup(maxDegree int, r io.Reader) (*SRS, error) {
+	if maxDegree < 1 {
+		return nil, fmt.Errorf("SRS max degree %d < 1", maxDegree)
+	}
+	s, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	srs := NewSRS(s, maxDegree)
+	s.SetInt64(0)
+	return srs, nil
+}
+
+// Commit returns the Commitment to p under the SRS.
+func Commit(srs *SRS, p *polynomial.Polynomial) (*Commitment, error) {
+	c, err := srs.Commit(p)
+	if err != nil {
+		return nil, err
+	}
+	return (*Commitment)(c), nil
+}
+
+// Open returns a Proof of the evaluation of p at z, with the SRS under which p
+// was committed to.
+func Open(srs *SRS, p *polynomial.Polynomial, z *big.Int) (*Proof, error) {
+	return srs.Open(p, z)
+}
+
+// Verify reports whether the proof is valid for the Commitment, with the
+// VerifierKey of the SRS under which the Commitment was created.
+func Verify(vk *VerifierKey, c *Commitment, proof *Proof) bool {
+	return vk.Verify(c.G1(), proof)
+}
@@ -0,0 +1,73 @@
+package kzg
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestSetupCommitOpenVerify(t *testing.T) {
+	srs, err := Setup(8, rand.Reader)
+	if err != nil {
+		t.Fatalf("Setup(): %v", err)
+	}
+	if got := srs.MaxDegree(); got != 8 {
+		t.Errorf("Setup(8).MaxDegree() = %d; want 8", got)
+	}
+	vk := srs.VerifierKey()
+
+	p := polynomial.NewPolynomialFromCoefficients([]int64{2, -3, 1, 0, 7})
+	c, err := Commit(srs, p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	for _, z := range []int64{0, 1, 5, -42} {
+		proof, err := Open(srs, p, big.NewInt(z))
+		if err != nil {
+			t.Fatalf("Open(%d): %v", z, err)
+		}
+		if !Verify(vk, c, proof) {
+			t.Errorf("Verify(Commit(), Open(%d)) = false; want true", z)
+		}
+
+		wrong := *proof
+		wrong.Y = Field.Add(proof.Y, big.NewInt(1))
+		if Verify(vk, c, &wrong) {
+			t.Errorf("Verify(Commit(), <wrong y at %d>) = true; want false", z)
+		}
+	}
+
+	// A different setup has a different secret.
+	other, err := Setup(8, rand.Reader)
+	if err != nil {
+		t.Fatalf("Setup(): %v", err)
+	}
+	proof, err := Open(srs, p, big.NewInt(5))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	if Verify(other.VerifierKey(), c, proof) {
+		t.Errorf("Verify() with VerifierKey of another Setup() = true; want false")
+	}
+}
+
+func TestSetupErrors(t *testing.T) {
+	if _, err := Setup(0, rand.Reader); err == nil {
+		t.Errorf("Setup(0): got nil error")
+	}
+	if _, err := Setup(4, errReader{}); err == nil {
+		t.Errorf("Setup(<failing reader>): got nil error")
+	}
+}
+
+type errReader struct{}
+
+var errRead = errors.New("read error")
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
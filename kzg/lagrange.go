@@ -0,0 +1,63 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A LagrangeSRS holds the G1 powers of an SRS in Lagrange form over a Domain,
+// i.e. G1[i] = [L_i(s)]_1 for the Lagrange basis polynomials L_i of the
+// Domain, with which polynomials are committed to directly from their
+// evaluations over the Domain.
+type LagrangeSRS struct {
+	domain *polynomial.Domain
+	G1     []*bn256.G1
+}
+
+// Lagrange returns the LagrangeSRS of srs over the Domain, whose size must not
+// exceed srs.MaxDegree()+1. As L_i(X) = 1/n sum_j w^(-ij) X^j, the points are
+// the inverse FFT of the first n powers of s on G1, computed with O(n log n)
+// group operations.
+func (srs *SRS) Lagrange(d *polynomial.Domain) (*LagrangeSRS, error) {
+	n := int(d.Size())
+	if n > len(srs.G1) {
+		return nil, fmt.Errorf("domain of size %d exceeds SRS of max degree %d", n, srs.MaxDegree())
+	}
+	nInv, err := Field.MultInverse(big.NewInt(int64(n)))
+	if err != nil {
+		return nil, err
+	}
+
+	g1 := make([]*bn256.G1, n)
+	for i := range g1 {
+		g1[i] = new(bn256.G1).Set(srs.G1[i])
+	}
+	fftPoints(g1, d, true)
+	for _, p := range g1 {
+		p.ScalarMult(p, nInv)
+	}
+	return &LagrangeSRS{domain: d, G1: g1}, nil
+}
+
+// Domain returns the Domain over which the LagrangeSRS is defined.
+func (l *LagrangeSRS) Domain() *polynomial.Domain {
+	return l.domain
+}
+
+// CommitLagrange returns [p(s)]_1 for the polynomial p of degree less than
+// the size of the Domain with p(w^i) = evals[i]; missing evaluations are
+// treated as zero. The commitment equals srs.Commit() of the coefficients
+// returned by the Domain's IFFT, without computing them.
+func (l *LagrangeSRS) CommitLagrange(evals []*big.Int) (*bn256.G1, error) {
+	if len(evals) > len(l.G1) {
+		return nil, fmt.Errorf("%d evaluations exceed domain size %d", len(evals), len(l.G1))
+	}
+	if len(evals) == 0 {
+		return new(bn256.G1).ScalarBaseMult(bigZero), nil
+	}
+	return msm.MultiExp(l.G1[:len(evals)], reduceAll(evals))
+}
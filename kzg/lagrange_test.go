@@ -0,0 +1,100 @@
+package kzg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestCommitLagrange(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 8)
+
+	for _, size := range []uint64{1, 2, 8} {
+		d, err := polynomial.NewDomain(Field, size, rand.Reader)
+		if err != nil {
+			t.Fatalf("NewDomain(%d): %v", size, err)
+		}
+		l, err := srs.Lagrange(d)
+		if err != nil {
+			t.Fatalf("Lagrange(<domain of size %d>): %v", size, err)
+		}
+
+		for n := 0; n <= int(size); n++ {
+			evals := make([]*big.Int, n)
+			for i := range evals {
+				evals[i] = big.NewInt(int64(3*i + 1))
+			}
+			if n > 0 {
+				evals[0] = new(big.Int).Neg(evals[0]) // not reduced
+			}
+
+			got, err := l.CommitLagrange(evals)
+			if err != nil {
+				t.Fatalf("CommitLagrange(<%d evaluations>) over domain of size %d: %v", n, size, err)
+			}
+			want, err := srs.Commit(polynomial.NewPolynomial(d.IFFT(evals)))
+			if err != nil {
+				t.Fatalf("Commit(IFFT()): %v", err)
+			}
+			if !bytes.Equal(got.Marshal(), want.Marshal()) {
+				t.Errorf("CommitLagrange(<%d evaluations>) over domain of size %d != Commit(IFFT())", n, size)
+			}
+		}
+	}
+}
+
+func TestLagrangeBasis(t *testing.T) {
+	const s = 1337
+	srs := NewSRS(big.NewInt(s), 4)
+	d, err := polynomial.NewDomain(Field, 4, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	l, err := srs.Lagrange(d)
+	if err != nil {
+		t.Fatalf("Lagrange(): %v", err)
+	}
+	if l.Domain() != d {
+		t.Errorf("Domain() != <domain passed to Lagrange()>")
+	}
+
+	for i, p := range l.G1 {
+		ys := make([]*big.Int, d.Size())
+		for j := range ys {
+			ys[j] = big.NewInt(0)
+		}
+		ys[i] = big.NewInt(1)
+		li := polynomial.NewPolynomial(d.IFFT(ys))
+		want := new(bn256.G1).ScalarBaseMult(li.Evaluate(big.NewInt(s), Field))
+		if !bytes.Equal(p.Marshal(), want.Marshal()) {
+			t.Errorf("G1[%d] != [L_%d(s)]_1", i, i)
+		}
+	}
+}
+
+func TestLagrangeErrors(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 2)
+	d, err := polynomial.NewDomain(Field, 4, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	if _, err := srs.Lagrange(d); err == nil {
+		t.Errorf("Lagrange(<domain of size 4>) with SRS of max degree 2: got nil error")
+	}
+
+	d, err = polynomial.NewDomain(Field, 2, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	l, err := srs.Lagrange(d)
+	if err != nil {
+		t.Fatalf("Lagrange(<domain of size 2>): %v", err)
+	}
+	if _, err := l.CommitLagrange([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}); err == nil {
+		t.Errorf("CommitLagrange(<3 evaluations>) over domain of size 2: got nil error")
+	}
+}
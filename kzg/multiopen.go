@@ -0,0 +1,177 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+)
+
+// openManyLeaf is the number of points up to which OpenMany evaluates the
+// remainder of the quotient coefficients with an MSM per point instead of
+// further descending the subproduct tree.
+var openManyLeaf = 128
+
+// mulG2Threshold is the length of the shorter operand below which mulG2
+// multiplies by schoolbook instead of with the FFT.
+const mulG2Threshold = 16
+
+// OpenMany returns Proofs of the evaluations of p at all zs, in order.
+//
+// The quotient commitments are the evaluations at the zs of the polynomial
+// with coefficients h on G2, computed as polynomial.EvaluateMany does for
+// scalars: the coefficients are reduced modulo the polynomials of a subproduct
+// tree over the zs, with products of G2 and scalar polynomials computed with
+// the FFT. This takes O(n log^2 n) group operations for n points and deg p < n,
+// instead of the O(n^2 / log n) of an MSM per point with Open. As the FFTs
+// over G2 have a significantly larger constant factor than MSMs, up to
+// openManyLeaf points are evaluated with an MSM each, and the tree only pays
+// off for thousands of points.
+func (o *Openings) OpenMany(zs []*big.Int) ([]*Proof, error) {
+	reduced := make([]*big.Int, len(zs))
+	for i, z := range zs {
+		reduced[i] = new(big.Int).Mod(z, Field.Order())
+	}
+	proofs := make([]*Proof, len(zs))
+	if len(zs) == 0 {
+		return proofs, nil
+	}
+
+	qs := make([]*bn256.G2, len(zs))
+	if err := newG2Tree(reduced).evaluate(o.h, qs); err != nil {
+		return nil, err
+	}
+	ys := polynomial.EvaluateMany(o.p, reduced, Field)
+	for i := range proofs {
+		proofs[i] = &Proof{Z: reduced[i], Y: ys[i], Quotient: qs[i]}
+	}
+	return proofs, nil
+}
+
+// A g2Tree is a subproduct tree, holding prod_i (v - zs[i]) over a range of
+// points, along with the trees of both halves of the range.
+type g2Tree struct {
+	zs          []*big.Int
+	poly        *polynomial.Polynomial
+	left, right *g2Tree
+}
+
+func newG2Tree(zs []*big.Int) *g2Tree {
+	if len(zs) <= openManyLeaf {
+		return &g2Tree{zs: zs, poly: polynomial.FromRoots(zs, Field)}
+	}
+	mid := len(zs) / 2
+	left, right := newG2Tree(zs[:mid]), newG2Tree(zs[mid:])
+	return &g2Tree{zs: zs, poly: left.poly.Mul(right.poly, Field), left: left, right: right}
+}
+
+// evaluate sets out to the evaluations at t.zs of the polynomial with
+// coefficients h on G2.
+func (t *g2Tree) evaluate(h []*bn256.G2, out []*bn256.G2) error {
+	h, err := remG2(h, t.poly)
+	if err != nil {
+		return err
+	}
+	if t.left == nil {
+		for i, z := range t.zs {
+			if len(h) == 0 {
+				out[i] = new(bn256.G2).ScalarBaseMult(bigZero)
+				continue
+			}
+			if out[i], err = msm.MultiExp(h, polynomial.ComputePowers(z, len(h), Field)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	mid := len(t.left.zs)
+	if err := t.left.evaluate(h, out[:mid]); err != nil {
+		return err
+	}
+	return t.right.evaluate(h, out[mid:])
+}
+
+// remG2 returns the coefficients of h mod m for the polynomial with
+// coefficients h on G2 and a monic m. As for polynomial.Div with Newton
+// iteration, the quotient is the reversal of rev(h) / rev(m) mod v^j, for j
+// coefficients of the quotient, with the power series 1 / rev(m) being the
+// reversal of the quotient of v^(k+j-1) by m, for k the degree of m.
+func remG2(h []*bn256.G2, m *polynomial.Polynomial) ([]*bn256.G2, error) {
+	k := m.Degree()
+	if len(h) <= k {
+		return h, nil
+	}
+	j := len(h) - k
+
+	power := polynomial.NewZeroPolynomial(k + j - 1)
+	(*power)[k+j-1] = big.NewInt(1)
+	quo, _ := power.Div(m, Field)
+	inv := make([]*big.Int, j)
+	for i := range inv {
+		inv[i] = bigZero
+		if t := j - 1 - i; t < len(*quo) {
+			inv[i] = (*quo)[t]
+		}
+	}
+
+	rev := make([]*bn256.G2, j)
+	for i := range rev {
+		rev[i] = h[len(h)-1-i]
+	}
+	qRev, err := mulG2(rev, inv, j)
+	if err != nil {
+		return nil, err
+	}
+	q := make([]*bn256.G2, j)
+	for i := range q {
+		q[i] = qRev[j-1-i]
+	}
+
+	mq, err := mulG2(q, (*m)[:k], k)
+	if err != nil {
+		return nil, err
+	}
+	r := make([]*bn256.G2, k)
+	for i := range r {
+		r[i] = new(bn256.G2).Neg(mq[i])
+		r[i].Add(r[i], h[i])
+	}
+	return r, nil
+}
+
+// mulG2 returns the first n coefficients of the product of the polynomial with
+// coefficients points on G2 and that with coefficients scalars, as new points.
+func mulG2(points []*bn256.G2, scalars []*big.Int, n int) ([]*bn256.G2, error) {
+	if len(points) < mulG2Threshold || len(scalars) < mulG2Threshold {
+		out := padG2(nil, n)
+		tmp := new(bn256.G2)
+		for i, p := range points {
+			for j, s := range scalars {
+				if i+j >= n {
+					break
+				}
+				out[i+j].Add(out[i+j], tmp.ScalarMult(p, s))
+			}
+		}
+		return out, nil
+	}
+
+	size := uint64(1) << bits.Len(uint(len(points)+len(scalars)-2))
+	dom, err := polynomial.CanonicalDomain(Field, size)
+	if err != nil {
+		return nil, fmt.Errorf("CanonicalDomain(%d): %v", size, err)
+	}
+	sizeInv, err := Field.MultInverse(new(big.Int).SetUint64(size))
+	if err != nil {
+		return nil, err
+	}
+	sHat := dom.FFT(scalars)
+	pHat := fftPoints(padG2(points, int(size)), dom, false)
+	for i, p := range pHat {
+		p.ScalarMult(p, Field.Mul(sHat[i], sizeInv))
+	}
+	return fftPoints(pHat, dom, true)[:n], nil
+}
@@ -0,0 +1,74 @@
+package kzg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestOpenMany(t *testing.T) {
+	// Descend the subproduct tree for few points.
+	defer func(leaf int) { openManyLeaf = leaf }(openManyLeaf)
+	openManyLeaf = 4
+
+	srs := NewSRS(big.NewInt(1337), 70)
+	vk := srs.VerifierKey()
+
+	for _, tt := range []struct {
+		degree, points int
+	}{
+		{degree: 0, points: 3},
+		{degree: 5, points: 0},
+		{degree: 5, points: 40},
+		{degree: 70, points: 1},
+		{degree: 70, points: 50},
+	} {
+		p, err := polynomial.Random(tt.degree, Field, rand.Reader)
+		if err != nil {
+			t.Fatalf("Random(%d): %v", tt.degree, err)
+		}
+		commitment, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(<degree %d>): %v", tt.degree, err)
+		}
+		o, err := srs.PrecomputeOpenings(p)
+		if err != nil {
+			t.Fatalf("PrecomputeOpenings(<degree %d>): %v", tt.degree, err)
+		}
+
+		zs := make([]*big.Int, tt.points)
+		for i := range zs {
+			if zs[i], err = Field.Random(rand.Reader); err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+		}
+		if tt.points > 2 {
+			// Unreduced, negative and repeated points.
+			zs[0] = new(big.Int).Add(zs[1], Field.Order())
+			zs[2] = big.NewInt(-1337)
+		}
+
+		proofs, err := o.OpenMany(zs)
+		if err != nil {
+			t.Fatalf("OpenMany(<%d points>) of degree %d: %v", tt.points, tt.degree, err)
+		}
+		if len(proofs) != len(zs) {
+			t.Fatalf("OpenMany(<%d points>) returned %d proofs", len(zs), len(proofs))
+		}
+		for i, got := range proofs {
+			want, err := o.Open(zs[i])
+			if err != nil {
+				t.Fatalf("Open(%v): %v", zs[i], err)
+			}
+			if got.Z.Cmp(want.Z) != 0 || got.Y.Cmp(want.Y) != 0 || !bytes.Equal(got.Quotient.Marshal(), want.Quotient.Marshal()) {
+				t.Errorf("OpenMany(<%d points>)[%d] of degree %d != Open()", tt.points, i, tt.degree)
+			}
+			if !vk.Verify(commitment, got) {
+				t.Errorf("Verify(OpenMany(<%d points>)[%d]) of degree %d = false; want true", tt.points, i, tt.degree)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+// quotient computes the polynomial division q(v) + r(v) = (p(v) - y) / (v - z)
+// and checks that r = 0.
+func quotient(p *polynomial.Polynomial, z, y *big.Int) (*polynomial.Polynomial, error) {
+	q, r := p.Add(
+		// constant polynomial: g(v) = -y
+		polynomial.NewPolynomial([]*big.Int{new(big.Int).Neg(y)}), Field,
+	).Div(
+		// degree 1 poly: g(v) = -z + v
+		polynomial.NewPolynomial([]*big.Int{new(big.Int).Neg(z), big.NewInt(1)}), Field,
+	)
+
+	if !r.Eq(polynomial.ZeroPolynomial) {
+		return nil, fmt.Errorf("division rest not zero: %v", r)
+	}
+
+	return q, nil
+}
+
+// Open returns a Proof of the evaluation of p at z.
+func (srs *SRS) Open(p *polynomial.Polynomial, z *big.Int) (*Proof, error) {
+	p, err := srs.trim(p)
+	if err != nil {
+		return nil, err
+	}
+
+	z = new(big.Int).Mod(z, Field.Order())
+	y := p.Evaluate(z, Field)
+	q, err := quotient(p, z, y)
+	if err != nil {
+		return nil, err
+	}
+
+	qs2, err := srs.CommitG2(q)
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{Z: z, Y: y, Quotient: qs2}, nil
+}
+
+// QuotientCommitmentsBothCurves returns y = p(z) and the commitments to the
+// quotient q(v) = (p(v) - y) / (v - z) on both curves. By commutativity of the
+// pairing, either can be used for verification; see VerifierKey.Verify and
+// VerifierKey.VerifyWithG1Quotient respectively.
+func (srs *SRS) QuotientCommitmentsBothCurves(p *polynomial.Polynomial, z *big.Int) (g1 *bn256.G1, g2 *bn256.G2, y *big.Int, err error) {
+	p, err = srs.trim(p)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	z = new(big.Int).Mod(z, Field.Order())
+	y = p.Evaluate(z, Field)
+	q, err := quotient(p, z, y)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if g1, err = srs.Commit(q); err != nil {
+		return nil, nil, nil, err
+	}
+	if g2, err = srs.CommitG2(q); err != nil {
+		return nil, nil, nil, err
+	}
+	return g1, g2, y, nil
+}
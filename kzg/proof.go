@@ -0,0 +1,62 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// A Proof attests that a committed polynomial p evaluates to p(Z) = Y. The
+// Quotient is [q(s)]_2 for q(v) = (p(v) - Y) / (v - Z).
+type Proof struct {
+	Z, Y     *big.Int
+	Quotient *bn256.G2
+}
+
+const (
+	evmWordSize = 32
+	// EVMProofSize is the length of the encoding returned by Proof.MarshalEVM.
+	EVMProofSize = 6 * evmWordSize
+)
+
+// MarshalEVM encodes the proof as consumed by the bn256 precompiles. All values
+// are big-endian 32-byte words in the order
+//
+//	z | y | q.x.c1 | q.x.c0 | q.y.c1 | q.y.c0
+//
+// where q is the Quotient and each G2 coordinate c1*i + c0 is an element of the
+// quadratic extension field. Note that the imaginary part c1 comes first, as
+// required by the ecPairing precompile (EIP-197). The scalars are reduced modulo
+// bn256.Order.
+func (p *Proof) MarshalEVM() []byte {
+	buf := make([]byte, EVMProofSize)
+	new(big.Int).Mod(p.Z, bn256.Order).FillBytes(buf[:evmWordSize])
+	new(big.Int).Mod(p.Y, bn256.Order).FillBytes(buf[evmWordSize : 2*evmWordSize])
+	// bn256.G2.Marshal() already uses the (c1, c0) ordering of the precompile.
+	copy(buf[2*evmWordSize:], p.Quotient.Marshal())
+	return buf
+}
+
+// UnmarshalEVM is the inverse of MarshalEVM.
+func (p *Proof) UnmarshalEVM(buf []byte) error {
+	if len(buf) != EVMProofSize {
+		return fmt.Errorf("invalid proof length %d; want %d", len(buf), EVMProofSize)
+	}
+
+	z := new(big.Int).SetBytes(buf[:evmWordSize])
+	y := new(big.Int).SetBytes(buf[evmWordSize : 2*evmWordSize])
+	for _, x := range []*big.Int{z, y} {
+		if x.Cmp(bn256.Order) >= 0 {
+			return fmt.Errorf("scalar %v exceeds group order", x)
+		}
+	}
+
+	q := new(bn256.G2)
+	if _, err := q.Unmarshal(buf[2*evmWordSize:]); err != nil {
+		return fmt.Errorf("bn256.G2.Unmarshal(): %v", err)
+	}
+
+	p.Z, p.Y, p.Quotient = z, y, q
+	return nil
+}
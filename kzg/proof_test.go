@@ -0,0 +1,96 @@
+package kzg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProofMarshalEVMLayout(t *testing.T) {
+	p := &Proof{
+		Z:        big.NewInt(5),
+		Y:        big.NewInt(12),
+		Quotient: new(bn256.G2).ScalarBaseMult(big.NewInt(1)),
+	}
+
+	// The G2 generator as specified in EIP-197, (x.c1, x.c0, y.c1, y.c0).
+	want, err := hex.DecodeString("" +
+		"0000000000000000000000000000000000000000000000000000000000000005" +
+		"000000000000000000000000000000000000000000000000000000000000000c" +
+		"198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c2" +
+		"1800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed" +
+		"090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b" +
+		"12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+	)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(): %v", err)
+	}
+
+	if got := p.MarshalEVM(); !bytes.Equal(got, want) {
+		t.Errorf("MarshalEVM() mismatch: want %x, got %x", want, got)
+	}
+}
+
+func TestProofEVMRoundTrip(t *testing.T) {
+	tests := []struct {
+		z, y, q int64
+	}{
+		{z: 0, y: 0, q: 0},
+		{z: 5, y: 12, q: 1},
+		{z: 1337, y: -1, q: 42},
+	}
+
+	for _, tt := range tests {
+		p := &Proof{
+			Z:        big.NewInt(tt.z),
+			Y:        big.NewInt(tt.y),
+			Quotient: new(bn256.G2).ScalarBaseMult(big.NewInt(tt.q)),
+		}
+
+		got := new(Proof)
+		if err := got.UnmarshalEVM(p.MarshalEVM()); err != nil {
+			t.Fatalf("UnmarshalEVM(MarshalEVM()): %v", err)
+		}
+
+		wantY := new(big.Int).Mod(p.Y, bn256.Order)
+		if got.Z.Cmp(p.Z) != 0 || got.Y.Cmp(wantY) != 0 {
+			t.Errorf("scalar mismatch: want (%v, %v), got (%v, %v)", p.Z, wantY, got.Z, got.Y)
+		}
+		if diff := cmp.Diff(p.Quotient.String(), got.Quotient.String()); diff != "" {
+			t.Errorf("quotient mismatch, diff %v", diff)
+		}
+	}
+}
+
+func TestProofUnmarshalEVMErrors(t *testing.T) {
+	valid := (&Proof{
+		Z:        big.NewInt(1),
+		Y:        big.NewInt(2),
+		Quotient: new(bn256.G2).ScalarBaseMult(big.NewInt(3)),
+	}).MarshalEVM()
+
+	overflow := append([]byte{}, valid...)
+	bn256.Order.FillBytes(overflow[:evmWordSize])
+
+	offCurve := append([]byte{}, valid...)
+	offCurve[len(offCurve)-1] ^= 1
+
+	tests := []struct {
+		name string
+		buf  []byte
+	}{
+		{name: "short", buf: valid[:EVMProofSize-1]},
+		{name: "scalar overflow", buf: overflow},
+		{name: "point off curve", buf: offCurve},
+	}
+
+	for _, tt := range tests {
+		if err := new(Proof).UnmarshalEVM(tt.buf); err == nil {
+			t.Errorf("UnmarshalEVM(%s): got nil error", tt.name)
+		}
+	}
+}
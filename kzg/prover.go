@@ -0,0 +1,23 @@
+package kzg
+
+// A Prover creates proofs about polynomials committed to with an SRS.
+type Prover struct {
+	srs    *SRS
+	config Config
+	cache  powersCache
+}
+
+// NewProver returns a Prover using the SRS and the zero Config.
+func NewProver(srs *SRS) *Prover {
+	return NewProverWithConfig(srs, Config{})
+}
+
+// NewProverWithConfig returns a Prover using the SRS and Config.
+func NewProverWithConfig(srs *SRS, cfg Config) *Prover {
+	return &Prover{srs: srs, config: cfg.clone()}
+}
+
+// SRS returns the SRS used by the Prover.
+func (pr *Prover) SRS() *SRS {
+	return pr.srs
+}
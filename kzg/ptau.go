@@ -0,0 +1,181 @@
+package kzg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// Section types of the .ptau format.
+const (
+	ptauHeader = 1
+	ptauTauG1  = 2
+	ptauTauG2  = 3
+)
+
+// SRSFromPowersOfTau parses a powers-of-tau transcript in the .ptau format of
+// snarkjs, as produced by the Perpetual Powers of Tau and Hermez ceremonies
+// over bn128 (the curve referred to as bn256 here). The returned SRS supports
+// polynomials of degree up to 2^power - 1, for the power of the ceremony.
+//
+// The tauG2 section may hold fewer powers than that, at least two, as in the
+// layout of the Ethereum KZG ceremony with 4096 powers in G1 but 65 in G2; see
+// SRS for the resulting limits. The transcript of that ceremony (EIP-4844) is
+// over BLS12-381, though, and is rejected with an error, as is any other
+// curve; its mainnet output is used by the kzg4844 package instead.
+//
+// The points are only checked to be on their curves; see the .ptau
+// contributions section, which isn't parsed, for verification of the
+// ceremony itself.
+func SRSFromPowersOfTau(r io.Reader) (*SRS, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading powers of tau: %v", err)
+	}
+	if trimmed := bytes.TrimLeft(buf, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '{' {
+		return nil, fmt.Errorf("JSON transcripts of the Ethereum KZG ceremony are over BLS12-381; only .ptau files over bn256 are supported, see kzg4844 for the ceremony's output")
+	}
+
+	sections, err := parsePtauSections(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	n8, power, err := parsePtauHeader(sections[ptauHeader])
+	if err != nil {
+		return nil, err
+	}
+	if power > 30 {
+		return nil, fmt.Errorf(".ptau power %d too large", power)
+	}
+	n := 1 << power
+
+	// tauG1 holds 2^(power+1)-1 powers, of which the first n are used, as
+	// are at most n of tauG2.
+	tauG1, tauG2 := sections[ptauTauG1], sections[ptauTauG2]
+	m := len(tauG2) / (4 * n8)
+	if m > n {
+		m = n
+	}
+	if len(tauG1) < n*2*n8 || m < 2 {
+		return nil, fmt.Errorf(".ptau of power %d has %d tauG1 and %d tauG2 bytes; want at least %d and %d", power, len(tauG1), len(tauG2), n*2*n8, 2*4*n8)
+	}
+
+	g1 := make([]*bn256.G1, n)
+	g2 := make([]*bn256.G2, m)
+	d := ptauDecoder{n8: n8, rInv: new(big.Int).ModInverse(new(big.Int).Lsh(bigOne, 8*uint(n8)), bn256.P)}
+	for i := range g1 {
+		if g1[i], err = d.g1(tauG1[i*2*n8:]); err != nil {
+			return nil, fmt.Errorf("tauG1[%d]: %v", i, err)
+		}
+	}
+	for i := range g2 {
+		if g2[i], err = d.g2(tauG2[i*4*n8:]); err != nil {
+			return nil, fmt.Errorf("tauG2[%d]: %v", i, err)
+		}
+	}
+
+	return &SRS{G1: g1, G2: g2}, nil
+}
+
+// parsePtauSections returns the contents of all sections of a .ptau file,
+// keyed by section type.
+func parsePtauSections(buf []byte) (map[uint32][]byte, error) {
+	if len(buf) < 12 || string(buf[:4]) != "ptau" {
+		return nil, fmt.Errorf("not a .ptau file")
+	}
+	nSections := binary.LittleEndian.Uint32(buf[8:])
+	buf = buf[12:]
+
+	sections := make(map[uint32][]byte)
+	for i := uint32(0); i < nSections; i++ {
+		if len(buf) < 12 {
+			return nil, fmt.Errorf(".ptau section %d: truncated header", i)
+		}
+		typ, size := binary.LittleEndian.Uint32(buf), binary.LittleEndian.Uint64(buf[4:])
+		buf = buf[12:]
+		if uint64(len(buf)) < size {
+			return nil, fmt.Errorf(".ptau section %d of type %d: %d bytes; want %d", i, typ, len(buf), size)
+		}
+		sections[typ], buf = buf[:size], buf[size:]
+	}
+
+	for _, typ := range []uint32{ptauHeader, ptauTauG1, ptauTauG2} {
+		if _, ok := sections[typ]; !ok {
+			return nil, fmt.Errorf(".ptau missing section of type %d", typ)
+		}
+	}
+	return sections, nil
+}
+
+// parsePtauHeader returns the size n8 of a base-field element and the power of
+// the ceremony, checking that the prime is that of bn256.
+func parsePtauHeader(h []byte) (n8, power int, _ error) {
+	if len(h) < 4 {
+		return 0, 0, fmt.Errorf(".ptau header truncated")
+	}
+	n8 = int(binary.LittleEndian.Uint32(h))
+	if n8 != evmWordSize || len(h) < 4+n8+4 {
+		return 0, 0, fmt.Errorf(".ptau header with %d-byte field elements; only bn256 is supported", n8)
+	}
+	if q := leInt(h[4 : 4+n8]); q.Cmp(bn256.P) != 0 {
+		return 0, 0, fmt.Errorf(".ptau over field of order %v; only bn256 is supported", q)
+	}
+	return n8, int(binary.LittleEndian.Uint32(h[4+n8:])), nil
+}
+
+// leInt returns the little-endian integer encoded by buf.
+func leInt(buf []byte) *big.Int {
+	be := make([]byte, len(buf))
+	for i, b := range buf {
+		be[len(buf)-1-i] = b
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// A ptauDecoder decodes points, whose coordinates are little-endian field
+// elements in Montgomery form x*R with R = 2^(8*n8).
+type ptauDecoder struct {
+	n8   int
+	rInv *big.Int
+}
+
+// coords decodes n consecutive coordinates into bn256's big-endian encoding.
+func (d ptauDecoder) coords(buf []byte, n int) []byte {
+	out := make([]byte, n*d.n8)
+	for i := 0; i < n; i++ {
+		x := leInt(buf[i*d.n8 : (i+1)*d.n8])
+		x.Mul(x, d.rInv).Mod(x, bn256.P)
+		x.FillBytes(out[i*d.n8 : (i+1)*d.n8])
+	}
+	return out
+}
+
+func (d ptauDecoder) g1(buf []byte) (*bn256.G1, error) {
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(d.coords(buf, 2)); err != nil {
+		return nil, fmt.Errorf("bn256.G1.Unmarshal(): %v", err)
+	}
+	return p, nil
+}
+
+func (d ptauDecoder) g2(buf []byte) (*bn256.G2, error) {
+	// .ptau stores x.c0 | x.c1 | y.c0 | y.c1, whereas bn256 expects the
+	// imaginary part first.
+	c := d.coords(buf, 4)
+	w := d.n8
+	swapped := make([]byte, 0, len(c))
+	for _, i := range []int{1, 0, 3, 2} {
+		swapped = append(swapped, c[i*w:(i+1)*w]...)
+	}
+
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(swapped); err != nil {
+		return nil, fmt.Errorf("bn256.G2.Unmarshal(): %v", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,187 @@
+package kzg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+// ptauSection returns a .ptau section of the specified type.
+func ptauSection(typ uint32, data []byte) []byte {
+	buf := make([]byte, 12, 12+len(data))
+	binary.LittleEndian.PutUint32(buf, typ)
+	binary.LittleEndian.PutUint64(buf[4:], uint64(len(data)))
+	return append(buf, data...)
+}
+
+// lem returns the little-endian Montgomery encodings of the big-endian
+// coordinates in be.
+func lem(be []byte) []byte {
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	var out []byte
+	for i := 0; i < len(be); i += 32 {
+		x := new(big.Int).SetBytes(be[i : i+32])
+		out = append(out, leBytes(x.Mul(x, r).Mod(x, bn256.P))...)
+	}
+	return out
+}
+
+// encodePtau returns a .ptau file of the specified power for the secret s, in
+// the format written by snarkjs, with the first g2Powers powers in tauG2.
+func encodePtau(s *big.Int, power, g2Powers int) []byte {
+	n := 1 << power
+	powers := polynomial.ComputePowers(s, 2*n-1, Field)
+
+	header := append(u32(32), leBytes(bn256.P)...)
+	header = append(header, u32(uint32(power))...)
+	header = append(header, u32(uint32(power))...)
+
+	var tauG1, tauG2 []byte
+	for _, x := range powers {
+		tauG1 = append(tauG1, lem(new(bn256.G1).ScalarBaseMult(x).Marshal())...)
+	}
+	for _, x := range powers[:g2Powers] {
+		// bn256 encodes the imaginary part first.
+		be := new(bn256.G2).ScalarBaseMult(x).Marshal()
+		c0c1 := append(append(append(append([]byte{}, be[32:64]...), be[:32]...), be[96:]...), be[64:96]...)
+		tauG2 = append(tauG2, lem(c0c1)...)
+	}
+
+	buf := append([]byte("ptau"), u32(1)...)
+	buf = append(buf, u32(4)...)
+	buf = append(buf, ptauSection(ptauHeader, header)...)
+	buf = append(buf, ptauSection(ptauTauG1, tauG1)...)
+	buf = append(buf, ptauSection(ptauTauG2, tauG2)...)
+	// Sections that aren't required are skipped.
+	buf = append(buf, ptauSection(7, []byte("contributions"))...)
+	return buf
+}
+
+func u32(x uint32) []byte {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, x)
+	return buf
+}
+
+// leBytes returns the 32-byte little-endian encoding of x.
+func leBytes(x *big.Int) []byte {
+	le := x.FillBytes(make([]byte, 32))
+	for j := 0; j < 16; j++ {
+		le[j], le[31-j] = le[31-j], le[j]
+	}
+	return le
+}
+
+func TestSRSFromPowersOfTau(t *testing.T) {
+	srs, err := SRSFromPowersOfTau(bytes.NewReader(encodePtau(big.NewInt(1337), 3, 8)))
+	if err != nil {
+		t.Fatalf("SRSFromPowersOfTau(): %v", err)
+	}
+	if got := srs.MaxDegree(); got != 7 {
+		t.Errorf("SRSFromPowersOfTau(<power 3>).MaxDegree() = %d; want 7", got)
+	}
+
+	want := NewSRS(big.NewInt(1337), 7)
+	for i := range want.G1 {
+		if !bytes.Equal(srs.G1[i].Marshal(), want.G1[i].Marshal()) {
+			t.Errorf("SRSFromPowersOfTau().G1[%d] != [1337^%d]_1", i, i)
+		}
+		if !bytes.Equal(srs.G2[i].Marshal(), want.G2[i].Marshal()) {
+			t.Errorf("SRSFromPowersOfTau().G2[%d] != [1337^%d]_2", i, i)
+		}
+	}
+}
+
+// TestSRSFromPowersOfTauCeremonyLayout checks the layout of the Ethereum KZG
+// ceremony, with 4096 powers in G1 but only 65 in G2.
+func TestSRSFromPowersOfTauCeremonyLayout(t *testing.T) {
+	srs, err := SRSFromPowersOfTau(bytes.NewReader(encodePtau(big.NewInt(1337), 12, 65)))
+	if err != nil {
+		t.Fatalf("SRSFromPowersOfTau(): %v", err)
+	}
+	if got, want := srs.MaxDegree(), 4095; got != want {
+		t.Errorf("SRSFromPowersOfTau().MaxDegree() = %d; want %d", got, want)
+	}
+	if got, want := len(srs.G2), 65; got != want {
+		t.Errorf("len(SRSFromPowersOfTau().G2) = %d; want %d", got, want)
+	}
+	if err := srs.Verify(); err != nil {
+		t.Errorf("Verify(): %v", err)
+	}
+	buf, err := srs.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if got, want := len(buf), 8+4096*64+65*128; got != want {
+		t.Errorf("len(MarshalBinary()) = %d; want %d", got, want)
+	}
+	reloaded := new(SRS)
+	if err := reloaded.UnmarshalBinary(buf); err != nil {
+		t.Fatalf("UnmarshalBinary(MarshalBinary()): %v", err)
+	}
+	if len(reloaded.G1) != len(srs.G1) || len(reloaded.G2) != len(srs.G2) {
+		t.Errorf("UnmarshalBinary(MarshalBinary()) has %d and %d powers; want %d and %d", len(reloaded.G1), len(reloaded.G2), len(srs.G1), len(srs.G2))
+	}
+
+	vk := srs.VerifierKey()
+	z := big.NewInt(42)
+	for _, tt := range []struct {
+		degree  int
+		wantErr bool
+	}{
+		{degree: 65, wantErr: false},
+		{degree: 66, wantErr: true},
+	} {
+		p := polynomial.NewPolynomial(polynomial.ComputePowers(big.NewInt(3), tt.degree+1, Field))
+		c, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(<degree %d>): %v", tt.degree, err)
+		}
+		proof, err := srs.Open(p, z)
+		if gotErr := err != nil; gotErr != tt.wantErr {
+			t.Errorf("Open(<degree %d>) error %v; want error %t", tt.degree, err, tt.wantErr)
+		}
+		if err == nil && !vk.Verify(c, proof) {
+			t.Errorf("Verify(Open(<degree %d>)) = false; want true", tt.degree)
+		}
+	}
+}
+
+func TestSRSFromPowersOfTauErrors(t *testing.T) {
+	valid := encodePtau(big.NewInt(1337), 1, 2)
+
+	wrongField := append([]byte{}, valid...)
+	// The first byte of q in the header section.
+	wrongField[12+12+4] ^= 1
+
+	notOnCurve := append([]byte{}, valid...)
+	// The last byte of tauG1[0].y, following the header section of 44 bytes.
+	notOnCurve[12+12+44+12+63] ^= 1
+
+	tests := []struct {
+		name    string
+		buf     []byte
+		wantErr string
+	}{
+		{name: "empty", buf: nil, wantErr: "not a .ptau file"},
+		{name: "Ethereum KZG ceremony", buf: []byte(` {"transcripts": []}`), wantErr: "BLS12-381"},
+		{name: "truncated", buf: valid[:len(valid)-20], wantErr: "section 3"},
+		{name: "wrong field", buf: wrongField, wantErr: "only bn256 is supported"},
+		{name: "not on curve", buf: notOnCurve, wantErr: "tauG1[0]"},
+		{name: "single power in G2", buf: encodePtau(big.NewInt(1337), 1, 1), wantErr: "tauG2 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SRSFromPowersOfTau(bytes.NewReader(tt.buf))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("SRSFromPowersOfTau(): got err %v; want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+// Package solgen generates Solidity contracts verifying KZG proofs on-chain
+// with the bn256 precompiles of EIP-196 and EIP-197.
+package solgen
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"text/template"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+)
+
+// Config configures the generated contract.
+type Config struct {
+	// ContractName is the name of the contract, defaulting to KZGVerifier.
+	ContractName string
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// Generate writes a Solidity contract that verifies proofs against the
+// VerifierKey, as VerifierKey.Verify does. The contract exposes
+//
+//	verify(uint256[2] commitment, uint256 z, uint256 y, uint256[4] quotient)
+//	verifyEncoded(uint256[2] commitment, bytes proof)
+//	verifyMembership(uint256[2] commitment, uint256 member, uint256[4] quotient)
+//
+// where commitment is the affine encoding of a commitment, quotient is the
+// encoding of kzg.Proof.Quotient as in kzg.Proof.MarshalEVM, and proof is the
+// entire output of MarshalEVM. verifyMembership checks a proof that the
+// committed polynomial evaluates to 0 at member, as with the membership
+// package.
+func Generate(w io.Writer, vk *kzg.VerifierKey, cfg Config) error {
+	if cfg.ContractName == "" {
+		cfg.ContractName = "KZGVerifier"
+	}
+	if !identifier.MatchString(cfg.ContractName) {
+		return fmt.Errorf("invalid contract name %q", cfg.ContractName)
+	}
+
+	s1 := words(vk.S1.Marshal())
+	g2 := words(new(bn256.G2).ScalarBaseMult(big.NewInt(1)).Marshal())
+	return contract.Execute(w, struct {
+		Name       string
+		S1         []string
+		G2         []string
+		FieldOrder string
+		GroupOrder string
+		ProofSize  int
+	}{
+		Name:       cfg.ContractName,
+		S1:         s1,
+		G2:         g2,
+		FieldOrder: bn256.P.String(),
+		GroupOrder: bn256.Order.String(),
+		ProofSize:  kzg.EVMProofSize,
+	})
+}
+
+// words returns the 32-byte words of buf as hex literals.
+func words(buf []byte) []string {
+	var ws []string
+	for i := 0; i < len(buf); i += 32 {
+		ws = append(ws, fmt.Sprintf("0x%x", buf[i:i+32]))
+	}
+	return ws
+}
+
+var contract = template.Must(template.New("contract").Parse(`// SPDX-License-Identifier: MIT
+// Code generated by zkp.xyz/membership/kzg/solgen. DO NOT EDIT.
+pragma solidity ^0.8.0;
+
+/// @notice Verifies KZG proofs that a committed polynomial p evaluates to
+/// p(z) = y, by checking e([s - z]_1, [q(s)]_2) == e([p(s) - y]_1, [1]_2).
+contract {{.Name}} {
+    /// @dev Order of the base field of bn256.
+    uint256 internal constant P = {{.FieldOrder}};
+    /// @dev Order of the groups of bn256, i.e. of the scalar field.
+    uint256 internal constant R = {{.GroupOrder}};
+
+    /// @dev [s]_1
+    uint256 internal constant S1_X = {{index .S1 0}};
+    uint256 internal constant S1_Y = {{index .S1 1}};
+
+    /// @dev [1]_2, with the imaginary part of each coordinate first.
+    uint256 internal constant G2_X_C1 = {{index .G2 0}};
+    uint256 internal constant G2_X_C0 = {{index .G2 1}};
+    uint256 internal constant G2_Y_C1 = {{index .G2 2}};
+    uint256 internal constant G2_Y_C0 = {{index .G2 3}};
+
+    function verify(
+        uint256[2] calldata commitment,
+        uint256 z,
+        uint256 y,
+        uint256[4] memory quotient
+    ) public view returns (bool) {
+        require(z < R && y < R, "scalar out of range");
+
+        // [s - z]_1
+        (uint256 ax, uint256 ay) = ecMul(1, 2, (R - z) % R);
+        (ax, ay) = ecAdd(S1_X, S1_Y, ax, ay);
+
+        // (-1) * [p(s) - y]_1, moving the right-hand side to the left.
+        (uint256 bx, uint256 by) = ecMul(1, 2, (R - y) % R);
+        (bx, by) = ecAdd(commitment[0], commitment[1], bx, by);
+        by = (P - by) % P;
+
+        uint256[12] memory input = [
+            ax, ay, quotient[0], quotient[1], quotient[2], quotient[3],
+            bx, by, G2_X_C1, G2_X_C0, G2_Y_C1, G2_Y_C0
+        ];
+        uint256[1] memory out;
+        bool ok;
+        assembly {
+            ok := staticcall(gas(), 0x08, input, 0x180, out, 0x20)
+        }
+        return ok && out[0] == 1;
+    }
+
+    /// @param proof The {{.ProofSize}}-byte encoding z | y | quotient.
+    function verifyEncoded(uint256[2] calldata commitment, bytes calldata proof) external view returns (bool) {
+        require(proof.length == {{.ProofSize}}, "invalid proof length");
+        (uint256 z, uint256 y, uint256[4] memory quotient) = abi.decode(proof, (uint256, uint256, uint256[4]));
+        return verify(commitment, z, y, quotient);
+    }
+
+    function verifyMembership(
+        uint256[2] calldata commitment,
+        uint256 member,
+        uint256[4] calldata quotient
+    ) external view returns (bool) {
+        return verify(commitment, member % R, 0, quotient);
+    }
+
+    function ecAdd(uint256 ax, uint256 ay, uint256 bx, uint256 by) internal view returns (uint256, uint256) {
+        uint256[4] memory input = [ax, ay, bx, by];
+        uint256[2] memory out;
+        bool ok;
+        assembly {
+            ok := staticcall(gas(), 0x06, input, 0x80, out, 0x40)
+        }
+        require(ok, "ecAdd failed");
+        return (out[0], out[1]);
+    }
+
+    function ecMul(uint256 x, uint256 y, uint256 scalar) internal view returns (uint256, uint256) {
+        uint256[3] memory input = [x, y, scalar];
+        uint256[2] memory out;
+        bool ok;
+        assembly {
+            ok := staticcall(gas(), 0x07, input, 0x60, out, 0x40)
+        }
+        require(ok, "ecMul failed");
+        return (out[0], out[1]);
+    }
+}
+`))
@@ -0,0 +1,135 @@
+package solgen
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// constants returns the uint256 constants declared in the contract.
+func constants(t *testing.T, src string) map[string]*big.Int {
+	t.Helper()
+	cs := make(map[string]*big.Int)
+	for _, m := range regexp.MustCompile(`uint256 internal constant (\w+) = (\w+);`).FindAllStringSubmatch(src, -1) {
+		v, ok := new(big.Int).SetString(m[2], 0)
+		if !ok {
+			t.Fatalf("invalid constant %s = %s", m[1], m[2])
+		}
+		cs[m[1]] = v
+	}
+	return cs
+}
+
+// precompile runs the bn256 precompile at the specified address on the words.
+func precompile(t *testing.T, addr byte, words ...*big.Int) []byte {
+	t.Helper()
+	var input []byte
+	for _, w := range words {
+		input = append(input, common.BigToHash(w).Bytes()...)
+	}
+	out, _, err := vm.RunPrecompiledContract(vm.PrecompiledContractsIstanbul[common.BytesToAddress([]byte{addr})], input, 1e6)
+	if err != nil {
+		t.Fatalf("precompile %#x: %v", addr, err)
+	}
+	return out
+}
+
+func point(out []byte) (*big.Int, *big.Int) {
+	return new(big.Int).SetBytes(out[:32]), new(big.Int).SetBytes(out[32:64])
+}
+
+// simulate mirrors the contract's verify() with the precompiles, using the
+// constants of the generated source.
+func simulate(t *testing.T, cs map[string]*big.Int, c *bn256.G1, proof *kzg.Proof) bool {
+	t.Helper()
+	P, R := cs["P"], cs["R"]
+	neg := func(x *big.Int) *big.Int { return new(big.Int).Mod(new(big.Int).Sub(R, x), R) }
+	one, two := big.NewInt(1), big.NewInt(2)
+
+	ax, ay := point(precompile(t, 7, one, two, neg(proof.Z)))
+	ax, ay = point(precompile(t, 6, cs["S1_X"], cs["S1_Y"], ax, ay))
+
+	cx, cy := point(c.Marshal())
+	bx, by := point(precompile(t, 7, one, two, neg(proof.Y)))
+	bx, by = point(precompile(t, 6, cx, cy, bx, by))
+	by = new(big.Int).Mod(new(big.Int).Sub(P, by), P)
+
+	q := proof.Quotient.Marshal()
+	out := precompile(t, 8,
+		ax, ay,
+		new(big.Int).SetBytes(q[:32]), new(big.Int).SetBytes(q[32:64]), new(big.Int).SetBytes(q[64:96]), new(big.Int).SetBytes(q[96:]),
+		bx, by,
+		cs["G2_X_C1"], cs["G2_X_C0"], cs["G2_Y_C1"], cs["G2_Y_C0"],
+	)
+	return new(big.Int).SetBytes(out).Cmp(one) == 0
+}
+
+func TestGenerate(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 4)
+	var buf bytes.Buffer
+	if err := Generate(&buf, srs.VerifierKey(), Config{ContractName: "SetVerifier"}); err != nil {
+		t.Fatalf("Generate(): %v", err)
+	}
+	src := buf.String()
+
+	for _, want := range []string{"contract SetVerifier {", "function verify(", "function verifyEncoded(", "function verifyMembership("} {
+		if !strings.Contains(src, want) {
+			t.Errorf("Generate() output missing %q", want)
+		}
+	}
+
+	cs := constants(t, src)
+	for name, want := range map[string]*big.Int{"P": bn256.P, "R": bn256.Order} {
+		if cs[name].Cmp(want) != 0 {
+			t.Errorf("constant %s = %v; want %v", name, cs[name], want)
+		}
+	}
+
+	p := polynomial.FromRoots([]*big.Int{big.NewInt(3), big.NewInt(42)}, kzg.Field)
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	for _, z := range []int64{3, 42, 5} {
+		t.Run(fmt.Sprintf("z=%d", z), func(t *testing.T) {
+			proof, err := srs.Open(p, big.NewInt(z))
+			if err != nil {
+				t.Fatalf("Open(): %v", err)
+			}
+			if !simulate(t, cs, c, proof) {
+				t.Errorf("verify(Open()) = false; want true")
+			}
+
+			wrong := *proof
+			wrong.Y = kzg.Field.Add(proof.Y, big.NewInt(1))
+			if simulate(t, cs, c, &wrong) {
+				t.Errorf("verify(<wrong y>) = true; want false")
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidName(t *testing.T) {
+	vk := kzg.NewSRS(big.NewInt(1337), 1).VerifierKey()
+	if err := Generate(new(bytes.Buffer), vk, Config{ContractName: "not valid"}); err == nil {
+		t.Errorf("Generate(<invalid contract name>): got nil error")
+	}
+
+	var buf bytes.Buffer
+	if err := Generate(&buf, vk, Config{}); err != nil {
+		t.Fatalf("Generate(): %v", err)
+	}
+	if !strings.Contains(buf.String(), "contract KZGVerifier {") {
+		t.Errorf("Generate(<default config>) doesn't declare KZGVerifier")
+	}
+}
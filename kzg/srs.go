@@ -0,0 +1,216 @@
+package kzg
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+)
+
+var (
+	// Field is the scalar field of bn256, over which all committed polynomials
+	// are defined.
+	Field = curve.BN256.ScalarField()
+
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+)
+
+// An SRS is a structured reference string, holding the hidden powers of a
+// secret s on both curves: G1[i] = [s^i]_1 for i in [0, MaxDegree()] and
+// G2[i] = [s^i]_2 for i < len(G2).
+//
+// G2 may hold fewer powers than G1, at least two, as does the Ethereum KZG
+// ceremony with 4096 powers in G1 but 65 in G2. MaxDegree, commitments and
+// the VerifierKey only depend on G1 and G2[1], but witnesses on G2, such as
+// the quotients of proofs, are limited to degree len(G2)-1, so only
+// polynomials of degree up to len(G2) can be opened.
+//
+// A hiding SRS, as returned by NewHidingSRS, additionally holds the powers of
+// s for a second generator h = [a]_1 of unknown discrete logarithm a, with
+// H1[i] = [a*s^i]_1 and H2[i] = [a*s^i]_2, enabling CommitHiding. H1 and H2
+// are nil otherwise.
+type SRS struct {
+	G1 []*bn256.G1
+	G2 []*bn256.G2
+	H1 []*bn256.G1
+	H2 []*bn256.G2
+}
+
+// NewSRS returns an SRS for the secret s, supporting polynomials of degree up
+// to maxDegree. Knowledge of s allows forging of proofs, so it MUST be
+// discarded after setup.
+func NewSRS(s *big.Int, maxDegree int) *SRS {
+	ss := polynomial.ComputePowers(s, maxDegree+1, Field)
+	srs := &SRS{
+		G1: make([]*bn256.G1, len(ss)),
+		G2: make([]*bn256.G2, len(ss)),
+	}
+	for i, v := range ss {
+		srs.G1[i] = new(bn256.G1).ScalarBaseMult(v)
+		srs.G2[i] = new(bn256.G2).ScalarBaseMult(v)
+	}
+	return srs
+}
+
+// MaxDegree returns the maximum degree of polynomials that can be committed to
+// with the SRS.
+func (srs *SRS) MaxDegree() int {
+	return len(srs.G1) - 1
+}
+
+const (
+	g1Size = 2 * evmWordSize
+	g2Size = 4 * evmWordSize
+)
+
+// MarshalBinary encodes the SRS as the number of powers n = MaxDegree()+1, as a
+// big-endian uint32, followed by the n points of G1 and then the points of G2
+// in their uncompressed encodings, as returned by bn256.G1.Marshal and
+// bn256.G2.Marshal respectively. If G2 holds fewer powers m < n, the top bit
+// of the header is set and m follows as another big-endian uint32, so SRSs
+// with equal counts keep their encoding. For a hiding SRS, which requires
+// equal counts, the n points of H1 and then H2 follow in the same encodings.
+func (srs *SRS) MarshalBinary() ([]byte, error) {
+	n, m := len(srs.G1), len(srs.G2)
+	if n == 0 || m == 0 || m > n || n >= shortG2 {
+		return nil, fmt.Errorf("SRS with %d powers in G1 and %d in G2 can't be encoded", n, m)
+	}
+	if srs.IsHiding() && (m != n || len(srs.H1) != n || len(srs.H2) != n) {
+		return nil, fmt.Errorf("len(SRS.G2), len(SRS.H1), len(SRS.H2) != len(SRS.G1): %d, %d, %d != %d", m, len(srs.H1), len(srs.H2), n)
+	}
+
+	header := []byte{0, 0, 0, 0}
+	binary.BigEndian.PutUint32(header, uint32(n))
+	if m < n {
+		header[0] |= 0x80
+		header = append(header, 0, 0, 0, 0)
+		binary.BigEndian.PutUint32(header[4:], uint32(m))
+	}
+	size := n*g1Size + m*g2Size
+	if srs.IsHiding() {
+		size *= 2
+	}
+	buf := make([]byte, 0, len(header)+size)
+	buf = append(buf, header...)
+	buf = appendPoints(buf, srs.G1)
+	buf = appendPoints(buf, srs.G2)
+	buf = appendPoints(buf, srs.H1)
+	buf = appendPoints(buf, srs.H2)
+	return buf, nil
+}
+
+// shortG2 is the header bit marking an encoding with fewer powers in G2.
+const shortG2 = 1 << 31
+
+func appendPoints[P interface{ Marshal() []byte }](buf []byte, ps []P) []byte {
+	for _, p := range ps {
+		buf = append(buf, p.Marshal()...)
+	}
+	return buf
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary. It returns an error if buf
+// is malformed or any point isn't on its curve, but doesn't check that the
+// points are powers of the same secret; see Verify.
+func (srs *SRS) UnmarshalBinary(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("SRS encoding of %d bytes too short for header", len(buf))
+	}
+	header := binary.BigEndian.Uint32(buf)
+	buf = buf[4:]
+	n, m := int(header&^shortG2), int(header&^shortG2)
+	if header&shortG2 != 0 {
+		if len(buf) < 4 {
+			return fmt.Errorf("SRS encoding too short for count of powers in G2")
+		}
+		m = int(binary.BigEndian.Uint32(buf))
+		buf = buf[4:]
+		if m == 0 || m >= n {
+			return fmt.Errorf("SRS encoding of %d powers in G1 has %d in G2; want fewer, at least one", n, m)
+		}
+	}
+	section := n*g1Size + m*g2Size
+	if n == 0 || (len(buf) != section && (m != n || len(buf) != 2*section)) {
+		return fmt.Errorf("SRS encoding of %d powers in G1 and %d in G2 has %d bytes; want %d", n, m, len(buf), section)
+	}
+
+	var (
+		s   SRS
+		err error
+	)
+	if s.G1, buf, err = unmarshalPoints[bn256.G1](buf, n, g1Size); err != nil {
+		return fmt.Errorf("bn256.G1.Unmarshal() of %v", err)
+	}
+	if s.G2, buf, err = unmarshalPoints[bn256.G2](buf, m, g2Size); err != nil {
+		return fmt.Errorf("bn256.G2.Unmarshal() of %v", err)
+	}
+	if len(buf) > 0 {
+		if s.H1, buf, err = unmarshalPoints[bn256.G1](buf, n, g1Size); err != nil {
+			return fmt.Errorf("bn256.G1.Unmarshal() of hiding %v", err)
+		}
+		if s.H2, _, err = unmarshalPoints[bn256.G2](buf, n, g2Size); err != nil {
+			return fmt.Errorf("bn256.G2.Unmarshal() of hiding %v", err)
+		}
+	}
+
+	*srs = s
+	return nil
+}
+
+// unmarshalPoints decodes n points of the given encoded size from the start of
+// buf, returning them and the rest of buf.
+func unmarshalPoints[E any, P interface {
+	*E
+	Unmarshal([]byte) ([]byte, error)
+}](buf []byte, n, size int) ([]P, []byte, error) {
+	ps := make([]P, n)
+	for i := range ps {
+		ps[i] = new(E)
+		if _, err := ps[i].Unmarshal(buf[i*size : (i+1)*size]); err != nil {
+			return nil, nil, fmt.Errorf("power %d: %v", i, err)
+		}
+	}
+	return ps, buf[n*size:], nil
+}
+
+// trim returns p with its coefficients reduced into the Field and without
+// coefficients beyond its degree, checking that the degree is supported by the
+// SRS. Reduction is required as msm.MultiExp doesn't support negative scalars.
+func (srs *SRS) trim(p *polynomial.Polynomial) (*polynomial.Polynomial, error) {
+	d := p.Degree()
+	if d > srs.MaxDegree() {
+		return nil, fmt.Errorf("polynomial degree %d exceeds SRS max degree %d", d, srs.MaxDegree())
+	}
+	cs := make([]*big.Int, d+1)
+	for i := range cs {
+		cs[i] = new(big.Int).Mod((*p)[i], bn256.Order)
+	}
+	return polynomial.NewPolynomial(cs), nil
+}
+
+// Commit returns the commitment [p(s)]_1 to p.
+func (srs *SRS) Commit(p *polynomial.Polynomial) (*bn256.G1, error) {
+	p, err := srs.trim(p)
+	if err != nil {
+		return nil, err
+	}
+	return msm.MultiExp(srs.G1[:len(*p)], *p)
+}
+
+// CommitG2 returns [p(s)]_2, committing to p on G2 as required for quotients
+// and other witnesses paired with commitments on G1.
+func (srs *SRS) CommitG2(p *polynomial.Polynomial) (*bn256.G2, error) {
+	p, err := srs.trim(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(*p) > len(srs.G2) {
+		return nil, fmt.Errorf("polynomial degree %d exceeds SRS max G2 degree %d", p.Degree(), len(srs.G2)-1)
+	}
+	return msm.MultiExp(srs.G2[:len(*p)], *p)
+}
@@ -0,0 +1,106 @@
+package kzg
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestCommitExceedsSRS(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 2)
+
+	// Trailing zero coefficients don't count towards the degree.
+	if _, err := srs.Commit(polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 0, 0})); err != nil {
+		t.Errorf("Commit(<degree 2 with trailing zeros>): %v", err)
+	}
+
+	p := polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 4})
+	_, err := srs.Commit(p)
+	if want := "polynomial degree 3 exceeds SRS max degree 2"; err == nil || err.Error() != want {
+		t.Errorf("Commit(<degree 3>) with SRS of max degree 2: got err %v; want %q", err, want)
+	}
+}
+
+func TestSRSMarshalBinary(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 5)
+	buf, err := srs.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if got, want := len(buf), 4+6*(64+128); got != want {
+		t.Errorf("len(MarshalBinary()) = %d; want %d", got, want)
+	}
+
+	got := new(SRS)
+	if err := got.UnmarshalBinary(buf); err != nil {
+		t.Fatalf("UnmarshalBinary(MarshalBinary()): %v", err)
+	}
+	if got.MaxDegree() != srs.MaxDegree() {
+		t.Fatalf("UnmarshalBinary(MarshalBinary()).MaxDegree() = %d; want %d", got.MaxDegree(), srs.MaxDegree())
+	}
+	for i := range srs.G1 {
+		if !bytes.Equal(got.G1[i].Marshal(), srs.G1[i].Marshal()) || !bytes.Equal(got.G2[i].Marshal(), srs.G2[i].Marshal()) {
+			t.Errorf("UnmarshalBinary(MarshalBinary()) power %d differs", i)
+		}
+	}
+
+	// The reloaded SRS is usable for proving and verification.
+	p := polynomial.NewPolynomialFromCoefficients([]int64{2, -3, 1, 5})
+	c, err := got.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	proof, err := got.Open(p, big.NewInt(7))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	if !srs.VerifierKey().Verify(c, proof) {
+		t.Errorf("Verify() of proof from reloaded SRS = false; want true")
+	}
+}
+
+func TestSRSUnmarshalBinaryErrors(t *testing.T) {
+	buf, err := NewSRS(big.NewInt(1337), 2).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+
+	offCurve := append([]byte{}, buf...)
+	offCurve[4+63] ^= 1 // y coordinate of [1]_1
+
+	short := &SRS{G1: NewSRS(big.NewInt(1337), 2).G1, G2: NewSRS(big.NewInt(1337), 1).G2}
+	shortBuf, err := short.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() with fewer powers in G2: %v", err)
+	}
+	equalG2 := append([]byte{}, shortBuf...)
+	equalG2[7] = 3
+
+	tests := []struct {
+		name string
+		buf  []byte
+	}{
+		{name: "empty", buf: nil},
+		{name: "zero powers", buf: []byte{0, 0, 0, 0}},
+		{name: "truncated", buf: buf[:len(buf)-1]},
+		{name: "trailing byte", buf: append(append([]byte{}, buf...), 0)},
+		{name: "point not on curve", buf: offCurve},
+		{name: "truncated G2 count", buf: shortBuf[:6]},
+		{name: "truncated fewer powers in G2", buf: shortBuf[:len(shortBuf)-1]},
+		{name: "flagged G2 count not fewer", buf: equalG2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srs := new(SRS)
+			if err := srs.UnmarshalBinary(tt.buf); err == nil {
+				t.Errorf("UnmarshalBinary(): got nil error")
+			}
+			if srs.G1 != nil || srs.G2 != nil {
+				t.Errorf("UnmarshalBinary() modified SRS despite error")
+			}
+		})
+	}
+}
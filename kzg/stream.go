@@ -0,0 +1,109 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/msm"
+)
+
+// A StreamingCommitter computes a commitment from values received
+// incrementally, e.g. read from a file or the network, folding each chunk into
+// a running multi-exponentiation so that only the current chunk is held in
+// memory. The values are the coefficients of the committed polynomial, or its
+// evaluations over a Domain for a committer returned by a LagrangeSRS.
+//
+// A StreamingCommitter is not safe for concurrent use.
+type StreamingCommitter struct {
+	bases   []*bn256.G1
+	n       int
+	sum     *bn256.G1
+	partial []byte // incomplete word of Write
+}
+
+// NewStreamingCommitter returns a StreamingCommitter of coefficients; the
+// resulting commitment equals srs.Commit() of the polynomial they define.
+func (srs *SRS) NewStreamingCommitter() *StreamingCommitter {
+	return newStreamingCommitter(srs.G1)
+}
+
+// NewStreamingCommitter returns a StreamingCommitter of evaluations over the
+// Domain; the resulting commitment equals l.CommitLagrange() of them.
+func (l *LagrangeSRS) NewStreamingCommitter() *StreamingCommitter {
+	return newStreamingCommitter(l.G1)
+}
+
+func newStreamingCommitter(bases []*bn256.G1) *StreamingCommitter {
+	return &StreamingCommitter{
+		bases: bases,
+		sum:   new(bn256.G1).ScalarBaseMult(bigZero),
+	}
+}
+
+// Add folds the next values, reduced into the Field, into the commitment. It
+// returns an error, without folding any of them, if the total number of
+// values would exceed the number of powers of the SRS, or the size of the
+// Domain.
+func (sc *StreamingCommitter) Add(values ...*big.Int) error {
+	if len(values) == 0 {
+		return nil
+	}
+	if sc.n+len(values) > len(sc.bases) {
+		return fmt.Errorf("%d values exceed the %d powers of the SRS", sc.n+len(values), len(sc.bases))
+	}
+	p, err := msm.MultiExp(sc.bases[sc.n:sc.n+len(values)], reduceAll(values))
+	if err != nil {
+		return err
+	}
+	sc.sum.Add(sc.sum, p)
+	sc.n += len(values)
+	return nil
+}
+
+// Write implements io.Writer, folding buf as a sequence of 32-byte big-endian
+// values into the commitment. Values may be split across calls to Write.
+func (sc *StreamingCommitter) Write(buf []byte) (int, error) {
+	written := len(buf)
+	if len(sc.partial) > 0 {
+		k := evmWordSize - len(sc.partial)
+		if k > len(buf) {
+			k = len(buf)
+		}
+		sc.partial = append(sc.partial, buf[:k]...)
+		buf = buf[k:]
+		if len(sc.partial) < evmWordSize {
+			return written, nil
+		}
+		if err := sc.Add(new(big.Int).SetBytes(sc.partial)); err != nil {
+			sc.partial = sc.partial[:len(sc.partial)-k]
+			return 0, err
+		}
+		sc.partial = sc.partial[:0]
+	}
+
+	values := make([]*big.Int, len(buf)/evmWordSize)
+	for i := range values {
+		values[i] = new(big.Int).SetBytes(buf[i*evmWordSize : (i+1)*evmWordSize])
+	}
+	if err := sc.Add(values...); err != nil {
+		return written - len(buf), err
+	}
+	sc.partial = append(sc.partial, buf[len(values)*evmWordSize:]...)
+	return written, nil
+}
+
+// Len returns the number of values folded into the commitment.
+func (sc *StreamingCommitter) Len() int {
+	return sc.n
+}
+
+// Commitment returns the commitment to the values added so far, with any
+// remaining values treated as zero. It returns an error if Write received an
+// incomplete value.
+func (sc *StreamingCommitter) Commitment() (*bn256.G1, error) {
+	if len(sc.partial) > 0 {
+		return nil, fmt.Errorf("incomplete value of %d bytes; want %d", len(sc.partial), evmWordSize)
+	}
+	return new(bn256.G1).Set(sc.sum), nil
+}
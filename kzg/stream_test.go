@@ -0,0 +1,128 @@
+package kzg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestStreamingCommitter(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 15)
+	p, err := polynomial.Random(15, Field, rand.Reader)
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+	want, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	for _, chunk := range []int{1, 3, 16} {
+		sc := srs.NewStreamingCommitter()
+		for i := 0; i < len(*p); i += chunk {
+			j := i + chunk
+			if j > len(*p) {
+				j = len(*p)
+			}
+			if err := sc.Add((*p)[i:j]...); err != nil {
+				t.Fatalf("Add(<values %d to %d>): %v", i, j, err)
+			}
+		}
+		if got := sc.Len(); got != len(*p) {
+			t.Errorf("Len() = %d; want %d", got, len(*p))
+		}
+		got, err := sc.Commitment()
+		if err != nil {
+			t.Fatalf("Commitment(): %v", err)
+		}
+		if !bytes.Equal(got.Marshal(), want.Marshal()) {
+			t.Errorf("Commitment() after Add() in chunks of %d != Commit()", chunk)
+		}
+	}
+
+	if err := srs.NewStreamingCommitter().Add(make([]*big.Int, 17)...); err == nil {
+		t.Errorf("Add(<17 values>) with SRS of max degree 15: got nil error")
+	}
+}
+
+func TestStreamingCommitterWrite(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 7)
+	p, err := polynomial.Random(7, Field, rand.Reader)
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+	want, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	var buf []byte
+	for _, c := range *p {
+		buf = append(buf, c.FillBytes(make([]byte, evmWordSize))...)
+	}
+
+	for _, chunk := range []int{1, 7, 32, 45, len(buf)} {
+		sc := srs.NewStreamingCommitter()
+		for i := 0; i < len(buf); i += chunk {
+			j := i + chunk
+			if j > len(buf) {
+				j = len(buf)
+			}
+			if n, err := sc.Write(buf[i:j]); err != nil || n != j-i {
+				t.Fatalf("Write(<bytes %d to %d>) = %d, %v; want %d, nil", i, j, n, err, j-i)
+			}
+		}
+		got, err := sc.Commitment()
+		if err != nil {
+			t.Fatalf("Commitment(): %v", err)
+		}
+		if !bytes.Equal(got.Marshal(), want.Marshal()) {
+			t.Errorf("Commitment() after Write() in chunks of %d != Commit()", chunk)
+		}
+	}
+
+	sc := srs.NewStreamingCommitter()
+	if _, err := sc.Write(buf[:40]); err != nil {
+		t.Fatalf("Write(<40 bytes>): %v", err)
+	}
+	if _, err := sc.Commitment(); err == nil {
+		t.Errorf("Commitment() after Write(<40 bytes>): got nil error")
+	}
+	if n, err := sc.Write(make([]byte, len(buf))); err == nil || n > len(buf)-40 {
+		t.Errorf("Write(<more values than the SRS>) = %d, %v; want at most %d, non-nil error", n, err, len(buf)-40)
+	}
+}
+
+func TestStreamingCommitterLagrange(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 8)
+	d, err := polynomial.NewDomain(Field, 8, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	l, err := srs.Lagrange(d)
+	if err != nil {
+		t.Fatalf("Lagrange(): %v", err)
+	}
+	evals := []*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(4), big.NewInt(1), big.NewInt(5)}
+	want, err := l.CommitLagrange(evals)
+	if err != nil {
+		t.Fatalf("CommitLagrange(): %v", err)
+	}
+
+	sc := l.NewStreamingCommitter()
+	if err := sc.Add(evals[:2]...); err != nil {
+		t.Fatalf("Add(): %v", err)
+	}
+	if err := sc.Add(evals[2:]...); err != nil {
+		t.Fatalf("Add(): %v", err)
+	}
+	got, err := sc.Commitment()
+	if err != nil {
+		t.Fatalf("Commitment(): %v", err)
+	}
+	if !bytes.Equal(got.Marshal(), want.Marshal()) {
+		t.Errorf("Commitment() != CommitLagrange()")
+	}
+}
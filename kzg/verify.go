@@ -0,0 +1,78 @@
+package kzg
+
+import (
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// A VerifierKey holds the parts of an SRS required for verification. It is
+// independent of the maximum degree of the SRS from which it was derived, so
+// proofs from any SRS with the same secret s are verifiable.
+type VerifierKey struct {
+	S1 *bn256.G1 // [s]_1
+	S2 *bn256.G2 // [s]_2
+	// SS1 is [s^2]_1, only required for verification of derivatives. It is
+	// nil if derived from an SRS of maximum degree 1.
+	SS1 *bn256.G1
+	// H is the second generator [a]_1 of a hiding SRS, only required for
+	// verification of hiding commitments. It is nil otherwise.
+	H *bn256.G1
+}
+
+// VerifierKey returns the VerifierKey for the SRS, which MUST have a maximum
+// degree of at least 1.
+func (srs *SRS) VerifierKey() *VerifierKey {
+	vk := &VerifierKey{S1: srs.G1[1], S2: srs.G2[1]}
+	if srs.MaxDegree() >= 2 {
+		vk.SS1 = srs.G1[2]
+	}
+	if srs.IsHiding() {
+		vk.H = srs.H1[0]
+	}
+	return vk
+}
+
+// Verify reports whether the proof is valid for the commitment c, i.e. whether
+// the committed polynomial p satisfies p(proof.Z) = proof.Y, by checking
+//
+//	e([s - z]_1, [q(s)]_2) == e([p(s) - y]_1, [1]_2).
+func (vk *VerifierKey) Verify(c *bn256.G1, proof *Proof) bool {
+	nz1 := new(bn256.G1).Neg(new(bn256.G1).ScalarBaseMult(proof.Z))
+	ny1 := new(bn256.G1).Neg(new(bn256.G1).ScalarBaseMult(proof.Y))
+
+	return bn256.PairingCheck(
+		[]*bn256.G1{
+			// [s - z]_1
+			new(bn256.G1).Add(vk.S1, nz1),
+			// (-1) * [p(s) - y]_1, moving the right-hand side to the left.
+			new(bn256.G1).Neg(new(bn256.G1).Add(c, ny1)),
+		},
+		[]*bn256.G2{
+			proof.Quotient,
+			new(bn256.G2).ScalarBaseMult(big.NewInt(1)),
+		},
+	)
+}
+
+// VerifyWithG1Quotient is equivalent to Verify, but with the quotient [q(s)]_1
+// committed to on G1 instead, by checking
+//
+//	e([q(s)]_1, [s - z]_2) == e([p(s) - y]_1, [1]_2).
+func (vk *VerifierKey) VerifyWithG1Quotient(c *bn256.G1, z, y *big.Int, quotient *bn256.G1) bool {
+	nz2 := new(bn256.G2).Neg(new(bn256.G2).ScalarBaseMult(new(big.Int).Mod(z, bn256.Order)))
+	ny1 := new(bn256.G1).Neg(new(bn256.G1).ScalarBaseMult(y))
+
+	return bn256.PairingCheck(
+		[]*bn256.G1{
+			quotient,
+			// (-1) * [p(s) - y]_1
+			new(bn256.G1).Neg(new(bn256.G1).Add(c, ny1)),
+		},
+		[]*bn256.G2{
+			// [s - z]_2
+			new(bn256.G2).Add(vk.S2, nz2),
+			new(bn256.G2).ScalarBaseMult(big.NewInt(1)),
+		},
+	)
+}
@@ -0,0 +1,113 @@
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+func TestOpenVerify(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	tests := []struct {
+		c []int64
+		z int64
+	}{
+		{c: []int64{42}, z: 0},
+		{c: []int64{2, -3, 1}, z: 1},
+		{c: []int64{2, -3, 1}, z: 5},
+		{c: []int64{6, -5, 1, 0, 0, 7}, z: -1337},
+	}
+
+	for _, tt := range tests {
+		p := polynomial.NewPolynomialFromCoefficients(tt.c)
+		c, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(%v): %v", tt.c, err)
+		}
+
+		proof, err := srs.Open(p, big.NewInt(tt.z))
+		if err != nil {
+			t.Fatalf("Open(%v, %d): %v", tt.c, tt.z, err)
+		}
+		if want := p.Evaluate(big.NewInt(tt.z), Field); proof.Y.Cmp(want) != 0 {
+			t.Errorf("Open(%v, %d).Y = %v; want %v", tt.c, tt.z, proof.Y, want)
+		}
+		if !vk.Verify(c, proof) {
+			t.Errorf("Verify(Commit(%v), Open(%v, %d)) = false; want true", tt.c, tt.c, tt.z)
+		}
+
+		wrong := *proof
+		wrong.Y = new(big.Int).Add(proof.Y, big.NewInt(1))
+		if vk.Verify(c, &wrong) {
+			t.Errorf("Verify(Commit(%v), <wrong y>) = true; want false", tt.c)
+		}
+	}
+}
+
+func TestVerifierKeyDegreeAgnostic(t *testing.T) {
+	// A shared secret emulates two setups from the same ceremony.
+	tau := big.NewInt(987654321)
+	prover := NewSRS(tau, 100)
+	verifier := NewSRS(tau, 50).VerifierKey()
+
+	p := polynomial.NewZeroPolynomial(100)
+	for i := range *p {
+		(*p)[i] = big.NewInt(int64(3*i + 1))
+	}
+
+	c, err := prover.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	proof, err := prover.Open(p, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+
+	if !verifier.Verify(c, proof) {
+		t.Errorf("Verify() with VerifierKey from degree-50 SRS = false; want true")
+	}
+	if NewSRS(big.NewInt(1), 50).VerifierKey().Verify(c, proof) {
+		t.Errorf("Verify() with VerifierKey for different secret = true; want false")
+	}
+}
+
+func TestOpenExceedsSRS(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 2)
+	p := polynomial.NewPolynomialFromCoefficients([]int64{1, 2, 3, 4})
+	if _, err := srs.Open(p, big.NewInt(1)); err == nil {
+		t.Errorf("Open(<degree 3>) with SRS of max degree 2: got nil error")
+	}
+}
+
+func TestQuotientCommitmentsBothCurves(t *testing.T) {
+	srs := NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	for _, cs := range [][]int64{{42}, {2, -3, 1}, {6, -5, 1, 0, 0, 7}} {
+		p := polynomial.NewPolynomialFromCoefficients(cs)
+		z := big.NewInt(-5)
+
+		c, err := srs.Commit(p)
+		if err != nil {
+			t.Fatalf("Commit(%v): %v", cs, err)
+		}
+		q1, q2, y, err := srs.QuotientCommitmentsBothCurves(p, z)
+		if err != nil {
+			t.Fatalf("QuotientCommitmentsBothCurves(%v): %v", cs, err)
+		}
+
+		if !vk.Verify(c, &Proof{Z: new(big.Int).Mod(z, Field.Order()), Y: y, Quotient: q2}) {
+			t.Errorf("Verify(%v) with G2 quotient = false; want true", cs)
+		}
+		if !vk.VerifyWithG1Quotient(c, z, y, q1) {
+			t.Errorf("VerifyWithG1Quotient(%v) = false; want true", cs)
+		}
+		if vk.VerifyWithG1Quotient(c, z, Field.Add(y, big.NewInt(1)), q1) {
+			t.Errorf("VerifyWithG1Quotient(%v, <wrong y>) = true; want false", cs)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package kzg4844
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+)
+
+// Points are encoded in the compressed form of the ZCash BLS12-381 serialisation
+// format, as required by EIP-4844: the big-endian x coordinate (c1 before c0
+// for G2) with the three most significant bits of the first byte as flags.
+const (
+	flagCompressed = 0x80
+	flagInfinity   = 0x40
+	flagSign       = 0x20
+	flagMask       = flagCompressed | flagInfinity | flagSign
+)
+
+const (
+	fpSize = 48
+	g1Size = fpSize
+	g2Size = 2 * fpSize
+)
+
+// fp is the base field of BLS12-381.
+var fp = func() *galois.Field {
+	p, _ := new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+	return galois.NewField(p)
+}()
+
+var (
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+	bigFour = big.NewInt(4)
+	// fpHalf is (p-1)/2; y is lexicographically largest iff y > fpHalf.
+	fpHalf = new(big.Int).Rsh(fp.Order(), 1)
+)
+
+var errInvalidPoint = errors.New("invalid point encoding")
+
+// compressG1 returns the compressed encoding of p.
+func compressG1(p curve.Point) [g1Size]byte {
+	raw := p.Marshal()
+	var out [g1Size]byte
+	x, y := new(big.Int).SetBytes(raw[:fpSize]), new(big.Int).SetBytes(raw[fpSize:])
+	if x.Sign() == 0 && y.Sign() == 0 {
+		out[0] = flagCompressed | flagInfinity
+		return out
+	}
+	x.FillBytes(out[:])
+	out[0] |= flagCompressed
+	if y.Cmp(fpHalf) > 0 {
+		out[0] |= flagSign
+	}
+	return out
+}
+
+// decompressG1 returns the G1 point of the compressed encoding b, which MUST
+// be in the prime-order subgroup.
+func decompressG1(b []byte) (curve.Point, error) {
+	x, infinity, sign, err := parseFlags(b, g1Size)
+	if err != nil {
+		return nil, err
+	}
+	g1 := curve.BLS12381.G1()
+	if infinity {
+		return g1.New(), nil
+	}
+
+	// y^2 = x^3 + 4
+	y, err := fp.Sqrt(fp.Add(fp.Mul(fp.Square(x), x), bigFour))
+	if err != nil {
+		return nil, fmt.Errorf("%w: x not on curve", errInvalidPoint)
+	}
+	if (y.Cmp(fpHalf) > 0) != sign {
+		y = fp.Sub(bigZero, y)
+	}
+
+	raw := make([]byte, 2*fpSize)
+	x.FillBytes(raw[:fpSize])
+	y.FillBytes(raw[fpSize:])
+	p := g1.New()
+	if err := p.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidPoint, err)
+	}
+	return p, nil
+}
+
+// decompressG2 is the G2 equivalent of decompressG1.
+func decompressG2(b []byte) (curve.Point, error) {
+	x1, infinity, sign, err := parseFlags(b, g2Size)
+	if err != nil {
+		return nil, err
+	}
+	g2 := curve.BLS12381.G2()
+	if infinity {
+		return g2.New(), nil
+	}
+	x := fp2{
+		new(big.Int).SetBytes(b[fpSize:]),
+		x1,
+	}
+	if x[0].Cmp(fp.Order()) >= 0 {
+		return nil, fmt.Errorf("%w: coordinate not less than field order", errInvalidPoint)
+	}
+
+	// y^2 = x^3 + 4(1+u)
+	y, ok := x.square().mul(x).add(fp2{bigFour, bigFour}).sqrt()
+	if !ok {
+		return nil, fmt.Errorf("%w: x not on curve", errInvalidPoint)
+	}
+	if y.lexicographicallyLargest() != sign {
+		y = y.neg()
+	}
+
+	// go-ethereum's uncompressed G2 encoding is x.c1, x.c0, y.c1, y.c0.
+	raw := make([]byte, 4*fpSize)
+	for i, c := range []*big.Int{x[1], x[0], y[1], y[0]} {
+		c.FillBytes(raw[i*fpSize : (i+1)*fpSize])
+	}
+	p := g2.New()
+	if err := p.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidPoint, err)
+	}
+	return p, nil
+}
+
+// parseFlags checks the length and flags of the compressed encoding b, and
+// returns its leading coordinate without flags.
+func parseFlags(b []byte, size int) (x *big.Int, infinity, sign bool, _ error) {
+	if len(b) != size {
+		return nil, false, false, fmt.Errorf("%w: %d bytes; want %d", errInvalidPoint, len(b), size)
+	}
+	flags := b[0] & flagMask
+	if flags&flagCompressed == 0 {
+		return nil, false, false, fmt.Errorf("%w: not compressed", errInvalidPoint)
+	}
+
+	coord := append([]byte{b[0] &^ flagMask}, b[1:fpSize]...)
+	x = new(big.Int).SetBytes(coord)
+	if flags&flagInfinity != 0 {
+		nonZero := flags&flagSign != 0 || x.Sign() != 0
+		for _, c := range b[fpSize:] {
+			nonZero = nonZero || c != 0
+		}
+		if nonZero {
+			return nil, false, false, fmt.Errorf("%w: non-zero point at infinity", errInvalidPoint)
+		}
+		return x, true, false, nil
+	}
+	if x.Cmp(fp.Order()) >= 0 {
+		return nil, false, false, fmt.Errorf("%w: coordinate not less than field order", errInvalidPoint)
+	}
+	return x, false, flags&flagSign != 0, nil
+}
+
+// An fp2 is the element c0 + c1*u of Fp[u]/(u^2+1).
+type fp2 [2]*big.Int
+
+func (a fp2) add(b fp2) fp2 {
+	return fp2{fp.Add(a[0], b[0]), fp.Add(a[1], b[1])}
+}
+
+func (a fp2) neg() fp2 {
+	return fp2{fp.Sub(bigZero, a[0]), fp.Sub(bigZero, a[1])}
+}
+
+func (a fp2) mul(b fp2) fp2 {
+	return fp2{
+		fp.Sub(fp.Mul(a[0], b[0]), fp.Mul(a[1], b[1])),
+		fp.Add(fp.Mul(a[0], b[1]), fp.Mul(a[1], b[0])),
+	}
+}
+
+func (a fp2) square() fp2 {
+	return a.mul(a)
+}
+
+func (a fp2) exp(e *big.Int) fp2 {
+	r := fp2{big.NewInt(1), big.NewInt(0)}
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = r.square()
+		if e.Bit(i) == 1 {
+			r = r.mul(a)
+		}
+	}
+	return r
+}
+
+func (a fp2) equal(b fp2) bool {
+	return a[0].Cmp(b[0]) == 0 && a[1].Cmp(b[1]) == 0
+}
+
+// lexicographicallyLargest compares c1 first, and c0 only if c1 is zero.
+func (a fp2) lexicographicallyLargest() bool {
+	if a[1].Sign() != 0 {
+		return a[1].Cmp(fpHalf) > 0
+	}
+	return a[0].Cmp(fpHalf) > 0
+}
+
+// sqrt returns a square root of a, and whether it exists, with Algorithm 9 of
+// https://eprint.iacr.org/2012/685 for p = 3 mod 4.
+func (a fp2) sqrt() (fp2, bool) {
+	// (p-3)/4
+	e := new(big.Int).Sub(fp.Order(), big.NewInt(3))
+	e.Rsh(e, 2)
+
+	a1 := a.exp(e)
+	alpha := a1.square().mul(a)
+	x0 := a1.mul(a)
+
+	var x fp2
+	minusOne := fp2{fp.Sub(bigZero, bigOne), bigZero}
+	if alpha.equal(minusOne) {
+		// u * x0
+		x = fp2{fp.Sub(bigZero, x0[1]), x0[0]}
+	} else {
+		// (1 + alpha)^((p-1)/2) * x0
+		b := alpha.add(fp2{bigOne, bigZero}).exp(fpHalf)
+		x = b.mul(x0)
+	}
+	return x, x.square().equal(a)
+}
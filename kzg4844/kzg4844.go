@@ -0,0 +1,310 @@
+// Package kzg4844 implements the KZG commitments to blobs of EIP-4844 over
+// BLS12-381, byte-compatible with the consensus specs and c-kzg-4844, using
+// the mainnet trusted setup.
+//
+// A blob is a polynomial of degree < 4096 in evaluation form, i.e. its
+// evaluations over the 4096th roots of unity in bit-reversed order. See
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/polynomial-commitments.md.
+package kzg4844
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"zkp.xyz/membership/curve"
+)
+
+const (
+	// FieldElementsPerBlob is the number of field elements in a Blob.
+	FieldElementsPerBlob = 4096
+	// BytesPerFieldElement is the size of an encoded field element.
+	BytesPerFieldElement = 32
+	// BlobSize is the size of a Blob in bytes.
+	BlobSize = FieldElementsPerBlob * BytesPerFieldElement
+)
+
+type (
+	// A Blob holds FieldElementsPerBlob big-endian field elements, each of
+	// which MUST be less than the BLS12-381 scalar field order.
+	Blob [BlobSize]byte
+	// A Commitment is a compressed G1 point committing to a Blob.
+	Commitment [g1Size]byte
+	// A Proof is a compressed G1 point proving the evaluation of a Blob.
+	Proof [g1Size]byte
+	// A Scalar is a big-endian field element.
+	Scalar [BytesPerFieldElement]byte
+)
+
+// fiatShamirDomain separates the challenges of blob proofs.
+const fiatShamirDomain = "FSBLOBVERIFY_V1_"
+
+// primitiveRoot generates the multiplicative group of the scalar field.
+const primitiveRoot = 7
+
+// ErrInvalidScalar is returned for encoded field elements that are not less
+// than the order of the scalar field.
+var ErrInvalidScalar = errors.New("scalar not less than BLS12-381 scalar field order")
+
+// fr is the scalar field of BLS12-381.
+var fr = curve.BLS12381.ScalarField()
+
+// roots holds the FieldElementsPerBlob-th roots of unity in bit-reversed
+// order, such that the ith field element of a Blob is the evaluation at
+// roots[i].
+var roots = func() []*big.Int {
+	e := new(big.Int).Sub(fr.Order(), bigOne)
+	e.Div(e, big.NewInt(FieldElementsPerBlob))
+	w := fr.Exp(big.NewInt(primitiveRoot), e)
+
+	rs := make([]*big.Int, FieldElementsPerBlob)
+	x := big.NewInt(1)
+	for i := range rs {
+		rs[i] = x
+		x = fr.Mul(x, w)
+	}
+	return bitReverse(rs)
+}()
+
+// bitReverse permutes xs in place, swapping the elements at indices which are
+// each other's bit reversal, and returns it. The length of xs MUST be a power
+// of two.
+func bitReverse[T any](xs []T) []T {
+	shift := 64 - bits.Len64(uint64(len(xs)-1))
+	for i := range xs {
+		if j := int(bits.Reverse64(uint64(i)) >> shift); i < j {
+			xs[i], xs[j] = xs[j], xs[i]
+		}
+	}
+	return xs
+}
+
+// BlobToCommitment returns the commitment to the blob.
+func BlobToCommitment(blob *Blob) (Commitment, error) {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return Commitment{}, err
+	}
+	c, err := commit(poly)
+	if err != nil {
+		return Commitment{}, err
+	}
+	return Commitment(compressG1(c)), nil
+}
+
+// ComputeProof returns a proof of the evaluation y of the blob at z.
+func ComputeProof(blob *Blob, z Scalar) (Proof, Scalar, error) {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	zz, err := z.field()
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	proof, y, err := computeProof(poly, zz)
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	return proof, scalar(y), nil
+}
+
+// ComputeBlobProof returns the proof used by VerifyBlobProof, i.e. that of the
+// evaluation at a challenge derived from the blob and its commitment.
+func ComputeBlobProof(blob *Blob, commitment Commitment) (Proof, error) {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, err
+	}
+	if _, err := decompressG1(commitment[:]); err != nil {
+		return Proof{}, fmt.Errorf("commitment: %w", err)
+	}
+	proof, _, err := computeProof(poly, challenge(blob, commitment))
+	return proof, err
+}
+
+// VerifyProof reports whether the proof shows that the blob committed to
+// evaluates to y at z. It returns an error if any input is malformed.
+func VerifyProof(commitment Commitment, z, y Scalar, proof Proof) (bool, error) {
+	zz, err := z.field()
+	if err != nil {
+		return false, err
+	}
+	yy, err := y.field()
+	if err != nil {
+		return false, err
+	}
+	return verifyProof(commitment, zz, yy, proof)
+}
+
+// VerifyBlobProof reports whether the proof, as returned by ComputeBlobProof,
+// shows that the commitment is to the blob. It returns an error if any input
+// is malformed.
+func VerifyBlobProof(blob *Blob, commitment Commitment, proof Proof) (bool, error) {
+	poly, err := blobToPolynomial(blob)
+	if err != nil {
+		return false, err
+	}
+	z := challenge(blob, commitment)
+	return verifyProof(commitment, z, evaluate(poly, z), proof)
+}
+
+func blobToPolynomial(blob *Blob) ([]*big.Int, error) {
+	poly := make([]*big.Int, FieldElementsPerBlob)
+	for i := range poly {
+		var s Scalar
+		copy(s[:], blob[i*BytesPerFieldElement:])
+		x, err := s.field()
+		if err != nil {
+			return nil, fmt.Errorf("blob element %d: %w", i, err)
+		}
+		poly[i] = x
+	}
+	return poly, nil
+}
+
+func (s Scalar) field() (*big.Int, error) {
+	x := new(big.Int).SetBytes(s[:])
+	if x.Cmp(fr.Order()) >= 0 {
+		return nil, ErrInvalidScalar
+	}
+	return x, nil
+}
+
+func scalar(x *big.Int) Scalar {
+	var s Scalar
+	x.FillBytes(s[:])
+	return s
+}
+
+// challenge returns the Fiat-Shamir challenge of the blob and commitment.
+func challenge(blob *Blob, commitment Commitment) *big.Int {
+	h := sha256.New()
+	h.Write([]byte(fiatShamirDomain))
+	var degree [16]byte
+	big.NewInt(FieldElementsPerBlob).FillBytes(degree[:])
+	h.Write(degree[:])
+	h.Write(blob[:])
+	h.Write(commitment[:])
+	return fr.Mod(new(big.Int).SetBytes(h.Sum(nil)))
+}
+
+// commit returns the commitment to the polynomial in evaluation form.
+func commit(poly []*big.Int) (curve.Point, error) {
+	s, err := trustedSetup()
+	if err != nil {
+		return nil, err
+	}
+	return curve.BLS12381.G1().MultiExp(s.g1Lagrange, poly)
+}
+
+// evaluate returns the evaluation of the polynomial in evaluation form at z,
+// with the barycentric formula (z^n - 1)/n * sum_i poly[i]*w_i/(z - w_i) for z
+// outside of the domain.
+func evaluate(poly []*big.Int, z *big.Int) *big.Int {
+	dens := make([]*big.Int, len(roots))
+	for i, w := range roots {
+		if w.Cmp(z) == 0 {
+			return new(big.Int).Set(poly[i])
+		}
+		dens[i] = fr.Sub(z, w)
+	}
+	invs, err := fr.BatchInverse(dens)
+	if err != nil {
+		// Unreachable as z is not in the domain.
+		panic(err)
+	}
+
+	sum := big.NewInt(0)
+	for i, w := range roots {
+		sum = fr.Add(sum, fr.Mul(fr.Mul(poly[i], w), invs[i]))
+	}
+	n := big.NewInt(FieldElementsPerBlob)
+	zn := fr.Sub(fr.Exp(z, n), bigOne)
+	nInv, err := fr.MultInverse(n)
+	if err != nil {
+		panic(err)
+	}
+	return fr.Mul(sum, fr.Mul(zn, nInv))
+}
+
+// computeProof returns the proof of the evaluation y of the polynomial in
+// evaluation form at z, committing to the quotient (poly - y)/(X - z) in
+// evaluation form.
+func computeProof(poly []*big.Int, z *big.Int) (Proof, *big.Int, error) {
+	y := evaluate(poly, z)
+
+	nums, dens := make([]*big.Int, len(poly)), make([]*big.Int, len(poly))
+	inDomain := -1
+	for i, w := range roots {
+		if w.Cmp(z) == 0 {
+			inDomain = i
+			nums[i], dens[i] = big.NewInt(0), big.NewInt(1)
+			continue
+		}
+		nums[i], dens[i] = fr.Sub(poly[i], y), fr.Sub(w, z)
+	}
+	quotient, err := fr.DivSlice(nums, dens)
+	if err != nil {
+		return Proof{}, nil, err
+	}
+	if inDomain >= 0 {
+		quotient[inDomain] = quotientAtRoot(poly, y, inDomain)
+	}
+
+	c, err := commit(quotient)
+	if err != nil {
+		return Proof{}, nil, err
+	}
+	return Proof(compressG1(c)), y, nil
+}
+
+// quotientAtRoot returns the evaluation of (poly - y)/(X - z) at z = roots[m],
+// i.e. sum_{i != m} (poly[i] - y) * w_i / (z * (z - w_i)).
+func quotientAtRoot(poly []*big.Int, y *big.Int, m int) *big.Int {
+	z := roots[m]
+	nums, dens := make([]*big.Int, 0, len(roots)-1), make([]*big.Int, 0, len(roots)-1)
+	for i, w := range roots {
+		if i == m {
+			continue
+		}
+		nums = append(nums, fr.Mul(fr.Sub(poly[i], y), w))
+		dens = append(dens, fr.Mul(z, fr.Sub(z, w)))
+	}
+	terms, err := fr.DivSlice(nums, dens)
+	if err != nil {
+		// Unreachable as the roots are distinct and non-zero.
+		panic(err)
+	}
+	sum := big.NewInt(0)
+	for _, t := range terms {
+		sum = fr.Add(sum, t)
+	}
+	return sum
+}
+
+// verifyProof checks e(C - [y]_1, -[1]_2) * e(proof, [s - z]_2) = 1.
+func verifyProof(commitment Commitment, z, y *big.Int, proof Proof) (bool, error) {
+	c, err := decompressG1(commitment[:])
+	if err != nil {
+		return false, fmt.Errorf("commitment: %w", err)
+	}
+	pi, err := decompressG1(proof[:])
+	if err != nil {
+		return false, fmt.Errorf("proof: %w", err)
+	}
+	s, err := trustedSetup()
+	if err != nil {
+		return false, err
+	}
+
+	g1, g2 := curve.BLS12381.G1(), curve.BLS12381.G2()
+	cMinusY := g1.New().Add(c, g1.New().ScalarBaseMult(new(big.Int).Neg(y)))
+	sMinusZ := g2.New().Add(s.g2Monomial[1], g2.New().ScalarBaseMult(new(big.Int).Neg(z)))
+	return curve.BLS12381.PairingCheck(
+		[]curve.Point{cMinusY, pi},
+		[]curve.Point{g2.New().Neg(g2.Generator()), sMinusZ},
+	), nil
+}
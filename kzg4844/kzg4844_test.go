@@ -0,0 +1,306 @@
+package kzg4844
+
+import (
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"zkp.xyz/membership/curve"
+)
+
+// vectors are the mainnet reference tests of the consensus specs, with blobs
+// deduplicated into Blobs and referenced by index. A nil output means that the
+// inputs are invalid.
+type vectors struct {
+	Blobs               []string
+	BlobToKZGCommitment []struct {
+		Name  string
+		Input struct {
+			Blob int
+		}
+		Output *string
+	} `json:"blob_to_kzg_commitment"`
+	ComputeKZGProof []struct {
+		Name  string
+		Input struct {
+			Blob int
+			Z    string
+		}
+		Output *[2]string
+	} `json:"compute_kzg_proof"`
+	ComputeBlobKZGProof []struct {
+		Name  string
+		Input struct {
+			Blob       int
+			Commitment string
+		}
+		Output *string
+	} `json:"compute_blob_kzg_proof"`
+	VerifyKZGProof []struct {
+		Name  string
+		Input struct {
+			Commitment, Z, Y, Proof string
+		}
+		Output *bool
+	} `json:"verify_kzg_proof"`
+	VerifyBlobKZGProof []struct {
+		Name  string
+		Input struct {
+			Blob              int
+			Commitment, Proof string
+		}
+		Output *bool
+	} `json:"verify_blob_kzg_proof"`
+}
+
+func loadVectors(t *testing.T) *vectors {
+	t.Helper()
+	f, err := os.Open("testdata/vectors.json.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := new(vectors)
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// decodeHex decodes s into dst and reports whether s has the length of dst.
+func decodeHex(t *testing.T, dst []byte, s string) bool {
+	t.Helper()
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q): %v", s, err)
+	}
+	return copy(dst, b) == len(b) && len(b) == len(dst)
+}
+
+func (v *vectors) blob(t *testing.T, i int) (*Blob, bool) {
+	t.Helper()
+	b := new(Blob)
+	return b, decodeHex(t, b[:], v.Blobs[i])
+}
+
+func TestBlobToCommitment(t *testing.T) {
+	v := loadVectors(t)
+	for _, tt := range v.BlobToKZGCommitment {
+		blob, ok := v.blob(t, tt.Input.Blob)
+		if !ok {
+			if tt.Output != nil {
+				t.Fatalf("%s: blob of invalid length with valid output", tt.Name)
+			}
+			continue
+		}
+		got, err := BlobToCommitment(blob)
+		if tt.Output == nil {
+			if err == nil {
+				t.Errorf("%s: BlobToCommitment() got nil error", tt.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: BlobToCommitment(): %v", tt.Name, err)
+			continue
+		}
+		var want Commitment
+		decodeHex(t, want[:], *tt.Output)
+		if got != want {
+			t.Errorf("%s: BlobToCommitment() = %x; want %x", tt.Name, got, want)
+		}
+	}
+}
+
+func TestComputeProof(t *testing.T) {
+	v := loadVectors(t)
+	for _, tt := range v.ComputeKZGProof {
+		blob, okBlob := v.blob(t, tt.Input.Blob)
+		var z Scalar
+		okZ := decodeHex(t, z[:], tt.Input.Z)
+		if !okBlob || !okZ {
+			if tt.Output != nil {
+				t.Fatalf("%s: input of invalid length with valid output", tt.Name)
+			}
+			continue
+		}
+
+		proof, y, err := ComputeProof(blob, z)
+		if tt.Output == nil {
+			if err == nil {
+				t.Errorf("%s: ComputeProof() got nil error", tt.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ComputeProof(): %v", tt.Name, err)
+			continue
+		}
+		var (
+			wantProof Proof
+			wantY     Scalar
+		)
+		decodeHex(t, wantProof[:], tt.Output[0])
+		decodeHex(t, wantY[:], tt.Output[1])
+		if proof != wantProof || y != wantY {
+			t.Errorf("%s: ComputeProof() = %x, %x; want %x, %x", tt.Name, proof, y, wantProof, wantY)
+		}
+	}
+}
+
+func TestComputeBlobProof(t *testing.T) {
+	v := loadVectors(t)
+	for _, tt := range v.ComputeBlobKZGProof {
+		blob, okBlob := v.blob(t, tt.Input.Blob)
+		var c Commitment
+		okC := decodeHex(t, c[:], tt.Input.Commitment)
+		if !okBlob || !okC {
+			if tt.Output != nil {
+				t.Fatalf("%s: input of invalid length with valid output", tt.Name)
+			}
+			continue
+		}
+
+		got, err := ComputeBlobProof(blob, c)
+		if tt.Output == nil {
+			if err == nil {
+				t.Errorf("%s: ComputeBlobProof() got nil error", tt.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ComputeBlobProof(): %v", tt.Name, err)
+			continue
+		}
+		var want Proof
+		decodeHex(t, want[:], *tt.Output)
+		if got != want {
+			t.Errorf("%s: ComputeBlobProof() = %x; want %x", tt.Name, got, want)
+		}
+	}
+}
+
+func TestVerifyProof(t *testing.T) {
+	v := loadVectors(t)
+	for _, tt := range v.VerifyKZGProof {
+		var (
+			c     Commitment
+			z, y  Scalar
+			proof Proof
+		)
+		ok := decodeHex(t, c[:], tt.Input.Commitment)
+		ok = decodeHex(t, z[:], tt.Input.Z) && ok
+		ok = decodeHex(t, y[:], tt.Input.Y) && ok
+		ok = decodeHex(t, proof[:], tt.Input.Proof) && ok
+		if !ok {
+			if tt.Output != nil {
+				t.Fatalf("%s: input of invalid length with valid output", tt.Name)
+			}
+			continue
+		}
+
+		got, err := VerifyProof(c, z, y, proof)
+		if tt.Output == nil {
+			if err == nil {
+				t.Errorf("%s: VerifyProof() got nil error", tt.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: VerifyProof(): %v", tt.Name, err)
+			continue
+		}
+		if got != *tt.Output {
+			t.Errorf("%s: VerifyProof() = %t; want %t", tt.Name, got, *tt.Output)
+		}
+	}
+}
+
+func TestVerifyBlobProof(t *testing.T) {
+	v := loadVectors(t)
+	for _, tt := range v.VerifyBlobKZGProof {
+		blob, okBlob := v.blob(t, tt.Input.Blob)
+		var (
+			c     Commitment
+			proof Proof
+		)
+		okC := decodeHex(t, c[:], tt.Input.Commitment)
+		okProof := decodeHex(t, proof[:], tt.Input.Proof)
+		if !okBlob || !okC || !okProof {
+			if tt.Output != nil {
+				t.Fatalf("%s: input of invalid length with valid output", tt.Name)
+			}
+			continue
+		}
+
+		got, err := VerifyBlobProof(blob, c, proof)
+		if tt.Output == nil {
+			if err == nil {
+				t.Errorf("%s: VerifyBlobProof() got nil error", tt.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: VerifyBlobProof(): %v", tt.Name, err)
+			continue
+		}
+		if got != *tt.Output {
+			t.Errorf("%s: VerifyBlobProof() = %t; want %t", tt.Name, got, *tt.Output)
+		}
+	}
+}
+
+func TestTrustedSetup(t *testing.T) {
+	s, err := trustedSetup()
+	if err != nil {
+		t.Fatalf("trustedSetup(): %v", err)
+	}
+	if g := curve.BLS12381.G2().Generator(); !s.g2Monomial[0].Equal(g) {
+		t.Errorf("trustedSetup() G2[0] != generator")
+	}
+	// sum_i L_i = 1
+	ones := make([]*big.Int, FieldElementsPerBlob)
+	for i := range ones {
+		ones[i] = big.NewInt(1)
+	}
+	got, err := commit(ones)
+	if err != nil {
+		t.Fatalf("commit(): %v", err)
+	}
+	if g := curve.BLS12381.G1().Generator(); !got.Equal(g) {
+		t.Errorf("commit(<constant 1>) != generator")
+	}
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	g1 := curve.BLS12381.G1()
+	for _, k := range []int64{0, 1, 2, 1337, -1} {
+		p := g1.New().ScalarBaseMult(big.NewInt(k))
+		c := compressG1(p)
+		got, err := decompressG1(c[:])
+		if err != nil {
+			t.Fatalf("decompressG1(compressG1([%d]G1)): %v", k, err)
+		}
+		if !got.Equal(p) {
+			t.Errorf("decompressG1(compressG1([%d]G1)) != [%d]G1", k, k)
+		}
+	}
+
+	for _, b := range [][]byte{
+		make([]byte, g1Size),
+		make([]byte, g1Size-1),
+		append([]byte{flagCompressed | flagInfinity | flagSign}, make([]byte, g1Size-1)...),
+	} {
+		if _, err := decompressG1(b); err == nil {
+			t.Errorf("decompressG1(%x) got nil error", b)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package kzg4844
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"zkp.xyz/membership/curve"
+)
+
+// trustedSetupJSON is the mainnet trusted setup of the KZG ceremony, with the
+// G1 powers in Lagrange form over the domain in natural order.
+//
+//go:embed trusted_setup.json
+var trustedSetupJSON []byte
+
+type setup struct {
+	g1Lagrange []curve.Point
+	g2Monomial []curve.Point
+}
+
+var (
+	setupOnce   sync.Once
+	setupCached *setup
+	setupErr    error
+)
+
+// trustedSetup returns the decoded trusted setup, decoding it on first use.
+func trustedSetup() (*setup, error) {
+	setupOnce.Do(func() {
+		setupCached, setupErr = parseSetup(trustedSetupJSON)
+	})
+	return setupCached, setupErr
+}
+
+func parseSetup(buf []byte) (*setup, error) {
+	var raw struct {
+		G1Lagrange []string `json:"g1_lagrange"`
+		G2Monomial []string `json:"g2_monomial"`
+	}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return nil, fmt.Errorf("parse trusted setup: %v", err)
+	}
+	if len(raw.G1Lagrange) != FieldElementsPerBlob {
+		return nil, fmt.Errorf("trusted setup has %d G1 points; want %d", len(raw.G1Lagrange), FieldElementsPerBlob)
+	}
+	if len(raw.G2Monomial) < 2 {
+		return nil, fmt.Errorf("trusted setup has %d G2 points; want at least 2", len(raw.G2Monomial))
+	}
+
+	s := &setup{}
+	var err error
+	if s.g1Lagrange, err = parsePoints(raw.G1Lagrange, decompressG1); err != nil {
+		return nil, fmt.Errorf("trusted setup G1: %v", err)
+	}
+	bitReverse(s.g1Lagrange)
+	if s.g2Monomial, err = parsePoints(raw.G2Monomial, decompressG2); err != nil {
+		return nil, fmt.Errorf("trusted setup G2: %v", err)
+	}
+	return s, nil
+}
+
+func parsePoints(encoded []string, decompress func([]byte) (curve.Point, error)) ([]curve.Point, error) {
+	points := make([]curve.Point, len(encoded))
+	for i, e := range encoded {
+		b, err := hex.DecodeString(strings.TrimPrefix(e, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %v", i, err)
+		}
+		if points[i], err = decompress(b); err != nil {
+			return nil, fmt.Errorf("point %d: %v", i, err)
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,304 @@
+// Package lookup implements the logUp lookup argument of Haböck, "Multivariate
+// lookups based on logarithmic derivatives", adapted to univariate KZG
+// commitments: proofs that every value of a committed witness column belongs
+// to a committed Table.
+//
+// The Table t and the witness column f are interpolated over a Domain H of
+// size n. The witness column is committed to by the caller, e.g. as a wire of
+// a PLONK circuit over the same Domain, or with Table.CommitColumn, and the
+// verifier checks the Proof against that commitment. With the multiplicities m of the
+// values of the Table in f, every value of f is in t iff, for random beta,
+//
+//	sum_i 1 / (beta + f(w^i)) = sum_i m(w^i) / (beta + t(w^i)).
+//
+// The prover commits to the running sum phi with phi(w^0) = 0 and
+// phi(w^(i+1)) = phi(w^i) + 1/(beta + f(w^i)) - m(w^i)/(beta + t(w^i)), which
+// wraps around H iff the sums are equal, i.e. iff
+//
+//	(phi(w X) - phi(X)) (beta + f) (beta + t) - (beta + t) + m (beta + f)
+//
+// is divisible by Z_H = X^n - 1. The quotient q is committed to, all
+// polynomials are opened at a challenge zeta, and phi additionally at w zeta.
+package lookup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// ErrNotInTable is returned by ProveLookup for witness values missing from
+// the Table.
+var ErrNotInTable = errors.New("value not in table")
+
+var (
+	// Field is the scalar field of bn256, over which Tables are defined.
+	Field = kzg.Field
+
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+)
+
+// A Table is a committed column of values, padded with its first value to the
+// size of its Domain.
+type Table struct {
+	domain *polynomial.Domain
+	values []*big.Int
+	index  map[string]int
+	poly   *polynomial.Polynomial
+	key    *TableKey
+}
+
+// A TableKey holds the public parameters of a Table required for
+// verification: the size N and generator Omega of its Domain and the
+// commitment T to its values.
+type TableKey struct {
+	N     uint64
+	Omega *big.Int
+	T     *bn256.G1
+}
+
+// NewTable returns the Table of the values, which are reduced into the Field,
+// supporting witness columns of at most as many values as the smallest power
+// of two n of at least len(values). Proofs require an SRS of maximum degree
+// at least 2n+2. The values are interpolated over the canonical Domain of
+// size n.
+func NewTable(srs *kzg.SRS, values []*big.Int) (*Table, error) {
+	if len(values) == 0 {
+		return nil, errors.New("empty table")
+	}
+	n := uint64(1)
+	for n < uint64(len(values)) {
+		n <<= 1
+	}
+	if srs.MaxDegree() < 2*int(n)+2 {
+		return nil, fmt.Errorf("SRS of max degree %d; want at least %d for table of size %d", srs.MaxDegree(), 2*n+2, n)
+	}
+	d, err := polynomial.CanonicalDomain(Field, n)
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{domain: d, values: make([]*big.Int, n), index: make(map[string]int)}
+	for i := range t.values {
+		v := values[0]
+		if i < len(values) {
+			v = values[i]
+		}
+		t.values[i] = new(big.Int).Mod(v, Field.Order())
+		if _, ok := t.index[t.values[i].String()]; !ok {
+			t.index[t.values[i].String()] = i
+		}
+	}
+	t.poly = polynomial.NewPolynomial(d.IFFT(t.values)).Normalize(Field)
+	c, err := srs.Commit(t.poly)
+	if err != nil {
+		return nil, err
+	}
+	t.key = &TableKey{N: n, Omega: d.Generator(), T: c}
+	return t, nil
+}
+
+// Size returns the number of rows of the Table including padding, which bounds
+// the length of witness columns.
+func (t *Table) Size() int {
+	return len(t.values)
+}
+
+// Key returns the TableKey of the Table.
+func (t *Table) Key() *TableKey {
+	return t.key
+}
+
+// A Column is a committed witness column: a polynomial evaluating to the
+// values of the column over the Domain of a Table, typically blinded with
+// Domain.Blinded, and its commitment C.
+type Column struct {
+	Poly *polynomial.Polynomial
+	C    *bn256.G1
+}
+
+// CommitColumn returns the Column of at most t.Size() values reduced into the
+// Field, padded with the first value of the Table and blinded with
+// randomness drawn from r.
+func (t *Table) CommitColumn(srs *kzg.SRS, values []*big.Int, r io.Reader) (*Column, error) {
+	n := t.Size()
+	if len(values) > n {
+		return nil, fmt.Errorf("witness column of %d values; want at most %d", len(values), n)
+	}
+	fs := make([]*big.Int, n)
+	for i := range fs {
+		fs[i] = t.values[0]
+		if i < len(values) {
+			fs[i] = values[i]
+		}
+		if fs[i] == nil || fs[i].Sign() < 0 || fs[i].Cmp(Field.Order()) >= 0 {
+			return nil, fmt.Errorf("witness[%d] not in the field", i)
+		}
+	}
+	p, err := t.domain.Blinded(fs, 2, r)
+	if err != nil {
+		return nil, err
+	}
+	c, err := srs.Commit(p)
+	if err != nil {
+		return nil, err
+	}
+	return &Column{Poly: p, C: c}, nil
+}
+
+// A Proof attests that every value of a committed witness column is in a
+// Table. M, Phi and Q commit to the multiplicities, running sum and quotient.
+// AtZeta opens f, t, m, phi and q, in that order, at zeta; AtZetaOmega opens
+// phi at w zeta.
+type Proof struct {
+	M, Phi, Q           *bn256.G1
+	AtZeta, AtZetaOmega *kzg.AggregateProof
+}
+
+// Indices of the evaluations of Proof.AtZeta.
+const (
+	evalF = iota
+	evalT
+	evalM
+	evalPhi
+	evalQ
+	numEvals
+)
+
+// newTranscript returns the Transcript of the TableKey and the commitment to
+// the witness column.
+func newTranscript(key *TableKey, f *bn256.G1) *transcript.Transcript {
+	t := transcript.New("zkp.xyz/lookup")
+	t.AppendScalar("n", new(big.Int).SetUint64(key.N))
+	t.AppendScalar("omega", key.Omega)
+	t.AppendG1("table", key.T)
+	t.AppendG1("f", f)
+	return t
+}
+
+// ProveLookup returns a Proof that every value of the witness Column over the
+// Domain of the Table is in the Table, or an error wrapping ErrNotInTable.
+// The blinding factors are drawn from r.
+func ProveLookup(pr *kzg.Prover, table *Table, column *Column, r io.Reader) (*Proof, error) {
+	d, n := table.domain, table.Size()
+	if column == nil || column.Poly == nil || column.C == nil {
+		return nil, errors.New("incomplete witness column")
+	}
+	f := column.Poly
+	fs, ms := d.Values(f), make([]*big.Int, n)
+	for i := range ms {
+		ms[i] = big.NewInt(0)
+	}
+	for i, v := range fs {
+		j, ok := table.index[v.String()]
+		if !ok {
+			return nil, fmt.Errorf("witness[%d]: %w", i, ErrNotInTable)
+		}
+		ms[j] = Field.Add(ms[j], bigOne)
+	}
+
+	proof := new(Proof)
+	t := newTranscript(table.key, column.C)
+	m, err := d.Blinded(ms, 2, r)
+	if err != nil {
+		return nil, err
+	}
+	if proof.M, err = pr.SRS().Commit(m); err != nil {
+		return nil, err
+	}
+	t.AppendG1("m", proof.M)
+	beta := t.Challenge(Field)
+
+	// The running sum of 1/(beta + f_i) - m_i/(beta + t_i).
+	nums, dens := make([]*big.Int, 2*n), make([]*big.Int, 2*n)
+	for i := 0; i < n; i++ {
+		nums[i], dens[i] = bigOne, Field.Add(beta, fs[i])
+		nums[n+i], dens[n+i] = ms[i], Field.Add(beta, table.values[i])
+	}
+	terms, err := Field.DivSlice(nums, dens)
+	if err != nil {
+		return nil, err
+	}
+	phis := make([]*big.Int, n)
+	phis[0] = big.NewInt(0)
+	for i := 1; i < n; i++ {
+		phis[i] = Field.Add(phis[i-1], Field.Sub(terms[i-1], terms[n+i-1]))
+	}
+	phi, err := d.Blinded(phis, 3, r)
+	if err != nil {
+		return nil, err
+	}
+	if proof.Phi, err = pr.SRS().Commit(phi); err != nil {
+		return nil, err
+	}
+	t.AppendG1("phi", proof.Phi)
+
+	b := polynomial.NewPolynomial([]*big.Int{beta})
+	bf, bt := f.Add(b, Field), table.poly.Add(b, Field)
+	numerator := d.Shift(phi).Sub(phi, Field).Mul(bf, Field).Mul(bt, Field).
+		Sub(bt, Field).Add(m.Mul(bf, Field), Field)
+	q, rem := numerator.Div(d.Vanishing(), Field)
+	if rem.Degree() != 0 || (*rem)[0].Sign() != 0 {
+		return nil, errors.New("running sum does not wrap around")
+	}
+	if proof.Q, err = pr.SRS().Commit(q); err != nil {
+		return nil, err
+	}
+	t.AppendG1("q", proof.Q)
+	zeta := t.Challenge(Field)
+
+	ps := []*polynomial.Polynomial{f, table.poly, m, phi, q}
+	cs := []*bn256.G1{column.C, table.key.T, proof.M, proof.Phi, proof.Q}
+	if proof.AtZeta, err = pr.OpenAggregate(ps, cs, zeta); err != nil {
+		return nil, err
+	}
+	zetaOmega := Field.Mul(zeta, d.Generator())
+	if proof.AtZetaOmega, err = pr.OpenAggregate(ps[evalPhi:evalPhi+1], cs[evalPhi:evalPhi+1], zetaOmega); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// Verify reports whether the proof is valid for the witness column committed
+// to as f and the Table of the TableKey, by checking both openings and, with
+// their evaluations,
+//
+//	(phi(w zeta) - phi(zeta)) (beta + f) (beta + t) - (beta + t) + m (beta + f) = q Z_H(zeta).
+func Verify(vk *kzg.VerifierKey, key *TableKey, f *bn256.G1, proof *Proof) bool {
+	if f == nil || proof.M == nil || proof.Phi == nil || proof.Q == nil {
+		return false
+	}
+	t := newTranscript(key, f)
+	t.AppendG1("m", proof.M)
+	beta := t.Challenge(Field)
+	t.AppendG1("phi", proof.Phi)
+	t.AppendG1("q", proof.Q)
+	zeta := t.Challenge(Field)
+
+	zetaOmega := Field.Mul(zeta, key.Omega)
+	if !proof.AtZeta.OpensAt(zeta, numEvals) || !proof.AtZetaOmega.OpensAt(zetaOmega, 1) {
+		return false
+	}
+	ys := proof.AtZeta.Ys
+
+	zh, _, err := polynomial.LagrangeAt(zeta, key.Omega, key.N, 0, Field)
+	if err != nil {
+		return false
+	}
+	bf, bt := Field.Add(beta, ys[evalF]), Field.Add(beta, ys[evalT])
+	lhs := Field.Mul(Field.Mul(Field.Sub(proof.AtZetaOmega.Ys[0], ys[evalPhi]), bf), bt)
+	lhs = Field.Add(Field.Sub(lhs, bt), Field.Mul(ys[evalM], bf))
+	if lhs.Cmp(Field.Mul(ys[evalQ], zh)) != 0 {
+		return false
+	}
+
+	cs := []*bn256.G1{f, key.T, proof.M, proof.Phi, proof.Q}
+	return vk.VerifyAggregate(cs, proof.AtZeta) &&
+		vk.VerifyAggregate(cs[evalPhi:evalPhi+1], proof.AtZetaOmega)
+}
@@ -0,0 +1,156 @@
+package lookup
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// srs supports Tables of up to 8 rows.
+var srs = kzg.NewSRS(big.NewInt(1337), 2*8+2)
+
+func ints(xs ...int64) []*big.Int {
+	out := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		out[i] = big.NewInt(x)
+	}
+	return out
+}
+
+func TestLookup(t *testing.T) {
+	table, err := NewTable(srs, ints(1, 2, 3, 5, 8, 13))
+	if err != nil {
+		t.Fatalf("NewTable() error %v", err)
+	}
+	if got, want := table.Size(), 8; got != want {
+		t.Errorf("Size() = %d; want %d", got, want)
+	}
+	pr, vk := kzg.NewProver(srs), srs.VerifierKey()
+
+	for _, tt := range []struct {
+		name    string
+		witness []*big.Int
+	}{
+		{"full", ints(13, 8, 5, 3, 2, 1, 1, 1)},
+		{"repeated", ints(5, 5, 5)},
+		{"padding value", ints(1)},
+		{"empty", nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			column, err := table.CommitColumn(srs, tt.witness, rand.Reader)
+			if err != nil {
+				t.Fatalf("CommitColumn() error %v", err)
+			}
+			proof, err := ProveLookup(pr, table, column, rand.Reader)
+			if err != nil {
+				t.Fatalf("ProveLookup() error %v", err)
+			}
+			if !Verify(vk, table.Key(), column.C, proof) {
+				t.Error("Verify() = false; want true")
+			}
+		})
+	}
+
+	// A column committed to independently of the proof, unblinded and padded
+	// with zeros, which are in the table.
+	zeros, err := NewTable(srs, ints(0, 7, 1, 2, 3))
+	if err != nil {
+		t.Fatalf("NewTable() error %v", err)
+	}
+	p := polynomial.NewPolynomial(zeros.domain.IFFT(ints(7, 0, 7, 7, 0, 0, 0, 0)))
+	c, err := srs.Commit(p)
+	if err != nil {
+		t.Fatalf("Commit() error %v", err)
+	}
+	if proof, err := ProveLookup(pr, zeros, &Column{Poly: p, C: c}, rand.Reader); err != nil {
+		t.Errorf("ProveLookup(<external column>) error %v", err)
+	} else if !Verify(vk, zeros.Key(), c, proof) {
+		t.Error("Verify(<external column>) = false; want true")
+	}
+
+	column, err := table.CommitColumn(srs, ints(2, 3, 13), rand.Reader)
+	if err != nil {
+		t.Fatalf("CommitColumn() error %v", err)
+	}
+	proof, err := ProveLookup(pr, table, column, rand.Reader)
+	if err != nil {
+		t.Fatalf("ProveLookup() error %v", err)
+	}
+	other, err := NewTable(srs, ints(2, 3, 13, 21))
+	if err != nil {
+		t.Fatalf("NewTable() error %v", err)
+	}
+	g := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+	shifted := func(p *bn256.G1) *bn256.G1 { return new(bn256.G1).Add(p, g) }
+	withEval := func(i int) *Proof {
+		p := *proof
+		at := *p.AtZeta
+		at.Ys = append([]*big.Int(nil), at.Ys...)
+		at.Ys[i] = Field.Add(at.Ys[i], bigOne)
+		p.AtZeta = &at
+		return &p
+	}
+	another, err := table.CommitColumn(srs, ints(2, 3, 13), rand.Reader)
+	if err != nil {
+		t.Fatalf("CommitColumn() error %v", err)
+	}
+	tests := []struct {
+		name  string
+		key   *TableKey
+		f     *bn256.G1
+		proof *Proof
+	}{
+		{"other table", other.Key(), column.C, proof},
+		{"wrong F", table.Key(), shifted(column.C), proof},
+		{"other column of equal values", table.Key(), another.C, proof},
+		{"no column", table.Key(), nil, proof},
+		{"wrong M", table.Key(), column.C, &Proof{M: shifted(proof.M), Phi: proof.Phi, Q: proof.Q, AtZeta: proof.AtZeta, AtZetaOmega: proof.AtZetaOmega}},
+		{"wrong m(zeta)", table.Key(), column.C, withEval(evalM)},
+		{"wrong q(zeta)", table.Key(), column.C, withEval(evalQ)},
+		{"swapped openings", table.Key(), column.C, &Proof{M: proof.M, Phi: proof.Phi, Q: proof.Q, AtZeta: proof.AtZeta, AtZetaOmega: proof.AtZeta}},
+		{"incomplete", table.Key(), column.C, &Proof{M: proof.M, Phi: proof.Phi, Q: proof.Q}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(vk, tt.key, tt.f, tt.proof) {
+				t.Error("Verify() = true; want false")
+			}
+		})
+	}
+}
+
+func TestProveLookupErrors(t *testing.T) {
+	table, err := NewTable(srs, ints(1, 2, 3))
+	if err != nil {
+		t.Fatalf("NewTable() error %v", err)
+	}
+	pr := kzg.NewProver(srs)
+
+	column, err := table.CommitColumn(srs, ints(1, 4), rand.Reader)
+	if err != nil {
+		t.Fatalf("CommitColumn() error %v", err)
+	}
+	if _, err := ProveLookup(pr, table, column, rand.Reader); !errors.Is(err, ErrNotInTable) {
+		t.Errorf("ProveLookup(missing value) error %v; want %v", err, ErrNotInTable)
+	}
+	if _, err := ProveLookup(pr, table, &Column{Poly: column.Poly}, rand.Reader); err == nil {
+		t.Error("ProveLookup(uncommitted column) error nil; want error")
+	}
+	if _, err := table.CommitColumn(srs, ints(1, 1, 1, 1, 1), rand.Reader); err == nil {
+		t.Error("CommitColumn(too long column) error nil; want error")
+	}
+	if _, err := table.CommitColumn(srs, ints(-1), rand.Reader); err == nil {
+		t.Error("CommitColumn(unreduced value) error nil; want error")
+	}
+	if _, err := NewTable(kzg.NewSRS(big.NewInt(1337), 2*4+1), ints(1, 2, 3)); err == nil {
+		t.Error("NewTable() with too small SRS; error nil; want error")
+	}
+	if _, err := NewTable(srs, nil); err == nil {
+		t.Error("NewTable(nil) error nil; want error")
+	}
+}
@@ -0,0 +1,149 @@
+package membership
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// Relationships between committed Sets follow from their vanishing
+// polynomials: A is a subset of B iff p_A divides p_B, and I is the
+// intersection of A and B iff p_I divides both and the cofactors p_A/p_I and
+// p_B/p_I are coprime. See Papamanthou et al., "Optimal Verification of
+// Operations on Dynamic Sets", https://eprint.iacr.org/2010/455.
+
+// A SubsetProof attests that a committed Set A is a subset of another, B.
+type SubsetProof struct {
+	// Cofactor is [w(s)]_2 for w = p_B / p_A.
+	Cofactor *bn256.G2
+}
+
+// An IntersectionProof attests that a committed Set I is the intersection of
+// two others, A and B.
+type IntersectionProof struct {
+	// SubsetA and SubsetB show that I is a subset of A and B respectively.
+	SubsetA, SubsetB *SubsetProof
+	// BezoutA and BezoutB are [x(s)]_1 and [y(s)]_1 for x*(p_A/p_I) +
+	// y*(p_B/p_I) = 1, showing that no member outside of I is in both A and
+	// B.
+	BezoutA, BezoutB *bn256.G1
+}
+
+// ProveSubset returns a SubsetProof that the current version of a is a subset
+// of the current version of b, or an error if it isn't. It returns
+// ErrDifferentSRS unless both Sets were committed to with the same SRS.
+func ProveSubset(a, b *Set) (*SubsetProof, error) {
+	if a.srs == nil || b.srs == nil {
+		return nil, ErrNotCommitted
+	}
+	if a.srs != b.srs {
+		return nil, ErrDifferentSRS
+	}
+	for m := range a.members {
+		if !b.members[m] {
+			return nil, fmt.Errorf("%v is not a member of the superset", m)
+		}
+	}
+	return proveSubset(a.srs, a.poly, b.poly)
+}
+
+// proveSubset returns a SubsetProof for the vanishing polynomials pa and pb of
+// a subset and its superset.
+func proveSubset(srs *kzg.SRS, pa, pb *polynomial.Polynomial) (*SubsetProof, error) {
+	w, r := pb.Div(pa, kzg.Field)
+	if !r.Eq(polynomial.ZeroPolynomial) {
+		// Unreachable as all roots of pa are roots of pb.
+		return nil, fmt.Errorf("division rest not zero: %v", r)
+	}
+	c, err := srs.CommitG2(w)
+	if err != nil {
+		return nil, err
+	}
+	return &SubsetProof{Cofactor: c}, nil
+}
+
+// VerifySubset reports whether the proof shows that the Set committed to as a
+// is a subset of the one committed to as b, by checking
+//
+//	e([p_A(s)]_1, [w(s)]_2) == e([p_B(s)]_1, [1]_2).
+func VerifySubset(a, b *kzg.Commitment, proof *SubsetProof) bool {
+	return bn256.PairingCheck(
+		[]*bn256.G1{a.G1(), new(bn256.G1).Neg(b.G1())},
+		[]*bn256.G2{proof.Cofactor, g2Generator()},
+	)
+}
+
+// ProveIntersection returns an IntersectionProof that the current version of
+// i is the intersection of the current versions of a and b, or an error if it
+// isn't. It returns ErrDifferentSRS unless all Sets were committed to with the
+// same SRS.
+func ProveIntersection(a, b, i *Set) (*IntersectionProof, error) {
+	if a.srs == nil || b.srs == nil || i.srs == nil {
+		return nil, ErrNotCommitted
+	}
+	if a.srs != i.srs || b.srs != i.srs {
+		return nil, ErrDifferentSRS
+	}
+	for m := range i.members {
+		if !a.members[m] || !b.members[m] {
+			return nil, fmt.Errorf("%v is a member of the intersection but not of both sets", m)
+		}
+	}
+	for m := range a.members {
+		if b.members[m] && !i.members[m] {
+			return nil, fmt.Errorf("%v is a member of both sets but not of the intersection", m)
+		}
+	}
+
+	subA, err := proveSubset(i.srs, i.poly, a.poly)
+	if err != nil {
+		return nil, err
+	}
+	subB, err := proveSubset(i.srs, i.poly, b.poly)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, _ := a.poly.Div(i.poly, kzg.Field)
+	cb, _ := b.poly.Div(i.poly, kzg.Field)
+	g, x, y := polynomial.XGCD(ca, cb, kzg.Field)
+	if !g.Eq(polynomial.OnePolynomial) {
+		// Unreachable as the cofactors share no roots.
+		return nil, fmt.Errorf("gcd of cofactors is %v; want 1", g)
+	}
+	bezoutA, err := i.srs.Commit(x)
+	if err != nil {
+		return nil, err
+	}
+	bezoutB, err := i.srs.Commit(y)
+	if err != nil {
+		return nil, err
+	}
+	return &IntersectionProof{SubsetA: subA, SubsetB: subB, BezoutA: bezoutA, BezoutB: bezoutB}, nil
+}
+
+// VerifyIntersection reports whether the proof shows that the Set committed to
+// as i is the intersection of the ones committed to as a and b, by verifying
+// both SubsetProofs and checking
+//
+//	e([x(s)]_1, [p_A(s)/p_I(s)]_2) * e([y(s)]_1, [p_B(s)/p_I(s)]_2) == e([1]_1, [1]_2).
+func VerifyIntersection(a, b, i *kzg.Commitment, proof *IntersectionProof) bool {
+	if !VerifySubset(i, a, proof.SubsetA) || !VerifySubset(i, b, proof.SubsetB) {
+		return false
+	}
+	return bn256.PairingCheck(
+		[]*bn256.G1{proof.BezoutA, proof.BezoutB, new(bn256.G1).Neg(g1Generator())},
+		[]*bn256.G2{proof.SubsetA.Cofactor, proof.SubsetB.Cofactor, g2Generator()},
+	)
+}
+
+func g1Generator() *bn256.G1 {
+	return new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+}
+
+func g2Generator() *bn256.G2 {
+	return new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+}
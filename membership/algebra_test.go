@@ -0,0 +1,124 @@
+package membership
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/kzg"
+)
+
+func TestSubset(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+
+	tests := []struct {
+		a, b []int64
+		want bool
+	}{
+		{a: []int64{}, b: []int64{1, 2}, want: true},
+		{a: []int64{2}, b: []int64{1, 2, 3}, want: true},
+		{a: []int64{1, 2, 3}, b: []int64{3, 2, 1}, want: true},
+		{a: []int64{1, 4}, b: []int64{1, 2, 3}, want: false},
+		{a: []int64{1, 2, 3}, b: []int64{2}, want: false},
+	}
+
+	for _, tt := range tests {
+		a, b := committedSet(t, bigInts(tt.a...), srs), committedSet(t, bigInts(tt.b...), srs)
+		proof, err := ProveSubset(a, b)
+		if !tt.want {
+			if err == nil {
+				t.Errorf("ProveSubset(%v, %v): got nil error", tt.a, tt.b)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ProveSubset(%v, %v): %v", tt.a, tt.b, err)
+		}
+		if !VerifySubset(a.Commitment(), b.Commitment(), proof) {
+			t.Errorf("VerifySubset(%v, %v) = false; want true", tt.a, tt.b)
+		}
+		other := committedSet(t, bigInts(100), srs)
+		if VerifySubset(a.Commitment(), other.Commitment(), proof) {
+			t.Errorf("VerifySubset(%v, <other set>, ProveSubset(%v, %v)) = true; want false", tt.a, tt.a, tt.b)
+		}
+	}
+
+	if _, err := ProveSubset(NewSet(bigInts(1)), committedSet(t, bigInts(1), srs)); !errors.Is(err, ErrNotCommitted) {
+		t.Errorf("ProveSubset(<uncommitted>): got err %v; want %v", err, ErrNotCommitted)
+	}
+	other := kzg.NewSRS(big.NewInt(42), 10)
+	if _, err := ProveSubset(committedSet(t, bigInts(1), other), committedSet(t, bigInts(1, 2), srs)); !errors.Is(err, ErrDifferentSRS) {
+		t.Errorf("ProveSubset(<different SRSs>): got err %v; want %v", err, ErrDifferentSRS)
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+
+	tests := []struct {
+		a, b, i []int64
+		want    bool
+	}{
+		{a: []int64{1, 2, 3}, b: []int64{2, 3, 4, 5}, i: []int64{2, 3}, want: true},
+		{a: []int64{1, 2}, b: []int64{3, 4}, i: []int64{}, want: true},
+		{a: []int64{1, 2}, b: []int64{1, 2}, i: []int64{1, 2}, want: true},
+		{a: []int64{1, 2, 3}, b: []int64{2}, i: []int64{2}, want: true},
+		// Incomplete and excessive intersections.
+		{a: []int64{1, 2, 3}, b: []int64{2, 3, 4}, i: []int64{2}, want: false},
+		{a: []int64{1, 2, 3}, b: []int64{2, 3, 4}, i: []int64{1, 2, 3}, want: false},
+	}
+
+	for _, tt := range tests {
+		a, b, i := committedSet(t, bigInts(tt.a...), srs), committedSet(t, bigInts(tt.b...), srs), committedSet(t, bigInts(tt.i...), srs)
+		proof, err := ProveIntersection(a, b, i)
+		if !tt.want {
+			if err == nil {
+				t.Errorf("ProveIntersection(%v, %v, %v): got nil error", tt.a, tt.b, tt.i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ProveIntersection(%v, %v, %v): %v", tt.a, tt.b, tt.i, err)
+		}
+		if !VerifyIntersection(a.Commitment(), b.Commitment(), i.Commitment(), proof) {
+			t.Errorf("VerifyIntersection(%v, %v, %v) = false; want true", tt.a, tt.b, tt.i)
+		}
+	}
+
+	other := kzg.NewSRS(big.NewInt(42), 10)
+	a, b := committedSet(t, bigInts(1, 2), srs), committedSet(t, bigInts(2, 3), srs)
+	for _, sets := range [][3]*Set{
+		{a, b, committedSet(t, bigInts(2), other)},
+		{a, committedSet(t, bigInts(2, 3), other), committedSet(t, bigInts(2), srs)},
+	} {
+		if _, err := ProveIntersection(sets[0], sets[1], sets[2]); !errors.Is(err, ErrDifferentSRS) {
+			t.Errorf("ProveIntersection(<different SRSs>): got err %v; want %v", err, ErrDifferentSRS)
+		}
+	}
+}
+
+func TestIntersectionIncompleteRejected(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	a, b := committedSet(t, bigInts(1, 2, 3), srs), committedSet(t, bigInts(2, 3, 4), srs)
+	partial := committedSet(t, bigInts(2), srs)
+
+	// Both subset proofs of the partial intersection are valid, but the
+	// cofactors (v-1)(v-3) and (v-3)(v-4) aren't coprime, so no Bézout
+	// cofactors exist. Reuse those of the honest proof instead.
+	subA, err := ProveSubset(partial, a)
+	if err != nil {
+		t.Fatalf("ProveSubset(): %v", err)
+	}
+	subB, err := ProveSubset(partial, b)
+	if err != nil {
+		t.Fatalf("ProveSubset(): %v", err)
+	}
+	honest, err := ProveIntersection(a, b, committedSet(t, bigInts(2, 3), srs))
+	if err != nil {
+		t.Fatalf("ProveIntersection(): %v", err)
+	}
+	forged := &IntersectionProof{SubsetA: subA, SubsetB: subB, BezoutA: honest.BezoutA, BezoutB: honest.BezoutB}
+	if VerifyIntersection(a.Commitment(), b.Commitment(), partial.Commitment(), forged) {
+		t.Errorf("VerifyIntersection(<partial intersection>) = true; want false")
+	}
+}
@@ -0,0 +1,63 @@
+package membership
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+)
+
+// A HiddenProof attests that the value committed to by Member is in a
+// committed Set, without revealing which.
+type HiddenProof struct {
+	// Opening is the opening of the vanishing polynomial at the hidden member,
+	// whose Y is the identity, i.e. a commitment to 0 without blinding.
+	Opening *kzg.HiddenProof
+	// Version is the version of the Set for which the HiddenProof was
+	// created. Like Proof.Version, it is an unauthenticated label.
+	Version uint64
+}
+
+// Member returns the Pedersen commitment kzg.CommitScalar(z, blinder) to the
+// hidden member z, which can be linked to other proofs about z by the prover
+// knowing the blinder.
+func (p *HiddenProof) Member() *bn256.G1 {
+	return new(bn256.G1).Set(p.Opening.Z)
+}
+
+// ProveMemberHidden returns a HiddenProof that z is in the committed Set, or
+// an error if it isn't. The blinder of the commitment to z MUST be uniformly
+// random, e.g. drawn with kzg.Field.Random, for the HiddenProof to hide z. The
+// randomness of the opening is drawn from r.
+func (s *Set) ProveMemberHidden(z, blinder *big.Int, r io.Reader) (*HiddenProof, error) {
+	if s.srs == nil {
+		return nil, ErrNotCommitted
+	}
+	if !s.Contains(z) {
+		return nil, fmt.Errorf("%v is not a member of the set", z)
+	}
+
+	proof, err := kzg.OpenHidden(s.srs, s.poly, canonical(z), blinder, big.NewInt(0), r)
+	if err != nil {
+		return nil, err
+	}
+	return &HiddenProof{Opening: proof, Version: s.Version()}, nil
+}
+
+// VerifyMemberHidden reports whether the proof shows that its hidden Member is
+// in the Set with the specified commitment, i.e. whether the committed
+// polynomial evaluates to y = 0 at the committed point, by checking that the
+// commitment to y is the identity.
+func VerifyMemberHidden(vk *kzg.VerifierKey, commitment *kzg.Commitment, proof *HiddenProof) bool {
+	if proof == nil || proof.Opening == nil || proof.Opening.Y == nil {
+		return false
+	}
+	zero := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	if !bytes.Equal(proof.Opening.Y.Marshal(), zero.Marshal()) {
+		return false
+	}
+	return kzg.VerifyHidden(vk, commitment, proof.Opening)
+}
@@ -0,0 +1,75 @@
+package membership
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+)
+
+func TestMembersHidden(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	members := bigInts(1, 2, 42, -7, 100000)
+	s := committedSet(t, members, srs)
+
+	for _, m := range members {
+		blinder, err := kzg.Field.Random(rand.Reader)
+		if err != nil {
+			t.Fatalf("Random(): %v", err)
+		}
+		proof, err := s.ProveMemberHidden(m, blinder, rand.Reader)
+		if err != nil {
+			t.Fatalf("ProveMemberHidden(%v): %v", m, err)
+		}
+		if !VerifyMemberHidden(vk, s.Commitment(), proof) {
+			t.Errorf("VerifyMemberHidden(ProveMemberHidden(%v)) = false; want true", m)
+		}
+		if want := kzg.CommitScalar(m, blinder); !bytes.Equal(proof.Member().Marshal(), want.Marshal()) {
+			t.Errorf("ProveMemberHidden(%v).Member() != CommitScalar(%v, blinder)", m, m)
+		}
+		other := committedSet(t, bigInts(3, 4), srs)
+		if VerifyMemberHidden(vk, other.Commitment(), proof) {
+			t.Errorf("VerifyMemberHidden(<other set>, ProveMemberHidden(%v)) = true; want false", m)
+		}
+	}
+}
+
+func TestNonMembersHidden(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+	s := committedSet(t, bigInts(1, 2, 3), srs)
+
+	if _, err := s.ProveMemberHidden(big.NewInt(4), big.NewInt(1), rand.Reader); err == nil {
+		t.Errorf("ProveMemberHidden(4) for non-member: got nil error")
+	}
+	if _, err := NewSet(bigInts(1)).ProveMemberHidden(big.NewInt(1), big.NewInt(1), rand.Reader); !errors.Is(err, ErrNotCommitted) {
+		t.Errorf("ProveMemberHidden() before Commit(): got err %v; want %v", err, ErrNotCommitted)
+	}
+
+	// A valid opening at a non-member commits to a non-zero y.
+	opening, err := kzg.OpenHidden(srs, s.poly, big.NewInt(4), big.NewInt(1), big.NewInt(0), rand.Reader)
+	if err != nil {
+		t.Fatalf("OpenHidden(4): %v", err)
+	}
+	if !kzg.VerifyHidden(vk, s.Commitment(), opening) {
+		t.Fatalf("VerifyHidden(OpenHidden(4)) = false; want true")
+	}
+	if VerifyMemberHidden(vk, s.Commitment(), &HiddenProof{Opening: opening}) {
+		t.Errorf("VerifyMemberHidden(<opening at non-member>) = true; want false")
+	}
+
+	// Even with the claimed y replaced by the identity.
+	opening.Y = new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	if VerifyMemberHidden(vk, s.Commitment(), &HiddenProof{Opening: opening}) {
+		t.Errorf("VerifyMemberHidden(<opening at non-member with identity Y>) = true; want false")
+	}
+	if VerifyMemberHidden(vk, s.Commitment(), &HiddenProof{}) {
+		t.Errorf("VerifyMemberHidden(<empty proof>) = true; want false")
+	}
+}
@@ -0,0 +1,185 @@
+// Package membership implements set-membership proofs based on KZG polynomial
+// commitments.
+//
+// A set S is encoded as the roots of its vanishing polynomial
+// p(v) = prod_{z in S} (v - z). Committing to p hides the members and proving
+// that z is a member amounts to opening the commitment at z and showing that
+// p(z) = 0.
+package membership
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// ErrNotCommitted is returned by operations that require a Set to have been
+// committed to with Set.Commit.
+var ErrNotCommitted = errors.New("set not committed")
+
+// ErrDifferentSRS is returned by operations relating Sets that were committed
+// to with different SRSs.
+var ErrDifferentSRS = errors.New("sets committed to with different SRSs")
+
+// A Set is a set of field elements, which can be committed to.
+type Set struct {
+	members map[string]bool
+	poly    *polynomial.Polynomial
+
+	// srs is nil until the Set is committed to.
+	srs *kzg.SRS
+	// history holds the commitments to all versions of the Set since it was
+	// committed to, indexed by version.
+	history []*kzg.Commitment
+}
+
+// A Proof attests that a value is a member of a committed Set.
+type Proof struct {
+	// Quotient is [q(s)]_2 for q(v) = p(v) / (v - z).
+	Quotient *bn256.G2
+	// Version is the version of the Set for which the Proof was created. It
+	// is an unauthenticated label; see VerifyAtVersion.
+	Version uint64
+}
+
+// canonical returns z reduced into kzg.Field.
+func canonical(z *big.Int) *big.Int {
+	return new(big.Int).Mod(z, kzg.Field.Order())
+}
+
+// NewSet returns a Set of the specified members. Members are reduced into
+// kzg.Field and duplicates are ignored.
+func NewSet(members []*big.Int) *Set {
+	s := &Set{members: make(map[string]bool)}
+
+	var roots []*big.Int
+	for _, m := range members {
+		z := canonical(m)
+		if s.members[z.String()] {
+			continue
+		}
+		s.members[z.String()] = true
+		roots = append(roots, z)
+	}
+	s.poly = polynomial.FromRoots(roots, kzg.Field)
+	return s
+}
+
+// Commit commits to the Set with the SRS and returns the commitment, which can
+// be shared publicly. The number of members can't exceed srs.MaxDegree(). A
+// Set can only be committed to once; subsequent updates are committed to with
+// the same SRS.
+func (s *Set) Commit(srs *kzg.SRS) (*kzg.Commitment, error) {
+	if s.srs != nil {
+		return nil, fmt.Errorf("set already committed")
+	}
+	c, err := kzg.Commit(srs, s.poly)
+	if err != nil {
+		return nil, fmt.Errorf("committing to set of %d members: %v", len(s.members), err)
+	}
+	s.srs = srs
+	s.history = []*kzg.Commitment{c}
+	return copyCommitment(c), nil
+}
+
+func copyCommitment(c *kzg.Commitment) *kzg.Commitment {
+	return (*kzg.Commitment)(new(bn256.G1).Set(c.G1()))
+}
+
+// Commitment returns the commitment to the current version of the Set, or nil
+// if it hasn't been committed to.
+func (s *Set) Commitment() *kzg.Commitment {
+	if s.srs == nil {
+		return nil
+	}
+	return copyCommitment(s.history[len(s.history)-1])
+}
+
+// Len returns the number of distinct members in the Set.
+func (s *Set) Len() int {
+	return len(s.members)
+}
+
+// Contains reports whether z is a member of the Set.
+func (s *Set) Contains(z *big.Int) bool {
+	return s.members[canonical(z).String()]
+}
+
+// ProveMember returns a Proof that z is in the committed Set, or an error if
+// it isn't.
+func (s *Set) ProveMember(z *big.Int) (*Proof, error) {
+	if s.srs == nil {
+		return nil, ErrNotCommitted
+	}
+	if !s.Contains(z) {
+		return nil, fmt.Errorf("%v is not a member of the set", z)
+	}
+
+	proof, err := kzg.Open(s.srs, s.poly, z)
+	if err != nil {
+		return nil, err
+	}
+	if proof.Y.Sign() != 0 {
+		// Unreachable as all members are roots.
+		return nil, fmt.Errorf("vanishing polynomial evaluates to %v at member %v", proof.Y, z)
+	}
+	return &Proof{Quotient: proof.Quotient, Version: s.Version()}, nil
+}
+
+// VerifyMember reports whether the proof shows that z is in the Set with the
+// specified commitment, i.e. whether the committed polynomial evaluates to
+// y = 0 at z.
+//
+// Verification is independent of the Set itself, but requires the VerifierKey
+// of the SRS with which the Set was committed to.
+func VerifyMember(vk *kzg.VerifierKey, commitment *kzg.Commitment, z *big.Int, proof *Proof) bool {
+	return kzg.Verify(vk, commitment, &kzg.Proof{
+		Z:        canonical(z),
+		Y:        big.NewInt(0),
+		Quotient: proof.Quotient,
+	})
+}
+
+// ProveAll returns Proofs for all members of the committed Set, sorted in
+// ascending order, and the respective members.
+//
+// The quotients of all members share the FK20 precomputation of
+// kzg.SRS.PrecomputeOpenings, with O(n log n) group operations for n members,
+// and are then evaluated at all members at once with kzg.Openings.OpenMany, in
+// O(n log^2 n) group operations instead of a polynomial division or MSM per
+// member.
+func (s *Set) ProveAll() ([]*big.Int, []*Proof, error) {
+	if s.srs == nil {
+		return nil, nil, ErrNotCommitted
+	}
+	o, err := s.srs.PrecomputeOpenings(s.poly)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := make([]*big.Int, 0, len(s.members))
+	for m := range s.members {
+		z, _ := new(big.Int).SetString(m, 10)
+		members = append(members, z)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Cmp(members[j]) < 0 })
+
+	openings, err := o.OpenMany(members)
+	if err != nil {
+		return nil, nil, err
+	}
+	proofs := make([]*Proof, len(members))
+	for i, proof := range openings {
+		if proof.Y.Sign() != 0 {
+			// Unreachable as all members are roots.
+			return nil, nil, fmt.Errorf("vanishing polynomial evaluates to %v at member %v", proof.Y, members[i])
+		}
+		proofs[i] = &Proof{Quotient: proof.Quotient, Version: s.Version()}
+	}
+	return members, proofs, nil
+}
@@ -0,0 +1,183 @@
+package membership
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+)
+
+func bigInts(xs ...int64) []*big.Int {
+	var bs []*big.Int
+	for _, x := range xs {
+		bs = append(bs, big.NewInt(x))
+	}
+	return bs
+}
+
+func TestMembers(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	members := bigInts(1, 2, 42, -7, 100000)
+	s := committedSet(t, members, srs)
+
+	for _, m := range members {
+		proof, err := s.ProveMember(m)
+		if err != nil {
+			t.Fatalf("ProveMember(%v): %v", m, err)
+		}
+		if !VerifyMember(vk, s.Commitment(), m, proof) {
+			t.Errorf("VerifyMember(%v, ProveMember(%v)) = false; want true", m, m)
+		}
+		// The proof is specific to the member.
+		other := new(big.Int).Add(m, big.NewInt(1))
+		if VerifyMember(vk, s.Commitment(), other, proof) {
+			t.Errorf("VerifyMember(%v, ProveMember(%v)) = true; want false", other, m)
+		}
+	}
+}
+
+func TestNonMembers(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	s := committedSet(t, bigInts(1, 2, 3), srs)
+
+	for _, z := range bigInts(0, 4, -1, 1000) {
+		if _, err := s.ProveMember(z); err == nil {
+			t.Errorf("ProveMember(%v) for non-member: got nil error", z)
+		}
+
+		// Reusing the proof of a member, or an arbitrary point, fails.
+		proof, err := s.ProveMember(big.NewInt(1))
+		if err != nil {
+			t.Fatalf("ProveMember(1): %v", err)
+		}
+		forgeries := []*Proof{
+			proof,
+			{Quotient: new(bn256.G2).ScalarBaseMult(big.NewInt(1))},
+		}
+		for i, f := range forgeries {
+			if VerifyMember(vk, s.Commitment(), z, f) {
+				t.Errorf("VerifyMember(%v, <forgery %d>) for non-member = true; want false", z, i)
+			}
+		}
+	}
+}
+
+func TestEmptySet(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	s := committedSet(t, nil, srs)
+	if s.Len() != 0 {
+		t.Errorf("NewSet(nil).Len() = %d; want 0", s.Len())
+	}
+
+	for _, z := range bigInts(0, 1) {
+		if _, err := s.ProveMember(z); err == nil {
+			t.Errorf("ProveMember(%v) on empty set: got nil error", z)
+		}
+		for _, q := range bigInts(0, 1, 42) {
+			if VerifyMember(vk, s.Commitment(), z, &Proof{Quotient: new(bn256.G2).ScalarBaseMult(q)}) {
+				t.Errorf("VerifyMember(%v, [%v]_2) on empty set = true; want false", z, q)
+			}
+		}
+	}
+}
+
+func TestNewSetDuplicates(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 3)
+
+	s := committedSet(t, bigInts(1, 2, 1, 2, 3), srs)
+	if s.Len() != 3 {
+		t.Errorf("Len() = %d; want 3", s.Len())
+	}
+
+	if _, err := NewSet(bigInts(1, 2, 3, 4)).Commit(srs); err == nil {
+		t.Errorf("Commit() of 4 members with SRS of max degree 3: got nil error")
+	}
+}
+
+func TestNotCommitted(t *testing.T) {
+	s := NewSet(bigInts(1, 2, 3))
+	if s.Commitment() != nil {
+		t.Errorf("Commitment() before Commit() = %v; want nil", s.Commitment())
+	}
+	if _, err := s.ProveMember(big.NewInt(1)); !errors.Is(err, ErrNotCommitted) {
+		t.Errorf("ProveMember() before Commit(): got err %v; want %v", err, ErrNotCommitted)
+	}
+	if _, err := s.CommitmentAt(0); !errors.Is(err, ErrNotCommitted) {
+		t.Errorf("CommitmentAt() before Commit(): got err %v; want %v", err, ErrNotCommitted)
+	}
+
+	// Updates before committing are reflected in the commitment.
+	if _, err := s.Add(big.NewInt(4)); err != nil {
+		t.Fatalf("Add(4): %v", err)
+	}
+	srs := kzg.NewSRS(big.NewInt(1337), 4)
+	c, err := s.Commit(srs)
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	if want := committedSet(t, bigInts(1, 2, 3, 4), srs).Commitment(); !bytes.Equal(c.G1().Marshal(), want.G1().Marshal()) {
+		t.Errorf("Commit() after Add(4) differs from Commit() of NewSet(1, 2, 3, 4)")
+	}
+	if s.Version() != 0 {
+		t.Errorf("Version() after Commit() = %d; want 0", s.Version())
+	}
+	if _, err := s.Commit(srs); err == nil {
+		t.Errorf("second Commit(): got nil error")
+	}
+}
+
+func committedSet(t *testing.T, members []*big.Int, srs *kzg.SRS) *Set {
+	t.Helper()
+	s := NewSet(members)
+	if _, err := s.Commit(srs); err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	return s
+}
+
+func TestProveAll(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	s := NewSet(bigInts(42, 1, -7, 100000, 2))
+	if _, _, err := s.ProveAll(); !errors.Is(err, ErrNotCommitted) {
+		t.Errorf("ProveAll() before Commit(): got err %v; want %v", err, ErrNotCommitted)
+	}
+	if _, err := s.Commit(srs); err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+
+	members, proofs, err := s.ProveAll()
+	if err != nil {
+		t.Fatalf("ProveAll(): %v", err)
+	}
+	want := bigInts(1, 2, 42, 100000)
+	want = append(want, canonical(big.NewInt(-7)))
+	if len(members) != len(want) || len(proofs) != len(want) {
+		t.Fatalf("ProveAll() returned %d members and %d proofs; want %d", len(members), len(proofs), len(want))
+	}
+	for i, m := range members {
+		if m.Cmp(want[i]) != 0 {
+			t.Errorf("ProveAll() member %d = %v; want %v", i, m, want[i])
+		}
+		if !VerifyMember(vk, s.Commitment(), m, proofs[i]) {
+			t.Errorf("VerifyMember(%v, ProveAll()[%d]) = false; want true", m, i)
+		}
+		single, err := s.ProveMember(m)
+		if err != nil {
+			t.Fatalf("ProveMember(%v): %v", m, err)
+		}
+		if !bytes.Equal(single.Quotient.Marshal(), proofs[i].Quotient.Marshal()) {
+			t.Errorf("ProveAll()[%d] != ProveMember(%v)", i, m)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package membership
+
+import (
+	"fmt"
+
+	"zkp.xyz/membership/kzg"
+)
+
+// Migrate returns a copy of the current version of s committed to with srs,
+// e.g. when replacing the setup s was committed to with, along with a
+// kzg.EqualityProof for VerifyMigration that the commitments to both hold the
+// same members. s is left unchanged, and the history of the migrated Set
+// starts with its commitment under srs.
+//
+// Sets are committed to on bn256, so both SRSs are over it. Migrating a Set to
+// another curve, such as BLS12-381, isn't supported: openings of the vanishing
+// polynomials at a common integer challenge z give Z(z) modulo each of the two
+// scalar field orders, and by the Chinese remainder theorem any pair of such
+// values is consistent with some integer, whatever the sets. A sound proof
+// would need to show that the members committed to in both groups are the
+// same bounded integers, with a cross-group discrete logarithm equality and
+// range proof per member.
+func (s *Set) Migrate(srs *kzg.SRS) (*Set, *kzg.EqualityProof, error) {
+	if s.srs == nil {
+		return nil, nil, ErrNotCommitted
+	}
+	m := &Set{members: make(map[string]bool, len(s.members)), poly: s.poly.Clone()}
+	for z := range s.members {
+		m.members[z] = true
+	}
+	if _, err := m.Commit(srs); err != nil {
+		return nil, nil, fmt.Errorf("migrating set: %v", err)
+	}
+	proof, err := kzg.ProveEqual(s.srs, s.Commitment().G1(), s.poly, srs, m.Commitment().G1(), m.poly)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, proof, nil
+}
+
+// VerifyMigration reports whether the proof shows that the Sets committed to
+// as from, verified with vkFrom, and as to, verified with vkTo, hold the same
+// members, as for a Set migrated with Migrate.
+func VerifyMigration(vkFrom *kzg.VerifierKey, from *kzg.Commitment, vkTo *kzg.VerifierKey, to *kzg.Commitment, proof *kzg.EqualityProof) bool {
+	if proof == nil || proof.Proof1 == nil || proof.Proof2 == nil {
+		return false
+	}
+	return kzg.VerifyEqual(vkFrom, from.G1(), vkTo, to.G1(), proof)
+}
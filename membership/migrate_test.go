@@ -0,0 +1,62 @@
+package membership
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/kzg"
+)
+
+func TestMigrate(t *testing.T) {
+	from := kzg.NewSRS(big.NewInt(1337), 5)
+	to := kzg.NewSRS(big.NewInt(4242), 10)
+	vkFrom, vkTo := from.VerifierKey(), to.VerifierKey()
+
+	for _, members := range [][]int64{{1, 2, 3}, {}, {-5, 0, 7, 100}} {
+		s := committedSet(t, bigInts(members...), from)
+		before := s.Commitment()
+		m, proof, err := s.Migrate(to)
+		if err != nil {
+			t.Fatalf("Migrate(<set of %v>): %v", members, err)
+		}
+		if !VerifyMigration(vkFrom, s.Commitment(), vkTo, m.Commitment(), proof) {
+			t.Errorf("VerifyMigration(<set of %v>) = false; want true", members)
+		}
+		if want := committedSet(t, bigInts(members...), to).Commitment(); m.Commitment().G1().String() != want.G1().String() {
+			t.Errorf("Migrate(<set of %v>).Commitment() differs from committing to the set with the new SRS", members)
+		}
+		if s.Commitment().G1().String() != before.G1().String() {
+			t.Errorf("Migrate(<set of %v>) changed the commitment of the original set", members)
+		}
+		if m.Version() != 0 || m.Len() != s.Len() {
+			t.Errorf("Migrate(<set of %v>) at version %d with %d members; want 0 and %d", members, m.Version(), m.Len(), s.Len())
+		}
+
+		// The migrated Set evolves independently.
+		if _, err := m.Add(big.NewInt(42)); err != nil {
+			t.Fatalf("Add() to migrated set: %v", err)
+		}
+		if s.Contains(big.NewInt(42)) {
+			t.Errorf("Add() to migrated set added to the original set")
+		}
+		if VerifyMigration(vkFrom, s.Commitment(), vkTo, m.Commitment(), proof) {
+			t.Errorf("VerifyMigration(<set of %v>, <updated migrated set>) = true; want false", members)
+		}
+		other := committedSet(t, bigInts(100), to)
+		if VerifyMigration(vkFrom, s.Commitment(), vkTo, other.Commitment(), proof) {
+			t.Errorf("VerifyMigration(<set of %v>, <other set>) = true; want false", members)
+		}
+	}
+
+	if _, _, err := NewSet(bigInts(1)).Migrate(to); !errors.Is(err, ErrNotCommitted) {
+		t.Errorf("Migrate() before Commit(): got err %v; want %v", err, ErrNotCommitted)
+	}
+	large := committedSet(t, bigInts(1, 2, 3, 4, 5, 6, 7, 8), to)
+	if _, _, err := large.Migrate(from); err == nil {
+		t.Errorf("Migrate() to SRS of too small degree: got nil error")
+	}
+	if VerifyMigration(vkFrom, NewSet(nil).Commitment(), vkTo, NewSet(nil).Commitment(), nil) {
+		t.Errorf("VerifyMigration(<nil proof>) = true; want false")
+	}
+}
@@ -0,0 +1,105 @@
+package membership
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/polynomial"
+)
+
+// linear returns the polynomial v - z.
+func linear(z *big.Int) *polynomial.Polynomial {
+	return polynomial.NewPolynomial([]*big.Int{kzg.Field.Sub(big.NewInt(0), z), big.NewInt(1)})
+}
+
+// Add adds z to the Set, multiplying its polynomial by (v - z) instead of
+// recomputing the product over all members. If the Set is committed to, it
+// returns the commitment to the new version, otherwise nil. It returns an
+// error if z is already a member or the SRS of a committed Set is too small,
+// in which case the Set is unchanged.
+//
+// The new commitment is derived from the current one C to p as
+//
+//	[p(s) (s - z)]_1 = [s p(s)]_1 - z C,
+//
+// where [s p(s)]_1 is p committed to with the powers of the SRS shifted by
+// one. Without knowledge of s, this multi-exponentiation over the current
+// coefficients can't be avoided, but the product p (v - z) needn't be
+// committed to.
+func (s *Set) Add(z *big.Int) (*kzg.Commitment, error) {
+	z = canonical(z)
+	if s.members[z.String()] {
+		return nil, fmt.Errorf("%v is already a member of the set", z)
+	}
+	p := s.poly.Mul(linear(z), kzg.Field)
+	if s.srs != nil {
+		c, err := s.shifted(s.poly)
+		if err != nil {
+			return nil, fmt.Errorf("committing to updated set: %v", err)
+		}
+		c.Add(c, new(bn256.G1).ScalarMult(new(bn256.G1).Neg(s.history[len(s.history)-1].G1()), z))
+		s.history = append(s.history, (*kzg.Commitment)(c))
+	}
+	s.poly = p
+	s.members[z.String()] = true
+	return s.Commitment(), nil
+}
+
+// shifted returns [s p(s)]_1 for the SRS of the Set.
+func (s *Set) shifted(p *polynomial.Polynomial) (*bn256.G1, error) {
+	p = p.Normalize(kzg.Field)
+	if len(*p) >= len(s.srs.G1) {
+		return nil, fmt.Errorf("shifted polynomial degree %d exceeds SRS max degree %d", len(*p), len(s.srs.G1)-1)
+	}
+	return msm.MultiExp(s.srs.G1[1:len(*p)+1], *p)
+}
+
+// Remove removes z from the Set, dividing its polynomial by (v - z). Like
+// Add, it returns the commitment to the new version of a committed Set. It
+// returns an error if z isn't a member, in which case the Set is unchanged.
+//
+// As for Add, the new commitment is derived from the current one C: for the
+// quotient q, C = [s q(s)]_1 - z [q(s)]_1, so [q(s)]_1 = z^-1 ([s q(s)]_1 - C).
+// For z = 0, C is [s q(s)]_1 itself and q is committed to directly.
+func (s *Set) Remove(z *big.Int) (*kzg.Commitment, error) {
+	z = canonical(z)
+	if !s.members[z.String()] {
+		return nil, fmt.Errorf("%v is not a member of the set", z)
+	}
+	q, r := s.poly.Div(linear(z), kzg.Field)
+	if !r.Eq(polynomial.ZeroPolynomial) {
+		// Unreachable as all members are roots.
+		return nil, fmt.Errorf("division rest not zero: %v", r)
+	}
+	if s.srs != nil {
+		c, err := s.removed(q, z)
+		if err != nil {
+			return nil, fmt.Errorf("committing to updated set: %v", err)
+		}
+		s.history = append(s.history, (*kzg.Commitment)(c))
+	}
+	s.poly = q
+	delete(s.members, z.String())
+	return s.Commitment(), nil
+}
+
+// removed returns the commitment to q = p / (v - z) for the current
+// commitment to p.
+func (s *Set) removed(q *polynomial.Polynomial, z *big.Int) (*bn256.G1, error) {
+	if z.Sign() == 0 {
+		return s.srs.Commit(q)
+	}
+	zInv, err := kzg.Field.MultInverse(z)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.shifted(q)
+	if err != nil {
+		return nil, err
+	}
+	c.Add(c, new(bn256.G1).Neg(s.history[len(s.history)-1].G1()))
+	return c.ScalarMult(c, zInv), nil
+}
@@ -0,0 +1,127 @@
+package membership
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/kzg"
+)
+
+func TestAddRemove(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 4)
+	vk := srs.VerifierKey()
+
+	s := committedSet(t, bigInts(1, 2, 3), srs)
+	if _, err := s.Add(big.NewInt(42)); err != nil {
+		t.Fatalf("Add(42): %v", err)
+	}
+	c, err := s.Remove(big.NewInt(2))
+	if err != nil {
+		t.Fatalf("Remove(2): %v", err)
+	}
+
+	want := committedSet(t, bigInts(1, 3, 42), srs)
+	if !bytes.Equal(c.G1().Marshal(), want.Commitment().G1().Marshal()) {
+		t.Errorf("Remove(2) after Add(42) = %v; want commitment of NewSet(1, 3, 42)", c.G1())
+	}
+	if !bytes.Equal(s.Commitment().G1().Marshal(), c.G1().Marshal()) {
+		t.Errorf("Commitment() != commitment returned by Remove()")
+	}
+	if s.Len() != 3 || s.Contains(big.NewInt(2)) || !s.Contains(big.NewInt(42)) {
+		t.Errorf("members after Add(42), Remove(2) not {1, 3, 42}")
+	}
+	if got := s.Version(); got != 2 {
+		t.Errorf("Version() = %d; want 2", got)
+	}
+
+	proof, err := s.ProveMember(big.NewInt(42))
+	if err != nil {
+		t.Fatalf("ProveMember(42): %v", err)
+	}
+	if !VerifyMember(vk, c, big.NewInt(42), proof) {
+		t.Errorf("VerifyMember(<added member>) = false; want true")
+	}
+	if _, err := s.ProveMember(big.NewInt(2)); err == nil {
+		t.Errorf("ProveMember(<removed member>): got nil error")
+	}
+
+	// Failed updates leave the Set unchanged.
+	for _, tt := range []struct {
+		name   string
+		update func(*big.Int) (*kzg.Commitment, error)
+		z      int64
+	}{
+		{name: "Add(<member>)", update: s.Add, z: 1},
+		{name: "Remove(<non-member>)", update: s.Remove, z: 2},
+	} {
+		if _, err := tt.update(big.NewInt(tt.z)); err == nil {
+			t.Errorf("%s: got nil error", tt.name)
+		}
+	}
+	if _, err := s.Add(big.NewInt(5)); err != nil {
+		t.Fatalf("Add(5): %v", err)
+	}
+	if _, err := s.Add(big.NewInt(6)); err == nil {
+		t.Errorf("Add() exceeding SRS max degree: got nil error")
+	}
+	if s.Len() != 4 || s.Version() != 3 || s.Contains(big.NewInt(6)) {
+		t.Errorf("failed Add() modified the set")
+	}
+}
+
+func TestAddRemoveIncremental(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 8)
+	s := committedSet(t, bigInts(3, 1, 4), srs)
+	members := map[int64]bool{3: true, 1: true, 4: true}
+
+	for _, tt := range []struct {
+		add bool
+		z   int64
+	}{
+		{add: true, z: 0},
+		{add: true, z: -5},
+		{add: false, z: 1},
+		{add: false, z: 0},
+		{add: true, z: 9},
+		{add: false, z: 3},
+		{add: false, z: 4},
+		{add: false, z: -5},
+		{add: false, z: 9},
+		{add: true, z: 2},
+	} {
+		update, name := s.Remove, "Remove"
+		if tt.add {
+			update, name = s.Add, "Add"
+		}
+		c, err := update(big.NewInt(tt.z))
+		if err != nil {
+			t.Fatalf("%s(%d): %v", name, tt.z, err)
+		}
+		members[tt.z] = tt.add
+
+		var ms []int64
+		for m, ok := range members {
+			if ok {
+				ms = append(ms, m)
+			}
+		}
+		want := committedSet(t, bigInts(ms...), srs).Commitment()
+		if !bytes.Equal(c.G1().Marshal(), want.G1().Marshal()) {
+			t.Errorf("%s(%d) = %v; want commitment of NewSet(%v)", name, tt.z, c.G1(), ms)
+		}
+	}
+}
+
+func TestAddRemoveNotCommitted(t *testing.T) {
+	s := NewSet(bigInts(1, 2))
+	for _, update := range []func(*big.Int) (*kzg.Commitment, error){s.Add, s.Remove} {
+		c, err := update(big.NewInt(3))
+		if err != nil {
+			t.Fatalf("update(): %v", err)
+		}
+		if c != nil {
+			t.Errorf("update() of uncommitted set returned commitment %v; want nil", c)
+		}
+	}
+}
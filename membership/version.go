@@ -0,0 +1,42 @@
+package membership
+
+import (
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/kzg"
+)
+
+// Version returns the version of the Set, starting at 0 when committed to and
+// incremented by every successful Add or Remove thereafter. It is 0 for Sets
+// that haven't been committed to.
+func (s *Set) Version() uint64 {
+	if s.srs == nil {
+		return 0
+	}
+	return uint64(len(s.history) - 1)
+}
+
+// CommitmentAt returns the commitment to the specified version of the Set.
+func (s *Set) CommitmentAt(version uint64) (*kzg.Commitment, error) {
+	if s.srs == nil {
+		return nil, ErrNotCommitted
+	}
+	if version > s.Version() {
+		return nil, fmt.Errorf("version %d exceeds current version %d", version, s.Version())
+	}
+	return copyCommitment(s.history[version]), nil
+}
+
+// VerifyAtVersion is equivalent to VerifyMember, but additionally requires
+// that the proof is labelled with the specified version of the Set, of which
+// commitment is the commitment as returned by CommitmentAt.
+//
+// Proof.Version is an unauthenticated label: only the commitment is bound
+// cryptographically. Proofs for versions whose members differ are rejected by
+// the commitment alone, but a proof for another version with the same
+// members, e.g. before an Add and the matching Remove, verifies once its label
+// is rewritten.
+func VerifyAtVersion(vk *kzg.VerifierKey, commitment *kzg.Commitment, version uint64, member *big.Int, proof *Proof) bool {
+	return proof.Version == version && VerifyMember(vk, commitment, member, proof)
+}
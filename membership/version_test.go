@@ -0,0 +1,82 @@
+package membership
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/kzg"
+)
+
+func TestVerifyAtVersion(t *testing.T) {
+	srs := kzg.NewSRS(big.NewInt(1337), 10)
+	vk := srs.VerifierKey()
+
+	s := committedSet(t, bigInts(1, 2, 3), srs)
+	proof, err := s.ProveMember(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("ProveMember(1): %v", err)
+	}
+	if _, err := s.Add(big.NewInt(42)); err != nil {
+		t.Fatalf("Add(42): %v", err)
+	}
+
+	before, err := s.CommitmentAt(0)
+	if err != nil {
+		t.Fatalf("CommitmentAt(0): %v", err)
+	}
+	if _, err := s.CommitmentAt(2); err == nil {
+		t.Errorf("CommitmentAt(<future version>): got nil error")
+	}
+
+	fresh, err := s.ProveMember(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("ProveMember(1): %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		version uint64
+		proof   *Proof
+		want    bool
+	}{
+		{name: "stale proof at pre-update version", version: 0, proof: proof, want: true},
+		{name: "stale proof at post-update version", version: 1, proof: proof, want: false},
+		{name: "fresh proof at post-update version", version: 1, proof: fresh, want: true},
+		{name: "fresh proof at pre-update version", version: 0, proof: fresh, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := s.CommitmentAt(tt.version)
+			if err != nil {
+				t.Fatalf("CommitmentAt(%d): %v", tt.version, err)
+			}
+			if got := VerifyAtVersion(vk, c, tt.version, big.NewInt(1), tt.proof); got != tt.want {
+				t.Errorf("VerifyAtVersion() = %t; want %t", got, tt.want)
+			}
+		})
+	}
+
+	// The stale proof is also cryptographically invalid for the new commitment,
+	// regardless of its claimed version.
+	stale := *proof
+	stale.Version = 1
+	if VerifyAtVersion(vk, s.Commitment(), 1, big.NewInt(1), &stale) {
+		t.Errorf("VerifyAtVersion(<stale proof with rewritten version>) = true; want false")
+	}
+	if !VerifyAtVersion(vk, before, 0, big.NewInt(1), proof) {
+		t.Errorf("VerifyAtVersion(<pre-update commitment>) = false; want true")
+	}
+
+	// The version is only a label: after removing 42 again, the members and
+	// thus the commitments of versions 0 and 2 are equal, and a relabelled
+	// proof of version 0 is accepted.
+	if _, err := s.Remove(big.NewInt(42)); err != nil {
+		t.Fatalf("Remove(42): %v", err)
+	}
+	relabelled := *proof
+	relabelled.Version = 2
+	if !VerifyAtVersion(vk, s.Commitment(), 2, big.NewInt(1), &relabelled) {
+		t.Errorf("VerifyAtVersion(<relabelled proof of a version with equal members>) = false; want true")
+	}
+}
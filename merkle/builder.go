@@ -0,0 +1,83 @@
+package merkle
+
+// A Builder computes the root of a Tree from a stream of leaves, holding only
+// one hash per level instead of the whole tree.
+type Builder struct {
+	h Hasher
+	n int
+	// pending[l] is the root of a complete subtree of 2^l leaves that is
+	// still missing its right sibling, or nil.
+	pending [][]byte
+	// empty[l] is the root of a subtree of 2^l empty leaves.
+	empty [][]byte
+}
+
+// NewBuilder returns a Builder without leaves.
+func NewBuilder(h Hasher) *Builder {
+	return &Builder{h: h}
+}
+
+// Push appends a leaf.
+func (b *Builder) Push(leaf []byte) {
+	b.n++
+	node := b.h.HashLeaf(leaf)
+	l := 0
+	for ; l < len(b.pending) && b.pending[l] != nil; l++ {
+		node = b.h.HashNode(b.pending[l], node)
+		b.pending[l] = nil
+	}
+	if l == len(b.pending) {
+		b.pending = append(b.pending, nil)
+	}
+	b.pending[l] = node
+}
+
+// Len returns the number of leaves pushed so far.
+func (b *Builder) Len() int {
+	return b.n
+}
+
+// emptyRoot returns the root of a subtree of 2^l empty leaves.
+func (b *Builder) emptyRoot(l int) []byte {
+	if len(b.empty) == 0 {
+		b.empty = append(b.empty, b.h.HashLeaf(nil))
+	}
+	for len(b.empty) <= l {
+		e := b.empty[len(b.empty)-1]
+		b.empty = append(b.empty, b.h.HashNode(e, e))
+	}
+	return b.empty[l]
+}
+
+// Root returns the root of the Tree over the leaves pushed so far, equal to
+// that of New, or ErrNoLeaves if there are none. More leaves can be pushed
+// afterwards.
+func (b *Builder) Root() ([]byte, error) {
+	if b.n == 0 {
+		return nil, ErrNoLeaves
+	}
+	d := depth(b.n)
+	if d < len(b.pending) && b.pending[d] != nil {
+		// A complete tree of 2^d leaves.
+		return b.pending[d], nil
+	}
+
+	// Fold subtrees from the right, padding with empty ones, where acc is the
+	// root of the rightmost subtree of 2^l leaves, or nil if it is empty.
+	var acc []byte
+	for l := 0; l < d; l++ {
+		var p []byte
+		if l < len(b.pending) {
+			p = b.pending[l]
+		}
+		switch {
+		case p != nil && acc != nil:
+			acc = b.h.HashNode(p, acc)
+		case p != nil:
+			acc = b.h.HashNode(p, b.emptyRoot(l))
+		case acc != nil:
+			acc = b.h.HashNode(acc, b.emptyRoot(l))
+		}
+	}
+	return acc, nil
+}
@@ -0,0 +1,95 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"hash"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+	"zkp.xyz/membership/galois"
+)
+
+// A Hasher hashes the leaves and inner nodes of a Tree.
+type Hasher interface {
+	// HashLeaf returns the hash of the leaf data.
+	HashLeaf(leaf []byte) []byte
+	// HashNode returns the hash of the inner node with the children's hashes.
+	HashNode(left, right []byte) []byte
+}
+
+// byteHasher is a Hasher from a standard hash function, prefixing leaves with
+// 0x00 and inner nodes with 0x01 so that neither can be passed off as the
+// other.
+type byteHasher func() hash.Hash
+
+func (h byteHasher) HashLeaf(leaf []byte) []byte {
+	d := h()
+	d.Write([]byte{0})
+	d.Write(leaf)
+	return d.Sum(nil)
+}
+
+func (h byteHasher) HashNode(left, right []byte) []byte {
+	d := h()
+	d.Write([]byte{1})
+	d.Write(left)
+	d.Write(right)
+	return d.Sum(nil)
+}
+
+var (
+	// SHA256 is a Hasher based on SHA-256.
+	SHA256 Hasher = byteHasher(sha256.New)
+	// Keccak256 is a Hasher based on the legacy Keccak-256 used by Ethereum,
+	// which differs from the standardised SHA3-256 in its padding.
+	Keccak256 Hasher = byteHasher(sha3.NewLegacyKeccak256)
+)
+
+// A Compressor is a two-to-one hash over a field, e.g. an algebraic hash such
+// as Poseidon or MiMC, which is cheap to evaluate in arithmetic circuits.
+type Compressor interface {
+	Compress(a, b *big.Int) *big.Int
+}
+
+// A CompressorFunc is a function used as a Compressor.
+type CompressorFunc func(a, b *big.Int) *big.Int
+
+// Compress returns fn(a, b).
+func (fn CompressorFunc) Compress(a, b *big.Int) *big.Int {
+	return fn(a, b)
+}
+
+// A FieldHasher is a Hasher over field elements, encoded in big-endian form
+// with the byte length of the order. Leaves are interpreted as big-endian
+// integers, reduced into the field, and used as is, and inner nodes are the
+// compression of their children. This matches the trees of circuits that
+// verify inclusion with an algebraic hash. Unlike the byte Hashers, leaves and
+// inner nodes share their encoding, so the number of leaves MUST be fixed by
+// the verifier, as Verify does.
+type FieldHasher struct {
+	f *galois.Field
+	c Compressor
+}
+
+// NewFieldHasher returns a FieldHasher over f with the Compressor.
+func NewFieldHasher(f *galois.Field, c Compressor) *FieldHasher {
+	return &FieldHasher{f: f, c: c}
+}
+
+// Element returns the field element encoded by b, a hash returned by h.
+func (h *FieldHasher) Element(b []byte) *big.Int {
+	return h.f.Mod(new(big.Int).SetBytes(b))
+}
+
+func (h *FieldHasher) encode(x *big.Int) []byte {
+	x = new(big.Int).Mod(x, h.f.Order())
+	return x.FillBytes(make([]byte, (h.f.Order().BitLen()+7)/8))
+}
+
+func (h *FieldHasher) HashLeaf(leaf []byte) []byte {
+	return h.encode(new(big.Int).SetBytes(leaf))
+}
+
+func (h *FieldHasher) HashNode(left, right []byte) []byte {
+	return h.encode(h.c.Compress(h.Element(left), h.Element(right)))
+}
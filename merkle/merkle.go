@@ -0,0 +1,219 @@
+// Package merkle implements binary Merkle trees with inclusion proofs for
+// single leaves and multiproofs for several, over byte-oriented hashes such as
+// SHA-256 and Keccak-256 or algebraic hashes over a galois.Field.
+//
+// A tree over n leaves is padded with empty leaves, HashLeaf(nil), to the
+// next power of two. Proofs are verified against the number of leaves n,
+// which fixes the depth and excludes the padding.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// ErrNoLeaves is returned for trees without leaves, which have no root.
+var ErrNoLeaves = errors.New("tree without leaves")
+
+// A Tree holds all nodes of a Merkle tree.
+type Tree struct {
+	h Hasher
+	n int
+	// levels[0] holds the padded leaf hashes and levels[depth] the root.
+	levels [][][]byte
+}
+
+// depth returns the depth of a tree with n > 0 leaves.
+func depth(n int) int {
+	return bits.Len(uint(n - 1))
+}
+
+// New returns the Tree over the leaves.
+func New(h Hasher, leaves [][]byte) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrNoLeaves
+	}
+	d := depth(len(leaves))
+	level := make([][]byte, 1<<d)
+	for i := range level {
+		if i < len(leaves) {
+			level[i] = h.HashLeaf(leaves[i])
+		} else {
+			level[i] = h.HashLeaf(nil)
+		}
+	}
+
+	t := &Tree{h: h, n: len(leaves), levels: [][][]byte{level}}
+	for len(level) > 1 {
+		next := make([][]byte, len(level)/2)
+		for i := range next {
+			next[i] = h.HashNode(level[2*i], level[2*i+1])
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t, nil
+}
+
+// Root returns the root of the Tree.
+func (t *Tree) Root() []byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Len returns the number of leaves of the Tree, excluding padding.
+func (t *Tree) Len() int {
+	return t.n
+}
+
+// A Proof shows that a leaf is at Index of a Tree. Siblings holds the hashes
+// of the siblings of the path from the leaf to the root, bottom up.
+type Proof struct {
+	Index    int
+	Siblings [][]byte
+}
+
+// Prove returns a Proof for leaf i.
+func (t *Tree) Prove(i int) (*Proof, error) {
+	if i < 0 || i >= t.n {
+		return nil, fmt.Errorf("leaf index %d out of range [0, %d)", i, t.n)
+	}
+	proof := &Proof{Index: i}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		proof.Siblings = append(proof.Siblings, level[i^1])
+		i /= 2
+	}
+	return proof, nil
+}
+
+// Verify reports whether the proof shows that leaf is at proof.Index of the
+// tree with the root and n leaves.
+func Verify(h Hasher, root []byte, n int, leaf []byte, proof *Proof) bool {
+	if n <= 0 || proof.Index < 0 || proof.Index >= n || len(proof.Siblings) != depth(n) {
+		return false
+	}
+	node, i := h.HashLeaf(leaf), proof.Index
+	for _, sib := range proof.Siblings {
+		if i%2 == 0 {
+			node = h.HashNode(node, sib)
+		} else {
+			node = h.HashNode(sib, node)
+		}
+		i /= 2
+	}
+	return bytes.Equal(node, root)
+}
+
+// A MultiProof shows that leaves are at the Indices of a Tree, sorted and
+// distinct. Nodes holds the hashes of the nodes that can't be computed from
+// the leaves or from other nodes, level by level from the bottom up and by
+// ascending position within a level, so shared parts of the paths are only
+// included once.
+type MultiProof struct {
+	Indices []int
+	Nodes   [][]byte
+}
+
+// sortedIndices returns the distinct indices in ascending order, or an error
+// if any of them is out of range [0, n).
+func sortedIndices(indices []int, n int) ([]int, error) {
+	sorted := append([]int(nil), indices...)
+	sort.Ints(sorted)
+	out := sorted[:0]
+	for _, i := range sorted {
+		if i < 0 || i >= n {
+			return nil, fmt.Errorf("leaf index %d out of range [0, %d)", i, n)
+		}
+		if len(out) == 0 || out[len(out)-1] != i {
+			out = append(out, i)
+		}
+	}
+	return out, nil
+}
+
+// parents returns the distinct positions of the parents of the sorted
+// positions.
+func parents(positions []int) []int {
+	var out []int
+	for _, p := range positions {
+		if len(out) == 0 || out[len(out)-1] != p/2 {
+			out = append(out, p/2)
+		}
+	}
+	return out
+}
+
+// ProveMulti returns a MultiProof for the leaves at the indices, which may be
+// in any order and repeat.
+func (t *Tree) ProveMulti(indices []int) (*MultiProof, error) {
+	known, err := sortedIndices(indices, t.n)
+	if err != nil {
+		return nil, err
+	}
+	if len(known) == 0 {
+		return nil, errors.New("no leaf indices")
+	}
+
+	proof := &MultiProof{Indices: known}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		for j, p := range known {
+			// A left child's sibling is known iff it is the next position.
+			if p%2 == 0 && j+1 < len(known) && known[j+1] == p+1 {
+				continue
+			}
+			if p%2 == 1 && j > 0 && known[j-1] == p-1 {
+				continue
+			}
+			proof.Nodes = append(proof.Nodes, level[p^1])
+		}
+		known = parents(known)
+	}
+	return proof, nil
+}
+
+// VerifyMulti reports whether the proof shows that leaves[j] is at
+// proof.Indices[j] of the tree with the root and n leaves, for all j.
+func VerifyMulti(h Hasher, root []byte, n int, leaves [][]byte, proof *MultiProof) bool {
+	if n <= 0 || len(leaves) == 0 || len(leaves) != len(proof.Indices) {
+		return false
+	}
+	for j, i := range proof.Indices {
+		if i < 0 || i >= n || (j > 0 && i <= proof.Indices[j-1]) {
+			return false
+		}
+	}
+
+	positions := proof.Indices
+	hashes := make([][]byte, len(leaves))
+	for j, l := range leaves {
+		hashes[j] = h.HashLeaf(l)
+	}
+	nodes := proof.Nodes
+	for l := 0; l < depth(n); l++ {
+		var nextPos []int
+		var next [][]byte
+		for j := 0; j < len(positions); j++ {
+			p := positions[j]
+			var left, right []byte
+			switch {
+			case p%2 == 0 && j+1 < len(positions) && positions[j+1] == p+1:
+				left, right = hashes[j], hashes[j+1]
+				j++
+			case len(nodes) == 0:
+				return false
+			case p%2 == 0:
+				left, right = hashes[j], nodes[0]
+				nodes = nodes[1:]
+			default:
+				left, right = nodes[0], hashes[j]
+				nodes = nodes[1:]
+			}
+			nextPos = append(nextPos, p/2)
+			next = append(next, h.HashNode(left, right))
+		}
+		positions, hashes = nextPos, next
+	}
+	return len(nodes) == 0 && len(hashes) == 1 && bytes.Equal(hashes[0], root)
+}
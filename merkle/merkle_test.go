@@ -0,0 +1,193 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+var f = galois.NewField(bn256.Order)
+
+// toyCompressor is an algebraic, but insecure, stand-in for Poseidon or MiMC.
+var toyCompressor = CompressorFunc(func(a, b *big.Int) *big.Int {
+	x := f.Add(f.Mul(a, big.NewInt(3)), b)
+	return f.Add(f.Mul(f.Square(x), x), big.NewInt(7))
+})
+
+var hashers = map[string]Hasher{
+	"SHA256":    SHA256,
+	"Keccak256": Keccak256,
+	"Field":     NewFieldHasher(f, toyCompressor),
+}
+
+func leaves(n int) [][]byte {
+	ls := make([][]byte, n)
+	for i := range ls {
+		ls[i] = []byte(fmt.Sprintf("leaf %d", i))
+	}
+	return ls
+}
+
+func TestHashers(t *testing.T) {
+	leaf := []byte("leaf")
+	sum := sha256.Sum256(append([]byte{0}, leaf...))
+	if got := SHA256.HashLeaf(leaf); !bytes.Equal(got, sum[:]) {
+		t.Errorf("SHA256.HashLeaf(%q) = %x; want %x", leaf, got, sum)
+	}
+	// Keccak-256 of the single byte 0x00.
+	want, _ := hex.DecodeString("bc36789e7a1e281436464229828f817d6612f7b477d66591ff96a9e064bcc98a")
+	if got := Keccak256.HashLeaf(nil); !bytes.Equal(got, want) {
+		t.Errorf("Keccak256.HashLeaf(nil) = %x; want %x", got, want)
+	}
+
+	h := NewFieldHasher(f, toyCompressor)
+	a, b := big.NewInt(5), big.NewInt(11)
+	node := h.HashNode(h.HashLeaf(a.Bytes()), h.HashLeaf(b.Bytes()))
+	if got, want := h.Element(node), toyCompressor(a, b); got.Cmp(want) != 0 {
+		t.Errorf("FieldHasher.HashNode(5, 11) = %v; want %v", got, want)
+	}
+	if got := len(node); got != 32 {
+		t.Errorf("len(FieldHasher.HashNode()) = %d; want 32", got)
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	for name, h := range hashers {
+		for _, n := range []int{1, 2, 3, 8, 13} {
+			ls := leaves(n)
+			tree, err := New(h, ls)
+			if err != nil {
+				t.Fatalf("New(%s, <%d leaves>): %v", name, n, err)
+			}
+			for i, l := range ls {
+				proof, err := tree.Prove(i)
+				if err != nil {
+					t.Fatalf("Prove(%d): %v", i, err)
+				}
+				if !Verify(h, tree.Root(), n, l, proof) {
+					t.Errorf("%s: Verify(<%d leaves>, Prove(%d)) = false", name, n, i)
+				}
+				if Verify(h, tree.Root(), n, []byte("other"), proof) {
+					t.Errorf("%s: Verify(<%d leaves>, Prove(%d)) with wrong leaf = true", name, n, i)
+				}
+				if Verify(h, tree.Root(), 2*n, l, proof) {
+					t.Errorf("%s: Verify(<%d leaves>, Prove(%d)) with %d leaves = true", name, n, i, 2*n)
+				}
+				if n > 1 {
+					moved := &Proof{Index: (i + 1) % n, Siblings: proof.Siblings}
+					if Verify(h, tree.Root(), n, l, moved) {
+						t.Errorf("%s: Verify(<%d leaves>, Prove(%d)) at index %d = true", name, n, i, moved.Index)
+					}
+				}
+			}
+
+			if _, err := tree.Prove(n); err == nil {
+				t.Errorf("%s: Prove(%d) of padding: got nil error", name, n)
+			}
+		}
+	}
+
+	if _, err := New(SHA256, nil); err != ErrNoLeaves {
+		t.Errorf("New(<no leaves>): got err %v; want %v", err, ErrNoLeaves)
+	}
+}
+
+func TestMultiProof(t *testing.T) {
+	tests := []struct {
+		n         int
+		indices   []int
+		wantNodes int
+	}{
+		{n: 1, indices: []int{0}, wantNodes: 0},
+		{n: 8, indices: []int{3}, wantNodes: 3},
+		{n: 8, indices: []int{0, 1}, wantNodes: 2},
+		{n: 8, indices: []int{0, 7}, wantNodes: 4},
+		{n: 8, indices: []int{7, 0, 7, 1}, wantNodes: 3},
+		{n: 8, indices: []int{0, 1, 2, 3, 4, 5, 6, 7}, wantNodes: 0},
+		{n: 13, indices: []int{12, 2, 9}, wantNodes: 7},
+	}
+
+	for name, h := range hashers {
+		for _, tt := range tests {
+			ls := leaves(tt.n)
+			tree, err := New(h, ls)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, err := tree.ProveMulti(tt.indices)
+			if err != nil {
+				t.Fatalf("ProveMulti(%v): %v", tt.indices, err)
+			}
+			if got := len(proof.Nodes); got != tt.wantNodes {
+				t.Errorf("%s: len(ProveMulti(<%d leaves>, %v).Nodes) = %d; want %d", name, tt.n, tt.indices, got, tt.wantNodes)
+			}
+
+			var proven [][]byte
+			for _, i := range proof.Indices {
+				proven = append(proven, ls[i])
+			}
+			if !VerifyMulti(h, tree.Root(), tt.n, proven, proof) {
+				t.Errorf("%s: VerifyMulti(<%d leaves>, ProveMulti(%v)) = false", name, tt.n, tt.indices)
+			}
+
+			proven[0] = []byte("other")
+			if VerifyMulti(h, tree.Root(), tt.n, proven, proof) {
+				t.Errorf("%s: VerifyMulti(<%d leaves>, ProveMulti(%v)) with wrong leaf = true", name, tt.n, tt.indices)
+			}
+		}
+	}
+
+	tree, err := New(SHA256, leaves(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, indices := range [][]int{nil, {8}, {-1}} {
+		if _, err := tree.ProveMulti(indices); err == nil {
+			t.Errorf("ProveMulti(%v): got nil error", indices)
+		}
+	}
+	proof, err := tree.ProveMulti([]int{1, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls := leaves(8)
+	if VerifyMulti(SHA256, tree.Root(), 8, [][]byte{ls[1], ls[4]}, &MultiProof{Indices: proof.Indices, Nodes: proof.Nodes[1:]}) {
+		t.Errorf("VerifyMulti() with missing node = true")
+	}
+	if VerifyMulti(SHA256, tree.Root(), 8, [][]byte{ls[1], ls[4]}, &MultiProof{Indices: proof.Indices, Nodes: append(proof.Nodes, proof.Nodes[0])}) {
+		t.Errorf("VerifyMulti() with extra node = true")
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	for name, h := range hashers {
+		b := NewBuilder(h)
+		if _, err := b.Root(); err != ErrNoLeaves {
+			t.Errorf("%s: NewBuilder().Root(): got err %v; want %v", name, err, ErrNoLeaves)
+		}
+		ls := leaves(20)
+		for n, l := range ls {
+			b.Push(l)
+			got, err := b.Root()
+			if err != nil {
+				t.Fatalf("Root(): %v", err)
+			}
+			tree, err := New(h, ls[:n+1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, tree.Root()) {
+				t.Errorf("%s: Builder.Root() after %d leaves = %x; want %x", name, n+1, got, tree.Root())
+			}
+			if b.Len() != n+1 {
+				t.Errorf("%s: Builder.Len() = %d; want %d", name, b.Len(), n+1)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package msm implements multi-scalar multiplication (MSM), the computation of
+// sum_i scalars[i]*points[i], with Pippenger's bucket method.
+//
+// Compared to n separate scalar multiplications, each requiring ~b doublings
+// and ~b/2 additions for b-bit scalars, Pippenger's method splits scalars into
+// c-bit windows and sorts the points of every window into 2^c-1 buckets by
+// digit. A window costs ~n+2^(c+1) additions, resulting in O(b*n/log(n))
+// additions overall instead of O(b*n). Windows are independent and computed
+// concurrently.
+package msm
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"sync"
+
+	"zkp.xyz/membership/polynomial"
+)
+
+// naiveThreshold is the minimum number of points for which Pippenger's method
+// is used. Below it, the bucket overhead outweighs the savings.
+const naiveThreshold = 16
+
+// MultiExp returns sum_i scalars[i]*points[i], which is the identity if there
+// are no points. Scalars MUST be non-negative; for bn256, reduce them modulo
+// bn256.Order to minimise the number of windows. The points are not modified.
+func MultiExp[E any, G polynomial.PointerGroupElement[E, G]](points []G, scalars []*big.Int) (G, error) {
+	if len(points) != len(scalars) {
+		return nil, fmt.Errorf("len(points) != len(scalars): %d != %d", len(points), len(scalars))
+	}
+	maxBits := 0
+	for i, s := range scalars {
+		if s.Sign() < 0 {
+			return nil, fmt.Errorf("negative scalar %v at index %d", s, i)
+		}
+		if b := s.BitLen(); b > maxBits {
+			maxBits = b
+		}
+	}
+
+	if len(points) < naiveThreshold {
+		return naive[E, G](points, scalars), nil
+	}
+
+	c := windowSize(len(points))
+	windows := make([]G, (maxBits+c-1)/c)
+	parallelize(len(windows), func(w int) {
+		windows[w] = window[E, G](points, scalars, uint(w*c), uint(c))
+	})
+
+	// sum_w 2^(w*c) * windows[w] by Horner's method.
+	sum := identity[E, G]()
+	for w := len(windows) - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			sum.Add(sum, sum)
+		}
+		sum.Add(sum, windows[w])
+	}
+	return sum, nil
+}
+
+// windowSize returns the number of bits per window for n points, balancing the
+// n additions for sorting points into buckets against the 2^(c+1) additions for
+// summing them.
+func windowSize(n int) int {
+	c := bits.Len(uint(n)) - 3
+	if c < 2 {
+		return 2
+	}
+	if c > 16 {
+		return 16
+	}
+	return c
+}
+
+// window returns sum_i d_i*points[i] where d_i is the c-bit digit of
+// scalars[i] starting at bit offset.
+func window[E any, G polynomial.PointerGroupElement[E, G]](points []G, scalars []*big.Int, offset, c uint) G {
+	buckets := make([]G, 1<<c-1)
+	for i, s := range scalars {
+		d := digit(s, offset, c)
+		if d == 0 {
+			continue
+		}
+		if b := buckets[d-1]; b == nil {
+			buckets[d-1] = new(E)
+			buckets[d-1].Set(points[i])
+		} else {
+			b.Add(b, points[i])
+		}
+	}
+
+	// sum_d d*buckets[d-1] = sum_d sum_{d' >= d} buckets[d'-1], accumulating
+	// the inner sums from the top.
+	running, sum := identity[E, G](), identity[E, G]()
+	for d := len(buckets) - 1; d >= 0; d-- {
+		if buckets[d] != nil {
+			running.Add(running, buckets[d])
+		}
+		sum.Add(sum, running)
+	}
+	return sum
+}
+
+// digit returns the c bits of s starting at bit offset.
+func digit(s *big.Int, offset, c uint) uint {
+	var d uint
+	for i := uint(0); i < c; i++ {
+		d |= s.Bit(int(offset+i)) << i
+	}
+	return d
+}
+
+func naive[E any, G polynomial.PointerGroupElement[E, G]](points []G, scalars []*big.Int) G {
+	sum, tmp := identity[E, G](), G(new(E))
+	for i, p := range points {
+		tmp.ScalarMult(p, scalars[i])
+		sum.Add(sum, tmp)
+	}
+	return sum
+}
+
+func identity[E any, G polynomial.PointerGroupElement[E, G]]() G {
+	g := G(new(E))
+	g.ScalarBaseMult(new(big.Int))
+	return g
+}
+
+// parallelize calls fn for every i in [0,n), with up to GOMAXPROCS concurrent
+// calls.
+func parallelize(n int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,138 @@
+package msm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+func randomScalars(tb testing.TB, n int) []*big.Int {
+	tb.Helper()
+	scalars := make([]*big.Int, n)
+	for i := range scalars {
+		s, err := rand.Int(rand.Reader, bn256.Order)
+		if err != nil {
+			tb.Fatalf("rand.Int(): %v", err)
+		}
+		scalars[i] = s
+	}
+	return scalars
+}
+
+func g1Points(scalars []*big.Int) []*bn256.G1 {
+	ps := make([]*bn256.G1, len(scalars))
+	for i, s := range scalars {
+		ps[i] = new(bn256.G1).ScalarBaseMult(s)
+	}
+	return ps
+}
+
+// want returns sum_i scalars[i]*points[i] by EvaluateOnPowers.
+func want[E any, G polynomial.PointerGroupElement[E, G]](tb testing.TB, points []G, scalars []*big.Int) G {
+	tb.Helper()
+	if len(points) == 0 {
+		return identity[E, G]()
+	}
+	got, err := polynomial.EvaluateOnPowers(polynomial.NewPolynomial(scalars), points)
+	if err != nil {
+		tb.Fatalf("EvaluateOnPowers(): %v", err)
+	}
+	return got
+}
+
+func TestMultiExpG1(t *testing.T) {
+	for _, n := range []int{0, 1, 15, 16, 17, 100, 300} {
+		t.Run(fmt.Sprintf("%d points", n), func(t *testing.T) {
+			points := g1Points(randomScalars(t, n))
+			scalars := randomScalars(t, n)
+			if n > 2 {
+				// Zero and duplicate digits, and scalars exceeding the order.
+				scalars[0] = big.NewInt(0)
+				scalars[1] = new(big.Int).Set(scalars[2])
+				scalars[2] = new(big.Int).Add(scalars[2], bn256.Order)
+			}
+
+			got, err := MultiExp(points, scalars)
+			if err != nil {
+				t.Fatalf("MultiExp(): %v", err)
+			}
+			if w := want(t, points, scalars); !bytes.Equal(got.Marshal(), w.Marshal()) {
+				t.Errorf("MultiExp() = %v; want %v", got, w)
+			}
+		})
+	}
+}
+
+func TestMultiExpG2(t *testing.T) {
+	const n = 40
+	points := make([]*bn256.G2, n)
+	for i, s := range randomScalars(t, n) {
+		points[i] = new(bn256.G2).ScalarBaseMult(s)
+	}
+	scalars := randomScalars(t, n)
+
+	got, err := MultiExp(points, scalars)
+	if err != nil {
+		t.Fatalf("MultiExp(): %v", err)
+	}
+	if w := want(t, points, scalars); !bytes.Equal(got.Marshal(), w.Marshal()) {
+		t.Errorf("MultiExp() = %v; want %v", got, w)
+	}
+}
+
+func TestMultiExpErrors(t *testing.T) {
+	points := g1Points(randomScalars(t, 3))
+
+	if _, err := MultiExp(points, randomScalars(t, 2)); err == nil {
+		t.Errorf("MultiExp(<3 points>, <2 scalars>): got nil error")
+	}
+	scalars := randomScalars(t, 3)
+	scalars[1] = big.NewInt(-1)
+	if _, err := MultiExp(points, scalars); err == nil {
+		t.Errorf("MultiExp(<negative scalar>): got nil error")
+	}
+}
+
+func TestDigit(t *testing.T) {
+	s := big.NewInt(0b1101_0110)
+	tests := []struct {
+		offset, c uint
+		want      uint
+	}{
+		{offset: 0, c: 4, want: 0b0110},
+		{offset: 4, c: 4, want: 0b1101},
+		{offset: 2, c: 3, want: 0b101},
+		{offset: 6, c: 4, want: 0b11},
+		{offset: 100, c: 8, want: 0},
+	}
+	for _, tt := range tests {
+		if got := digit(s, tt.offset, tt.c); got != tt.want {
+			t.Errorf("digit(%b, %d, %d) = %b; want %b", s, tt.offset, tt.c, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	for _, n := range []int{256, 4096} {
+		points := g1Points(randomScalars(b, n))
+		scalars := randomScalars(b, n)
+
+		b.Run(fmt.Sprintf("Pippenger/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := MultiExp(points, scalars); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("EvaluateOnPowers/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				want(b, points, scalars)
+			}
+		})
+	}
+}
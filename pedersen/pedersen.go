@@ -0,0 +1,139 @@
+// Package pedersen implements Pedersen vector commitments over G1 of a
+// curve.Suite: a commitment to values v_0, ..., v_{n-1} with the blinder r is
+// sum_i v_i G[i] + r H.
+//
+// The commitments are perfectly hiding and computationally binding, and
+// additively homomorphic: the sum of commitments is a commitment to the sum of
+// the values under the sum of the blinders. Unlike kzg, the Params require no
+// trusted setup; the generators are hashed to the curve, so nobody knows the
+// discrete logarithm of any of them with respect to the others.
+package pedersen
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+)
+
+// dst is the domain separation tag from which all generators are hashed.
+const dst = "zkp.xyz/pedersen/generators"
+
+// Params are the public parameters for committing to up to len(G) values.
+type Params struct {
+	suite curve.Suite
+	f     *galois.Field
+	G     []curve.Point
+	H     curve.Point
+}
+
+// generator returns the generator with the index, where H is index 0 and G[i]
+// index i+1.
+func generator(s curve.Suite, index uint64) curve.Point {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], index)
+	return s.HashToG1([]byte(dst), msg[:])
+}
+
+// Setup returns the Params over the Suite for vectors of up to n values. They
+// are deterministic, so those for smaller n have a prefix of the generators of
+// those for larger ones, and commitments to shorter vectors are commitments to
+// the vectors padded with zeros.
+func Setup(s curve.Suite, n int) (*Params, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("vector length %d < 1", n)
+	}
+	params := &Params{suite: s, f: s.ScalarField(), G: make([]curve.Point, n), H: generator(s, 0)}
+	for i := range params.G {
+		params.G[i] = generator(s, uint64(i+1))
+	}
+	return params, nil
+}
+
+// Suite returns the curve of the Params.
+func (params *Params) Suite() curve.Suite {
+	return params.suite
+}
+
+// Field returns the scalar field, to which values and blinders are reduced.
+func (params *Params) Field() *galois.Field {
+	return params.f
+}
+
+// Len returns the maximum number of values committed to with the Params.
+func (params *Params) Len() int {
+	return len(params.G)
+}
+
+// RandomBlinder returns a uniformly random blinder.
+func (params *Params) RandomBlinder(r io.Reader) (*big.Int, error) {
+	return params.f.Random(r)
+}
+
+// A Commitment is a commitment to a vector of values under some Params.
+type Commitment struct {
+	P curve.Point
+}
+
+// Commit returns the Commitment to the values with the blinder.
+func (params *Params) Commit(values []*big.Int, blinder *big.Int) (*Commitment, error) {
+	if len(values) > len(params.G) {
+		return nil, fmt.Errorf("vector of %d values exceeds Params length %d", len(values), len(params.G))
+	}
+	points := append(append([]curve.Point(nil), params.G[:len(values)]...), params.H)
+	scalars := append(append([]*big.Int(nil), values...), blinder)
+	p, err := params.suite.G1().MultiExp(points, scalars)
+	if err != nil {
+		return nil, err
+	}
+	return &Commitment{P: p}, nil
+}
+
+// Verify reports whether the Commitment opens to the values with the blinder,
+// i.e. whether it equals Commit(values, blinder).
+func (params *Params) Verify(c *Commitment, values []*big.Int, blinder *big.Int) bool {
+	want, err := params.Commit(values, blinder)
+	if err != nil {
+		return false
+	}
+	return c.P.Equal(want.P)
+}
+
+// Add returns a + b, a commitment to the sum of the values of a and b under
+// the sum of their blinders.
+func (params *Params) Add(a, b *Commitment) *Commitment {
+	return &Commitment{P: params.suite.G1().New().Add(a.P, b.P)}
+}
+
+// defaultParams returns the Params over bn256 for n values, or one if n = 0.
+func defaultParams(n int) (*Params, error) {
+	if n == 0 {
+		n = 1
+	}
+	return Setup(curve.BN256, n)
+}
+
+// Commit returns the Commitment to the values with the blinder under the
+// Params over bn256 for len(values) values, or one if there are none.
+// Commitments to many vectors SHOULD reuse Params from Setup instead, as
+// deriving generators is costly.
+func Commit(values []*big.Int, blinder *big.Int) (*Commitment, error) {
+	params, err := defaultParams(len(values))
+	if err != nil {
+		return nil, err
+	}
+	return params.Commit(values, blinder)
+}
+
+// Verify reports whether the Commitment returned by Commit opens to the values
+// with the blinder.
+func Verify(c *Commitment, values []*big.Int, blinder *big.Int) bool {
+	params, err := defaultParams(len(values))
+	if err != nil {
+		return false
+	}
+	return params.Verify(c, values, blinder)
+}
@@ -0,0 +1,167 @@
+package pedersen
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/curve"
+)
+
+var suites = []curve.Suite{curve.BN256, curve.BLS12381}
+
+func randomValues(t *testing.T, params *Params, n int) []*big.Int {
+	t.Helper()
+	vs := make([]*big.Int, n)
+	for i := range vs {
+		v, err := params.Field().Random(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vs[i] = v
+	}
+	return vs
+}
+
+func TestSetup(t *testing.T) {
+	if _, err := Setup(curve.BN256, 0); err == nil {
+		t.Errorf("Setup(0): got nil error")
+	}
+	for _, s := range suites {
+		small, err := Setup(s, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		large, err := Setup(s, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if large.Len() != 4 {
+			t.Errorf("%s: Setup(4).Len() = %d; want 4", s.Name(), large.Len())
+		}
+		if !small.H.Equal(large.H) {
+			t.Errorf("%s: Setup(2).H != Setup(4).H", s.Name())
+		}
+		gens := append([]curve.Point{large.H}, large.G...)
+		for i, g := range gens {
+			if i < len(small.G) && !small.G[i].Equal(large.G[i]) {
+				t.Errorf("%s: Setup(2).G[%d] != Setup(4).G[%d]", s.Name(), i, i)
+			}
+			for _, h := range gens[:i] {
+				if g.Equal(h) {
+					t.Errorf("%s: generators repeat", s.Name())
+				}
+			}
+		}
+	}
+}
+
+func TestCommitVerify(t *testing.T) {
+	for _, s := range suites {
+		params, err := Setup(s, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, n := range []int{0, 1, 4} {
+			values := randomValues(t, params, n)
+			r, err := params.RandomBlinder(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := params.Commit(values, r)
+			if err != nil {
+				t.Fatalf("%s: Commit(<%d values>): %v", s.Name(), n, err)
+			}
+			if !params.Verify(c, values, r) {
+				t.Errorf("%s: Verify(Commit(<%d values>)) = false", s.Name(), n)
+			}
+			if params.Verify(c, values, new(big.Int).Add(r, big.NewInt(1))) {
+				t.Errorf("%s: Verify(Commit(<%d values>)) with other blinder = true", s.Name(), n)
+			}
+			if n > 0 {
+				other := append([]*big.Int(nil), values...)
+				other[0] = new(big.Int).Add(other[0], big.NewInt(1))
+				if params.Verify(c, other, r) {
+					t.Errorf("%s: Verify(Commit(<%d values>)) with other values = true", s.Name(), n)
+				}
+			}
+			padded := append(append([]*big.Int(nil), values...), big.NewInt(0))
+			if n < params.Len() && !params.Verify(c, padded, r) {
+				t.Errorf("%s: Verify(Commit(<%d values>)) with padding = false", s.Name(), n)
+			}
+		}
+
+		if _, err := params.Commit(randomValues(t, params, 5), big.NewInt(0)); err == nil {
+			t.Errorf("%s: Commit(<5 values>) with Len() = 4: got nil error", s.Name())
+		}
+		if params.Verify(&Commitment{P: s.G1().New()}, randomValues(t, params, 5), big.NewInt(0)) {
+			t.Errorf("%s: Verify(<5 values>) with Len() = 4 = true", s.Name())
+		}
+	}
+}
+
+func TestHiding(t *testing.T) {
+	params, err := Setup(curve.BN256, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	a, err := params.Commit(values, big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := params.Commit(values, big.NewInt(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.P.Equal(b.P) {
+		t.Errorf("Commit() with different blinders are equal")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	for _, s := range suites {
+		params, err := Setup(s, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := params.Field()
+		xs, ys := randomValues(t, params, 3), randomValues(t, params, 2)
+		rx, ry := big.NewInt(5), f.Sub(big.NewInt(0), big.NewInt(7))
+		cx, err := params.Commit(xs, rx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cy, err := params.Commit(ys, ry)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum := []*big.Int{f.Add(xs[0], ys[0]), f.Add(xs[1], ys[1]), xs[2]}
+		got := params.Add(cx, cy)
+		if !params.Verify(got, sum, f.Add(rx, ry)) {
+			t.Errorf("%s: Verify(Add(Commit(x), Commit(y))) for x + y = false", s.Name())
+		}
+		if !params.Verify(cx, xs, rx) {
+			t.Errorf("%s: Add() modified its operand", s.Name())
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	values := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	c, err := Commit(values, big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := Setup(curve.BN256, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(c, values, big.NewInt(3)) || !params.Verify(c, values, big.NewInt(3)) {
+		t.Errorf("Verify(Commit(1, 2)) = false")
+	}
+	if Verify(c, values, big.NewInt(4)) {
+		t.Errorf("Verify(Commit(1, 2)) with other blinder = true")
+	}
+}
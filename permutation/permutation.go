@@ -0,0 +1,280 @@
+// Package permutation implements the grand-product argument of PLONK as a
+// standalone primitive over KZG commitments: proofs that two committed
+// vectors a and b are permutations of each other, e.g. for shuffles of
+// commitments published beforehand. GrandProduct is shared with package plonk.
+//
+// The vectors are interpolated over a Domain H of size n and committed to by
+// the caller, e.g. with Params.Commit.
+// They are equal as multisets iff, for random gamma,
+//
+//	prod_i (gamma + a(w^i)) = prod_i (gamma + b(w^i)).
+//
+// The prover commits to the grand product z with z(w^0) = 1 and
+// z(w^(i+1)) = z(w^i) (gamma + a(w^i)) / (gamma + b(w^i)), which wraps around
+// H iff the products are equal, i.e. iff, for random alpha,
+//
+//	z(w X) (gamma + b) - z (gamma + a) + alpha (z - 1) L_0
+//
+// is divisible by Z_H = X^n - 1. The quotient q is committed to, all
+// polynomials are opened at a challenge zeta, and z additionally at w zeta.
+package permutation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// ErrNotPermutation is returned by Prove for vectors that aren't
+// permutations of each other.
+var ErrNotPermutation = errors.New("vectors are not permutations of each other")
+
+var (
+	// Field is the scalar field of bn256, over which vectors are defined.
+	Field = kzg.Field
+
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+)
+
+// Params are the public parameters of proofs for vectors of at most N values,
+// interpolated over the Domain of size N generated by Omega.
+type Params struct {
+	N      uint64
+	Omega  *big.Int
+	domain *polynomial.Domain
+}
+
+// NewParams returns the Params for vectors of at most size values, over the
+// Domain of the smallest power of two n of at least size. Proofs require an
+// SRS of maximum degree at least n+3. The Domain is the canonical one of
+// size n.
+func NewParams(srs *kzg.SRS, size int) (*Params, error) {
+	n := uint64(1)
+	for n < uint64(size) {
+		n <<= 1
+	}
+	if srs.MaxDegree() < int(n)+3 {
+		return nil, fmt.Errorf("SRS of max degree %d; want at least %d for vectors of size %d", srs.MaxDegree(), n+3, n)
+	}
+	d, err := polynomial.CanonicalDomain(Field, n)
+	if err != nil {
+		return nil, err
+	}
+	return &Params{N: n, Omega: d.Generator(), domain: d}, nil
+}
+
+// A Vector is a committed vector: a polynomial evaluating to its values over
+// the Domain of the Params, typically blinded with Domain.Blinded, and its
+// commitment C.
+type Vector struct {
+	Poly *polynomial.Polynomial
+	C    *bn256.G1
+}
+
+// Commit returns the Vector of at most params.N values reduced into the Field,
+// padded with zeros and blinded with randomness drawn from r.
+func (params *Params) Commit(srs *kzg.SRS, values []*big.Int, r io.Reader) (*Vector, error) {
+	d := params.domain
+	if d == nil {
+		return nil, errors.New("params without domain; use NewParams")
+	}
+	n := int(d.Size())
+	if len(values) > n {
+		return nil, fmt.Errorf("vector of %d values; want at most %d", len(values), n)
+	}
+	xs := padded(values, n)
+	for i, x := range xs {
+		if x == nil || x.Sign() < 0 || x.Cmp(Field.Order()) >= 0 {
+			return nil, fmt.Errorf("value %d not in the field", i)
+		}
+	}
+	p, err := d.Blinded(xs, 2, r)
+	if err != nil {
+		return nil, err
+	}
+	c, err := srs.Commit(p)
+	if err != nil {
+		return nil, err
+	}
+	return &Vector{Poly: p, C: c}, nil
+}
+
+// A Proof attests that two committed vectors are permutations of each other.
+// Z and Q commit to the grand product and quotient. AtZeta opens a, b, z and
+// q, in that order, at zeta; AtZetaOmega opens z at w zeta.
+type Proof struct {
+	Z, Q                *bn256.G1
+	AtZeta, AtZetaOmega *kzg.AggregateProof
+}
+
+// Indices of the evaluations of Proof.AtZeta.
+const (
+	evalA = iota
+	evalB
+	evalZ
+	evalQ
+	numEvals
+)
+
+// newTranscript returns the Transcript of the Params and the commitments to
+// the vectors.
+func newTranscript(params *Params, a, b *bn256.G1) *transcript.Transcript {
+	t := transcript.New("zkp.xyz/permutation")
+	t.AppendScalar("n", new(big.Int).SetUint64(params.N))
+	t.AppendScalar("omega", params.Omega)
+	t.AppendG1("a", a)
+	t.AppendG1("b", b)
+	return t
+}
+
+// GrandProduct returns the running product z of the grand-product argument,
+// with z_0 = 1 and z_(i+1) = z_i nums[i] / dens[i] for i < len(nums)-1, and
+// whether it wraps around, i.e. whether prod_i nums[i] / dens[i] = 1. It
+// returns an error if nums and dens differ in length or a denominator is
+// zero.
+func GrandProduct(nums, dens []*big.Int) ([]*big.Int, bool, error) {
+	if len(nums) != len(dens) || len(nums) == 0 {
+		return nil, false, fmt.Errorf("%d numerators and %d denominators; want equal, non-zero numbers", len(nums), len(dens))
+	}
+	ratios, err := Field.DivSlice(nums, dens)
+	if err != nil {
+		return nil, false, err
+	}
+	n := len(ratios)
+	zs := make([]*big.Int, n)
+	zs[0] = big.NewInt(1)
+	for i := 1; i < n; i++ {
+		zs[i] = Field.Mul(zs[i-1], ratios[i-1])
+	}
+	return zs, Field.Mul(zs[n-1], ratios[n-1]).Cmp(bigOne) == 0, nil
+}
+
+// Prove returns a Proof that the values of the Vectors a and b over the
+// Domain of the Params are permutations of each other, or an error wrapping
+// ErrNotPermutation. The blinding factors of the grand product are drawn from
+// r.
+func Prove(pr *kzg.Prover, params *Params, a, b *Vector, r io.Reader) (*Proof, error) {
+	d := params.domain
+	if d == nil {
+		return nil, errors.New("params without domain; use NewParams")
+	}
+	for _, v := range []*Vector{a, b} {
+		if v == nil || v.Poly == nil || v.C == nil {
+			return nil, errors.New("incomplete vector")
+		}
+	}
+	n := int(d.Size())
+	pa, pb := a.Poly, b.Poly
+	as, bs := d.Values(pa), d.Values(pb)
+
+	proof := new(Proof)
+	t := newTranscript(params, a.C, b.C)
+	gamma := t.Challenge(Field)
+	nums, dens := make([]*big.Int, n), make([]*big.Int, n)
+	for i := range nums {
+		nums[i], dens[i] = Field.Add(gamma, as[i]), Field.Add(gamma, bs[i])
+	}
+	zs, wraps, err := GrandProduct(nums, dens)
+	if err != nil {
+		return nil, err
+	}
+	if !wraps {
+		return nil, ErrNotPermutation
+	}
+	z, err := d.Blinded(zs, 3, r)
+	if err != nil {
+		return nil, err
+	}
+	if proof.Z, err = pr.SRS().Commit(z); err != nil {
+		return nil, err
+	}
+	t.AppendG1("z", proof.Z)
+	alpha := t.Challenge(Field)
+
+	g := polynomial.NewPolynomial([]*big.Int{gamma})
+	first := z.Sub(polynomial.OnePolynomial, Field).Mul(d.LagrangeBasis(0), Field)
+	numerator := d.Shift(z).Mul(pb.Add(g, Field), Field).
+		Sub(z.Mul(pa.Add(g, Field), Field), Field).Add(first.Scale(alpha, Field), Field)
+	q, rem := numerator.Div(d.Vanishing(), Field)
+	if rem.Degree() != 0 || (*rem)[0].Sign() != 0 {
+		return nil, ErrNotPermutation
+	}
+	if proof.Q, err = pr.SRS().Commit(q); err != nil {
+		return nil, err
+	}
+	t.AppendG1("q", proof.Q)
+	zeta := t.Challenge(Field)
+
+	ps := []*polynomial.Polynomial{pa, pb, z, q}
+	cs := []*bn256.G1{a.C, b.C, proof.Z, proof.Q}
+	if proof.AtZeta, err = pr.OpenAggregate(ps, cs, zeta); err != nil {
+		return nil, err
+	}
+	zetaOmega := Field.Mul(zeta, d.Generator())
+	if proof.AtZetaOmega, err = pr.OpenAggregate(ps[evalZ:evalZ+1], cs[evalZ:evalZ+1], zetaOmega); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// padded returns xs padded with zeros to n values.
+func padded(xs []*big.Int, n int) []*big.Int {
+	out := make([]*big.Int, n)
+	for i := range out {
+		out[i] = bigZero
+		if i < len(xs) {
+			out[i] = xs[i]
+		}
+	}
+	return out
+}
+
+// Verify reports whether the proof is valid for the vectors committed to as a
+// and b and the Params, by checking both openings and, with their
+// evaluations,
+//
+//	z(w zeta) (gamma + b) - z (gamma + a) + alpha (z - 1) L_0(zeta) = q Z_H(zeta)
+//
+// with L_0(zeta) = Z_H(zeta) / (n (zeta - 1)).
+func Verify(vk *kzg.VerifierKey, params *Params, a, b *bn256.G1, proof *Proof) bool {
+	if a == nil || b == nil || proof.Z == nil || proof.Q == nil {
+		return false
+	}
+	t := newTranscript(params, a, b)
+	gamma := t.Challenge(Field)
+	t.AppendG1("z", proof.Z)
+	alpha := t.Challenge(Field)
+	t.AppendG1("q", proof.Q)
+	zeta := t.Challenge(Field)
+
+	zetaOmega := Field.Mul(zeta, params.Omega)
+	if !proof.AtZeta.OpensAt(zeta, numEvals) || !proof.AtZetaOmega.OpensAt(zetaOmega, 1) {
+		return false
+	}
+	ys := proof.AtZeta.Ys
+
+	zh, ls, err := polynomial.LagrangeAt(zeta, params.Omega, params.N, 1, Field)
+	if err != nil {
+		return false
+	}
+	l0 := ls[0]
+	lhs := Field.Sub(
+		Field.Mul(proof.AtZetaOmega.Ys[0], Field.Add(gamma, ys[evalB])),
+		Field.Mul(ys[evalZ], Field.Add(gamma, ys[evalA])),
+	)
+	lhs = Field.Add(lhs, Field.Mul(alpha, Field.Mul(Field.Sub(ys[evalZ], bigOne), l0)))
+	if lhs.Cmp(Field.Mul(ys[evalQ], zh)) != 0 {
+		return false
+	}
+
+	cs := []*bn256.G1{a, b, proof.Z, proof.Q}
+	return vk.VerifyAggregate(cs, proof.AtZeta) &&
+		vk.VerifyAggregate(cs[evalZ:evalZ+1], proof.AtZetaOmega)
+}
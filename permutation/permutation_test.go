@@ -0,0 +1,202 @@
+package permutation
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// srs supports vectors of up to 8 values.
+var srs = kzg.NewSRS(big.NewInt(1337), 8+3)
+
+func ints(xs ...int64) []*big.Int {
+	out := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		out[i] = big.NewInt(x)
+	}
+	return out
+}
+
+// commit returns the Vectors of a and b.
+func commit(t *testing.T, params *Params, a, b []*big.Int) (*Vector, *Vector) {
+	t.Helper()
+	va, err := params.Commit(srs, a, rand.Reader)
+	if err != nil {
+		t.Fatalf("Commit(%v) error %v", a, err)
+	}
+	vb, err := params.Commit(srs, b, rand.Reader)
+	if err != nil {
+		t.Fatalf("Commit(%v) error %v", b, err)
+	}
+	return va, vb
+}
+
+func TestPermutation(t *testing.T) {
+	params, err := NewParams(srs, 6)
+	if err != nil {
+		t.Fatalf("NewParams() error %v", err)
+	}
+	if got, want := params.N, uint64(8); got != want {
+		t.Errorf("NewParams().N = %d; want %d", got, want)
+	}
+	pr, vk := kzg.NewProver(srs), srs.VerifierKey()
+
+	for _, tt := range []struct {
+		name string
+		a, b []*big.Int
+	}{
+		{"shuffle", ints(1, 2, 3, 4, 5, 6), ints(6, 4, 2, 1, 3, 5)},
+		{"repeated", ints(7, 7, 1), ints(7, 1, 7)},
+		{"identity", ints(1, 2, 3, 4, 5, 6, 7, 8), ints(1, 2, 3, 4, 5, 6, 7, 8)},
+		{"empty", nil, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := commit(t, params, tt.a, tt.b)
+			proof, err := Prove(pr, params, a, b, rand.Reader)
+			if err != nil {
+				t.Fatalf("Prove() error %v", err)
+			}
+			if !Verify(vk, params, a.C, b.C, proof) {
+				t.Error("Verify() = false; want true")
+			}
+		})
+	}
+
+	// Vectors committed to independently of the proof, without blinding.
+	pa := polynomial.NewPolynomial(params.domain.IFFT(ints(4, 0, 9, 4)))
+	pb := polynomial.NewPolynomial(params.domain.IFFT(ints(9, 4, 4, 0)))
+	var cs [2]*bn256.G1
+	for i, p := range []*polynomial.Polynomial{pa, pb} {
+		if cs[i], err = srs.Commit(p); err != nil {
+			t.Fatalf("Commit() error %v", err)
+		}
+	}
+	if proof, err := Prove(pr, params, &Vector{Poly: pa, C: cs[0]}, &Vector{Poly: pb, C: cs[1]}, rand.Reader); err != nil {
+		t.Errorf("Prove(<external vectors>) error %v", err)
+	} else if !Verify(vk, params, cs[0], cs[1], proof) {
+		t.Error("Verify(<external vectors>) = false; want true")
+	}
+
+	a, b := commit(t, params, ints(1, 2, 3), ints(3, 1, 2))
+	proof, err := Prove(pr, params, a, b, rand.Reader)
+	if err != nil {
+		t.Fatalf("Prove() error %v", err)
+	}
+	another, _ := commit(t, params, ints(1, 2, 3), nil)
+	other, err := NewParams(srs, 4)
+	if err != nil {
+		t.Fatalf("NewParams() error %v", err)
+	}
+	g := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+	shifted := func(p *bn256.G1) *bn256.G1 { return new(bn256.G1).Add(p, g) }
+	withEval := func(i int) *Proof {
+		p := *proof
+		at := *p.AtZeta
+		at.Ys = append([]*big.Int(nil), at.Ys...)
+		at.Ys[i] = Field.Add(at.Ys[i], bigOne)
+		p.AtZeta = &at
+		return &p
+	}
+	tests := []struct {
+		name   string
+		params *Params
+		a, b   *bn256.G1
+		proof  *Proof
+	}{
+		{"other params", other, a.C, b.C, proof},
+		{"wrong A", params, shifted(a.C), b.C, proof},
+		{"other commitment to equal values", params, another.C, b.C, proof},
+		{"swapped vectors", params, b.C, a.C, proof},
+		{"no vector", params, nil, b.C, proof},
+		{"wrong z(zeta)", params, a.C, b.C, withEval(evalZ)},
+		{"wrong q(zeta)", params, a.C, b.C, withEval(evalQ)},
+		{"swapped openings", params, a.C, b.C, &Proof{Z: proof.Z, Q: proof.Q, AtZeta: proof.AtZeta, AtZetaOmega: proof.AtZeta}},
+		{"incomplete", params, a.C, b.C, &Proof{Z: proof.Z, Q: proof.Q}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(vk, tt.params, tt.a, tt.b, tt.proof) {
+				t.Error("Verify() = true; want false")
+			}
+		})
+	}
+}
+
+func TestProveErrors(t *testing.T) {
+	params, err := NewParams(srs, 4)
+	if err != nil {
+		t.Fatalf("NewParams() error %v", err)
+	}
+	pr := kzg.NewProver(srs)
+
+	for _, tt := range []struct {
+		name string
+		a, b []*big.Int
+	}{
+		{"distinct multisets", ints(1, 2, 3), ints(1, 2, 4)},
+		{"distinct multiplicities", ints(1, 1, 2), ints(1, 2, 2)},
+		{"distinct lengths", ints(1, 2), ints(2)},
+	} {
+		a, b := commit(t, params, tt.a, tt.b)
+		if _, err := Prove(pr, params, a, b, rand.Reader); !errors.Is(err, ErrNotPermutation) {
+			t.Errorf("Prove(%s) error %v; want %v", tt.name, err, ErrNotPermutation)
+		}
+	}
+	a, b := commit(t, params, ints(1), ints(1))
+	if _, err := Prove(pr, params, a, &Vector{Poly: b.Poly}, rand.Reader); err == nil {
+		t.Error("Prove(uncommitted vector) error nil; want error")
+	}
+	if _, err := params.Commit(srs, ints(1, 2, 3, 4, 5), rand.Reader); err == nil {
+		t.Error("Commit(too long vector) error nil; want error")
+	}
+	if _, err := params.Commit(srs, ints(-1), rand.Reader); err == nil {
+		t.Error("Commit(unreduced value) error nil; want error")
+	}
+	if _, err := Prove(pr, &Params{N: params.N, Omega: params.Omega}, a, b, rand.Reader); err == nil {
+		t.Error("Prove() with Params without Domain; error nil; want error")
+	}
+	if _, err := NewParams(kzg.NewSRS(big.NewInt(1337), 4+2), 4); err == nil {
+		t.Error("NewParams() with too small SRS; error nil; want error")
+	}
+}
+
+func TestGrandProduct(t *testing.T) {
+	for _, tt := range []struct {
+		nums, dens []*big.Int
+		wraps      bool
+	}{
+		{ints(2, 3, 4), ints(3, 4, 2), true},
+		{ints(1), ints(1), true},
+		{ints(2, 2), ints(1, 1), false},
+	} {
+		zs, wraps, err := GrandProduct(tt.nums, tt.dens)
+		if err != nil {
+			t.Fatalf("GrandProduct(%v, %v) error %v", tt.nums, tt.dens, err)
+		}
+		if wraps != tt.wraps {
+			t.Errorf("GrandProduct(%v, %v) wraps = %t; want %t", tt.nums, tt.dens, wraps, tt.wraps)
+		}
+		// z_i prod_(j<i) dens[j] = prod_(j<i) nums[j].
+		for i, z := range zs {
+			lhs, rhs := z, big.NewInt(1)
+			for j := 0; j < i; j++ {
+				lhs, rhs = Field.Mul(lhs, tt.dens[j]), Field.Mul(rhs, tt.nums[j])
+			}
+			if lhs.Cmp(rhs) != 0 {
+				t.Errorf("GrandProduct(%v, %v)[%d] = %v; want prod_(j<%[3]d) nums[j]/dens[j]", tt.nums, tt.dens, i, z)
+			}
+		}
+	}
+
+	if _, _, err := GrandProduct(ints(1, 2), ints(1)); err == nil {
+		t.Error("GrandProduct(<length mismatch>) error nil; want error")
+	}
+	if _, _, err := GrandProduct(ints(1), ints(0)); err == nil {
+		t.Error("GrandProduct(<zero denominator>) error nil; want error")
+	}
+}
@@ -0,0 +1,271 @@
+package plonk
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrUnsatisfied is returned for witnesses that violate a gate.
+var ErrUnsatisfied = errors.New("gate not satisfied")
+
+// A Variable is a value carried by the wires of a Circuit under construction
+// by a Builder. All wires of a Variable are bound to be equal by the copy
+// constraints.
+type Variable struct {
+	id int
+}
+
+// unused marks a wire of a gate that isn't connected to a Variable; its value
+// is 0.
+const unused = -1
+
+// A gate is the constraint qL a + qR b + qO c + qM a b + qC = 0 on the values
+// of its wires a, b and c, the Variables at wires or unused.
+type gate struct {
+	qL, qR, qO, qM, qC *big.Int
+	wires              [3]int
+}
+
+// eval returns the left-hand side of the gate for the values of the
+// Variables.
+func (g *gate) eval(w []*big.Int) *big.Int {
+	a, b, c := wireValue(w, g.wires[0]), wireValue(w, g.wires[1]), wireValue(w, g.wires[2])
+	sum := Field.Add(Field.Mul(g.qL, a), Field.Mul(g.qR, b))
+	sum = Field.Add(sum, Field.Mul(g.qO, c))
+	sum = Field.Add(sum, Field.Mul(g.qM, Field.Mul(a, b)))
+	return Field.Add(sum, g.qC)
+}
+
+func wireValue(w []*big.Int, v int) *big.Int {
+	if v == unused {
+		return bigZero
+	}
+	return w[v]
+}
+
+// A variable is a Variable declared with a Builder, with the name of inputs.
+type variable struct {
+	public bool
+	name   string
+}
+
+// A hint computes the value of the output Variable of a gate,
+// qL a + qR b + qM a b + qC with qO = -1, from those of its inputs.
+type hint struct {
+	out, gate int
+}
+
+// newGate returns the gate with the selectors reduced into the Field.
+func newGate(qL, qR, qO, qM, qC *big.Int, wires [3]int) gate {
+	mod := func(x *big.Int) *big.Int { return new(big.Int).Mod(x, Field.Order()) }
+	return gate{qL: mod(qL), qR: mod(qR), qO: mod(qO), qM: mod(qM), qC: mod(qC), wires: wires}
+}
+
+// smallGate is newGate for int64 selectors.
+func smallGate(qL, qR, qO, qM, qC int64, wires [3]int) gate {
+	return newGate(big.NewInt(qL), big.NewInt(qR), big.NewInt(qO), big.NewInt(qM), big.NewInt(qC), wires)
+}
+
+// A Builder declares the Variables and gates of a Circuit.
+type Builder struct {
+	vars  []variable
+	names map[string]bool
+	gates []gate
+	hints []hint
+	err   error
+}
+
+// NewBuilder returns a Builder of Circuits.
+func NewBuilder() *Builder {
+	return &Builder{names: make(map[string]bool)}
+}
+
+func (b *Builder) newVariable(public bool, name string) Variable {
+	if name != "" {
+		if b.names[name] && b.err == nil {
+			b.err = fmt.Errorf("duplicate input %q", name)
+		}
+		b.names[name] = true
+	}
+	b.vars = append(b.vars, variable{public: public, name: name})
+	return Variable{len(b.vars) - 1}
+}
+
+// Public declares a public input, assigned by name on Solve.
+func (b *Builder) Public(name string) Variable {
+	return b.newVariable(true, name)
+}
+
+// Secret declares a secret input, assigned by name on Solve.
+func (b *Builder) Secret(name string) Variable {
+	return b.newVariable(false, name)
+}
+
+// Gate adds the gate qL x + qR y + qO z + qM x y + qC = 0. It doesn't compute
+// any Variable on Solve, so x, y and z must be computed otherwise.
+func (b *Builder) Gate(qL, qR, qO, qM, qC *big.Int, x, y, z Variable) {
+	b.gates = append(b.gates, newGate(qL, qR, qO, qM, qC, [3]int{x.id, y.id, z.id}))
+}
+
+// output adds the gate computing z = qL x + qR y + qM x y + qC for a new
+// Variable z, with x or y unused if nil.
+func (b *Builder) output(qL, qR, qM, qC int64, x, y *Variable) Variable {
+	z := b.newVariable(false, "")
+	wires := [3]int{unused, unused, z.id}
+	if x != nil {
+		wires[0] = x.id
+	}
+	if y != nil {
+		wires[1] = y.id
+	}
+	b.hints = append(b.hints, hint{out: z.id, gate: len(b.gates)})
+	b.gates = append(b.gates, smallGate(qL, qR, -1, qM, qC, wires))
+	return z
+}
+
+// Add returns a Variable constrained to x + y.
+func (b *Builder) Add(x, y Variable) Variable {
+	return b.output(1, 1, 0, 0, &x, &y)
+}
+
+// Sub returns a Variable constrained to x - y.
+func (b *Builder) Sub(x, y Variable) Variable {
+	return b.output(1, -1, 0, 0, &x, &y)
+}
+
+// Mul returns a Variable constrained to x * y.
+func (b *Builder) Mul(x, y Variable) Variable {
+	return b.output(0, 0, 1, 0, &x, &y)
+}
+
+// AddConstant returns a Variable constrained to x + k.
+func (b *Builder) AddConstant(x Variable, k int64) Variable {
+	return b.output(1, 0, 0, k, &x, nil)
+}
+
+// Constant returns a Variable constrained to k.
+func (b *Builder) Constant(k int64) Variable {
+	return b.output(0, 0, 0, k, nil, nil)
+}
+
+// AssertEqual adds the gate x - y = 0.
+func (b *Builder) AssertEqual(x, y Variable) {
+	b.gates = append(b.gates, smallGate(1, -1, 0, 0, 0, [3]int{x.id, y.id, unused}))
+}
+
+// AssertBoolean adds the gate x * x - x = 0, i.e. that x is 0 or 1.
+func (b *Builder) AssertBoolean(x Variable) {
+	b.gates = append(b.gates, smallGate(-1, 0, 0, 1, 0, [3]int{x.id, x.id, unused}))
+}
+
+// A Circuit is a set of gates on Variables, with the public inputs bound by
+// the first gates, in order of declaration.
+type Circuit struct {
+	vars   []variable
+	public []int
+	gates  []gate
+	hints  []hint
+	inputs map[string]int
+}
+
+// Build returns the Circuit of the declared Variables and gates.
+func (b *Builder) Build() (*Circuit, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	c := &Circuit{vars: b.vars, inputs: make(map[string]int)}
+	for i, v := range b.vars {
+		if v.name != "" {
+			c.inputs[v.name] = i
+		}
+		if v.public {
+			// x_i + PI(w^i) = 0 with PI(w^i) = -x_i; see Verify.
+			c.public = append(c.public, i)
+			c.gates = append(c.gates, smallGate(1, 0, 0, 0, 0, [3]int{i, unused, unused}))
+		}
+	}
+	for _, g := range b.gates {
+		for _, v := range g.wires {
+			if v < unused || v >= len(b.vars) {
+				return nil, errors.New("gate on a Variable of another Builder")
+			}
+		}
+	}
+	c.gates = append(c.gates, b.gates...)
+	for _, h := range b.hints {
+		c.hints = append(c.hints, hint{out: h.out, gate: len(c.public) + h.gate})
+	}
+	return c, nil
+}
+
+// NumGates returns the number of gates, including those of the public inputs.
+func (c *Circuit) NumGates() int {
+	return len(c.gates)
+}
+
+// NumPublic returns the number of public inputs.
+func (c *Circuit) NumPublic() int {
+	return len(c.public)
+}
+
+// Solve returns the values of all Variables for the named inputs, and checks
+// that they satisfy the Circuit. All inputs must be assigned; values are
+// reduced into the Field.
+func (c *Circuit) Solve(inputs map[string]*big.Int) ([]*big.Int, error) {
+	w := make([]*big.Int, len(c.vars))
+	for name, i := range c.inputs {
+		x, ok := inputs[name]
+		if !ok {
+			return nil, fmt.Errorf("input %q not assigned", name)
+		}
+		w[i] = new(big.Int).Mod(x, Field.Order())
+	}
+	if len(inputs) != len(c.inputs) {
+		for name := range inputs {
+			if _, ok := c.inputs[name]; !ok {
+				return nil, fmt.Errorf("unknown input %q", name)
+			}
+		}
+	}
+	for _, h := range c.hints {
+		// Without the output, the gate evaluates to its value.
+		g := c.gates[h.gate]
+		g.qO, g.wires[2] = bigZero, unused
+		w[h.out] = g.eval(w)
+	}
+	if err := c.IsSatisfied(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// IsSatisfied returns nil if the values of the Variables satisfy all gates, or
+// an error wrapping ErrUnsatisfied for the first that they violate. The gates
+// of the public inputs are checked by Verify.
+func (c *Circuit) IsSatisfied(w []*big.Int) error {
+	if len(w) != len(c.vars) {
+		return fmt.Errorf("witness of %d values; want %d", len(w), len(c.vars))
+	}
+	for i, x := range w {
+		if x == nil || x.Sign() < 0 || x.Cmp(Field.Order()) >= 0 {
+			return fmt.Errorf("witness[%d] not in the field", i)
+		}
+	}
+	for i, g := range c.gates[len(c.public):] {
+		if g.eval(w).Sign() != 0 {
+			return fmt.Errorf("gate %d: %w", len(c.public)+i, ErrUnsatisfied)
+		}
+	}
+	return nil
+}
+
+// PublicInputs returns the values of the public inputs, in order of
+// declaration.
+func (c *Circuit) PublicInputs(w []*big.Int) []*big.Int {
+	xs := make([]*big.Int, len(c.public))
+	for i, v := range c.public {
+		xs[i] = w[v]
+	}
+	return xs
+}
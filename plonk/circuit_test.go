@@ -0,0 +1,104 @@
+package plonk
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// cubic returns the Circuit of out = x^3 + x + 5.
+func cubic(t *testing.T) *Circuit {
+	t.Helper()
+	b := NewBuilder()
+	out := b.Public("out")
+	x := b.Secret("x")
+	x3 := b.Mul(b.Mul(x, x), x)
+	b.AssertEqual(b.AddConstant(b.Add(x3, x), 5), out)
+	c, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error %v", err)
+	}
+	return c
+}
+
+func TestSolve(t *testing.T) {
+	c := cubic(t)
+	if got, want := c.NumGates(), 6; got != want {
+		t.Errorf("NumGates() = %d; want %d", got, want)
+	}
+	if got, want := c.NumPublic(), 1; got != want {
+		t.Errorf("NumPublic() = %d; want %d", got, want)
+	}
+
+	w, err := c.Solve(map[string]*big.Int{"out": big.NewInt(35), "x": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Solve() error %v", err)
+	}
+	for i, want := range []int64{35, 3, 9, 27, 30, 35} {
+		if w[i].Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("Solve()[%d] = %v; want %d", i, w[i], want)
+		}
+	}
+	if got := c.PublicInputs(w); len(got) != 1 || got[0].Int64() != 35 {
+		t.Errorf("PublicInputs() = %v; want [35]", got)
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	b := NewBuilder()
+	x := b.Secret("x")
+	bit := b.Secret("bit")
+	b.AssertBoolean(bit)
+	b.AssertEqual(b.Sub(x, b.Constant(2)), b.Constant(0))
+	c, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		inputs          map[string]*big.Int
+		wantErr         bool
+		wantUnsatisfied bool
+	}{
+		{"valid", map[string]*big.Int{"x": big.NewInt(2), "bit": big.NewInt(1)}, false, false},
+		{"reduced input", map[string]*big.Int{"x": new(big.Int).Add(bn256.Order, big.NewInt(2)), "bit": big.NewInt(0)}, false, false},
+		{"not boolean", map[string]*big.Int{"x": big.NewInt(2), "bit": big.NewInt(2)}, true, true},
+		{"wrong x", map[string]*big.Int{"x": big.NewInt(3), "bit": big.NewInt(0)}, true, true},
+		{"missing input", map[string]*big.Int{"x": big.NewInt(2)}, true, false},
+		{"unknown input", map[string]*big.Int{"x": big.NewInt(2), "bit": big.NewInt(1), "y": big.NewInt(0)}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Solve(tt.inputs)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("Solve() error %v; want error %t", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrUnsatisfied); got != tt.wantUnsatisfied {
+				t.Errorf("errors.Is(Solve(), ErrUnsatisfied) = %t; want %t", got, tt.wantUnsatisfied)
+			}
+		})
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	dup := NewBuilder()
+	dup.Public("x")
+	dup.Secret("x")
+	if _, err := dup.Build(); err == nil {
+		t.Error("Build() with duplicate inputs; want error")
+	}
+
+	other := NewBuilder()
+	other.Secret("x")
+	other.Secret("y")
+	z := other.Secret("z")
+	b := NewBuilder()
+	b.Secret("z")
+	b.Mul(z, z)
+	if _, err := b.Build(); err == nil {
+		t.Error("Build() with an unknown Variable; want error")
+	}
+}
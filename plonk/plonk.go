@@ -0,0 +1,241 @@
+// Package plonk implements the zk-SNARK of Gabizon, Williamson and Ciobotaru,
+// "PLONK: Permutations over Lagrange-bases for Oecumenical Noninteractive
+// arguments of Knowledge", over KZG commitments on bn256, without lookups.
+//
+// A Circuit of n gates is indexed by the elements w^i of a Domain H of size n,
+// with the selectors, wires, permutation and the grand product z as the
+// polynomials interpolating their values over H. A witness satisfies the
+// Circuit iff, for random beta, gamma and alpha,
+//
+//	qL a + qR b + qO c + qM a b + qC + PI
+//	+ alpha (z(X) (a + beta X + gamma) (b + beta k1 X + gamma) (c + beta k2 X + gamma)
+//	   - z(w X) (a + beta S1 + gamma) (b + beta S2 + gamma) (c + beta S3 + gamma))
+//	+ alpha^2 (z - 1) L_0
+//
+// is divisible by the vanishing polynomial Z_H = X^n - 1, where the wires of
+// the ith gate are assigned the identities w^i, k1 w^i and k2 w^i, and S1, S2
+// and S3 map each of them to the next wire of the same Variable. The quotient
+// t is committed to in full rather than split, so proofs require an SRS of
+// maximum degree at least 3n+5. All polynomials are opened at a challenge
+// zeta, and z additionally at w zeta, with two kzg.AggregateProofs; the
+// verifier then checks the identity above at zeta.
+//
+// Challenges are derived with a transcript.Transcript of the VerifyingKey,
+// the public inputs and the commitments of the proof.
+package plonk
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+var (
+	// Field is the scalar field of bn256, over which Circuits are defined.
+	Field = kzg.Field
+
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+
+	// k1 and k2 generate the cosets of H assigned to the wires b and c.
+	k1 = big.NewInt(2)
+	k2 = big.NewInt(3)
+)
+
+// A VerifyingKey verifies Proofs of a Circuit over a Domain of size N,
+// generated by Omega, with the commitments to its selector and permutation
+// polynomials.
+type VerifyingKey struct {
+	N                  uint64
+	Omega              *big.Int
+	NumPublic          int
+	QL, QR, QO, QM, QC *bn256.G1
+	S1, S2, S3         *bn256.G1
+	KZG                *kzg.VerifierKey
+}
+
+// fixed returns the commitments to the selector and permutation polynomials,
+// in the order of ProvingKey.fixed.
+func (vk *VerifyingKey) fixed() []*bn256.G1 {
+	return []*bn256.G1{vk.QL, vk.QR, vk.QO, vk.QM, vk.QC, vk.S1, vk.S2, vk.S3}
+}
+
+// A ProvingKey proves witnesses of a Circuit.
+type ProvingKey struct {
+	circuit *Circuit
+	domain  *polynomial.Domain
+	prover  *kzg.Prover
+	vk      *VerifyingKey
+	// fixed holds the polynomials qL, qR, qO, qM, qC, S1, S2 and S3, and sigma
+	// the evaluations of S1, S2 and S3 over the Domain.
+	fixed []*polynomial.Polynomial
+	sigma [3][]*big.Int
+}
+
+// Circuit returns the Circuit of the ProvingKey.
+func (pk *ProvingKey) Circuit() *Circuit {
+	return pk.circuit
+}
+
+// VerifyingKey returns the VerifyingKey matching the ProvingKey.
+func (pk *ProvingKey) VerifyingKey() *VerifyingKey {
+	return pk.vk
+}
+
+// Setup returns the keys of the Circuit for the SRS, which must support
+// polynomials of degree 3n+5 for the smallest power of two n of at least
+// c.NumGates(), over the canonical Domain of size n.
+func Setup(c *Circuit, srs *kzg.SRS) (*ProvingKey, *VerifyingKey, error) {
+	n := uint64(1)
+	for n < uint64(len(c.gates)) {
+		n <<= 1
+	}
+	if srs.MaxDegree() < 3*int(n)+5 {
+		return nil, nil, fmt.Errorf("SRS of max degree %d; want at least %d for %d gates", srs.MaxDegree(), 3*n+5, n)
+	}
+	d, err := polynomial.CanonicalDomain(Field, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The cosets H, k1 H and k2 H must be distinct.
+	nn := new(big.Int).SetUint64(n)
+	k2k1, err := Field.Div(k2, k1)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, k := range []*big.Int{k1, k2, k2k1} {
+		if Field.Exp(k, nn).Cmp(bigOne) == 0 {
+			return nil, nil, errors.New("wire cosets not distinct")
+		}
+	}
+
+	zeros := func() []*big.Int {
+		xs := make([]*big.Int, n)
+		for i := range xs {
+			xs[i] = big.NewInt(0)
+		}
+		return xs
+	}
+	var selectors [5][]*big.Int
+	for s := range selectors {
+		selectors[s] = zeros()
+	}
+	for i, g := range c.gates {
+		for s, q := range []*big.Int{g.qL, g.qR, g.qO, g.qM, g.qC} {
+			selectors[s][i] = q
+		}
+	}
+
+	// Each wire is assigned the identity k_j w^i; S_j maps it to that of the
+	// next wire of its Variable, and unused wires to themselves.
+	ks := [3]*big.Int{bigOne, k1, k2}
+	var ids [3][]*big.Int
+	for j := range ids {
+		ids[j] = make([]*big.Int, n)
+		for i := range ids[j] {
+			ids[j][i] = Field.Mul(ks[j], d.Element(uint64(i)))
+		}
+	}
+	type position struct{ column, row int }
+	cycles := make([][]position, len(c.vars))
+	for i, g := range c.gates {
+		for j, v := range g.wires {
+			if v != unused {
+				cycles[v] = append(cycles[v], position{j, i})
+			}
+		}
+	}
+	pk := &ProvingKey{circuit: c, domain: d, prover: kzg.NewProver(srs)}
+	for j := range pk.sigma {
+		pk.sigma[j] = append([]*big.Int(nil), ids[j]...)
+	}
+	for _, cycle := range cycles {
+		for i, p := range cycle {
+			next := cycle[(i+1)%len(cycle)]
+			pk.sigma[p.column][p.row] = ids[next.column][next.row]
+		}
+	}
+
+	vk := &VerifyingKey{N: n, Omega: d.Generator(), NumPublic: len(c.public), KZG: srs.VerifierKey()}
+	var cs []*bn256.G1
+	for _, evals := range append(selectors[:], pk.sigma[:]...) {
+		p := polynomial.NewPolynomial(d.IFFT(evals)).Normalize(Field)
+		commitment, err := srs.Commit(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		pk.fixed = append(pk.fixed, p)
+		cs = append(cs, commitment)
+	}
+	vk.QL, vk.QR, vk.QO, vk.QM, vk.QC, vk.S1, vk.S2, vk.S3 = cs[0], cs[1], cs[2], cs[3], cs[4], cs[5], cs[6], cs[7]
+	pk.vk = vk
+	return pk, vk, nil
+}
+
+// A Proof is a PLONK proof, with the commitments to the wire polynomials a, b
+// and c, the grand product z and the quotient t. AtZeta opens a, b, c, z, t,
+// the selectors and S1, S2, S3, in that order, at zeta; AtZetaOmega opens z at
+// w zeta.
+type Proof struct {
+	A, B, C, Z, T       *bn256.G1
+	AtZeta, AtZetaOmega *kzg.AggregateProof
+}
+
+// Indices of the evaluations of Proof.AtZeta.
+const (
+	evalA = iota
+	evalB
+	evalC
+	evalZ
+	evalT
+	evalQL
+	evalQR
+	evalQO
+	evalQM
+	evalQC
+	evalS1
+	evalS2
+	evalS3
+	numEvals
+)
+
+// challenges holds the Fiat-Shamir challenges of a Proof.
+type challenges struct {
+	beta, gamma, alpha, zeta *big.Int
+}
+
+// newTranscript returns the Transcript of the VerifyingKey and the public
+// inputs.
+func newTranscript(vk *VerifyingKey, public []*big.Int) *transcript.Transcript {
+	t := transcript.New("zkp.xyz/plonk")
+	t.AppendScalar("n", new(big.Int).SetUint64(vk.N))
+	t.AppendScalar("omega", vk.Omega)
+	for _, c := range vk.fixed() {
+		t.AppendG1("fixed", c)
+	}
+	for _, x := range public {
+		t.AppendScalar("public", x)
+	}
+	return t
+}
+
+// challenges returns the challenges of the proof, whose commitments must be
+// non-nil.
+func (proof *Proof) challenges(vk *VerifyingKey, public []*big.Int) challenges {
+	t := newTranscript(vk, public)
+	var ch challenges
+	t.AppendG1("a", proof.A)
+	t.AppendG1("b", proof.B)
+	t.AppendG1("c", proof.C)
+	ch.beta, ch.gamma = t.Challenge(Field), t.Challenge(Field)
+	t.AppendG1("z", proof.Z)
+	ch.alpha = t.Challenge(Field)
+	t.AppendG1("t", proof.T)
+	ch.zeta = t.Challenge(Field)
+	return ch
+}
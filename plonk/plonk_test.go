@@ -0,0 +1,163 @@
+package plonk
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+)
+
+// srs supports Circuits of up to 8 gates.
+var srs = kzg.NewSRS(big.NewInt(1337), 3*8+5)
+
+func TestPlonk(t *testing.T) {
+	c := cubic(t)
+	w, err := c.Solve(map[string]*big.Int{"out": big.NewInt(35), "x": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Solve() error %v", err)
+	}
+	pk, vk, err := Setup(c, srs)
+	if err != nil {
+		t.Fatalf("Setup() error %v", err)
+	}
+	proof, err := Prove(pk, w, rand.Reader)
+	if err != nil {
+		t.Fatalf("Prove() error %v", err)
+	}
+	public := c.PublicInputs(w)
+	if !Verify(vk, proof, public) {
+		t.Fatal("Verify() = false; want true")
+	}
+
+	g := new(bn256.G1).ScalarBaseMult(big.NewInt(1))
+	shifted := func(p *bn256.G1) *bn256.G1 { return new(bn256.G1).Add(p, g) }
+	withEval := func(i int) *Proof {
+		p := *proof
+		at := *p.AtZeta
+		at.Ys = append([]*big.Int(nil), at.Ys...)
+		at.Ys[i] = Field.Add(at.Ys[i], bigOne)
+		p.AtZeta = &at
+		return &p
+	}
+	tests := []struct {
+		name   string
+		proof  func() *Proof
+		public []*big.Int
+	}{
+		{"wrong public input", func() *Proof { return proof }, []*big.Int{big.NewInt(36)}},
+		{"unreduced public input", func() *Proof { return proof }, []*big.Int{new(big.Int).Add(public[0], bn256.Order)}},
+		{"no public input", func() *Proof { return proof }, nil},
+		{"wrong A", func() *Proof { p := *proof; p.A = shifted(p.A); return &p }, public},
+		{"wrong Z", func() *Proof { p := *proof; p.Z = shifted(p.Z); return &p }, public},
+		{"wrong T", func() *Proof { p := *proof; p.T = shifted(p.T); return &p }, public},
+		{"wrong a(zeta)", func() *Proof { return withEval(evalA) }, public},
+		{"wrong t(zeta)", func() *Proof { return withEval(evalT) }, public},
+		{"wrong S3(zeta)", func() *Proof { return withEval(evalS3) }, public},
+		{"wrong z(w zeta)", func() *Proof {
+			p := *proof
+			at := *p.AtZetaOmega
+			at.Ys = []*big.Int{Field.Add(at.Ys[0], bigOne)}
+			p.AtZetaOmega = &at
+			return &p
+		}, public},
+		{"swapped openings", func() *Proof {
+			p := *proof
+			p.AtZetaOmega = p.AtZeta
+			return &p
+		}, public},
+		{"incomplete", func() *Proof { p := *proof; p.AtZeta = nil; return &p }, public},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if Verify(vk, tt.proof(), tt.public) {
+				t.Error("Verify() = true; want false")
+			}
+		})
+	}
+
+	// Proofs are randomised, so that they don't reveal the witness.
+	other, err := Prove(pk, w, rand.Reader)
+	if err != nil {
+		t.Fatalf("Prove() error %v", err)
+	}
+	if other.A.String() == proof.A.String() || !Verify(vk, other, public) {
+		t.Error("Prove() twice returned equal or invalid proofs")
+	}
+}
+
+// TestCopyConstraints checks that proofs are bound to the wiring of the
+// Circuit, not only to its gates.
+func TestCopyConstraints(t *testing.T) {
+	// out = x * y with y = x, or with y an independent input.
+	circuit := func(square bool) *Circuit {
+		b := NewBuilder()
+		out := b.Public("out")
+		x := b.Secret("x")
+		y := x
+		if !square {
+			y = b.Secret("y")
+		}
+		b.AssertEqual(b.Mul(x, y), out)
+		c, err := b.Build()
+		if err != nil {
+			t.Fatalf("Build() error %v", err)
+		}
+		return c
+	}
+
+	product := circuit(false)
+	w, err := product.Solve(map[string]*big.Int{"out": big.NewInt(6), "x": big.NewInt(2), "y": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Solve() error %v", err)
+	}
+	pk, vk, err := Setup(product, srs)
+	if err != nil {
+		t.Fatalf("Setup() error %v", err)
+	}
+	proof, err := Prove(pk, w, rand.Reader)
+	if err != nil {
+		t.Fatalf("Prove() error %v", err)
+	}
+	if !Verify(vk, proof, product.PublicInputs(w)) {
+		t.Fatal("Verify() = false; want true")
+	}
+
+	// The square has the same selectors and Domain, but binds both factors
+	// to x.
+	_, sk, err := Setup(circuit(true), srs)
+	if err != nil {
+		t.Fatalf("Setup() error %v", err)
+	}
+	if sk.Omega.Cmp(vk.Omega) != 0 || sk.QM.String() != vk.QM.String() || sk.S2.String() == vk.S2.String() {
+		t.Fatal("Setup() of the square and product; want distinct permutations only")
+	}
+	if Verify(sk, proof, product.PublicInputs(w)) {
+		t.Error("Verify() with the permutation of another wiring = true; want false")
+	}
+}
+
+func TestProveErrors(t *testing.T) {
+	c := cubic(t)
+	w, err := c.Solve(map[string]*big.Int{"out": big.NewInt(35), "x": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Solve() error %v", err)
+	}
+	pk, _, err := Setup(c, srs)
+	if err != nil {
+		t.Fatalf("Setup() error %v", err)
+	}
+
+	bad := append([]*big.Int(nil), w...)
+	bad[2] = big.NewInt(10)
+	if _, err := Prove(pk, bad, rand.Reader); err == nil {
+		t.Error("Prove(unsatisfying witness) error nil; want error")
+	}
+	if _, err := Prove(pk, w[1:], rand.Reader); err == nil {
+		t.Error("Prove(short witness) error nil; want error")
+	}
+	if _, _, err := Setup(c, kzg.NewSRS(big.NewInt(1337), 3*8+4)); err == nil {
+		t.Error("Setup() with too small SRS; error nil; want error")
+	}
+}
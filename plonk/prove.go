@@ -0,0 +1,132 @@
+package plonk
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/permutation"
+	"zkp.xyz/membership/polynomial"
+)
+
+// Prove returns a Proof for the values of the Variables of the Circuit, as
+// returned by Circuit.Solve, drawing the blinding factors from r.
+func Prove(pk *ProvingKey, w []*big.Int, r io.Reader) (*Proof, error) {
+	c, d := pk.circuit, pk.domain
+	if err := c.IsSatisfied(w); err != nil {
+		return nil, err
+	}
+	n := int(d.Size())
+	public := c.PublicInputs(w)
+	t := newTranscript(pk.vk, public)
+	proof := new(Proof)
+
+	// Round 1: the wire polynomials, blinded with (b_1 X + b_0) Z_H.
+	var wires [3][]*big.Int
+	for j := range wires {
+		wires[j] = make([]*big.Int, n)
+		for i := range wires[j] {
+			wires[j][i] = bigZero
+			if i < len(c.gates) {
+				wires[j][i] = wireValue(w, c.gates[i].wires[j])
+			}
+		}
+	}
+	var abc [3]*polynomial.Polynomial
+	var commitments [3]*bn256.G1
+	for j := range abc {
+		var err error
+		if abc[j], err = d.Blinded(wires[j], 2, r); err != nil {
+			return nil, err
+		}
+		if commitments[j], err = pk.prover.SRS().Commit(abc[j]); err != nil {
+			return nil, err
+		}
+	}
+	proof.A, proof.B, proof.C = commitments[0], commitments[1], commitments[2]
+	t.AppendG1("a", proof.A)
+	t.AppendG1("b", proof.B)
+	t.AppendG1("c", proof.C)
+	beta, gamma := t.Challenge(Field), t.Challenge(Field)
+
+	// Round 2: the grand product z, with z(w^0) = 1 and
+	// z(w^(i+1)) = z(w^i) prod_j (w_j + beta k_j w^i + gamma) / (w_j + beta S_j(w^i) + gamma).
+	ks := [3]*big.Int{bigOne, k1, k2}
+	nums, dens := make([]*big.Int, n), make([]*big.Int, n)
+	for i := range nums {
+		nums[i], dens[i] = big.NewInt(1), big.NewInt(1)
+		x := d.Element(uint64(i))
+		for j := range wires {
+			wg := Field.Add(wires[j][i], gamma)
+			nums[i] = Field.Mul(nums[i], Field.Add(wg, Field.Mul(beta, Field.Mul(ks[j], x))))
+			dens[i] = Field.Mul(dens[i], Field.Add(wg, Field.Mul(beta, pk.sigma[j][i])))
+		}
+	}
+	zs, wraps, err := permutation.GrandProduct(nums, dens)
+	if err != nil {
+		return nil, err
+	}
+	if !wraps {
+		return nil, fmt.Errorf("grand product: %w", ErrUnsatisfied)
+	}
+	z, err := d.Blinded(zs, 3, r)
+	if err != nil {
+		return nil, err
+	}
+	if proof.Z, err = pk.prover.SRS().Commit(z); err != nil {
+		return nil, err
+	}
+	t.AppendG1("z", proof.Z)
+	alpha := t.Challenge(Field)
+
+	// Round 3: the quotient t of the identity of the package documentation.
+	a, b, cc := abc[0], abc[1], abc[2]
+	ql, qr, qo, qm, qc := pk.fixed[0], pk.fixed[1], pk.fixed[2], pk.fixed[3], pk.fixed[4]
+	s1, s2, s3 := pk.fixed[5], pk.fixed[6], pk.fixed[7]
+
+	pis := make([]*big.Int, n)
+	for i := range pis {
+		pis[i] = bigZero
+		if i < len(public) {
+			pis[i] = Field.Sub(bigZero, public[i])
+		}
+	}
+	pi := polynomial.NewPolynomial(d.IFFT(pis))
+	gates := ql.Mul(a, Field).Add(qr.Mul(b, Field), Field).Add(qo.Mul(cc, Field), Field).
+		Add(qm.Mul(a.Mul(b, Field), Field), Field).Add(qc, Field).Add(pi, Field)
+
+	// id returns p + beta k X + gamma, and sigma p + beta s + gamma.
+	id := func(p *polynomial.Polynomial, k *big.Int) *polynomial.Polynomial {
+		return p.Add(polynomial.NewPolynomial([]*big.Int{gamma, Field.Mul(beta, k)}), Field)
+	}
+	sigma := func(p, s *polynomial.Polynomial) *polynomial.Polynomial {
+		return p.Add(s.Scale(beta, Field), Field).Add(polynomial.NewPolynomial([]*big.Int{gamma}), Field)
+	}
+	perm := z.Mul(id(a, bigOne), Field).Mul(id(b, k1), Field).Mul(id(cc, k2), Field).Sub(
+		d.Shift(z).Mul(sigma(a, s1), Field).Mul(sigma(b, s2), Field).Mul(sigma(cc, s3), Field), Field)
+	first := z.Sub(polynomial.OnePolynomial, Field).Mul(d.LagrangeBasis(0), Field)
+	numerator := gates.Add(perm.Scale(alpha, Field), Field).Add(first.Scale(Field.Mul(alpha, alpha), Field), Field)
+
+	quotient, rem := numerator.Div(d.Vanishing(), Field)
+	if rem.Degree() != 0 || (*rem)[0].Sign() != 0 {
+		return nil, fmt.Errorf("quotient: %w", ErrUnsatisfied)
+	}
+	if proof.T, err = pk.prover.SRS().Commit(quotient); err != nil {
+		return nil, err
+	}
+	t.AppendG1("t", proof.T)
+	zeta := t.Challenge(Field)
+
+	// Round 4: the openings at zeta and w zeta.
+	ps := append([]*polynomial.Polynomial{a, b, cc, z, quotient}, pk.fixed...)
+	cs := append([]*bn256.G1{proof.A, proof.B, proof.C, proof.Z, proof.T}, pk.vk.fixed()...)
+	if proof.AtZeta, err = pk.prover.OpenAggregate(ps, cs, zeta); err != nil {
+		return nil, err
+	}
+	zetaOmega := Field.Mul(zeta, d.Generator())
+	if proof.AtZetaOmega, err = pk.prover.OpenAggregate(ps[evalZ:evalZ+1], cs[evalZ:evalZ+1], zetaOmega); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
@@ -0,0 +1,78 @@
+package plonk
+
+import (
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/polynomial"
+)
+
+// Verify reports whether the proof is valid for the public inputs, each of
+// which must be reduced into the Field. With the evaluations of AtZeta, it
+// checks
+//
+//	t(zeta) Z_H(zeta) = qL a + qR b + qO c + qM a b + qC + PI(zeta)
+//	  + alpha (z(zeta) (a + beta zeta + gamma) (b + beta k1 zeta + gamma) (c + beta k2 zeta + gamma)
+//	     - z(w zeta) (a + beta S1 + gamma) (b + beta S2 + gamma) (c + beta S3 + gamma))
+//	  + alpha^2 (z(zeta) - 1) L_0(zeta)
+//
+// where PI(zeta) = -sum_i x_i L_i(zeta), and both openings.
+func Verify(vk *VerifyingKey, proof *Proof, public []*big.Int) bool {
+	if len(public) != vk.NumPublic || proof.A == nil || proof.B == nil || proof.C == nil ||
+		proof.Z == nil || proof.T == nil {
+		return false
+	}
+	for _, x := range public {
+		if x == nil || x.Sign() < 0 || x.Cmp(Field.Order()) >= 0 {
+			return false
+		}
+	}
+	ch := proof.challenges(vk, public)
+	zetaOmega := Field.Mul(ch.zeta, vk.Omega)
+	if !proof.AtZeta.OpensAt(ch.zeta, numEvals) || !proof.AtZetaOmega.OpensAt(zetaOmega, 1) {
+		return false
+	}
+	ys := proof.AtZeta.Ys
+
+	m := len(public)
+	if m == 0 {
+		m = 1
+	}
+	zh, lagrange, err := polynomial.LagrangeAt(ch.zeta, vk.Omega, vk.N, m, Field)
+	if err != nil {
+		return false
+	}
+	pi := big.NewInt(0)
+	for i, x := range public {
+		pi = Field.Sub(pi, Field.Mul(x, lagrange[i]))
+	}
+
+	a, b, c, z := ys[evalA], ys[evalB], ys[evalC], ys[evalZ]
+	gates := Field.Add(Field.Mul(ys[evalQL], a), Field.Mul(ys[evalQR], b))
+	gates = Field.Add(gates, Field.Mul(ys[evalQO], c))
+	gates = Field.Add(gates, Field.Mul(ys[evalQM], Field.Mul(a, b)))
+	gates = Field.Add(Field.Add(gates, ys[evalQC]), pi)
+
+	// term returns x + beta y + gamma.
+	term := func(x, y *big.Int) *big.Int {
+		return Field.Add(Field.Add(x, Field.Mul(ch.beta, y)), ch.gamma)
+	}
+	ids := Field.Mul(z, term(a, ch.zeta))
+	ids = Field.Mul(ids, term(b, Field.Mul(k1, ch.zeta)))
+	ids = Field.Mul(ids, term(c, Field.Mul(k2, ch.zeta)))
+	sigmas := Field.Mul(proof.AtZetaOmega.Ys[0], term(a, ys[evalS1]))
+	sigmas = Field.Mul(sigmas, term(b, ys[evalS2]))
+	sigmas = Field.Mul(sigmas, term(c, ys[evalS3]))
+	perm := Field.Sub(ids, sigmas)
+	first := Field.Mul(Field.Sub(z, bigOne), lagrange[0])
+
+	rhs := Field.Add(gates, Field.Mul(ch.alpha, perm))
+	rhs = Field.Add(rhs, Field.Mul(Field.Mul(ch.alpha, ch.alpha), first))
+	if Field.Mul(ys[evalT], zh).Cmp(rhs) != 0 {
+		return false
+	}
+
+	cs := append([]*bn256.G1{proof.A, proof.B, proof.C, proof.Z, proof.T}, vk.fixed()...)
+	return vk.KZG.VerifyAggregate(cs, proof.AtZeta) &&
+		vk.KZG.VerifyAggregate(cs[evalZ:evalZ+1], proof.AtZetaOmega)
+}
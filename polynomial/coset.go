@@ -0,0 +1,63 @@
+package polynomial
+
+import (
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// CosetFFT returns the evaluations of the polynomial with the given
+// coefficients at shift*w^i for each element w^i of the Domain, in order, i.e.
+// over the coset shift*D. As with FFT, missing coefficients are treated as
+// zero and CosetFFT panics if there are more coefficients than elements.
+func (d *Domain) CosetFFT(coeffs []*big.Int, shift *big.Int) []*big.Int {
+	return d.FFT(scalePowers(coeffs, shift, d.field))
+}
+
+// CosetIFFT is the inverse of CosetFFT, returning the coefficients of the
+// unique polynomial of degree < d.Size() that evaluates to evals over the
+// coset shift*D, or an error if shift is zero.
+func (d *Domain) CosetIFFT(evals []*big.Int, shift *big.Int) ([]*big.Int, error) {
+	inv, err := d.field.MultInverse(shift)
+	if err != nil {
+		return nil, fmt.Errorf("coset shift: %w", err)
+	}
+	return scalePowers(d.IFFT(evals), inv, d.field), nil
+}
+
+// scalePowers returns cs[i] * x^i, so that p(x v) has the coefficients
+// scalePowers(p, x).
+func scalePowers(cs []*big.Int, x *big.Int, f *galois.Field) []*big.Int {
+	out := make([]*big.Int, len(cs))
+	pow := big.NewInt(1)
+	for i, c := range cs {
+		out[i] = f.Mul(c, pow)
+		pow = f.Mul(pow, x)
+	}
+	return out
+}
+
+// LowDegreeExtend returns the Reed-Solomon encoding, with rate 1/blowup, of the
+// polynomial of degree < n that evaluates to evals over CanonicalDomain(f, n),
+// for n = len(evals): its evaluations over CanonicalDomain(f, n*blowup). As
+// canonical Domains are nested, the encoding is systematic, with evals at
+// every blowup-th position. Both n and blowup must be powers of two.
+func LowDegreeExtend(evals []*big.Int, blowup uint64, f *galois.Field) ([]*big.Int, error) {
+	if blowup == 0 || blowup&(blowup-1) != 0 {
+		return nil, fmt.Errorf("blowup %d is not a power of two", blowup)
+	}
+	n := uint64(len(evals))
+	if n*blowup/blowup != n {
+		return nil, fmt.Errorf("extended domain size %d * %d overflows", n, blowup)
+	}
+	d, err := CanonicalDomain(f, n)
+	if err != nil {
+		return nil, err
+	}
+	ext, err := CanonicalDomain(f, n*blowup)
+	if err != nil {
+		return nil, err
+	}
+	return ext.FFT(d.IFFT(evals)), nil
+}
@@ -0,0 +1,110 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+func TestCosetFFT(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	d, err := CanonicalDomain(f, 16)
+	if err != nil {
+		t.Fatalf("CanonicalDomain(): %v", err)
+	}
+
+	for _, degree := range []int{0, 5, 15} {
+		p, err := Random(degree, f, rand.Reader)
+		if err != nil {
+			t.Fatalf("Random(): %v", err)
+		}
+		shift, err := f.Random(rand.Reader)
+		if err != nil {
+			t.Fatalf("Random(): %v", err)
+		}
+
+		evals := d.CosetFFT(*p, shift)
+		for i, y := range evals {
+			x := f.Mul(shift, d.Element(uint64(i)))
+			if want := p.Evaluate(x, f); y.Cmp(want) != 0 {
+				t.Errorf("CosetFFT()[%d] = %v; want p(shift w^%[1]d) = %v", i, y, want)
+			}
+		}
+
+		cs, err := d.CosetIFFT(evals, shift)
+		if err != nil {
+			t.Fatalf("CosetIFFT(): %v", err)
+		}
+		if got := NewPolynomial(cs); !got.Eq(p) {
+			t.Errorf("CosetIFFT(CosetFFT(%v)) = %v", p, got)
+		}
+	}
+
+	if _, err := d.CosetIFFT(make([]*big.Int, 0), big.NewInt(0)); !errors.Is(err, galois.ErrNotInvertible) {
+		t.Errorf("CosetIFFT(<zero shift>): got err %v; want %v", err, galois.ErrNotInvertible)
+	}
+}
+
+func TestLowDegreeExtend(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	for _, tt := range []struct {
+		n, blowup uint64
+	}{
+		{n: 1, blowup: 4},
+		{n: 8, blowup: 1},
+		{n: 8, blowup: 2},
+		{n: 16, blowup: 8},
+	} {
+		d, err := CanonicalDomain(f, tt.n)
+		if err != nil {
+			t.Fatalf("CanonicalDomain(%d): %v", tt.n, err)
+		}
+		p, err := Random(int(tt.n)-1, f, rand.Reader)
+		if err != nil {
+			t.Fatalf("Random(): %v", err)
+		}
+		evals := d.FFT(*p)
+
+		got, err := LowDegreeExtend(evals, tt.blowup, f)
+		if err != nil {
+			t.Fatalf("LowDegreeExtend(<%d evals>, %d): %v", tt.n, tt.blowup, err)
+		}
+		ext, err := CanonicalDomain(f, tt.n*tt.blowup)
+		if err != nil {
+			t.Fatalf("CanonicalDomain(%d): %v", tt.n*tt.blowup, err)
+		}
+		if uint64(len(got)) != ext.Size() {
+			t.Fatalf("len(LowDegreeExtend(<%d evals>, %d)) = %d; want %d", tt.n, tt.blowup, len(got), ext.Size())
+		}
+		for i, y := range got {
+			if want := p.Evaluate(ext.Element(uint64(i)), f); y.Cmp(want) != 0 {
+				t.Errorf("LowDegreeExtend(<%d evals>, %d)[%d] = %v; want %v", tt.n, tt.blowup, i, y, want)
+			}
+			if i%int(tt.blowup) == 0 && y.Cmp(evals[i/int(tt.blowup)]) != 0 {
+				t.Errorf("LowDegreeExtend(<%d evals>, %d)[%d] = %v; want evals[%d] = %v", tt.n, tt.blowup, i, y, i/int(tt.blowup), evals[i/int(tt.blowup)])
+			}
+		}
+	}
+
+	for _, tt := range []struct {
+		n, blowup uint64
+	}{
+		{n: 8, blowup: 0},
+		{n: 8, blowup: 3},
+		{n: 6, blowup: 2},
+		{n: 0, blowup: 2},
+		{n: 8, blowup: 1 << 28},
+	} {
+		evals := make([]*big.Int, tt.n)
+		for i := range evals {
+			evals[i] = big.NewInt(int64(i))
+		}
+		if _, err := LowDegreeExtend(evals, tt.blowup, f); err == nil {
+			t.Errorf("LowDegreeExtend(<%d evals>, %d): got nil error", tt.n, tt.blowup)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package polynomial
+
+import (
+	"fmt"
+
+	"zkp.xyz/membership/galois"
+)
+
+// Div returns the quotient and rest of the polynomial division p / divisor,
+// with the strategies of Generic.Div. If the divisor has degree at most that
+// of p, it panics if the leading coefficient of the normalized divisor is not
+// invertible in f.
+func (p *Polynomial) Div(divisor *Polynomial, f *galois.Field) (*Polynomial, *Polynomial) {
+	quo, rem, err := toGeneric(p, f).Div(toGeneric(divisor, f))
+	if err != nil {
+		panic(fmt.Sprintf("polynomial division by %v: %v", divisor, err))
+	}
+	return fromGeneric(quo, f), fromGeneric(rem, f)
+}
@@ -0,0 +1,212 @@
+package polynomial
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"zkp.xyz/membership/galois"
+)
+
+// A Domain is a multiplicative subgroup {w^0, w^1, ..., w^(size-1)} of a field,
+// generated by a primitive size-th root of unity w, over which polynomials can
+// be evaluated and interpolated with the FFT. The powers of w and w^-1 are
+// computed once, as twiddle factors of the FFT and its inverse, which is that
+// of the equivalent GenericDomain.
+type Domain struct {
+	field        *galois.Field
+	size         uint64
+	generator    *big.Int
+	invGenerator *big.Int
+	elements     []*big.Int // w^i
+	inverse      []*big.Int // w^-i
+	sizeInv      *big.Int
+	generic      *GenericDomain[fieldElement]
+}
+
+// NewDomain returns a Domain of the specified size, which must be a power of two
+// that divides q-1, generated by a random primitive root of unity. The Reader
+// is propagated to f.RootOfUnity(). See CanonicalDomain for a reproducible
+// Domain.
+func NewDomain(f *galois.Field, size uint64, r io.Reader) (*Domain, error) {
+	if err := checkDomainSize(f, size); err != nil {
+		return nil, err
+	}
+
+	w := big.NewInt(1)
+	if size > 1 {
+		var err error
+		if w, err = f.RootOfUnity(r, size, true); err != nil {
+			return nil, fmt.Errorf("RootOfUnity(%d): %v", size, err)
+		}
+	}
+	return newDomain(f, size, w)
+}
+
+// checkDomainSize returns an error if size isn't a power of two dividing q-1.
+func checkDomainSize(f *galois.Field, size uint64) error {
+	if size == 0 || size&(size-1) != 0 {
+		return fmt.Errorf("domain size %d is not a power of two", size)
+	}
+	if !f.HasSubgroupOfOrder(size) {
+		return fmt.Errorf("domain size %d does not divide q-1 = %v", size, new(big.Int).Sub(f.Order(), big.NewInt(1)))
+	}
+	return nil
+}
+
+// domainCache holds canonical Domains by field order and size.
+var domainCache sync.Map
+
+// CanonicalDomain returns the Domain of the specified size, which must be a
+// power of two that divides q-1, generated by f.PrimitiveRootOfUnity(size).
+// Unlike NewDomain, the Domain is therefore reproducible, and the Domain of
+// size n is generated by the square of the generator of that of size 2n.
+//
+// Domains are cached by field order and size, and shared between callers.
+func CanonicalDomain(f *galois.Field, size uint64) (*Domain, error) {
+	key := fmt.Sprintf("%v/%d", f.Order(), size)
+	if d, ok := domainCache.Load(key); ok {
+		return d.(*Domain), nil
+	}
+	if err := checkDomainSize(f, size); err != nil {
+		return nil, err
+	}
+
+	w, err := f.PrimitiveRootOfUnity(size)
+	if err != nil {
+		return nil, err
+	}
+	d, err := newDomain(f, size, w)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := domainCache.LoadOrStore(key, d)
+	return actual.(*Domain), nil
+}
+
+// newDomain returns a Domain generated by w, which MUST be a primitive size-th
+// root of unity.
+func newDomain(f *galois.Field, size uint64, w *big.Int) (*Domain, error) {
+	wInv, err := f.MultInverse(w)
+	if err != nil {
+		return nil, err
+	}
+	sizeInv, err := f.MultInverse(new(big.Int).SetUint64(size))
+	if err != nil {
+		return nil, err
+	}
+
+	// The powers are shared by both views of the Domain.
+	g := &GenericDomain[fieldElement]{
+		size:     size,
+		elements: elements(ComputePowers(w, int(size), f), f),
+		inverse:  elements(ComputePowers(wInv, int(size), f), f),
+		sizeInv:  fieldElement{sizeInv, f},
+	}
+	d := &Domain{
+		field:        f,
+		size:         size,
+		generator:    new(big.Int).Set(w),
+		invGenerator: wInv,
+		elements:     ints(g.elements, f),
+		inverse:      ints(g.inverse, f),
+		sizeInv:      sizeInv,
+		generic:      g,
+	}
+	return d, nil
+}
+
+// Size returns the number of elements in the Domain.
+func (d *Domain) Size() uint64 {
+	return d.size
+}
+
+// Element returns w^i.
+func (d *Domain) Element(i uint64) *big.Int {
+	return new(big.Int).Set(d.elements[i%d.size])
+}
+
+// Generator returns the primitive root of unity w generating the Domain.
+func (d *Domain) Generator() *big.Int {
+	return new(big.Int).Set(d.generator)
+}
+
+// InverseGenerator returns w^-1.
+func (d *Domain) InverseGenerator() *big.Int {
+	return new(big.Int).Set(d.invGenerator)
+}
+
+// Vanishing returns the vanishing polynomial X^n - 1 of the Domain, which is
+// zero exactly on its n elements.
+func (d *Domain) Vanishing() *Polynomial {
+	z := *NewZeroPolynomial(int(d.size))
+	z[0].Sub(d.field.Order(), big.NewInt(1))
+	z[d.size].SetInt64(1)
+	return &z
+}
+
+// Values returns the evaluations of p, of any degree, at each element of the
+// Domain, in order, by reducing p modulo X^n - 1 before the FFT. In particular
+// it recovers the values of d.Blinded.
+func (d *Domain) Values(p *Polynomial) []*big.Int {
+	n := int(d.size)
+	cs := *NewZeroPolynomial(n - 1)
+	for i, c := range *p {
+		cs[i%n] = d.field.Add(cs[i%n], c)
+	}
+	return d.FFT(cs)
+}
+
+// Blinded returns the polynomial interpolating evals over the Domain plus
+// b(X) (X^n - 1) for a random b of degree k-1 drawn from r, which still
+// evaluates to evals over the Domain, while k evaluations outside of it reveal
+// nothing about them.
+func (d *Domain) Blinded(evals []*big.Int, k int, r io.Reader) (*Polynomial, error) {
+	n := int(d.size)
+	cs := append(d.IFFT(evals), make([]*big.Int, k)...)
+	for i := n; i < len(cs); i++ {
+		cs[i] = big.NewInt(0)
+	}
+	for i := 0; i < k; i++ {
+		b, err := d.field.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		cs[i] = d.field.Sub(cs[i], b)
+		cs[n+i] = d.field.Add(cs[n+i], b)
+	}
+	return NewPolynomial(cs).Normalize(d.field), nil
+}
+
+// VanishingAt returns x^n - 1, the evaluation of d.Vanishing() at x.
+func (d *Domain) VanishingAt(x *big.Int) *big.Int {
+	return vanishingAt(x, d.size, d.field)
+}
+
+func vanishingAt(x *big.Int, n uint64, f *galois.Field) *big.Int {
+	return f.Sub(f.Exp(x, new(big.Int).SetUint64(n)), big.NewInt(1))
+}
+
+// Shift returns p(w X), the polynomial whose evaluation at each element w^i of
+// the Domain is that of p at the next element w^(i+1).
+func (d *Domain) Shift(p *Polynomial) *Polynomial {
+	shifted := make(Polynomial, len(*p))
+	for i, c := range *p {
+		shifted[i] = d.field.Mul(c, d.elements[uint64(i)%d.size])
+	}
+	return &shifted
+}
+
+// FFT returns the evaluations of the polynomial with the given coefficients at
+// each element of the Domain, in order. Missing coefficients are treated as
+// zero; FFT panics if there are more coefficients than elements in the Domain.
+func (d *Domain) FFT(coeffs []*big.Int) []*big.Int {
+	return ints(d.generic.FFT(elements(coeffs, d.field)), d.field)
+}
+
+// IFFT is the inverse of FFT, returning the coefficients of the unique
+// polynomial of degree < d.Size() that evaluates to evals over the Domain.
+func (d *Domain) IFFT(evals []*big.Int) []*big.Int {
+	return ints(d.generic.IFFT(elements(evals, d.field)), d.field)
+}
@@ -0,0 +1,186 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/google/go-cmp/cmp"
+	"zkp.xyz/membership/galois"
+)
+
+func TestDomainFFT(t *testing.T) {
+	tests := []struct {
+		f      *galois.Field
+		size   uint64
+		degree int
+	}{
+		{f: galois.NewField(big.NewInt(17)), size: 1, degree: 0},
+		{f: galois.NewField(big.NewInt(17)), size: 2, degree: 1},
+		{f: galois.NewField(big.NewInt(17)), size: 16, degree: 15},
+		{f: galois.NewField(big.NewInt(17)), size: 16, degree: 5},
+		{f: galois.NewField(bn256.Order), size: 64, degree: 63},
+		{f: galois.NewField(bn256.Order), size: 256, degree: 100},
+	}
+
+	for _, tt := range tests {
+		d, err := NewDomain(tt.f, tt.size, rand.Reader)
+		if err != nil {
+			t.Fatalf("NewDomain(%v, %d): %v", tt.f.Order(), tt.size, err)
+		}
+		p, err := Random(tt.degree, tt.f, rand.Reader)
+		if err != nil {
+			t.Fatalf("Random(): %v", err)
+		}
+
+		evals := d.FFT(*p)
+		if got := uint64(len(evals)); got != tt.size {
+			t.Fatalf("len(FFT()) = %d; want %d", got, tt.size)
+		}
+		for i, y := range evals {
+			x := d.Element(uint64(i))
+			if want := p.Evaluate(x, tt.f); y.Cmp(want) != 0 {
+				t.Errorf("FFT(%v)[%d] = %v; want p(%v) = %v", p, i, y, x, want)
+			}
+		}
+
+		got := NewPolynomial(d.IFFT(evals))
+		if !got.Eq(p) {
+			t.Errorf("IFFT(FFT(%v)) = %v", p, got)
+		}
+	}
+}
+
+func TestDomainElements(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	d, err := NewDomain(f, 32, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := uint64(0); i < d.Size(); i++ {
+		seen[d.Element(i).String()] = true
+	}
+	if len(seen) != 32 {
+		t.Errorf("Domain of size 32 has %d distinct elements", len(seen))
+	}
+	if diff := cmp.Diff("1", f.Exp(d.Generator(), big.NewInt(32)).String()); diff != "" {
+		t.Errorf("Generator()^Size() != 1, diff %v", diff)
+	}
+}
+
+func TestNewDomainErrors(t *testing.T) {
+	tests := []struct {
+		f    *galois.Field
+		size uint64
+	}{
+		{f: galois.NewField(big.NewInt(17)), size: 0},
+		{f: galois.NewField(big.NewInt(17)), size: 6},
+		{f: galois.NewField(big.NewInt(17)), size: 32},
+		{f: galois.NewField(big.NewInt(7)), size: 4},
+		{f: galois.NewField(bn256.Order), size: 1 << 29},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewDomain(tt.f, tt.size, rand.Reader); err == nil {
+			t.Errorf("NewDomain(%v, %d): got nil error", tt.f.Order(), tt.size)
+		}
+	}
+}
+
+func TestCanonicalDomain(t *testing.T) {
+	one := big.NewInt(1)
+	for _, f := range []*galois.Field{galois.NewField(big.NewInt(17)), galois.NewField(bn256.Order)} {
+		var prev *Domain
+		for size := uint64(1); size <= 16; size *= 2 {
+			d, err := CanonicalDomain(f, size)
+			if err != nil {
+				t.Fatalf("CanonicalDomain(%v, %d): %v", f.Order(), size, err)
+			}
+			if again, err := CanonicalDomain(f, size); err != nil || again != d {
+				t.Errorf("CanonicalDomain(%v, %d) not cached; got %p, %v; want %p", f.Order(), size, again, err, d)
+			}
+			w := d.Generator()
+			if f.Exp(w, new(big.Int).SetUint64(size)).Cmp(one) != 0 || (size > 1 && f.Exp(w, new(big.Int).SetUint64(size/2)).Cmp(one) == 0) {
+				t.Errorf("CanonicalDomain(%v, %d).Generator() = %v not a primitive root", f.Order(), size, w)
+			}
+			if got := f.Mul(w, d.InverseGenerator()); got.Cmp(one) != 0 {
+				t.Errorf("Generator() * InverseGenerator() = %v; want 1", got)
+			}
+			if prev != nil && f.Mul(w, w).Cmp(prev.Generator()) != 0 {
+				t.Errorf("CanonicalDomain(%v, %d).Generator()^2 != CanonicalDomain(%[1]v, %d).Generator()", f.Order(), size, size/2)
+			}
+			prev = d
+
+			z := d.Vanishing()
+			if z.Degree() != int(size) {
+				t.Errorf("Vanishing().Degree() = %d; want %d", z.Degree(), size)
+			}
+			for i := uint64(0); i < size; i++ {
+				if y := z.Evaluate(d.Element(i), f); y.Sign() != 0 {
+					t.Errorf("Vanishing()(w^%d) = %v; want 0", i, y)
+				}
+			}
+			x := big.NewInt(3)
+			if got, want := d.VanishingAt(x), z.Evaluate(x, f); got.Cmp(want) != 0 {
+				t.Errorf("VanishingAt(%v) = %v; want %v", x, got, want)
+			}
+		}
+	}
+
+	if _, err := CanonicalDomain(galois.NewField(big.NewInt(17)), 32); err == nil {
+		t.Errorf("CanonicalDomain(17, 32): got nil error")
+	}
+}
+
+func TestShift(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	d, err := CanonicalDomain(f, 4)
+	if err != nil {
+		t.Fatalf("CanonicalDomain(): %v", err)
+	}
+	// Degree above the Domain size, so powers of w wrap around.
+	p, err := Random(9, f, rand.Reader)
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+	shifted := d.Shift(p)
+	for _, x := range []*big.Int{big.NewInt(0), big.NewInt(7), d.Element(3)} {
+		if got, want := shifted.Evaluate(x, f), p.Evaluate(f.Mul(x, d.Generator()), f); got.Cmp(want) != 0 {
+			t.Errorf("Shift(p)(%v) = %v; want p(w %v) = %v", x, got, x, want)
+		}
+	}
+}
+
+func TestBlinded(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	d, err := CanonicalDomain(f, 8)
+	if err != nil {
+		t.Fatalf("CanonicalDomain(): %v", err)
+	}
+	evals := make([]*big.Int, d.Size())
+	for i := range evals {
+		evals[i] = big.NewInt(int64(i * i))
+	}
+
+	for _, k := range []int{0, 1, 3} {
+		p, err := d.Blinded(evals, k, rand.Reader)
+		if err != nil {
+			t.Fatalf("Blinded(k = %d): %v", k, err)
+		}
+		if want := int(d.Size()) + k - 1; k > 0 && p.Degree() != want {
+			t.Errorf("Blinded(k = %d).Degree() = %d; want %d", k, p.Degree(), want)
+		}
+		values := d.Values(p)
+		for i, y := range evals {
+			if got := p.Evaluate(d.Element(uint64(i)), f); got.Cmp(y) != 0 {
+				t.Errorf("Blinded(k = %d)(w^%d) = %v; want %v", k, i, got, y)
+			}
+			if values[i].Cmp(y) != 0 {
+				t.Errorf("Values(Blinded(k = %d))[%d] = %v; want %v", k, i, values[i], y)
+			}
+		}
+	}
+}
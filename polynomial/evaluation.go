@@ -0,0 +1,153 @@
+package polynomial
+
+import (
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// An EvaluationForm represents a polynomial of degree less than the size of a
+// Domain by its evaluations at each element of the Domain, i.e. by its
+// coefficients in the Lagrange basis of the Domain.
+type EvaluationForm struct {
+	domain *Domain
+	evals  []*big.Int
+}
+
+// NewEvaluationForm returns the EvaluationForm with the specified evaluations,
+// of which there must be exactly d.Size(). The evaluations are reduced into
+// the field of the Domain.
+func NewEvaluationForm(d *Domain, evals []*big.Int) (*EvaluationForm, error) {
+	if uint64(len(evals)) != d.size {
+		return nil, fmt.Errorf("%d evaluations over domain of size %d", len(evals), d.size)
+	}
+	e := &EvaluationForm{domain: d, evals: make([]*big.Int, len(evals))}
+	for i, y := range evals {
+		e.evals[i] = new(big.Int).Mod(y, d.field.Order())
+	}
+	return e, nil
+}
+
+// Evaluations returns the EvaluationForm of p over the Domain, or an error if
+// the degree of p isn't less than d.Size().
+func (d *Domain) Evaluations(p *Polynomial) (*EvaluationForm, error) {
+	if uint64(p.Degree()) >= d.size {
+		return nil, fmt.Errorf("polynomial degree %d not less than domain size %d", p.Degree(), d.size)
+	}
+	return &EvaluationForm{domain: d, evals: d.FFT((*p)[:p.Degree()+1])}, nil
+}
+
+// LagrangeBasis returns the ith Lagrange basis polynomial of the Domain, which
+// evaluates to 1 at w^i and to 0 at all other elements.
+func (d *Domain) LagrangeBasis(i uint64) *Polynomial {
+	evals := make([]*big.Int, d.size)
+	for j := range evals {
+		evals[j] = big.NewInt(0)
+	}
+	evals[i%d.size].SetInt64(1)
+	return NewPolynomial(d.IFFT(evals))
+}
+
+// LagrangeAt returns Z_H(x) = x^n - 1 and the evaluations L_i(x) =
+// w^i Z_H(x) / (n (x - w^i)) of the first count Lagrange basis polynomials of
+// the Domain of size n generated by w, as known to verifiers without the
+// Domain itself. It returns an error for x in the Domain, where the formula
+// is undefined.
+func LagrangeAt(x, w *big.Int, n uint64, count int, f *galois.Field) (*big.Int, []*big.Int, error) {
+	zh := vanishingAt(x, n, f)
+	if zh.Sign() == 0 {
+		return nil, nil, fmt.Errorf("%v is in the domain", x)
+	}
+	nums, dens := make([]*big.Int, count), make([]*big.Int, count)
+	wi := big.NewInt(1)
+	size := new(big.Int).SetUint64(n)
+	for i := range nums {
+		nums[i] = f.Mul(wi, zh)
+		dens[i] = f.Mul(size, f.Sub(x, wi))
+		wi = f.Mul(wi, w)
+	}
+	ls, err := f.DivSlice(nums, dens)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zh, ls, nil
+}
+
+// Domain returns the Domain over which e is evaluated.
+func (e *EvaluationForm) Domain() *Domain {
+	return e.domain
+}
+
+// Evaluations returns a copy of the evaluations, in the order of the elements
+// of the Domain.
+func (e *EvaluationForm) Evaluations() []*big.Int {
+	evals := make([]*big.Int, len(e.evals))
+	for i, y := range e.evals {
+		evals[i] = new(big.Int).Set(y)
+	}
+	return evals
+}
+
+// Coefficients returns the coefficient form of e.
+func (e *EvaluationForm) Coefficients() *Polynomial {
+	return NewPolynomial(e.domain.IFFT(e.evals))
+}
+
+// EvaluateAt returns the evaluation of e at an arbitrary z with the barycentric
+// formula (z^n - 1)/n * sum_i y_i w^i/(z - w^i), in O(n) field operations and
+// a single inversion, without converting to coefficient form. If z is an
+// element of the Domain, its evaluation is returned directly.
+func (e *EvaluationForm) EvaluateAt(z *big.Int) *big.Int {
+	d, f := e.domain, e.domain.field
+	z = new(big.Int).Mod(z, f.Order())
+	dens := make([]*big.Int, d.size)
+	for i, w := range d.elements {
+		if w.Cmp(z) == 0 {
+			return new(big.Int).Set(e.evals[i])
+		}
+		dens[i] = f.Sub(z, w)
+	}
+	invs, err := f.BatchInverse(dens)
+	if err != nil {
+		// Unreachable as z is not in the Domain.
+		panic(err)
+	}
+
+	sum := big.NewInt(0)
+	for i, w := range d.elements {
+		sum = f.Add(sum, f.Mul(f.Mul(e.evals[i], w), invs[i]))
+	}
+	return f.Mul(sum, f.Mul(d.VanishingAt(z), d.sizeInv))
+}
+
+// Add returns e+x, or an error if they are evaluated over distinct Domains.
+func (e *EvaluationForm) Add(x *EvaluationForm) (*EvaluationForm, error) {
+	return e.pointwise(x, e.domain.field.Add)
+}
+
+// Mul returns the pointwise product of e and x, or an error if they are
+// evaluated over distinct Domains. This only equals the evaluations of the
+// product polynomial if its degree is less than the size of the Domain;
+// otherwise it is the product modulo v^n - 1 for Domain size n.
+func (e *EvaluationForm) Mul(x *EvaluationForm) (*EvaluationForm, error) {
+	return e.pointwise(x, e.domain.field.Mul)
+}
+
+func (e *EvaluationForm) pointwise(x *EvaluationForm, op func(a, b *big.Int) *big.Int) (*EvaluationForm, error) {
+	if !e.domain.equal(x.domain) {
+		return nil, fmt.Errorf("evaluations over distinct domains")
+	}
+	result := &EvaluationForm{domain: e.domain, evals: make([]*big.Int, len(e.evals))}
+	for i := range e.evals {
+		result.evals[i] = op(e.evals[i], x.evals[i])
+	}
+	return result, nil
+}
+
+// equal reports whether d and x consist of the same elements in the same order.
+func (d *Domain) equal(x *Domain) bool {
+	return d == x || (d.size == x.size &&
+		d.field.Order().Cmp(x.field.Order()) == 0 &&
+		d.Generator().Cmp(x.Generator()) == 0)
+}
@@ -0,0 +1,182 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+func TestEvaluationForm(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	d, err := NewDomain(f, 16, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+
+	p1, err := Random(5, f, rand.Reader)
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+	p2, err := Random(9, f, rand.Reader)
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+
+	e1, err := d.Evaluations(p1)
+	if err != nil {
+		t.Fatalf("Evaluations(): %v", err)
+	}
+	e2, err := d.Evaluations(p2)
+	if err != nil {
+		t.Fatalf("Evaluations(): %v", err)
+	}
+
+	for i, y := range e1.Evaluations() {
+		if want := p1.Evaluate(d.Element(uint64(i)), f); y.Cmp(want) != 0 {
+			t.Errorf("Evaluations()[%d] = %v; want %v", i, y, want)
+		}
+	}
+	if got := e1.Coefficients(); !got.Eq(p1) {
+		t.Errorf("Coefficients() = %v; want %v", got, p1)
+	}
+
+	sum, err := e1.Add(e2)
+	if err != nil {
+		t.Fatalf("Add(): %v", err)
+	}
+	if got, want := sum.Coefficients(), p1.Add(p2, f); !got.Eq(want) {
+		t.Errorf("Add().Coefficients() = %v; want %v", got, want)
+	}
+
+	prod, err := e1.Mul(e2)
+	if err != nil {
+		t.Fatalf("Mul(): %v", err)
+	}
+	if got, want := prod.Coefficients(), p1.Mul(p2, f); !got.Eq(want) {
+		t.Errorf("Mul().Coefficients() = %v; want %v", got, want)
+	}
+
+	// NewEvaluationForm is the inverse of Evaluations.
+	e, err := NewEvaluationForm(d, e1.Evaluations())
+	if err != nil {
+		t.Fatalf("NewEvaluationForm(): %v", err)
+	}
+	if got := e.Coefficients(); !got.Eq(p1) {
+		t.Errorf("NewEvaluationForm(Evaluations()).Coefficients() = %v; want %v", got, p1)
+	}
+}
+
+func TestEvaluationFormErrors(t *testing.T) {
+	f := galois.NewField(big.NewInt(17))
+	d8, err := NewDomain(f, 8, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	d4, err := NewDomain(f, 4, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+
+	if _, err := d8.Evaluations(NewPolynomialFromCoefficients([]int64{1, 2, 3, 4, 5, 6, 7, 8, 9})); err == nil {
+		t.Errorf("Evaluations(<degree 8>) over domain of size 8: got nil error")
+	}
+	if _, err := NewEvaluationForm(d8, bigInts([]int64{1, 2, 3})); err == nil {
+		t.Errorf("NewEvaluationForm(<3 evaluations>) over domain of size 8: got nil error")
+	}
+
+	e8, err := d8.Evaluations(OnePolynomial)
+	if err != nil {
+		t.Fatalf("Evaluations(): %v", err)
+	}
+	e4, err := d4.Evaluations(OnePolynomial)
+	if err != nil {
+		t.Fatalf("Evaluations(): %v", err)
+	}
+	if _, err := e8.Add(e4); err == nil {
+		t.Errorf("Add() over distinct domains: got nil error")
+	}
+	if _, err := e8.Mul(e4); err == nil {
+		t.Errorf("Mul() over distinct domains: got nil error")
+	}
+}
+
+func TestLagrangeBasis(t *testing.T) {
+	f := galois.NewField(big.NewInt(17))
+	d, err := NewDomain(f, 8, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+
+	for i := uint64(0); i < d.Size(); i++ {
+		l := d.LagrangeBasis(i)
+		for j := uint64(0); j < d.Size(); j++ {
+			want := int64(0)
+			if i == j {
+				want = 1
+			}
+			if got := l.Evaluate(d.Element(j), f); got.Int64() != want {
+				t.Errorf("LagrangeBasis(%d) at w^%d = %v; want %d", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestLagrangeAt(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	d, err := CanonicalDomain(f, 8)
+	if err != nil {
+		t.Fatalf("CanonicalDomain(): %v", err)
+	}
+	x := big.NewInt(42)
+	zh, ls, err := LagrangeAt(x, d.Generator(), d.Size(), 3, f)
+	if err != nil {
+		t.Fatalf("LagrangeAt(): %v", err)
+	}
+	if want := d.Vanishing().Evaluate(x, f); zh.Cmp(want) != 0 {
+		t.Errorf("LagrangeAt() Z_H = %v; want %v", zh, want)
+	}
+	for i, l := range ls {
+		if want := d.LagrangeBasis(uint64(i)).Evaluate(x, f); l.Cmp(want) != 0 {
+			t.Errorf("LagrangeAt()[%d] = %v; want %v", i, l, want)
+		}
+	}
+	if _, _, err := LagrangeAt(d.Element(3), d.Generator(), d.Size(), 1, f); err == nil {
+		t.Errorf("LagrangeAt(<domain element>): got nil error")
+	}
+}
+
+func TestEvaluateAt(t *testing.T) {
+	for _, f := range []*galois.Field{galois.NewField(big.NewInt(17)), galois.NewField(bn256.Order)} {
+		for _, size := range []uint64{1, 2, 8} {
+			d, err := CanonicalDomain(f, size)
+			if err != nil {
+				t.Fatalf("CanonicalDomain(%v, %d): %v", f.Order(), size, err)
+			}
+			p, err := Random(int(size)-1, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			e, err := d.Evaluations(p)
+			if err != nil {
+				t.Fatalf("Evaluations(): %v", err)
+			}
+
+			zs := []*big.Int{big.NewInt(0), d.Element(size - 1), new(big.Int).Add(d.Element(1), f.Order())}
+			for i := 0; i < 5; i++ {
+				z, err := f.Random(rand.Reader)
+				if err != nil {
+					t.Fatalf("Random(): %v", err)
+				}
+				zs = append(zs, z)
+			}
+			for _, z := range zs {
+				if got, want := e.EvaluateAt(z), p.Evaluate(z, f); got.Cmp(want) != 0 {
+					t.Errorf("EvaluateAt(%v) over domain of size %d mod %v = %v; want %v", z, size, f.Order(), got, want)
+				}
+			}
+		}
+	}
+}
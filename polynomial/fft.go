@@ -0,0 +1,6 @@
+package polynomial
+
+// FFTMulThreshold is the minimum degree of both factors for Mul to multiply
+// with the FFT, provided that the field has a subgroup of sufficient
+// power-of-two order. Below it, schoolbook multiplication is faster.
+var FFTMulThreshold = 64
@@ -0,0 +1,92 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+func TestMulFFT(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	tests := []struct{ d1, d2 int }{
+		{d1: 0, d2: 0},
+		{d1: 1, d2: 0},
+		{d1: 3, d2: 4},
+		{d1: 64, d2: 64},
+		{d1: 100, d2: 300},
+		{d1: 511, d2: 513},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("deg %d * deg %d", tt.d1, tt.d2), func(t *testing.T) {
+			p1, err := Random(tt.d1, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			p2, err := Random(tt.d2, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			// Trailing zeros are ignored.
+			*p2 = append(*p2, big.NewInt(0))
+
+			want := p1.mulSerial(p2, f)
+			got, err := p1.mulFFT(p2, f)
+			if err != nil {
+				t.Fatalf("mulFFT(): %v", err)
+			}
+			if !got.Eq(want) {
+				t.Errorf("mulFFT() = %v; want %v", got, want)
+			}
+			if got := p1.Mul(p2, f); !got.Eq(want) {
+				t.Errorf("Mul() = %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMulFFTUnsupportedField(t *testing.T) {
+	// 13-1 has no subgroup of order 256, so Mul falls back to schoolbook
+	// multiplication.
+	f := galois.NewField(big.NewInt(13))
+	p, err := Random(FFTMulThreshold, f, rand.Reader)
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+
+	if _, err := p.mulFFT(p, f); err == nil {
+		t.Errorf("mulFFT() over field of order 13: got nil error")
+	}
+	if got, want := p.Mul(p, f), p.mulSerial(p, f); !got.Eq(want) {
+		t.Errorf("Mul() = %v; want %v", got, want)
+	}
+}
+
+func BenchmarkMulFFT(b *testing.B) {
+	f := galois.NewField(bn256.Order)
+
+	for _, d := range []int{32, 64, 128, 1024} {
+		p, err := Random(d, f, rand.Reader)
+		if err != nil {
+			b.Fatalf("Random(): %v", err)
+		}
+
+		b.Run(fmt.Sprintf("schoolbook/deg=%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				p.mulSerial(p, f)
+			}
+		})
+		b.Run(fmt.Sprintf("fft/deg=%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := p.mulFFT(p, f); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package polynomial
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// A fieldElement adapts an element of a *galois.Field to galois.Element, so
+// that Polynomial shares the algorithms of Generic. Unlike those of
+// fixed-width Elements, its field is carried by the value: the zero value is
+// zero in an unspecified field, adopting that of the other operand, as does
+// galois.FieldElement. Values are never modified, so the wrapped *big.Int may
+// be shared.
+type fieldElement struct {
+	x *big.Int
+	f *galois.Field
+}
+
+// int returns the value of e, treating nil as zero.
+func (e fieldElement) int() *big.Int {
+	if e.x == nil {
+		return bigZero
+	}
+	return e.x
+}
+
+// combine returns op(e, b) in the field of either operand, or the unreduced
+// plain(e, b) if neither has one, which is only the case for zero values and
+// their One.
+func (e fieldElement) combine(b fieldElement, op func(*galois.Field, *big.Int, *big.Int) *big.Int, plain func(z, x, y *big.Int) *big.Int) fieldElement {
+	f := e.f
+	if f == nil {
+		f = b.f
+	}
+	if f == nil {
+		return fieldElement{plain(new(big.Int), e.int(), b.int()), nil}
+	}
+	return fieldElement{op(f, e.int(), b.int()), f}
+}
+
+func (e fieldElement) Add(b fieldElement) fieldElement {
+	return e.combine(b, (*galois.Field).Add, (*big.Int).Add)
+}
+
+func (e fieldElement) Sub(b fieldElement) fieldElement {
+	return e.combine(b, (*galois.Field).Sub, (*big.Int).Sub)
+}
+
+func (e fieldElement) Mul(b fieldElement) fieldElement {
+	return e.combine(b, (*galois.Field).Mul, (*big.Int).Mul)
+}
+
+func (e fieldElement) Neg() fieldElement {
+	return fieldElement{f: e.f}.Sub(e)
+}
+
+// Inverse returns the multiplicative inverse of e, or an error wrapping
+// galois.ErrNotInvertible for zero or without a field.
+func (e fieldElement) Inverse() (fieldElement, error) {
+	if e.f == nil {
+		return fieldElement{}, fmt.Errorf("%w: %v without a field", galois.ErrNotInvertible, e.int())
+	}
+	inv, err := e.f.MultInverse(e.int())
+	if err != nil {
+		return fieldElement{}, err
+	}
+	return fieldElement{inv, e.f}, nil
+}
+
+func (e fieldElement) IsZero() bool {
+	return e.int().Sign() == 0
+}
+
+// One returns 1 in the field of e.
+func (e fieldElement) One() fieldElement {
+	return fieldElement{big.NewInt(1), e.f}
+}
+
+// Bytes returns the big-endian encoding of e, padded to the length of the
+// field's order. It panics if e has no field.
+func (e fieldElement) Bytes() []byte {
+	return e.int().FillBytes(make([]byte, (e.f.Order().BitLen()+7)/8))
+}
+
+// Random returns a uniformly random element of the field of e, which it panics
+// without.
+func (e fieldElement) Random(r io.Reader) (fieldElement, error) {
+	x, err := e.f.Random(r)
+	if err != nil {
+		return fieldElement{}, err
+	}
+	return fieldElement{x, e.f}, nil
+}
+
+// genericDomain returns the GenericDomain shared by CanonicalDomain(e.f, size),
+// as fieldElements are of a single type for all fields.
+func (e fieldElement) genericDomain(size uint64) (*GenericDomain[fieldElement], error) {
+	d, err := CanonicalDomain(e.f, size)
+	if err != nil {
+		return nil, err
+	}
+	return d.generic, nil
+}
+
+// elements returns xs as fieldElements of f, reduced into fresh values, with
+// nil treated as zero.
+func elements(xs []*big.Int, f *galois.Field) []fieldElement {
+	es := make([]fieldElement, len(xs))
+	for i, x := range xs {
+		es[i] = fieldElement{new(big.Int), f}
+		if x != nil {
+			f.Mod(es[i].x.Set(x))
+		}
+	}
+	return es
+}
+
+// ints is the inverse of elements, reducing values without a field into f.
+func ints(es []fieldElement, f *galois.Field) []*big.Int {
+	xs := make([]*big.Int, len(es))
+	for i, e := range es {
+		if xs[i] = e.x; e.x == nil || e.f == nil {
+			xs[i] = f.Mod(new(big.Int).Set(e.int()))
+		}
+	}
+	return xs
+}
+
+// toGeneric returns p as a Generic polynomial over f.
+func toGeneric(p *Polynomial, f *galois.Field) Generic[fieldElement] {
+	return Generic[fieldElement](elements((*p)[:p.Degree()+1], f)).normalize()
+}
+
+// fromGeneric is the inverse of toGeneric, returning a normalized Polynomial.
+func fromGeneric(g Generic[fieldElement], f *galois.Field) *Polynomial {
+	p := Polynomial(ints(g, f))
+	return p.trim()
+}
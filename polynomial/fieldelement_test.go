@@ -0,0 +1,104 @@
+package polynomial
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/galois"
+)
+
+// The strategies of Generic.Mul and Generic.Div, applied to Polynomials for the
+// tests and benchmarks comparing them.
+
+func (p *Polynomial) mulSerial(m *Polynomial, f *galois.Field) *Polynomial {
+	a, b := toGeneric(p, f), toGeneric(m, f)
+	if len(a) == 0 || len(b) == 0 {
+		return NewZeroPolynomial(0)
+	}
+	return fromGeneric(a.mulSerial(b), f)
+}
+
+func (p *Polynomial) mulParallel(m *Polynomial, f *galois.Field) *Polynomial {
+	a, b := toGeneric(p, f), toGeneric(m, f)
+	if len(a) == 0 || len(b) == 0 {
+		return NewZeroPolynomial(0)
+	}
+	return fromGeneric(a.mulParallel(b), f)
+}
+
+func (p *Polynomial) mulFFT(m *Polynomial, f *galois.Field) (*Polynomial, error) {
+	a, b := toGeneric(p, f), toGeneric(m, f)
+	if len(a) == 0 || len(b) == 0 {
+		return NewZeroPolynomial(0), nil
+	}
+	prod, ok := a.mulFFT(b)
+	if !ok {
+		return nil, errors.New("field without domain of sufficient size")
+	}
+	return fromGeneric(prod, f), nil
+}
+
+func (p *Polynomial) divSchoolbook(d *Polynomial, leadInv *big.Int, f *galois.Field) (*Polynomial, *Polynomial) {
+	quo, rem := toGeneric(p, f).divSchoolbook(toGeneric(d, f), fieldElement{leadInv, f})
+	return fromGeneric(quo, f), fromGeneric(rem, f)
+}
+
+func (p *Polynomial) divNewton(d *Polynomial, f *galois.Field) (*Polynomial, *Polynomial) {
+	a, g := toGeneric(p, f), toGeneric(d, f)
+	if len(a) < len(g) {
+		return p.Div(d, f)
+	}
+	leadInv, err := g[len(g)-1].Inverse()
+	if err != nil {
+		panic(err)
+	}
+	quo, rem := a.divNewton(g, leadInv)
+	return fromGeneric(quo, f), fromGeneric(rem, f)
+}
+
+func (p *Polynomial) rem(d *Polynomial, f *galois.Field) *Polynomial {
+	_, r := p.Div(d, f)
+	return r
+}
+
+func TestFieldElement(t *testing.T) {
+	f := galois.NewField(big.NewInt(97))
+	x, y := fieldElement{big.NewInt(90), f}, fieldElement{big.NewInt(10), f}
+	var zero fieldElement
+
+	tests := []struct {
+		name      string
+		got, want fieldElement
+	}{
+		{"x+y", x.Add(y), fieldElement{big.NewInt(3), f}},
+		{"y-x", y.Sub(x), fieldElement{big.NewInt(17), f}},
+		{"x*y", x.Mul(y), fieldElement{big.NewInt(27), f}},
+		{"-x", x.Neg(), fieldElement{big.NewInt(7), f}},
+		{"0+x", zero.Add(x), x},
+		{"0-x", zero.Sub(x), fieldElement{big.NewInt(7), f}},
+		{"0*x", zero.Mul(x), fieldElement{big.NewInt(0), f}},
+		{"x.One()", x.One(), fieldElement{big.NewInt(1), f}},
+	}
+	for _, tt := range tests {
+		if tt.got.f != tt.want.f || tt.got.int().Cmp(tt.want.int()) != 0 {
+			t.Errorf("%s = %v in %p; want %v in %p", tt.name, tt.got.int(), tt.got.f, tt.want.int(), tt.want.f)
+		}
+	}
+
+	if inv, err := x.Inverse(); err != nil || !inv.Mul(x).Sub(x.One()).IsZero() {
+		t.Errorf("x.Inverse() = %v, %v; want inverse of %v", inv.int(), err, x.int())
+	}
+	for _, e := range []fieldElement{{big.NewInt(0), f}, zero.One()} {
+		if _, err := e.Inverse(); !errors.Is(err, galois.ErrNotInvertible) {
+			t.Errorf("Inverse() of %v in %p: got err %v; want %v", e.int(), e.f, err, galois.ErrNotInvertible)
+		}
+	}
+	if got, want := x.Bytes(), []byte{90}; !bytes.Equal(got, want) {
+		t.Errorf("x.Bytes() = %x; want %x", got, want)
+	}
+	if got := ints([]fieldElement{zero, zero.One().Neg()}, f); got[0].Sign() != 0 || got[1].Int64() != 96 {
+		t.Errorf("ints(<values without field>) = %v; want [0 96]", got)
+	}
+}
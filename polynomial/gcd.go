@@ -0,0 +1,74 @@
+package polynomial
+
+import (
+	"zkp.xyz/membership/galois"
+)
+
+// isZero reports whether p is the zero polynomial.
+func (p *Polynomial) isZero() bool {
+	return p.Degree() == 0 && (len(*p) == 0 || (*p)[0].Sign() == 0)
+}
+
+// Monic returns p scaled such that its leading coefficient is 1. The zero
+// polynomial is returned unchanged. Monic panics if the leading coefficient is
+// not invertible in f.
+func (p *Polynomial) Monic(f *galois.Field) *Polynomial {
+	p = p.Normalize(f)
+	if p.isZero() {
+		return NewZeroPolynomial(0)
+	}
+	inv, err := f.MultInverse((*p)[p.Degree()])
+	if err != nil {
+		panic(err)
+	}
+	return p.Scale(inv, f)
+}
+
+// GCD returns the monic greatest common divisor of p and q, computed with the
+// Euclidean algorithm. If either is the zero polynomial, the monic form of the
+// other is returned, and the GCD of two zero polynomials is zero.
+func (p *Polynomial) GCD(q *Polynomial, f *galois.Field) *Polynomial {
+	a, b := p.Normalize(f), q.Normalize(f)
+	for !b.isZero() {
+		_, r := a.Div(b, f)
+		a, b = b, r
+	}
+	return a.Monic(f)
+}
+
+// Coprime reports whether p and q share no common factor of non-zero degree.
+func Coprime(p, q *Polynomial, f *galois.Field) bool {
+	g := p.GCD(q, f)
+	return g.Degree() == 0 && !g.isZero()
+}
+
+// GCD returns the monic greatest common divisor of a and b; see
+// Polynomial.GCD.
+func GCD(a, b *Polynomial, f *galois.Field) *Polynomial {
+	return a.GCD(b, f)
+}
+
+// XGCD returns the monic greatest common divisor g of a and b, as GCD, and
+// Bézout cofactors s and t such that s*a + t*b = g, computed with the extended
+// Euclidean algorithm. The cofactors satisfy deg(s) < deg(b) - deg(g) and
+// deg(t) < deg(a) - deg(g), unless either input is a divisor of the other.
+func XGCD(a, b *Polynomial, f *galois.Field) (g, s, t *Polynomial) {
+	r0, r1 := a.Normalize(f), b.Normalize(f)
+	s0, s1 := OnePolynomial, ZeroPolynomial
+	t0, t1 := ZeroPolynomial, OnePolynomial
+	for !r1.isZero() {
+		q, r := r0.Div(r1, f)
+		r0, r1 = r1, r
+		s0, s1 = s1, s0.Sub(q.Mul(s1, f), f)
+		t0, t1 = t1, t0.Sub(q.Mul(t1, f), f)
+	}
+	if r0.isZero() {
+		return NewZeroPolynomial(0), s0.Clone(), t0.Clone()
+	}
+
+	inv, err := f.MultInverse((*r0)[r0.Degree()])
+	if err != nil {
+		panic(err)
+	}
+	return r0.Scale(inv, f), s0.Scale(inv, f), t0.Scale(inv, f)
+}
@@ -0,0 +1,140 @@
+package polynomial
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+// vanishing returns prod_i (v - roots[i]).
+func vanishing(roots []int64, f *galois.Field) *Polynomial {
+	p := OnePolynomial
+	for _, z := range roots {
+		p = p.Mul(NewPolynomialFromCoefficients([]int64{-z, 1}), f)
+	}
+	return p
+}
+
+func TestGCD(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	tests := []struct {
+		name string
+		p, q *Polynomial
+		want *Polynomial
+	}{
+		{
+			name: "disjoint",
+			p:    vanishing([]int64{1, 2, 3}, f),
+			q:    vanishing([]int64{4, 5}, f),
+			want: OnePolynomial,
+		},
+		{
+			name: "overlapping",
+			p:    vanishing([]int64{1, 2, 3}, f),
+			q:    vanishing([]int64{2, 3, 4, 5}, f),
+			want: vanishing([]int64{2, 3}, f),
+		},
+		{
+			name: "subset",
+			p:    vanishing([]int64{1, 2, 3}, f),
+			q:    vanishing([]int64{2}, f),
+			want: vanishing([]int64{2}, f),
+		},
+		{
+			name: "non-monic identical",
+			p:    vanishing([]int64{1, 2}, f).Scale(big.NewInt(7), f),
+			q:    vanishing([]int64{1, 2}, f).Scale(big.NewInt(7), f),
+			want: vanishing([]int64{1, 2}, f),
+		},
+		{
+			name: "zero and non-zero",
+			p:    ZeroPolynomial,
+			q:    vanishing([]int64{1, 2}, f).Scale(big.NewInt(3), f),
+			want: vanishing([]int64{1, 2}, f),
+		},
+		{
+			name: "non-zero and zero",
+			p:    vanishing([]int64{5}, f).Scale(big.NewInt(3), f),
+			q:    ZeroPolynomial,
+			want: vanishing([]int64{5}, f),
+		},
+		{
+			name: "zero and zero",
+			p:    ZeroPolynomial,
+			q:    ZeroPolynomial,
+			want: ZeroPolynomial,
+		},
+		{
+			name: "constants",
+			p:    NewPolynomialFromCoefficients([]int64{3}),
+			q:    NewPolynomialFromCoefficients([]int64{5}),
+			want: OnePolynomial,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.GCD(tt.q, f); !got.Eq(tt.want) {
+			t.Errorf("%s: GCD(%v, %v): want %v, got %v", tt.name, tt.p, tt.q, tt.want, got)
+		}
+	}
+}
+
+func TestCoprime(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	tests := []struct {
+		p, q []int64
+		want bool
+	}{
+		{p: []int64{1, 2, 3}, q: []int64{4, 5}, want: true},
+		{p: []int64{1, 2, 3}, q: []int64{3, 4}, want: false},
+		{p: []int64{}, q: []int64{4, 5}, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := Coprime(vanishing(tt.p, f), vanishing(tt.q, f), f); got != tt.want {
+			t.Errorf("Coprime(Vanishing(%v), Vanishing(%v)) = %t; want %t", tt.p, tt.q, got, tt.want)
+		}
+	}
+	if Coprime(ZeroPolynomial, vanishing([]int64{1}, f), f) {
+		t.Errorf("Coprime(0, v - 1) = true; want false")
+	}
+}
+
+func TestXGCD(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	tests := []struct {
+		name string
+		a, b *Polynomial
+	}{
+		{name: "disjoint", a: vanishing([]int64{1, 2, 3}, f), b: vanishing([]int64{4, 5}, f)},
+		{name: "overlapping", a: vanishing([]int64{1, 2, 3}, f), b: vanishing([]int64{2, 3, 4, 5}, f)},
+		{name: "subset", a: vanishing([]int64{1, 2, 3}, f), b: vanishing([]int64{2}, f)},
+		{name: "non-monic", a: vanishing([]int64{1, 2, 6}, f).Scale(big.NewInt(7), f), b: vanishing([]int64{6, 7}, f).Scale(big.NewInt(3), f)},
+		{name: "zero and non-zero", a: ZeroPolynomial, b: vanishing([]int64{1, 2}, f).Scale(big.NewInt(3), f)},
+		{name: "non-zero and zero", a: vanishing([]int64{5}, f).Scale(big.NewInt(3), f), b: ZeroPolynomial},
+		{name: "zero and zero", a: ZeroPolynomial, b: ZeroPolynomial},
+		{name: "constants", a: NewPolynomialFromCoefficients([]int64{3}), b: NewPolynomialFromCoefficients([]int64{5})},
+	}
+
+	for _, tt := range tests {
+		g, s, u := XGCD(tt.a, tt.b, f)
+		if want := GCD(tt.a, tt.b, f); !g.Eq(want) {
+			t.Errorf("%s: XGCD(%v, %v) gcd: want %v, got %v", tt.name, tt.a, tt.b, want, g)
+		}
+		if got := s.Mul(tt.a, f).Add(u.Mul(tt.b, f), f); !got.Eq(g) {
+			t.Errorf("%s: XGCD(%v, %v) = %v, %v, %v; s*a + t*b = %v", tt.name, tt.a, tt.b, g, s, u, got)
+		}
+	}
+
+	// Cofactors of non-divisors are bounded in degree.
+	a, b := vanishing([]int64{1, 2, 3, 4}, f), vanishing([]int64{3, 4, 5}, f)
+	g, s, u := XGCD(a, b, f)
+	if s.Degree() >= b.Degree()-g.Degree() || u.Degree() >= a.Degree()-g.Degree() {
+		t.Errorf("XGCD(%v, %v) cofactors of degree %d, %d; want < %d, %d", a, b, s.Degree(), u.Degree(), b.Degree()-g.Degree(), a.Degree()-g.Degree())
+	}
+}
@@ -0,0 +1,256 @@
+package polynomial
+
+import (
+	"errors"
+	"fmt"
+
+	"zkp.xyz/membership/galois"
+)
+
+// A Generic is a polynomial over galois.Elements, such as galois.Goldilocks,
+// stored as its coefficients in ascending order of degree. Unlike Polynomial,
+// its arithmetic needs no *galois.Field as the field is implied by E. Results
+// of arithmetic are normalized without trailing zero coefficients, so the zero
+// polynomial has none.
+//
+// For fields with roots of unity, Mul, Div and EvaluateManyGeneric use a
+// GenericDomain. Polynomial implements its multiplication, division,
+// multipoint evaluation and interpolation with those of Generic, over an
+// adapter of its *big.Int coefficients, while the remaining algorithms, such
+// as GCD, sparse polynomials and cosets, are only available for Polynomial.
+type Generic[E galois.Element[E]] []E
+
+// normalize returns p without trailing zero coefficients.
+func (p Generic[E]) normalize() Generic[E] {
+	for len(p) > 0 && p[len(p)-1].IsZero() {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+// Degree returns the degree of p, or -1 for the zero polynomial.
+func (p Generic[E]) Degree() int {
+	return len(p.normalize()) - 1
+}
+
+// Evaluate returns p(x).
+func (p Generic[E]) Evaluate(x E) E {
+	var y E
+	for i := len(p) - 1; i >= 0; i-- {
+		y = y.Mul(x).Add(p[i])
+	}
+	return y
+}
+
+// Add returns p+q.
+func (p Generic[E]) Add(q Generic[E]) Generic[E] {
+	return p.combine(q, E.Add)
+}
+
+// Sub returns p-q.
+func (p Generic[E]) Sub(q Generic[E]) Generic[E] {
+	return p.combine(q, E.Sub)
+}
+
+// combine returns the coefficient-wise op of p and q, with missing
+// coefficients treated as zero.
+func (p Generic[E]) combine(q Generic[E], op func(a, b E) E) Generic[E] {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	out := make(Generic[E], n)
+	for i := range out {
+		var a, b E
+		if i < len(p) {
+			a = p[i]
+		}
+		if i < len(q) {
+			b = q[i]
+		}
+		out[i] = op(a, b)
+	}
+	return out.normalize()
+}
+
+// Scale returns c*p.
+func (p Generic[E]) Scale(c E) Generic[E] {
+	out := make(Generic[E], len(p))
+	for i, x := range p {
+		out[i] = x.Mul(c)
+	}
+	return out.normalize()
+}
+
+// Mul returns p*q. If both factors have degree at least FFTMulThreshold and
+// the field has a GenericDomain of sufficient size, the product is computed
+// with the FFT. Otherwise schoolbook multiplication is used, concurrently for
+// products of degree at least ParallelMulThreshold.
+func (p Generic[E]) Mul(q Generic[E]) Generic[E] {
+	p, q = p.normalize(), q.normalize()
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+	if len(p) > FFTMulThreshold && len(q) > FFTMulThreshold {
+		if prod, ok := p.mulFFT(q); ok {
+			return prod
+		}
+	}
+	if len(p)+len(q)-2 >= ParallelMulThreshold {
+		return p.mulParallel(q)
+	}
+	return p.mulSerial(q)
+}
+
+// mulSerial returns p*q for normalized, non-zero p and q by schoolbook
+// multiplication.
+func (p Generic[E]) mulSerial(q Generic[E]) Generic[E] {
+	out := make(Generic[E], len(p)+len(q)-1)
+	for i, x := range p {
+		for j, y := range q {
+			out[i+j] = out[i+j].Add(x.Mul(y))
+		}
+	}
+	return out.normalize()
+}
+
+// Div returns the quotient and remainder of p divided by d, or an error if d
+// is the zero polynomial. If both d and the quotient have degree at least
+// FFTMulThreshold and the field has roots of unity, the quotient is computed
+// with a constant number of multiplications, and thus with the FFT or
+// concurrently as determined by Mul. Otherwise long division is used.
+func (p Generic[E]) Div(d Generic[E]) (quo, rem Generic[E], err error) {
+	d = d.normalize()
+	if len(d) == 0 {
+		return nil, nil, errors.New("division by zero polynomial")
+	}
+	p = p.normalize()
+	if len(p) < len(d) {
+		return nil, append(Generic[E](nil), p...), nil
+	}
+	leadInv, err := d[len(d)-1].Inverse()
+	if err != nil {
+		return nil, nil, err
+	}
+	if k := len(p) - len(d) + 1; len(d) > FFTMulThreshold && k > FFTMulThreshold && hasRootsOfUnity[E]() {
+		quo, rem = p.divNewton(d, leadInv)
+		return quo, rem, nil
+	}
+	quo, rem = p.divSchoolbook(d, leadInv)
+	return quo, rem, nil
+}
+
+// divSchoolbook returns the quotient and remainder of p / d by long division
+// in O((deg p - deg d) deg d) field operations, for normalized p and d with
+// len(p) >= len(d) and leadInv the inverse of the leading coefficient of d.
+func (p Generic[E]) divSchoolbook(d Generic[E], leadInv E) (quo, rem Generic[E]) {
+	rem = append(Generic[E](nil), p...)
+	quo = make(Generic[E], len(rem)-len(d)+1)
+	for i := len(quo) - 1; i >= 0; i-- {
+		c := rem[i+len(d)-1].Mul(leadInv)
+		quo[i] = c
+		if c.IsZero() {
+			continue
+		}
+		for j, x := range d {
+			rem[i+j] = rem[i+j].Sub(c.Mul(x))
+		}
+	}
+	return quo.normalize(), rem[:len(d)-1].normalize()
+}
+
+// divNewton is equivalent to divSchoolbook, but computes the quotient from the
+// reversed polynomials with a power-series inverse obtained by Newton
+// iteration. The cost is that of a constant number of multiplications, so
+// O(n log n) with the FFT.
+func (p Generic[E]) divNewton(d Generic[E], leadInv E) (quo, rem Generic[E]) {
+	k := len(p) - len(d) + 1
+	quo = p.reversed().Mul(d.reversed().invSeries(leadInv, k)).truncated(k).reversed()
+	return quo.normalize(), p.Sub(d.Mul(quo))
+}
+
+// reversed returns the coefficients of p in reverse order, i.e. v^(len(p)-1)
+// p(1/v).
+func (p Generic[E]) reversed() Generic[E] {
+	r := make(Generic[E], len(p))
+	for i, c := range p {
+		r[len(p)-1-i] = c
+	}
+	return r
+}
+
+// truncated returns p mod v^k with exactly k coefficients.
+func (p Generic[E]) truncated(k int) Generic[E] {
+	t := make(Generic[E], k)
+	copy(t, p)
+	return t
+}
+
+// invSeries returns g such that g*h = 1 mod v^k, for h0Inv the inverse of the
+// constant coefficient of h, by Newton iteration as for Polynomial.
+func (h Generic[E]) invSeries(h0Inv E, k int) Generic[E] {
+	// Each iteration g <- g * (2 - h*g) doubles the precision.
+	g := Generic[E]{h0Inv}
+	one := h0Inv.One()
+	two := Generic[E]{one.Add(one)}
+	for n := 1; n < k; {
+		n *= 2
+		hg := h.truncated(n).Mul(g).truncated(n)
+		g = g.Mul(two.Sub(hg)).truncated(n)
+	}
+	return g.truncated(k)
+}
+
+// divLinear returns p / (X - a), discarding the remainder p(a).
+func (p Generic[E]) divLinear(a E) Generic[E] {
+	p = p.normalize()
+	if len(p) < 2 {
+		return nil
+	}
+	out := make(Generic[E], len(p)-1)
+	var carry E
+	for i := len(p) - 1; i >= 1; i-- {
+		carry = carry.Mul(a).Add(p[i])
+		out[i-1] = carry
+	}
+	return out
+}
+
+// GenericFromRoots returns the monic polynomial prod_i (X - roots[i])
+// vanishing on the roots, or the constant polynomial 1 if there are none. The
+// product is computed as a balanced product tree, halving the roots
+// recursively, so that large factors are multiplied with the FFT.
+func GenericFromRoots[E galois.Element[E]](roots []E) Generic[E] {
+	switch len(roots) {
+	case 0:
+		var one E
+		return Generic[E]{one.One()}
+	case 1:
+		return Generic[E]{roots[0].Neg(), roots[0].One()}
+	}
+	mid := len(roots) / 2
+	return GenericFromRoots(roots[:mid]).Mul(GenericFromRoots(roots[mid:]))
+}
+
+// InterpolateGeneric returns the unique polynomial of degree < len(xs) with
+// p(xs[i]) = ys[i], with O(n^2) field operations. It returns an error if the
+// lengths differ or are zero, or the xs aren't distinct.
+func InterpolateGeneric[E galois.Element[E]](xs, ys []E) (Generic[E], error) {
+	if len(xs) != len(ys) || len(xs) == 0 {
+		return nil, fmt.Errorf("len(xs) != len(ys) or empty: %d != %d", len(xs), len(ys))
+	}
+	z := GenericFromRoots(xs)
+
+	// The ith Lagrange basis polynomial is b_i(v) / b_i(x_i) for
+	// b_i = z / (v - x_i).
+	var result Generic[E]
+	for i, x := range xs {
+		basis := z.divLinear(x)
+		inv, err := basis.Evaluate(x).Inverse()
+		if err != nil {
+			return nil, fmt.Errorf("interpolation points not distinct: %w", err)
+		}
+		result = result.Add(basis.Scale(ys[i].Mul(inv)))
+	}
+	return result, nil
+}
@@ -0,0 +1,187 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"zkp.xyz/membership/galois"
+)
+
+// testGeneric checks Generic arithmetic over the field of E.
+func testGeneric[E galois.Element[E]](t *testing.T) {
+	t.Helper()
+	randoms := func(n int) []E {
+		es := make([]E, n)
+		for i := range es {
+			var err error
+			if es[i], err = es[i].Random(rand.Reader); err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+		}
+		return es
+	}
+
+	// Products and quotients of 200 points exceed FFTMulThreshold, so they use
+	// the FFT and Newton division where the field has a GenericDomain.
+	for _, n := range []int{1, 2, 3, 17, 200} {
+		t.Run(fmt.Sprintf("%d points", n), func(t *testing.T) {
+			p := Generic[E](randoms(n))
+			xs := randoms(n)
+			ys := make([]E, n)
+			for i, x := range xs {
+				ys[i] = p.Evaluate(x)
+			}
+
+			got, err := InterpolateGeneric(xs, ys)
+			if err != nil {
+				t.Fatalf("InterpolateGeneric(): %v", err)
+			}
+			if got.Degree() != p.Degree() {
+				t.Fatalf("InterpolateGeneric(<evaluations of degree-%d polynomial>) of degree %d", p.Degree(), got.Degree())
+			}
+			for i := range p {
+				if got[i] != p[i] {
+					t.Errorf("InterpolateGeneric()[%d] = %v; want %v", i, got[i], p[i])
+				}
+			}
+
+			z := GenericFromRoots(xs)
+			if z.Degree() != n {
+				t.Errorf("GenericFromRoots(<%d roots>).Degree() = %d; want %d", n, z.Degree(), n)
+			}
+			q := Generic[E](randoms(n/2 + 3))
+			for _, x := range append(xs, randoms(3)...) {
+				if i := indexOf(xs, x); i >= 0 && !z.Evaluate(x).IsZero() {
+					t.Errorf("GenericFromRoots() doesn't vanish at root %d", i)
+				}
+				px, qx := p.Evaluate(x), q.Evaluate(x)
+				for _, op := range []struct {
+					name      string
+					got, want E
+				}{
+					{"p*q", p.Mul(q).Evaluate(x), px.Mul(qx)},
+					{"p+q", p.Add(q).Evaluate(x), px.Add(qx)},
+					{"p-q", p.Sub(q).Evaluate(x), px.Sub(qx)},
+				} {
+					if op.got != op.want {
+						t.Errorf("(%s)(x) = %v; want %v", op.name, op.got, op.want)
+					}
+				}
+			}
+			if got := p.Sub(p).Degree(); got != -1 {
+				t.Errorf("(p-p).Degree() = %d; want -1", got)
+			}
+
+			quo, rem, err := p.Mul(q).Add(z).Div(q)
+			if err != nil {
+				t.Fatalf("Div(): %v", err)
+			}
+			if rem.Degree() >= q.Degree() {
+				t.Errorf("Div() remainder of degree %d; want < %d", rem.Degree(), q.Degree())
+			}
+			if got, want := quo.Mul(q).Add(rem), p.Mul(q).Add(z); got.Sub(want).Degree() != -1 {
+				t.Errorf("quo*q + rem = %v; want %v", got, want)
+			}
+		})
+	}
+
+	xs := randoms(2)
+	if _, err := InterpolateGeneric([]E{xs[0], xs[1], xs[0]}, randoms(3)); err == nil {
+		t.Errorf("InterpolateGeneric(<duplicate x>): got nil error")
+	}
+	if _, err := InterpolateGeneric(xs, randoms(1)); err == nil {
+		t.Errorf("InterpolateGeneric(<length mismatch>): got nil error")
+	}
+	if _, _, err := Generic[E](xs).Div(nil); err == nil {
+		t.Errorf("Div(<zero polynomial>): got nil error")
+	}
+}
+
+func indexOf[E comparable](es []E, e E) int {
+	for i, x := range es {
+		if x == e {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestGeneric(t *testing.T) {
+	t.Run("Goldilocks", testGeneric[galois.Goldilocks])
+	t.Run("BabyBear", testGeneric[galois.BabyBear])
+	t.Run("BN256Fr", testGeneric[galois.BN256Fr])
+	t.Run("GF(2^64)", testGeneric[galois.GF64])
+	t.Run("GF(2^128)", testGeneric[galois.GF128])
+}
+
+// testGenericDomain checks the GenericDomain of E against Evaluate.
+func testGenericDomain[E galois.Element[E]](t *testing.T) {
+	for _, size := range []uint64{1, 2, 8, 256} {
+		t.Run(fmt.Sprintf("size %d", size), func(t *testing.T) {
+			d, err := NewGenericDomain[E](size)
+			if err != nil {
+				t.Fatalf("NewGenericDomain(%d): %v", size, err)
+			}
+			var zero E
+			p := make(Generic[E], size/2+1)
+			for i := range p {
+				if p[i], err = zero.Random(rand.Reader); err != nil {
+					t.Fatalf("Random(): %v", err)
+				}
+			}
+
+			evals := d.FFT(p)
+			for i, y := range evals {
+				if want := p.Evaluate(d.Element(uint64(i))); y != want {
+					t.Errorf("FFT()[%d] = %v; want Evaluate(w^%d) = %v", i, y, i, want)
+				}
+			}
+			if got := d.IFFT(evals).normalize(); got.Sub(p).Degree() != -1 {
+				t.Errorf("IFFT(FFT(p)) = %v; want %v", got, p)
+			}
+			if got := d.Element(size); got != zero.One() {
+				t.Errorf("Element(%d) = %v; want 1", size, got)
+			}
+		})
+	}
+}
+
+func TestGenericDomain(t *testing.T) {
+	t.Run("Goldilocks", testGenericDomain[galois.Goldilocks])
+	t.Run("BabyBear", testGenericDomain[galois.BabyBear])
+	t.Run("BN256Fr", testGenericDomain[galois.BN256Fr])
+
+	if _, err := NewGenericDomain[galois.GF64](8); err == nil {
+		t.Errorf("NewGenericDomain[GF64](8): got nil error")
+	}
+	if _, err := NewGenericDomain[galois.Goldilocks](3); err == nil {
+		t.Errorf("NewGenericDomain[Goldilocks](3): got nil error")
+	}
+}
+
+func BenchmarkGenericMul(b *testing.B) {
+	f := galois.NewField(galois.BN256Order)
+
+	for _, d := range []int{64, 1024} {
+		p, err := Random(d, f, rand.Reader)
+		if err != nil {
+			b.Fatalf("Random(): %v", err)
+		}
+		g := make(Generic[galois.BN256Fr], len(*p))
+		for i, c := range *p {
+			g[i] = galois.NewBN256Fr(c)
+		}
+
+		b.Run(fmt.Sprintf("Polynomial/deg=%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				p.Mul(p, f)
+			}
+		})
+		b.Run(fmt.Sprintf("Generic[BN256Fr]/deg=%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.Mul(g)
+			}
+		})
+	}
+}
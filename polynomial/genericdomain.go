@@ -0,0 +1,203 @@
+package polynomial
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"zkp.xyz/membership/galois"
+)
+
+// rootsOfUnity is implemented by Elements of fields with power-of-two roots of
+// unity, such as the galois.SmallPrimeElements and galois.BN256Fr.
+type rootsOfUnity[E any] interface {
+	RootOfUnity(n uint64) (E, error)
+}
+
+// fieldDomains is implemented by Elements whose GenericDomains depend on the
+// field carried by each value rather than on their type, such as the
+// fieldElements of Polynomial.
+type fieldDomains[E galois.Element[E]] interface {
+	genericDomain(size uint64) (*GenericDomain[E], error)
+}
+
+// hasRootsOfUnity reports whether E implements rootsOfUnity or fieldDomains.
+func hasRootsOfUnity[E galois.Element[E]]() bool {
+	var zero E
+	_, roots := any(zero).(rootsOfUnity[E])
+	_, domains := any(zero).(fieldDomains[E])
+	return roots || domains
+}
+
+// genericDomainOf returns the GenericDomain of the specified size of the field
+// of x.
+func genericDomainOf[E galois.Element[E]](x E, size uint64) (*GenericDomain[E], error) {
+	if fd, ok := any(x).(fieldDomains[E]); ok {
+		return fd.genericDomain(size)
+	}
+	return NewGenericDomain[E](size)
+}
+
+// A GenericDomain is the Domain of a field of fixed-width galois.Elements: the
+// subgroup of size-th roots of unity, generated by the root returned by the
+// Elements' RootOfUnity method.
+type GenericDomain[E galois.Element[E]] struct {
+	size     uint64
+	elements []E // w^i
+	inverse  []E // w^-i
+	sizeInv  E
+}
+
+// genericDomainKey identifies a GenericDomain by the zero value of its Element
+// type and its size.
+type genericDomainKey struct {
+	zero any
+	size uint64
+}
+
+// genericDomainCache holds GenericDomains by genericDomainKey.
+var genericDomainCache sync.Map
+
+// NewGenericDomain returns the GenericDomain of the specified size, a power of
+// two dividing the order of the multiplicative group. It returns an error if E
+// has no RootOfUnity method, e.g. for binary fields.
+//
+// Like those of CanonicalDomain, GenericDomains are cached by Element type and
+// size, and shared between callers.
+func NewGenericDomain[E galois.Element[E]](size uint64) (*GenericDomain[E], error) {
+	var zero E
+	key := genericDomainKey{zero, size}
+	if d, ok := genericDomainCache.Load(key); ok {
+		return d.(*GenericDomain[E]), nil
+	}
+	r, ok := any(zero).(rootsOfUnity[E])
+	if !ok {
+		return nil, fmt.Errorf("%T has no roots of unity", zero)
+	}
+	w, err := r.RootOfUnity(size)
+	if err != nil {
+		return nil, err
+	}
+	wInv, err := w.Inverse()
+	if err != nil {
+		return nil, err
+	}
+
+	// size is a power of two, as RootOfUnity succeeded.
+	n := zero.One()
+	for two, s := n.Add(n), size; s > 1; s /= 2 {
+		n = n.Mul(two)
+	}
+	sizeInv, err := n.Inverse()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &GenericDomain[E]{size: size, elements: genericPowers(w, int(size)), inverse: genericPowers(wInv, int(size)), sizeInv: sizeInv}
+	actual, _ := genericDomainCache.LoadOrStore(key, d)
+	return actual.(*GenericDomain[E]), nil
+}
+
+// genericPowers returns x^0, ..., x^(n-1).
+func genericPowers[E galois.Element[E]](x E, n int) []E {
+	ps := make([]E, n)
+	if n > 0 {
+		ps[0] = x.One()
+	}
+	for i := 1; i < n; i++ {
+		ps[i] = ps[i-1].Mul(x)
+	}
+	return ps
+}
+
+// Size returns the number of elements in the GenericDomain.
+func (d *GenericDomain[E]) Size() uint64 {
+	return d.size
+}
+
+// Element returns w^i for the generator w.
+func (d *GenericDomain[E]) Element(i uint64) E {
+	return d.elements[i%d.size]
+}
+
+// FFT returns the evaluations of the polynomial with the coefficients over the
+// GenericDomain, in the order of its elements. It panics if there are more
+// coefficients than elements.
+func (d *GenericDomain[E]) FFT(coeffs Generic[E]) []E {
+	return d.transform(coeffs, d.elements)
+}
+
+// IFFT is the inverse of FFT, returning the coefficients of the unique
+// polynomial of degree < d.Size() that evaluates to evals over the
+// GenericDomain. The result isn't normalized.
+func (d *GenericDomain[E]) IFFT(evals []E) Generic[E] {
+	cs := d.transform(evals, d.inverse)
+	parallelize(len(cs), fftChunk, func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			cs[i] = cs[i].Mul(d.sizeInv)
+		}
+	})
+	return cs
+}
+
+// fftChunk is the minimum number of butterflies, or values, processed by each
+// worker of a concurrent FFT.
+const fftChunk = 256
+
+// transform computes the iterative, radix-2 Cooley-Tukey FFT with the given
+// twiddle factors. The butterflies of each stage are independent and computed
+// concurrently for large domains.
+func (d *GenericDomain[E]) transform(in []E, twiddles []E) []E {
+	if uint64(len(in)) > d.size {
+		panic(fmt.Sprintf("%d values exceed domain size %d", len(in), d.size))
+	}
+
+	n := int(d.size)
+	out := make([]E, n)
+	shift := 64 - bits.Len64(d.size-1)
+	parallelize(n, fftChunk, func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			j := i
+			if n > 1 {
+				j = int(bits.Reverse64(uint64(i)) >> shift)
+			}
+			if j < len(in) {
+				out[i] = in[j]
+			}
+		}
+	})
+
+	for size := 2; size <= n; size *= 2 {
+		half, step := size/2, n/size
+		// Butterfly b combines out[start+k] and out[start+k+half].
+		parallelize(n/2, fftChunk, func(_, lo, hi int) {
+			for b := lo; b < hi; b++ {
+				start, k := (b/half)*size, b%half
+				t := twiddles[k*step].Mul(out[start+k+half])
+				u := out[start+k]
+				out[start+k] = u.Add(t)
+				out[start+k+half] = u.Sub(t)
+			}
+		})
+	}
+	return out
+}
+
+// mulFFT is equivalent to mulSerial, but evaluates both factors over a
+// GenericDomain of sufficient size, multiplies pointwise and interpolates the
+// product, in O(n log n) field operations. It returns false if the field has
+// no GenericDomain of that size.
+func (p Generic[E]) mulFFT(q Generic[E]) (Generic[E], bool) {
+	size := uint64(1) << bits.Len(uint(len(p)+len(q)-2))
+	d, err := genericDomainOf(p[len(p)-1], size)
+	if err != nil {
+		return nil, false
+	}
+	a, b := d.FFT(p), d.FFT(q)
+	parallelize(len(a), fftChunk, func(_, lo, hi int) {
+		for i := lo; i < hi; i++ {
+			a[i] = a[i].Mul(b[i])
+		}
+	})
+	return d.IFFT(a)[:len(p)+len(q)-1].normalize(), true
+}
@@ -0,0 +1,19 @@
+package polynomial
+
+import (
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// Interpolate returns the unique polynomial of degree less than len(xs) that
+// evaluates to ys[i] at xs[i], using Lagrange interpolation as
+// InterpolateGeneric does. It returns an error if the slices differ in length
+// or are empty, or if the xs aren't distinct in f.
+func Interpolate(xs, ys []*big.Int, f *galois.Field) (*Polynomial, error) {
+	p, err := InterpolateGeneric(elements(xs, f), elements(ys, f))
+	if err != nil {
+		return nil, err
+	}
+	return fromGeneric(p, f), nil
+}
@@ -0,0 +1,68 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+func TestInterpolate(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	for _, n := range []int{1, 2, 3, 17} {
+		t.Run(fmt.Sprintf("%d points", n), func(t *testing.T) {
+			p, err := Random(n-1, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+
+			var xs, ys []*big.Int
+			for i := 0; i < n; i++ {
+				x := big.NewInt(int64(i*i - 5))
+				xs, ys = append(xs, x), append(ys, p.Evaluate(x, f))
+			}
+
+			got, err := Interpolate(xs, ys, f)
+			if err != nil {
+				t.Fatalf("Interpolate(): %v", err)
+			}
+			if !got.Eq(p) {
+				t.Errorf("Interpolate(<evaluations of %v>) = %v", p, got)
+			}
+		})
+	}
+}
+
+func TestInterpolateErrors(t *testing.T) {
+	f := galois.NewField(big.NewInt(13))
+
+	tests := []struct {
+		name   string
+		xs, ys []int64
+	}{
+		{name: "empty"},
+		{name: "length mismatch", xs: []int64{1, 2}, ys: []int64{1}},
+		{name: "duplicate x", xs: []int64{1, 2, 1}, ys: []int64{1, 2, 3}},
+		{name: "congruent x", xs: []int64{1, 14}, ys: []int64{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Interpolate(bigInts(tt.xs), bigInts(tt.ys), f); err == nil {
+				t.Errorf("Interpolate(%v, %v): got nil error", tt.xs, tt.ys)
+			}
+		})
+	}
+}
+
+func bigInts(xs []int64) []*big.Int {
+	bs := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		bs[i] = big.NewInt(x)
+	}
+	return bs
+}
@@ -0,0 +1,71 @@
+package polynomial
+
+import (
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// evaluateManyLeaf is the number of points below which EvaluateMany evaluates
+// the remainder directly instead of further descending the subproduct tree.
+const evaluateManyLeaf = 16
+
+// EvaluateMany returns the evaluations of p at all xs. Instead of evaluating p
+// at each point in O(deg p) field operations, p is reduced modulo the
+// polynomials of a subproduct tree over the points, prod_i (v - xs[i]) for
+// halves of xs recursively. With fast multiplication and division this takes
+// O(n log^2 n) operations for n points and deg p < n. As the constant factor is
+// significantly larger than that of Evaluate, this only pays off for
+// polynomials and sets of points with thousands of elements.
+func EvaluateMany(p *Polynomial, xs []*big.Int, f *galois.Field) []*big.Int {
+	return ints(EvaluateManyGeneric(toGeneric(p, f), elements(xs, f)), f)
+}
+
+// EvaluateManyGeneric is EvaluateMany for a Generic polynomial. It only pays
+// off for fields with a GenericDomain.
+func EvaluateManyGeneric[E galois.Element[E]](p Generic[E], xs []E) []E {
+	ys := make([]E, len(xs))
+	if len(xs) == 0 {
+		return ys
+	}
+	tree := newGenericTree(xs)
+	tree.evaluate(p.rem(tree.poly), ys)
+	return ys
+}
+
+// A genericTree holds prod_i (v - xs[i]) over a range of points, along with
+// the trees of both halves of the range.
+type genericTree[E galois.Element[E]] struct {
+	xs          []E
+	poly        Generic[E]
+	left, right *genericTree[E]
+}
+
+func newGenericTree[E galois.Element[E]](xs []E) *genericTree[E] {
+	if len(xs) <= evaluateManyLeaf {
+		return &genericTree[E]{xs: xs, poly: GenericFromRoots(xs)}
+	}
+	mid := len(xs) / 2
+	left, right := newGenericTree(xs[:mid]), newGenericTree(xs[mid:])
+	return &genericTree[E]{xs: xs, poly: left.poly.Mul(right.poly), left: left, right: right}
+}
+
+// evaluate sets ys to the evaluations of r, congruent to p modulo t.poly, at
+// t.xs.
+func (t *genericTree[E]) evaluate(r Generic[E], ys []E) {
+	if t.left == nil {
+		for i, x := range t.xs {
+			ys[i] = r.Evaluate(x)
+		}
+		return
+	}
+	mid := len(t.left.xs)
+	t.left.evaluate(r.rem(t.left.poly), ys[:mid])
+	t.right.evaluate(r.rem(t.right.poly), ys[mid:])
+}
+
+// rem returns p mod d for a monic d, whose division never fails.
+func (p Generic[E]) rem(d Generic[E]) Generic[E] {
+	_, r, _ := p.Div(d)
+	return r
+}
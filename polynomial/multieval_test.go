@@ -0,0 +1,139 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/google/go-cmp/cmp"
+	"zkp.xyz/membership/galois"
+)
+
+func TestEvaluateMany(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	tests := []struct{ degree, n int }{
+		{degree: 0, n: 0},
+		{degree: 0, n: 1},
+		{degree: 3, n: 5},
+		{degree: 20, n: 3},
+		{degree: 100, n: 100},
+		{degree: 300, n: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("degree %d at %d points", tt.degree, tt.n), func(t *testing.T) {
+			p, err := Random(tt.degree, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			xs := make([]*big.Int, tt.n)
+			want := make([]*big.Int, tt.n)
+			for i := range xs {
+				xs[i] = big.NewInt(int64(3*i - 7))
+				want[i] = p.Evaluate(xs[i], f)
+			}
+
+			got := EvaluateMany(p, xs, f)
+			if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b *big.Int) bool { return a.Cmp(b) == 0 })); diff != "" {
+				t.Errorf("EvaluateMany() diff (-Evaluate() +EvaluateMany()):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluateManyGeneric(t *testing.T) {
+	for _, tt := range []struct{ degree, n int }{{0, 0}, {3, 5}, {100, 100}, {300, 500}} {
+		t.Run(fmt.Sprintf("degree %d at %d points", tt.degree, tt.n), func(t *testing.T) {
+			p := make(Generic[galois.BN256Fr], tt.degree+1)
+			for i := range p {
+				var err error
+				if p[i], err = galois.RandomBN256Fr(rand.Reader); err != nil {
+					t.Fatalf("RandomBN256Fr(): %v", err)
+				}
+			}
+			xs := make([]galois.BN256Fr, tt.n)
+			want := make([]galois.BN256Fr, tt.n)
+			for i := range xs {
+				xs[i] = galois.NewBN256Fr(big.NewInt(int64(3*i - 7)))
+				want[i] = p.Evaluate(xs[i])
+			}
+
+			got := EvaluateManyGeneric(p, xs)
+			if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b galois.BN256Fr) bool { return a == b })); diff != "" {
+				t.Errorf("EvaluateManyGeneric() diff (-Evaluate() +EvaluateManyGeneric()):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDivStrategies(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	for _, d := range []struct{ n, m int }{{5, 2}, {10, 10}, {3, 7}, {140, 70}} {
+		t.Run(fmt.Sprintf("deg %d / deg %d", d.n, d.m), func(t *testing.T) {
+			p, err := Random(d.n, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			divisor, err := Random(d.m, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			leadInv, err := f.MultInverse((*divisor)[d.m])
+			if err != nil {
+				t.Fatalf("MultInverse(): %v", err)
+			}
+
+			divs := map[string]func(*Polynomial, *galois.Field) (*Polynomial, *Polynomial){
+				"Div": p.Div,
+			}
+			if d.n >= d.m {
+				divs["divSchoolbook"] = func(d *Polynomial, f *galois.Field) (*Polynomial, *Polynomial) {
+					return p.divSchoolbook(d, leadInv, f)
+				}
+				divs["divNewton"] = p.divNewton
+			}
+			for name, div := range divs {
+				q, r := div(divisor, f)
+				if r.Degree() >= d.m && d.m > 0 {
+					t.Errorf("%s() rest has degree %d; want < %d", name, r.Degree(), d.m)
+				}
+				if got := q.Mul(divisor, f).Add(r, f); !got.Eq(p) {
+					t.Errorf("%s(): quotient*divisor + rest != p", name)
+				}
+				if got := p.rem(divisor, f); !got.Eq(r) {
+					t.Errorf("rem() = %v; want %s() rest %v", got, name, r)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEvaluateMany(b *testing.B) {
+	f := galois.NewField(bn256.Order)
+	const n = 2048
+	p, err := Random(n-1, f, rand.Reader)
+	if err != nil {
+		b.Fatalf("Random(): %v", err)
+	}
+	xs := make([]*big.Int, n)
+	for i := range xs {
+		xs[i] = big.NewInt(int64(i))
+	}
+
+	b.Run("subproduct tree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			EvaluateMany(p, xs, f)
+		}
+	})
+	b.Run("Evaluate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, x := range xs {
+				p.Evaluate(x, f)
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package multivariate
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"zkp.xyz/membership/galois"
+)
+
+// MultilinearFromEvaluations returns the multilinear extension of vals, i.e.
+// the unique Polynomial p of degree at most one in each of n variables with
+// p(b_0, ..., b_{n-1}) = vals[b] on the Boolean hypercube, where b_i is bit i
+// of the index b. It returns an error unless len(vals) is a power of two.
+func MultilinearFromEvaluations(vals []*big.Int, f *galois.Field) (*Polynomial, error) {
+	if len(vals) == 0 || len(vals)&(len(vals)-1) != 0 {
+		return nil, fmt.Errorf("number of evaluations %d is not a power of two", len(vals))
+	}
+	n := bits.TrailingZeros(uint(len(vals)))
+
+	// Inverting the zeta transform over subsets leaves in cs[b] the
+	// coefficient of the monomial prod_{i in b} x_i.
+	cs := make([]*big.Int, len(vals))
+	for b, v := range vals {
+		cs[b] = f.Mod(new(big.Int).Set(v))
+	}
+	for i := 0; i < n; i++ {
+		for b := range cs {
+			if b&(1<<i) != 0 {
+				cs[b] = f.Sub(cs[b], cs[b^(1<<i)])
+			}
+		}
+	}
+
+	ts := make([]Term, 0, len(cs))
+	for b, c := range cs {
+		e := make([]int, n)
+		for i := range e {
+			e[i] = (b >> i) & 1
+		}
+		ts = append(ts, Term{e, c})
+	}
+	return newPolynomial(n, ts, f), nil
+}
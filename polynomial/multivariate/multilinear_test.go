@@ -0,0 +1,51 @@
+package multivariate
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestMultilinearFromEvaluations(t *testing.T) {
+	tests := []struct {
+		vals []int64
+		want string
+	}{
+		{vals: []int64{7}, want: "7"},
+		{vals: []int64{3, 5}, want: "2*x0 + 3"},
+		{vals: []int64{0, 0, 0, 1}, want: "x0*x1"},
+		{vals: []int64{1, 2, 3, 4, 5, 6, 7, -1}, want: "92*x0*x1*x2 + x0 + 2*x1 + 4*x2 + 1"},
+	}
+
+	for _, tt := range tests {
+		p, err := MultilinearFromEvaluations(ints(tt.vals...), f)
+		if err != nil {
+			t.Fatalf("MultilinearFromEvaluations(%v): %v", tt.vals, err)
+		}
+		if got := p.String(); got != tt.want {
+			t.Errorf("MultilinearFromEvaluations(%v) = %v; want %v", tt.vals, got, tt.want)
+		}
+		if !p.IsMultilinear() {
+			t.Errorf("MultilinearFromEvaluations(%v).IsMultilinear() = false", tt.vals)
+		}
+
+		for b, v := range tt.vals {
+			xs := make([]*big.Int, p.NumVars())
+			for i := range xs {
+				xs[i] = big.NewInt(int64(b>>i) & 1)
+			}
+			got, err := p.Evaluate(xs, f)
+			if err != nil {
+				t.Fatalf("Evaluate(%v): %v", xs, err)
+			}
+			if want := f.Mod(big.NewInt(v)); got.Cmp(want) != 0 {
+				t.Errorf("MultilinearFromEvaluations(%v).Evaluate(%v) = %v; want %v", tt.vals, xs, got, want)
+			}
+		}
+	}
+
+	for _, n := range []int{0, 3, 6} {
+		if _, err := MultilinearFromEvaluations(ints(make([]int64, n)...), f); err == nil {
+			t.Errorf("MultilinearFromEvaluations(<%d values>): got nil error", n)
+		}
+	}
+}
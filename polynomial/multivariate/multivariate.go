@@ -0,0 +1,338 @@
+// Package multivariate implements sparse polynomials in several variables
+// over a galois.Field.
+package multivariate
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A Term is the monomial Coefficient * prod_i x_i^Exponents[i].
+type Term struct {
+	Exponents   []int
+	Coefficient *big.Int
+}
+
+// degree returns the total degree of t.
+func (t Term) degree() int {
+	d := 0
+	for _, e := range t.Exponents {
+		d += e
+	}
+	return d
+}
+
+// A Polynomial is a polynomial in a fixed number of variables, stored as its
+// terms with non-zero coefficients reduced into the field, sorted
+// lexicographically by exponents. The zero polynomial has no terms.
+type Polynomial struct {
+	vars  int
+	terms []Term
+}
+
+// NewPolynomial returns the sum of the terms in vars variables, reduced into
+// f. The terms may be in any order and repeat exponents. It returns an error
+// if a term doesn't have exactly vars exponents or has a negative exponent.
+func NewPolynomial(vars int, terms []Term, f *galois.Field) (*Polynomial, error) {
+	if vars < 0 {
+		return nil, fmt.Errorf("negative number of variables %d", vars)
+	}
+	ts := make([]Term, len(terms))
+	for i, t := range terms {
+		if len(t.Exponents) != vars {
+			return nil, fmt.Errorf("term %d has %d exponents; want %d", i, len(t.Exponents), vars)
+		}
+		for _, e := range t.Exponents {
+			if e < 0 {
+				return nil, fmt.Errorf("term %d has negative exponent %d", i, e)
+			}
+		}
+		ts[i] = Term{append([]int(nil), t.Exponents...), f.Mod(new(big.Int).Set(t.Coefficient))}
+	}
+	return newPolynomial(vars, ts, f), nil
+}
+
+// newPolynomial returns the sum of the terms, taking ownership of them.
+func newPolynomial(vars int, ts []Term, f *galois.Field) *Polynomial {
+	sort.SliceStable(ts, func(i, j int) bool { return less(ts[i].Exponents, ts[j].Exponents) })
+
+	var sum []Term
+	for _, t := range ts {
+		if n := len(sum); n > 0 && equal(sum[n-1].Exponents, t.Exponents) {
+			sum[n-1].Coefficient = f.Add(sum[n-1].Coefficient, t.Coefficient)
+			continue
+		}
+		sum = append(sum, t)
+	}
+
+	out := sum[:0]
+	for _, t := range sum {
+		if t.Coefficient.Sign() != 0 {
+			out = append(out, t)
+		}
+	}
+	return &Polynomial{vars: vars, terms: out}
+}
+
+func less(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func equal(a, b []int) bool {
+	return !less(a, b) && !less(b, a)
+}
+
+// Constant returns the constant polynomial c in vars variables.
+func Constant(vars int, c *big.Int, f *galois.Field) *Polynomial {
+	p, _ := NewPolynomial(vars, []Term{{make([]int, vars), c}}, f)
+	return p
+}
+
+// Variable returns the polynomial x_i in vars variables. It panics if i is out
+// of range.
+func Variable(vars, i int) *Polynomial {
+	if i < 0 || i >= vars {
+		panic(fmt.Sprintf("variable %d out of range [0, %d)", i, vars))
+	}
+	e := make([]int, vars)
+	e[i] = 1
+	return &Polynomial{vars: vars, terms: []Term{{e, big.NewInt(1)}}}
+}
+
+// FromUnivariate returns the univariate p as a Polynomial in the single
+// variable x_0, reduced into f.
+func FromUnivariate(p *polynomial.Polynomial, f *galois.Field) *Polynomial {
+	var ts []Term
+	for i, c := range *p {
+		ts = append(ts, Term{[]int{i}, c})
+	}
+	m, _ := NewPolynomial(1, ts, f)
+	return m
+}
+
+// Univariate returns p as a univariate polynomial, or an error if p has more
+// than one variable.
+func (p *Polynomial) Univariate() (*polynomial.Polynomial, error) {
+	if p.vars > 1 {
+		return nil, fmt.Errorf("polynomial in %d variables is not univariate", p.vars)
+	}
+	u := polynomial.NewZeroPolynomial(p.DegreeIn(0))
+	for _, t := range p.terms {
+		e := 0
+		if p.vars == 1 {
+			e = t.Exponents[0]
+		}
+		(*u)[e].Set(t.Coefficient)
+	}
+	return u, nil
+}
+
+// NumVars returns the number of variables of p.
+func (p *Polynomial) NumVars() int {
+	return p.vars
+}
+
+// Terms returns copies of the terms of p, sorted lexicographically by
+// exponents.
+func (p *Polynomial) Terms() []Term {
+	ts := make([]Term, len(p.terms))
+	for i, t := range p.terms {
+		ts[i] = Term{append([]int(nil), t.Exponents...), new(big.Int).Set(t.Coefficient)}
+	}
+	return ts
+}
+
+// IsZero reports whether p is the zero polynomial.
+func (p *Polynomial) IsZero() bool {
+	return len(p.terms) == 0
+}
+
+// Degree returns the total degree of p, which is 0 for the zero polynomial.
+func (p *Polynomial) Degree() int {
+	d := 0
+	for _, t := range p.terms {
+		if td := t.degree(); td > d {
+			d = td
+		}
+	}
+	return d
+}
+
+// DegreeIn returns the degree of p in the variable x_i, which is 0 if p
+// doesn't depend on it.
+func (p *Polynomial) DegreeIn(i int) int {
+	d := 0
+	for _, t := range p.terms {
+		if i < len(t.Exponents) && t.Exponents[i] > d {
+			d = t.Exponents[i]
+		}
+	}
+	return d
+}
+
+// DegreeBounds returns the degree of p in every variable.
+func (p *Polynomial) DegreeBounds() []int {
+	ds := make([]int, p.vars)
+	for i := range ds {
+		ds[i] = p.DegreeIn(i)
+	}
+	return ds
+}
+
+// IsMultilinear reports whether p has degree at most one in every variable.
+func (p *Polynomial) IsMultilinear() bool {
+	for _, d := range p.DegreeBounds() {
+		if d > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Eq reports whether p and x are the same polynomial in the same number of
+// variables.
+func (p *Polynomial) Eq(x *Polynomial) bool {
+	if p.vars != x.vars || len(p.terms) != len(x.terms) {
+		return false
+	}
+	for i, t := range p.terms {
+		if !equal(t.Exponents, x.terms[i].Exponents) || t.Coefficient.Cmp(x.terms[i].Coefficient) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns p in a human-readable form, e.g. "3*x0^2*x2 + 1".
+func (p *Polynomial) String() string {
+	if p.IsZero() {
+		return "0"
+	}
+	var s string
+	for i := len(p.terms) - 1; i >= 0; i-- {
+		t := p.terms[i]
+		if s != "" {
+			s += " + "
+		}
+		mono := ""
+		for v, e := range t.Exponents {
+			switch {
+			case e == 1:
+				mono += fmt.Sprintf("*x%d", v)
+			case e > 1:
+				mono += fmt.Sprintf("*x%d^%d", v, e)
+			}
+		}
+		switch {
+		case mono == "":
+			s += t.Coefficient.String()
+		case t.Coefficient.Cmp(big.NewInt(1)) == 0:
+			s += mono[1:]
+		default:
+			s += t.Coefficient.String() + mono
+		}
+	}
+	return s
+}
+
+// checkVars returns an error if p and x differ in their number of variables.
+func (p *Polynomial) checkVars(x *Polynomial) error {
+	if p.vars != x.vars {
+		return fmt.Errorf("polynomials in %d and %d variables", p.vars, x.vars)
+	}
+	return nil
+}
+
+// Add returns p+x, or an error if they differ in their number of variables.
+func (p *Polynomial) Add(x *Polynomial, f *galois.Field) (*Polynomial, error) {
+	if err := p.checkVars(x); err != nil {
+		return nil, err
+	}
+	ts := append(p.Terms(), x.Terms()...)
+	return newPolynomial(p.vars, ts, f), nil
+}
+
+// Sub returns p-x, or an error if they differ in their number of variables.
+func (p *Polynomial) Sub(x *Polynomial, f *galois.Field) (*Polynomial, error) {
+	return p.Add(x.Scale(big.NewInt(-1), f), f)
+}
+
+// Scale returns scalar*p.
+func (p *Polynomial) Scale(scalar *big.Int, f *galois.Field) *Polynomial {
+	ts := p.Terms()
+	for i := range ts {
+		ts[i].Coefficient = f.Mul(ts[i].Coefficient, scalar)
+	}
+	return newPolynomial(p.vars, ts, f)
+}
+
+// Mul returns p*x, or an error if they differ in their number of variables,
+// with a number of field multiplications equal to the product of the numbers
+// of terms.
+func (p *Polynomial) Mul(x *Polynomial, f *galois.Field) (*Polynomial, error) {
+	if err := p.checkVars(x); err != nil {
+		return nil, err
+	}
+	ts := make([]Term, 0, len(p.terms)*len(x.terms))
+	for _, a := range p.terms {
+		for _, b := range x.terms {
+			e := make([]int, p.vars)
+			for i := range e {
+				e[i] = a.Exponents[i] + b.Exponents[i]
+			}
+			ts = append(ts, Term{e, f.Mul(a.Coefficient, b.Coefficient)})
+		}
+	}
+	return newPolynomial(p.vars, ts, f), nil
+}
+
+// Evaluate returns p(xs), or an error if len(xs) isn't the number of
+// variables.
+func (p *Polynomial) Evaluate(xs []*big.Int, f *galois.Field) (*big.Int, error) {
+	if len(xs) != p.vars {
+		return nil, fmt.Errorf("%d values for polynomial in %d variables", len(xs), p.vars)
+	}
+	pows := make([][]*big.Int, p.vars)
+	for i, x := range xs {
+		pows[i] = polynomial.ComputePowers(x, p.DegreeIn(i)+1, f)
+	}
+
+	y := big.NewInt(0)
+	for _, t := range p.terms {
+		m := t.Coefficient
+		for i, e := range t.Exponents {
+			if e > 0 {
+				m = f.Mul(m, pows[i][e])
+			}
+		}
+		y = f.Add(y, m)
+	}
+	return y, nil
+}
+
+// PartialEvaluate returns p with x substituted for the variable x_i, as a
+// polynomial in the remaining variables, which keep their order. It returns
+// an error if i is out of range.
+func (p *Polynomial) PartialEvaluate(i int, x *big.Int, f *galois.Field) (*Polynomial, error) {
+	if i < 0 || i >= p.vars {
+		return nil, fmt.Errorf("variable %d out of range [0, %d)", i, p.vars)
+	}
+	pows := polynomial.ComputePowers(x, p.DegreeIn(i)+1, f)
+
+	ts := make([]Term, len(p.terms))
+	for j, t := range p.terms {
+		e := make([]int, 0, p.vars-1)
+		e = append(append(e, t.Exponents[:i]...), t.Exponents[i+1:]...)
+		ts[j] = Term{e, f.Mul(t.Coefficient, pows[t.Exponents[i]])}
+	}
+	return newPolynomial(p.vars-1, ts, f), nil
+}
@@ -0,0 +1,217 @@
+package multivariate
+
+import (
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+)
+
+var f = galois.NewField(big.NewInt(101))
+
+// poly returns the Polynomial in vars variables with terms given as exponents
+// followed by the coefficient.
+func poly(t *testing.T, vars int, terms ...[]int64) *Polynomial {
+	t.Helper()
+	var ts []Term
+	for _, term := range terms {
+		e := make([]int, vars)
+		for i := range e {
+			e[i] = int(term[i])
+		}
+		ts = append(ts, Term{e, big.NewInt(term[vars])})
+	}
+	p, err := NewPolynomial(vars, ts, f)
+	if err != nil {
+		t.Fatalf("NewPolynomial(): %v", err)
+	}
+	return p
+}
+
+func ints(xs ...int64) []*big.Int {
+	bs := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		bs[i] = big.NewInt(x)
+	}
+	return bs
+}
+
+func TestNewPolynomial(t *testing.T) {
+	tests := []struct {
+		p       *Polynomial
+		want    string
+		degree  int
+		degrees []int
+	}{
+		{p: poly(t, 2), want: "0", degree: 0, degrees: []int{0, 0}},
+		{p: poly(t, 2, []int64{0, 0, 5}), want: "5", degree: 0, degrees: []int{0, 0}},
+		{p: poly(t, 2, []int64{1, 0, 2}, []int64{1, 0, 99}), want: "0", degree: 0, degrees: []int{0, 0}},
+		{p: poly(t, 2, []int64{2, 1, 3}, []int64{0, 1, -1}, []int64{2, 1, 1}), want: "4*x0^2*x1 + 100*x1", degree: 3, degrees: []int{2, 1}},
+		{p: poly(t, 3, []int64{0, 0, 4, 1}, []int64{1, 1, 1, 1}), want: "x0*x1*x2 + x2^4", degree: 4, degrees: []int{1, 1, 4}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.String(); got != tt.want {
+			t.Errorf("String() = %q; want %q", got, tt.want)
+		}
+		if got := tt.p.Degree(); got != tt.degree {
+			t.Errorf("%v.Degree() = %d; want %d", tt.p, got, tt.degree)
+		}
+		got := tt.p.DegreeBounds()
+		for i := range tt.degrees {
+			if got[i] != tt.degrees[i] {
+				t.Errorf("%v.DegreeBounds() = %v; want %v", tt.p, got, tt.degrees)
+				break
+			}
+		}
+	}
+}
+
+func TestNewPolynomialErrors(t *testing.T) {
+	tests := []struct {
+		vars  int
+		terms []Term
+	}{
+		{vars: -1},
+		{vars: 2, terms: []Term{{[]int{1}, big.NewInt(1)}}},
+		{vars: 1, terms: []Term{{[]int{-1}, big.NewInt(1)}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewPolynomial(tt.vars, tt.terms, f); err == nil {
+			t.Errorf("NewPolynomial(%d, %v): got nil error", tt.vars, tt.terms)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	// p = 3*x0^2*x1 + 2*x1*x2 + 7
+	p := poly(t, 3, []int64{2, 1, 0, 3}, []int64{0, 1, 1, 2}, []int64{0, 0, 0, 7})
+
+	tests := []struct {
+		xs   []*big.Int
+		want int64
+	}{
+		{xs: ints(0, 0, 0), want: 7},
+		{xs: ints(1, 1, 1), want: 12},
+		{xs: ints(2, 3, 4), want: (3*4*3 + 2*3*4 + 7) % 101},
+		{xs: ints(100, 5, 100), want: (15 + 10*100 + 7) % 101},
+	}
+
+	for _, tt := range tests {
+		got, err := p.Evaluate(tt.xs, f)
+		if err != nil {
+			t.Fatalf("%v.Evaluate(%v): %v", p, tt.xs, err)
+		}
+		if got.Cmp(big.NewInt(tt.want)) != 0 {
+			t.Errorf("%v.Evaluate(%v) = %v; want %d", p, tt.xs, got, tt.want)
+		}
+	}
+
+	if _, err := p.Evaluate(ints(1, 2), f); err == nil {
+		t.Errorf("%v.Evaluate(<2 values>): got nil error", p)
+	}
+}
+
+func TestPartialEvaluate(t *testing.T) {
+	p := poly(t, 3, []int64{2, 1, 0, 3}, []int64{0, 1, 1, 2}, []int64{1, 0, 3, 5}, []int64{0, 0, 0, 7})
+	xs := ints(6, 17, 42)
+
+	want, err := p.Evaluate(xs, f)
+	if err != nil {
+		t.Fatalf("%v.Evaluate(%v): %v", p, xs, err)
+	}
+	for i := 0; i < 3; i++ {
+		q, err := p.PartialEvaluate(i, xs[i], f)
+		if err != nil {
+			t.Fatalf("%v.PartialEvaluate(%d): %v", p, i, err)
+		}
+		if q.NumVars() != 2 {
+			t.Errorf("%v.PartialEvaluate(%d).NumVars() = %d; want 2", p, i, q.NumVars())
+		}
+		rest := append(append([]*big.Int(nil), xs[:i]...), xs[i+1:]...)
+		if got, err := q.Evaluate(rest, f); err != nil || got.Cmp(want) != 0 {
+			t.Errorf("%v.PartialEvaluate(%d, %v).Evaluate(%v) = %v, %v; want %v", p, i, xs[i], rest, got, err, want)
+		}
+	}
+
+	if _, err := p.PartialEvaluate(3, big.NewInt(1), f); err == nil {
+		t.Errorf("%v.PartialEvaluate(3): got nil error", p)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := poly(t, 2, []int64{1, 0, 1}, []int64{0, 1, 1})
+	b := poly(t, 2, []int64{1, 0, 1}, []int64{0, 1, -1})
+	xs := ints(9, 33)
+
+	ea, _ := a.Evaluate(xs, f)
+	eb, _ := b.Evaluate(xs, f)
+
+	sum, err := a.Add(b, f)
+	if err != nil {
+		t.Fatalf("Add(): %v", err)
+	}
+	if want := poly(t, 2, []int64{1, 0, 2}); !sum.Eq(want) {
+		t.Errorf("%v.Add(%v) = %v; want %v", a, b, sum, want)
+	}
+	diff, err := a.Sub(b, f)
+	if err != nil {
+		t.Fatalf("Sub(): %v", err)
+	}
+	if want := poly(t, 2, []int64{0, 1, 2}); !diff.Eq(want) {
+		t.Errorf("%v.Sub(%v) = %v; want %v", a, b, diff, want)
+	}
+	prod, err := a.Mul(b, f)
+	if err != nil {
+		t.Fatalf("Mul(): %v", err)
+	}
+	if want := poly(t, 2, []int64{2, 0, 1}, []int64{0, 2, -1}); !prod.Eq(want) {
+		t.Errorf("%v.Mul(%v) = %v; want %v", a, b, prod, want)
+	}
+	if got, _ := prod.Evaluate(xs, f); got.Cmp(f.Mul(ea, eb)) != 0 {
+		t.Errorf("%v.Evaluate(%v) = %v; want %v", prod, xs, got, f.Mul(ea, eb))
+	}
+	if !a.IsMultilinear() || prod.IsMultilinear() {
+		t.Errorf("IsMultilinear() = %v, %v; want true, false", a.IsMultilinear(), prod.IsMultilinear())
+	}
+
+	if _, err := a.Add(Variable(3, 0), f); err == nil {
+		t.Errorf("Add(<3 variables>) to 2 variables: got nil error")
+	}
+	if _, err := a.Mul(Variable(3, 0), f); err == nil {
+		t.Errorf("Mul(<3 variables>) with 2 variables: got nil error")
+	}
+}
+
+func TestUnivariate(t *testing.T) {
+	u := polynomial.NewPolynomialFromCoefficients([]int64{4, 0, -1, 3})
+	p := FromUnivariate(u, f)
+	got, err := p.Univariate()
+	if err != nil {
+		t.Fatalf("Univariate(): %v", err)
+	}
+	if want := u.Normalize(f); !got.Eq(want) {
+		t.Errorf("FromUnivariate(%v).Univariate() = %v; want %v", u, got, want)
+	}
+
+	q, err := poly(t, 2, []int64{1, 2, 1}).PartialEvaluate(1, big.NewInt(3), f)
+	if err != nil {
+		t.Fatalf("PartialEvaluate(): %v", err)
+	}
+	got, err = q.Univariate()
+	if err != nil {
+		t.Fatalf("Univariate(): %v", err)
+	}
+	if want := polynomial.NewPolynomialFromCoefficients([]int64{0, 9}); !got.Eq(want) {
+		t.Errorf("(x0*x1^2)(x1 = 3).Univariate() = %v; want %v", got, want)
+	}
+
+	if _, err := Variable(2, 1).Univariate(); err == nil {
+		t.Errorf("Variable(2, 1).Univariate(): got nil error")
+	}
+	if got, _ := Constant(0, big.NewInt(5), f).Evaluate(nil, f); got.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("Constant(0, 5).Evaluate() = %v; want 5", got)
+	}
+}
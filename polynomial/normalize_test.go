@@ -0,0 +1,166 @@
+package polynomial
+
+import (
+	"math/big"
+	mrand "math/rand"
+	"testing"
+
+	"zkp.xyz/membership/galois"
+)
+
+func TestNormalize(t *testing.T) {
+	f := galois.NewField(big.NewInt(7))
+	tests := []struct {
+		p    *Polynomial
+		want []int64
+	}{
+		{p: NewPolynomial(nil), want: []int64{0}},
+		{p: NewPolynomialFromCoefficients([]int64{0, 0, 0}), want: []int64{0}},
+		{p: NewPolynomialFromCoefficients([]int64{1, 2, 0, 0}), want: []int64{1, 2}},
+		{p: NewPolynomialFromCoefficients([]int64{-1, 8, 14}), want: []int64{6, 1}},
+		{p: NewPolynomialFromCoefficients([]int64{3, -7}), want: []int64{3}},
+		{p: NewPolynomial([]*big.Int{nil, big.NewInt(1), nil}), want: []int64{0, 1}},
+		{p: NewPolynomial([]*big.Int{nil}), want: []int64{0}},
+	}
+
+	for _, tt := range tests {
+		got := tt.p.Normalize(f)
+		if want := NewPolynomialFromCoefficients(tt.want); !isNormalized(got, f) || !reflectEq(got, want) {
+			t.Errorf("%v.Normalize() = %v; want %v", tt.p, got, want)
+		}
+		if again := got.Normalize(f); !reflectEq(again, got) {
+			t.Errorf("%v.Normalize().Normalize() = %v; want %v", tt.p, again, got)
+		}
+	}
+}
+
+// reflectEq reports whether p and x have identical coefficients, including
+// their number.
+func reflectEq(p, x *Polynomial) bool {
+	if len(*p) != len(*x) {
+		return false
+	}
+	for i := range *p {
+		if (*p)[i].Cmp((*x)[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isNormalized reports whether p has the form of arithmetic results documented
+// on Polynomial.
+func isNormalized(p *Polynomial, f *galois.Field) bool {
+	if len(*p) == 0 {
+		return false
+	}
+	for _, c := range *p {
+		if c == nil || c.Sign() < 0 || c.Cmp(f.Order()) >= 0 {
+			return false
+		}
+	}
+	return len(*p) == 1 || (*p)[len(*p)-1].Sign() != 0
+}
+
+// unnormalized returns a random polynomial of degree at most maxDegree with
+// coefficients in (-2q, 2q) and up to two trailing coefficients that are
+// multiples of the order q.
+func unnormalized(r *mrand.Rand, maxDegree int, f *galois.Field) *Polynomial {
+	q := f.Order().Int64()
+	p := make(Polynomial, r.Intn(maxDegree+1)+1)
+	for i := range p {
+		p[i] = big.NewInt(r.Int63n(4*q-1) - 2*q + 1)
+	}
+	for i := r.Intn(3); i > 0; i-- {
+		p = append(p, big.NewInt(q*(r.Int63n(5)-2)))
+	}
+	return &p
+}
+
+func TestArithmeticNormalized(t *testing.T) {
+	// 97 - 1 = 3 * 2^5 supports FFTs of size up to 32.
+	f := galois.NewField(big.NewInt(97))
+	r := mrand.New(mrand.NewSource(1))
+
+	binary := map[string]func(a, b *Polynomial) *Polynomial{
+		"Add":         func(a, b *Polynomial) *Polynomial { return a.Add(b, f) },
+		"Sub":         func(a, b *Polynomial) *Polynomial { return a.Sub(b, f) },
+		"Mul":         func(a, b *Polynomial) *Polynomial { return a.Mul(b, f) },
+		"MulLazy":     func(a, b *Polynomial) *Polynomial { return a.MulLazy(b, f) },
+		"mulSerial":   func(a, b *Polynomial) *Polynomial { return a.mulSerial(b, f) },
+		"mulParallel": func(a, b *Polynomial) *Polynomial { return a.mulParallel(b, f) },
+		"mulFFT": func(a, b *Polynomial) *Polynomial {
+			prod, err := a.mulFFT(b, f)
+			if err != nil {
+				t.Fatalf("mulFFT(): %v", err)
+			}
+			return prod
+		},
+		"GCD": func(a, b *Polynomial) *Polynomial { return a.GCD(b, f) },
+	}
+	unary := map[string]func(a *Polynomial) *Polynomial{
+		"Scale(-3)":  func(a *Polynomial) *Polynomial { return a.Scale(big.NewInt(-3), f) },
+		"Scale(97)":  func(a *Polynomial) *Polynomial { return a.Scale(big.NewInt(97), f) },
+		"Derivative": func(a *Polynomial) *Polynomial { return a.Derivative(f) },
+		"Integrate": func(a *Polynomial) *Polynomial {
+			i, err := a.Integrate(f)
+			if err != nil {
+				t.Fatalf("Integrate(): %v", err)
+			}
+			return i
+		},
+		"Monic": func(a *Polynomial) *Polynomial { return a.Monic(f) },
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := unnormalized(r, 12, f), unnormalized(r, 12, f)
+		na, nb := a.Normalize(f), b.Normalize(f)
+		if !isNormalized(na, f) || !reflectEq(na.Normalize(f), na) {
+			t.Fatalf("%v.Normalize() = %v; not normalized or not idempotent", a, na)
+		}
+		if x := big.NewInt(r.Int63n(97)); a.Evaluate(x, f).Cmp(na.Evaluate(x, f)) != 0 {
+			t.Errorf("%v.Evaluate(%v) != %v.Evaluate(%v)", a, x, na, x)
+		}
+
+		for name, op := range binary {
+			got := op(a, b)
+			if !isNormalized(got, f) {
+				t.Errorf("%s(%v, %v) = %v; not normalized", name, a, b, got)
+			}
+			if want := op(na, nb); !reflectEq(got, want) {
+				t.Errorf("%s(%v, %v) = %v; want %v as for normalized inputs", name, a, b, got, want)
+			}
+		}
+		for name, op := range unary {
+			got := op(a)
+			if !isNormalized(got, f) {
+				t.Errorf("%s(%v) = %v; not normalized", name, a, got)
+			}
+			if want := op(na); !reflectEq(got, want) {
+				t.Errorf("%s(%v) = %v; want %v as for normalized inputs", name, a, got, want)
+			}
+		}
+
+		if nb.isZero() {
+			continue
+		}
+		divs := map[string]func(a, b *Polynomial) (*Polynomial, *Polynomial){
+			"Div": func(a, b *Polynomial) (*Polynomial, *Polynomial) { return a.Div(b, f) },
+			"divNewton": func(a, b *Polynomial) (*Polynomial, *Polynomial) {
+				if b = b.Normalize(f); a.Degree() < b.Degree() {
+					return a.Div(b, f)
+				}
+				return a.divNewton(b, f)
+			},
+		}
+		for name, div := range divs {
+			q, rest := div(a, b)
+			if !isNormalized(q, f) || !isNormalized(rest, f) {
+				t.Errorf("%s(%v, %v) = %v, %v; not normalized", name, a, b, q, rest)
+			}
+			if wq, wr := div(na, nb); !reflectEq(q, wq) || !reflectEq(rest, wr) {
+				t.Errorf("%s(%v, %v) = %v, %v; want %v, %v as for normalized inputs", name, a, b, q, rest, wq, wr)
+			}
+		}
+	}
+}
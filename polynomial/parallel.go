@@ -0,0 +1,112 @@
+package polynomial
+
+import (
+	"runtime"
+	"sync"
+)
+
+var (
+	// ParallelMulThreshold is the minimum degree of a product for Mul to be
+	// computed concurrently. Below it, goroutine overhead outweighs the gains.
+	ParallelMulThreshold = 256
+	// ParallelEvaluateThreshold is the minimum number of powers for
+	// EvaluateOnPowers to be computed concurrently.
+	ParallelEvaluateThreshold = 32
+	// MaxWorkers is the maximum number of goroutines used by any concurrent
+	// computation, i.e. Mul, Div, FFT and EvaluateOnPowers. If it is not
+	// positive, runtime.GOMAXPROCS(0) is used. A value of 1 disables
+	// concurrency altogether.
+	MaxWorkers = 0
+)
+
+// maxWorkers returns MaxWorkers, defaulting to GOMAXPROCS.
+func maxWorkers() int {
+	if MaxWorkers > 0 {
+		return MaxWorkers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// parallelize splits [0,n) into contiguous ranges of at least minChunk
+// elements, one per worker, and calls fn concurrently for each. It returns the
+// number of ranges, which is at most maxWorkers().
+func parallelize(n, minChunk int, fn func(worker, lo, hi int)) int {
+	workers := maxWorkers()
+	if max := (n + minChunk - 1) / minChunk; workers > max {
+		workers = max
+	}
+	if workers <= 1 {
+		fn(0, 0, n)
+		return 1
+	}
+
+	var wg sync.WaitGroup
+	chunk := (n + workers - 1) / workers
+	w := 0
+	for lo := 0; lo < n; lo += chunk {
+		hi := lo + chunk
+		if hi > n {
+			hi = n
+		}
+		wg.Add(1)
+		go func(w, lo, hi int) {
+			defer wg.Done()
+			fn(w, lo, hi)
+		}(w, lo, hi)
+		w++
+	}
+	wg.Wait()
+	return w
+}
+
+// mulParallel is equivalent to mulSerial. Instead of partitioning the
+// coefficients of p, which would result in concurrent writes to overlapping
+// coefficients of the product, every worker computes a contiguous range of
+// product coefficients prod[k] = sum_{i+j=k} p[i]*q[j].
+func (p Generic[E]) mulParallel(q Generic[E]) Generic[E] {
+	prod := make(Generic[E], len(p)+len(q)-1)
+
+	parallelize(len(prod), 64, func(_, lo, hi int) {
+		for k := lo; k < hi; k++ {
+			var sum E
+			i := 0
+			if k >= len(q) {
+				i = k - len(q) + 1
+			}
+			for ; i < len(p) && i <= k; i++ {
+				sum = sum.Add(p[i].Mul(q[k-i]))
+			}
+			prod[k] = sum
+		}
+	})
+
+	return prod.normalize()
+}
+
+// evaluateOnPowersParallel is equivalent to EvaluateOnPowersInto, but every
+// worker accumulates a partial sum over a range of powers. As the group is
+// commutative, the order in which partial sums are combined is irrelevant.
+func evaluateOnPowersParallel[G GroupElement[G]](dst, tmp G, p *Polynomial, xPowers []G, newElement func() G) error {
+	if len(*p) > len(xPowers) || len(*p) == 0 {
+		// Defer to the serial implementation for consistent errors.
+		return EvaluateOnPowersInto(dst, tmp, p, xPowers)
+	}
+	xPowers = xPowers[:len(*p)]
+
+	partial := make([]G, maxWorkers())
+	n := parallelize(len(xPowers), 8, func(w, lo, hi int) {
+		acc, scratch := newElement(), newElement()
+		sub := (*p)[lo:hi]
+		if err := EvaluateOnPowersInto(acc, scratch, &sub, xPowers[lo:hi]); err != nil {
+			// Unreachable as lengths are equal and non-zero.
+			panic(err)
+		}
+		partial[w] = acc
+	})
+
+	dst.Set(partial[0])
+	for _, s := range partial[1:n] {
+		dst.Add(dst, s)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package polynomial
+
+import (
+	"crypto/rand"
+	"math/big"
+	"runtime"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/google/go-cmp/cmp"
+	"zkp.xyz/membership/galois"
+)
+
+// withProcs runs fn with GOMAXPROCS set to n, forcing concurrency even on
+// single-core machines.
+func withProcs(n int, fn func()) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(n))
+	fn()
+}
+
+func newG1() *bn256.G1 {
+	return new(bn256.G1)
+}
+
+func TestMulParallel(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	tests := []struct{ d1, d2 int }{
+		{d1: 0, d2: 0},
+		{d1: 1, d2: 0},
+		{d1: 0, d2: 300},
+		{d1: 17, d2: 300},
+		{d1: 300, d2: 301},
+	}
+
+	withProcs(4, func() {
+		for _, tt := range tests {
+			p1, err := Random(tt.d1, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			p2, err := Random(tt.d2, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+
+			want := p1.mulSerial(p2, f)
+			if got := p1.mulParallel(p2, f); !got.Eq(want) {
+				t.Errorf("mulParallel(<degree %d>, <degree %d>) != mulSerial()", tt.d1, tt.d2)
+			}
+			if got := p1.Mul(p2, f); !got.Eq(want) {
+				t.Errorf("Mul(<degree %d>, <degree %d>) != mulSerial()", tt.d1, tt.d2)
+			}
+		}
+	})
+}
+
+func TestMaxWorkers(t *testing.T) {
+	defer func(n int) { MaxWorkers = n }(MaxWorkers)
+
+	withProcs(8, func() {
+		for _, tt := range []struct{ maxWorkers, want int }{
+			{maxWorkers: 0, want: 8},
+			{maxWorkers: -1, want: 8},
+			{maxWorkers: 1, want: 1},
+			{maxWorkers: 3, want: 3},
+			{maxWorkers: 16, want: 16},
+		} {
+			MaxWorkers = tt.maxWorkers
+			if got := parallelize(1000, 1, func(_, _, _ int) {}); got != tt.want {
+				t.Errorf("parallelize() with MaxWorkers = %d and GOMAXPROCS = 8 used %d workers; want %d", tt.maxWorkers, got, tt.want)
+			}
+		}
+	})
+}
+
+func TestFFTParallel(t *testing.T) {
+	defer func(n int) { MaxWorkers = n }(MaxWorkers)
+	f := galois.NewField(bn256.Order)
+
+	d, err := NewDomain(f, 4*fftChunk, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	p, err := Random(int(d.Size())-1, f, rand.Reader)
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+
+	MaxWorkers = 1
+	want := d.FFT(*p)
+	MaxWorkers = 4
+	got := d.FFT(*p)
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b *big.Int) bool { return a.Cmp(b) == 0 })); diff != "" {
+		t.Errorf("FFT() diff (-serial +concurrent):\n%s", diff)
+	}
+	if back := NewPolynomial(d.IFFT(got)); !back.Eq(p) {
+		t.Errorf("IFFT(FFT(p)) != p with concurrency")
+	}
+}
+
+func TestMulTrailingZeros(t *testing.T) {
+	f := galois.NewField(big.NewInt(7))
+	p := NewPolynomialFromCoefficients([]int64{1, 1, 0, 0})
+	want := NewPolynomialFromCoefficients([]int64{1, 2, 1})
+
+	for name, mul := range map[string]func(*Polynomial, *galois.Field) *Polynomial{
+		"mulSerial":   p.mulSerial,
+		"mulParallel": p.mulParallel,
+	} {
+		if got := mul(p, f); !got.Eq(want) {
+			t.Errorf("%s(%v, %v): want %v, got %v", name, p, p, want, got)
+		}
+	}
+}
+
+func TestEvaluateOnPowersParallel(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	withProcs(4, func() {
+		for _, n := range []int{1, 5, 64, 101} {
+			p, err := Random(n-1, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			xPowers := ComputePowers(big.NewInt(1337), n, f)
+			xPowersHidden := make([]*bn256.G1, n)
+			for i, v := range xPowers {
+				xPowersHidden[i] = new(bn256.G1).ScalarBaseMult(v)
+			}
+
+			want := new(bn256.G1)
+			if err := EvaluateOnPowersInto(want, new(bn256.G1), p, xPowersHidden); err != nil {
+				t.Fatalf("EvaluateOnPowersInto(): %v", err)
+			}
+
+			got := new(bn256.G1)
+			if err := evaluateOnPowersParallel(got, new(bn256.G1), p, xPowersHidden, newG1); err != nil {
+				t.Fatalf("evaluateOnPowersParallel(): %v", err)
+			}
+			if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+				t.Errorf("evaluateOnPowersParallel(<%d powers>) != EvaluateOnPowersInto(), diff %v", n, diff)
+			}
+		}
+
+		p := NewPolynomialFromCoefficients([]int64{1, 2})
+		if err := evaluateOnPowersParallel(new(bn256.G1), new(bn256.G1), p, []*bn256.G1{}, newG1); err == nil {
+			t.Errorf("evaluateOnPowersParallel() with len(xPowers) < len(coefficients): got nil error")
+		}
+	})
+}
+
+const benchmarkParallelDegree = 8192
+
+func BenchmarkMulSerial(b *testing.B) {
+	f := galois.NewField(bn256.Order)
+	p, _ := benchmarkPowers(b, benchmarkParallelDegree/2+1)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		p.mulSerial(p, f)
+	}
+}
+
+func BenchmarkMulParallel(b *testing.B) {
+	f := galois.NewField(bn256.Order)
+	p, _ := benchmarkPowers(b, benchmarkParallelDegree/2+1)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		p.mulParallel(p, f)
+	}
+}
+
+func BenchmarkEvaluateOnPowersSerial(b *testing.B) {
+	p, xPowers := benchmarkPowers(b, benchmarkParallelDegree+1)
+	dst, tmp := new(bn256.G1), new(bn256.G1)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := EvaluateOnPowersInto(dst, tmp, p, xPowers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEvaluateOnPowersParallel(b *testing.B) {
+	p, xPowers := benchmarkPowers(b, benchmarkParallelDegree+1)
+	dst, tmp := new(bn256.G1), new(bn256.G1)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := evaluateOnPowersParallel(dst, tmp, p, xPowers, newG1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,19 +1,28 @@
 package polynomial
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
-	"reflect"
 
 	"zkp.xyz/membership/galois"
 )
 
 var (
 	bigZero        = big.NewInt(0)
+	bigMinusOne    = big.NewInt(-1)
 	ZeroPolynomial = NewPolynomialFromCoefficients([]int64{0})
 	OnePolynomial  = NewPolynomialFromCoefficients([]int64{1})
 )
 
+// A Polynomial is stored as its coefficients in ascending order of degree.
+// Results of arithmetic are normalized: every coefficient is reduced into the
+// field, non-nil, and the leading coefficient is non-zero unless the result is
+// the zero polynomial, which has the single coefficient 0. Inputs need not be
+// normalized, but nil coefficients are only accepted by Degree and Normalize.
+// Polynomials over fixed-width galois.Elements are Generic, whose FFT,
+// multiplication, division, multipoint evaluation and interpolation algorithms
+// Polynomial shares.
 type Polynomial []*big.Int
 
 func NewZeroPolynomial(maxDegree int) *Polynomial {
@@ -62,6 +71,10 @@ func (p *Polynomial) Evaluate(x *big.Int, f *galois.Field) *big.Int {
 	return y
 }
 
+// ErrEmptyInput is returned by EvaluateOnPowers if the polynomial has no
+// coefficients, in which case there is no meaningful evaluation.
+var ErrEmptyInput = errors.New("polynomial without coefficients")
+
 type GroupElement[T any] interface {
 	Set(T) T
 	Add(a, b T) T
@@ -69,73 +82,188 @@ type GroupElement[T any] interface {
 	ScalarBaseMult(scalar *big.Int) T
 }
 
-func EvaluateOnPowers[G GroupElement[G]](p *Polynomial, xPowers []G) (G, error) {
-	var y G
+// A PointerGroupElement is a GroupElement G implemented by *E, allowing new
+// elements to be allocated with new(E).
+type PointerGroupElement[E any, G any] interface {
+	*E
+	GroupElement[G]
+}
 
-	if len(*p) != len(xPowers) {
-		return y, fmt.Errorf("len(coefficients) != len(xPowers): %d != %d", len(*p), len(xPowers))
-	}
+// EvaluateOnPowers returns sum_i p[i]*xPowers[i], i.e. the evaluation of p at
+// a hidden x given the hidden powers of x. Only the first len(*p) powers are
+// used, so xPowers may be longer than required, e.g. all powers of an SRS. It
+// returns ErrEmptyInput if p has no coefficients. Evaluations with at least
+// ParallelEvaluateThreshold powers are computed concurrently.
+//
+// The type parameters are inferred from xPowers, e.g. []*bn256.G1. The result
+// and scratch space are allocated with new(E), without reflection; see
+// EvaluateOnPowersFunc for GroupElements whose zero value isn't usable.
+func EvaluateOnPowers[E any, G PointerGroupElement[E, G]](p *Polynomial, xPowers []G) (G, error) {
+	return EvaluateOnPowersFunc(p, xPowers, newPointer[E, G])
+}
 
-	y = reflect.New(reflect.TypeOf(y).Elem()).Interface().(G)
-	y.ScalarBaseMult(bigZero)
+func newPointer[E any, G PointerGroupElement[E, G]]() G {
+	return new(E)
+}
+
+// EvaluateOnPowersFunc is equivalent to EvaluateOnPowers, but allocates the
+// result and scratch space with newElement, which MUST return a distinct
+// element on every call.
+func EvaluateOnPowersFunc[G GroupElement[G]](p *Polynomial, xPowers []G, newElement func() G) (G, error) {
+	var zero G
+
+	y := newElement()
+	tmp := newElement()
+
+	var err error
+	if len(*p) >= ParallelEvaluateThreshold {
+		err = evaluateOnPowersParallel(y, tmp, p, xPowers, newElement)
+	} else {
+		err = EvaluateOnPowersInto(y, tmp, p, xPowers)
+	}
+	if err != nil {
+		return zero, err
+	}
+	return y, nil
+}
 
-	var tmp G
-	tmp = reflect.New(reflect.TypeOf(tmp).Elem()).Interface().(G)
+// EvaluateOnPowersInto is equivalent to EvaluateOnPowers but sets dst to the
+// result instead of allocating it. The tmp element is used as scratch space and
+// MUST NOT alias dst or any of the xPowers.
+func EvaluateOnPowersInto[G GroupElement[G]](dst, tmp G, p *Polynomial, xPowers []G) error {
+	if len(*p) > len(xPowers) {
+		return fmt.Errorf("len(coefficients) > len(xPowers): %d > %d", len(*p), len(xPowers))
+	}
+	if len(*p) == 0 {
+		return ErrEmptyInput
+	}
+	xPowers = xPowers[:len(*p)]
 
+	dst.ScalarBaseMult(bigZero)
 	for i, x := range xPowers {
 		tmp.ScalarMult(x, (*p)[i])
-		y.Add(y, tmp)
+		dst.Add(dst, tmp)
 	}
 
-	return y, nil
+	return nil
 }
 
 func (p *Polynomial) Clone() *Polynomial {
 	clone := *NewZeroPolynomial(p.Degree())
-	for i, c := range *p {
+	for i, c := range (*p)[:len(clone)] {
 		clone[i].Set(c)
 	}
 	return &clone
 }
 
-func (p *Polynomial) Div(divisor *Polynomial, f *galois.Field) (*Polynomial, *Polynomial) {
-	numerator := *p.Clone()
-	quotient := *NewZeroPolynomial(numerator.Degree() - divisor.Degree())
-
-	for numerator.Degree() >= divisor.Degree() {
-		ip := numerator.Degree()
-		id := divisor.Degree()
-		quotient[ip-id] = f.Div(numerator[ip], (*divisor)[id])
-		numerator = *p.Sub(divisor.Mul(&quotient, f), f)
-		if (numerator.Degree() == 0) && (numerator[0].Cmp(bigZero) == 0) {
-			break
+// Normalize returns a copy of p with every coefficient reduced into f, nil
+// coefficients treated as zero, and leading zeros trimmed.
+func (p *Polynomial) Normalize(f *galois.Field) *Polynomial {
+	norm := make(Polynomial, len(*p))
+	for i, c := range *p {
+		if c == nil {
+			norm[i] = big.NewInt(0)
+		} else {
+			norm[i] = f.Mod(new(big.Int).Set(c))
 		}
 	}
+	return norm.trim()
+}
 
-	return &quotient, &numerator
+// trim returns p without leading zero coefficients, reslicing it in place, or
+// the zero polynomial if p has no coefficients.
+func (p *Polynomial) trim() *Polynomial {
+	if len(*p) == 0 {
+		return NewZeroPolynomial(0)
+	}
+	t := (*p)[:p.Degree()+1]
+	return &t
 }
 
+// Degree returns the index of the highest non-zero coefficient of p, treating
+// nil coefficients as zero, or 0 if there is none. Coefficients aren't
+// reduced, so a leading multiple of the order counts as non-zero; see
+// Normalize.
 func (p *Polynomial) Degree() int {
 	for d := len(*p) - 1; d >= 1; d-- {
-		if (*p)[d].Cmp(bigZero) != 0 {
+		if c := (*p)[d]; c != nil && c.Sign() != 0 {
 			return d
 		}
 	}
 	return 0
 }
 
+// Mul returns p*m, with the strategies of Generic.Mul: if both factors have
+// degree at least FFTMulThreshold and f supports it, the product is computed
+// with the FFT. Otherwise every multiply-add is reduced into f, keeping
+// intermediate values at most twice the size of the order; see MulLazy for an
+// alternative. Such products of degree at least ParallelMulThreshold are
+// computed concurrently.
 func (p *Polynomial) Mul(m *Polynomial, f *galois.Field) *Polynomial {
+	return fromGeneric(toGeneric(p, f).Mul(toGeneric(m, f)), f)
+}
+
+// MulLazy is equivalent to Mul, but accumulates unreduced products and only
+// reduces each coefficient of the product once. This saves a reduction and
+// allocation per multiply-add at the cost of intermediate values growing by
+// log2(degree) bits beyond twice the size of the order. MulLazy is never
+// computed concurrently.
+func (p *Polynomial) MulLazy(m *Polynomial, f *galois.Field) *Polynomial {
 	prod := *NewZeroPolynomial(p.Degree() + m.Degree())
-	for i, a := range *p {
-		for j, b := range *m {
-			prod[i+j] = f.Add(prod[i+j], f.Mul(a, b))
+	ab := new(big.Int)
+	for i, a := range (*p)[:p.Degree()+1] {
+		for j, b := range (*m)[:m.Degree()+1] {
+			prod[i+j].Add(prod[i+j], ab.Mul(a, b))
 		}
 	}
-	return &prod
+	for _, c := range prod {
+		f.Mod(c)
+	}
+	return prod.trim()
+}
+
+// Derivative returns the formal derivative of p.
+func (p *Polynomial) Derivative(f *galois.Field) *Polynomial {
+	d := p.Degree()
+	if d == 0 {
+		return NewZeroPolynomial(0)
+	}
+
+	deriv := make(Polynomial, d)
+	for i := range deriv {
+		deriv[i] = f.Mul((*p)[i+1], big.NewInt(int64(i+1)))
+	}
+	return deriv.trim()
+}
+
+// Integrate returns the formal antiderivative of p with a zero constant term,
+// i.e. the unique polynomial P with P(0) = 0 and P' = p. Integrating v^i
+// requires the inverse of i+1, so an error is returned for fields of prime
+// order q if the degree of p is at least q-1.
+func (p *Polynomial) Integrate(f *galois.Field) (*Polynomial, error) {
+	d := p.Degree()
+	nums, dens := make([]*big.Int, d+1), make([]*big.Int, d+1)
+	for i := range nums {
+		nums[i], dens[i] = (*p)[i], big.NewInt(int64(i+1))
+	}
+	cs, err := f.DivSlice(nums, dens)
+	if err != nil {
+		return nil, fmt.Errorf("integrating polynomial of degree %d: %v", d, err)
+	}
+	return NewPolynomial(append([]*big.Int{big.NewInt(0)}, cs...)).trim(), nil
+}
+
+// Scale returns scalar*p.
+func (p *Polynomial) Scale(scalar *big.Int, f *galois.Field) *Polynomial {
+	scaled := make(Polynomial, p.Degree()+1)
+	for i := range scaled {
+		scaled[i] = f.Mul((*p)[i], scalar)
+	}
+	return scaled.trim()
 }
 
 func (p *Polynomial) Sub(x *Polynomial, f *galois.Field) *Polynomial {
-	return p.Add(x.Mul(NewPolynomialFromCoefficients([]int64{-1}), f), f)
+	return p.Add(x.Scale(bigMinusOne, f), f)
 }
 
 func (p *Polynomial) Add(x *Polynomial, f *galois.Field) *Polynomial {
@@ -146,15 +274,15 @@ func (p *Polynomial) Add(x *Polynomial, f *galois.Field) *Polynomial {
 		result = *NewZeroPolynomial(x.Degree())
 	}
 
-	for i, v := range *p {
+	for i, v := range (*p)[:p.Degree()+1] {
 		result[i] = f.Add(result[i], v)
 	}
 
-	for i, v := range *x {
+	for i, v := range (*x)[:x.Degree()+1] {
 		result[i] = f.Add(result[i], v)
 	}
 
-	return &result
+	return result.trim()
 }
 
 func (p *Polynomial) Eq(x *Polynomial) bool {
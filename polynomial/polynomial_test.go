@@ -1,8 +1,11 @@
 package polynomial
 
 import (
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
 	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
@@ -141,6 +144,13 @@ func TestDiv(t *testing.T) {
 			wantQuotient: []int64{1},
 			wantRest:     []int64{0},
 		},
+		{
+			c1:           []int64{3},
+			c2:           []int64{1, 1},
+			f:            galois.NewField(big.NewInt(7)),
+			wantQuotient: []int64{0},
+			wantRest:     []int64{3},
+		},
 	}
 
 	for _, tt := range tests {
@@ -241,3 +251,347 @@ func TestEvaluateOnPowers(t *testing.T) {
 		}
 	}
 }
+
+func TestEvaluateOnPowersEmpty(t *testing.T) {
+	p := NewPolynomial([]*big.Int{})
+	if _, err := EvaluateOnPowers(p, []*bn256.G1{new(bn256.G1)}); !errors.Is(err, ErrEmptyInput) {
+		t.Errorf("EvaluateOnPowers(<empty>, <1 power>): got err %v, want %v", err, ErrEmptyInput)
+	}
+}
+
+func TestEvaluateOnPowersInto(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	p := NewPolynomialFromCoefficients([]int64{6, -5, 1, 42})
+	x := big.NewInt(1337)
+
+	xPowers := ComputePowers(x, len(*p), f)
+	xPowersHidden := make([]*bn256.G1, len(xPowers))
+	for i, v := range xPowers {
+		xPowersHidden[i] = new(bn256.G1).ScalarBaseMult(v)
+	}
+
+	want, err := EvaluateOnPowers(p, xPowersHidden)
+	if err != nil {
+		t.Fatalf("EvaluateOnPowers(p, xPowersHidden): %v", err)
+	}
+
+	// Reuse of a previously populated dst must not affect the result.
+	dst := new(bn256.G1).ScalarBaseMult(big.NewInt(7))
+	if err := EvaluateOnPowersInto(dst, new(bn256.G1), p, xPowersHidden); err != nil {
+		t.Fatalf("EvaluateOnPowersInto(dst, tmp, p, xPowersHidden): %v", err)
+	}
+	if diff := cmp.Diff(want.String(), dst.String()); diff != "" {
+		t.Errorf("EvaluateOnPowersInto() != EvaluateOnPowers(), diff %v", diff)
+	}
+
+	if err := EvaluateOnPowersInto(dst, new(bn256.G1), p, xPowersHidden[1:]); err == nil {
+		t.Errorf("EvaluateOnPowersInto() with len(xPowers) < len(coefficients): got nil error")
+	}
+}
+
+func benchmarkPowers(b *testing.B, n int) (*Polynomial, []*bn256.G1) {
+	f := galois.NewField(bn256.Order)
+	p := NewZeroPolynomial(n - 1)
+	for i := range *p {
+		c, err := f.Random(rand.Reader)
+		if err != nil {
+			b.Fatalf("Random(): %v", err)
+		}
+		(*p)[i] = c
+	}
+
+	xPowers := ComputePowers(big.NewInt(1337), n, f)
+	xPowersHidden := make([]*bn256.G1, n)
+	for i, v := range xPowers {
+		xPowersHidden[i] = new(bn256.G1).ScalarBaseMult(v)
+	}
+	return p, xPowersHidden
+}
+
+func BenchmarkEvaluateOnPowers(b *testing.B) {
+	p, xPowers := benchmarkPowers(b, 257)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := EvaluateOnPowers(p, xPowers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEvaluateOnPowersFunc(t *testing.T) {
+	f := galois.NewField(big.NewInt(101))
+	p := NewPolynomialFromCoefficients([]int64{3, 0, 1, 7})
+	x := big.NewInt(5)
+
+	var xPowers []*galois.FieldElement
+	for _, y := range ComputePowers(x, 4, f) {
+		xPowers = append(xPowers, galois.NewFieldElement(y, f))
+	}
+
+	var allocated int
+	got, err := EvaluateOnPowersFunc(p, xPowers, func() *galois.FieldElement {
+		allocated++
+		return &galois.FieldElement{Field: f}
+	})
+	if err != nil {
+		t.Fatalf("EvaluateOnPowersFunc(): %v", err)
+	}
+	if want := p.Evaluate(x, f); got.Int().Cmp(want) != 0 {
+		t.Errorf("EvaluateOnPowersFunc() = %v; want %v", got.Int(), want)
+	}
+	if got.Field != f {
+		t.Errorf("EvaluateOnPowersFunc().Field = %v; want element allocated by factory", got.Field)
+	}
+	if allocated == 0 {
+		t.Errorf("EvaluateOnPowersFunc() didn't call newElement")
+	}
+}
+
+// reflectNew allocates a G as EvaluateOnPowers did before it was constrained to
+// pointer types.
+func reflectNew[G any]() G {
+	var zero G
+	return reflect.New(reflect.TypeOf(zero).Elem()).Interface().(G)
+}
+
+// BenchmarkEvaluateOnPowersAllocation evaluates in the plain field, where
+// group operations are cheap enough for the cost of allocating elements to be
+// measurable.
+func BenchmarkEvaluateOnPowersAllocation(b *testing.B) {
+	f := galois.NewField(bn256.Order)
+	p := NewPolynomialFromCoefficients([]int64{1, 2, 3, 4})
+	var xPowers []*galois.FieldElement
+	for _, y := range ComputePowers(big.NewInt(1337), len(*p), f) {
+		xPowers = append(xPowers, galois.NewFieldElement(y, f))
+	}
+
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := EvaluateOnPowers(p, xPowers); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := EvaluateOnPowersFunc(p, xPowers, reflectNew[*galois.FieldElement]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkEvaluateOnPowersInto(b *testing.B) {
+	p, xPowers := benchmarkPowers(b, 257)
+	dst, tmp := new(bn256.G1), new(bn256.G1)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := EvaluateOnPowersInto(dst, tmp, p, xPowers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDerivative(t *testing.T) {
+	tests := []struct {
+		c    []int64
+		f    *galois.Field
+		want []int64
+	}{
+		{
+			c:    []int64{42},
+			f:    galois.NewField(big.NewInt(100)),
+			want: []int64{0},
+		},
+		{
+			c:    []int64{1, 2, 3, 4},
+			f:    galois.NewField(big.NewInt(100)),
+			want: []int64{2, 6, 12},
+		},
+		{
+			c:    []int64{1, 2, 3, 4},
+			f:    galois.NewField(big.NewInt(7)),
+			want: []int64{2, 6, 5},
+		},
+		{
+			// d/dv v^3 = 3v^2 = 0 in characteristic 3.
+			c:    []int64{1, 1, 0, 1},
+			f:    galois.NewField(big.NewInt(3)),
+			want: []int64{1},
+		},
+	}
+
+	for _, tt := range tests {
+		got := NewPolynomialFromCoefficients(tt.c).Derivative(tt.f)
+		if want := NewPolynomialFromCoefficients(tt.want); !got.Eq(want) {
+			t.Errorf("Derivative(%v) mod %v: want %v, got %v", tt.c, tt.f.Order(), want, got)
+		}
+	}
+}
+
+func TestIntegrate(t *testing.T) {
+	tests := []struct {
+		c    []int64
+		f    *galois.Field
+		want []int64
+	}{
+		{
+			c:    []int64{0},
+			f:    galois.NewField(big.NewInt(101)),
+			want: []int64{0},
+		},
+		{
+			c:    []int64{42},
+			f:    galois.NewField(big.NewInt(101)),
+			want: []int64{0, 42},
+		},
+		{
+			c:    []int64{2, 6, 12},
+			f:    galois.NewField(big.NewInt(101)),
+			want: []int64{0, 2, 3, 4},
+		},
+		{
+			// 1/2 = 4 and 1/3 = 5 mod 7.
+			c:    []int64{1, 1, 1},
+			f:    galois.NewField(big.NewInt(7)),
+			want: []int64{0, 1, 4, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		p := NewPolynomialFromCoefficients(tt.c)
+		got, err := p.Integrate(tt.f)
+		if err != nil {
+			t.Fatalf("Integrate(%v) mod %v: %v", tt.c, tt.f.Order(), err)
+		}
+		if want := NewPolynomialFromCoefficients(tt.want); !got.Eq(want) {
+			t.Errorf("Integrate(%v) mod %v: want %v, got %v", tt.c, tt.f.Order(), want, got)
+		}
+		if d := got.Derivative(tt.f); !d.Eq(p) {
+			t.Errorf("Derivative(Integrate(%v)) mod %v: want %v, got %v", tt.c, tt.f.Order(), p, d)
+		}
+	}
+
+	// Integrating v^2 requires 1/3, which doesn't exist mod 3.
+	if _, err := NewPolynomialFromCoefficients([]int64{1, 1, 1}).Integrate(galois.NewField(big.NewInt(3))); err == nil {
+		t.Errorf("Integrate(<degree 2>) mod 3: got nil error")
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		c      []int64
+		scalar int64
+		f      *galois.Field
+		want   []int64
+	}{
+		{
+			c:      []int64{1, 2, 3},
+			scalar: 2,
+			f:      galois.NewField(big.NewInt(100)),
+			want:   []int64{2, 4, 6},
+		},
+		{
+			c:      []int64{1, 2, 3},
+			scalar: 2,
+			f:      galois.NewField(big.NewInt(5)),
+			want:   []int64{2, 4, 1},
+		},
+		{
+			c:      []int64{1, 2, 3},
+			scalar: 0,
+			f:      galois.NewField(big.NewInt(5)),
+			want:   []int64{0},
+		},
+		{
+			c:      []int64{1, 2, 3},
+			scalar: -1,
+			f:      galois.NewField(big.NewInt(10)),
+			want:   []int64{9, 8, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		got := NewPolynomialFromCoefficients(tt.c).Scale(big.NewInt(tt.scalar), tt.f)
+		if want := NewPolynomialFromCoefficients(tt.want); !got.Eq(want) {
+			t.Errorf("%d * %v mod %v: want %v, got %v", tt.scalar, tt.c, tt.f.Order(), want, got)
+		}
+	}
+}
+
+func TestEvaluateOnPowersPrefix(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	p := NewPolynomialFromCoefficients([]int64{6, -5, 1})
+	x := big.NewInt(1337)
+
+	xPowers := ComputePowers(x, 10, f)
+	xPowersHidden := make([]*bn256.G1, len(xPowers))
+	for i, v := range xPowers {
+		xPowersHidden[i] = new(bn256.G1).ScalarBaseMult(v)
+	}
+
+	want := new(bn256.G1).ScalarBaseMult(p.Evaluate(x, f))
+	got, err := EvaluateOnPowers(p, xPowersHidden)
+	if err != nil {
+		t.Fatalf("EvaluateOnPowers(<3 coefficients>, <10 powers>): %v", err)
+	}
+	if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+		t.Errorf("EvaluateOnPowers(<3 coefficients>, <10 powers>) != Hide(p.Evaluate(x)), diff %v", diff)
+	}
+
+	if _, err := EvaluateOnPowers(p, xPowersHidden[:2]); err == nil {
+		t.Errorf("EvaluateOnPowers(<3 coefficients>, <2 powers>): got nil error")
+	}
+}
+
+func TestMulLazy(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	for _, d := range []struct{ d1, d2 int }{{0, 0}, {1, 0}, {5, 17}, {64, 64}} {
+		t.Run(fmt.Sprintf("deg %d * deg %d", d.d1, d.d2), func(t *testing.T) {
+			p1, err := Random(d.d1, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			p2, err := Random(d.d2, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Random(): %v", err)
+			}
+			// Unreduced coefficients.
+			(*p1)[0] = new(big.Int).Neg((*p1)[0])
+			(*p2)[0] = new(big.Int).Add((*p2)[0], bn256.Order)
+
+			if got, want := p1.MulLazy(p2, f), p1.Mul(p2, f); !got.Eq(want) {
+				t.Errorf("MulLazy() = %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func benchmarkMul(b *testing.B, mul func(p, m *Polynomial, f *galois.Field) *Polynomial) {
+	f := galois.NewField(bn256.Order)
+	p, err := Random(128, f, rand.Reader)
+	if err != nil {
+		b.Fatalf("Random(): %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		mul(p, p, f)
+	}
+}
+
+func BenchmarkMulReducing(b *testing.B) {
+	benchmarkMul(b, (*Polynomial).mulSerial)
+}
+
+func BenchmarkMulLazy(b *testing.B) {
+	benchmarkMul(b, (*Polynomial).MulLazy)
+}
@@ -0,0 +1,37 @@
+package polynomial
+
+import (
+	"fmt"
+	"io"
+
+	"zkp.xyz/membership/galois"
+)
+
+// Random returns a polynomial of exactly the specified degree with coefficients
+// drawn uniformly from f, except for the leading coefficient, which is drawn
+// uniformly from the non-zero elements. The Reader is propagated to
+// f.Random().
+func Random(degree int, f *galois.Field, r io.Reader) (*Polynomial, error) {
+	if degree < 0 {
+		return nil, fmt.Errorf("negative degree %d", degree)
+	}
+
+	p := make(Polynomial, degree+1)
+	for i := range p {
+		c, err := f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		p[i] = c
+	}
+
+	for degree > 0 && p[degree].Sign() == 0 {
+		c, err := f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		p[degree] = c
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,72 @@
+package polynomial
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+func TestRandom(t *testing.T) {
+	tests := []struct {
+		degree int
+		f      *galois.Field
+	}{
+		{degree: 0, f: galois.NewField(bn256.Order)},
+		{degree: 1, f: galois.NewField(bn256.Order)},
+		{degree: 50, f: galois.NewField(bn256.Order)},
+		// Leading coefficients are zero with probability 1/2 on each draw.
+		{degree: 10, f: galois.NewField(big.NewInt(2))},
+	}
+
+	for _, tt := range tests {
+		for seed := int64(0); seed < 10; seed++ {
+			p, err := Random(tt.degree, tt.f, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatalf("Random(%d, %v, <seed %d>): %v", tt.degree, tt.f.Order(), seed, err)
+			}
+			if got := p.Degree(); got != tt.degree {
+				t.Errorf("Random(%d, %v, <seed %d>).Degree() = %d", tt.degree, tt.f.Order(), seed, got)
+			}
+		}
+	}
+}
+
+func TestRandomIndependentReaders(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	p1, err := Random(5, f, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+	p2, err := Random(5, f, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatalf("Random(): %v", err)
+	}
+
+	if p1.Eq(p2) {
+		t.Errorf("Random() with independent readers returned identical polynomials %v", p1)
+	}
+}
+
+type errReader struct{}
+
+var errRead = errors.New("read error")
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
+
+func TestRandomErrors(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	if _, err := Random(3, f, errReader{}); err == nil {
+		t.Errorf("Random(<failing reader>): got nil error")
+	}
+	if _, err := Random(-1, f, rand.New(rand.NewSource(0))); err == nil {
+		t.Errorf("Random(<negative degree>): got nil error")
+	}
+}
@@ -0,0 +1,151 @@
+package polynomial
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"zkp.xyz/membership/galois"
+)
+
+// A Term is the monomial Coefficient*v^Degree.
+type Term struct {
+	Degree      int
+	Coefficient *big.Int
+}
+
+// A SparsePolynomial is a polynomial stored as its terms with non-zero
+// coefficients, sorted by ascending degree, requiring space proportional to
+// the number of such terms instead of to the degree. The zero polynomial has
+// no terms.
+type SparsePolynomial []Term
+
+// NewSparsePolynomial returns the sum of the terms, which may be in any order
+// and repeat degrees. Degrees MUST be non-negative.
+func NewSparsePolynomial(terms []Term) *SparsePolynomial {
+	sorted := make([]Term, len(terms))
+	copy(sorted, terms)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Degree < sorted[j].Degree })
+
+	var s SparsePolynomial
+	for _, t := range sorted {
+		if n := len(s); n > 0 && s[n-1].Degree == t.Degree {
+			s[n-1].Coefficient.Add(s[n-1].Coefficient, t.Coefficient)
+			continue
+		}
+		s = append(s, Term{t.Degree, new(big.Int).Set(t.Coefficient)})
+	}
+	return s.compact()
+}
+
+// compact removes terms with zero coefficients in place.
+func (s SparsePolynomial) compact() *SparsePolynomial {
+	out := s[:0]
+	for _, t := range s {
+		if t.Coefficient.Sign() != 0 {
+			out = append(out, t)
+		}
+	}
+	return &out
+}
+
+// Sparse returns p as a SparsePolynomial.
+func (p *Polynomial) Sparse() *SparsePolynomial {
+	var s SparsePolynomial
+	for i, c := range *p {
+		if c.Sign() != 0 {
+			s = append(s, Term{i, new(big.Int).Set(c)})
+		}
+	}
+	return &s
+}
+
+// Dense returns s as a Polynomial.
+func (s *SparsePolynomial) Dense() *Polynomial {
+	p := NewZeroPolynomial(s.Degree())
+	for _, t := range *s {
+		(*p)[t.Degree].Set(t.Coefficient)
+	}
+	return p
+}
+
+// Degree returns the degree of s, which is 0 for the zero polynomial.
+func (s *SparsePolynomial) Degree() int {
+	if len(*s) == 0 {
+		return 0
+	}
+	return (*s)[len(*s)-1].Degree
+}
+
+// Add returns s+x, reduced into f. Neither s nor x is modified, and the terms
+// of the sum don't share coefficients with either.
+func (s *SparsePolynomial) Add(x *SparsePolynomial, f *galois.Field) *SparsePolynomial {
+	sum := make(SparsePolynomial, 0, len(*s)+len(*x))
+	a, b := *s, *x
+	for len(a) > 0 || len(b) > 0 {
+		switch {
+		case len(b) == 0 || (len(a) > 0 && a[0].Degree < b[0].Degree):
+			sum = append(sum, Term{a[0].Degree, new(big.Int).Mod(a[0].Coefficient, f.Order())})
+			a = a[1:]
+		case len(a) == 0 || b[0].Degree < a[0].Degree:
+			sum = append(sum, Term{b[0].Degree, new(big.Int).Mod(b[0].Coefficient, f.Order())})
+			b = b[1:]
+		default:
+			sum = append(sum, Term{a[0].Degree, f.Add(a[0].Coefficient, b[0].Coefficient)})
+			a, b = a[1:], b[1:]
+		}
+	}
+	return sum.compact()
+}
+
+// Mul returns s*x, reduced into f, with a number of field multiplications equal
+// to the product of the numbers of terms.
+func (s *SparsePolynomial) Mul(x *SparsePolynomial, f *galois.Field) *SparsePolynomial {
+	coeffs := make(map[int]*big.Int)
+	for _, a := range *s {
+		for _, b := range *x {
+			d := a.Degree + b.Degree
+			if c, ok := coeffs[d]; ok {
+				coeffs[d] = f.Add(c, f.Mul(a.Coefficient, b.Coefficient))
+			} else {
+				coeffs[d] = f.Mul(a.Coefficient, b.Coefficient)
+			}
+		}
+	}
+
+	prod := make(SparsePolynomial, 0, len(coeffs))
+	for d, c := range coeffs {
+		prod = append(prod, Term{d, c})
+	}
+	sort.Slice(prod, func(i, j int) bool { return prod[i].Degree < prod[j].Degree })
+	return prod.compact()
+}
+
+// Evaluate returns s(x), with O(log(Degree())) field multiplications per term.
+func (s *SparsePolynomial) Evaluate(x *big.Int, f *galois.Field) *big.Int {
+	y, pow, prev := big.NewInt(0), big.NewInt(1), 0
+	for _, t := range *s {
+		pow = f.Mul(pow, f.Exp(x, big.NewInt(int64(t.Degree-prev))))
+		prev = t.Degree
+		y = f.Add(y, f.Mul(t.Coefficient, pow))
+	}
+	return y
+}
+
+// EvaluateSparseOnPowers is the SparsePolynomial equivalent of
+// EvaluateOnPowers, returning sum_t t.Coefficient*xPowers[t.Degree] and thus
+// skipping the scalar multiplications of all zero coefficients. The zero
+// polynomial evaluates to the identity.
+func EvaluateSparseOnPowers[E any, G PointerGroupElement[E, G]](s *SparsePolynomial, xPowers []G) (G, error) {
+	if d := s.Degree(); len(*s) > 0 && d >= len(xPowers) {
+		return nil, fmt.Errorf("degree %d >= len(xPowers) %d", d, len(xPowers))
+	}
+
+	y, tmp := G(new(E)), G(new(E))
+	y.ScalarBaseMult(bigZero)
+	for _, t := range *s {
+		tmp.ScalarMult(xPowers[t.Degree], t.Coefficient)
+		y.Add(y, tmp)
+	}
+	return y, nil
+}
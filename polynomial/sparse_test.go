@@ -0,0 +1,129 @@
+package polynomial
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/google/go-cmp/cmp"
+	"zkp.xyz/membership/galois"
+)
+
+// sparse returns the SparsePolynomial with the degree-coefficient pairs.
+func sparse(terms ...int64) *SparsePolynomial {
+	var ts []Term
+	for i := 0; i < len(terms); i += 2 {
+		ts = append(ts, Term{int(terms[i]), big.NewInt(terms[i+1])})
+	}
+	return NewSparsePolynomial(ts)
+}
+
+func TestNewSparsePolynomial(t *testing.T) {
+	tests := []struct {
+		terms []int64
+		want  []int64
+	}{
+		{terms: nil, want: []int64{0}},
+		{terms: []int64{0, 5}, want: []int64{5}},
+		{terms: []int64{3, 1, 0, 2}, want: []int64{2, 0, 0, 1}},
+		{terms: []int64{2, 1, 2, 4, 1, 0}, want: []int64{0, 0, 5}},
+		{terms: []int64{4, 1, 4, -1}, want: []int64{0}},
+	}
+
+	for _, tt := range tests {
+		s := sparse(tt.terms...)
+		for _, term := range *s {
+			if term.Coefficient.Sign() == 0 {
+				t.Errorf("NewSparsePolynomial(%v) has zero term of degree %d", tt.terms, term.Degree)
+			}
+		}
+		want := NewPolynomialFromCoefficients(tt.want)
+		if got := s.Dense(); !got.Eq(want) {
+			t.Errorf("NewSparsePolynomial(%v).Dense() = %v; want %v", tt.terms, got, want)
+		}
+		if got := want.Sparse().Dense(); !got.Eq(want) {
+			t.Errorf("%v.Sparse().Dense() = %v; want %v", want, got, want)
+		}
+		if got, want := s.Degree(), want.Degree(); got != want {
+			t.Errorf("NewSparsePolynomial(%v).Degree() = %d; want %d", tt.terms, got, want)
+		}
+	}
+}
+
+func TestSparseArithmetic(t *testing.T) {
+	f := galois.NewField(big.NewInt(101))
+
+	tests := []struct {
+		a, b *SparsePolynomial
+	}{
+		{a: sparse(), b: sparse(3, 1)},
+		{a: sparse(0, 1, 100, 1), b: sparse(0, -1, 100, 1)},
+		{a: sparse(1, 7, 5, 3), b: sparse(5, 98, 2, 4)},
+		{a: sparse(1000, 1), b: sparse(0, 100, 1, 2)},
+	}
+
+	for _, tt := range tests {
+		da, db := tt.a.Dense(), tt.b.Dense()
+		if got, want := tt.a.Add(tt.b, f).Dense(), da.Add(db, f); !got.Eq(want) {
+			t.Errorf("%v.Add(%v) = %v; want %v", tt.a, tt.b, got, want)
+		}
+		if got, want := tt.a.Mul(tt.b, f).Dense(), da.Mul(db, f); !got.Eq(want) {
+			t.Errorf("%v.Mul(%v) = %v; want %v", tt.a, tt.b, got, want)
+		}
+		for _, x := range []int64{0, 1, 2, 57} {
+			if got, want := tt.a.Evaluate(big.NewInt(x), f), da.Evaluate(big.NewInt(x), f); got.Cmp(want) != 0 {
+				t.Errorf("%v.Evaluate(%d) = %v; want %v", tt.a, x, got, want)
+			}
+		}
+	}
+}
+
+func TestSparseAddPreservesOperands(t *testing.T) {
+	f := galois.NewField(big.NewInt(101))
+	a, b := sparse(0, -1, 2, 205, 7, 3), sparse(1, 300, 7, -4)
+	wantA, wantB := fmt.Sprint(a), fmt.Sprint(b)
+
+	sum := a.Add(b, f)
+	if got := fmt.Sprint(a); got != wantA {
+		t.Errorf("Add() modified receiver to %v; want %v", got, wantA)
+	}
+	if got := fmt.Sprint(b); got != wantB {
+		t.Errorf("Add() modified argument to %v; want %v", got, wantB)
+	}
+
+	for _, term := range *sum {
+		term.Coefficient.SetInt64(42)
+	}
+	if got := fmt.Sprint(a); got != wantA {
+		t.Errorf("Add() result shares coefficients with receiver, modified to %v; want %v", got, wantA)
+	}
+	if got := fmt.Sprint(b); got != wantB {
+		t.Errorf("Add() result shares coefficients with argument, modified to %v; want %v", got, wantB)
+	}
+}
+
+func TestEvaluateSparseOnPowers(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	x := big.NewInt(1337)
+	xPowers := ComputePowers(x, 64, f)
+	xPowersHidden := make([]*bn256.G1, len(xPowers))
+	for i, v := range xPowers {
+		xPowersHidden[i] = new(bn256.G1).ScalarBaseMult(v)
+	}
+
+	for _, s := range []*SparsePolynomial{sparse(), sparse(0, 3), sparse(1, 2, 40, 5, 63, 7)} {
+		got, err := EvaluateSparseOnPowers(s, xPowersHidden)
+		if err != nil {
+			t.Fatalf("EvaluateSparseOnPowers(%v): %v", s, err)
+		}
+		want := new(bn256.G1).ScalarBaseMult(s.Evaluate(x, f))
+		if diff := cmp.Diff(want.String(), got.String()); diff != "" {
+			t.Errorf("EvaluateSparseOnPowers(%v) != Hide(Evaluate(x)), diff %v", s, diff)
+		}
+	}
+
+	if _, err := EvaluateSparseOnPowers(sparse(64, 1), xPowersHidden); err == nil {
+		t.Errorf("EvaluateSparseOnPowers(<degree 64>) with 64 powers: got nil error")
+	}
+}
@@ -0,0 +1,72 @@
+package polynomial
+
+import (
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// BuildVanishingStreaming returns the vanishing polynomial prod_i (v - roots_i)
+// of all roots received until the channel is closed. An empty channel results
+// in the constant polynomial 1. Upon receiving a nil root, no further roots are
+// consumed and an error is returned.
+//
+// The roots are never held in their entirety. Instead, partial products are
+// merged as soon as they cover the same number of roots, like carries in a
+// binary counter. At most log2(n)+1 partial products are therefore pending at
+// any time, their total size is bounded by that of the final result, and
+// multiplications are balanced.
+func BuildVanishingStreaming(roots <-chan *big.Int, f *galois.Field) (*Polynomial, error) {
+	acc := newVanishingAccumulator(f)
+	for z := range roots {
+		if z == nil {
+			return nil, fmt.Errorf("nil root after %d roots", acc.n)
+		}
+		acc.push(z)
+	}
+	return acc.result(), nil
+}
+
+// FromRoots returns the vanishing polynomial prod_i (v - roots_i), or the
+// constant polynomial 1 if there are no roots, as a balanced product tree as
+// GenericFromRoots does.
+func FromRoots(roots []*big.Int, f *galois.Field) *Polynomial {
+	return fromGeneric(GenericFromRoots(elements(roots, f)), f)
+}
+
+// A vanishingAccumulator holds partial vanishing polynomials in decreasing
+// order of the number of roots they cover, each a power of two.
+type vanishingAccumulator struct {
+	f       *galois.Field
+	n       int
+	pending []*Polynomial
+	counts  []int
+}
+
+func newVanishingAccumulator(f *galois.Field) *vanishingAccumulator {
+	return &vanishingAccumulator{f: f}
+}
+
+func (a *vanishingAccumulator) push(z *big.Int) {
+	a.n++
+	p := NewPolynomial([]*big.Int{a.f.Sub(bigZero, z), big.NewInt(1)})
+	count := 1
+
+	for last := len(a.pending) - 1; last >= 0 && a.counts[last] == count; last-- {
+		p = a.pending[last].Mul(p, a.f)
+		count *= 2
+		a.pending, a.counts = a.pending[:last], a.counts[:last]
+	}
+	a.pending = append(a.pending, p)
+	a.counts = append(a.counts, count)
+}
+
+func (a *vanishingAccumulator) result() *Polynomial {
+	p := NewPolynomialFromCoefficients([]int64{1})
+	// Multiplying smallest first keeps intermediate products balanced.
+	for i := len(a.pending) - 1; i >= 0; i-- {
+		p = a.pending[i].Mul(p, a.f)
+	}
+	return p
+}
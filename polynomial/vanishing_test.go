@@ -0,0 +1,153 @@
+package polynomial
+
+import (
+	"math/big"
+	"math/bits"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+func streamRoots(roots []int64) <-chan *big.Int {
+	c := make(chan *big.Int)
+	go func() {
+		for _, z := range roots {
+			c <- big.NewInt(z)
+		}
+		close(c)
+	}()
+	return c
+}
+
+func TestBuildVanishingStreaming(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	tests := [][]int64{
+		{},
+		{1},
+		{1, 2},
+		{1, 2, 3},
+		{-5, 0, 7, 11, 13, 42, 1337},
+	}
+
+	var many []int64
+	for i := int64(0); i < 100; i++ {
+		many = append(many, i*i-50)
+	}
+	tests = append(tests, many)
+
+	for _, roots := range tests {
+		got, err := BuildVanishingStreaming(streamRoots(roots), f)
+		if err != nil {
+			t.Fatalf("BuildVanishingStreaming(%v): %v", roots, err)
+		}
+
+		want := OnePolynomial
+		for _, z := range roots {
+			want = want.Mul(NewPolynomialFromCoefficients([]int64{-z, 1}), f)
+		}
+
+		if !got.Eq(want) {
+			t.Errorf("BuildVanishingStreaming(%v): want %v, got %v", roots, want, got)
+		}
+		for _, z := range roots {
+			if y := got.Evaluate(big.NewInt(z), f); y.Sign() != 0 {
+				t.Errorf("BuildVanishingStreaming(%v) evaluated at root %d = %v; want 0", roots, z, y)
+			}
+		}
+	}
+}
+
+func TestBuildVanishingStreamingFresh(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+	got, err := BuildVanishingStreaming(streamRoots(nil), f)
+	if err != nil {
+		t.Fatalf("BuildVanishingStreaming(<no roots>): %v", err)
+	}
+	(*got)[0].SetInt64(2)
+	if want := NewPolynomialFromCoefficients([]int64{1}); !OnePolynomial.Eq(want) {
+		t.Errorf("OnePolynomial = %v after modifying BuildVanishingStreaming(<no roots>); want %v", OnePolynomial, want)
+	}
+}
+
+func TestVanishingAccumulatorBounded(t *testing.T) {
+	f := galois.NewField(big.NewInt(65537))
+	acc := newVanishingAccumulator(f)
+
+	const n = 2000
+	for i := 1; i <= n; i++ {
+		acc.push(big.NewInt(int64(i)))
+
+		if max := bits.Len(uint(i)); len(acc.pending) > max {
+			t.Fatalf("%d pending partial products after %d roots; want <= %d", len(acc.pending), i, max)
+		}
+		size := 0
+		for _, p := range acc.pending {
+			size += len(*p)
+		}
+		// Each partial product covering k roots has k+1 coefficients.
+		if max := i + len(acc.pending); size > max {
+			t.Fatalf("%d pending coefficients after %d roots; want <= %d", size, i, max)
+		}
+	}
+}
+
+func TestBuildVanishingStreamingNilRoot(t *testing.T) {
+	c := make(chan *big.Int, 2)
+	c <- big.NewInt(1)
+	c <- nil
+	close(c)
+
+	if _, err := BuildVanishingStreaming(c, galois.NewField(bn256.Order)); err == nil {
+		t.Errorf("BuildVanishingStreaming(<nil root>): got nil error")
+	}
+}
+
+func TestFromRoots(t *testing.T) {
+	f := galois.NewField(bn256.Order)
+
+	for _, n := range []int{0, 1, 2, 3, 7, 200} {
+		var roots []int64
+		for i := 0; i < n; i++ {
+			roots = append(roots, int64(i*i-50))
+		}
+
+		want := OnePolynomial
+		for _, z := range roots {
+			want = want.Mul(NewPolynomialFromCoefficients([]int64{-z, 1}), f)
+		}
+
+		if got := FromRoots(bigInts(roots), f); !got.Eq(want) {
+			t.Errorf("FromRoots(%v): want %v, got %v", roots, want, got)
+		}
+	}
+
+	got := FromRoots(nil, f)
+	(*got)[0].SetInt64(2)
+	if want := NewPolynomialFromCoefficients([]int64{1}); !OnePolynomial.Eq(want) {
+		t.Errorf("OnePolynomial = %v after modifying FromRoots(nil); want %v", OnePolynomial, want)
+	}
+}
+
+func BenchmarkFromRoots(b *testing.B) {
+	f := galois.NewField(bn256.Order)
+	roots := make([]*big.Int, 2048)
+	for i := range roots {
+		roots[i] = big.NewInt(int64(i))
+	}
+
+	b.Run("product tree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FromRoots(roots, f)
+		}
+	})
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p := OnePolynomial
+			for _, z := range roots {
+				p = p.Mul(NewPolynomial([]*big.Int{f.Sub(bigZero, z), big.NewInt(1)}), f)
+			}
+		}
+	})
+}
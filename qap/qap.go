@@ -0,0 +1,188 @@
+// Package qap implements quadratic arithmetic programs (Gennaro, Gentry,
+// Parno and Raykova, "Quadratic Span Programs and Succinct NIZKs without
+// PCPs"), the polynomial form of an r1cs.System proven by Groth16.
+//
+// The constraints are indexed by the elements w^i of a Domain of size n, and
+// each variable j of the System by the polynomials A_j, B_j and C_j of degree
+// less than n that interpolate column j of the constraint matrices. A witness
+// w satisfies the System iff
+//
+//	(sum_j w_j A_j) * (sum_j w_j B_j) - (sum_j w_j C_j) = H * Z
+//
+// for some polynomial H, where Z = X^n - 1 vanishes on the Domain.
+package qap
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/r1cs"
+)
+
+// ErrNotDivisible is returned for witnesses whose polynomial isn't divisible
+// by the vanishing polynomial of the Domain.
+var ErrNotDivisible = errors.New("not divisible by the vanishing polynomial")
+
+// A QAP is the quadratic arithmetic program of an r1cs.System.
+type QAP struct {
+	system *r1cs.System
+	domain *polynomial.Domain
+	// A, B and C hold the polynomials of each variable.
+	A, B, C []*polynomial.Polynomial
+	// Z is the vanishing polynomial X^n - 1 of the Domain.
+	Z *polynomial.Polynomial
+}
+
+// FromR1CS returns the QAP of the System over the smallest Domain of at least
+// s.NumConstraints() elements, as returned by polynomial.CanonicalDomain.
+func FromR1CS(s *r1cs.System) (*QAP, error) {
+	n := uint64(1)
+	for n < uint64(s.NumConstraints()) {
+		n *= 2
+	}
+	d, err := polynomial.CanonicalDomain(s.Field(), n)
+	if err != nil {
+		return nil, fmt.Errorf("domain for %d constraints: %v", s.NumConstraints(), err)
+	}
+
+	q := &QAP{system: s, domain: d}
+	cols := columns(s, n)
+	for k, dst := range []*[]*polynomial.Polynomial{&q.A, &q.B, &q.C} {
+		*dst = make([]*polynomial.Polynomial, len(cols[k]))
+		for j, col := range cols[k] {
+			(*dst)[j] = polynomial.NewPolynomial(d.IFFT(col)).Normalize(s.Field())
+		}
+	}
+
+	q.Z = d.Vanishing()
+	return q, nil
+}
+
+// columns returns the columns of the matrices A, B and C of the System,
+// padded with zeros to n rows.
+func columns(s *r1cs.System, n uint64) [3][][]*big.Int {
+	var cols [3][][]*big.Int
+	for k := range cols {
+		cols[k] = make([][]*big.Int, s.NumVariables())
+		for j := range cols[k] {
+			cols[k][j] = zeros(n)
+		}
+	}
+	for i, c := range s.Constraints() {
+		for k, row := range [3]r1cs.Row{c.A, c.B, c.C} {
+			for _, e := range row {
+				cols[k][e.Index][i].Set(e.Value)
+			}
+		}
+	}
+	return cols
+}
+
+// zeros returns n zeros.
+func zeros(n uint64) []*big.Int {
+	xs := make([]*big.Int, n)
+	for i := range xs {
+		xs[i] = big.NewInt(0)
+	}
+	return xs
+}
+
+// System returns the r1cs.System of the QAP.
+func (q *QAP) System() *r1cs.System {
+	return q.system
+}
+
+// Domain returns the Domain indexing the constraints.
+func (q *QAP) Domain() *polynomial.Domain {
+	return q.domain
+}
+
+// Field returns the field of the QAP.
+func (q *QAP) Field() *galois.Field {
+	return q.system.Field()
+}
+
+// Combine returns the polynomials sum_j w_j A_j, sum_j w_j B_j and
+// sum_j w_j C_j of the witness, computed from the evaluations of the
+// constraints rather than the polynomials of each variable.
+func (q *QAP) Combine(w []*big.Int) (a, b, c *polynomial.Polynomial, err error) {
+	if len(w) != q.system.NumVariables() {
+		return nil, nil, nil, fmt.Errorf("witness of %d values; want %d", len(w), q.system.NumVariables())
+	}
+	f := q.Field()
+	reduced := make([]*big.Int, len(w))
+	for j, x := range w {
+		reduced[j] = new(big.Int).Mod(x, f.Order())
+	}
+	var evals [3][]*big.Int
+	for k := range evals {
+		evals[k] = zeros(q.domain.Size())
+	}
+	for i, con := range q.system.Constraints() {
+		evals[0][i], evals[1][i], evals[2][i] = con.A.Eval(reduced, f), con.B.Eval(reduced, f), con.C.Eval(reduced, f)
+	}
+	a = polynomial.NewPolynomial(q.domain.IFFT(evals[0])).Normalize(f)
+	b = polynomial.NewPolynomial(q.domain.IFFT(evals[1])).Normalize(f)
+	c = polynomial.NewPolynomial(q.domain.IFFT(evals[2])).Normalize(f)
+	return a, b, c, nil
+}
+
+// Quotient returns the polynomial H of the witness, of degree at most n-2, or
+// ErrNotDivisible if the witness doesn't satisfy the QAP.
+func (q *QAP) Quotient(w []*big.Int) (*polynomial.Polynomial, error) {
+	a, b, c, err := q.Combine(w)
+	if err != nil {
+		return nil, err
+	}
+	f := q.Field()
+	h, rem := a.Mul(b, f).Sub(c, f).Div(q.Z, f)
+	if !rem.Eq(polynomial.ZeroPolynomial) {
+		return nil, ErrNotDivisible
+	}
+	return h, nil
+}
+
+// IsSatisfied returns nil if the witness satisfies the QAP, i.e. if its
+// polynomial is divisible by Z, or ErrNotDivisible.
+func (q *QAP) IsSatisfied(w []*big.Int) error {
+	_, err := q.Quotient(w)
+	return err
+}
+
+// EvaluateAt returns the evaluations of A_j, B_j and C_j at x for each
+// variable j, as required for a trusted setup, without evaluating the
+// polynomials of each variable: A_j(x) = sum_i A[i][j] L_i(x) for the
+// Lagrange basis polynomials L_i of the Domain, with
+// L_i(x) = w^i (x^n - 1) / (n (x - w^i)). x MUST NOT be in the Domain.
+func (q *QAP) EvaluateAt(x *big.Int) (a, b, c []*big.Int, err error) {
+	f := q.Field()
+	n := q.domain.Size()
+	nums, dens := make([]*big.Int, n), make([]*big.Int, n)
+	zx := f.Sub(f.Exp(x, new(big.Int).SetUint64(n)), big.NewInt(1))
+	for i := range nums {
+		wi := q.domain.Element(uint64(i))
+		nums[i] = f.Mul(wi, zx)
+		dens[i] = f.Mul(new(big.Int).SetUint64(n), f.Sub(f.Mod(new(big.Int).Set(x)), wi))
+	}
+	ls, err := f.DivSlice(nums, dens)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("x in the domain: %v", err)
+	}
+
+	m := q.system.NumVariables()
+	var out [3][]*big.Int
+	for k := range out {
+		out[k] = zeros(uint64(m))
+	}
+	for i, con := range q.system.Constraints() {
+		for k, row := range [3]r1cs.Row{con.A, con.B, con.C} {
+			for _, e := range row {
+				out[k][e.Index] = f.Add(out[k][e.Index], f.Mul(e.Value, ls[i]))
+			}
+		}
+	}
+	return out[0], out[1], out[2], nil
+}
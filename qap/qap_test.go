@@ -0,0 +1,144 @@
+package qap
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/r1cs"
+)
+
+var f = galois.NewField(bn256.Order)
+
+// cubic returns the QAP of out = x^3 + x + 5, of 3 constraints over a Domain
+// of size 4, and the witness for x = 3.
+func cubic(t *testing.T) (*QAP, []*big.Int) {
+	t.Helper()
+	b := r1cs.NewBuilder(f)
+	out := b.Public("out")
+	x := b.Secret("x")
+	x2 := b.Mul(x.LC(), x.LC())
+	x3 := b.Mul(x2.LC(), x.LC())
+	b.AssertEqual(x3.LC().Add(x.LC()).Add(r1cs.Constant(big.NewInt(5))), out.LC())
+	s, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error %v", err)
+	}
+	w, err := s.Solve(map[string]*big.Int{"out": big.NewInt(35), "x": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Solve() error %v", err)
+	}
+	q, err := FromR1CS(s)
+	if err != nil {
+		t.Fatalf("FromR1CS() error %v", err)
+	}
+	return q, w
+}
+
+func TestFromR1CS(t *testing.T) {
+	q, _ := cubic(t)
+	if got, want := q.Domain().Size(), uint64(4); got != want {
+		t.Fatalf("Domain().Size() = %d; want %d", got, want)
+	}
+	if got, want := q.Z.Degree(), 4; got != want {
+		t.Errorf("Z.Degree() = %d; want %d", got, want)
+	}
+
+	// The polynomials of each variable interpolate the columns of the
+	// matrices, and vanish on the padding.
+	s := q.System()
+	for i := uint64(0); i < q.Domain().Size(); i++ {
+		wi := q.Domain().Element(i)
+		want := make([][3]*big.Int, s.NumVariables())
+		for j := range want {
+			want[j] = [3]*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+		}
+		if i < uint64(s.NumConstraints()) {
+			c := s.Constraints()[i]
+			for k, row := range [3]r1cs.Row{c.A, c.B, c.C} {
+				for _, e := range row {
+					want[e.Index][k] = e.Value
+				}
+			}
+		}
+		for j := range want {
+			for k, p := range [3]*big.Int{q.A[j].Evaluate(wi, f), q.B[j].Evaluate(wi, f), q.C[j].Evaluate(wi, f)} {
+				if p.Cmp(want[j][k]) != 0 {
+					t.Errorf("%c_%d(w^%d) = %v; want %v", "ABC"[k], j, i, p, want[j][k])
+				}
+			}
+		}
+	}
+}
+
+func TestQuotient(t *testing.T) {
+	q, w := cubic(t)
+	h, err := q.Quotient(w)
+	if err != nil {
+		t.Fatalf("Quotient() error %v", err)
+	}
+	if got, max := h.Degree(), int(q.Domain().Size())-2; got > max {
+		t.Errorf("Quotient().Degree() = %d; want at most %d", got, max)
+	}
+	a, b, c, err := q.Combine(w)
+	if err != nil {
+		t.Fatalf("Combine() error %v", err)
+	}
+	if lhs, rhs := a.Mul(b, f).Sub(c, f), h.Mul(q.Z, f); !lhs.Eq(rhs) {
+		t.Errorf("A*B - C = %v; want H*Z = %v", lhs, rhs)
+	}
+	if err := q.IsSatisfied(w); err != nil {
+		t.Errorf("IsSatisfied() = %v; want nil", err)
+	}
+
+	tests := []struct {
+		name string
+		j    int
+		x    int64
+	}{
+		{"wrong output", 1, 36},
+		{"wrong input", 2, 4},
+		{"wrong intermediate", 3, 10},
+		{"wrong one", 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bad := append([]*big.Int(nil), w...)
+			bad[tt.j] = big.NewInt(tt.x)
+			if _, err := q.Quotient(bad); !errors.Is(err, ErrNotDivisible) {
+				t.Errorf("Quotient() error %v; want %v", err, ErrNotDivisible)
+			}
+		})
+	}
+
+	if _, err := q.Quotient(w[1:]); err == nil || errors.Is(err, ErrNotDivisible) {
+		t.Errorf("Quotient(short witness) error %v; want length error", err)
+	}
+}
+
+func TestEvaluateAt(t *testing.T) {
+	q, _ := cubic(t)
+	x, err := f.Random(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	as, bs, cs, err := q.EvaluateAt(x)
+	if err != nil {
+		t.Fatalf("EvaluateAt() error %v", err)
+	}
+	for j := range q.A {
+		for k, got := range [3]*big.Int{as[j], bs[j], cs[j]} {
+			want := [3]*big.Int{q.A[j].Evaluate(x, f), q.B[j].Evaluate(x, f), q.C[j].Evaluate(x, f)}[k]
+			if got.Cmp(want) != 0 {
+				t.Errorf("EvaluateAt()[%c][%d] = %v; want %v", "ABC"[k], j, got, want)
+			}
+		}
+	}
+
+	if _, _, _, err := q.EvaluateAt(q.Domain().Element(1)); err == nil {
+		t.Error("EvaluateAt(w) error nil; want error")
+	}
+}
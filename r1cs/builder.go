@@ -0,0 +1,190 @@
+package r1cs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"zkp.xyz/membership/galois"
+)
+
+// A HintFunc computes the value of an intermediate variable from the values
+// of the LinearCombinations it was declared with.
+type HintFunc func(f *galois.Field, inputs []*big.Int) (*big.Int, error)
+
+// A variable is a variable declared with a Builder.
+type variable struct {
+	public bool
+	name   string
+}
+
+// A builderHint is a hint in terms of the Builder's variables.
+type builderHint struct {
+	v      Variable
+	fn     HintFunc
+	inputs []LinearCombination
+}
+
+// A Builder declares the variables and constraints of a System.
+type Builder struct {
+	f           *galois.Field
+	vars        []variable
+	names       map[string]bool
+	constraints [][3]LinearCombination
+	hints       []builderHint
+	err         error
+}
+
+// NewBuilder returns a Builder of Systems over the field.
+func NewBuilder(f *galois.Field) *Builder {
+	return &Builder{f: f, vars: []variable{{}}, names: make(map[string]bool)}
+}
+
+func (b *Builder) newVariable(public bool, name string) Variable {
+	if name != "" {
+		if b.names[name] && b.err == nil {
+			b.err = fmt.Errorf("duplicate input %q", name)
+		}
+		b.names[name] = true
+	}
+	b.vars = append(b.vars, variable{public: public, name: name})
+	return Variable{len(b.vars) - 1}
+}
+
+// Public declares a public input, assigned by name on Solve.
+func (b *Builder) Public(name string) Variable {
+	return b.newVariable(true, name)
+}
+
+// Secret declares a secret input, assigned by name on Solve.
+func (b *Builder) Secret(name string) Variable {
+	return b.newVariable(false, name)
+}
+
+// Hint declares a secret intermediate variable, computed on Solve by fn from
+// the values of the inputs. The Hint doesn't constrain the variable; that is
+// up to the caller.
+func (b *Builder) Hint(fn HintFunc, inputs ...LinearCombination) Variable {
+	v := b.newVariable(false, "")
+	b.hints = append(b.hints, builderHint{v: v, fn: fn, inputs: inputs})
+	return v
+}
+
+// Constrain adds the constraint x * y = z.
+func (b *Builder) Constrain(x, y, z LinearCombination) {
+	b.constraints = append(b.constraints, [3]LinearCombination{x, y, z})
+}
+
+// Mul returns a variable constrained to x * y.
+func (b *Builder) Mul(x, y LinearCombination) Variable {
+	v := b.Hint(func(f *galois.Field, in []*big.Int) (*big.Int, error) {
+		return f.Mul(in[0], in[1]), nil
+	}, x, y)
+	b.Constrain(x, y, v.LC())
+	return v
+}
+
+// Inverse returns a variable constrained to 1/x, with which Solve fails for
+// x = 0.
+func (b *Builder) Inverse(x LinearCombination) Variable {
+	v := b.Hint(func(f *galois.Field, in []*big.Int) (*big.Int, error) {
+		return f.MultInverse(in[0])
+	}, x)
+	b.Constrain(x, v.LC(), One.LC())
+	return v
+}
+
+// AssertEqual adds the constraint x = y.
+func (b *Builder) AssertEqual(x, y LinearCombination) {
+	b.Constrain(x, One.LC(), y)
+}
+
+// AssertBoolean adds the constraint that x is 0 or 1.
+func (b *Builder) AssertBoolean(x LinearCombination) {
+	b.Constrain(x, x, x)
+}
+
+// Build returns the System of the declared variables and constraints, with
+// the witness layout (One, public inputs in order of declaration, secret
+// variables in order of declaration).
+func (b *Builder) Build() (*System, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	s := &System{f: b.f, inputs: make(map[string]int)}
+	index := make([]int, len(b.vars))
+	for i, v := range b.vars[1:] {
+		if v.public {
+			s.public++
+			index[i+1] = s.public
+		}
+	}
+	for i, v := range b.vars[1:] {
+		if !v.public {
+			s.secret++
+			index[i+1] = s.public + s.secret
+		}
+	}
+	s.names = make([]string, s.NumVariables())
+	for i, v := range b.vars {
+		s.names[index[i]] = v.name
+		if v.name != "" {
+			s.inputs[v.name] = index[i]
+		}
+	}
+
+	row := func(lc LinearCombination) (Row, error) {
+		coeffs := make(map[int]*big.Int)
+		for _, t := range lc {
+			if t.Variable.id < 0 || t.Variable.id >= len(b.vars) {
+				return nil, errors.New("variable of another Builder")
+			}
+			i := index[t.Variable.id]
+			if c, ok := coeffs[i]; ok {
+				coeffs[i] = c.Add(c, t.Coefficient)
+			} else {
+				coeffs[i] = new(big.Int).Set(t.Coefficient)
+			}
+		}
+		var r Row
+		for i, c := range coeffs {
+			if c.Mod(c, b.f.Order()).Sign() != 0 {
+				r = append(r, Entry{Index: i, Value: c})
+			}
+		}
+		sort.Slice(r, func(i, j int) bool { return r[i].Index < r[j].Index })
+		return r, nil
+	}
+
+	for i, c := range b.constraints {
+		var rows [3]Row
+		for j, lc := range c {
+			r, err := row(lc)
+			if err != nil {
+				return nil, fmt.Errorf("constraint %d: %v", i, err)
+			}
+			rows[j] = r
+		}
+		s.constraints = append(s.constraints, Constraint{A: rows[0], B: rows[1], C: rows[2]})
+	}
+	for _, h := range b.hints {
+		inputs := make([]Row, len(h.inputs))
+		for j, lc := range h.inputs {
+			r, err := row(lc)
+			if err != nil {
+				return nil, fmt.Errorf("hint of variable %d: %v", index[h.v.id], err)
+			}
+			inputs[j] = r
+		}
+		fn, f := h.fn, b.f
+		s.hints = append(s.hints, hint{index: index[h.v.id], solve: func(w []*big.Int) (*big.Int, error) {
+			xs := make([]*big.Int, len(inputs))
+			for j, r := range inputs {
+				xs[j] = r.Eval(w, f)
+			}
+			return fn(f, xs)
+		}})
+	}
+	return s, nil
+}
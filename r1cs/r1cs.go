@@ -0,0 +1,216 @@
+// Package r1cs implements rank-1 constraint systems over a galois.Field: sets
+// of constraints <A_i, w> * <B_i, w> = <C_i, w> on a witness vector w, the
+// arithmetization underlying QAP-based SNARKs such as Groth16.
+//
+// Systems are built with a Builder, which declares variables and constraints
+// and records how intermediate variables are computed, so that a System can
+// Solve for the full witness from its inputs. The witness vector is laid out
+// as w = (1, public inputs..., secret variables...).
+package r1cs
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+)
+
+// ErrUnsatisfied is returned for witnesses that violate a constraint.
+var ErrUnsatisfied = errors.New("constraint not satisfied")
+
+var bigOne = big.NewInt(1)
+
+// A Variable is a variable of a System under construction by a Builder.
+type Variable struct {
+	id int
+}
+
+// One is the constant Variable 1, at index 0 of every witness.
+var One = Variable{0}
+
+// A Term is a Variable scaled by a Coefficient.
+type Term struct {
+	Variable    Variable
+	Coefficient *big.Int
+}
+
+// A LinearCombination is a sum of Terms.
+type LinearCombination []Term
+
+// LC returns the LinearCombination 1*v.
+func (v Variable) LC() LinearCombination {
+	return LinearCombination{{Variable: v, Coefficient: bigOne}}
+}
+
+// Constant returns the LinearCombination c*One.
+func Constant(c *big.Int) LinearCombination {
+	return LinearCombination{{Variable: One, Coefficient: c}}
+}
+
+// Add returns lc + other.
+func (lc LinearCombination) Add(other LinearCombination) LinearCombination {
+	return append(append(LinearCombination(nil), lc...), other...)
+}
+
+// Sub returns lc - other.
+func (lc LinearCombination) Sub(other LinearCombination) LinearCombination {
+	return lc.Add(other.Scale(big.NewInt(-1)))
+}
+
+// Scale returns k*lc.
+func (lc LinearCombination) Scale(k *big.Int) LinearCombination {
+	out := make(LinearCombination, len(lc))
+	for i, t := range lc {
+		out[i] = Term{Variable: t.Variable, Coefficient: new(big.Int).Mul(t.Coefficient, k)}
+	}
+	return out
+}
+
+// An Entry is a non-zero Value at the Index of a sparse row.
+type Entry struct {
+	Index int
+	Value *big.Int
+}
+
+// A Row is a sparse vector over the witness, sorted by Index.
+type Row []Entry
+
+// Eval returns <r, w>.
+func (r Row) Eval(w []*big.Int, f *galois.Field) *big.Int {
+	sum := new(big.Int)
+	for _, e := range r {
+		sum = f.Add(sum, f.Mul(e.Value, w[e.Index]))
+	}
+	return sum
+}
+
+// A Constraint is <A, w> * <B, w> = <C, w>.
+type Constraint struct {
+	A, B, C Row
+}
+
+// A hint computes the value of a secret variable from the witness so far.
+type hint struct {
+	index int
+	solve func(w []*big.Int) (*big.Int, error)
+}
+
+// A System is a rank-1 constraint system over a field.
+type System struct {
+	f           *galois.Field
+	public      int
+	secret      int
+	constraints []Constraint
+	// names holds the names of the inputs by index, and inputs their indices
+	// by name.
+	names  []string
+	inputs map[string]int
+	hints  []hint
+}
+
+// Field returns the field of the System.
+func (s *System) Field() *galois.Field {
+	return s.f
+}
+
+// NumVariables returns the length of witnesses, including One.
+func (s *System) NumVariables() int {
+	return 1 + s.public + s.secret
+}
+
+// NumPublic returns the number of public inputs, at indices [1, NumPublic].
+func (s *System) NumPublic() int {
+	return s.public
+}
+
+// NumConstraints returns the number of Constraints.
+func (s *System) NumConstraints() int {
+	return len(s.constraints)
+}
+
+// Constraints returns the Constraints of the System, which MUST NOT be
+// modified.
+func (s *System) Constraints() []Constraint {
+	return s.constraints
+}
+
+// Name returns the name of the variable at index i of the witness, or "" for
+// One and intermediate variables.
+func (s *System) Name(i int) string {
+	return s.names[i]
+}
+
+// IsSatisfied returns nil if the witness satisfies all Constraints, or an
+// error wrapping ErrUnsatisfied for the first that it violates.
+func (s *System) IsSatisfied(w []*big.Int) error {
+	if len(w) != s.NumVariables() {
+		return fmt.Errorf("witness of %d values; want %d", len(w), s.NumVariables())
+	}
+	if w[0] == nil || w[0].Cmp(bigOne) != 0 {
+		return fmt.Errorf("witness[0] = %v; want 1", w[0])
+	}
+	for i, x := range w {
+		if x == nil || x.Sign() < 0 || x.Cmp(s.f.Order()) >= 0 {
+			return fmt.Errorf("witness[%d] not in the field", i)
+		}
+	}
+	for i, c := range s.constraints {
+		if s.f.Mul(c.A.Eval(w, s.f), c.B.Eval(w, s.f)).Cmp(c.C.Eval(w, s.f)) != 0 {
+			return fmt.Errorf("constraint %d: %w", i, ErrUnsatisfied)
+		}
+	}
+	return nil
+}
+
+// Solve returns the witness for the named inputs, computing all intermediate
+// variables, and checks that it satisfies the System. All inputs must be
+// assigned; values are reduced into the field.
+func (s *System) Solve(inputs map[string]*big.Int) ([]*big.Int, error) {
+	w := make([]*big.Int, s.NumVariables())
+	w[0] = big.NewInt(1)
+	for name, i := range s.inputs {
+		x, ok := inputs[name]
+		if !ok {
+			return nil, fmt.Errorf("input %q not assigned", name)
+		}
+		w[i] = new(big.Int).Mod(x, s.f.Order())
+	}
+	if len(inputs) != len(s.inputs) {
+		for name := range inputs {
+			if _, ok := s.inputs[name]; !ok {
+				return nil, fmt.Errorf("unknown input %q", name)
+			}
+		}
+	}
+	for _, h := range s.hints {
+		x, err := h.solve(w)
+		if err != nil {
+			return nil, fmt.Errorf("solving variable %d: %v", h.index, err)
+		}
+		w[h.index] = new(big.Int).Mod(x, s.f.Order())
+	}
+	if err := s.IsSatisfied(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// WithInputConstraints returns a copy of the System with an additional
+// constraint w_i * 0 = 0 for One and each public input i. They hold for every
+// witness, but make the QAP polynomials of the public inputs linearly
+// independent, as required for the soundness of Groth16; snarkjs appends the
+// same constraints.
+func (s *System) WithInputConstraints() *System {
+	t := *s
+	t.constraints = append([]Constraint(nil), s.constraints...)
+	for i := 0; i <= s.public; i++ {
+		t.constraints = append(t.constraints, Constraint{A: Row{{Index: i, Value: big.NewInt(1)}}})
+	}
+	return &t
+}
+
+// PublicInputs returns the public inputs of the witness, w[1:NumPublic+1].
+func (s *System) PublicInputs(w []*big.Int) []*big.Int {
+	return w[1 : 1+s.public : 1+s.public]
+}
@@ -0,0 +1,188 @@
+package r1cs
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+var f = galois.NewField(bn256.Order)
+
+// cubic returns the System of out = x^3 + x + 5.
+func cubic(t *testing.T) *System {
+	t.Helper()
+	b := NewBuilder(f)
+	out := b.Public("out")
+	x := b.Secret("x")
+	x2 := b.Mul(x.LC(), x.LC())
+	x3 := b.Mul(x2.LC(), x.LC())
+	b.AssertEqual(x3.LC().Add(x.LC()).Add(Constant(big.NewInt(5))), out.LC())
+	s, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error %v", err)
+	}
+	return s
+}
+
+func TestSolve(t *testing.T) {
+	s := cubic(t)
+	if got, want := s.NumVariables(), 5; got != want {
+		t.Errorf("NumVariables() = %d; want %d", got, want)
+	}
+	if got, want := s.NumPublic(), 1; got != want {
+		t.Errorf("NumPublic() = %d; want %d", got, want)
+	}
+	if got, want := s.NumConstraints(), 3; got != want {
+		t.Errorf("NumConstraints() = %d; want %d", got, want)
+	}
+	for i, want := range []string{"", "out", "x", "", ""} {
+		if got := s.Name(i); got != want {
+			t.Errorf("Name(%d) = %q; want %q", i, got, want)
+		}
+	}
+
+	w, err := s.Solve(map[string]*big.Int{"out": big.NewInt(35), "x": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Solve() error %v", err)
+	}
+	for i, want := range []int64{1, 35, 3, 9, 27} {
+		if w[i].Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("Solve()[%d] = %v; want %d", i, w[i], want)
+		}
+	}
+	if got := s.PublicInputs(w); len(got) != 1 || got[0].Int64() != 35 {
+		t.Errorf("PublicInputs() = %v; want [35]", got)
+	}
+	if err := s.IsSatisfied(w); err != nil {
+		t.Errorf("IsSatisfied(Solve()) = %v; want nil", err)
+	}
+}
+
+func TestIsSatisfied(t *testing.T) {
+	s := cubic(t)
+	ints := func(xs ...int64) []*big.Int {
+		w := make([]*big.Int, len(xs))
+		for i, x := range xs {
+			w[i] = big.NewInt(x)
+		}
+		return w
+	}
+
+	tests := []struct {
+		name            string
+		w               []*big.Int
+		wantErr         bool
+		wantUnsatisfied bool
+	}{
+		{"valid", ints(1, 35, 3, 9, 27), false, false},
+		{"wrong output", ints(1, 36, 3, 9, 27), true, true},
+		{"wrong square", ints(1, 35, 3, 10, 27), true, true},
+		{"wrong one", ints(2, 35, 3, 9, 27), true, false},
+		{"short", ints(1, 35, 3, 9), true, false},
+		{"unreduced", append(ints(1, 35, 3, 9), new(big.Int).Add(bn256.Order, big.NewInt(27))), true, false},
+		{"negative", ints(1, 35, 3, 9, -27), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.IsSatisfied(tt.w)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("IsSatisfied() error %v; want error %t", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrUnsatisfied); got != tt.wantUnsatisfied {
+				t.Errorf("errors.Is(IsSatisfied(), ErrUnsatisfied) = %t; want %t", got, tt.wantUnsatisfied)
+			}
+		})
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	b := NewBuilder(f)
+	x := b.Secret("x")
+	bit := b.Secret("bit")
+	b.Inverse(x.LC())
+	b.AssertBoolean(bit.LC())
+	s, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		inputs          map[string]*big.Int
+		wantErr         bool
+		wantUnsatisfied bool
+	}{
+		{"valid", map[string]*big.Int{"x": big.NewInt(2), "bit": big.NewInt(1)}, false, false},
+		{"reduced input", map[string]*big.Int{"x": big.NewInt(-2), "bit": new(big.Int).Set(bn256.Order)}, false, false},
+		{"not invertible", map[string]*big.Int{"x": big.NewInt(0), "bit": big.NewInt(0)}, true, false},
+		{"not boolean", map[string]*big.Int{"x": big.NewInt(2), "bit": big.NewInt(2)}, true, true},
+		{"missing input", map[string]*big.Int{"x": big.NewInt(2)}, true, false},
+		{"unknown input", map[string]*big.Int{"x": big.NewInt(2), "bit": big.NewInt(1), "y": big.NewInt(0)}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Solve(tt.inputs)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("Solve() error %v; want error %t", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrUnsatisfied); got != tt.wantUnsatisfied {
+				t.Errorf("errors.Is(Solve(), ErrUnsatisfied) = %t; want %t", got, tt.wantUnsatisfied)
+			}
+		})
+	}
+
+	if got := tests[1].inputs["bit"]; got.Cmp(bn256.Order) != 0 {
+		t.Errorf("Solve() modified its inputs: %v", got)
+	}
+}
+
+func TestBuildRows(t *testing.T) {
+	b := NewBuilder(f)
+	x := b.Secret("x")
+	y := b.Public("y")
+	// Terms of the same variable are merged and cancelling ones dropped.
+	lc := x.LC().Add(x.LC()).Add(y.LC()).Sub(y.LC()).Add(Constant(big.NewInt(-1)))
+	b.Constrain(lc, One.LC(), y.LC())
+	s, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error %v", err)
+	}
+	a := s.Constraints()[0].A
+	want := Row{{Index: 0, Value: new(big.Int).Sub(bn256.Order, big.NewInt(1))}, {Index: 2, Value: big.NewInt(2)}}
+	if len(a) != len(want) {
+		t.Fatalf("A = %v; want %v", a, want)
+	}
+	for i := range want {
+		if a[i].Index != want[i].Index || a[i].Value.Cmp(want[i].Value) != 0 {
+			t.Errorf("A[%d] = %v; want %v", i, a[i], want[i])
+		}
+	}
+
+	dup := NewBuilder(f)
+	dup.Public("x")
+	dup.Secret("x")
+	if _, err := dup.Build(); err == nil {
+		t.Error("Build() with duplicate inputs; want error")
+	}
+}
+
+func TestWithInputConstraints(t *testing.T) {
+	s := cubic(t)
+	in := s.WithInputConstraints()
+	if got, want := in.NumConstraints(), s.NumConstraints()+s.NumPublic()+1; got != want {
+		t.Errorf("WithInputConstraints().NumConstraints() = %d; want %d", got, want)
+	}
+	if got, want := s.NumConstraints(), 3; got != want {
+		t.Errorf("NumConstraints() after WithInputConstraints() = %d; want %d", got, want)
+	}
+	w, err := in.Solve(map[string]*big.Int{"out": big.NewInt(35), "x": big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("WithInputConstraints().Solve() error %v", err)
+	}
+	if err := s.IsSatisfied(w); err != nil {
+		t.Errorf("IsSatisfied() = %v; want nil", err)
+	}
+}
@@ -0,0 +1,393 @@
+// Package rangeproof implements aggregated Bulletproofs range proofs over
+// bn256, showing that Pedersen commitments hold values in [0, 2^n) without
+// revealing them. A proof for m values has 2*log2(n*m) + 4 points. See Bünz et
+// al., "Bulletproofs: Short Proofs for Confidential Transactions and More",
+// https://eprint.iacr.org/2017/1066, section 4.
+//
+// Values are committed to as v*G[0] + r*H with the generators of
+// pedersen.Setup(curve.BN256, 1), so commitments of the pedersen package can
+// be proven to be in range. The vector generators of the inner-product
+// argument are those of ipa.Setup.
+package rangeproof
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/ipa"
+	"zkp.xyz/membership/msm"
+	"zkp.xyz/membership/pedersen"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+var (
+	// Field is the scalar field of bn256, to which values and blinders belong.
+	Field = ipa.Field
+
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+	bigTwo  = big.NewInt(2)
+)
+
+// MaxBits is the maximum bit length of ranges, far enough below that of the
+// Field for sums of values not to wrap around.
+const MaxBits = 128
+
+// Params are the public parameters for proving that up to M values are in
+// [0, 2^N).
+type Params struct {
+	N, M int
+	ped  *pedersen.Params
+	// g and h are the Pedersen generators of values and blinders.
+	g, h *bn256.G1
+	// gs and hs are the N*M vector generators, and u that of inner products.
+	gs, hs []*bn256.G1
+	u      *bn256.G1
+}
+
+// toG1 returns the bn256 point of p, a Point of curve.BN256.
+func toG1(p curve.Point) (*bn256.G1, error) {
+	g := new(bn256.G1)
+	if _, err := g.Unmarshal(p.Marshal()); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func isPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// Setup returns the Params for ranges of nbits, a power of two of at most
+// MaxBits, and up to m values per proof, a power of two.
+func Setup(nbits, m int) (*Params, error) {
+	if !isPowerOfTwo(nbits) || nbits > MaxBits {
+		return nil, fmt.Errorf("%d bits not a power of two in [1, %d]", nbits, MaxBits)
+	}
+	if !isPowerOfTwo(m) {
+		return nil, fmt.Errorf("%d values not a power of two", m)
+	}
+	ped, err := pedersen.Setup(curve.BN256, 1)
+	if err != nil {
+		return nil, err
+	}
+	g, err := toG1(ped.G[0])
+	if err != nil {
+		return nil, err
+	}
+	h, err := toG1(ped.H)
+	if err != nil {
+		return nil, err
+	}
+	n := nbits * m
+	vec, err := ipa.Setup(2*n - 1)
+	if err != nil {
+		return nil, err
+	}
+	return &Params{N: nbits, M: m, ped: ped, g: g, h: h, gs: vec.G[:n], hs: vec.G[n:], u: vec.U}, nil
+}
+
+// Pedersen returns the Pedersen parameters of the commitments.
+func (params *Params) Pedersen() *pedersen.Params {
+	return params.ped
+}
+
+// A Proof shows that committed values are in range. A and S commit to the
+// bits of the values and blinding vectors, T1 and T2 to the coefficients of
+// t(X), and THat = t(x), TauX and Mu are the evaluations and blinders at the
+// challenge x, of which the IPA shows knowledge of the vectors l(x) and r(x).
+type Proof struct {
+	A, S, T1, T2   *bn256.G1
+	TauX, Mu, THat *big.Int
+	IPA            *ipa.InnerProductProof
+}
+
+// newTranscript returns the Transcript of a proof for the commitments.
+func (params *Params) newTranscript(vs []*bn256.G1) *transcript.Transcript {
+	t := transcript.New("zkp.xyz/rangeproof")
+	t.AppendScalar("n", big.NewInt(int64(params.N)))
+	t.AppendScalar("m", big.NewInt(int64(len(vs))))
+	for _, v := range vs {
+		t.AppendG1("V", v)
+	}
+	return t
+}
+
+func randomVector(n int, r io.Reader) ([]*big.Int, error) {
+	v := make([]*big.Int, n)
+	for i := range v {
+		x, err := Field.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = x
+	}
+	return v, nil
+}
+
+func innerProduct(a, b []*big.Int) *big.Int {
+	sum := big.NewInt(0)
+	for i := range a {
+		sum = Field.Add(sum, Field.Mul(a[i], b[i]))
+	}
+	return sum
+}
+
+// vectorCommit returns scalar*h + <a, gs> + <b, hs>.
+func (params *Params) vectorCommit(scalar *big.Int, a, b []*big.Int) (*bn256.G1, error) {
+	points := append(append([]*bn256.G1{params.h}, params.gs[:len(a)]...), params.hs[:len(b)]...)
+	scalars := append(append([]*big.Int{scalar}, a...), b...)
+	return msm.MultiExp(points, scalars)
+}
+
+// Commit returns the Pedersen commitment v*G[0] + blinder*H.
+func (params *Params) Commit(v, blinder *big.Int) (*pedersen.Commitment, error) {
+	return params.ped.Commit([]*big.Int{v}, blinder)
+}
+
+// zPowers returns z^2, ..., z^(m+1), the weights of the values' range checks.
+func zPowers(z *big.Int, m int) []*big.Int {
+	return polynomial.ComputePowers(z, m+2, Field)[2:]
+}
+
+// ProveAggregated returns a Proof that the commitments to vs[j] with the
+// blinders[j] hold values in [0, 2^N), for up to M values. The values must be
+// in range. It draws randomness from r.
+func (params *Params) ProveAggregated(vs, blinders []*big.Int, r io.Reader) (*Proof, error) {
+	m := len(vs)
+	if !isPowerOfTwo(m) || m > params.M || len(blinders) != m {
+		return nil, fmt.Errorf("%d values and %d blinders; want an equal power of two of at most %d", m, len(blinders), params.M)
+	}
+	bound := new(big.Int).Lsh(bigOne, uint(params.N))
+	commitments := make([]*bn256.G1, m)
+	for j, v := range vs {
+		if v.Sign() < 0 || v.Cmp(bound) >= 0 {
+			return nil, fmt.Errorf("value %d out of range [0, 2^%d)", j, params.N)
+		}
+		c, err := params.Commit(v, blinders[j])
+		if err != nil {
+			return nil, err
+		}
+		if commitments[j], err = toG1(c.P); err != nil {
+			return nil, err
+		}
+	}
+
+	n := params.N * m
+	aL, aR := make([]*big.Int, n), make([]*big.Int, n)
+	for j, v := range vs {
+		for i := 0; i < params.N; i++ {
+			aL[j*params.N+i] = big.NewInt(int64(v.Bit(i)))
+			aR[j*params.N+i] = Field.Sub(aL[j*params.N+i], bigOne)
+		}
+	}
+	alpha, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	a, err := params.vectorCommit(alpha, aL, aR)
+	if err != nil {
+		return nil, err
+	}
+	sL, err := randomVector(n, r)
+	if err != nil {
+		return nil, err
+	}
+	sR, err := randomVector(n, r)
+	if err != nil {
+		return nil, err
+	}
+	rho, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	s, err := params.vectorCommit(rho, sL, sR)
+	if err != nil {
+		return nil, err
+	}
+
+	t := params.newTranscript(commitments)
+	t.AppendG1("A", a)
+	t.AppendG1("S", s)
+	y, z := t.Challenge(Field), t.Challenge(Field)
+
+	// l(X) = l0 + l1 X and r(X) = r0 + r1 X, so that the constant term of
+	// t(X) = <l(X), r(X)> is sum_j z^(j+2) v_j + delta(y, z) iff all bits are
+	// bits of the values.
+	ys := polynomial.ComputePowers(y, n, Field)
+	twos := polynomial.ComputePowers(bigTwo, params.N, Field)
+	zs := zPowers(z, m)
+	l0, l1, r0, r1 := make([]*big.Int, n), sL, make([]*big.Int, n), make([]*big.Int, n)
+	for i := range l0 {
+		l0[i] = Field.Sub(aL[i], z)
+		r0[i] = Field.Add(Field.Mul(ys[i], Field.Add(aR[i], z)), Field.Mul(zs[i/params.N], twos[i%params.N]))
+		r1[i] = Field.Mul(ys[i], sR[i])
+	}
+	t1 := Field.Add(innerProduct(l0, r1), innerProduct(l1, r0))
+	t2 := innerProduct(l1, r1)
+
+	tau1, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	tau2, err := Field.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	T1, err := msm.MultiExp([]*bn256.G1{params.g, params.h}, []*big.Int{t1, tau1})
+	if err != nil {
+		return nil, err
+	}
+	T2, err := msm.MultiExp([]*bn256.G1{params.g, params.h}, []*big.Int{t2, tau2})
+	if err != nil {
+		return nil, err
+	}
+	t.AppendG1("T1", T1)
+	t.AppendG1("T2", T2)
+	x := t.Challenge(Field)
+
+	l, rr := make([]*big.Int, n), make([]*big.Int, n)
+	for i := range l {
+		l[i] = Field.Add(l0[i], Field.Mul(l1[i], x))
+		rr[i] = Field.Add(r0[i], Field.Mul(r1[i], x))
+	}
+	proof := &Proof{A: a, S: s, T1: T1, T2: T2, THat: innerProduct(l, rr)}
+	proof.TauX = Field.Add(Field.Mul(tau2, Field.Mul(x, x)), Field.Mul(tau1, x))
+	for j, g := range blinders {
+		proof.TauX = Field.Add(proof.TauX, Field.Mul(zs[j], g))
+	}
+	proof.Mu = Field.Add(alpha, Field.Mul(rho, x))
+
+	u, hs, err := params.ipaInputs(t, proof, y, n)
+	if err != nil {
+		return nil, err
+	}
+	proof.IPA, err = ipa.ProveInnerProduct(params.gs[:n], hs, u, l, rr, t)
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// ipaInputs appends the evaluations to the transcript and returns the
+// generator of inner products, scaled by a challenge, and the generators
+// H'_i = y^-i H_i with respect to which r(x) is committed.
+func (params *Params) ipaInputs(t *transcript.Transcript, proof *Proof, y *big.Int, n int) (*bn256.G1, []*bn256.G1, error) {
+	t.AppendScalar("tau_x", proof.TauX)
+	t.AppendScalar("mu", proof.Mu)
+	t.AppendScalar("t_hat", proof.THat)
+	u := new(bn256.G1).ScalarMult(params.u, t.Challenge(Field))
+
+	yInv, err := Field.MultInverse(y)
+	if err != nil {
+		return nil, nil, fmt.Errorf("challenge y: %v", err)
+	}
+	yInvs := polynomial.ComputePowers(yInv, n, Field)
+	hs := make([]*bn256.G1, n)
+	for i := range hs {
+		hs[i] = new(bn256.G1).ScalarMult(params.hs[i], yInvs[i])
+	}
+	return u, hs, nil
+}
+
+// VerifyAggregated reports whether the proof shows that all commitments hold
+// values in [0, 2^N).
+func (params *Params) VerifyAggregated(cs []*pedersen.Commitment, proof *Proof) bool {
+	m := len(cs)
+	if !isPowerOfTwo(m) || m > params.M || proof.IPA == nil {
+		return false
+	}
+	for _, p := range []*bn256.G1{proof.A, proof.S, proof.T1, proof.T2} {
+		if p == nil {
+			return false
+		}
+	}
+	for _, x := range []*big.Int{proof.TauX, proof.Mu, proof.THat} {
+		if x == nil || x.Sign() < 0 || x.Cmp(Field.Order()) >= 0 {
+			return false
+		}
+	}
+	commitments := make([]*bn256.G1, m)
+	for j, c := range cs {
+		var err error
+		if commitments[j], err = toG1(c.P); err != nil {
+			return false
+		}
+	}
+
+	n := params.N * m
+	t := params.newTranscript(commitments)
+	t.AppendG1("A", proof.A)
+	t.AppendG1("S", proof.S)
+	y, z := t.Challenge(Field), t.Challenge(Field)
+	t.AppendG1("T1", proof.T1)
+	t.AppendG1("T2", proof.T2)
+	x := t.Challenge(Field)
+
+	// THat*g + TauX*h == sum_j z^(j+2) V_j + delta(y, z)*g + x*T1 + x^2*T2, with
+	// delta(y, z) = (z - z^2) <1, y^n> - sum_j z^(j+3) <1, 2^N>.
+	ys := polynomial.ComputePowers(y, n, Field)
+	twos := polynomial.ComputePowers(bigTwo, params.N, Field)
+	zs := zPowers(z, m)
+	sumY, sumTwos := big.NewInt(0), big.NewInt(0)
+	for _, yi := range ys {
+		sumY = Field.Add(sumY, yi)
+	}
+	for _, ti := range twos {
+		sumTwos = Field.Add(sumTwos, ti)
+	}
+	delta := Field.Mul(Field.Sub(z, Field.Mul(z, z)), sumY)
+	for _, zj := range zs {
+		delta = Field.Sub(delta, Field.Mul(Field.Mul(zj, z), sumTwos))
+	}
+	points := append([]*bn256.G1{params.g, params.h, proof.T1, proof.T2}, commitments...)
+	scalars := []*big.Int{Field.Sub(delta, proof.THat), Field.Sub(bigZero, proof.TauX), x, Field.Mul(x, x)}
+	scalars = append(scalars, zs...)
+	check, err := msm.MultiExp(points, scalars)
+	if err != nil || !bytes.Equal(check.Marshal(), new(bn256.G1).ScalarBaseMult(bigZero).Marshal()) {
+		return false
+	}
+
+	u, hs, err := params.ipaInputs(t, proof, y, n)
+	if err != nil {
+		return false
+	}
+	// P = A + x*S - z <1, G> + <z y^n + z^(j+2) 2^N, H'> - Mu*h + THat*u.
+	points = []*bn256.G1{proof.A, proof.S, params.h, u}
+	scalars = []*big.Int{bigOne, x, Field.Sub(bigZero, proof.Mu), proof.THat}
+	negZ := Field.Sub(bigZero, z)
+	for i := 0; i < n; i++ {
+		points = append(points, params.gs[i], hs[i])
+		scalars = append(scalars, negZ, Field.Add(Field.Mul(z, ys[i]), Field.Mul(zs[i/params.N], twos[i%params.N])))
+	}
+	p, err := msm.MultiExp(points, scalars)
+	if err != nil {
+		return false
+	}
+	return ipa.VerifyInnerProduct(params.gs[:n], hs, u, p, proof.IPA, t)
+}
+
+// Prove returns a Proof that the commitment to v with the blinder holds a
+// value in [0, 2^nbits), under the Params Setup(nbits, 1).
+func Prove(v, blinder *big.Int, nbits int, r io.Reader) (*Proof, error) {
+	params, err := Setup(nbits, 1)
+	if err != nil {
+		return nil, err
+	}
+	return params.ProveAggregated([]*big.Int{v}, []*big.Int{blinder}, r)
+}
+
+// Verify reports whether the proof shows that the commitment holds a value in
+// [0, 2^nbits), under the Params Setup(nbits, 1).
+func Verify(c *pedersen.Commitment, nbits int, proof *Proof) bool {
+	params, err := Setup(nbits, 1)
+	if err != nil {
+		return false
+	}
+	return params.VerifyAggregated([]*pedersen.Commitment{c}, proof)
+}
@@ -0,0 +1,165 @@
+package rangeproof
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/pedersen"
+)
+
+func blinders(t *testing.T, m int) []*big.Int {
+	t.Helper()
+	bs, err := randomVector(m, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bs
+}
+
+func commit(t *testing.T, params *Params, vs, bs []*big.Int) []*pedersen.Commitment {
+	t.Helper()
+	cs := make([]*pedersen.Commitment, len(vs))
+	for i := range vs {
+		c, err := params.Commit(vs[i], bs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		cs[i] = c
+	}
+	return cs
+}
+
+func TestSetupErrors(t *testing.T) {
+	for _, tt := range []struct{ nbits, m int }{{0, 1}, {3, 1}, {256, 1}, {8, 0}, {8, 3}} {
+		if _, err := Setup(tt.nbits, tt.m); err == nil {
+			t.Errorf("Setup(%d, %d): got nil error", tt.nbits, tt.m)
+		}
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	tests := []struct {
+		nbits int
+		vs    []int64
+	}{
+		{nbits: 1, vs: []int64{1}},
+		{nbits: 8, vs: []int64{0}},
+		{nbits: 8, vs: []int64{255}},
+		{nbits: 16, vs: []int64{1234, 0, 65535, 7}},
+		{nbits: 64, vs: []int64{1 << 62, 42}},
+	}
+
+	for _, tt := range tests {
+		params, err := Setup(tt.nbits, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vs := make([]*big.Int, len(tt.vs))
+		for i, v := range tt.vs {
+			vs[i] = big.NewInt(v)
+		}
+		bs := blinders(t, len(vs))
+		proof, err := params.ProveAggregated(vs, bs, rand.Reader)
+		if err != nil {
+			t.Fatalf("ProveAggregated(%v) with %d bits: %v", tt.vs, tt.nbits, err)
+		}
+		cs := commit(t, params, vs, bs)
+		if !params.VerifyAggregated(cs, proof) {
+			t.Errorf("VerifyAggregated(ProveAggregated(%v)) with %d bits = false", tt.vs, tt.nbits)
+		}
+		if got, want := len(proof.IPA.L), len(tt.vs)*tt.nbits; 1<<got != want {
+			t.Errorf("len(ProveAggregated(%v).IPA.L) = %d; want log2(%d)", tt.vs, got, want)
+		}
+
+		other := commit(t, params, vs, blinders(t, len(vs)))
+		if params.VerifyAggregated(other, proof) {
+			t.Errorf("VerifyAggregated(ProveAggregated(%v)) for other commitments = true", tt.vs)
+		}
+		if len(cs) > 1 {
+			cs[0], cs[1] = cs[1], cs[0]
+			if params.VerifyAggregated(cs, proof) {
+				t.Errorf("VerifyAggregated(ProveAggregated(%v)) with swapped commitments = true", tt.vs)
+			}
+		}
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	params, err := Setup(8, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, b := big.NewInt(200), big.NewInt(99)
+	c, err := params.Commit(v, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(*Proof)
+	}{
+		{name: "A", tamper: func(p *Proof) { p.A = new(bn256.G1).Add(p.A, p.S) }},
+		{name: "T1", tamper: func(p *Proof) { p.T1 = p.T2 }},
+		{name: "TauX", tamper: func(p *Proof) { p.TauX = Field.Add(p.TauX, bigOne) }},
+		{name: "Mu", tamper: func(p *Proof) { p.Mu = Field.Add(p.Mu, bigOne) }},
+		{name: "THat", tamper: func(p *Proof) { p.THat = Field.Add(p.THat, bigOne) }},
+		{name: "unreduced Mu", tamper: func(p *Proof) { p.Mu = new(big.Int).Add(p.Mu, Field.Order()) }},
+		{name: "IPA", tamper: func(p *Proof) { p.IPA.A = Field.Add(p.IPA.A, bigOne) }},
+		{name: "missing IPA", tamper: func(p *Proof) { p.IPA = nil }},
+	}
+	for _, tt := range tests {
+		proof, err := params.ProveAggregated([]*big.Int{v}, []*big.Int{b}, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tt.tamper(proof)
+		if params.VerifyAggregated([]*pedersen.Commitment{c}, proof) {
+			t.Errorf("VerifyAggregated() with tampered %s = true", tt.name)
+		}
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	params, err := Setup(8, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, vs := range [][]*big.Int{
+		{big.NewInt(256)},
+		{big.NewInt(-1)},
+		{big.NewInt(1), big.NewInt(1 << 10)},
+	} {
+		if _, err := params.ProveAggregated(vs, blinders(t, len(vs)), rand.Reader); err == nil {
+			t.Errorf("ProveAggregated(%v) with 8 bits: got nil error", vs)
+		}
+	}
+	for _, m := range []int{3, 4} {
+		vs := make([]*big.Int, m)
+		for i := range vs {
+			vs[i] = big.NewInt(1)
+		}
+		if _, err := params.ProveAggregated(vs, blinders(t, m), rand.Reader); err == nil {
+			t.Errorf("ProveAggregated(<%d values>) with M = 2: got nil error", m)
+		}
+	}
+
+	// A proof for 16 bits doesn't show an 8-bit range.
+	v, b := big.NewInt(1000), big.NewInt(5)
+	proof, err := Prove(v, b, 16, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := pedersen.Commit([]*big.Int{v}, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Verify(c, 16, proof) {
+		t.Errorf("Verify(<pedersen.Commit(1000)>, 16, Prove(1000, 16)) = false")
+	}
+	if Verify(c, 8, proof) {
+		t.Errorf("Verify(<pedersen.Commit(1000)>, 8, Prove(1000, 16)) = true")
+	}
+}
@@ -0,0 +1,45 @@
+package shamir
+
+import (
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/polynomial"
+)
+
+// SplitVerifiable is equivalent to Split over the scalar field of the Suite,
+// additionally returning the commitments C_j = a_j G to the coefficients a_j
+// of the sharing polynomial, for G the generator of G1. Shareholders check
+// their shares against them with VerifyShare. C_0 = secret * G reveals the
+// secret to anyone able to compute discrete logarithms, so secrets MUST be
+// uniformly random.
+func SplitVerifiable(s curve.Suite, secret *big.Int, t, n int, r io.Reader) ([]Share, []curve.Point, error) {
+	f := s.ScalarField()
+	p, err := sharingPolynomial(secret, t, n, f, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitments := make([]curve.Point, len(*p))
+	for j, a := range *p {
+		commitments[j] = s.G1().New().ScalarBaseMult(a)
+	}
+	return shares(p, n, f), commitments, nil
+}
+
+// VerifyShare reports whether the share is an evaluation of the polynomial
+// committed to by the commitments, i.e. whether
+//
+//	Y G = sum_j X^j C_j.
+func VerifyShare(s curve.Suite, share Share, commitments []curve.Point) bool {
+	if share.X == nil || share.Y == nil || len(commitments) == 0 {
+		return false
+	}
+	f := s.ScalarField()
+	powers := polynomial.ComputePowers(f.Mod(new(big.Int).Set(share.X)), len(commitments), f)
+	got, err := s.G1().MultiExp(commitments, powers)
+	if err != nil {
+		return false
+	}
+	return got.Equal(s.G1().New().ScalarBaseMult(share.Y))
+}
@@ -0,0 +1,48 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+)
+
+func TestSplitVerifiable(t *testing.T) {
+	for name, s := range map[string]curve.Suite{"bn256": curve.BN256, "bls12381": curve.BLS12381} {
+		t.Run(name, func(t *testing.T) {
+			f := galois.NewField(s.Order())
+			secret, err := f.Random(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			shares, commitments, err := SplitVerifiable(s, secret, 3, 5, rand.Reader)
+			if err != nil {
+				t.Fatalf("SplitVerifiable() error %v", err)
+			}
+			if len(commitments) != 3 {
+				t.Errorf("len(commitments) = %d; want 3", len(commitments))
+			}
+			for _, share := range shares {
+				if !VerifyShare(s, share, commitments) {
+					t.Errorf("VerifyShare(%v) = false; want true", share.X)
+				}
+				tampered := Share{X: share.X, Y: f.Add(share.Y, big.NewInt(1))}
+				if VerifyShare(s, tampered, commitments) {
+					t.Errorf("VerifyShare() of tampered share %v = true; want false", share.X)
+				}
+			}
+			got, err := Combine(shares[1:4], f)
+			if err != nil {
+				t.Fatalf("Combine() error %v", err)
+			}
+			if got.Cmp(secret) != 0 {
+				t.Errorf("Combine() = %v; want %v", got, secret)
+			}
+			if VerifyShare(s, Share{X: big.NewInt(1)}, commitments) || VerifyShare(s, shares[0], nil) {
+				t.Error("VerifyShare() of incomplete inputs = true; want false")
+			}
+		})
+	}
+}
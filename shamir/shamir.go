@@ -0,0 +1,86 @@
+// Package shamir implements Shamir's secret sharing ("How to Share a Secret")
+// over a galois.Field, and Feldman's verifiable secret sharing ("A Practical
+// Scheme for Non-interactive Verifiable Secret Sharing") over G1 of a
+// curve.Suite.
+//
+// The secret is the constant coefficient of a random polynomial p of degree
+// t-1, and share i is the evaluation (i, p(i)) for i in [1, n]. Any t shares
+// determine p by interpolation, and thereby the secret p(0), while fewer
+// reveal nothing about it.
+package shamir
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A Share is the evaluation Y = p(X) of the sharing polynomial.
+type Share struct {
+	X, Y *big.Int
+}
+
+// sharingPolynomial returns a random polynomial of degree t-1 with the secret
+// as constant coefficient, checking that 1 <= t <= n < f.Order().
+func sharingPolynomial(secret *big.Int, t, n int, f *galois.Field, r io.Reader) (*polynomial.Polynomial, error) {
+	if t < 1 || t > n {
+		return nil, fmt.Errorf("threshold %d of %d shares; want 1 <= t <= n", t, n)
+	}
+	if big.NewInt(int64(n)).Cmp(f.Order()) >= 0 {
+		return nil, fmt.Errorf("%d shares; want fewer than the field order %v", n, f.Order())
+	}
+	cs := make([]*big.Int, t)
+	cs[0] = f.Mod(new(big.Int).Set(secret))
+	for i := 1; i < t; i++ {
+		c, err := f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		cs[i] = c
+	}
+	return polynomial.NewPolynomial(cs), nil
+}
+
+// shares returns the evaluations of p at 1, ..., n.
+func shares(p *polynomial.Polynomial, n int, f *galois.Field) []Share {
+	out := make([]Share, n)
+	for i := range out {
+		x := big.NewInt(int64(i + 1))
+		out[i] = Share{X: x, Y: p.Evaluate(x, f)}
+	}
+	return out
+}
+
+// Split splits the secret, reduced into f, into n shares, any t of which
+// recover it with Combine. The coefficients of the sharing polynomial are
+// drawn from r.
+func Split(secret *big.Int, t, n int, f *galois.Field, r io.Reader) ([]Share, error) {
+	p, err := sharingPolynomial(secret, t, n, f, r)
+	if err != nil {
+		return nil, err
+	}
+	return shares(p, n, f), nil
+}
+
+// Combine returns the secret shared by the shares, by interpolating them at
+// 0. With fewer shares than the threshold, the result is unrelated to the
+// secret. It returns an error if there are no shares or their X are not
+// distinct in f.
+func Combine(shares []Share, f *galois.Field) (*big.Int, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares")
+	}
+	xs, ys := make([]*big.Int, len(shares)), make([]*big.Int, len(shares))
+	for i, s := range shares {
+		xs[i], ys[i] = s.X, s.Y
+	}
+	p, err := polynomial.Interpolate(xs, ys, f)
+	if err != nil {
+		return nil, err
+	}
+	return p.Evaluate(big.NewInt(0), f), nil
+}
@@ -0,0 +1,82 @@
+package shamir
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/galois"
+)
+
+var f = galois.NewField(big.NewInt(2147483647))
+
+func TestSplitCombine(t *testing.T) {
+	secret := big.NewInt(1234567)
+	tests := []struct {
+		name string
+		t, n int
+	}{
+		{"1 of 1", 1, 1},
+		{"2 of 3", 2, 3},
+		{"3 of 5", 3, 5},
+		{"5 of 5", 5, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := Split(secret, tt.t, tt.n, f, rand.Reader)
+			if err != nil {
+				t.Fatalf("Split() error %v", err)
+			}
+			if len(shares) != tt.n {
+				t.Fatalf("len(Split()) = %d; want %d", len(shares), tt.n)
+			}
+			// Every window of t shares recovers the secret.
+			for i := 0; i+tt.t <= tt.n; i++ {
+				got, err := Combine(shares[i:i+tt.t], f)
+				if err != nil {
+					t.Fatalf("Combine() error %v", err)
+				}
+				if got.Cmp(secret) != 0 {
+					t.Errorf("Combine(shares[%d:%d]) = %v; want %v", i, i+tt.t, got, secret)
+				}
+			}
+			if tt.t > 1 {
+				got, err := Combine(shares[:tt.t-1], f)
+				if err != nil {
+					t.Fatalf("Combine() error %v", err)
+				}
+				if got.Cmp(secret) == 0 {
+					t.Errorf("Combine() of %d shares = secret; want unrelated value", tt.t-1)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		t, n int
+	}{
+		{"zero threshold", 0, 3},
+		{"threshold exceeds shares", 4, 3},
+		{"too many shares", 2, 2147483647},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Split(big.NewInt(1), tt.t, tt.n, f, rand.Reader); err == nil {
+				t.Errorf("Split(t = %d, n = %d) error nil; want error", tt.t, tt.n)
+			}
+		})
+	}
+}
+
+func TestCombineErrors(t *testing.T) {
+	if _, err := Combine(nil, f); err == nil {
+		t.Error("Combine(nil) error nil; want error")
+	}
+	dup := []Share{{X: big.NewInt(1), Y: big.NewInt(2)}, {X: big.NewInt(1), Y: big.NewInt(3)}}
+	if _, err := Combine(dup, f); err == nil {
+		t.Error("Combine() of duplicate X error nil; want error")
+	}
+}
@@ -0,0 +1,364 @@
+package sigma
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/transcript"
+)
+
+// A Statement is a relation with a sigma protocol proving knowledge of a
+// witness, as returned by Group.DLogStatement, Group.Representation and
+// EqualDLog, or composed of others with And and Or. All parts of a Statement
+// MUST have scalar fields of the same order.
+type Statement interface {
+	field() *galois.Field
+	// counts returns the numbers of commitments, challenges and responses
+	// of the Statement's Proofs.
+	counts() (commitments, challenges, responses int)
+	appendTo(t *transcript.Transcript)
+	// commit returns the prover's commitments for the witness, and the state
+	// with which to respond.
+	commit(w any, r io.Reader) ([]curve.Point, any, error)
+	respond(w, state any, c *big.Int) (challenges, responses []*big.Int)
+	// simulate returns an accepting conversation for the challenge c without
+	// a witness.
+	simulate(c *big.Int, r io.Reader) ([]curve.Point, []*big.Int, []*big.Int, error)
+	// check reports whether the conversation is accepting; the lengths of the
+	// slices are as returned by counts.
+	check(commitments []curve.Point, challenges, responses []*big.Int, c *big.Int) bool
+}
+
+// A Proof is a non-interactive proof of a Statement. Its parts are those of
+// the Statement's leaves in order, with the challenges of each disjunction
+// preceding those of its disjuncts.
+type Proof struct {
+	Commitments []curve.Point
+	Challenges  []*big.Int
+	Responses   []*big.Int
+}
+
+// Prove returns a Proof of the Statement with the witness, drawing randomness
+// from r. The witness of a conjunction is a []any of those of its conjuncts,
+// and that of a disjunction an OrWitness.
+func Prove(st Statement, w any, t *transcript.Transcript, r io.Reader) (*Proof, error) {
+	commitments, state, err := st.commit(w, r)
+	if err != nil {
+		return nil, err
+	}
+	st.appendTo(t)
+	for _, p := range commitments {
+		appendPoint(t, "sigma/commitment", p)
+	}
+	c := t.Challenge(st.field())
+	challenges, responses := st.respond(w, state, c)
+	return &Proof{Commitments: commitments, Challenges: challenges, Responses: responses}, nil
+}
+
+// Verify reports whether the proof is valid for the Statement.
+func Verify(st Statement, proof *Proof, t *transcript.Transcript) bool {
+	nc, nch, nr := st.counts()
+	if len(proof.Commitments) != nc || len(proof.Challenges) != nch || len(proof.Responses) != nr {
+		return false
+	}
+	f := st.field()
+	for _, xs := range [][]*big.Int{proof.Challenges, proof.Responses} {
+		for _, x := range xs {
+			if x == nil || x.Sign() < 0 || x.Cmp(f.Order()) >= 0 {
+				return false
+			}
+		}
+	}
+	st.appendTo(t)
+	for _, p := range proof.Commitments {
+		appendPoint(t, "sigma/commitment", p)
+	}
+	c := t.Challenge(f)
+	return st.check(proof.Commitments, proof.Challenges, proof.Responses, c)
+}
+
+// appendComposite appends the kind and number of parts of a composed
+// Statement, followed by the parts.
+func appendComposite(t *transcript.Transcript, label string, parts []Statement) {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(parts)))
+	t.AppendBytes(label, n[:])
+	for _, p := range parts {
+		p.appendTo(t)
+	}
+}
+
+// sameField returns the field of the parts, or an error if they differ.
+func sameField(parts []Statement) (*galois.Field, error) {
+	if len(parts) == 0 {
+		return nil, errors.New("no statements")
+	}
+	f := parts[0].field()
+	for _, p := range parts[1:] {
+		if p.field().Order().Cmp(f.Order()) != 0 {
+			return nil, errors.New("statements over fields of different order")
+		}
+	}
+	return f, nil
+}
+
+// split splits the parts of a conversation into those of the statements.
+type split struct {
+	commitments []curve.Point
+	challenges  []*big.Int
+	responses   []*big.Int
+}
+
+func (s *split) next(st Statement) ([]curve.Point, []*big.Int, []*big.Int) {
+	nc, nch, nr := st.counts()
+	cs, chs, rs := s.commitments[:nc], s.challenges[:nch], s.responses[:nr]
+	s.commitments, s.challenges, s.responses = s.commitments[nc:], s.challenges[nch:], s.responses[nr:]
+	return cs, chs, rs
+}
+
+// An and is the conjunction of Statements, proven with the same challenge.
+type and struct {
+	f     *galois.Field
+	parts []Statement
+}
+
+// And returns the Statement that the prover knows witnesses of all parts,
+// with a []any witness holding those of the parts in order.
+func And(parts ...Statement) (Statement, error) {
+	f, err := sameField(parts)
+	if err != nil {
+		return nil, err
+	}
+	return &and{f: f, parts: parts}, nil
+}
+
+func (a *and) field() *galois.Field {
+	return a.f
+}
+
+func (a *and) counts() (int, int, int) {
+	var nc, nch, nr int
+	for _, p := range a.parts {
+		c, ch, r := p.counts()
+		nc, nch, nr = nc+c, nch+ch, nr+r
+	}
+	return nc, nch, nr
+}
+
+func (a *and) appendTo(t *transcript.Transcript) {
+	appendComposite(t, "sigma/and", a.parts)
+}
+
+func (a *and) witnesses(w any) ([]any, error) {
+	ws, ok := w.([]any)
+	if !ok || len(ws) != len(a.parts) {
+		return nil, fmt.Errorf("witness %T for a conjunction of %d statements", w, len(a.parts))
+	}
+	return ws, nil
+}
+
+func (a *and) commit(w any, r io.Reader) ([]curve.Point, any, error) {
+	ws, err := a.witnesses(w)
+	if err != nil {
+		return nil, nil, err
+	}
+	var commitments []curve.Point
+	states := make([]any, len(a.parts))
+	for i, p := range a.parts {
+		cs, state, err := p.commit(ws[i], r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("conjunct %d: %v", i, err)
+		}
+		commitments, states[i] = append(commitments, cs...), state
+	}
+	return commitments, states, nil
+}
+
+func (a *and) respond(w, state any, c *big.Int) ([]*big.Int, []*big.Int) {
+	ws, states := w.([]any), state.([]any)
+	var challenges, responses []*big.Int
+	for i, p := range a.parts {
+		chs, rs := p.respond(ws[i], states[i], c)
+		challenges, responses = append(challenges, chs...), append(responses, rs...)
+	}
+	return challenges, responses
+}
+
+func (a *and) simulate(c *big.Int, r io.Reader) ([]curve.Point, []*big.Int, []*big.Int, error) {
+	var commitments []curve.Point
+	var challenges, responses []*big.Int
+	for _, p := range a.parts {
+		cs, chs, rs, err := p.simulate(c, r)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		commitments = append(commitments, cs...)
+		challenges, responses = append(challenges, chs...), append(responses, rs...)
+	}
+	return commitments, challenges, responses, nil
+}
+
+func (a *and) check(commitments []curve.Point, challenges, responses []*big.Int, c *big.Int) bool {
+	s := &split{commitments, challenges, responses}
+	for _, p := range a.parts {
+		cs, chs, rs := s.next(p)
+		if !p.check(cs, chs, rs, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// An OrWitness is the witness of a disjunction: the witness of the part at
+// Index.
+type OrWitness struct {
+	Index   int
+	Witness any
+}
+
+// An or is the disjunction of Statements, proven with the technique of
+// Cramer, Damgård and Schoenmakers, "Proofs of Partial Knowledge and
+// Simplified Design of Witness Hiding Protocols": the prover simulates the
+// parts it has no witness for with challenges of its choice, and the
+// challenges of all parts must sum to the challenge of the disjunction. The
+// Proof holds the challenges of all but the last part.
+type or struct {
+	f     *galois.Field
+	parts []Statement
+}
+
+// Or returns the Statement that the prover knows a witness of at least one of
+// the parts, with an OrWitness. Proofs don't reveal which.
+func Or(parts ...Statement) (Statement, error) {
+	f, err := sameField(parts)
+	if err != nil {
+		return nil, err
+	}
+	return &or{f: f, parts: parts}, nil
+}
+
+func (o *or) field() *galois.Field {
+	return o.f
+}
+
+func (o *or) counts() (int, int, int) {
+	nc, nch, nr := 0, len(o.parts)-1, 0
+	for _, p := range o.parts {
+		c, ch, r := p.counts()
+		nc, nch, nr = nc+c, nch+ch, nr+r
+	}
+	return nc, nch, nr
+}
+
+func (o *or) appendTo(t *transcript.Transcript) {
+	appendComposite(t, "sigma/or", o.parts)
+}
+
+// orState holds the simulated conversations of the parts without a witness,
+// and the state of the one with.
+type orState struct {
+	challenges []*big.Int
+	sims       []split
+	state      any
+}
+
+func (o *or) commit(w any, r io.Reader) ([]curve.Point, any, error) {
+	ow, ok := w.(OrWitness)
+	if !ok || ow.Index < 0 || ow.Index >= len(o.parts) {
+		return nil, nil, fmt.Errorf("witness %T for a disjunction of %d statements", w, len(o.parts))
+	}
+	st := &orState{challenges: make([]*big.Int, len(o.parts)), sims: make([]split, len(o.parts))}
+	var commitments []curve.Point
+	for i, p := range o.parts {
+		if i == ow.Index {
+			cs, state, err := p.commit(ow.Witness, r)
+			if err != nil {
+				return nil, nil, fmt.Errorf("disjunct %d: %v", i, err)
+			}
+			commitments, st.state = append(commitments, cs...), state
+			continue
+		}
+		c, err := o.f.Random(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		cs, chs, rs, err := p.simulate(c, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		st.challenges[i], st.sims[i] = c, split{cs, chs, rs}
+		commitments = append(commitments, cs...)
+	}
+	return commitments, st, nil
+}
+
+func (o *or) respond(w, state any, c *big.Int) ([]*big.Int, []*big.Int) {
+	ow, st := w.(OrWitness), state.(*orState)
+	ci := c
+	for i, x := range st.challenges {
+		if i != ow.Index {
+			ci = o.f.Sub(ci, x)
+		}
+	}
+	st.challenges[ow.Index] = ci
+
+	challenges := append([]*big.Int(nil), st.challenges[:len(o.parts)-1]...)
+	var responses []*big.Int
+	for i, p := range o.parts {
+		chs, rs := st.sims[i].challenges, st.sims[i].responses
+		if i == ow.Index {
+			chs, rs = p.respond(ow.Witness, st.state, ci)
+		}
+		challenges, responses = append(challenges, chs...), append(responses, rs...)
+	}
+	return challenges, responses
+}
+
+func (o *or) simulate(c *big.Int, r io.Reader) ([]curve.Point, []*big.Int, []*big.Int, error) {
+	cs := make([]*big.Int, len(o.parts))
+	last := c
+	for i := range cs[:len(cs)-1] {
+		x, err := o.f.Random(r)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		cs[i], last = x, o.f.Sub(last, x)
+	}
+	cs[len(cs)-1] = last
+
+	var commitments []curve.Point
+	challenges := append([]*big.Int(nil), cs[:len(cs)-1]...)
+	var responses []*big.Int
+	for i, p := range o.parts {
+		pcs, chs, rs, err := p.simulate(cs[i], r)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		commitments = append(commitments, pcs...)
+		challenges, responses = append(challenges, chs...), append(responses, rs...)
+	}
+	return commitments, challenges, responses, nil
+}
+
+func (o *or) check(commitments []curve.Point, challenges, responses []*big.Int, c *big.Int) bool {
+	n := len(o.parts)
+	last := c
+	for _, x := range challenges[:n-1] {
+		last = o.f.Sub(last, x)
+	}
+	cs := append(append([]*big.Int(nil), challenges[:n-1]...), last)
+
+	s := &split{commitments, challenges[n-1:], responses}
+	for i, p := range o.parts {
+		pcs, chs, rs := s.next(p)
+		if !p.check(pcs, chs, rs, cs[i]) {
+			return false
+		}
+	}
+	return true
+}
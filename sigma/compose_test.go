@@ -0,0 +1,223 @@
+package sigma
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/pedersen"
+	"zkp.xyz/membership/transcript"
+)
+
+// proveVerify proves the statement with the witness and reports whether the
+// proof verifies, failing the test if proving fails.
+func proveVerify(t *testing.T, st Statement, w any) (*Proof, bool) {
+	t.Helper()
+	proof, err := Prove(st, w, transcript.New("test"), rand.Reader)
+	if err != nil {
+		t.Fatalf("Prove(): %v", err)
+	}
+	return proof, Verify(st, proof, transcript.New("test"))
+}
+
+func TestLinear(t *testing.T) {
+	g := groups["bn256 G1"]
+	st, x := randomDLog(t, g)
+	if _, ok := proveVerify(t, g.DLogStatement(st), x); !ok {
+		t.Errorf("Verify(Prove(DLogStatement())) = false")
+	}
+
+	params, err := pedersen.Setup(curve.BN256, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs := []*big.Int{big.NewInt(3), big.NewInt(4), big.NewInt(5)}
+	c, err := params.Commit(vs[:2], vs[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep := g.Representation(c.P, params.G[0], params.G[1], params.H)
+	proof, ok := proveVerify(t, rep, vs)
+	if !ok {
+		t.Errorf("Verify(Prove(Representation())) = false")
+	}
+	other := g.Representation(c.P, params.G[1], params.G[0], params.H)
+	if Verify(other, proof, transcript.New("test")) {
+		t.Errorf("Verify(Prove(Representation())) with permuted bases = true")
+	}
+
+	if _, err := Prove(rep, []*big.Int{big.NewInt(3), big.NewInt(4), big.NewInt(6)}, transcript.New("test"), rand.Reader); err == nil {
+		t.Errorf("Prove(Representation()) with wrong witness: got nil error")
+	}
+	if _, err := Prove(rep, x, transcript.New("test"), rand.Reader); err == nil {
+		t.Errorf("Prove(Representation()) with *big.Int witness: got nil error")
+	}
+}
+
+func TestEqualDLog(t *testing.T) {
+	for _, s := range []curve.Suite{curve.BN256, curve.BLS12381} {
+		g1, g2 := G1(s), G2(s)
+		x := big.NewInt(1234)
+		a := &DLog{Base: g1.Curve().Generator(), X: g1.Curve().New().ScalarBaseMult(x)}
+		b := &DLog{Base: g2.Curve().Generator(), X: g2.Curve().New().ScalarBaseMult(x)}
+		st, err := EqualDLog(g1, a, g2, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := proveVerify(t, st, x); !ok {
+			t.Errorf("%s: Verify(Prove(EqualDLog())) = false", s.Name())
+		}
+
+		unequal := &DLog{Base: b.Base, X: g2.Curve().New().ScalarBaseMult(big.NewInt(1235))}
+		st, err = EqualDLog(g1, a, g2, unequal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Prove(st, x, transcript.New("test"), rand.Reader); err == nil {
+			t.Errorf("%s: Prove(EqualDLog()) of unequal logarithms: got nil error", s.Name())
+		}
+	}
+
+	if _, err := EqualDLog(G1(curve.BN256), &DLog{}, G1(curve.BLS12381), &DLog{}); err == nil {
+		t.Errorf("EqualDLog() across curves: got nil error")
+	}
+}
+
+func TestAnd(t *testing.T) {
+	g := groups["bn256 G1"]
+	a, x := randomDLog(t, g)
+	b, y := randomDLog(t, g)
+	st, err := And(g.DLogStatement(a), g.DLogStatement(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, ok := proveVerify(t, st, []any{x, y})
+	if !ok {
+		t.Errorf("Verify(Prove(And())) = false")
+	}
+	swapped, err := And(g.DLogStatement(b), g.DLogStatement(a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Verify(swapped, proof, transcript.New("test")) {
+		t.Errorf("Verify(Prove(And(a, b))) for And(b, a) = true")
+	}
+	if _, err := Prove(st, []any{x, x}, transcript.New("test"), rand.Reader); err == nil {
+		t.Errorf("Prove(And()) with one wrong witness: got nil error")
+	}
+	if _, err := Prove(st, []any{x}, transcript.New("test"), rand.Reader); err == nil {
+		t.Errorf("Prove(And()) with missing witness: got nil error")
+	}
+
+	if _, err := And(); err == nil {
+		t.Errorf("And(): got nil error")
+	}
+	if _, err := And(g.DLogStatement(a), G1(curve.BLS12381).DLogStatement(&DLog{})); err == nil {
+		t.Errorf("And() across curves: got nil error")
+	}
+}
+
+func TestOr(t *testing.T) {
+	g := groups["bn256 G1"]
+	var parts []Statement
+	var xs []*big.Int
+	for i := 0; i < 3; i++ {
+		st, x := randomDLog(t, g)
+		parts, xs = append(parts, g.DLogStatement(st)), append(xs, x)
+	}
+	st, err := Or(parts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var proofs []*Proof
+	for i, x := range xs {
+		proof, ok := proveVerify(t, st, OrWitness{Index: i, Witness: x})
+		if !ok {
+			t.Errorf("Verify(Prove(Or())) with witness %d = false", i)
+		}
+		if got, want := len(proof.Challenges), len(parts)-1; got != want {
+			t.Errorf("len(Prove(Or()).Challenges) = %d; want %d", got, want)
+		}
+		proofs = append(proofs, proof)
+	}
+
+	tampered := *proofs[0]
+	tampered.Challenges = []*big.Int{g.Field().Add(proofs[0].Challenges[0], big.NewInt(1)), proofs[0].Challenges[1]}
+	if Verify(st, &tampered, transcript.New("test")) {
+		t.Errorf("Verify(Prove(Or())) with tampered challenge = true")
+	}
+
+	if _, err := Prove(st, OrWitness{Index: 0, Witness: xs[1]}, transcript.New("test"), rand.Reader); err == nil {
+		t.Errorf("Prove(Or()) with witness for other index: got nil error")
+	}
+	for _, w := range []any{OrWitness{Index: 3, Witness: xs[0]}, xs[0]} {
+		if _, err := Prove(st, w, transcript.New("test"), rand.Reader); err == nil {
+			t.Errorf("Prove(Or(), %v): got nil error", w)
+		}
+	}
+
+	// Without any witness, only simulation succeeds, which needs control
+	// of the challenge.
+	c := g.Field().Mod(big.NewInt(99))
+	cs, chs, rs, err := st.simulate(c, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.check(cs, chs, rs, c) {
+		t.Errorf("check(simulate(c), c) = false")
+	}
+	if Verify(st, &Proof{Commitments: cs, Challenges: chs, Responses: rs}, transcript.New("test")) {
+		t.Errorf("Verify(<simulated proof>) = true")
+	}
+}
+
+func TestOpeningInSet(t *testing.T) {
+	// I know an opening (v, r) of C = v*G[0] + r*H AND v is one of {a, b},
+	// i.e. C - a*G[0] or C - b*G[0] is a multiple r*H.
+	params, err := pedersen.Setup(curve.BN256, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := G1(curve.BN256)
+	set := []*big.Int{big.NewInt(10), big.NewInt(20)}
+
+	statement := func(c *pedersen.Commitment) Statement {
+		var members []Statement
+		for _, a := range set {
+			x := g.Curve().New().Add(c.P, g.Curve().New().ScalarMult(params.G[0], new(big.Int).Neg(a)))
+			members = append(members, g.DLogStatement(&DLog{Base: params.H, X: x}))
+		}
+		in, err := Or(members...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		st, err := And(g.Representation(c.P, params.G[0], params.H), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return st
+	}
+
+	r := big.NewInt(777)
+	c, err := params.Commit([]*big.Int{set[1]}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := []any{[]*big.Int{set[1], r}, OrWitness{Index: 1, Witness: r}}
+	if _, ok := proveVerify(t, statement(c), w); !ok {
+		t.Errorf("Verify(Prove(<opening in set>)) = false")
+	}
+
+	outside, err := params.Commit([]*big.Int{big.NewInt(30)}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range set {
+		w := []any{[]*big.Int{big.NewInt(30), r}, OrWitness{Index: i, Witness: r}}
+		if _, err := Prove(statement(outside), w, transcript.New("test"), rand.Reader); err == nil {
+			t.Errorf("Prove(<opening outside set>) with index %d: got nil error", i)
+		}
+	}
+}
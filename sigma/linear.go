@@ -0,0 +1,183 @@
+package sigma
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/transcript"
+)
+
+// An equation is Y = sum_i x_i Bases[i] in a Group, over the witness scalars
+// x_0, ..., x_{n-1} of a linear statement.
+type equation struct {
+	g     *Group
+	y     curve.Point
+	bases []curve.Point
+}
+
+// eval returns sum_i xs[i] Bases[i] - c*Y, or sum_i xs[i] Bases[i] if c is
+// nil.
+func (e *equation) eval(xs []*big.Int, c *big.Int) curve.Point {
+	points := append([]curve.Point(nil), e.bases...)
+	scalars := append([]*big.Int(nil), xs...)
+	if c != nil {
+		points = append(points, e.y)
+		scalars = append(scalars, e.g.f.Sub(bigZero, c))
+	}
+	p, err := e.g.g.MultiExp(points, scalars)
+	if err != nil {
+		// Unreachable as the lengths match.
+		panic(err)
+	}
+	return p
+}
+
+// A linear statement is that the prover knows the scalars satisfying all of
+// its equations, the basis of all leaves of composed statements. Its sigma
+// protocol is the generalisation of Schnorr's to group homomorphisms: the
+// commitments are R_j = sum_i k_i Bases_j[i] for random k_i, and the
+// responses s_i = k_i + c*x_i satisfy sum_i s_i Bases_j[i] = R_j + c*Y_j.
+type linear struct {
+	f   *galois.Field
+	n   int
+	eqs []equation
+}
+
+// DLogStatement returns the Statement of the DLog in the Group, with a
+// *big.Int witness.
+func (g *Group) DLogStatement(st *DLog) Statement {
+	return &linear{f: g.f, n: 1, eqs: []equation{{g: g, y: st.X, bases: []curve.Point{st.Base}}}}
+}
+
+// Representation returns the Statement that the prover knows scalars x_i with
+// X = sum_i x_i Bases[i] in the Group, with a []*big.Int witness of the same
+// length as the Bases. For the generators G[0] and H of pedersen.Params, it
+// is knowledge of an opening (v, r) of the commitment X = v*G[0] + r*H.
+func (g *Group) Representation(x curve.Point, bases ...curve.Point) Statement {
+	return &linear{f: g.f, n: len(bases), eqs: []equation{{g: g, y: x, bases: bases}}}
+}
+
+// EqualDLog returns the Statement that the prover knows a single x with
+// a.X = x*a.Base in Group ga and b.X = x*b.Base in Group gb, with a *big.Int
+// witness. The Groups may differ, e.g. G1 and G2 of a curve.Suite, but must
+// have the same order.
+func EqualDLog(ga *Group, a *DLog, gb *Group, b *DLog) (Statement, error) {
+	if ga.f.Order().Cmp(gb.f.Order()) != 0 {
+		return nil, errors.New("groups of different order")
+	}
+	return &linear{f: ga.f, n: 1, eqs: []equation{
+		{g: ga, y: a.X, bases: []curve.Point{a.Base}},
+		{g: gb, y: b.X, bases: []curve.Point{b.Base}},
+	}}, nil
+}
+
+func (l *linear) field() *galois.Field {
+	return l.f
+}
+
+func (l *linear) counts() (int, int, int) {
+	return len(l.eqs), 0, l.n
+}
+
+func (l *linear) appendTo(t *transcript.Transcript) {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(l.n))
+	t.AppendBytes("sigma/linear", n[:])
+	for _, e := range l.eqs {
+		appendPoint(t, "sigma/linear/y", e.y)
+		for _, b := range e.bases {
+			appendPoint(t, "sigma/linear/base", b)
+		}
+	}
+}
+
+// scalars returns the witness as n scalars.
+func (l *linear) scalars(w any) ([]*big.Int, error) {
+	switch w := w.(type) {
+	case *big.Int:
+		if l.n == 1 {
+			return []*big.Int{w}, nil
+		}
+	case []*big.Int:
+		if len(w) == l.n {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("witness %T for a statement of %d scalars", w, l.n)
+}
+
+func (l *linear) random(r io.Reader) ([]*big.Int, error) {
+	xs := make([]*big.Int, l.n)
+	for i := range xs {
+		x, err := l.f.Random(r)
+		if err != nil {
+			return nil, err
+		}
+		xs[i] = x
+	}
+	return xs, nil
+}
+
+func (l *linear) commit(w any, r io.Reader) ([]curve.Point, any, error) {
+	xs, err := l.scalars(w)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, e := range l.eqs {
+		if len(e.bases) != l.n {
+			return nil, nil, fmt.Errorf("equation of %d bases for %d scalars", len(e.bases), l.n)
+		}
+		if !e.eval(xs, nil).Equal(e.y) {
+			return nil, nil, errors.New("witness does not satisfy statement")
+		}
+	}
+
+	ks, err := l.random(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitments := make([]curve.Point, len(l.eqs))
+	for j, e := range l.eqs {
+		commitments[j] = e.eval(ks, nil)
+	}
+	return commitments, ks, nil
+}
+
+func (l *linear) respond(w, state any, c *big.Int) ([]*big.Int, []*big.Int) {
+	xs, _ := l.scalars(w)
+	ks := state.([]*big.Int)
+	ss := make([]*big.Int, l.n)
+	for i := range ss {
+		ss[i] = l.f.Add(ks[i], l.f.Mul(c, xs[i]))
+	}
+	return nil, ss
+}
+
+func (l *linear) simulate(c *big.Int, r io.Reader) ([]curve.Point, []*big.Int, []*big.Int, error) {
+	ss, err := l.random(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	commitments := make([]curve.Point, len(l.eqs))
+	for j, e := range l.eqs {
+		if len(e.bases) != l.n {
+			return nil, nil, nil, fmt.Errorf("equation of %d bases for %d scalars", len(e.bases), l.n)
+		}
+		commitments[j] = e.eval(ss, c)
+	}
+	return commitments, nil, ss, nil
+}
+
+func (l *linear) check(commitments []curve.Point, _, responses []*big.Int, c *big.Int) bool {
+	for j, e := range l.eqs {
+		if len(e.bases) != l.n || !e.eval(responses, c).Equal(commitments[j]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package sigma
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/transcript"
+)
+
+// A DLog is the statement that the prover knows the discrete logarithm x of X
+// to the Base, i.e. X = x*Base.
+type DLog struct {
+	Base, X curve.Point
+}
+
+// A DLogProof is a Schnorr proof of a DLog: the commitment R = k*Base for a
+// random k, and the response S = k + c*x to the challenge c.
+type DLogProof struct {
+	R curve.Point
+	S *big.Int
+}
+
+// dlogChallenge appends the statement and commitment to the transcript and
+// returns the challenge.
+func (g *Group) dlogChallenge(st *DLog, r curve.Point, t *transcript.Transcript) *big.Int {
+	appendPoint(t, "sigma/dlog/base", st.Base)
+	appendPoint(t, "sigma/dlog/x", st.X)
+	appendPoint(t, "sigma/dlog/r", r)
+	return t.Challenge(g.f)
+}
+
+// ProveDLog returns a DLogProof of the statement with the witness x, drawing
+// the randomness of the commitment from r.
+func (g *Group) ProveDLog(st *DLog, x *big.Int, t *transcript.Transcript, r io.Reader) (*DLogProof, error) {
+	if !g.g.New().ScalarMult(st.Base, x).Equal(st.X) {
+		return nil, errors.New("witness does not satisfy statement")
+	}
+	k, err := g.f.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	R := g.g.New().ScalarMult(st.Base, k)
+	c := g.dlogChallenge(st, R, t)
+	return &DLogProof{R: R, S: g.f.Add(k, g.f.Mul(c, x))}, nil
+}
+
+// VerifyDLog reports whether the proof is valid for the statement, i.e.
+// whether S*Base = R + c*X.
+func (g *Group) VerifyDLog(st *DLog, proof *DLogProof, t *transcript.Transcript) bool {
+	if !g.canonical(proof.S) {
+		return false
+	}
+	c := g.dlogChallenge(st, proof.R, t)
+	lhs := g.g.New().ScalarMult(st.Base, proof.S)
+	rhs := g.g.New().Add(proof.R, g.g.New().ScalarMult(st.X, c))
+	return lhs.Equal(rhs)
+}
+
+// BatchVerifyDLog reports whether all proofs are valid for their respective
+// statements, each with its own transcript, with a single multi-exponentiation.
+// The individual checks S_i*Base_i - R_i - c_i*X_i = 0 are combined with
+// random weights drawn from r; if any proof is invalid, the combined check
+// passes with probability at most 1/|Field|. An error is only returned if r
+// fails.
+func (g *Group) BatchVerifyDLog(sts []*DLog, proofs []*DLogProof, ts []*transcript.Transcript, r io.Reader) (bool, error) {
+	if len(sts) != len(proofs) || len(sts) != len(ts) {
+		return false, nil
+	}
+	var points []curve.Point
+	var scalars []*big.Int
+	for i, proof := range proofs {
+		if !g.canonical(proof.S) {
+			return false, nil
+		}
+		w, err := g.f.Random(r)
+		if err != nil {
+			return false, err
+		}
+		c := g.dlogChallenge(sts[i], proof.R, ts[i])
+		points = append(points, sts[i].Base, proof.R, sts[i].X)
+		scalars = append(scalars, g.f.Mul(w, proof.S), g.f.Sub(bigZero, w), g.f.Sub(bigZero, g.f.Mul(w, c)))
+	}
+	if len(points) == 0 {
+		return true, nil
+	}
+	sum, err := g.g.MultiExp(points, scalars)
+	if err != nil {
+		return false, nil
+	}
+	return sum.Equal(g.g.New()), nil
+}
+
+// canonical reports whether x is a reduced element of the field, so that
+// responses are not malleable.
+func (g *Group) canonical(x *big.Int) bool {
+	return x != nil && x.Sign() >= 0 && x.Cmp(g.f.Order()) < 0
+}
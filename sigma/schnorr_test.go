@@ -0,0 +1,131 @@
+package sigma
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/pedersen"
+	"zkp.xyz/membership/transcript"
+)
+
+var groups = map[string]*Group{
+	"bn256 G1":     G1(curve.BN256),
+	"bn256 G2":     G2(curve.BN256),
+	"BLS12-381 G1": G1(curve.BLS12381),
+}
+
+func randomDLog(t *testing.T, g *Group) (*DLog, *big.Int) {
+	t.Helper()
+	x, err := g.Field().Random(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := g.Field().Random(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := g.Curve().New().ScalarBaseMult(k)
+	return &DLog{Base: base, X: g.Curve().New().ScalarMult(base, x)}, x
+}
+
+func TestDLog(t *testing.T) {
+	for name, g := range groups {
+		st, x := randomDLog(t, g)
+		proof, err := g.ProveDLog(st, x, transcript.New("test"), rand.Reader)
+		if err != nil {
+			t.Fatalf("%s: ProveDLog(): %v", name, err)
+		}
+		if !g.VerifyDLog(st, proof, transcript.New("test")) {
+			t.Errorf("%s: VerifyDLog(ProveDLog()) = false", name)
+		}
+		if g.VerifyDLog(st, proof, transcript.New("other")) {
+			t.Errorf("%s: VerifyDLog(ProveDLog()) with other transcript = true", name)
+		}
+
+		other, _ := randomDLog(t, g)
+		if g.VerifyDLog(&DLog{Base: st.Base, X: other.X}, proof, transcript.New("test")) {
+			t.Errorf("%s: VerifyDLog(ProveDLog()) for other X = true", name)
+		}
+		tampered := &DLogProof{R: proof.R, S: g.Field().Add(proof.S, big.NewInt(1))}
+		if g.VerifyDLog(st, tampered, transcript.New("test")) {
+			t.Errorf("%s: VerifyDLog() with tampered response = true", name)
+		}
+		unreduced := &DLogProof{R: proof.R, S: new(big.Int).Add(proof.S, g.Field().Order())}
+		if g.VerifyDLog(st, unreduced, transcript.New("test")) {
+			t.Errorf("%s: VerifyDLog() with unreduced response = true", name)
+		}
+
+		if _, err := g.ProveDLog(st, new(big.Int).Add(x, big.NewInt(1)), transcript.New("test"), rand.Reader); err == nil {
+			t.Errorf("%s: ProveDLog() with wrong witness: got nil error", name)
+		}
+	}
+}
+
+func TestBatchVerifyDLog(t *testing.T) {
+	for name, g := range groups {
+		var sts []*DLog
+		var proofs []*DLogProof
+		var ts []*transcript.Transcript
+		for i := 0; i < 4; i++ {
+			st, x := randomDLog(t, g)
+			proof, err := g.ProveDLog(st, x, transcript.New("test"), rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sts, proofs, ts = append(sts, st), append(proofs, proof), append(ts, transcript.New("test"))
+		}
+		transcripts := func() []*transcript.Transcript {
+			fresh := make([]*transcript.Transcript, len(sts))
+			for i := range fresh {
+				fresh[i] = transcript.New("test")
+			}
+			return fresh
+		}
+
+		if ok, err := g.BatchVerifyDLog(sts, proofs, ts, rand.Reader); err != nil || !ok {
+			t.Errorf("%s: BatchVerifyDLog() = %v, %v; want true, nil", name, ok, err)
+		}
+		sts[0], sts[1] = sts[1], sts[0]
+		if ok, err := g.BatchVerifyDLog(sts, proofs, transcripts(), rand.Reader); err != nil || ok {
+			t.Errorf("%s: BatchVerifyDLog() with swapped statements = %v, %v; want false, nil", name, ok, err)
+		}
+		if ok, _ := g.BatchVerifyDLog(sts[1:], proofs, transcripts(), rand.Reader); ok {
+			t.Errorf("%s: BatchVerifyDLog() with missing statement = true", name)
+		}
+	}
+}
+
+func TestDLogPedersenBlinder(t *testing.T) {
+	// Knowledge of the blinder r of C = v*G[0] + r*H for a known v is a DLog
+	// of C - v*G[0] to H.
+	params, err := pedersen.Setup(curve.BN256, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := G1(curve.BN256)
+	v := big.NewInt(42)
+	r, err := params.RandomBlinder(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := params.Commit([]*big.Int{v}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := g.Curve().New().Add(c.P, g.Curve().New().ScalarMult(params.G[0], new(big.Int).Neg(v)))
+	st := &DLog{Base: params.H, X: x}
+
+	tr := transcript.New("test")
+	tr.AppendBytes("commitment", c.P.Marshal())
+	proof, err := g.ProveDLog(st, r, tr, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr = transcript.New("test")
+	tr.AppendBytes("commitment", c.P.Marshal())
+	if !g.VerifyDLog(st, proof, tr) {
+		t.Errorf("VerifyDLog(<Pedersen blinder>) = false")
+	}
+}
@@ -0,0 +1,59 @@
+// Package sigma implements non-interactive sigma protocols, zero-knowledge
+// proofs of knowledge of discrete logarithms, made non-interactive with the
+// Fiat-Shamir transform over a transcript.Transcript.
+//
+// Besides Schnorr proofs of a DLog, Statements about linear relations, such as
+// knowledge of a commitment opening or equal discrete logarithms across
+// groups, can be composed with And and Or, and proven with Prove.
+//
+// Proofs are bound to everything appended to their Transcript before proving,
+// so callers SHOULD append the context of a proof, e.g. the commitments whose
+// openings are proven, and MUST append the same messages when verifying.
+package sigma
+
+import (
+	"math/big"
+
+	"zkp.xyz/membership/curve"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/transcript"
+)
+
+var bigZero = big.NewInt(0)
+
+// A Group is a group of prime order in which statements are made, G1 or G2 of
+// a curve.Suite, together with its scalar field.
+type Group struct {
+	g curve.Group
+	f *galois.Field
+}
+
+func newGroup(s curve.Suite, g curve.Group) *Group {
+	return &Group{g: g, f: s.ScalarField()}
+}
+
+// G1 returns G1 of the Suite.
+func G1(s curve.Suite) *Group {
+	return newGroup(s, s.G1())
+}
+
+// G2 returns G2 of the Suite.
+func G2(s curve.Suite) *Group {
+	return newGroup(s, s.G2())
+}
+
+// Field returns the scalar field of the Group, to which witnesses and
+// responses belong.
+func (g *Group) Field() *galois.Field {
+	return g.f
+}
+
+// Curve returns the curve.Group.
+func (g *Group) Curve() curve.Group {
+	return g.g
+}
+
+// appendPoint appends the encoding of p to the transcript.
+func appendPoint(t *transcript.Transcript, label string, p curve.Point) {
+	t.AppendBytes(label, p.Marshal())
+}
@@ -0,0 +1,269 @@
+// Package sumcheck implements the sum-check protocol of Lund, Fortnow, Karloff
+// and Nisan, in which a prover convinces a verifier of the sum
+//
+//	H = sum_{b in {0,1}^n} g(b)
+//
+// of a multivariate polynomial g over the Boolean hypercube. In round j the
+// prover sends the univariate g_j(X) = sum g(r_0, ..., r_{j-1}, X, b) over the
+// remaining Boolean b, and the verifier checks g_j(0) + g_j(1) against the
+// previous claim before drawing the challenge r_j. After n rounds the claim
+// g(r_0, ..., r_{n-1}) = g_{n-1}(r_{n-1}) is left to an oracle for g, e.g. a
+// polynomial commitment or, in GKR, another sum-check.
+//
+// A cheating prover succeeds with probability at most sum_j d_j / |Field|
+// for the degrees d_j of g in every variable.
+package sumcheck
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/polynomial/multivariate"
+	"zkp.xyz/membership/transcript"
+)
+
+// ErrRejected is returned by the Verifier when a message of the prover is
+// inconsistent with its claim.
+var ErrRejected = errors.New("sum-check rejected")
+
+var (
+	bigZero = big.NewInt(0)
+	bigOne  = big.NewInt(1)
+)
+
+// A Prover holds g with the variables of completed rounds bound to their
+// challenges.
+type Prover struct {
+	f   *galois.Field
+	cur *multivariate.Polynomial
+}
+
+// NewProver returns a Prover for the sum of g over the Boolean hypercube.
+func NewProver(g *multivariate.Polynomial, f *galois.Field) *Prover {
+	return &Prover{f: f, cur: g}
+}
+
+// Sum returns the sum over the Boolean hypercube of the variables that aren't
+// bound yet, i.e. H before the first round.
+func (pr *Prover) Sum() *big.Int {
+	if pr.Rounds() == 0 {
+		y, _ := pr.cur.Evaluate(nil, pr.f)
+		return y
+	}
+	m := pr.message()
+	return pr.f.Add(m.Evaluate(bigZero, pr.f), m.Evaluate(bigOne, pr.f))
+}
+
+// Rounds returns the number of remaining rounds.
+func (pr *Prover) Rounds() int {
+	return pr.cur.NumVars()
+}
+
+// Message returns the prover's message g_j of the current round, or an error
+// if all rounds are completed.
+func (pr *Prover) Message() (*polynomial.Polynomial, error) {
+	if pr.Rounds() == 0 {
+		return nil, errors.New("no rounds remaining")
+	}
+	return pr.message(), nil
+}
+
+// message returns sum_b cur(X, b) over Boolean b, using that every remaining
+// variable with exponent 0 contributes a factor of 2 and every other one a
+// factor of 1.
+func (pr *Prover) message() *polynomial.Polynomial {
+	m := polynomial.NewZeroPolynomial(pr.cur.DegreeIn(0))
+	two := big.NewInt(2)
+	for _, t := range pr.cur.Terms() {
+		c := t.Coefficient
+		for _, e := range t.Exponents[1:] {
+			if e == 0 {
+				c = pr.f.Mul(c, two)
+			}
+		}
+		(*m)[t.Exponents[0]] = pr.f.Add((*m)[t.Exponents[0]], c)
+	}
+	return m.Normalize(pr.f)
+}
+
+// Bind completes the current round by fixing its variable to the verifier's
+// challenge r.
+func (pr *Prover) Bind(r *big.Int) error {
+	if pr.Rounds() == 0 {
+		return errors.New("no rounds remaining")
+	}
+	cur, err := pr.cur.PartialEvaluate(0, r, pr.f)
+	if err != nil {
+		return err
+	}
+	pr.cur = cur
+	return nil
+}
+
+// A Verifier checks the messages of an interactive sum-check.
+type Verifier struct {
+	f          *galois.Field
+	degrees    []int
+	claim      *big.Int
+	challenges []*big.Int
+}
+
+// NewVerifier returns a Verifier of the claimed sum of a polynomial with the
+// specified degree in each of its variables, e.g. from DegreeBounds.
+func NewVerifier(degrees []int, claim *big.Int, f *galois.Field) *Verifier {
+	return &Verifier{
+		f:       f,
+		degrees: append([]int(nil), degrees...),
+		claim:   f.Mod(new(big.Int).Set(claim)),
+	}
+}
+
+// Receive checks the prover's message of the current round and returns the
+// challenge for it, drawn from r. It returns an error wrapping ErrRejected if
+// the message exceeds the degree bound or doesn't sum to the current claim.
+func (v *Verifier) Receive(m *polynomial.Polynomial, r io.Reader) (*big.Int, error) {
+	m = m.Normalize(v.f)
+	if err := v.check(m); err != nil {
+		return nil, err
+	}
+	c, err := v.f.Random(r)
+	if err != nil {
+		return nil, err
+	}
+	v.bind(m, c)
+	return c, nil
+}
+
+// check returns an error if m isn't a valid message for the current round.
+func (v *Verifier) check(m *polynomial.Polynomial) error {
+	j := len(v.challenges)
+	if j == len(v.degrees) {
+		return errors.New("no rounds remaining")
+	}
+	if d := m.Degree(); d > v.degrees[j] {
+		return fmt.Errorf("%w: round %d message of degree %d > %d", ErrRejected, j, d, v.degrees[j])
+	}
+	if s := v.f.Add(m.Evaluate(bigZero, v.f), m.Evaluate(bigOne, v.f)); s.Cmp(v.claim) != 0 {
+		return fmt.Errorf("%w: round %d message sums to %v; want %v", ErrRejected, j, s, v.claim)
+	}
+	return nil
+}
+
+func (v *Verifier) bind(m *polynomial.Polynomial, c *big.Int) {
+	v.claim = m.Evaluate(c, v.f)
+	v.challenges = append(v.challenges, c)
+}
+
+// Done reports whether all rounds are completed.
+func (v *Verifier) Done() bool {
+	return len(v.challenges) == len(v.degrees)
+}
+
+// Challenges returns the challenges of the completed rounds.
+func (v *Verifier) Challenges() []*big.Int {
+	return append([]*big.Int(nil), v.challenges...)
+}
+
+// Claim returns the current claim: the sum over the remaining variables of g
+// with the completed rounds bound to their challenges. Once Done, this is the
+// claimed value of g at Challenges(), to be checked by an oracle.
+func (v *Verifier) Claim() *big.Int {
+	return new(big.Int).Set(v.claim)
+}
+
+// A Proof is a non-interactive sum-check, with the challenges derived with
+// Fiat-Shamir.
+type Proof struct {
+	Sum    *big.Int
+	Rounds []*polynomial.Polynomial
+}
+
+// appendMessage appends the coefficients of m, padded to its degree bound d.
+func appendMessage(t *transcript.Transcript, m *polynomial.Polynomial, d int) {
+	for i := 0; i <= d; i++ {
+		c := bigZero
+		if i < len(*m) {
+			c = (*m)[i]
+		}
+		t.AppendScalar("coefficient", c)
+	}
+}
+
+// Prove returns a Proof of the sum of g over the Boolean hypercube, and the
+// challenges at which g remains to be opened. The transcript SHOULD already
+// bind g, e.g. by a commitment, and continues with the messages of the proof.
+func Prove(g *multivariate.Polynomial, f *galois.Field, t *transcript.Transcript) (*Proof, []*big.Int, error) {
+	pr := NewProver(g, f)
+	degrees := g.DegreeBounds()
+
+	proof := &Proof{Sum: pr.Sum()}
+	t.AppendScalar("sum", proof.Sum)
+	var challenges []*big.Int
+	for j := range degrees {
+		m, err := pr.Message()
+		if err != nil {
+			return nil, nil, err
+		}
+		appendMessage(t, m, degrees[j])
+		c := t.Challenge(f)
+		if err := pr.Bind(c); err != nil {
+			return nil, nil, err
+		}
+		proof.Rounds = append(proof.Rounds, m)
+		challenges = append(challenges, c)
+	}
+	return proof, challenges, nil
+}
+
+// Verify checks the proof for a polynomial with the specified degree in each
+// variable, with a transcript in the state passed to Prove. It returns the
+// challenges and the claimed evaluation of g at them, which the caller MUST
+// check, or an error wrapping ErrRejected. The sum and the coefficients of the
+// rounds must be in [0, f.Order()), so that every proof has a single encoding.
+func Verify(degrees []int, proof *Proof, f *galois.Field, t *transcript.Transcript) ([]*big.Int, *big.Int, error) {
+	if len(proof.Rounds) != len(degrees) {
+		return nil, nil, fmt.Errorf("%w: %d rounds; want %d", ErrRejected, len(proof.Rounds), len(degrees))
+	}
+	if !canonical(f, proof.Sum) {
+		return nil, nil, fmt.Errorf("%w: sum not in [0, %v)", ErrRejected, f.Order())
+	}
+	v := NewVerifier(degrees, proof.Sum, f)
+	t.AppendScalar("sum", proof.Sum)
+	for j, m := range proof.Rounds {
+		if m == nil || !canonical(f, *m...) {
+			return nil, nil, fmt.Errorf("%w: round %d coefficient not in [0, %v)", ErrRejected, j, f.Order())
+		}
+		m = m.Normalize(f)
+		if err := v.check(m); err != nil {
+			return nil, nil, err
+		}
+		appendMessage(t, m, degrees[j])
+		v.bind(m, t.Challenge(f))
+	}
+	return v.Challenges(), v.Claim(), nil
+}
+
+// canonical reports whether all xs are non-nil and in [0, f.Order()).
+func canonical(f *galois.Field, xs ...*big.Int) bool {
+	for _, x := range xs {
+		if x == nil || x.Sign() < 0 || x.Cmp(f.Order()) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyPolynomial is equivalent to Verify, but evaluates g itself to check
+// the final claim, reporting whether the proof is valid for g.
+func VerifyPolynomial(g *multivariate.Polynomial, proof *Proof, f *galois.Field, t *transcript.Transcript) bool {
+	point, claim, err := Verify(g.DegreeBounds(), proof, f, t)
+	if err != nil {
+		return false
+	}
+	y, err := g.Evaluate(point, f)
+	return err == nil && y.Cmp(claim) == 0
+}
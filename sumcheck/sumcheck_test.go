@@ -0,0 +1,216 @@
+package sumcheck
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/polynomial/multivariate"
+	"zkp.xyz/membership/transcript"
+)
+
+var f = galois.NewField(bn256.Order)
+
+func ints(xs ...int64) []*big.Int {
+	bs := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		bs[i] = big.NewInt(x)
+	}
+	return bs
+}
+
+// testPolynomials returns polynomials in 0 to 3 variables, including the
+// product of two multilinear extensions as used by GKR.
+func testPolynomials(t *testing.T) []*multivariate.Polynomial {
+	t.Helper()
+	a, err := multivariate.MultilinearFromEvaluations(ints(1, 2, 3, 4, 5, 6, 7, 8), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := multivariate.MultilinearFromEvaluations(ints(3, 0, -1, 9, 2, 2, 4, 1), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ab, err := a.Mul(b, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x0 := multivariate.Variable(2, 0)
+	x0cubed, _ := x0.Mul(x0, f)
+	x0cubed, _ = x0cubed.Mul(x0, f)
+	return []*multivariate.Polynomial{
+		multivariate.Constant(0, big.NewInt(42), f),
+		multivariate.Constant(2, big.NewInt(5), f),
+		x0cubed,
+		a,
+		ab,
+	}
+}
+
+// hypercubeSum returns the sum of g over the Boolean hypercube by brute force.
+func hypercubeSum(t *testing.T, g *multivariate.Polynomial) *big.Int {
+	t.Helper()
+	n := g.NumVars()
+	sum := big.NewInt(0)
+	for b := 0; b < 1<<n; b++ {
+		xs := make([]*big.Int, n)
+		for i := range xs {
+			xs[i] = big.NewInt(int64(b>>i) & 1)
+		}
+		y, err := g.Evaluate(xs, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum = f.Add(sum, y)
+	}
+	return sum
+}
+
+func TestInteractive(t *testing.T) {
+	for _, g := range testPolynomials(t) {
+		pr := NewProver(g, f)
+		want := hypercubeSum(t, g)
+		if got := pr.Sum(); got.Cmp(want) != 0 {
+			t.Errorf("NewProver(%v).Sum() = %v; want %v", g, got, want)
+		}
+
+		v := NewVerifier(g.DegreeBounds(), pr.Sum(), f)
+		for pr.Rounds() > 0 {
+			m, err := pr.Message()
+			if err != nil {
+				t.Fatalf("Message(): %v", err)
+			}
+			r, err := v.Receive(m, rand.Reader)
+			if err != nil {
+				t.Fatalf("%v: Receive(%v): %v", g, m, err)
+			}
+			if err := pr.Bind(r); err != nil {
+				t.Fatalf("Bind(): %v", err)
+			}
+		}
+		if !v.Done() {
+			t.Fatalf("%v: Done() = false after all rounds", g)
+		}
+		y, err := g.Evaluate(v.Challenges(), f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if y.Cmp(v.Claim()) != 0 {
+			t.Errorf("%v: final Claim() = %v; want g(Challenges()) = %v", g, v.Claim(), y)
+		}
+	}
+}
+
+func TestVerifierRejects(t *testing.T) {
+	g := testPolynomials(t)[4]
+	pr := NewProver(g, f)
+	m, err := pr.Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrongSum := NewVerifier(g.DegreeBounds(), f.Add(pr.Sum(), big.NewInt(1)), f)
+	if _, err := wrongSum.Receive(m, rand.Reader); !errors.Is(err, ErrRejected) {
+		t.Errorf("Receive() with wrong claim: got err %v; want %v", err, ErrRejected)
+	}
+
+	// Adding X^2(X-1) keeps g_0(0) + g_0(1) but exceeds the degree bound.
+	tooHigh := m.Add(polynomial.NewPolynomialFromCoefficients([]int64{0, 0, -1, 1}), f)
+	v := NewVerifier(g.DegreeBounds(), pr.Sum(), f)
+	if _, err := v.Receive(tooHigh, rand.Reader); !errors.Is(err, ErrRejected) {
+		t.Errorf("Receive(<degree %d>): got err %v; want %v", tooHigh.Degree(), err, ErrRejected)
+	}
+}
+
+func TestProveVerify(t *testing.T) {
+	for _, g := range testPolynomials(t) {
+		proof, challenges, err := Prove(g, f, transcript.New("test"))
+		if err != nil {
+			t.Fatalf("Prove(%v): %v", g, err)
+		}
+		if want := hypercubeSum(t, g); proof.Sum.Cmp(want) != 0 {
+			t.Errorf("Prove(%v).Sum = %v; want %v", g, proof.Sum, want)
+		}
+
+		point, claim, err := Verify(g.DegreeBounds(), proof, f, transcript.New("test"))
+		if err != nil {
+			t.Fatalf("Verify(Prove(%v)): %v", g, err)
+		}
+		for i := range point {
+			if point[i].Cmp(challenges[i]) != 0 {
+				t.Errorf("Verify(Prove(%v)) challenge %d = %v; want %v", g, i, point[i], challenges[i])
+			}
+		}
+		if y, _ := g.Evaluate(point, f); y.Cmp(claim) != 0 {
+			t.Errorf("Verify(Prove(%v)) claim = %v; want %v", g, claim, y)
+		}
+		if !VerifyPolynomial(g, proof, f, transcript.New("test")) {
+			t.Errorf("VerifyPolynomial(%v, Prove()) = false", g)
+		}
+		// Only challenges for non-constant polynomials affect the claim.
+		if g.Degree() > 0 && VerifyPolynomial(g, proof, f, transcript.New("other")) {
+			t.Errorf("VerifyPolynomial(%v) with other transcript = true", g)
+		}
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	g := testPolynomials(t)[4]
+	other := testPolynomials(t)[3]
+
+	tests := []struct {
+		name   string
+		tamper func(*Proof)
+	}{
+		{
+			name:   "sum",
+			tamper: func(p *Proof) { p.Sum = f.Add(p.Sum, big.NewInt(1)) },
+		},
+		{
+			name: "consistent round",
+			tamper: func(p *Proof) {
+				// Adding 1 - 2X keeps g_1(0) + g_1(1) but changes g_1(r_1).
+				p.Rounds[1] = p.Rounds[1].Add(polynomial.NewPolynomialFromCoefficients([]int64{1, -2}), f)
+			},
+		},
+		{
+			name:   "missing round",
+			tamper: func(p *Proof) { p.Rounds = p.Rounds[:2] },
+		},
+		{
+			name:   "sum + r",
+			tamper: func(p *Proof) { p.Sum = new(big.Int).Add(p.Sum, f.Order()) },
+		},
+		{
+			name: "round coefficient - r",
+			tamper: func(p *Proof) {
+				m := p.Rounds[0].Clone()
+				(*m)[0] = new(big.Int).Sub((*m)[0], f.Order())
+				p.Rounds[0] = m
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		proof, _, err := Prove(g, f, transcript.New("test"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tt.tamper(proof)
+		if VerifyPolynomial(g, proof, f, transcript.New("test")) {
+			t.Errorf("VerifyPolynomial() with tampered %s = true", tt.name)
+		}
+	}
+
+	proof, _, err := Prove(g, f, transcript.New("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyPolynomial(other, proof, f, transcript.New("test")) {
+		t.Errorf("VerifyPolynomial(<other polynomial>, proof) = true")
+	}
+}
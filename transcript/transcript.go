@@ -0,0 +1,100 @@
+// Package transcript implements Fiat-Shamir transcripts, deriving verifier
+// challenges from all prior messages of a proof to make it non-interactive.
+package transcript
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+// Type tags of the encoded messages.
+const (
+	tagDomain byte = iota + 1
+	tagBytes
+	tagG1
+	tagG2
+	tagScalar
+	tagChallenge
+)
+
+// A Transcript accumulates the messages of a proof. Every message is encoded
+// with a type tag, its label, and its length, so distinct sequences of
+// messages never share an encoding. Both prover and verifier MUST append the
+// same messages in the same order.
+type Transcript struct {
+	h hash.Hash
+}
+
+// New returns a Transcript for the specified domain, which separates
+// challenges of different proof systems.
+func New(domain string) *Transcript {
+	t := &Transcript{h: sha256.New()}
+	t.append(tagDomain, "", []byte(domain))
+	return t
+}
+
+func (t *Transcript) append(tag byte, label string, data []byte) {
+	var n [4]byte
+	t.h.Write([]byte{tag})
+
+	binary.BigEndian.PutUint32(n[:], uint32(len(label)))
+	t.h.Write(n[:])
+	t.h.Write([]byte(label))
+
+	binary.BigEndian.PutUint32(n[:], uint32(len(data)))
+	t.h.Write(n[:])
+	t.h.Write(data)
+}
+
+// AppendBytes appends arbitrary data to the transcript.
+func (t *Transcript) AppendBytes(label string, data []byte) {
+	t.append(tagBytes, label, data)
+}
+
+// AppendG1 appends the uncompressed, affine encoding of p to the transcript.
+func (t *Transcript) AppendG1(label string, p *bn256.G1) {
+	t.append(tagG1, label, new(bn256.G1).Set(p).Marshal())
+}
+
+// AppendG2 appends the uncompressed, affine encoding of p to the transcript.
+func (t *Transcript) AppendG2(label string, p *bn256.G2) {
+	t.append(tagG2, label, new(bn256.G2).Set(p).Marshal())
+}
+
+// AppendScalar appends x to the transcript, encoded by its sign followed by its
+// absolute value in minimal big-endian form. Callers SHOULD reduce x into its
+// field before appending, as congruent values have distinct encodings.
+func (t *Transcript) AppendScalar(label string, x *big.Int) {
+	t.append(tagScalar, label, append([]byte{byte(x.Sign() + 1)}, x.Bytes()...))
+}
+
+// Challenge returns a challenge from f derived from all messages appended so
+// far. The challenge itself is then appended, so successive calls return
+// distinct challenges.
+//
+// 256 bits beyond the size of the field are drawn before reduction, making the
+// bias of the result negligible.
+func (t *Transcript) Challenge(f *galois.Field) *big.Int {
+	seed := t.h.Sum(nil)
+	order := f.Order()
+
+	var buf []byte
+	for i := uint32(0); len(buf)*8 < order.BitLen()+256; i++ {
+		h := sha256.New()
+		h.Write(seed)
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], i)
+		h.Write(n[:])
+		buf = h.Sum(buf)
+	}
+
+	c := new(big.Int).SetBytes(buf)
+	c.Mod(c, order)
+	t.append(tagChallenge, "", c.Bytes())
+	return c
+}
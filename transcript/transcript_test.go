@@ -0,0 +1,91 @@
+package transcript
+
+import (
+	"math/big"
+	"testing"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/galois"
+)
+
+var f = galois.NewField(bn256.Order)
+
+type message struct {
+	label string
+	g1    int64
+	g2    int64
+	x     int64
+	bytes string
+}
+
+func challenge(domain string, msgs []message) *big.Int {
+	t := New(domain)
+	for _, m := range msgs {
+		switch {
+		case m.g1 != 0:
+			t.AppendG1(m.label, new(bn256.G1).ScalarBaseMult(big.NewInt(m.g1)))
+		case m.g2 != 0:
+			t.AppendG2(m.label, new(bn256.G2).ScalarBaseMult(big.NewInt(m.g2)))
+		case m.bytes != "":
+			t.AppendBytes(m.label, []byte(m.bytes))
+		default:
+			t.AppendScalar(m.label, big.NewInt(m.x))
+		}
+	}
+	return t.Challenge(f)
+}
+
+func TestChallengeDeterministic(t *testing.T) {
+	msgs := []message{
+		{label: "commitment", g1: 42},
+		{label: "quotient", g2: 7},
+		{label: "z", x: 5},
+		{label: "data", bytes: "hello"},
+	}
+
+	a, b := challenge("test", msgs), challenge("test", msgs)
+	if a.Cmp(b) != 0 {
+		t.Errorf("identical transcripts: got distinct challenges %v and %v", a, b)
+	}
+	if a.Cmp(f.Order()) >= 0 || a.Sign() < 0 {
+		t.Errorf("Challenge() = %v not in field", a)
+	}
+}
+
+func TestChallengeChanges(t *testing.T) {
+	base := []message{
+		{label: "a", g1: 42},
+		{label: "b", x: 5},
+	}
+	want := challenge("test", base)
+
+	tests := []struct {
+		name   string
+		domain string
+		msgs   []message
+	}{
+		{name: "domain", domain: "other", msgs: base},
+		{name: "label", domain: "test", msgs: []message{{label: "x", g1: 42}, {label: "b", x: 5}}},
+		{name: "G1 value", domain: "test", msgs: []message{{label: "a", g1: 43}, {label: "b", x: 5}}},
+		{name: "scalar", domain: "test", msgs: []message{{label: "a", g1: 42}, {label: "b", x: 6}}},
+		{name: "negative scalar", domain: "test", msgs: []message{{label: "a", g1: 42}, {label: "b", x: -5}}},
+		{name: "order", domain: "test", msgs: []message{{label: "b", x: 5}, {label: "a", g1: 42}}},
+		{name: "type", domain: "test", msgs: []message{{label: "a", g2: 42}, {label: "b", x: 5}}},
+		{name: "extra message", domain: "test", msgs: append(base, message{label: "c", x: 0})},
+		{name: "label/data boundary", domain: "test", msgs: []message{{label: "a", g1: 42}, {label: "", bytes: "b"}}},
+	}
+
+	for _, tt := range tests {
+		if got := challenge(tt.domain, tt.msgs); got.Cmp(want) == 0 {
+			t.Errorf("changed %s: got unchanged challenge %v", tt.name, got)
+		}
+	}
+}
+
+func TestSuccessiveChallenges(t *testing.T) {
+	tr := New("test")
+	tr.AppendScalar("x", big.NewInt(1))
+	if a, b := tr.Challenge(f), tr.Challenge(f); a.Cmp(b) == 0 {
+		t.Errorf("successive challenges are identical: %v", a)
+	}
+}
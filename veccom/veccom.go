@@ -0,0 +1,189 @@
+// Package veccom implements vector commitments based on KZG polynomial
+// commitments.
+//
+// A vector (v_0, ..., v_{n-1}) is encoded as the unique polynomial p of degree
+// < n with p(w^i) = v_i over a Domain of nth roots of unity generated by w.
+// Committing to the vector is committing to p, and proving that position i
+// holds v_i amounts to opening the commitment at w^i. A proof for any number
+// of positions is a single group element.
+package veccom
+
+import (
+	"fmt"
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+// A Scheme holds the public parameters shared by provers and verifiers: an SRS
+// and a Domain, the size of which is the maximum length of committed vectors.
+type Scheme struct {
+	srs    *kzg.SRS
+	vk     *kzg.VerifierKey
+	domain *polynomial.Domain
+}
+
+// New returns a Scheme for vectors of up to d.Size() values, which requires the
+// SRS to support polynomials of degree d.Size()-1. The Domain MUST be over
+// kzg.Field.
+func New(srs *kzg.SRS, d *polynomial.Domain) (*Scheme, error) {
+	if d.Size()-1 > uint64(srs.MaxDegree()) {
+		return nil, fmt.Errorf("domain size %d exceeds SRS max degree %d + 1", d.Size(), srs.MaxDegree())
+	}
+	return &Scheme{srs: srs, vk: srs.VerifierKey(), domain: d}, nil
+}
+
+// Size returns the maximum length of vectors committed to with the Scheme.
+func (s *Scheme) Size() int {
+	return int(s.domain.Size())
+}
+
+// SRS returns the SRS of the Scheme.
+func (s *Scheme) SRS() *kzg.SRS {
+	return s.srs
+}
+
+// Domain returns the Domain of the Scheme, whose element w^i is the point of
+// position i.
+func (s *Scheme) Domain() *polynomial.Domain {
+	return s.domain
+}
+
+// A Vector is a committed vector of field elements.
+type Vector struct {
+	scheme     *Scheme
+	values     []*big.Int
+	poly       *polynomial.Polynomial
+	commitment *kzg.Commitment
+}
+
+// A Proof attests that a position of a committed vector holds a value. The
+// Quotient is [q(s)]_2 for q(v) = (p(v) - p(w^i)) / (v - w^i).
+type Proof struct {
+	Quotient *bn256.G2
+}
+
+// A BatchProof attests that multiple positions of a committed vector hold the
+// respective values; see kzg.BatchProof.
+type BatchProof struct {
+	Quotient *bn256.G2
+}
+
+// Commit commits to the values, which are reduced into kzg.Field. Vectors
+// shorter than s.Size() are padded with zeros, i.e. proofs can be created for
+// all positions in [0, s.Size()).
+func (s *Scheme) Commit(values []*big.Int) (*Vector, error) {
+	if len(values) > s.Size() {
+		return nil, fmt.Errorf("vector of %d values exceeds scheme size %d", len(values), s.Size())
+	}
+	padded := make([]*big.Int, s.Size())
+	for i := range padded {
+		padded[i] = new(big.Int)
+		if i < len(values) {
+			padded[i].Mod(values[i], kzg.Field.Order())
+		}
+	}
+
+	poly := polynomial.NewPolynomial(s.domain.IFFT(padded))
+	c, err := kzg.Commit(s.srs, poly)
+	if err != nil {
+		return nil, fmt.Errorf("committing to vector of %d values: %v", len(values), err)
+	}
+	return &Vector{scheme: s, values: padded, poly: poly, commitment: c}, nil
+}
+
+// Commitment returns the commitment to the Vector, which can be shared
+// publicly.
+func (v *Vector) Commitment() *kzg.Commitment {
+	return v.commitment
+}
+
+// Polynomial returns the polynomial interpolating the Vector over the Domain,
+// which MUST NOT be modified.
+func (v *Vector) Polynomial() *polynomial.Polynomial {
+	return v.poly
+}
+
+// Value returns the value at position i.
+func (v *Vector) Value(i int) *big.Int {
+	return new(big.Int).Set(v.values[i])
+}
+
+// ProvePosition returns a Proof that position i holds v.Value(i).
+func (v *Vector) ProvePosition(i int) (*Proof, error) {
+	if err := v.scheme.checkPositions([]int{i}); err != nil {
+		return nil, err
+	}
+	proof, err := v.scheme.srs.Open(v.poly, v.scheme.domain.Element(uint64(i)))
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{Quotient: proof.Quotient}, nil
+}
+
+// ProvePositions returns a BatchProof that the distinct positions hold their
+// respective values. There can be at most s.Size()-1 positions, or fewer if
+// the SRS used by the Scheme has a lower max degree.
+func (v *Vector) ProvePositions(positions []int) (*BatchProof, error) {
+	if err := v.scheme.checkPositions(positions); err != nil {
+		return nil, err
+	}
+	proof, err := v.scheme.srs.OpenBatch(v.poly, v.scheme.points(positions))
+	if err != nil {
+		return nil, err
+	}
+	return &BatchProof{Quotient: proof.Quotient}, nil
+}
+
+// VerifyPosition reports whether the proof shows that position i of the vector
+// committed to by c holds value.
+func (s *Scheme) VerifyPosition(c *kzg.Commitment, i int, value *big.Int, proof *Proof) bool {
+	if s.checkPositions([]int{i}) != nil {
+		return false
+	}
+	return kzg.Verify(s.vk, c, &kzg.Proof{
+		Z:        s.domain.Element(uint64(i)),
+		Y:        value,
+		Quotient: proof.Quotient,
+	})
+}
+
+// VerifyPositions reports whether the proof shows that the positions of the
+// vector committed to by c hold the respective values.
+func (s *Scheme) VerifyPositions(c *kzg.Commitment, positions []int, values []*big.Int, proof *BatchProof) bool {
+	if len(positions) != len(values) || s.checkPositions(positions) != nil {
+		return false
+	}
+	return s.srs.VerifyOpenBatch(c.G1(), &kzg.BatchProof{
+		Zs:       s.points(positions),
+		Ys:       values,
+		Quotient: proof.Quotient,
+	})
+}
+
+// checkPositions returns an error if any position is out of range or
+// repeated.
+func (s *Scheme) checkPositions(positions []int) error {
+	seen := make(map[int]bool)
+	for _, i := range positions {
+		if i < 0 || i >= s.Size() {
+			return fmt.Errorf("position %d out of range [0, %d)", i, s.Size())
+		}
+		if seen[i] {
+			return fmt.Errorf("repeated position %d", i)
+		}
+		seen[i] = true
+	}
+	return nil
+}
+
+// points returns the elements of the Domain at the positions.
+func (s *Scheme) points(positions []int) []*big.Int {
+	zs := make([]*big.Int, len(positions))
+	for j, i := range positions {
+		zs[j] = s.domain.Element(uint64(i))
+	}
+	return zs
+}
@@ -0,0 +1,145 @@
+package veccom
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+)
+
+func newScheme(t *testing.T, size uint64) *Scheme {
+	t.Helper()
+	d, err := polynomial.NewDomain(kzg.Field, size, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(%d): %v", size, err)
+	}
+	s, err := New(kzg.NewSRS(big.NewInt(1337), int(size)), d)
+	if err != nil {
+		t.Fatalf("New(): %v", err)
+	}
+	return s
+}
+
+func bigInts(xs ...int64) []*big.Int {
+	ys := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		ys[i] = big.NewInt(x)
+	}
+	return ys
+}
+
+func TestProveVerifyPosition(t *testing.T) {
+	s := newScheme(t, 8)
+
+	tests := []struct {
+		values []int64
+	}{
+		{values: []int64{}},
+		{values: []int64{42}},
+		{values: []int64{1, 2, 3}},
+		{values: []int64{5, -1, 0, 7, 11, 13, 17, 19}},
+	}
+
+	for _, tt := range tests {
+		vec, err := s.Commit(bigInts(tt.values...))
+		if err != nil {
+			t.Fatalf("Commit(%v): %v", tt.values, err)
+		}
+		c := vec.Commitment()
+
+		for i := 0; i < s.Size(); i++ {
+			want := new(big.Int)
+			if i < len(tt.values) {
+				want.Mod(big.NewInt(tt.values[i]), kzg.Field.Order())
+			}
+			if got := vec.Value(i); got.Cmp(want) != 0 {
+				t.Errorf("Commit(%v).Value(%d) = %v; want %v", tt.values, i, got, want)
+			}
+
+			proof, err := vec.ProvePosition(i)
+			if err != nil {
+				t.Fatalf("Commit(%v).ProvePosition(%d): %v", tt.values, i, err)
+			}
+			if !s.VerifyPosition(c, i, want, proof) {
+				t.Errorf("VerifyPosition(Commit(%v), %d, %v) = false; want true", tt.values, i, want)
+			}
+			if wrong := new(big.Int).Add(want, big.NewInt(1)); s.VerifyPosition(c, i, wrong, proof) {
+				t.Errorf("VerifyPosition(Commit(%v), %d, <wrong value>) = true; want false", tt.values, i)
+			}
+			if other := (i + 1) % s.Size(); vec.Value(other).Cmp(want) != 0 && s.VerifyPosition(c, other, want, proof) {
+				t.Errorf("VerifyPosition(Commit(%v), <wrong position>) = true; want false", tt.values)
+			}
+		}
+	}
+}
+
+func TestProveVerifyPositions(t *testing.T) {
+	s := newScheme(t, 8)
+	vec, err := s.Commit(bigInts(5, -1, 0, 7, 11, 13, 17, 19))
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	c := vec.Commitment()
+
+	for _, positions := range [][]int{{0}, {3, 1}, {0, 2, 4, 6}, {7, 6, 5, 4, 3, 2, 1}} {
+		values := make([]*big.Int, len(positions))
+		for j, i := range positions {
+			values[j] = vec.Value(i)
+		}
+
+		proof, err := vec.ProvePositions(positions)
+		if err != nil {
+			t.Fatalf("ProvePositions(%v): %v", positions, err)
+		}
+		if !s.VerifyPositions(c, positions, values, proof) {
+			t.Errorf("VerifyPositions(%v) = false; want true", positions)
+		}
+
+		wrong := append([]*big.Int{}, values...)
+		wrong[0] = new(big.Int).Add(wrong[0], big.NewInt(1))
+		if s.VerifyPositions(c, positions, wrong, proof) {
+			t.Errorf("VerifyPositions(%v, <wrong values>) = true; want false", positions)
+		}
+		if s.VerifyPositions(c, positions, values[1:], proof) {
+			t.Errorf("VerifyPositions(%v, <missing value>) = true; want false", positions)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	d, err := polynomial.NewDomain(kzg.Field, 8, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(): %v", err)
+	}
+	if _, err := New(kzg.NewSRS(big.NewInt(1337), 6), d); err == nil {
+		t.Errorf("New(<SRS of max degree 6>, <domain of size 8>): got nil error")
+	}
+
+	s := newScheme(t, 4)
+	if _, err := s.Commit(bigInts(1, 2, 3, 4, 5)); err == nil {
+		t.Errorf("Commit(<5 values>) with scheme of size 4: got nil error")
+	}
+
+	vec, err := s.Commit(bigInts(1, 2, 3, 4))
+	if err != nil {
+		t.Fatalf("Commit(): %v", err)
+	}
+	for _, i := range []int{-1, 4} {
+		if _, err := vec.ProvePosition(i); err == nil {
+			t.Errorf("ProvePosition(%d) with scheme of size 4: got nil error", i)
+		}
+	}
+	if _, err := vec.ProvePositions([]int{1, 2, 1}); err == nil {
+		t.Errorf("ProvePositions(<repeated position>): got nil error")
+	}
+
+	proof, err := vec.ProvePosition(0)
+	if err != nil {
+		t.Fatalf("ProvePosition(0): %v", err)
+	}
+	if s.VerifyPosition(vec.Commitment(), 4, big.NewInt(1), proof) {
+		t.Errorf("VerifyPosition(<out of range>) = true; want false")
+	}
+}
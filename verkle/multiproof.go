@@ -0,0 +1,220 @@
+package verkle
+
+import (
+	"math/big"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/transcript"
+)
+
+// A MultiProof shows the values, or absence, of several keys in the Trie
+// committed to by a root. Commitments holds the commitments to the nodes below
+// the root on the paths of the keys, in order of first visit, and Depths the
+// number of nodes on the path of each key. The openings of the digits of all
+// keys at all nodes on their paths are aggregated into the commitment D and
+// Quotient, as described by Feist, "PCS multiproofs using random evaluation":
+// for openings f_i(z_i) = y_i and challenges r and t,
+//
+//	g(X) = sum_i r^i (f_i(X) - y_i) / (X - z_i)
+//	h(X) = sum_i r^i f_i(X) / (t - z_i)
+//
+// D commits to g, and Quotient opens h - g at t to sum_i r^i y_i / (t - z_i).
+type MultiProof struct {
+	Commitments []*kzg.Commitment
+	Depths      []int
+	D           *bn256.G1
+	Quotient    *bn256.G2
+}
+
+// An opening is the claim that the committed polynomial p has p(z) = y, with
+// p only known to the prover.
+type opening struct {
+	c    *kzg.Commitment
+	p    *polynomial.Polynomial
+	z, y *big.Int
+}
+
+// A slot identifies a digit of a node by the digits of the path to the node.
+type slot struct {
+	path  string
+	digit int
+}
+
+// prefix returns the identifier of the node at the end of the digits.
+func prefix(ds []int) string {
+	b := make([]byte, len(ds))
+	for i, d := range ds {
+		b[i] = byte(d)
+	}
+	return string(b)
+}
+
+// ProveMulti returns a MultiProof of the values, or absence, of the keys.
+func (t *Trie) ProveMulti(keys [][]byte) (*MultiProof, error) {
+	proof := new(MultiProof)
+	seen := make(map[string]bool)
+	opened := make(map[slot]bool)
+	var openings []opening
+	for _, key := range keys {
+		path, ds, err := t.path(key)
+		if err != nil {
+			return nil, err
+		}
+		proof.Depths = append(proof.Depths, len(path))
+		for level, v := range path {
+			id := prefix(ds[:level])
+			if level > 0 && !seen[id] {
+				proof.Commitments = append(proof.Commitments, v.Commitment())
+			}
+			seen[id] = true
+			s := slot{id, ds[level]}
+			if opened[s] {
+				continue
+			}
+			opened[s] = true
+			openings = append(openings, opening{
+				c: v.Commitment(), p: v.Polynomial(),
+				z: t.scheme.Domain().Element(uint64(s.digit)), y: v.Value(s.digit),
+			})
+		}
+	}
+
+	f := kzg.Field
+	tr, r := multiTranscript(openings)
+	rs := polynomial.ComputePowers(r, len(openings), f)
+	// g sums the numerators with equal z before dividing.
+	numerators := make(map[string]*polynomial.Polynomial)
+	var zs []*big.Int
+	for i, o := range openings {
+		num := o.p.Sub(polynomial.NewPolynomial([]*big.Int{o.y}), f).Scale(rs[i], f)
+		if prev, ok := numerators[o.z.String()]; ok {
+			num = prev.Add(num, f)
+		} else {
+			zs = append(zs, o.z)
+		}
+		numerators[o.z.String()] = num
+	}
+	g := polynomial.NewZeroPolynomial(0)
+	for _, z := range zs {
+		q, _ := numerators[z.String()].Div(polynomial.NewPolynomial([]*big.Int{f.Sub(bigZero, z), big.NewInt(1)}), f)
+		g = g.Add(q, f)
+	}
+	srs := t.scheme.SRS()
+	var err error
+	if proof.D, err = srs.Commit(g); err != nil {
+		return nil, err
+	}
+	tr.AppendG1("d", proof.D)
+	point := tr.Challenge(f)
+
+	scalars, err := evaluationScalars(openings, rs, point)
+	if err != nil {
+		return nil, err
+	}
+	h := polynomial.NewZeroPolynomial(0)
+	for i, o := range openings {
+		h = h.Add(o.p.Scale(scalars[i], f), f)
+	}
+	open, err := srs.Open(h.Sub(g, f), point)
+	if err != nil {
+		return nil, err
+	}
+	proof.Quotient = open.Quotient
+	return proof, nil
+}
+
+// multiTranscript returns the Transcript of the openings and the challenge r.
+func multiTranscript(openings []opening) (*transcript.Transcript, *big.Int) {
+	t := transcript.New("zkp.xyz/verkle/multiproof")
+	for _, o := range openings {
+		t.AppendG1("commitment", o.c.G1())
+		t.AppendScalar("z", o.z)
+		t.AppendScalar("y", o.y)
+	}
+	return t, t.Challenge(kzg.Field)
+}
+
+// evaluationScalars returns r^i / (t - z_i) for each opening.
+func evaluationScalars(openings []opening, rs []*big.Int, t *big.Int) ([]*big.Int, error) {
+	dens := make([]*big.Int, len(openings))
+	for i, o := range openings {
+		dens[i] = kzg.Field.Sub(t, o.z)
+	}
+	return kzg.Field.DivSlice(rs, dens)
+}
+
+// VerifyMulti reports whether the proof shows that each key has the respective
+// value, or none if nil, in the Trie committed to by root. The Trie is only
+// used for its parameters.
+func (t *Trie) VerifyMulti(root *kzg.Commitment, keys, values [][]byte, proof *MultiProof) bool {
+	if len(keys) != len(values) || len(keys) != len(proof.Depths) || proof.D == nil || proof.Quotient == nil {
+		return false
+	}
+	commitments := map[string]*kzg.Commitment{"": root}
+	next := 0
+	claimed := make(map[slot]*big.Int)
+	var openings []opening
+	for k, key := range keys {
+		ds, err := t.digits(key)
+		if err != nil || proof.Depths[k] < 1 {
+			return false
+		}
+		var path []*kzg.Commitment
+		for level := 1; level < proof.Depths[k] && level < len(ds); level++ {
+			id := prefix(ds[:level])
+			c, ok := commitments[id]
+			if !ok {
+				if next == len(proof.Commitments) {
+					return false
+				}
+				c = proof.Commitments[next]
+				commitments[id] = c
+				next++
+			}
+			path = append(path, c)
+		}
+		if len(path)+1 != proof.Depths[k] {
+			return false
+		}
+		ys, ok := t.expected(values[k], path)
+		if !ok {
+			return false
+		}
+		for level, y := range ys {
+			s := slot{prefix(ds[:level]), ds[level]}
+			if prev, ok := claimed[s]; ok {
+				// Paths through the same slot must agree on its value.
+				if prev.Cmp(y) != 0 {
+					return false
+				}
+				continue
+			}
+			claimed[s] = y
+			openings = append(openings, opening{
+				c: commitments[s.path], z: t.scheme.Domain().Element(uint64(s.digit)), y: y,
+			})
+		}
+	}
+	if next != len(proof.Commitments) {
+		return false
+	}
+
+	f := kzg.Field
+	tr, r := multiTranscript(openings)
+	rs := polynomial.ComputePowers(r, len(openings), f)
+	tr.AppendG1("d", proof.D)
+	point := tr.Challenge(f)
+	scalars, err := evaluationScalars(openings, rs, point)
+	if err != nil {
+		return false
+	}
+	e := new(bn256.G1).Neg(proof.D)
+	y := big.NewInt(0)
+	for i, o := range openings {
+		e.Add(e, new(bn256.G1).ScalarMult(o.c.G1(), scalars[i]))
+		y = f.Add(y, f.Mul(scalars[i], o.y))
+	}
+	return t.scheme.SRS().VerifierKey().Verify(e, &kzg.Proof{Z: point, Y: y, Quotient: proof.Quotient})
+}
@@ -0,0 +1,276 @@
+// Package verkle implements a prototype of Verkle tries (Kuszmaul, "Verkle
+// Trees"): tries of fixed arity and depth whose nodes are veccom vector
+// commitments, with proofs of a path holding one opening per node instead of
+// all siblings, and multiproofs aggregating the openings of many paths into a
+// single KZG opening.
+//
+// Keys of a fixed length are split into digits of log2(arity) bits, most
+// significant first, each selecting a child of a node; the last digit selects
+// the slot of a value. Position i of an inner node holds the map into the
+// field of the commitment to child i, and position i of a node of the last
+// level the map of the value in slot i, or 0 if the child or slot is empty.
+// Commitments and values are mapped into the field with a
+// transcript.Transcript, so that they are 0 with negligible probability.
+//
+// Values are never removed, and the trie has no extension nodes: every path to
+// a value has the full depth.
+package verkle
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/transcript"
+	"zkp.xyz/membership/veccom"
+)
+
+var bigZero = big.NewInt(0)
+
+// A Trie maps keys of a fixed length to values.
+type Trie struct {
+	scheme *veccom.Scheme
+	keyLen int
+	bits   int
+	root   *node
+}
+
+// A node is a node of a Trie, with children at inner levels and values at the
+// last.
+type node struct {
+	children []*node
+	values   [][]byte
+	// vector holds the committed node, or nil if it has changed since.
+	vector *veccom.Vector
+}
+
+// New returns an empty Trie for keys of keyLen bytes, with the size of the
+// Scheme as arity, which must be 2, 4, 16 or 256.
+func New(scheme *veccom.Scheme, keyLen int) (*Trie, error) {
+	width := scheme.Size()
+	b := bits.Len(uint(width)) - 1
+	if width < 2 || 1<<b != width || 8%b != 0 {
+		return nil, fmt.Errorf("arity %d; want 2, 4, 16 or 256", width)
+	}
+	if keyLen <= 0 {
+		return nil, fmt.Errorf("key length %d; want at least 1", keyLen)
+	}
+	t := &Trie{scheme: scheme, keyLen: keyLen, bits: b}
+	t.root = t.newNode(0)
+	return t, nil
+}
+
+// Depth returns the number of levels of the Trie, i.e. of the nodes on the path
+// to each value.
+func (t *Trie) Depth() int {
+	return t.keyLen * 8 / t.bits
+}
+
+func (t *Trie) newNode(level int) *node {
+	if level == t.Depth()-1 {
+		return &node{values: make([][]byte, t.scheme.Size())}
+	}
+	return &node{children: make([]*node, t.scheme.Size())}
+}
+
+// digits returns the digits of the key, or an error if it has the wrong
+// length.
+func (t *Trie) digits(key []byte) ([]int, error) {
+	if len(key) != t.keyLen {
+		return nil, fmt.Errorf("key of %d bytes; want %d", len(key), t.keyLen)
+	}
+	ds := make([]int, 0, t.Depth())
+	mask := byte(1<<t.bits - 1)
+	for _, b := range key {
+		for shift := 8 - t.bits; shift >= 0; shift -= t.bits {
+			ds = append(ds, int(b>>shift&mask))
+		}
+	}
+	return ds, nil
+}
+
+// Put sets the value of the key, copying it.
+func (t *Trie) Put(key, value []byte) error {
+	ds, err := t.digits(key)
+	if err != nil {
+		return err
+	}
+	n := t.root
+	for level, d := range ds[:len(ds)-1] {
+		n.vector = nil
+		if n.children[d] == nil {
+			n.children[d] = t.newNode(level + 1)
+		}
+		n = n.children[d]
+	}
+	n.vector = nil
+	n.values[ds[len(ds)-1]] = append([]byte{}, value...)
+	return nil
+}
+
+// Get returns the value of the key, and whether it is set.
+func (t *Trie) Get(key []byte) ([]byte, bool) {
+	ds, err := t.digits(key)
+	if err != nil {
+		return nil, false
+	}
+	n := t.root
+	for _, d := range ds[:len(ds)-1] {
+		if n = n.children[d]; n == nil {
+			return nil, false
+		}
+	}
+	v := n.values[ds[len(ds)-1]]
+	return v, v != nil
+}
+
+// Root returns the commitment to the root of the Trie, committing to all nodes
+// changed since the last call.
+func (t *Trie) Root() (*kzg.Commitment, error) {
+	v, err := t.commit(t.root)
+	if err != nil {
+		return nil, err
+	}
+	return v.Commitment(), nil
+}
+
+// commit returns the committed node, committing to it and its descendants as
+// required.
+func (t *Trie) commit(n *node) (*veccom.Vector, error) {
+	if n.vector != nil {
+		return n.vector, nil
+	}
+	values := make([]*big.Int, t.scheme.Size())
+	for i := range values {
+		values[i] = bigZero
+		switch {
+		case n.values != nil && n.values[i] != nil:
+			values[i] = valueToField(n.values[i])
+		case n.children != nil && n.children[i] != nil:
+			child, err := t.commit(n.children[i])
+			if err != nil {
+				return nil, err
+			}
+			values[i] = commitmentToField(child.Commitment())
+		}
+	}
+	v, err := t.scheme.Commit(values)
+	if err != nil {
+		return nil, err
+	}
+	n.vector = v
+	return v, nil
+}
+
+// commitmentToField maps the commitment to a child into the field.
+func commitmentToField(c *kzg.Commitment) *big.Int {
+	t := transcript.New("zkp.xyz/verkle/commitment")
+	t.AppendG1("commitment", c.G1())
+	return t.Challenge(kzg.Field)
+}
+
+// valueToField maps a value into the field.
+func valueToField(value []byte) *big.Int {
+	t := transcript.New("zkp.xyz/verkle/value")
+	t.AppendBytes("value", value)
+	return t.Challenge(kzg.Field)
+}
+
+// A Proof shows that a key has a value, or none, in the Trie committed to by a
+// root. Commitments holds the commitments to the nodes on the path below the
+// root, up to the last node, and Openings the openings of the digits of the
+// key at the root and each of the nodes. The path ends early at the first
+// empty child for keys without value.
+type Proof struct {
+	Commitments []*kzg.Commitment
+	Openings    []*veccom.Proof
+}
+
+// path returns the committed nodes on the path to the key, ending at the first
+// empty child, and its digits.
+func (t *Trie) path(key []byte) ([]*veccom.Vector, []int, error) {
+	ds, err := t.digits(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	var path []*veccom.Vector
+	for n, level := t.root, 0; n != nil; level++ {
+		v, err := t.commit(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		path = append(path, v)
+		if n.children == nil {
+			break
+		}
+		n = n.children[ds[level]]
+	}
+	return path, ds, nil
+}
+
+// Prove returns a Proof of the value of the key, or of its absence.
+func (t *Trie) Prove(key []byte) (*Proof, error) {
+	path, ds, err := t.path(key)
+	if err != nil {
+		return nil, err
+	}
+	proof := new(Proof)
+	for level, v := range path {
+		if level > 0 {
+			proof.Commitments = append(proof.Commitments, v.Commitment())
+		}
+		opening, err := v.ProvePosition(ds[level])
+		if err != nil {
+			return nil, err
+		}
+		proof.Openings = append(proof.Openings, opening)
+	}
+	return proof, nil
+}
+
+// expected returns the values at the digits of the key at the nodes on its
+// path, with the commitments to all but the root, for its value or nil if
+// absent.
+func (t *Trie) expected(value []byte, commitments []*kzg.Commitment) ([]*big.Int, bool) {
+	levels := len(commitments) + 1
+	if levels > t.Depth() || (value != nil && levels != t.Depth()) {
+		return nil, false
+	}
+	ys := make([]*big.Int, levels)
+	for i, c := range commitments {
+		if c == nil {
+			return nil, false
+		}
+		ys[i] = commitmentToField(c)
+	}
+	ys[levels-1] = bigZero
+	if value != nil {
+		ys[levels-1] = valueToField(value)
+	}
+	return ys, true
+}
+
+// Verify reports whether the proof shows that the key has the value, or none
+// if value is nil, in the Trie committed to by root. The Trie is only used
+// for its parameters.
+func (t *Trie) Verify(root *kzg.Commitment, key, value []byte, proof *Proof) bool {
+	ds, err := t.digits(key)
+	if err != nil || len(proof.Openings) != len(proof.Commitments)+1 {
+		return false
+	}
+	ys, ok := t.expected(value, proof.Commitments)
+	if !ok {
+		return false
+	}
+	c := root
+	for level, y := range ys {
+		if proof.Openings[level] == nil || !t.scheme.VerifyPosition(c, ds[level], y, proof.Openings[level]) {
+			return false
+		}
+		if level < len(proof.Commitments) {
+			c = proof.Commitments[level]
+		}
+	}
+	return true
+}
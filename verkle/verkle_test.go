@@ -0,0 +1,189 @@
+package verkle
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"zkp.xyz/membership/kzg"
+	"zkp.xyz/membership/polynomial"
+	"zkp.xyz/membership/veccom"
+)
+
+func newTrie(t *testing.T, arity uint64, keyLen int) *Trie {
+	t.Helper()
+	d, err := polynomial.NewDomain(kzg.Field, arity, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain(%d) error %v", arity, err)
+	}
+	s, err := veccom.New(kzg.NewSRS(big.NewInt(1337), int(arity)-1), d)
+	if err != nil {
+		t.Fatalf("veccom.New() error %v", err)
+	}
+	trie, err := New(s, keyLen)
+	if err != nil {
+		t.Fatalf("New() error %v", err)
+	}
+	return trie
+}
+
+var entries = []struct {
+	key, value []byte
+}{
+	{[]byte{0x12, 0x34}, []byte("a")},
+	{[]byte{0x12, 0x35}, []byte("b")},
+	{[]byte{0x12, 0xf0}, []byte("c")},
+	{[]byte{0xab, 0xcd}, []byte{}},
+}
+
+func TestTrie(t *testing.T) {
+	for _, arity := range []uint64{2, 16} {
+		trie := newTrie(t, arity, 2)
+		if got, want := trie.Depth(), 16/(map[uint64]int{2: 1, 16: 4}[arity]); got != want {
+			t.Errorf("Depth() = %d; want %d", got, want)
+		}
+		empty, err := trie.Root()
+		if err != nil {
+			t.Fatalf("Root() error %v", err)
+		}
+		for _, e := range entries {
+			if err := trie.Put(e.key, e.value); err != nil {
+				t.Fatalf("Put(%x) error %v", e.key, err)
+			}
+		}
+		root, err := trie.Root()
+		if err != nil {
+			t.Fatalf("Root() error %v", err)
+		}
+		if root.G1().String() == empty.G1().String() {
+			t.Error("Root() unchanged by Put()")
+		}
+
+		for _, e := range entries {
+			if got, ok := trie.Get(e.key); !ok || !bytes.Equal(got, e.value) {
+				t.Errorf("Get(%x) = %q, %t; want %q, true", e.key, got, ok, e.value)
+			}
+			proof, err := trie.Prove(e.key)
+			if err != nil {
+				t.Fatalf("Prove(%x) error %v", e.key, err)
+			}
+			if !trie.Verify(root, e.key, e.value, proof) {
+				t.Errorf("Verify(%x, %q) = false; want true", e.key, e.value)
+			}
+			if trie.Verify(root, e.key, []byte("x"), proof) || trie.Verify(root, e.key, nil, proof) {
+				t.Errorf("Verify(%x) with the wrong value = true; want false", e.key)
+			}
+			if trie.Verify(empty, e.key, e.value, proof) {
+				t.Errorf("Verify(%x) with the wrong root = true; want false", e.key)
+			}
+		}
+
+		// Absent keys, sharing a prefix with a value or not.
+		for _, key := range [][]byte{{0x12, 0x36}, {0x00, 0x00}, {0x12, 0x00}} {
+			if _, ok := trie.Get(key); ok {
+				t.Errorf("Get(%x) ok; want absent", key)
+			}
+			proof, err := trie.Prove(key)
+			if err != nil {
+				t.Fatalf("Prove(%x) error %v", key, err)
+			}
+			if !trie.Verify(root, key, nil, proof) {
+				t.Errorf("Verify(%x, nil) = false; want true", key)
+			}
+			if trie.Verify(root, key, []byte("a"), proof) {
+				t.Errorf("Verify(%x, a) = true; want false", key)
+			}
+		}
+
+		// Proofs of one key don't verify for another.
+		proof, err := trie.Prove(entries[0].key)
+		if err != nil {
+			t.Fatalf("Prove() error %v", err)
+		}
+		if trie.Verify(root, entries[1].key, entries[0].value, proof) {
+			t.Error("Verify() of another key = true; want false")
+		}
+		if _, err := trie.Prove([]byte{1}); err == nil {
+			t.Error("Prove(short key) error nil; want error")
+		}
+		if err := trie.Put([]byte{1, 2, 3}, nil); err == nil {
+			t.Error("Put(long key) error nil; want error")
+		}
+	}
+}
+
+func TestMultiProof(t *testing.T) {
+	trie := newTrie(t, 16, 2)
+	for _, e := range entries {
+		if err := trie.Put(e.key, e.value); err != nil {
+			t.Fatalf("Put(%x) error %v", e.key, err)
+		}
+	}
+	root, err := trie.Root()
+	if err != nil {
+		t.Fatalf("Root() error %v", err)
+	}
+
+	keys := [][]byte{entries[0].key, entries[1].key, {0x12, 0x36}, {0x00, 0x00}, entries[3].key, entries[0].key}
+	values := [][]byte{entries[0].value, entries[1].value, nil, nil, entries[3].value, entries[0].value}
+	proof, err := trie.ProveMulti(keys)
+	if err != nil {
+		t.Fatalf("ProveMulti() error %v", err)
+	}
+	if !trie.VerifyMulti(root, keys, values, proof) {
+		t.Fatal("VerifyMulti() = false; want true")
+	}
+	// The nodes 1, 12, 123, a, ab and abc are each included once.
+	if got, want := len(proof.Commitments), 6; got != want {
+		t.Errorf("len(Commitments) = %d; want %d", got, want)
+	}
+
+	wrong := func(i int, v []byte) [][]byte {
+		vs := append([][]byte(nil), values...)
+		vs[i] = v
+		return vs
+	}
+	tests := []struct {
+		name   string
+		root   *kzg.Commitment
+		keys   [][]byte
+		values [][]byte
+		proof  *MultiProof
+	}{
+		{"wrong value", root, keys, wrong(1, []byte("x")), proof},
+		{"absent value", root, keys, wrong(0, nil), proof},
+		{"inconsistent duplicate", root, keys, wrong(5, []byte("x")), proof},
+		{"present absence", root, keys, wrong(2, []byte("a")), proof},
+		{"wrong root", trie.root.children[1].vector.Commitment(), keys, values, proof},
+		{"missing key", root, keys[1:], values[1:], proof},
+		{"wrong depths", root, keys, values, &MultiProof{Commitments: proof.Commitments, Depths: []int{4, 4, 4, 1, 4, 3}, D: proof.D, Quotient: proof.Quotient}},
+		{"extra commitment", root, keys, values, &MultiProof{Commitments: append(proof.Commitments, root), Depths: proof.Depths, D: proof.D, Quotient: proof.Quotient}},
+		{"wrong D", root, keys, values, &MultiProof{Commitments: proof.Commitments, Depths: proof.Depths, D: root.G1(), Quotient: proof.Quotient}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if trie.VerifyMulti(tt.root, tt.keys, tt.values, tt.proof) {
+				t.Error("VerifyMulti() = true; want false")
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	d, err := polynomial.NewDomain(kzg.Field, 8, rand.Reader)
+	if err != nil {
+		t.Fatalf("NewDomain() error %v", err)
+	}
+	s, err := veccom.New(kzg.NewSRS(big.NewInt(1337), 7), d)
+	if err != nil {
+		t.Fatalf("veccom.New() error %v", err)
+	}
+	if _, err := New(s, 2); err == nil {
+		t.Error("New() with arity 8; error nil; want error")
+	}
+	trie := newTrie(t, 4, 1)
+	if _, err := New(trie.scheme, 0); err == nil {
+		t.Error("New() with key length 0; error nil; want error")
+	}
+}